@@ -1,11 +1,16 @@
 package main
 
 import (
+	"os"
+
 	// Import the cmd directory with root.go
 	"github.com/redjax/syst/cmd"
+	"github.com/redjax/syst/internal/utils"
 )
 
 func main() {
-	// Call the root command
-	cmd.Execute()
+	// Call the root command, which prints its own errors
+	if err := cmd.Execute(); err != nil {
+		os.Exit(utils.ExitCode(err))
+	}
 }
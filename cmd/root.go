@@ -3,10 +3,12 @@
 package cmd
 
 import (
+	encjson "encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	// Import your CLI subcommands
 	encodecommand "github.com/redjax/syst/internal/commands/encodeCommand"
@@ -21,14 +23,15 @@ import (
 	weathercommand "github.com/redjax/syst/internal/commands/weatherCommand"
 	_which "github.com/redjax/syst/internal/commands/whichCommand"
 	zipBak "github.com/redjax/syst/internal/commands/zipBakCommand"
+	"github.com/redjax/syst/internal/utils/profile"
+	"github.com/redjax/syst/internal/utils/styles"
+	"github.com/redjax/syst/internal/utils/theme"
 	"github.com/redjax/syst/internal/version"
 
 	// Import your CLI config
-	// "github.com/redjax/syst/internal/config"
+	"github.com/redjax/syst/internal/config"
 
-	"github.com/knadh/koanf/parsers/json"
 	"github.com/knadh/koanf/providers/env"
-	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
 	"github.com/spf13/cobra"
 )
@@ -38,6 +41,17 @@ var (
 	cfgFile string
 	// For enabling debug logging with --debug/-D
 	debug bool
+	// For skipping the background upgrade check with --no-auto-upgrade
+	noAutoUpgrade bool
+	// For disabling colored output with --no-color
+	noColor bool
+	// For picking the color theme with --theme
+	themeName string
+	// For timing slow commands with the hidden --profile and --cpuprofile flags
+	profileEnabled bool
+	cpuProfile     string
+	// Result of the background upgrade check, printed after the command finishes
+	upgradeNotice *version.UpgradeNotice
 	// Initialize Koanf config instance
 	k = koanf.New(".")
 )
@@ -57,18 +71,61 @@ var rootCmd = &cobra.Command{
 	},
 }
 
-// Execute the root Cobra command
-func Execute() {
+// Execute the root Cobra command. The returned error has already been printed; main
+// exits with its status, see utils.ExitCode.
+func Execute() error {
 	// Import this into a main.go and call with cmd.Execute()
-	cobra.CheckErr(rootCmd.Execute())
+	err := rootCmd.Execute()
+
+	// #nosec G104 - A failed CPU profile write shouldn't change the command's result
+	profile.Stop(os.Stderr)
+
+	// Print the upgrade banner after command output so the two never interleave
+	upgradeNotice.Print(os.Stderr, time.Second)
+
+	return err
+}
+
+// startUpgradeCheck launches the background upgrade check unless it is disabled with
+// --no-auto-upgrade or SYST_NO_AUTO_UPGRADE. 'syst self ...' commands are skipped because
+// 'self upgrade' always checks immediately. The interval is read from SYST_UPGRADE_CHECK_INTERVAL.
+func startUpgradeCheck(cmd *cobra.Command) {
+	if noAutoUpgrade || k.Bool("no.auto.upgrade") {
+		return
+	}
+	if strings.HasPrefix(cmd.CommandPath(), rootCmd.Name()+" self") {
+		return
+	}
+
+	upgradeNotice = version.StartUpgradeCheck(k.Duration("upgrade.check.interval"))
+}
+
+// themeOrEnv returns the --theme flag, or SYST_THEME when the flag isn't given
+func themeOrEnv() string {
+	if themeName != "" {
+		return themeName
+	}
+	return k.String("theme")
 }
 
 // Initialize the root command
 func init() {
 	// Add flags to the CLI's root command, making them 'global'
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (JSON)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (YAML, TOML or JSON; defaults to syst/config.yaml in the user config directory)")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolP("version", "v", false, "Print version and exit")
+	rootCmd.Flags().Bool("json", false, "Print --version output as JSON")
+	rootCmd.PersistentFlags().BoolVar(&noAutoUpgrade, "no-auto-upgrade", false, "Skip the background check for a new syst release")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", "", "Color theme: "+strings.Join(theme.Names(), ", ")+" (also honors SYST_THEME)")
+
+	// Diagnostics for "syst is slow on my repository" reports, hidden from --help
+	rootCmd.PersistentFlags().BoolVar(&profileEnabled, "profile", false, "Print how long each phase of the command took when it exits")
+	rootCmd.PersistentFlags().StringVar(&cpuProfile, "cpuprofile", "", "Write a pprof CPU profile to this file (implies --profile)")
+	// #nosec G104 - The flags were just defined
+	rootCmd.PersistentFlags().MarkHidden("profile")
+	// #nosec G104 - The flags were just defined
+	rootCmd.PersistentFlags().MarkHidden("cpuprofile")
 
 	// Add other CLI subcommands
 	rootCmd.AddCommand(showCommand.NewShowCmd())
@@ -87,9 +144,29 @@ func init() {
 
 	// Handle persistent flags like -v/--version and -d/--debug
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		// Fill in the flags that weren't given from the environment and config file
+		cobra.CheckErr(config.ApplyFlags(k, cmd))
+
+		// Handle --no-color, NO_COLOR and SYST_NO_COLOR before anything is printed
+		styles.Init(noColor || k.Bool("no.color"))
+
+		// The theme was already picked before the styles were built; report a name that
+		// didn't match one instead of silently using the default
+		if name := themeOrEnv(); name != "" {
+			if _, err := theme.Lookup(name); err != nil {
+				cobra.CheckErr(err)
+			}
+		}
+
 		// Handle -v/--version
 		v, _ := cmd.Flags().GetBool("version")
 		if v {
+			if j, _ := cmd.Flags().GetBool("json"); j {
+				enc := encjson.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				cobra.CheckErr(enc.Encode(version.GetBuildInfo()))
+				os.Exit(0)
+			}
 			fmt.Printf("syst version:%s commit:%s date:%s\n", version.Version, version.Commit, version.Date)
 			os.Exit(0)
 		}
@@ -99,17 +176,31 @@ func init() {
 			log.SetFlags(log.LstdFlags | log.Lshortfile)
 			log.Println("DEBUG mode enabled")
 		}
+
+		// Handle --profile and --cpuprofile
+		if profileEnabled || cpuProfile != "" {
+			if err := profile.Start(cpuProfile); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+
+		startUpgradeCheck(cmd)
 	}
 
 	// Call the initConfig function when the root command is initialized
 	cobra.OnInitialize(initConfig)
 }
 
-// Load configuration for CLI app
+// Load configuration for CLI app. Flags override environment variables, which override
+// the config file, which overrides the built-in defaults.
 func initConfig() {
-	// Load from config file passed as arg
-	if cfgFile != "" {
-		if err := k.Load(file.Provider(cfgFile), json.Parser()); err != nil {
+	// Load from the config file passed as arg, or the one in the user config directory
+	path := cfgFile
+	if path == "" {
+		path = config.DefaultPath()
+	}
+	if path != "" {
+		if err := config.LoadFile(k, path); err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 			os.Exit(1)
 		}
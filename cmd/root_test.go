@@ -55,6 +55,9 @@ func TestRootCommandHasGlobalFlags(t *testing.T) {
 	if flags.Lookup("version") == nil {
 		t.Error("missing --version flag")
 	}
+	if flags.Lookup("no-auto-upgrade") == nil {
+		t.Error("missing --no-auto-upgrade flag")
+	}
 }
 
 func TestRootCommandHelp(t *testing.T) {
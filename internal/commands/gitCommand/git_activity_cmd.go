@@ -7,14 +7,26 @@ import (
 
 // NewGitActivityCommand returns the git activity command.
 func NewGitActivityCommand() *cobra.Command {
+	var opts activity.ActivityOptions
+
 	cmd := &cobra.Command{
-		Use:   "activity",
+		Use:   "activity [ref]",
 		Short: "Repository activity dashboard",
-		Long:  "Show recent commit activity, development patterns, and commit frequency analysis",
+		Long:  "Show recent commit activity, development patterns, and commit frequency analysis of the history reachable from ref (default HEAD)",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return activity.RunActivityDashboard()
+			if len(args) > 0 {
+				opts.Ref = args[0]
+			}
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			return activity.RunActivityDashboard(opts)
 		},
 	}
 
+	addBotFlags(cmd, &opts.ExcludeBots, &opts.BotPatterns)
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Only analyze the last N commits from ref (0 for the whole history)")
+	cmd.Flags().IntVar(&opts.RecentDays, "recent-days", activity.DefaultRecentDays, "How many days back the recent activity goes (i.e. 14 for a sprint, 90 for a quarter)")
+	addReportFlags(cmd, &opts.Report, "json, markdown or prom")
+
 	return cmd
 }
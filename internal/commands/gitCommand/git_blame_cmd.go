@@ -1,19 +1,44 @@
 package gitcommand
 
 import (
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	"github.com/redjax/syst/internal/services/gitService/blameService"
 	"github.com/spf13/cobra"
 )
 
 func NewGitBlameCommand() *cobra.Command {
+	var signatures gitservice.SignatureOptions
+
 	cmd := &cobra.Command{
-		Use:   "blame [file]",
+		Use:   "blame [file[@rev]]",
 		Short: "Interactive file investigation",
-		Long:  "Interactive blame viewer with line-by-line author information and historical changes",
+		Long: `Interactive blame viewer with line-by-line author information and historical changes.
+
+Append @rev to the file or directory to blame it as it was at a revision, i.e. main.go@v1.2.0 or
+main.go@HEAD~5. The file is read from that revision's tree and its history starts there. Without a
+revision, the working tree is blamed.
+
+A file's history follows renames, so it covers the file's whole lifetime. Pass --no-follow to stop at
+the most recent rename.
+
+Commits listed in .git-blame-ignore-revs at the repository root, or in the file given with
+--ignore-revs-file, are skipped: lines they last touched are attributed to the change before them,
+like git blame --ignore-revs-file. Use it for bulk reformatting commits.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return blameService.RunBlameViewer(args)
+			var opts blameService.BlameOptions
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			applyRelativeDates(cmd)
+			opts.NoFollow, _ = cmd.Flags().GetBool("no-follow")
+			opts.IgnoreRevsFile, _ = cmd.Flags().GetString("ignore-revs-file")
+			opts.Signatures = signatures
+			return blameService.RunBlameViewer(opts, args)
 		},
 	}
 
+	cmd.Flags().Bool("no-follow", false, "Don't follow renames when showing a file's history")
+	cmd.Flags().String("ignore-revs-file", "", "File of commits to skip when attributing lines (defaults to .git-blame-ignore-revs at the repository root)")
+	addSignatureFlags(cmd, &signatures)
+
 	return cmd
 }
@@ -0,0 +1,32 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/branchStatusService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitBranchStatusCommand creates the git branch-status command
+func NewGitBranchStatusCommand() *cobra.Command {
+	var opts branchStatusService.BranchStatusOptions
+
+	cmd := &cobra.Command{
+		Use:   "branch-status",
+		Short: "Show how far every local branch is ahead of or behind its upstream and the default branch",
+		Long: `List every local branch with its ahead/behind counts against the branch it tracks and against the
+repository's default branch (or --base), its last commit, and whether it is merged into the base. Branches
+far behind the base are highlighted: yellow from 10 commits behind, red from 50.`,
+		Example: `  syst git branch-status
+  syst git branch-status --base develop
+  syst git branch-status --json | jq -r '.branches[] | select(.behind_base > 50) | .name'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			return branchStatusService.RunBranchStatus(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Base, "base", "", "Branch to compare every branch with (default: the repository's default branch)")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the status as JSON")
+
+	return cmd
+}
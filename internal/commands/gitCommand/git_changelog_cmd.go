@@ -0,0 +1,38 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/changelogService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitChangelogCommand creates the git changelog command
+func NewGitChangelogCommand() *cobra.Command {
+	var opts changelogService.ChangelogOptions
+
+	cmd := &cobra.Command{
+		Use:   "changelog [from-ref] [to-ref]",
+		Short: "Generate a changelog from conventional commits",
+		Long: `Generate a Keep a Changelog style changelog from the Conventional Commits (feat:, fix:, chore:, ...)
+between two refs. from-ref defaults to the most recent tag before to-ref, and to-ref defaults to HEAD.`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var fromRef, toRef string
+			if len(args) >= 1 {
+				fromRef = args[0]
+			}
+			if len(args) >= 2 {
+				toRef = args[1]
+			}
+
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			return changelogService.RunChangelog(fromRef, toRef, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Write the changelog to a file instead of stdout")
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", "markdown", "Output format: markdown or json")
+	cmd.Flags().BoolVar(&opts.Copy, "copy", false, "Also copy the changelog to the clipboard (over SSH with OSC 52)")
+	cmd.Flags().BoolVar(&opts.GroupByScope, "group-by-scope", false, "Group entries in each section by commit scope")
+
+	return cmd
+}
@@ -1,6 +1,7 @@
 package gitcommand
 
 import (
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	"github.com/spf13/cobra"
 )
 
@@ -12,23 +13,69 @@ func NewGitCommand() *cobra.Command {
 		Long:  "Enhanced git helper operations like prune, for use with syst CLI.",
 	}
 
+	// Global repository selection, like 'git -C'
+	cmd.PersistentFlags().StringP("repo", "C", "", "Path inside the git repository to operate on (defaults to the current directory)")
+	cmd.PersistentFlags().Bool("no-cache", false, "Don't read or write the commit stats cache in .git/syst-cache")
+	cmd.PersistentFlags().Bool("remember", false, "Reopen the health and history TUIs on the view and selection they were last closed on")
+	cmd.PersistentFlags().Bool("relative-dates", false, "Show dates in the blame, compare, contributors, history and search TUIs as relative times, like \"3 hours ago\"")
+
 	// Add subcommands
 	cmd.AddCommand(NewGitPruneCommand())
 	cmd.AddCommand(NewGitSparseCloneCommand())
 	cmd.AddCommand(NewGitInfoCommand())
 	cmd.AddCommand(NewGitActivityCommand())
 	cmd.AddCommand(NewGitBlameCommand())
+	cmd.AddCommand(NewGitBranchStatusCommand())
 	cmd.AddCommand(NewGitBranchesCommand())
+	cmd.AddCommand(NewGitChangelogCommand())
 	cmd.AddCommand(NewGitCompareCommand())
 	cmd.AddCommand(NewGitContributorsCommand())
 	cmd.AddCommand(NewGitDiffCommand())
 	cmd.AddCommand(NewGitFilesCommand())
+	cmd.AddCommand(NewGitGraphCommand())
 	cmd.AddCommand(NewGitHealthCommand())
 	cmd.AddCommand(NewGitHistoryCommand())
+	cmd.AddCommand(NewGitHotspotsCommand())
 	cmd.AddCommand(NewGitIgnoredCommand())
+	cmd.AddCommand(NewGitLintCommitsCommand())
+	cmd.AddCommand(NewGitReflogCommand())
 	cmd.AddCommand(NewGitSearchCommand())
+	cmd.AddCommand(NewGitSizeCommand())
+	cmd.AddCommand(NewGitStaleBranchesCommand())
 	cmd.AddCommand(NewGitStatusCommand())
+	cmd.AddCommand(NewGitSubmodulesCommand())
+	cmd.AddCommand(NewGitSummaryCommand())
+	cmd.AddCommand(NewGitTagsCommand())
 	cmd.AddCommand(NewGitWorktreeCommand())
 
 	return cmd
 }
+
+// applyRelativeDates switches the dates the TUIs show to relative times when
+// --relative-dates was passed
+func applyRelativeDates(cmd *cobra.Command) {
+	gitservice.RelativeDates, _ = cmd.Flags().GetBool("relative-dates")
+}
+
+// addReportFlags adds the --format, --output and --copy flags shared by the commands that can
+// write their report instead of launching a TUI. formats lists the formats the report
+// supports, for the help text.
+func addReportFlags(cmd *cobra.Command, report *gitservice.ReportWriter, formats string) {
+	cmd.Flags().StringVarP(&report.Format, "format", "f", "", "Write the report as "+formats+" instead of launching the TUI")
+	cmd.Flags().StringVarP(&report.Output, "output", "o", "", "Write the report to a file; the format is inferred from its extension (.json, .csv, .md) unless --format is given")
+	cmd.Flags().BoolVar(&report.Copy, "copy", false, "Also copy the report to the clipboard (over SSH with OSC 52)")
+}
+
+// addSignatureFlags adds the --verify-signatures and --keyring flags shared by the
+// commands that show commit signatures
+func addSignatureFlags(cmd *cobra.Command, opts *gitservice.SignatureOptions) {
+	cmd.Flags().BoolVar(&opts.Verify, "verify-signatures", false, "Verify commit signatures against --keyring and flag unsigned or unverifiable commits")
+	cmd.Flags().StringVar(&opts.Keyring, "keyring", "", "File of OpenPGP public keys to verify signatures with (i.e. from gpg --export --armor)")
+}
+
+// addBotFlags adds the --exclude-bots and --bot-pattern flags shared by the commands that
+// can leave out commits by automation accounts
+func addBotFlags(cmd *cobra.Command, exclude *bool, patterns *[]string) {
+	cmd.Flags().BoolVar(exclude, "exclude-bots", false, "Hide commits from bot accounts (dependabot, github-actions, etc.)")
+	cmd.Flags().StringSliceVar(patterns, "bot-pattern", nil, "Author name/email pattern identifying bots, '*' wildcard (repeatable, replaces defaults)")
+}
@@ -6,14 +6,28 @@ import (
 )
 
 func NewGitCompareCommand() *cobra.Command {
+	var opts compareService.CompareOptions
+
 	cmd := &cobra.Command{
-		Use:   "compare [ref1] [ref2]",
+		Use:   "compare [ref1] [ref2] [ref...]",
 		Short: "Comparison tools for refs",
-		Long:  "Compare different branches/tags/commits showing divergence and shared history",
+		Long: `Compare different branches/tags/commits showing divergence and shared history.
+
+With no refs, the current branch is compared with its upstream (i.e. origin/main), to
+see how far ahead and behind it is. Branches without an upstream are compared with main.
+Pass --upstream to insist on the upstream, or @{upstream} (@{u}) as a ref.
+
+With three or more refs, each one is compared against the merge base of all of them,
+showing how far ahead it is and which commits no other ref contains.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return compareService.RunComparison(args)
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			applyRelativeDates(cmd)
+			return compareService.RunComparison(args, opts)
 		},
 	}
 
+	addReportFlags(cmd, &opts.Report, "json or markdown")
+	cmd.Flags().BoolVar(&opts.Upstream, "upstream", false, "Compare the current branch with its upstream, failing if it has none")
+
 	return cmd
 }
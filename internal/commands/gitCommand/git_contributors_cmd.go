@@ -1,19 +1,50 @@
 package gitcommand
 
 import (
+	"fmt"
+
 	"github.com/redjax/syst/internal/services/gitService/contributorsService"
 	"github.com/spf13/cobra"
 )
 
 func NewGitContributorsCommand() *cobra.Command {
+	var opts contributorsService.ContributorsOptions
+	var exportCSV, exportJSON bool
+
 	cmd := &cobra.Command{
-		Use:   "contributors",
+		Use:   "contributors [ref]",
 		Short: "Developer statistics and analysis",
-		Long:  "Show commit counts, line changes, and activity by author with interactive exploration",
+		Long:  "Show commit counts, line changes, and activity by author in the history reachable from ref (default HEAD), with interactive exploration",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return contributorsService.RunContributorsAnalysis()
+			switch {
+			case exportCSV && exportJSON:
+				return fmt.Errorf("--csv and --json cannot be used together")
+			case (exportCSV || exportJSON) && opts.Report.Format != "":
+				return fmt.Errorf("--csv and --json cannot be used with --format")
+			case exportCSV:
+				opts.Report.Format = "csv"
+			case exportJSON:
+				opts.Report.Format = "json"
+			}
+			if len(args) > 0 {
+				opts.Ref = args[0]
+			}
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			applyRelativeDates(cmd)
+			return contributorsService.RunContributorsAnalysis(opts)
 		},
 	}
 
+	cmd.Flags().BoolVar(&exportCSV, "csv", false, "Print contributor statistics as CSV instead of launching the TUI (same as --format csv)")
+	cmd.Flags().BoolVar(&exportJSON, "json", false, "Print contributor statistics as JSON instead of launching the TUI (same as --format json)")
+	addReportFlags(cmd, &opts.Report, "json, csv, markdown or prom")
+	cmd.Flags().BoolVar(&opts.CoAuthorCredit, "co-authors", false, "Share commit credit with Co-authored-by trailers (toggle with 'c' in the TUI)")
+	addBotFlags(cmd, &opts.ExcludeBots, &opts.BotPatterns)
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Only analyze the last N commits from ref (0 for the whole history)")
+	cmd.Flags().StringVar(&opts.MailmapFile, "mailmap", "", "Extra .mailmap file used to merge author identities")
+	cmd.Flags().IntVar(&opts.RecentDays, "recent-days", contributorsService.DefaultRecentDays, "How many days back a commit counts as recent (i.e. 14 for a sprint, 90 for a quarter)")
+
 	return cmd
 }
@@ -1,19 +1,68 @@
 package gitcommand
 
 import (
+	"fmt"
+
 	"github.com/redjax/syst/internal/services/gitService/diffService"
 	"github.com/spf13/cobra"
 )
 
 func NewGitDiffCommand() *cobra.Command {
+	var opts diffService.DiffOptions
+	var noIndex bool
+
 	cmd := &cobra.Command{
 		Use:   "diff [branch1] [branch2]",
 		Short: "Interactive change analysis between refs",
-		Long:  "Show changes between branches/commits/tags with interactive file-by-file diff viewer",
+		Long: `Show changes between branches/commits/tags with interactive file-by-file diff viewer.
+
+Use ref:path to compare two single files instead, e.g. a config across branches or a file that was
+renamed. A path without a ref is read from the working tree, and a single ref:path is compared against
+the working copy of the same file.
+
+Press + or - in the diff view to show more or fewer unchanged lines around each change.
+
+Pass --patch to print the diff as a patch that git apply accepts instead of opening the viewer.
+
+Pass --no-index to compare two files on disk that don't have to be in a repository, like
+git diff --no-index. A file that doesn't exist is shown as added or deleted.
+
+Examples:
+  syst git diff main feature
+  syst git diff main feature --context 10
+  syst git diff main:config.yml feature:config.yml
+  syst git diff HEAD~5:old/name.go new/name.go
+  syst git diff v1.0.0:README.md
+  syst git diff v1.0.0 HEAD --authors
+  syst git diff main feature --patch > feature.patch
+  syst git diff --no-index old.yml new.yml`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return diffService.RunDiffExplorer(args)
+			if opts.ContextLines < 1 {
+				return fmt.Errorf("--context must be at least 1")
+			}
+			if opts.Patch && (opts.IgnoreWhitespace || opts.Authors) {
+				return fmt.Errorf("--patch can't be combined with --ignore-whitespace or --authors")
+			}
+			if noIndex {
+				if len(args) != 2 {
+					return fmt.Errorf("--no-index compares exactly two files")
+				}
+				if opts.Authors {
+					return fmt.Errorf("--no-index can't be combined with --authors")
+				}
+				return diffService.RunFileDiff(args[0], args[1], opts)
+			}
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			return diffService.RunDiffExplorer(args, opts)
 		},
 	}
 
+	cmd.Flags().BoolVarP(&opts.IgnoreWhitespace, "ignore-whitespace", "w", false, "Ignore changes in leading/trailing whitespace and indentation")
+	cmd.Flags().IntVarP(&opts.ContextLines, "context", "U", diffService.DefaultContextLines, "Unchanged lines shown around each change, like git diff -U")
+	cmd.Flags().BoolVar(&opts.Patch, "patch", false, "Print the diff as a patch git apply accepts instead of launching the TUI")
+	cmd.Flags().BoolVar(&noIndex, "no-index", false, "Compare two files on disk, which don't have to be in a repository")
+	cmd.Flags().BoolVar(&opts.Authors, "authors", false, "Show which authors contributed to the changed files in the stats view (walks the commits in the range)")
+
 	return cmd
 }
@@ -6,14 +6,28 @@ import (
 )
 
 func NewGitFilesCommand() *cobra.Command {
+	var opts filesService.FileAnalysisOptions
+
 	cmd := &cobra.Command{
 		Use:   "files",
 		Short: "File analysis and statistics",
 		Long:  "Analyze repository files including size, frequency of changes, and type breakdown",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return filesService.RunFileAnalysis()
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON && opts.Report.Format == "" {
+				opts.Report.Format = "json"
+			}
+			return filesService.RunFileAnalysis(opts)
 		},
 	}
 
+	cmd.Flags().Bool("json", false, "Print the analysis as JSON instead of launching the TUI (same as --format json)")
+	addReportFlags(cmd, &opts.Report, "json or markdown")
+	cmd.Flags().BoolVar(&opts.NoLimit, "no-limit", false, "Include all results instead of the top 50 per section")
+	cmd.Flags().BoolVar(&opts.IncludeUntracked, "include-untracked", false, "Also scan the working tree and report untracked and gitignored files")
+	cmd.Flags().BoolVar(&opts.Lines, "lines", false, "Print the code, comment and blank lines per language (cloc-style) instead of launching the TUI")
+	cmd.Flags().IntVar(&opts.StaleMonths, "stale-months", 12, "Months without changes before a tracked file is considered stale")
+
 	return cmd
 }
@@ -0,0 +1,31 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/graphService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitGraphCommand creates the git graph command
+func NewGitGraphCommand() *cobra.Command {
+	var opts graphService.GraphOptions
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Browse the commit graph",
+		Long: `Draw the branch topology of the most recent commits, like git log --graph: each branch runs down its
+own lane, and merges join them. Merge commits are highlighted. Select a commit to see its changes.`,
+		Example: `  syst git graph
+  syst git graph --all -n 500`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			return graphService.RunGraph(opts)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.MaxCount, "max-count", "n", 200, "Number of commits to show (0 for all)")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Graph every branch and tag, not just HEAD")
+
+	return cmd
+}
@@ -7,12 +7,26 @@ import (
 
 // NewGitHealthCommand creates the git health command
 func NewGitHealthCommand() *cobra.Command {
-	return &cobra.Command{
+	var opts healthService.HealthOptions
+
+	cmd := &cobra.Command{
 		Use:   "health",
 		Short: "Repository health check",
 		Long:  "Analyze repository health including large files, potential issues, security concerns, and quality metrics",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return healthService.RunHealthCheck()
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			opts.Remember, _ = cmd.Flags().GetBool("remember")
+			return healthService.RunHealthCheck(opts)
 		},
 	}
+
+	addBotFlags(cmd, &opts.ExcludeBots, &opts.BotPatterns)
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Only check the last N commits from HEAD for commit health (0 for the whole history)")
+	cmd.Flags().BoolVar(&opts.AllowEmptyCommits, "allow-empty-commits", false, "Don't flag commits that change no files, for repositories that use them as markers")
+	cmd.Flags().IntVar(&opts.SigningWindow, "signing-window", healthService.DefaultSigningWindow, "Number of latest commits checked for signatures")
+	cmd.Flags().IntVar(&opts.SigningThreshold, "signing-threshold", healthService.DefaultSigningThreshold, "Percentage of those commits that should be signed, once any of them is")
+	addReportFlags(cmd, &opts.Report, "json, sarif, markdown or prom")
+
+	return cmd
 }
@@ -7,12 +7,28 @@ import (
 
 // NewGitHistoryCommand creates the git history command
 func NewGitHistoryCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:   "history",
+	var opts historyService.HistoryOptions
+
+	cmd := &cobra.Command{
+		Use:   "history [ref]",
 		Short: "Advanced git history views",
-		Long:  "Interactive timeline, commit frequency analysis, and tag/release history browser",
+		Long:  "Interactive timeline, commit frequency analysis, tag/release history and largest commits browser for the history reachable from ref (default HEAD)",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return historyService.RunHistoryExplorer()
+			if len(args) > 0 {
+				opts.Ref = args[0]
+			}
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			applyRelativeDates(cmd)
+			opts.Remember, _ = cmd.Flags().GetBool("remember")
+			return historyService.RunHistoryExplorer(opts)
 		},
 	}
+
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Only analyze the last N commits from ref (0 for the whole history)")
+	cmd.Flags().IntVar(&opts.ReviewRiskLines, "review-risk-lines", historyService.DefaultReviewRiskLines, "Highlight commits changing at least N lines as review risks in the largest commits view")
+	addSignatureFlags(cmd, &opts.Signatures)
+
+	return cmd
 }
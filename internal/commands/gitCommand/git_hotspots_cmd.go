@@ -0,0 +1,33 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/filesService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitHotspotsCommand creates the git hotspots command
+func NewGitHotspotsCommand() *cobra.Command {
+	var opts filesService.HotspotOptions
+
+	cmd := &cobra.Command{
+		Use:   "hotspots",
+		Short: "Find large files that change often",
+		Long: `Rank tracked files by churn (lines added and deleted across history) and size (current line count).
+Files that are both large and frequently changed score highest and are good refactoring candidates.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON && opts.Report.Format == "" {
+				opts.Report.Format = "json"
+			}
+			return filesService.RunHotspots(opts)
+		},
+	}
+
+	cmd.Flags().Bool("json", false, "Print the hotspots as JSON instead of launching the TUI (same as --format json)")
+	addReportFlags(cmd, &opts.Report, "json, csv or markdown")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "n", 50, "Number of files to show (0 for all)")
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/commitLintService"
+	"github.com/redjax/syst/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewGitLintCommitsCommand creates the git lint-commits command
+func NewGitLintCommitsCommand() *cobra.Command {
+	var opts commitLintService.CommitLintOptions
+
+	cmd := &cobra.Command{
+		Use:   "lint-commits [ref]",
+		Short: "Check commit messages against style rules",
+		Long: `Check the messages of the commits reachable from ref (default HEAD) against style rules, and
+exit with status 1 if any commit breaks one. Use --range A..B to only check the commits on B that are not on A,
+like the commits of a pull request.
+
+Rules:
+` + commitLintService.RulesHelp(),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var ref string
+			if len(args) > 0 {
+				ref = args[0]
+			}
+
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			failed, err := commitLintService.RunCommitLint(ref, opts)
+			if err != nil {
+				return err
+			}
+			// Exit non-zero so CI fails on bad commit messages, which the report already
+			// lists
+			if failed {
+				cmd.SilenceErrors, cmd.SilenceUsage = true, true
+				return utils.ErrExitFailure
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Range, "range", "", "Only lint commits in A..B (on B but not on A)")
+	cmd.Flags().IntVarP(&opts.MaxCount, "max-count", "n", 0, "Lint at most this many commits (0 for all)")
+	cmd.Flags().IntVar(&opts.MaxSubjectLength, "max-subject-length", commitLintService.DefaultMaxSubjectLength, "Longest allowed subject line")
+	cmd.Flags().BoolVar(&opts.Conventional, "conventional", false, "Require Conventional Commit prefixes like \"feat:\"")
+	cmd.Flags().StringSliceVar(&opts.Disabled, "disable", nil, "Rules to skip, comma-separated")
+	cmd.Flags().BoolVar(&opts.IncludeMerges, "include-merges", false, "Also lint merge commits")
+
+	return cmd
+}
@@ -0,0 +1,27 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/reflogService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitReflogCommand creates the git reflog command
+func NewGitReflogCommand() *cobra.Command {
+	var opts reflogService.ReflogOptions
+
+	cmd := &cobra.Command{
+		Use:   "reflog",
+		Short: "Browse the reflog to recover lost commits",
+		Long: `Browse the reflogs of HEAD and each local branch: every commit, checkout, reset, rebase and merge,
+with timestamps and the hashes before and after the move. Commits that are no longer on any branch are
+marked unreachable; open one to see its changes and the command to recover it after a bad reset.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			return reflogService.RunReflogViewer(opts)
+		},
+	}
+
+	return cmd
+}
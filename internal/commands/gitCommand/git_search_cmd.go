@@ -1,6 +1,9 @@
 package gitcommand
 
 import (
+	"fmt"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	"github.com/redjax/syst/internal/services/gitService/searchService"
 	"github.com/spf13/cobra"
 )
@@ -13,12 +16,16 @@ func NewGitSearchCommand() *cobra.Command {
 		searchContent bool
 		searchAuthors bool
 		searchCurrent bool
+		searchPickaxe bool
 		caseSensitive bool
 		maxResults    int
 		sinceDate     string
 		untilDate     string
 		authorFilter  string
 		fileFilter    string
+		contextLines  int
+		perFile       int
+		signatures    gitservice.SignatureOptions
 	)
 
 	cmd := &cobra.Command{
@@ -29,29 +36,45 @@ func NewGitSearchCommand() *cobra.Command {
 Examples:
   syst git search "bug fix"                    # Search all types for "bug fix"
   syst git search --commits "refactor"         # Search only commit messages
+  syst git search --commits a1b2c3             # Look up a commit by hash prefix
   syst git search --files "config"             # Search only file names
   syst git search --content "TODO"             # Search only file content
+  syst git search --matches-per-file 0 "TODO"  # List every match, not only the first in each file
   syst git search --authors "john"             # Search only author names
   syst git search --current "readme"           # Search only current files
+  syst git search --pickaxe "parseConfig"      # Find the commits that added or removed a string
   syst git search --since "2024-01-01" "fix"   # Search since specific date
   syst git search --author "john" --files      # Combine filters
 
 The search supports:
 - Commit messages and metadata
+- Commit hashes, by prefix (all candidates are listed if the prefix is ambiguous)
 - Historical file names across all commits  
 - File content (both current and historical)
 - Author names and emails
 - Current filesystem files
+- Recent commits, newest first, when the query is left empty
+- With --pickaxe, the commits that changed how often a string appears in a file, like
+  git log -S: the ones that introduced or removed it (case sensitive, merges skipped)
 
 Interactive commands in TUI:
+- enter: search; with an empty query, list the most recent commits
 - enter: view details
+- +/-: show more or fewer lines around a content match
 - n: new search
 - esc: back to search input
 - /: filter results (esc to exit filter)
 - q: quit`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if contextLines < 1 {
+				return fmt.Errorf("--context must be at least 1")
+			}
+			if perFile < 0 {
+				return fmt.Errorf("--matches-per-file must be 0 or more")
+			}
+
 			// If no search type flags are specified, enable all search types by default
-			noSearchTypeFlags := !searchCommits && !searchFiles && !searchContent && !searchAuthors && !searchCurrent
+			noSearchTypeFlags := !searchCommits && !searchFiles && !searchContent && !searchAuthors && !searchCurrent && !searchPickaxe
 			if noSearchTypeFlags {
 				searchCommits = true
 				searchFiles = true
@@ -60,19 +83,27 @@ Interactive commands in TUI:
 				searchCurrent = true
 			}
 
+			repoPath, _ := cmd.Flags().GetString("repo")
+			applyRelativeDates(cmd)
+
 			opts := searchService.SearchOptions{
-				Query:         args,
-				SearchCommits: searchCommits,
-				SearchFiles:   searchFiles,
-				SearchContent: searchContent,
-				SearchAuthors: searchAuthors,
-				SearchCurrent: searchCurrent,
-				CaseSensitive: caseSensitive,
-				MaxResults:    maxResults,
-				SinceDate:     sinceDate,
-				UntilDate:     untilDate,
-				AuthorFilter:  authorFilter,
-				FileFilter:    fileFilter,
+				RepoPath:       repoPath,
+				Query:          args,
+				SearchCommits:  searchCommits,
+				SearchFiles:    searchFiles,
+				SearchContent:  searchContent,
+				SearchAuthors:  searchAuthors,
+				SearchCurrent:  searchCurrent,
+				SearchPickaxe:  searchPickaxe,
+				CaseSensitive:  caseSensitive,
+				MaxResults:     maxResults,
+				SinceDate:      sinceDate,
+				UntilDate:      untilDate,
+				AuthorFilter:   authorFilter,
+				FileFilter:     fileFilter,
+				MatchesPerFile: perFile,
+				ContextLines:   contextLines,
+				Signatures:     signatures,
 			}
 			return searchService.RunAdvancedSearchWithOptions(opts)
 		},
@@ -84,6 +115,7 @@ Interactive commands in TUI:
 	cmd.Flags().BoolVar(&searchContent, "content", false, "Search file content only (historical and current)")
 	cmd.Flags().BoolVar(&searchAuthors, "authors", false, "Search author names and emails only")
 	cmd.Flags().BoolVar(&searchCurrent, "current", false, "Search current filesystem files only")
+	cmd.Flags().BoolVar(&searchPickaxe, "pickaxe", false, "Find the commits that introduced or removed the query, like git log -S (not included by default)")
 
 	// Filter flags
 	cmd.Flags().BoolVar(&caseSensitive, "case-sensitive", false, "Perform case-sensitive search")
@@ -92,6 +124,9 @@ Interactive commands in TUI:
 	cmd.Flags().StringVar(&untilDate, "until", "", "Search commits until date (YYYY-MM-DD)")
 	cmd.Flags().StringVar(&authorFilter, "author", "", "Filter results by author name/email")
 	cmd.Flags().StringVar(&fileFilter, "file-pattern", "", "Filter file results by pattern (supports wildcards)")
+	cmd.Flags().IntVar(&perFile, "matches-per-file", 1, "Content matches listed for each file (0 for every match)")
+	cmd.Flags().IntVar(&contextLines, "context", searchService.DefaultContextLines, "Lines shown before and after a content match")
+	addSignatureFlags(cmd, &signatures)
 
 	return cmd
 }
@@ -0,0 +1,29 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/repoSizeService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitSizeCommand creates the git size command
+func NewGitSizeCommand() *cobra.Command {
+	var opts repoSizeService.RepoSizeOptions
+
+	cmd := &cobra.Command{
+		Use:   "size",
+		Short: "Find the largest files ever committed",
+		Long: `Walk every commit reachable from any branch or tag and report the files that take the most space in
+the repository's history, grouped by path. Files that were deleted but still live in history are marked, since
+they make clones large even though they no longer appear in the working tree.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			return repoSizeService.RunRepoSize(opts)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.Top, "top", "n", 20, "Number of paths to show (0 for all)")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the report as JSON")
+
+	return cmd
+}
@@ -1,12 +1,17 @@
 package gitcommand
 
 import (
+	"fmt"
+
 	sparsecloneservice "github.com/redjax/syst/internal/services/gitService/sparseCloneService"
 	"github.com/spf13/cobra"
 )
 
 func NewGitSparseCloneCommand() *cobra.Command {
 	var opts sparsecloneservice.SparseCloneOptions
+	var noCone bool
+	var pathsFile string
+	var plan bool
 
 	cmd := &cobra.Command{
 		Use:   "sparse-clone",
@@ -14,7 +19,12 @@ func NewGitSparseCloneCommand() *cobra.Command {
 		Long: `Clone a git repository with sparse checkout in one step.
 
 If no flags are provided, an interactive TUI will guide you through the configuration.
-Otherwise, use the flags to specify the clone options directly.`,
+Otherwise, use the flags to specify the clone options directly.
+
+Pass --plan to print the git commands the clone would run, in order, without running them.
+
+An output directory that exists and isn't empty is never cloned into. Pass --force to
+remove it and clone in its place.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if required flags are provided
 			userFlag := cmd.Flag("username")
@@ -25,17 +35,30 @@ Otherwise, use the flags to specify the clone options directly.`,
 			repoProvided := repoFlag != nil && repoFlag.Changed
 			pathsProvided := pathsFlag != nil && pathsFlag.Changed
 
-			// If no required flags are provided, launch TUI
-			if !userProvided && !repoProvided && !pathsProvided {
-				tuiOpts, err := sparsecloneservice.RunSparseCloneTUI()
+			// Load paths from file up front so a bad path fails before the TUI opens
+			if pathsFile != "" {
+				filePaths, err := sparsecloneservice.LoadPathsFile(pathsFile)
+				if err != nil {
+					return err
+				}
+				opts.Paths = append(opts.Paths, filePaths...)
+				pathsProvided = pathsProvided || len(filePaths) > 0
+			}
+
+			// If no user/repo flags are provided, launch TUI
+			if !userProvided && !repoProvided {
+				tuiOpts, err := sparsecloneservice.RunSparseCloneTUI(opts)
 				if err != nil {
 					return err
 				}
-				return sparsecloneservice.SparseClone(*tuiOpts)
+				// The form doesn't ask about replacing the output directory
+				tuiOpts.Force = opts.Force
+				return runSparseClone(*tuiOpts, plan)
 			}
 
-			// Validate that all required flags are provided when using CLI mode
-			if !userProvided {
+			// Validate that all required flags are provided when using CLI mode. The user
+			// may come from the config file instead.
+			if opts.User == "" {
 				return cmd.Help()
 			}
 			if !repoProvided {
@@ -46,7 +69,8 @@ Otherwise, use the flags to specify the clone options directly.`,
 			}
 
 			// Use the provided flags
-			return sparsecloneservice.SparseClone(opts)
+			opts.ConeMode = !noCone
+			return runSparseClone(opts, plan)
 		},
 	}
 
@@ -56,7 +80,29 @@ Otherwise, use the flags to specify the clone options directly.`,
 	cmd.Flags().StringVarP(&opts.Output, "output-dir", "o", "", "Output directory (defaults to repo name)")
 	cmd.Flags().StringVarP(&opts.Branch, "checkout-branch", "b", "main", "Branch name to checkout")
 	cmd.Flags().StringSliceVarP(&opts.Paths, "checkout-path", "p", []string{}, "Paths to sparse-checkout (required, repeatable)")
+	cmd.Flags().StringVar(&pathsFile, "paths-file", "", "Read checkout paths from a file (one per line, # for comments)")
 	cmd.Flags().StringVar(&opts.Protocol, "protocol", "ssh", "Clone protocol: ssh or https")
+	cmd.Flags().BoolVar(&noCone, "no-cone", false, "Use non-cone mode, treating checkout paths as gitignore-style patterns")
+	cmd.Flags().BoolVar(&opts.ValidatePaths, "validate-paths", false, "Check that each path exists on the remote branch and prompt about missing ones")
+	cmd.Flags().IntVar(&opts.Depth, "depth", 0, "Create a shallow clone truncated to this many commits (0 = full history)")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Replace the output directory if it exists and isn't empty")
+	cmd.Flags().BoolVar(&plan, "plan", false, "Print the git commands the clone would run, without running them")
 
 	return cmd
 }
+
+// runSparseClone clones, or with plan only prints the git commands the clone would run
+func runSparseClone(opts sparsecloneservice.SparseCloneOptions, plan bool) error {
+	if !plan {
+		return sparsecloneservice.SparseClone(opts)
+	}
+
+	lines, err := sparsecloneservice.Plan(opts)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package gitcommand
+
+import (
+	"time"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/staleBranchesService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitStaleBranchesCommand creates the git stale-branches command
+func NewGitStaleBranchesCommand() *cobra.Command {
+	var opts staleBranchesService.StaleBranchesOptions
+	var mergedInto string
+	var olderThan string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "stale-branches",
+		Short: "List or delete merged and inactive local branches",
+		Long: `List local branches that are fully merged into a target branch (the repository's default branch
+unless --merged-into is given), and with --older-than, branches with no commits in that long.
+
+Nothing is deleted unless --delete is passed; the branches are then deleted after confirmation. Unmerged
+branches are only deleted with --force. The current, target and default branches are never deleted.`,
+		Example: `  syst git stale-branches
+  syst git stale-branches --older-than 90d
+  syst git stale-branches --merged-into develop --delete`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var age time.Duration
+			if olderThan != "" {
+				var err error
+				if age, err = gitservice.ParseAge(olderThan); err != nil {
+					return err
+				}
+			}
+
+			// An explicit --dry-run wins over --delete
+			if cmd.Flags().Changed("dry-run") && dryRun {
+				opts.Delete = false
+			}
+
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			return staleBranchesService.RunStaleBranches(mergedInto, age, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&mergedInto, "merged-into", "", "Branch to check merges against (default: the repository's default branch)")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Also select branches with no commits in this long, e.g. 90d, 2w or 36h")
+	cmd.Flags().BoolVar(&opts.Delete, "delete", false, "Delete the selected branches after confirmation")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", true, "List the selected branches without deleting them")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Delete without asking for confirmation")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Also delete selected branches that are not merged")
+
+	return cmd
+}
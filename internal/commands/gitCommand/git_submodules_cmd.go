@@ -0,0 +1,28 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/submodulesService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitSubmodulesCommand creates the git submodules command
+func NewGitSubmodulesCommand() *cobra.Command {
+	var opts submodulesService.SubmodulesOptions
+
+	cmd := &cobra.Command{
+		Use:   "submodules",
+		Short: "Show the status of each submodule",
+		Long: `List the submodules in .gitmodules with their configured URL and the commit the superproject records
+for them. For initialized submodules, also show the checked-out commit, whether it is behind or ahead of the
+recorded commit, and whether the submodule has uncommitted changes. Nothing is cloned or updated.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			return submodulesService.RunSubmodules(opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the submodules as JSON")
+
+	return cmd
+}
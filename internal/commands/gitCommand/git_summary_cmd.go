@@ -0,0 +1,33 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/summaryService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitSummaryCommand creates the git summary command
+func NewGitSummaryCommand() *cobra.Command {
+	var opts summaryService.SummaryOptions
+
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "One-screen overview of the repository's headline numbers",
+		Long: `Print the headline numbers of the other analyses on one screen: total commits, contributors, the
+health score, the last tag, the commit streak, the largest files and the file changed most. The analyses
+run without their TUIs and share the commit stats cache, so each commit is diffed once.`,
+		Example: `  syst git summary
+  syst git summary --exclude-bots
+  syst git summary --json | jq '.health_score'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			opts.NoCache, _ = cmd.Flags().GetBool("no-cache")
+			return summaryService.RunSummary(opts)
+		},
+	}
+
+	addBotFlags(cmd, &opts.ExcludeBots, &opts.BotPatterns)
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the summary as JSON")
+
+	return cmd
+}
@@ -0,0 +1,27 @@
+package gitcommand
+
+import (
+	"github.com/redjax/syst/internal/services/gitService/tagsService"
+	"github.com/spf13/cobra"
+)
+
+// NewGitTagsCommand creates the git tags command
+func NewGitTagsCommand() *cobra.Command {
+	var opts tagsService.TagManagerOptions
+
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "Interactive tag manager",
+		Long: `List tags with their date, type and the number of commits since each one. Create annotated tags
+on a chosen commit, delete tags, and push or delete them on a remote, with a confirmation before each change.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.RepoPath, _ = cmd.Flags().GetString("repo")
+			return tagsService.RunTagManager(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Remote, "remote", "origin", "Remote to push tags to and delete them from")
+
+	return cmd
+}
@@ -1,7 +1,9 @@
 package gitcommand
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -40,11 +42,13 @@ func NewGitWorktreeCommand() *cobra.Command {
 
 // NewWorktreeListCommand returns the worktree list command.
 func NewWorktreeListCommand() *cobra.Command {
+	var jsonOutput bool
+
 	cmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List all worktrees",
-		Long:    "List all Git worktrees in the repository",
+		Long:    "List all Git worktrees in the repository, with the main, locked and prunable ones marked",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			repoPath, _ := cmd.Flags().GetString("repo")
 
@@ -58,6 +62,15 @@ func NewWorktreeListCommand() *cobra.Command {
 				return err
 			}
 
+			if jsonOutput {
+				if worktrees == nil {
+					worktrees = []worktreeservice.Worktree{}
+				}
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(worktrees)
+			}
+
 			if len(worktrees) == 0 {
 				fmt.Println("No worktrees found")
 				return nil
@@ -69,13 +82,19 @@ func NewWorktreeListCommand() *cobra.Command {
 				if branch == "" {
 					branch = "(detached)"
 				}
-				fmt.Printf("  %s [%s]\n", wt.Path, branch)
+				line := fmt.Sprintf("  %s [%s]", wt.Path, branch)
+				if status := wt.Status(); status != "" {
+					line += " " + status
+				}
+				fmt.Println(line)
 			}
 
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the worktrees as JSON")
+
 	return cmd
 }
 
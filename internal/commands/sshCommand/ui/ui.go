@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	sshservice "github.com/redjax/syst/internal/services/sshService"
+	"github.com/redjax/syst/internal/utils/styles"
 )
 
 // --- Main menu ---
@@ -235,11 +236,15 @@ func (m *keygenModel) View() string {
 	}
 
 	// Navigation hints in dim gray
-	hints := "\033[90mNavigation:\n" +
+	hints := "Navigation:\n" +
 		"  Tab / Down  → Next field\n" +
 		"  Shift+Tab / Up  → Previous field\n" +
 		"  Enter → Submit\n" +
-		"  Esc → Back to main menu, Ctrl+C → Quit\033[0m\n"
+		"  Esc → Back to main menu, Ctrl+C → Quit"
+	if styles.ColorEnabled() {
+		hints = "\033[90m" + hints + "\033[0m"
+	}
+	hints += "\n"
 
 	return ui + hints
 }
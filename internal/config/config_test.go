@@ -1,6 +1,14 @@
 package config
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/spf13/cobra"
+)
 
 func TestParserForFile(t *testing.T) {
 	tests := []struct {
@@ -30,3 +38,149 @@ func TestParserForFile(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadFileNormalizesKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `theme: dracula
+no_auto_upgrade: true
+git:
+  sparse-clone:
+    provider: gitlab
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	k := koanf.New(".")
+	if err := LoadFile(k, path); err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	for key, want := range map[string]string{
+		"theme":                     "dracula",
+		"no.auto.upgrade":           "true",
+		"git.sparse.clone.provider": "gitlab",
+	} {
+		if got := k.String(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+
+	if err := LoadFile(k, filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadFile() of a missing file should fail")
+	}
+}
+
+func TestApplyFlags(t *testing.T) {
+	var theme, provider, user string
+	var limit int
+	var patterns []string
+
+	root := &cobra.Command{Use: "syst"}
+	root.PersistentFlags().StringVar(&theme, "theme", "", "")
+	git := &cobra.Command{Use: "git"}
+	clone := &cobra.Command{Use: "sparse-clone", Run: func(*cobra.Command, []string) {}}
+	clone.Flags().StringVar(&provider, "provider", "github", "")
+	clone.Flags().StringVarP(&user, "username", "u", "", "")
+	clone.Flags().IntVar(&limit, "limit", 0, "")
+	clone.Flags().StringSliceVar(&patterns, "bot-pattern", nil, "")
+	root.AddCommand(git)
+	git.AddCommand(clone)
+
+	if err := clone.ParseFlags([]string{"--username", "alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	k := koanf.New(".")
+	for key, value := range map[string]interface{}{
+		"theme":                        "solarized",
+		"git.sparse.clone.provider":    "codeberg",
+		"git.sparse.clone.username":    "bob",
+		"git.sparse.clone.limit":       500,
+		"git.sparse.clone.bot.pattern": []interface{}{"ci-*", "*[bot]"},
+	} {
+		if err := k.Set(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ApplyFlags(k, clone); err != nil {
+		t.Fatalf("ApplyFlags() error: %v", err)
+	}
+	if theme != "solarized" || provider != "codeberg" || limit != 500 {
+		t.Errorf("got theme %q, provider %q, limit %d, want solarized, codeberg, 500", theme, provider, limit)
+	}
+	if len(patterns) != 2 || patterns[0] != "ci-*" {
+		t.Errorf("bot patterns = %v, want [ci-* *[bot]]", patterns)
+	}
+	// Flags given on the command line win, and defaults from config don't count as given
+	if user != "alice" {
+		t.Errorf("username = %q, want the one from the command line", user)
+	}
+	if clone.Flags().Changed("provider") {
+		t.Error("a provider from config is marked as given on the command line")
+	}
+
+	if err := k.Set("git.sparse.clone.limit", "lots"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyFlags(k, clone); err == nil {
+		t.Error("ApplyFlags() with a limit that isn't a number should fail")
+	}
+}
+
+func TestApplyFlagsInheritedPerCommand(t *testing.T) {
+	root := &cobra.Command{Use: "syst"}
+	git := &cobra.Command{Use: "git"}
+	var remember bool
+	git.PersistentFlags().BoolVar(&remember, "remember", false, "")
+	history := &cobra.Command{Use: "history", Run: func(*cobra.Command, []string) {}}
+	health := &cobra.Command{Use: "health", Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(git)
+	git.AddCommand(history, health)
+
+	k := koanf.New(".")
+	for key, value := range map[string]interface{}{
+		"git.remember":         true,
+		"git.history.remember": false,
+	} {
+		if err := k.Set(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The subcommand's own key wins over the one of the command defining the flag
+	if err := ApplyFlags(k, history); err != nil || remember {
+		t.Errorf("ApplyFlags(history) = %v with remember %v, want git.history.remember", err, remember)
+	}
+	if err := ApplyFlags(k, health); err != nil || !remember {
+		t.Errorf("ApplyFlags(health) = %v with remember %v, want git.remember", err, remember)
+	}
+
+	if err := k.Set("git.remember", "yes"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyFlags(k, health); err == nil || !strings.Contains(err.Error(), "git.remember") {
+		t.Errorf("ApplyFlags() with remember = yes error = %v, want it reported", err)
+	}
+}
+
+func TestGetenv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("theme = \"dracula\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SYST_NO_COLOR", "true")
+
+	getenv := Getenv([]string{"git", "--config", path, "status"})
+	if got := getenv("SYST_THEME"); got != "dracula" {
+		t.Errorf("SYST_THEME = %q, want the theme from the config file", got)
+	}
+	if got := getenv("SYST_NO_COLOR"); got != "true" {
+		t.Errorf("SYST_NO_COLOR = %q, want the environment variable", got)
+	}
+
+	t.Setenv("SYST_THEME", "solarized")
+	if got := getenv("SYST_THEME"); got != "solarized" {
+		t.Errorf("SYST_THEME = %q, want the environment to win over the config file", got)
+	}
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// defaultFiles are the names the config file is looked for under, in the syst directory
+// of the user config directory
+var defaultFiles = []string{"config.yaml", "config.yml", "config.toml", "config.json"}
+
+// skipFlags are flags that are actions rather than settings, so they never come from config
+var skipFlags = map[string]bool{"config": true, "help": true, "version": true}
+
+// DefaultPath returns the config file in the syst directory of the user config directory
+// (i.e. ~/.config/syst/config.yaml on Linux), or "" when there isn't one.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	for _, name := range defaultFiles {
+		path := filepath.Join(dir, "syst", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Key normalizes a config key the way SYST_ environment variables are: lowercase, with
+// "_" and "-" as separators like ".". So no_auto_upgrade and no-auto-upgrade in a config
+// file, and SYST_NO_AUTO_UPGRADE, are all the key no.auto.upgrade.
+func Key(name string) string {
+	return strings.NewReplacer("_", ".", "-", ".").Replace(strings.ToLower(name))
+}
+
+// LoadFile loads the config file at path into k, with its keys normalized by Key. The
+// format is picked from the file extension.
+func LoadFile(k *koanf.Koanf, path string) error {
+	parser, err := parserForFile(path)
+	if err != nil {
+		return err
+	}
+
+	raw := koanf.New(".")
+	if err := raw.Load(file.Provider(path), parser); err != nil {
+		return fmt.Errorf("failed to load config file %s: %w", path, err)
+	}
+
+	for key, value := range raw.All() {
+		if err := k.Set(Key(key), value); err != nil {
+			return fmt.Errorf("failed to load config file %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// ApplyFlags sets each flag of cmd that wasn't given on the command line from k, where
+// it is keyed by the path of the command defining it and its name, i.e. the --limit of
+// 'syst git contributors' is git.contributors.limit and the global --theme is theme. An
+// inherited flag can also be set for cmd alone under cmd's path, i.e. the --no-cache of
+// 'syst git' as git.contributors.no.cache, which wins over git.no.cache. The flags keep
+// Changed unset, so commands still tell defaults from flags given by hand.
+func ApplyFlags(k *koanf.Koanf, cmd *cobra.Command) error {
+	for c := cmd; c != nil; c = c.Parent() {
+		// The command's own flags, then the persistent ones it inherits from each parent
+		flags := c.PersistentFlags()
+		if c == cmd {
+			flags = c.LocalFlags()
+		}
+
+		var err error
+		flags.VisitAll(func(f *pflag.Flag) {
+			if err != nil || f.Changed || skipFlags[f.Name] {
+				return
+			}
+			key := flagKey(c, f)
+			if c != cmd && k.Exists(flagKey(cmd, f)) {
+				key = flagKey(cmd, f)
+			}
+			if setErr := setFlag(k, key, f); setErr != nil {
+				err = fmt.Errorf("invalid value for %s in the environment or config file: %w", key, setErr)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flagKey is the config key of f under cmd: the command's key followed by the flag name
+func flagKey(cmd *cobra.Command, f *pflag.Flag) string {
+	if prefix := commandKey(cmd); prefix != "" {
+		return prefix + "." + Key(f.Name)
+	}
+	return Key(f.Name)
+}
+
+// commandKey is the config key of a command: its path without the root command, i.e.
+// git.sparse.clone for 'syst git sparse-clone'
+func commandKey(cmd *cobra.Command) string {
+	var parts []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		parts = append([]string{Key(c.Name())}, parts...)
+	}
+	return strings.Join(parts, ".")
+}
+
+// setFlag sets f from the value at key, if k has one. A list replaces a slice flag's
+// values; anything else is parsed like it was given on the command line.
+func setFlag(k *koanf.Koanf, key string, f *pflag.Flag) error {
+	value := k.Get(key)
+	switch value.(type) {
+	case nil, map[string]interface{}:
+		return nil
+	case []interface{}:
+		slice, ok := f.Value.(pflag.SliceValue)
+		if !ok {
+			return fmt.Errorf("--%s takes a single value, not a list", f.Name)
+		}
+		return slice.Replace(k.Strings(key))
+	}
+	return f.Value.Set(k.String(key))
+}
+
+// Getenv returns a function like os.Getenv that falls back to the config file for SYST_
+// variables, for settings read before the flags are parsed (like the color theme). The
+// file is the one --config names in args, or DefaultPath.
+func Getenv(args []string) func(string) string {
+	path := DefaultPath()
+	for i := 0; i < len(args) && args[i] != "--"; i++ {
+		switch {
+		case args[i] == "--config" && i+1 < len(args):
+			path = args[i+1]
+		case strings.HasPrefix(args[i], "--config="):
+			path = strings.TrimPrefix(args[i], "--config=")
+		}
+	}
+
+	k := koanf.New(".")
+	// A broken file is reported by the root command once it loads the config itself
+	if path != "" {
+		if err := LoadFile(k, path); err != nil {
+			k = koanf.New(".")
+		}
+	}
+
+	return func(name string) string {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+		if rest, ok := strings.CutPrefix(name, "SYST_"); ok {
+			return k.String(Key(rest))
+		}
+		return ""
+	}
+}
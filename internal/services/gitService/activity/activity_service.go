@@ -2,16 +2,22 @@ package activity
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/utils/profile"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type ViewMode int
@@ -22,73 +28,103 @@ const (
 	PatternsView
 	ContributorsView
 	TrendsView
+	AuthorsView
 )
 
+// ActivityOptions controls how activity data is gathered
+type ActivityOptions struct {
+	// RepoPath is the repository to analyze; empty means the current directory
+	RepoPath string
+	// ExcludeBots hides commits from authors matching BotPatterns
+	ExcludeBots bool
+	// BotPatterns overrides gitservice.DefaultBotPatterns
+	BotPatterns []string
+	// Ref is the branch, tag or commit whose history is analyzed; empty means HEAD
+	Ref string
+	// Limit caps how many commits are walked from Ref; 0 means the whole history
+	Limit int
+	// RecentDays is how many days back the recent activity goes; 0 means
+	// DefaultRecentDays
+	RecentDays int
+	// Report writes the activity data (json, markdown or prom) instead of launching the TUI
+	Report gitservice.ReportWriter
+}
+
+// DefaultRecentDays is the window of the recent activity when no other is given
+const DefaultRecentDays = 30
+
 type ActivityData struct {
-	TotalCommits    int
-	CommitsByHour   map[int]int    // hour -> count
-	CommitsByDay    map[int]int    // weekday -> count
-	CommitsByMonth  map[string]int // month -> count
-	RecentActivity  []CommitActivity
-	TopAuthors      []AuthorStats
-	CommitFrequency map[string]int // date -> count
-	AveragePerDay   float64
-	MostActiveDay   string
-	MostActiveHour  int
-	LongestStreak   int
-	CurrentStreak   int
-	MonthlyTrends   []MonthlyTrend
-	WeeklyActivity  []WeeklyActivity
-	HourlyDistrib   []HourlyActivity
-	AuthorTimeline  []AuthorActivity
+	TotalCommits    int              `json:"total_commits"`
+	BotCommits      int              `json:"bot_commits"`          // Commits hidden by bot filtering
+	LimitNote       string           `json:"limit_note,omitempty"` // Set when --limit cut the walk short
+	CommitsByHour   map[int]int      `json:"commits_by_hour"`      // hour -> count
+	CommitsByDay    map[int]int      `json:"commits_by_day"`       // weekday -> count
+	CommitsByMonth  map[string]int   `json:"commits_by_month"`     // month -> count
+	RecentActivity  []CommitActivity `json:"recent_activity"`
+	RecentDays      int              `json:"recent_days"` // Window of RecentActivity
+	TopAuthors      []AuthorStats    `json:"top_authors"`
+	CommitFrequency map[string]int   `json:"commit_frequency"` // date -> count
+	AveragePerDay   float64          `json:"average_per_day"`
+	MostActiveDay   string           `json:"most_active_day"`
+	MostActiveHour  int              `json:"most_active_hour"`
+	LongestStreak   int              `json:"longest_streak"`
+	CurrentStreak   int              `json:"current_streak"`
+	MonthlyTrends   []MonthlyTrend   `json:"monthly_trends"`
+	WeeklyActivity  []WeeklyActivity `json:"weekly_activity"`
+	HourlyDistrib   []HourlyActivity `json:"hourly_distribution"`
+	AuthorTimeline  []AuthorActivity `json:"author_timeline"`
 }
 
 type CommitActivity struct {
-	Date   string
-	Count  int
-	Author string
+	Date   string `json:"date"`
+	Count  int    `json:"count"`
+	Author string `json:"author"`
 }
 
 type AuthorStats struct {
-	Name        string
-	Commits     int
-	Percentage  float64
-	FirstCommit string
-	LastCommit  string
-	AvgPerWeek  float64
+	Name        string  `json:"name"`
+	Commits     int     `json:"commits"`
+	Percentage  float64 `json:"percentage"`
+	FirstCommit string  `json:"first_commit"`
+	LastCommit  string  `json:"last_commit"`
+	AvgPerWeek  float64 `json:"avg_per_week"`
 }
 
 type MonthlyTrend struct {
-	Month  string
-	Count  int
-	Change float64 // percentage change from previous month
+	Month  string  `json:"month"`
+	Count  int     `json:"count"`
+	Change float64 `json:"change"` // percentage change from previous month
 }
 
 type WeeklyActivity struct {
-	Week    string
-	Count   int
-	Authors []string
+	Week    string   `json:"week"`
+	Count   int      `json:"count"`
+	Authors []string `json:"authors"`
 }
 
 type HourlyActivity struct {
-	Hour  int
-	Count int
-	Peak  bool
+	Hour  int  `json:"hour"`
+	Count int  `json:"count"`
+	Peak  bool `json:"peak"`
 }
 
 type AuthorActivity struct {
-	Author string
-	Week   string
-	Count  int
+	Author string `json:"author"`
+	Week   string `json:"week"`
+	Count  int    `json:"count"`
 }
 
 type model struct {
 	data             ActivityData
 	currentView      ViewMode
 	contributorIndex int
+	scroll           int // Line offset into views taller than the terminal
 	err              error
 	loading          bool
 	tuiHelper        *terminal.ResponsiveTUIHelper
+	keys             terminal.KeyMap
+	opts             ActivityOptions
+	loader           gitservice.Loading
 }
 
 type dataLoadedMsg struct {
@@ -101,27 +137,27 @@ type errMsg struct {
 
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#25A065")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1)
 
 	sectionStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			MarginTop(1)
 
 	headerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			MarginBottom(1)
 
 	statsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575"))
+			Foreground(theme.Current.Success)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87"))
+			Foreground(theme.Current.Error)
 )
 
 // Helper function to get dynamic section style based on terminal width
@@ -135,14 +171,22 @@ func (m model) getTitleStyle() lipgloss.Style {
 }
 
 func (m model) Init() tea.Cmd {
-	return loadActivityData
+	return tea.Batch(
+		m.loader.Init(),
+		loadActivityData(m.opts, m.loader.Progress),
+	)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.tuiHelper.HandleWindowSizeMsg(msg)
-		return m, nil
+		return m.scrollTo(m.scroll), nil
+
+	case spinner.TickMsg, gitservice.ProgressMsg:
+		var cmd tea.Cmd
+		m.loader, cmd = m.loader.Update(msg)
+		return m, cmd
 
 	case dataLoadedMsg:
 		m.data = msg.data
@@ -155,108 +199,160 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// The error screen can only retry the analysis or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				return m.retry()
+			case key.Matches(msg, m.keys.Quit):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if offset, ok := m.tuiHelper.ScrollKey(msg, m.scroll, m.reservedLines()); ok {
+			return m.scrollTo(offset), nil
+		}
+
 		switch {
-		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 		case key.Matches(msg, key.NewBinding(key.WithKeys("1"))):
-			m.currentView = OverviewView
-			return m, nil
+			return m.switchView(OverviewView), nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("2"))):
-			m.currentView = TimingView
-			return m, nil
+			return m.switchView(TimingView), nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("3"))):
-			m.currentView = PatternsView
-			return m, nil
+			return m.switchView(PatternsView), nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("4"))):
-			m.currentView = ContributorsView
-			m.contributorIndex = 0
-			return m, nil
+			return m.switchView(ContributorsView), nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("5"))):
-			m.currentView = TrendsView
-			return m, nil
-		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+			return m.switchView(TrendsView), nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("6"))):
+			return m.switchView(AuthorsView), nil
+		case key.Matches(msg, m.keys.Left):
 			if m.currentView > 0 {
-				m.currentView--
-				if m.currentView == ContributorsView {
-					m.contributorIndex = 0
-				}
+				m = m.switchView(m.currentView - 1)
 			}
 			return m, nil
-		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
-			if m.currentView < TrendsView {
-				m.currentView++
-				if m.currentView == ContributorsView {
-					m.contributorIndex = 0
-				}
+		case key.Matches(msg, m.keys.Right):
+			if m.currentView < AuthorsView {
+				m = m.switchView(m.currentView + 1)
 			}
 			return m, nil
-		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
-			if m.currentView == ContributorsView && len(m.data.TopAuthors) > 0 {
-				if m.contributorIndex > 0 {
-					m.contributorIndex--
-				}
+		case key.Matches(msg, m.keys.Up):
+			// Past the top of the contributor list, or in views without one, scroll
+			if m.currentView == ContributorsView && m.contributorIndex > 0 {
+				m.contributorIndex--
+				return m, nil
 			}
-			return m, nil
-		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
-			if m.currentView == ContributorsView && len(m.data.TopAuthors) > 0 {
-				if m.contributorIndex < len(m.data.TopAuthors)-1 {
-					m.contributorIndex++
-				}
+			return m.scrollTo(m.scroll - 1), nil
+		case key.Matches(msg, m.keys.Down):
+			if m.currentView == ContributorsView && m.contributorIndex < len(m.data.TopAuthors)-1 {
+				m.contributorIndex++
+				return m, nil
 			}
-			return m, nil
+			return m.scrollTo(m.scroll + 1), nil
 		}
 	}
 
 	return m, nil
 }
 
+// retry starts the analysis over after it failed, with a fresh progress stream as the
+// last one was closed
+func (m model) retry() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.loading = true
+	m.loader = gitservice.NewLoading()
+	return m, m.Init()
+}
+
 func (m model) View() string {
 	if m.loading {
-		loadingMsg := "Loading repository activity data..."
-		return m.tuiHelper.CenterContent(loadingMsg)
+		return m.tuiHelper.CenterContent(m.loader.View("Loading repository activity data...", lipgloss.NewStyle()))
 	}
 
 	if m.err != nil {
 		errorMsg := fmt.Sprintf("Error: %v", m.err)
-		return m.tuiHelper.CenterContent(errorStyle.Render(errorMsg))
+		return m.tuiHelper.CenterContent(errorStyle.Render(errorMsg) + "\n\n" + m.keys.ErrorHelp())
 	}
 
 	var content strings.Builder
+	title, help := m.viewChrome()
 
-	// Title with current view indicator
-	viewNames := []string{"Overview", "Timing", "Patterns", "Contributors", "Trends"}
-	title := fmt.Sprintf("📊 Repository Activity Dashboard - %s", viewNames[m.currentView])
-	content.WriteString(m.getTitleStyle().Render(title))
+	content.WriteString(title)
 	content.WriteString("\n\n")
 
-	// Render current view
+	// Render current view, scrolled when it doesn't fit between the title and help
+	content.WriteString(m.tuiHelper.ScrollContent(m.renderCurrentView(), m.scroll, m.reservedLines()))
+
+	content.WriteString("\n")
+	content.WriteString(help)
+
+	return content.String()
+}
+
+// viewChrome renders the title, with the current view indicator, and the navigation help
+// shown around the current view
+func (m model) viewChrome() (string, string) {
+	viewNames := []string{"Overview", "Timing", "Patterns", "Contributors", "Trends", "Authors Over Time"}
+	title := fmt.Sprintf("📊 Repository Activity Dashboard - %s", viewNames[m.currentView])
+	if m.opts.Ref != "" {
+		title = fmt.Sprintf("📊 Repository Activity Dashboard (%s) - %s", m.opts.Ref, viewNames[m.currentView])
+	}
+
+	width, _ := m.tuiHelper.GetSize()
+	help := lipgloss.NewStyle().
+		Foreground(theme.Current.Muted).
+		Width(width).
+		Align(lipgloss.Center).
+		Render(terminal.HelpLine("1: Overview", "2: Timing", "3: Patterns", "4: Contributors", "5: Trends", "6: Authors",
+			terminal.HelpKey(m.keys.Left, m.keys.Right)+": Navigate",
+			terminal.HelpKey(m.keys.Up, m.keys.Down)+"/pgup/pgdn: Scroll",
+			terminal.Help(m.keys.Quit, "Quit")))
+
+	return m.getTitleStyle().Render(title), help
+}
+
+// reservedLines is the height of the title and help, and the blank line after the title
+func (m model) reservedLines() int {
+	title, help := m.viewChrome()
+	return lipgloss.Height(title) + 1 + lipgloss.Height(help)
+}
+
+func (m model) renderCurrentView() string {
 	switch m.currentView {
 	case OverviewView:
-		content.WriteString(m.renderOverviewView())
+		return m.renderOverviewView()
 	case TimingView:
-		content.WriteString(m.renderTimingView())
+		return m.renderTimingView()
 	case PatternsView:
-		content.WriteString(m.renderPatternsView())
+		return m.renderPatternsView()
 	case ContributorsView:
-		content.WriteString(m.renderContributorsView())
+		return m.renderContributorsView()
 	case TrendsView:
-		content.WriteString(m.renderTrendsView())
+		return m.renderTrendsView()
+	case AuthorsView:
+		return m.renderAuthorsView()
 	}
+	return ""
+}
 
-	// Navigation help at the bottom
-	width, _ := m.tuiHelper.GetSize()
-	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#626262")).
-		Width(width).
-		Align(lipgloss.Center).
-		Render("1: Overview • 2: Timing • 3: Patterns • 4: Contributors • 5: Trends • ←/→: Navigate • q: Quit")
-
-	content.WriteString("\n")
-	content.WriteString(help)
+// switchView shows view from the top, with the first contributor selected
+func (m model) switchView(view ViewMode) model {
+	m.currentView = view
+	m.contributorIndex = 0
+	m.scroll = 0
+	return m
+}
 
-	// Ensure content fits within terminal height
-	result := content.String()
-	return m.tuiHelper.TruncateContentToHeight(result)
+// scrollTo scrolls the current view to offset, kept within the lines hidden below the fold
+func (m model) scrollTo(offset int) model {
+	if m.loading || m.err != nil {
+		return m
+	}
+	m.scroll = m.tuiHelper.ClampScroll(m.renderCurrentView(), offset, m.reservedLines())
+	return m
 }
 
 func (m model) renderOverviewView() string {
@@ -303,8 +399,15 @@ func (m model) renderOverviewView() string {
 		}
 	}
 
+	if d.BotCommits > 0 {
+		content.WriteString(fmt.Sprintf("Bot commits hidden: %s\n", statsStyle.Render(fmt.Sprintf("%d", d.BotCommits))))
+	}
+	if d.LimitNote != "" {
+		content.WriteString(fmt.Sprintf("Stats are %s\n", d.LimitNote))
+	}
+
 	content.WriteString("\n")
-	content.WriteString(sectionStyleResponsive.Render(headerStyle.Render("📅 Recent Activity")))
+	content.WriteString(sectionStyleResponsive.Render(headerStyle.Render(fmt.Sprintf("📅 Recent Activity (last %d days)", d.RecentDays))))
 	content.WriteString("\n\n")
 
 	if len(d.RecentActivity) > 0 {
@@ -493,7 +596,7 @@ func (m model) renderContributorsView() string {
 	content.WriteString("\n\n")
 
 	if len(m.data.TopAuthors) > 0 {
-		content.WriteString("Navigate with ↑/↓ keys\n\n")
+		content.WriteString("Navigate with " + terminal.HelpKey(m.keys.Up, m.keys.Down) + " keys\n\n")
 
 		// Calculate how many contributors to show based on terminal height
 		maxContributors := m.tuiHelper.CalculateMaxItemsForHeight(5, 10) // 5 lines per contributor, 10 reserved lines
@@ -629,31 +732,43 @@ func (m model) renderTrendsView() string {
 	return content.String()
 }
 
-func loadActivityData() tea.Msg {
-	data, err := gatherActivityData()
-	if err != nil {
-		return errMsg{err}
+func loadActivityData(opts ActivityOptions, progress *gitservice.Progress) tea.Cmd {
+	return func() tea.Msg {
+		defer progress.Close()
+
+		data, err := gatherActivityData(opts, progress)
+		if err != nil {
+			return errMsg{err}
+		}
+		return dataLoadedMsg{data}
 	}
-	return dataLoadedMsg{data}
 }
 
-func gatherActivityData() (ActivityData, error) {
-	repo, err := git.PlainOpen(".")
+func gatherActivityData(opts ActivityOptions, progress *gitservice.Progress) (ActivityData, error) {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
 	if err != nil {
-		return ActivityData{}, fmt.Errorf("failed to open repository: %w", err)
+		return ActivityData{}, err
 	}
 
-	ref, err := repo.Head()
+	from, err := gitservice.StartCommit(repo, opts.Ref)
 	if err != nil {
-		return ActivityData{}, fmt.Errorf("failed to get HEAD: %w", err)
+		return ActivityData{}, err
 	}
 
-	cIter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
+	cIter, err := repo.Log(&git.LogOptions{From: from})
 	if err != nil {
 		return ActivityData{}, fmt.Errorf("failed to get log: %w", err)
 	}
+	progress.CountCommits(repo, from, opts.Limit)
+	limit := gitservice.NewCommitLimit(opts.Limit)
+
+	recentDays := opts.RecentDays
+	if recentDays <= 0 {
+		recentDays = DefaultRecentDays
+	}
 
 	data := ActivityData{
+		RecentDays:      recentDays,
 		CommitsByHour:   make(map[int]int),
 		CommitsByDay:    make(map[int]int),
 		CommitsByMonth:  make(map[string]int),
@@ -665,8 +780,31 @@ func gatherActivityData() (ActivityData, error) {
 	authorLastCommit := make(map[string]time.Time)
 	commitDates := []time.Time{}
 	recentDates := make(map[string]int)
+	recentCutoff := time.Now().AddDate(0, 0, -recentDays)
+	authorWeeks := make(map[string]map[string]int) // week -> author -> count
+
+	mailmap, err := gitservice.LoadRepoMailmap(repo, "")
+	if err != nil {
+		return ActivityData{}, err
+	}
 
+	var bots *gitservice.BotFilter
+	if opts.ExcludeBots {
+		bots = gitservice.NewBotFilter(opts.BotPatterns)
+	}
+
+	walked := profile.Phase(profile.LogWalk)
 	err = cIter.ForEach(func(c *object.Commit) error {
+		if !limit.Take() {
+			return storer.ErrStop
+		}
+		progress.Commit()
+		authorName, authorEmail := mailmap.Canonicalize(c.Author.Name, c.Author.Email)
+		if bots.IsBot(authorName, authorEmail) {
+			data.BotCommits++
+			return nil
+		}
+
 		data.TotalCommits++
 
 		// Time analysis
@@ -685,15 +823,20 @@ func gatherActivityData() (ActivityData, error) {
 		dateStr := commitTime.Format("2006-01-02")
 		data.CommitFrequency[dateStr]++
 
-		// Recent activity (last 30 days)
-		if time.Since(commitTime) < 30*24*time.Hour {
+		// Recent activity
+		if commitTime.After(recentCutoff) {
 			recentDates[dateStr]++
 		}
 
 		// Author stats with timeline
-		authorName := c.Author.Name
 		authorStats[authorName]++
 
+		week := isoWeekKey(commitTime)
+		if authorWeeks[week] == nil {
+			authorWeeks[week] = make(map[string]int)
+		}
+		authorWeeks[week][authorName]++
+
 		if _, exists := authorFirstCommit[authorName]; !exists {
 			authorFirstCommit[authorName] = commitTime
 			authorLastCommit[authorName] = commitTime
@@ -708,12 +851,15 @@ func gatherActivityData() (ActivityData, error) {
 
 		return nil
 	})
+	walked()
+	defer profile.Phase(profile.Render)()
 
 	if err != nil {
 		return ActivityData{}, fmt.Errorf("failed to iterate commits: %w", err)
 	}
 
 	// Calculate derived stats
+	data.LimitNote = limit.Note()
 	data.AveragePerDay = calculateAveragePerDay(commitDates)
 	data.MostActiveDay = findMostActiveDay(data.CommitsByDay)
 	data.MostActiveHour = findMostActiveHour(data.CommitsByHour)
@@ -721,8 +867,9 @@ func gatherActivityData() (ActivityData, error) {
 	data.TopAuthors = calculateTopAuthors(authorStats, data.TotalCommits, authorFirstCommit, authorLastCommit)
 	data.RecentActivity = formatRecentActivity(recentDates)
 	data.MonthlyTrends = calculateMonthlyTrends(data.CommitsByMonth)
-	data.WeeklyActivity = calculateWeeklyActivity(data.CommitFrequency, commitDates)
+	data.WeeklyActivity = calculateWeeklyActivity(authorWeeks)
 	data.HourlyDistrib = calculateHourlyDistribution(data.CommitsByHour)
+	data.AuthorTimeline = calculateAuthorTimeline(authorWeeks, data.TopAuthors, timelineAuthors)
 
 	return data, nil
 }
@@ -906,24 +1053,24 @@ func calculateMonthlyTrends(commitsByMonth map[string]int) []MonthlyTrend {
 	return trends
 }
 
-func calculateWeeklyActivity(commitFrequency map[string]int, commitDates []time.Time) []WeeklyActivity {
+func calculateWeeklyActivity(authorWeeks map[string]map[string]int) []WeeklyActivity {
 	var activity []WeeklyActivity
 
-	// Group by week (simplified - could be more sophisticated)
-	weeklyData := make(map[string]int)
-
-	for _, date := range commitDates {
-		year, week := date.ISOWeek()
-		weekKey := fmt.Sprintf("%d-W%02d", year, week)
-		weeklyData[weekKey]++
-	}
-
-	for week, count := range weeklyData {
-		activity = append(activity, WeeklyActivity{
-			Week:    week,
-			Count:   count,
-			Authors: []string{}, // Could be enhanced to track authors per week
+	for week, authors := range authorWeeks {
+		weekly := WeeklyActivity{Week: week, Authors: []string{}}
+		for author, count := range authors {
+			weekly.Count += count
+			weekly.Authors = append(weekly.Authors, author)
+		}
+		// Busiest authors of the week first
+		sort.Slice(weekly.Authors, func(i, j int) bool {
+			a, b := weekly.Authors[i], weekly.Authors[j]
+			if authors[a] != authors[b] {
+				return authors[a] > authors[b]
+			}
+			return a < b
 		})
+		activity = append(activity, weekly)
 	}
 
 	sort.Slice(activity, func(i, j int) bool {
@@ -987,11 +1134,37 @@ func formatRecentActivity(recentDates map[string]int) []CommitActivity {
 	return activity
 }
 
-// RunActivityDashboard starts the repository activity dashboard TUI
-func RunActivityDashboard() error {
+// AnalyzeActivity gathers the activity data without the TUI, i.e. for the summary
+func AnalyzeActivity(opts ActivityOptions) (ActivityData, error) {
+	return gatherActivityData(opts, nil)
+}
+
+// RunActivityDashboard starts the repository activity dashboard TUI, or writes the report when opts.Report is enabled
+func RunActivityDashboard(opts ActivityOptions) error {
+	if err := gitservice.CheckHistoryFrom(opts.RepoPath, opts.Ref); err != nil {
+		return err
+	}
+
+	if opts.Report.Enabled() {
+		data, err := gatherActivityData(opts, nil)
+		if err != nil {
+			return err
+		}
+		return opts.Report.Write(func(w io.Writer, format string) error {
+			return writeReport(w, gitservice.PromRepoName(opts.RepoPath), data, format)
+		}, reportFormats...)
+	}
+
+	if err := terminal.RequireTTY("--format json (or -o FILE)"); err != nil {
+		return err
+	}
+
 	m := model{
 		loading:   true,
 		tuiHelper: terminal.NewResponsiveTUIHelper(),
+		keys:      terminal.Keys(),
+		opts:      opts,
+		loader:    gitservice.NewLoading(),
 	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
@@ -0,0 +1,36 @@
+package activity
+
+import gitservice "github.com/redjax/syst/internal/services/gitService"
+
+// activityMetrics are the metrics of --format prom, every one labeled with the repo.
+// Scrapers depend on their names, so they must not change:
+//
+//	git_total_commits            commits walked, bots excluded with --exclude-bots
+//	git_bot_commits              commits hidden as bot commits
+//	git_commits_per_day          average commits per day over the history
+//	git_commit_streak_days       days in a row with commits, labeled with streak="longest" or "current"
+//	git_author_commits           commits of each top author, labeled with author
+func activityMetrics(data ActivityData) []gitservice.PromMetric {
+	authors := gitservice.PromMetric{Name: "git_author_commits", Help: "Commits by each of the most active authors."}
+	for _, author := range data.TopAuthors {
+		authors.Samples = append(authors.Samples, gitservice.PromSample{
+			Labels: map[string]string{"author": author.Name},
+			Value:  float64(author.Commits),
+		})
+	}
+
+	return []gitservice.PromMetric{
+		gitservice.Gauge("git_total_commits", "Commits in the analyzed history.", float64(data.TotalCommits)),
+		gitservice.Gauge("git_bot_commits", "Commits hidden because their author is a bot.", float64(data.BotCommits)),
+		gitservice.Gauge("git_commits_per_day", "Average commits per day.", data.AveragePerDay),
+		{
+			Name: "git_commit_streak_days",
+			Help: "Days in a row with at least one commit.",
+			Samples: []gitservice.PromSample{
+				{Labels: map[string]string{"streak": "longest"}, Value: float64(data.LongestStreak)},
+				{Labels: map[string]string{"streak": "current"}, Value: float64(data.CurrentStreak)},
+			},
+		},
+		authors,
+	}
+}
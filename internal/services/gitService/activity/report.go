@@ -0,0 +1,70 @@
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// reportFormats are the formats writeReport supports, the default first
+var reportFormats = []string{gitservice.FormatJSON, gitservice.FormatMarkdown, gitservice.FormatPrometheus}
+
+// writeReport writes the activity data of the repository named repo to w as "json",
+// "markdown" or "prom". The Markdown report has the headline numbers, the top authors
+// and the monthly trend.
+func writeReport(w io.Writer, repo string, data ActivityData, format string) error {
+	switch format {
+	case gitservice.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case gitservice.FormatMarkdown:
+		return writeMarkdown(w, data)
+	case gitservice.FormatPrometheus:
+		return gitservice.WritePrometheus(w, repo, activityMetrics(data))
+	default:
+		return fmt.Errorf("unsupported activity report format: %s", format)
+	}
+}
+
+func writeMarkdown(w io.Writer, data ActivityData) error {
+	fmt.Fprintf(w, "# Repository Activity\n\n")
+	if data.LimitNote != "" {
+		fmt.Fprintf(w, "_Stats are %s._\n\n", data.LimitNote)
+	}
+	fmt.Fprintf(w, "- Commits: %d\n", data.TotalCommits)
+	fmt.Fprintf(w, "- Average per day: %.2f\n", data.AveragePerDay)
+	fmt.Fprintf(w, "- Most active day: %s\n", data.MostActiveDay)
+	fmt.Fprintf(w, "- Most active hour: %02d:00\n", data.MostActiveHour)
+	fmt.Fprintf(w, "- Longest streak: %d days (current: %d)\n", data.LongestStreak, data.CurrentStreak)
+
+	if len(data.TopAuthors) > 0 {
+		fmt.Fprintf(w, "\n## Top Authors\n\n")
+		rows := make([][]string, len(data.TopAuthors))
+		for i, a := range data.TopAuthors {
+			rows[i] = []string{
+				a.Name, strconv.Itoa(a.Commits), fmt.Sprintf("%.1f%%", a.Percentage),
+				a.FirstCommit, a.LastCommit, fmt.Sprintf("%.1f", a.AvgPerWeek),
+			}
+		}
+		if err := gitservice.WriteMarkdownTable(w, []string{"Author", "Commits", "Share", "First Commit", "Last Commit", "Per Week"}, rows); err != nil {
+			return err
+		}
+	}
+
+	if len(data.MonthlyTrends) > 0 {
+		fmt.Fprintf(w, "\n## Monthly Trends\n\n")
+		rows := make([][]string, len(data.MonthlyTrends))
+		for i, t := range data.MonthlyTrends {
+			rows[i] = []string{t.Month, strconv.Itoa(t.Count), fmt.Sprintf("%+.1f%%", t.Change)}
+		}
+		if err := gitservice.WriteMarkdownTable(w, []string{"Month", "Commits", "Change"}, rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package activity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+const (
+	// timelineAuthors is how many of the top authors the authors over time view charts
+	timelineAuthors = 5
+	// othersLabel buckets the commits of every other author in the timeline
+	othersLabel = "others"
+)
+
+// timelineStyles color the top authors in the timeline, in order; others are drawn last
+var timelineStyles = func() []lipgloss.Style {
+	styles := make([]lipgloss.Style, timelineAuthors)
+	for i := range styles {
+		styles[i] = lipgloss.NewStyle().Foreground(theme.Current.PaletteColor(i))
+	}
+	return styles
+}()
+
+var othersStyle = lipgloss.NewStyle().Foreground(theme.Current.Muted)
+
+// isoWeekKey returns the ISO week of t, i.e. "2026-W03"
+func isoWeekKey(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// isoWeekStart returns the Monday starting an ISO week key
+func isoWeekStart(key string) (time.Time, error) {
+	var year, week int
+	if _, err := fmt.Sscanf(key, "%d-W%d", &year, &week); err != nil {
+		return time.Time{}, fmt.Errorf("invalid week %q: %w", key, err)
+	}
+	// January 4th is always in the first week of its ISO year
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, 7*(week-1)), nil
+}
+
+// calculateAuthorTimeline returns the weekly commits of the n top authors, with the
+// commits of everyone else under othersLabel. Entries are ordered by week, oldest first,
+// then in the order of topAuthors.
+func calculateAuthorTimeline(authorWeeks map[string]map[string]int, topAuthors []AuthorStats, n int) []AuthorActivity {
+	rank := make(map[string]int)
+	for i, author := range topAuthors[:min(n, len(topAuthors))] {
+		rank[author.Name] = i
+	}
+
+	var weeks []string
+	for week := range authorWeeks {
+		weeks = append(weeks, week)
+	}
+	sort.Strings(weeks)
+
+	var timeline []AuthorActivity
+	for _, week := range weeks {
+		counts := make([]int, len(rank))
+		others := 0
+		for author, count := range authorWeeks[week] {
+			if i, ok := rank[author]; ok {
+				counts[i] += count
+			} else {
+				others += count
+			}
+		}
+		for i, count := range counts {
+			if count > 0 {
+				timeline = append(timeline, AuthorActivity{Author: topAuthors[i].Name, Week: week, Count: count})
+			}
+		}
+		if others > 0 {
+			timeline = append(timeline, AuthorActivity{Author: othersLabel, Week: week, Count: others})
+		}
+	}
+
+	return timeline
+}
+
+// timelineRow holds the commits of each charted author over one or more weeks
+type timelineRow struct {
+	week   string // First week of the row
+	counts []int  // Indexed like the authors the rows were built for
+}
+
+func (r timelineRow) total() int {
+	total := 0
+	for _, count := range r.counts {
+		total += count
+	}
+	return total
+}
+
+// timelineRows spreads the timeline over at most maxRows rows, from its first week to its
+// last, weeks without commits included. When there are more weeks than rows, each row
+// adds up several consecutive weeks; the number of weeks per row is returned too.
+func timelineRows(timeline []AuthorActivity, authors []string, maxRows int) ([]timelineRow, int, error) {
+	if len(timeline) == 0 {
+		return nil, 1, nil
+	}
+
+	column := make(map[string]int)
+	for i, author := range authors {
+		column[author] = i
+	}
+	byWeek := make(map[string][]int)
+	for _, a := range timeline {
+		if byWeek[a.Week] == nil {
+			byWeek[a.Week] = make([]int, len(authors))
+		}
+		byWeek[a.Week][column[a.Author]] += a.Count
+	}
+
+	// The timeline is ordered by week
+	first, err := isoWeekStart(timeline[0].Week)
+	if err != nil {
+		return nil, 1, err
+	}
+	last, err := isoWeekStart(timeline[len(timeline)-1].Week)
+	if err != nil {
+		return nil, 1, err
+	}
+	weeks := int(last.Sub(first).Hours()/(24*7)) + 1
+	weeksPerRow := (weeks + max(maxRows, 1) - 1) / max(maxRows, 1)
+
+	var rows []timelineRow
+	for w := 0; w < weeks; w++ {
+		week := isoWeekKey(first.AddDate(0, 0, 7*w))
+		if w%weeksPerRow == 0 {
+			rows = append(rows, timelineRow{week: week, counts: make([]int, len(authors))})
+		}
+		row := &rows[len(rows)-1]
+		for i, count := range byWeek[week] {
+			row.counts[i] += count
+		}
+	}
+
+	return rows, weeksPerRow, nil
+}
+
+// stackedSegments splits width cells between counts in proportion, handing the cells left
+// over by rounding down to the largest remainders
+func stackedSegments(counts []int, width int) []int {
+	segments := make([]int, len(counts))
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	if total == 0 {
+		return segments
+	}
+
+	left := width
+	remainders := make([]int, len(counts))
+	order := make([]int, len(counts))
+	for i, count := range counts {
+		segments[i] = count * width / total
+		remainders[i] = count * width % total
+		left -= segments[i]
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for _, i := range order[:left] {
+		segments[i]++
+	}
+
+	return segments
+}
+
+// timelineLegend returns the authors charted in the timeline: the top authors in order,
+// then othersLabel if anyone else committed
+func timelineLegend(timeline []AuthorActivity, topAuthors []AuthorStats) []string {
+	var authors []string
+	for _, author := range topAuthors[:min(timelineAuthors, len(topAuthors))] {
+		authors = append(authors, author.Name)
+	}
+	for _, a := range timeline {
+		if a.Author == othersLabel {
+			return append(authors, othersLabel)
+		}
+	}
+	return authors
+}
+
+func timelineStyle(author string, i int) lipgloss.Style {
+	if author == othersLabel {
+		return othersStyle
+	}
+	return timelineStyles[i%len(timelineStyles)]
+}
+
+func (m model) renderAuthorsView() string {
+	d := m.data
+	var content strings.Builder
+
+	content.WriteString(m.getSectionStyle().Render(headerStyle.Render("👥 Authors Over Time")))
+	content.WriteString("\n\n")
+
+	if len(d.AuthorTimeline) == 0 {
+		content.WriteString("No author data available\n")
+		return content.String()
+	}
+
+	authors := timelineLegend(d.AuthorTimeline, d.TopAuthors)
+	legend := make([]string, len(authors))
+	for i, author := range authors {
+		legend[i] = timelineStyle(author, i).Render("█") + " " + author
+	}
+	content.WriteString(lipgloss.NewStyle().Width(m.tuiHelper.GetContentWidth()).Render(strings.Join(legend, "  ")))
+	content.WriteString("\n\n")
+
+	maxRows := m.tuiHelper.CalculateMaxItemsForHeight(1, 16) // 1 line per row, 16 reserved lines
+	rows, weeksPerRow, err := timelineRows(d.AuthorTimeline, authors, maxRows)
+	if err != nil {
+		return content.String() + errorStyle.Render(fmt.Sprintf("Error: %v", err)) + "\n"
+	}
+	barLength := m.tuiHelper.CalculateBarLength(16, 60) // 16 for the week and count, max 60 for bars
+
+	for _, row := range rows {
+		var bar strings.Builder
+		for i, cells := range stackedSegments(row.counts, barLength) {
+			bar.WriteString(timelineStyle(authors[i], i).Render(strings.Repeat("█", cells)))
+		}
+		total := row.total()
+		if total == 0 {
+			bar.WriteString(othersStyle.Render("·"))
+		}
+		content.WriteString(fmt.Sprintf("%-8s %s %s\n", row.week, bar.String(), statsStyle.Render(fmt.Sprintf("%d", total))))
+	}
+
+	content.WriteString("\n")
+	if weeksPerRow > 1 {
+		content.WriteString(fmt.Sprintf("Each row adds up %d weeks; bars show each author's share of the commits\n", weeksPerRow))
+	} else {
+		content.WriteString("Bars show each author's share of the week's commits\n")
+	}
+
+	return content.String()
+}
@@ -0,0 +1,97 @@
+package activity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCalculateAuthorTimeline(t *testing.T) {
+	authorWeeks := map[string]map[string]int{
+		"2026-W02": {"alice": 3, "carol": 1, "dave": 2},
+		"2026-W01": {"bob": 2},
+	}
+	top := []AuthorStats{{Name: "alice", Commits: 3}, {Name: "bob", Commits: 2}, {Name: "dave", Commits: 2}}
+
+	got := calculateAuthorTimeline(authorWeeks, top, 2)
+	want := []AuthorActivity{
+		{Author: "bob", Week: "2026-W01", Count: 2},
+		{Author: "alice", Week: "2026-W02", Count: 3},
+		{Author: othersLabel, Week: "2026-W02", Count: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("timeline = %+v, want %+v", got, want)
+	}
+
+	if legend := timelineLegend(got, top[:2]); !reflect.DeepEqual(legend, []string{"alice", "bob", othersLabel}) {
+		t.Errorf("legend = %v", legend)
+	}
+}
+
+func TestTimelineRows(t *testing.T) {
+	// Four weeks across the turn of the year, with an empty week in between
+	timeline := []AuthorActivity{
+		{Author: "alice", Week: "2025-W52", Count: 1},
+		{Author: "alice", Week: "2026-W02", Count: 2},
+		{Author: othersLabel, Week: "2026-W02", Count: 1},
+		{Author: othersLabel, Week: "2026-W03", Count: 4},
+	}
+	authors := []string{"alice", othersLabel}
+
+	rows, weeksPerRow, err := timelineRows(timeline, authors, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weeksPerRow != 1 || len(rows) != 4 {
+		t.Fatalf("got %d rows of %d weeks, want 4 rows of 1", len(rows), weeksPerRow)
+	}
+	if rows[1].week != "2026-W01" || rows[1].total() != 0 {
+		t.Errorf("rows[1] = %+v, want an empty 2026-W01", rows[1])
+	}
+
+	// Squeezed into two rows, each adds up two weeks
+	rows, weeksPerRow, err = timelineRows(timeline, authors, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []timelineRow{
+		{week: "2025-W52", counts: []int{1, 0}},
+		{week: "2026-W02", counts: []int{2, 5}},
+	}
+	if weeksPerRow != 2 || !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %+v (%d weeks per row), want %+v", rows, weeksPerRow, want)
+	}
+}
+
+func TestStackedSegments(t *testing.T) {
+	tests := []struct {
+		counts []int
+		width  int
+		want   []int
+	}{
+		{[]int{1, 1, 1}, 10, []int{4, 3, 3}},
+		{[]int{3, 0, 1}, 8, []int{6, 0, 2}},
+		{[]int{0, 0}, 5, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		if got := stackedSegments(tt.counts, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("stackedSegments(%v, %d) = %v, want %v", tt.counts, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestISOWeekStart(t *testing.T) {
+	for _, day := range []time.Time{
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+	} {
+		start, err := isoWeekStart(isoWeekKey(day))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if start.Weekday() != time.Monday || isoWeekKey(start) != isoWeekKey(day) {
+			t.Errorf("isoWeekStart(%s) = %s", isoWeekKey(day), start.Format("2006-01-02"))
+		}
+	}
+}
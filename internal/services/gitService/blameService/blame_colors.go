@@ -0,0 +1,120 @@
+package blameService
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// blameColorMode selects how blame lines are colored
+type blameColorMode int
+
+const (
+	// colorByAuthor gives each author their own color
+	colorByAuthor blameColorMode = iota
+	// colorByAge colors lines from hot (the file's newest commit) to cold (its oldest)
+	colorByAge
+)
+
+func (c blameColorMode) String() string {
+	if c == colorByAge {
+		return "age"
+	}
+	return "author"
+}
+
+// next returns the mode the color toggle switches to
+func (c blameColorMode) next() blameColorMode {
+	return (c + 1) % 2
+}
+
+// authorColor returns a stable color for author from the theme's palette
+func authorColor(author string) lipgloss.Color {
+	palette := theme.Current.Palette
+	if len(palette) == 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	// #nosec G104 - hash.Hash writes never fail
+	h.Write([]byte(author))
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+// ageColor returns the color of a commit made at date on the theme's gradient from Cold
+// at oldest to Hot at newest. Themes whose ends aren't "#RRGGBB" colors, like monochrome,
+// draw every line in Hot.
+func ageColor(date, oldest, newest time.Time) lipgloss.Color {
+	coldColor, okCold := parseRGB(theme.Current.Cold)
+	hotColor, okHot := parseRGB(theme.Current.Hot)
+	if !okCold || !okHot {
+		return theme.Current.Hot
+	}
+
+	heat := 1.0
+	if span := newest.Sub(oldest); span > 0 {
+		heat = float64(date.Sub(oldest)) / float64(span)
+		if heat < 0 {
+			heat = 0
+		} else if heat > 1 {
+			heat = 1
+		}
+	}
+
+	var rgb [3]uint8
+	for i := range rgb {
+		rgb[i] = uint8(float64(coldColor[i]) + heat*(float64(hotColor[i])-float64(coldColor[i])))
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2]))
+}
+
+// parseRGB parses a "#RRGGBB" color
+func parseRGB(color lipgloss.Color) ([3]uint8, bool) {
+	var rgb [3]uint8
+	if len(color) != 7 {
+		return rgb, false
+	}
+	if _, err := fmt.Sscanf(string(color), "#%02x%02x%02x", &rgb[0], &rgb[1], &rgb[2]); err != nil {
+		return rgb, false
+	}
+	return rgb, true
+}
+
+// blameDelegate renders blame lines in the default style, with the code colored by the
+// line's author or by the age of its commit
+type blameDelegate struct {
+	list.DefaultDelegate
+	mode           blameColorMode
+	oldest, newest time.Time
+}
+
+func newBlameDelegate(mode blameColorMode, lines *blameLines) blameDelegate {
+	d := blameDelegate{DefaultDelegate: list.NewDefaultDelegate(), mode: mode}
+	if lines != nil {
+		d.oldest, d.newest = lines.dateRange()
+	}
+	return d
+}
+
+func (d blameDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	line, ok := item.(*BlameLineItem)
+	if !ok || m.FilterState() == list.Filtering {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	blame := line.Line()
+	color := authorColor(blame.Author)
+	if d.mode == colorByAge {
+		color = ageColor(blame.CommitDate, d.oldest, d.newest)
+	}
+
+	// The selected line keeps the default selection colors
+	styled := d.DefaultDelegate
+	styled.Styles.NormalTitle = styled.Styles.NormalTitle.Foreground(color)
+	styled.Render(w, m, index, item)
+}
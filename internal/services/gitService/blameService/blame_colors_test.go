@@ -0,0 +1,84 @@
+package blameService
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// useTheme sets the current theme for the rest of the test
+func useTheme(t *testing.T, th theme.Theme) {
+	original := theme.Current
+	t.Cleanup(func() { theme.Current = original })
+	theme.Current = th
+}
+
+func TestAgeColor(t *testing.T) {
+	useTheme(t, theme.Default)
+	oldest := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newest := oldest.AddDate(2, 0, 0)
+
+	tests := []struct {
+		date time.Time
+		want lipgloss.Color
+	}{
+		{newest, "#FF5F5F"},
+		{oldest, "#5F87FF"},
+		{oldest.Add(newest.Sub(oldest) / 2), "#AF73AF"},
+		// Dates outside the range are clamped
+		{oldest.AddDate(-1, 0, 0), "#5F87FF"},
+	}
+	for _, tt := range tests {
+		if got := ageColor(tt.date, oldest, newest); got != tt.want {
+			t.Errorf("ageColor(%s) = %s, want %s", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+
+	// A file with a single commit is all hot
+	if got := ageColor(oldest, oldest, oldest); got != "#FF5F5F" {
+		t.Errorf("ageColor() with no date range = %s, want the hot color", got)
+	}
+
+	// Monochrome has no gradient
+	useTheme(t, theme.Monochrome)
+	if got := ageColor(oldest, oldest, newest); got != "" {
+		t.Errorf("ageColor() in monochrome = %s, want no color", got)
+	}
+}
+
+func TestBlameLinesDateRange(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lines := newBlameLines("a\nb\nc\nd", []BlameHunk{
+		{StartLine: 1, Lines: 1, CommitHash: "aaaaaaaaaa", CommitDate: first.AddDate(0, 6, 0)},
+		{StartLine: 2, Lines: 1, CommitHash: "bbbbbbbbbb", CommitDate: first},
+		{StartLine: 3, Lines: 1, CommitHash: "cccccccccc", CommitDate: first.AddDate(1, 0, 0)},
+		// Not committed yet, so not in the range
+		{StartLine: 4, Lines: 1, CommitDate: time.Now()},
+	})
+
+	oldest, newest := lines.dateRange()
+	if !oldest.Equal(first) || !newest.Equal(first.AddDate(1, 0, 0)) {
+		t.Errorf("dateRange() = %s, %s", oldest, newest)
+	}
+}
+
+func TestAuthorColor(t *testing.T) {
+	useTheme(t, theme.Default)
+	if authorColor("Alice") != authorColor("Alice") {
+		t.Error("authorColor() should be stable")
+	}
+	seen := make(map[lipgloss.Color]bool)
+	for _, author := range []string{"Alice", "Bob", "Carol", "Dave", "Erin", "Frank"} {
+		seen[authorColor(author)] = true
+	}
+	if len(seen) < 2 {
+		t.Error("authorColor() should spread authors over the palette")
+	}
+
+	useTheme(t, theme.Monochrome)
+	if got := authorColor("Alice"); got != "" {
+		t.Errorf("authorColor() in monochrome = %s, want no color", got)
+	}
+}
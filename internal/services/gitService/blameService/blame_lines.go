@@ -0,0 +1,101 @@
+package blameService
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// BlameHunk is a run of consecutive lines last changed by the same commit
+type BlameHunk struct {
+	StartLine   int // 1-based
+	Lines       int
+	Author      string
+	AuthorEmail string
+	CommitHash  string
+	CommitDate  time.Time
+	CommitMsg   string
+}
+
+// blameLines holds a file's content and blame hunks. BlameLine values are built on
+// demand, so a file with tens of thousands of lines costs one string, a slice of line
+// offsets and one entry per hunk rather than a struct per line.
+type blameLines struct {
+	content    string
+	lineStarts []int // Byte offset of each line in content
+	hunks      []BlameHunk
+}
+
+func newBlameLines(content string, hunks []BlameHunk) *blameLines {
+	lineStarts := make([]int, 1, strings.Count(content, "\n")+1)
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	return &blameLines{content: content, lineStarts: lineStarts, hunks: hunks}
+}
+
+// Len returns the number of lines
+func (b *blameLines) Len() int {
+	return len(b.lineStarts)
+}
+
+// Content returns the text of line n (1-based), without its newline
+func (b *blameLines) Content(n int) string {
+	start := b.lineStarts[n-1]
+	if n == len(b.lineStarts) {
+		return b.content[start:]
+	}
+	return b.content[start : b.lineStarts[n]-1]
+}
+
+// Line returns the blame for line n (1-based)
+func (b *blameLines) Line(n int) BlameLine {
+	line := BlameLine{LineNumber: n, Content: b.Content(n)}
+
+	// The last hunk starting at or before n
+	i := sort.Search(len(b.hunks), func(i int) bool { return b.hunks[i].StartLine > n }) - 1
+	if i >= 0 && n < b.hunks[i].StartLine+b.hunks[i].Lines {
+		h := b.hunks[i]
+		line.Author = h.Author
+		line.AuthorEmail = h.AuthorEmail
+		line.CommitHash = h.CommitHash
+		line.CommitDate = h.CommitDate
+		line.CommitMsg = h.CommitMsg
+	}
+	return line
+}
+
+// dateRange returns the dates of the oldest and newest commits in the blame. Lines not
+// committed yet are left out.
+func (b *blameLines) dateRange() (oldest, newest time.Time) {
+	for _, h := range b.hunks {
+		if h.CommitHash == "" {
+			continue
+		}
+		if oldest.IsZero() || h.CommitDate.Before(oldest) {
+			oldest = h.CommitDate
+		}
+		if newest.IsZero() || h.CommitDate.After(newest) {
+			newest = h.CommitDate
+		}
+	}
+	return oldest, newest
+}
+
+// items returns one list item per line. Each item only holds its line number; the list
+// builds the BlameLine when it renders the item, so only the visible page is ever
+// materialized, while filtering still covers the whole file.
+func (b *blameLines) items() []list.Item {
+	lineItems := make([]BlameLineItem, b.Len())
+	items := make([]list.Item, len(lineItems))
+	for i := range lineItems {
+		lineItems[i] = BlameLineItem{lines: b, number: i + 1}
+		// Pointers into one slice, so wrapping them in list.Item doesn't allocate
+		items[i] = &lineItems[i]
+	}
+	return items
+}
@@ -0,0 +1,107 @@
+package blameService
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/go-git/go-git/v5/plumbing"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestBlameLines(t *testing.T) {
+	lines := newBlameLines("first\nsecond\nthird\n", []BlameHunk{
+		{StartLine: 1, Lines: 2, Author: "Alice", CommitHash: "aaaaaaaaaa"},
+		{StartLine: 3, Lines: 1, Author: "Bob", CommitHash: "bbbbbbbbbb"},
+	})
+
+	if got := lines.Len(); got != 4 {
+		t.Fatalf("Len() = %d, want 4 (including the empty line after the final newline)", got)
+	}
+
+	tests := []struct {
+		n               int
+		content, author string
+	}{
+		{1, "first", "Alice"},
+		{2, "second", "Alice"},
+		{3, "third", "Bob"},
+		{4, "", ""}, // Not covered by a hunk
+	}
+	for _, tt := range tests {
+		line := lines.Line(tt.n)
+		if line.LineNumber != tt.n || line.Content != tt.content || line.Author != tt.author {
+			t.Errorf("Line(%d) = %+v, want %q by %q", tt.n, line, tt.content, tt.author)
+		}
+	}
+
+	items := lines.items()
+	if got := items[2].FilterValue(); got != "3 third Bob" {
+		t.Errorf("FilterValue() = %q", got)
+	}
+	if got := items[3].(*BlameLineItem).Description(); got != "Not committed yet" {
+		t.Errorf("Description() of a line outside the hunks = %q", got)
+	}
+}
+
+// eagerBlameLineItem is a blame list item holding its whole line, as the blame list
+// used to build for every line up front
+type eagerBlameLineItem struct {
+	line BlameLine
+}
+
+func (b eagerBlameLineItem) FilterValue() string { return b.line.Content }
+
+// BenchmarkBlameLoad measures opening the blame of a 50,000 line file and rendering its
+// first page, with the line items built lazily (as the TUI does) and eagerly.
+func BenchmarkBlameLoad(b *testing.B) {
+	repo := gittest.New(b)
+	var content strings.Builder
+	for i := range 50000 {
+		fmt.Fprintf(&content, "line %d of a very large generated file\n", i)
+	}
+	repo.CommitFile("large.txt", content.String(), "Add a large file")
+	stats := gitservice.NewCommitStatsCache(repo.Repository, false)
+
+	const pageSize = 20
+
+	b.Run("lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			analysis, err := analyzeFileBlame(repo.Repository, repo.Dir, stats, false, nil, plumbing.ZeroHash, "large.txt")
+			if err != nil {
+				b.Fatal(err)
+			}
+			items := analysis.lines.items()
+			for _, item := range items[:pageSize] {
+				_ = item.(*BlameLineItem).Title()
+				_ = item.(*BlameLineItem).Description()
+			}
+		}
+	})
+
+	b.Run("eager", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			analysis, err := analyzeFileBlame(repo.Repository, repo.Dir, stats, false, nil, plumbing.ZeroHash, "large.txt")
+			if err != nil {
+				b.Fatal(err)
+			}
+			blameLines := make([]BlameLine, 0, analysis.TotalLines)
+			for n := 1; n <= analysis.TotalLines; n++ {
+				blameLines = append(blameLines, analysis.Line(n))
+			}
+			items := make([]list.Item, len(blameLines))
+			for i, line := range blameLines {
+				items[i] = eagerBlameLineItem{line: line}
+			}
+			for _, item := range items[:pageSize] {
+				line := item.(eagerBlameLineItem).line
+				_ = fmt.Sprintf("%4d │ %s", line.LineNumber, line.Content)
+				_ = fmt.Sprintf("%s • %s • %s", line.Author, line.CommitHash[:8], line.CommitDate.Format("2006-01-02"))
+			}
+		}
+	})
+}
@@ -1,10 +1,10 @@
 package blameService
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"time"
 
@@ -16,7 +16,10 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type ViewMode int
@@ -28,17 +31,28 @@ const (
 	AuthorStatsView
 	CommitDetailsView
 	FileDiffView
+	LineOriginView
 )
 
 type BlameAnalysis struct {
-	FilePath      string
-	BlameLines    []BlameLine
+	FilePath string
+	// Revision is the revision the file was read from, as given after the @ in path@rev;
+	// empty for the working tree
+	Revision      string
+	Hunks         []BlameHunk
 	AuthorStats   []AuthorContribution
 	FileHistory   []FileCommit
 	TotalLines    int
 	LastModified  time.Time
 	OldestChange  time.Time
 	UniqueAuthors int
+
+	lines *blameLines
+}
+
+// Line returns the blame for line n (1-based)
+func (a BlameAnalysis) Line(n int) BlameLine {
+	return a.lines.Line(n)
 }
 
 type BlameLine struct {
@@ -62,13 +76,21 @@ type AuthorContribution struct {
 }
 
 type FileCommit struct {
-	Hash      string
-	Author    string
-	Date      time.Time
-	Message   string
+	Hash    string
+	Author  string
+	Date    time.Time
+	Message string
+	// Path is the file's name in this commit, which differs from the current name if the
+	// file was renamed later
+	Path string
+	// OldPath is the file's name before this commit: "" if the commit created the file,
+	// and different from Path if it renamed the file
+	OldPath   string
 	Changes   int
 	Additions int
 	Deletions int
+	// Ignored is set for the commits blame skips, see BlameOptions.IgnoreRevsFile
+	Ignored bool
 }
 
 type CommitDetails struct {
@@ -80,12 +102,15 @@ type CommitDetails struct {
 	FullMessage  string
 	Parents      []string
 	FilesChanged []FileChange
-	Stats        CommitStats
+	// Combined is the combined diff of a merge against all its parents, like git show -c
+	Combined  []FileChange
+	Stats     CommitStats
+	Signature gitservice.CommitSignature
 }
 
 type FileChange struct {
 	Path      string
-	Status    string // "modified", "added", "deleted", "renamed"
+	Status    string // "modified", "added", "deleted", "renamed", "merged"
 	OldPath   string // For renames
 	Additions int
 	Deletions int
@@ -111,13 +136,23 @@ type FileItem struct {
 	isDirectory  bool
 	size         int64
 	lastModified time.Time
+	// depth is how far the item is indented in the file tree, and expanded whether a
+	// directory's entries are listed below it
+	depth    int
+	expanded bool
 }
 
 func (f FileItem) Title() string {
-	if f.isDirectory {
-		return "📁 " + f.name
+	indent := strings.Repeat("  ", f.depth)
+	switch {
+	case f.name == "..":
+		return "📁 .."
+	case f.isDirectory && f.expanded:
+		return indent + "▾ 📁 " + f.name
+	case f.isDirectory:
+		return indent + "▸ 📁 " + f.name
 	}
-	return "📄 " + f.name
+	return indent + "  📄 " + f.name
 }
 
 func (f FileItem) Description() string {
@@ -125,30 +160,41 @@ func (f FileItem) Description() string {
 		return f.path
 	}
 	sizeStr := formatFileSize(f.size)
-	return fmt.Sprintf("%s • %s • %s", f.path, sizeStr, f.lastModified.Format("2006-01-02"))
+	return fmt.Sprintf("%s • %s • %s", f.path, sizeStr, gitservice.ListDate(f.lastModified, "2006-01-02"))
 }
 
 func (f FileItem) FilterValue() string {
 	return f.name + " " + f.path
 }
 
+// BlameLineItem is a line in the blame list, built from the analysis when rendered
 type BlameLineItem struct {
-	line BlameLine
+	lines  *blameLines
+	number int
+}
+
+func (b BlameLineItem) Line() BlameLine {
+	return b.lines.Line(b.number)
 }
 
 func (b BlameLineItem) Title() string {
-	return fmt.Sprintf("%4d │ %s", b.line.LineNumber, b.line.Content)
+	return fmt.Sprintf("%4d │ %s", b.number, b.lines.Content(b.number))
 }
 
 func (b BlameLineItem) Description() string {
+	line := b.Line()
+	if len(line.CommitHash) < 8 {
+		return "Not committed yet"
+	}
 	return fmt.Sprintf("%s • %s • %s",
-		b.line.Author,
-		b.line.CommitHash[:8],
-		b.line.CommitDate.Format("2006-01-02"))
+		line.Author,
+		line.CommitHash[:8],
+		gitservice.ListDate(line.CommitDate, "2006-01-02"))
 }
 
 func (b BlameLineItem) FilterValue() string {
-	return fmt.Sprintf("%d %s %s", b.line.LineNumber, b.line.Content, b.line.Author)
+	line := b.Line()
+	return fmt.Sprintf("%d %s %s", line.LineNumber, line.Content, line.Author)
 }
 
 // FileChangeItem for commit details list
@@ -167,6 +213,8 @@ func (f FileChangeItem) Title() string {
 		statusIcon = "📝"
 	case "modified":
 		statusIcon = "📝"
+	case "merged":
+		statusIcon = "🔀"
 	}
 	return fmt.Sprintf("%s %s", statusIcon, f.change.Path)
 }
@@ -190,6 +238,17 @@ type model struct {
 	commitDetails      CommitDetails
 	selectedCommit     string
 	selectedFileChange FileChange
+	origin             LineOrigin // The change that added the line traced with "o"
+	repo               *git.Repository
+	repoRoot           string
+	stats              *gitservice.CommitStatsCache
+	follow             bool
+	rev                plumbing.Hash // Zero to blame the working tree
+	revLabel           string        // The revision as given, i.e. "v1.2.0"
+	verifier           *gitservice.SignatureVerifier
+	ignoreRevs         ignoredRevs // Commits lines aren't attributed to
+	colorMode          blameColorMode
+	combinedDiff       bool // Show merges' combined diff instead of the first-parent diff
 
 	// UI components
 	fileList    list.Model
@@ -199,14 +258,17 @@ type model struct {
 	searchInput textinput.Model
 
 	// Data
-	files       []FileItem
+	files       []FileItem // Every tracked file under currentPath
 	currentPath string
+	expanded    map[string]bool // Directories listed with their entries in the file tree
 
 	// UI state
 	loading    bool
 	err        error
 	tuiHelper  *terminal.ResponsiveTUIHelper
 	showSearch bool
+	clipboard  terminal.ClipboardNotice
+	keys       terminal.KeyMap
 }
 
 type filesLoadedMsg struct {
@@ -225,24 +287,149 @@ type errMsg struct {
 	err error
 }
 
-// RunBlameViewer starts the interactive blame viewer TUI
-func RunBlameViewer(args []string) error {
+// BlameOptions controls the blame viewer
+type BlameOptions struct {
+	// RepoPath is the repository to inspect; empty means the current directory
+	RepoPath string
+	// NoCache disables the on-disk commit stats cache under .git/syst-cache
+	NoCache bool
+	// NoFollow stops a file's history at its last rename instead of continuing under
+	// its old name
+	NoFollow bool
+	// Signatures controls whether commit signatures are verified or only detected
+	Signatures gitservice.SignatureOptions
+	// IgnoreRevsFile lists commits blame skips, like git blame --ignore-revs-file; empty
+	// reads DefaultIgnoreRevsFile from the repository root if there is one
+	IgnoreRevsFile string
+}
+
+// RunBlameViewer starts the interactive blame viewer TUI. Like git -C, file arguments
+// are relative to opts.RepoPath. A file or directory given as path@rev is read from the
+// tree of that revision instead of the working tree.
+func RunBlameViewer(opts BlameOptions, args []string) error {
 	// Open the repository
-	repo, err := git.PlainOpen(".")
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+	if _, err := gitservice.Head(repo); err != nil {
+		return err
+	}
+
+	if err := terminal.RequireTTY(""); err != nil {
+		return err
+	}
+
+	root, err := gitservice.RepoRoot(repo)
+	if err != nil {
+		return fmt.Errorf("failed to resolve repository root: %w", err)
+	}
+
+	var rev plumbing.Hash
+	var revLabel string
+	if len(args) > 0 {
+		var path string
+		path, revLabel = splitRevision(opts.RepoPath, args[0])
+		args = append([]string{path}, args[1:]...)
+	}
+	if revLabel != "" {
+		if rev, err = gitservice.ResolveRef(repo, revLabel); err != nil {
+			return fmt.Errorf("failed to resolve '%s': %w", revLabel, err)
+		}
+	}
+
+	return runBlameViewer(repo, root, opts, rev, revLabel, resolveArgs(opts.RepoPath, root, args))
+}
+
+// RunFileBlame starts the blame viewer on a file of the working tree, given relative to
+// the repository root like tree entries are. It lets other TUIs drill down from a file in
+// their lists.
+func RunFileBlame(opts BlameOptions, path string) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+	root, err := gitservice.RepoRoot(repo)
+	if err != nil {
+		return fmt.Errorf("failed to resolve repository root: %w", err)
+	}
+	if !isFile(filepath.Join(root, filepath.FromSlash(path))) {
+		return fmt.Errorf("%s is not in the working tree", path)
+	}
+	return runBlameViewer(repo, root, opts, plumbing.ZeroHash, "", []string{path})
+}
+
+// runBlameViewer starts the TUI at rev (the working tree when zero) on args, the file or
+// directory to open relative to the repository root
+func runBlameViewer(repo *git.Repository, root string, opts BlameOptions, rev plumbing.Hash, revLabel string, args []string) error {
+	verifier, err := opts.Signatures.Verifier()
+	if err != nil {
+		return err
+	}
+
+	ignoreRevs, err := loadIgnoreRevs(root, opts.IgnoreRevsFile)
 	if err != nil {
-		return fmt.Errorf("failed to open repository: %w", err)
+		return err
 	}
 
 	// Initialize the model
-	m := initModel(repo, args)
+	stats := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+	m := initModel(repo, root, stats, !opts.NoFollow, rev, args)
+	m.verifier = verifier
+	m.ignoreRevs = ignoreRevs
+	m.revLabel = revLabel
+	if revLabel != "" {
+		m.fileList.Title = fmt.Sprintf("📁 Repository Files at %s", revLabel)
+	}
 
 	// Start the TUI
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	_, err = p.Run()
+
+	// #nosec G104 - A failed cache write only means the next run recomputes stats
+	stats.Save()
 	return err
 }
 
-func initModel(repo *git.Repository, args []string) model {
+// splitRevision splits a path@rev argument into its path and revision. The path is
+// taken to run to the first @, so revisions like HEAD@{1} and @last-tag keep theirs,
+// unless the whole argument names a file in the working tree. rev is empty when no
+// revision was given.
+func splitRevision(repoPath, arg string) (path, rev string) {
+	if isFile(filepath.Join(repoPath, arg)) {
+		return arg, ""
+	}
+	path, rev, _ = strings.Cut(arg, "@")
+	if path == "" {
+		path = "."
+	}
+	return path, rev
+}
+
+// resolveArgs rewrites a file or directory argument, given relative to repoPath, as a
+// slash-separated path relative to the repository root so it matches tree entries.
+func resolveArgs(repoPath, root string, args []string) []string {
+	if len(args) == 0 || args[0] == "" {
+		return args
+	}
+
+	path := args[0]
+	if !filepath.IsAbs(path) && repoPath != "" {
+		path = filepath.Join(repoPath, path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return args
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return args
+	}
+
+	return append([]string{filepath.ToSlash(rel)}, args[1:]...)
+}
+
+func initModel(repo *git.Repository, root string, stats *gitservice.CommitStatsCache, follow bool, rev plumbing.Hash, args []string) model {
 	// Initialize file list
 	fileList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
 	fileList.Title = "📁 Repository Files"
@@ -251,7 +438,7 @@ func initModel(repo *git.Repository, args []string) model {
 	fileList.SetShowPagination(true)
 
 	// Initialize blame list
-	blameList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	blameList := list.New([]list.Item{}, newBlameDelegate(colorByAuthor, nil), 0, 0)
 	blameList.Title = "🔍 File Blame"
 	blameList.SetShowStatusBar(false)
 	blameList.SetFilteringEnabled(true)
@@ -280,7 +467,7 @@ func initModel(repo *git.Repository, args []string) model {
 	startingPath := "."
 	selectedFile := ""
 	if len(args) > 0 && args[0] != "" {
-		if isFile(args[0]) {
+		if isFileAt(repo, rev, root, args[0]) {
 			selectedFile = args[0]
 			startingPath = filepath.Dir(args[0])
 		} else {
@@ -299,6 +486,17 @@ func initModel(repo *git.Repository, args []string) model {
 		currentPath:  startingPath,
 		loading:      true,
 		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.Keys(),
+		repo:         repo,
+		repoRoot:     root,
+		expanded:     map[string]bool{},
+		stats:        stats,
+		follow:       follow,
+		rev:          rev,
+	}
+
+	for _, l := range []*list.Model{&m.fileList, &m.blameList, &m.historyList, &m.commitList} {
+		m.keys.ApplyToList(l)
 	}
 
 	return m
@@ -308,11 +506,11 @@ func (m model) Init() tea.Cmd {
 	if m.selectedFile != "" {
 		// If a specific file was provided, load its blame directly
 		return tea.Batch(
-			loadFiles(m.currentPath),
-			loadBlameAnalysis(m.selectedFile),
+			loadFiles(m.repo, m.rev, m.currentPath),
+			loadBlameAnalysis(m.repo, m.repoRoot, m.stats, m.follow, m.ignoreRevs, m.rev, m.revLabel, m.selectedFile),
 		)
 	}
-	return loadFiles(m.currentPath)
+	return loadFiles(m.repo, m.rev, m.currentPath)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -331,12 +529,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case filesLoadedMsg:
 		m.loading = false
 		m.files = msg.files
-
-		items := make([]list.Item, len(msg.files))
-		for i, file := range msg.files {
-			items[i] = file
-		}
-		m.fileList.SetItems(items)
+		m.setFileItems()
 
 		// If we have a selected file, switch to blame view
 		if m.selectedFile != "" {
@@ -348,12 +541,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.analysis = msg.analysis
 
 		// Update blame list
-		blameItems := make([]list.Item, len(msg.analysis.BlameLines))
-		for i, line := range msg.analysis.BlameLines {
-			blameItems[i] = BlameLineItem{line: line}
-		}
-		m.blameList.SetItems(blameItems)
+		m.blameList.SetItems(msg.analysis.lines.items())
+		m.blameList.SetDelegate(newBlameDelegate(m.colorMode, msg.analysis.lines))
 		m.blameList.Title = fmt.Sprintf("🔍 Blame: %s", m.analysis.FilePath)
+		if m.analysis.Revision != "" {
+			m.blameList.Title += "@" + m.analysis.Revision
+		}
 
 		// Update history list
 		historyItems := make([]list.Item, len(msg.analysis.FileHistory))
@@ -362,40 +555,57 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.historyList.SetItems(historyItems)
 
+	case lineOriginMsg:
+		m.loading = false
+		m.origin = msg.origin
+
 	case commitDetailsMsg:
 		m.loading = false
 		m.commitDetails = msg.details
-
-		// Update commit details list with file changes
-		commitItems := make([]list.Item, len(msg.details.FilesChanged))
-		for i, fileChange := range msg.details.FilesChanged {
-			commitItems[i] = FileChangeItem{change: fileChange}
-		}
-		m.commitList.SetItems(commitItems)
-		m.commitList.Title = fmt.Sprintf("📝 Commit: %s", msg.details.Hash[:8])
+		m.setCommitItems()
 
 	case errMsg:
 		m.loading = false
 		m.err = msg.err
 
+	case terminal.ClipboardMsg, terminal.ClipboardClearMsg:
+		return m, m.clipboard.Update(msg)
+
 	case tea.KeyMsg:
+		// The error screen can only retry the load or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				m.err = nil
+				m.loading = true
+				return m, m.reload()
+			case key.Matches(msg, m.keys.Quit):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
 		// Handle global keys first
 		switch {
-		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		case key.Matches(msg, m.keys.Back):
 			if m.showSearch {
 				m.showSearch = false
 				m.searchInput.Blur()
 				return m, nil
 			}
+			if m.currentView == LineOriginView {
+				m.currentView = BlameView
+				return m, nil
+			}
 			if m.currentView != FileListView {
 				m.currentView = FileListView
 				return m, nil
 			}
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("/"))):
+		case key.Matches(msg, m.keys.Filter):
 			if m.currentView == FileListView {
 				m.showSearch = !m.showSearch
 				if m.showSearch {
@@ -434,12 +644,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
-			m.loading = true
-			if m.selectedFile != "" {
-				return m, loadBlameAnalysis(m.selectedFile)
+		case key.Matches(msg, m.keys.Copy) && !m.showSearch:
+			if hash := m.selectedHash(); hash != "" {
+				return m, terminal.CopyCmd(hash)
 			}
-			return m, loadFiles(m.currentPath)
+			return m, nil
+
+		case key.Matches(msg, m.keys.Refresh):
+			m.loading = true
+			return m, m.reload()
 		}
 
 		// Handle view-specific keys
@@ -449,16 +662,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Perform search
 				query := m.searchInput.Value()
 				if query != "" {
-					// Filter file list
-					filteredFiles := make([]FileItem, 0)
-					for _, file := range m.files {
-						if strings.Contains(strings.ToLower(file.name), strings.ToLower(query)) ||
-							strings.Contains(strings.ToLower(file.path), strings.ToLower(query)) {
-							filteredFiles = append(filteredFiles, file)
-						}
-					}
-					items := make([]list.Item, len(filteredFiles))
-					for i, file := range filteredFiles {
+					// Fuzzy-match every tracked file under the current directory,
+					// best match first
+					matches := terminal.FuzzySort(query, m.files, func(f FileItem) string { return f.name })
+					items := make([]list.Item, len(matches))
+					for i, file := range matches {
 						items[i] = file
 					}
 					m.fileList.SetItems(items)
@@ -474,53 +682,76 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch m.currentView {
 		case FileListView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			case key.Matches(msg, m.keys.Select):
 				if item, ok := m.fileList.SelectedItem().(FileItem); ok {
 					if item.isDirectory {
 						// Navigate into directory
 						m.currentPath = item.path
 						m.loading = true
-						return m, loadFiles(item.path)
+						return m, loadFiles(m.repo, m.rev, item.path)
 					} else {
 						// Load blame for file
 						m.selectedFile = item.path
 						m.loading = true
 						m.currentView = BlameView
-						return m, loadBlameAnalysis(item.path)
+						return m, loadBlameAnalysis(m.repo, m.repoRoot, m.stats, m.follow, m.ignoreRevs, m.rev, m.revLabel, item.path)
 					}
 				}
+			case msg.String() == " " && m.fileList.FilterState() != list.Filtering:
+				if item, ok := m.fileList.SelectedItem().(FileItem); ok {
+					m.toggleDirectory(item)
+				}
+				return m, nil
 			}
 			m.fileList, cmd = m.fileList.Update(msg)
 
 		case BlameView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
-				if item, ok := m.blameList.SelectedItem().(BlameLineItem); ok {
+			case key.Matches(msg, m.keys.Select):
+				if item, ok := m.blameList.SelectedItem().(*BlameLineItem); ok {
 					// Load commit details for the selected blame line
-					m.selectedCommit = item.line.CommitHash
+					m.selectedCommit = item.Line().CommitHash
 					m.loading = true
 					m.currentView = CommitDetailsView
-					return m, loadCommitDetails(item.line.CommitHash)
+					return m, loadCommitDetails(m.repo, m.stats, m.verifier, m.selectedCommit)
+				}
+			case msg.String() == "o" && m.blameList.FilterState() != list.Filtering:
+				if item, ok := m.blameList.SelectedItem().(*BlameLineItem); ok {
+					// Trace the selected line to the change that added it
+					m.origin = LineOrigin{Line: item.Line().LineNumber}
+					m.loading = true
+					m.currentView = LineOriginView
+					return m, loadLineOrigin(m.repo, m.repoRoot, m.follow, m.rev, m.selectedFile, m.origin.Line)
 				}
+			case msg.String() == "c" && m.blameList.FilterState() != list.Filtering:
+				// Toggle between author and age colors
+				m.colorMode = m.colorMode.next()
+				m.blameList.SetDelegate(newBlameDelegate(m.colorMode, m.analysis.lines))
+				return m, nil
 			}
 			m.blameList, cmd = m.blameList.Update(msg)
 
 		case FileHistoryView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			case key.Matches(msg, m.keys.Select):
 				if item, ok := m.historyList.SelectedItem().(FileCommitItem); ok {
 					// Load commit details for the selected history item
 					m.selectedCommit = item.commit.Hash
 					m.loading = true
 					m.currentView = CommitDetailsView
-					return m, loadCommitDetails(item.commit.Hash)
+					return m, loadCommitDetails(m.repo, m.stats, m.verifier, item.commit.Hash)
 				}
 			}
 			m.historyList, cmd = m.historyList.Update(msg)
 
 		case CommitDetailsView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			case msg.String() == "m" && m.commitDetails.isMerge():
+				// Toggle between the first-parent and combined diff
+				m.combinedDiff = !m.combinedDiff
+				m.setCommitItems()
+				return m, nil
+			case key.Matches(msg, m.keys.Select):
 				if item, ok := m.commitList.SelectedItem().(FileChangeItem); ok {
 					// Load diff view for the selected file
 					m.selectedFileChange = item.change
@@ -533,6 +764,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case FileDiffView:
 			// No specific key handling needed, just allow navigation back
 
+		case LineOriginView:
+			if key.Matches(msg, m.keys.Select) && m.origin.CommitHash != "" {
+				// Load commit details for the commit that added the line
+				m.selectedCommit = m.origin.CommitHash
+				m.loading = true
+				m.currentView = CommitDetailsView
+				return m, loadCommitDetails(m.repo, m.stats, m.verifier, m.selectedCommit)
+			}
+
 		case AuthorStatsView:
 			// No specific handling needed for author stats view
 		}
@@ -542,6 +782,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// reload loads what the current view shows again: the file list, the blame of the
+// selected file, the origin of the traced line, or the selected commit
+func (m model) reload() tea.Cmd {
+	switch {
+	case m.currentView == FileListView || m.selectedFile == "":
+		return loadFiles(m.repo, m.rev, m.currentPath)
+	case m.currentView == LineOriginView:
+		return loadLineOrigin(m.repo, m.repoRoot, m.follow, m.rev, m.selectedFile, m.origin.Line)
+	case (m.currentView == CommitDetailsView || m.currentView == FileDiffView) && m.selectedCommit != "":
+		return loadCommitDetails(m.repo, m.stats, m.verifier, m.selectedCommit)
+	}
+	return loadBlameAnalysis(m.repo, m.repoRoot, m.stats, m.follow, m.ignoreRevs, m.rev, m.revLabel, m.selectedFile)
+}
+
 func (m model) View() string {
 	if m.loading {
 		return m.renderLoading()
@@ -551,27 +805,71 @@ func (m model) View() string {
 		return m.renderError()
 	}
 
+	var view string
 	switch m.currentView {
-	case FileListView:
-		return m.renderFileList()
 	case BlameView:
-		return m.renderBlameView()
+		view = m.renderBlameView()
 	case FileHistoryView:
-		return m.renderHistoryView()
+		view = m.renderHistoryView()
 	case AuthorStatsView:
-		return m.renderAuthorStatsView()
+		view = m.renderAuthorStatsView()
 	case CommitDetailsView:
-		return m.renderCommitDetailsView()
+		view = m.renderCommitDetailsView()
 	case FileDiffView:
-		return m.renderFileDiffView()
+		view = m.renderFileDiffView()
+	case LineOriginView:
+		view = m.renderLineOriginView()
 	default:
-		return m.renderFileList()
+		view = m.renderFileList()
+	}
+
+	if notice := m.clipboard.View(); notice != "" {
+		view += "\n" + notice
+	}
+	return view
+}
+
+// selectedHash returns the full hash of the commit selected in the current view, or ""
+// if the view has no commit to copy.
+func (m model) selectedHash() string {
+	switch m.currentView {
+	case BlameView:
+		if item, ok := m.blameList.SelectedItem().(*BlameLineItem); ok {
+			return item.Line().CommitHash
+		}
+	case FileHistoryView:
+		if item, ok := m.historyList.SelectedItem().(FileCommitItem); ok {
+			return item.commit.Hash
+		}
+	case CommitDetailsView, FileDiffView:
+		return m.commitDetails.Hash
+	case LineOriginView:
+		return m.origin.CommitHash
+	}
+	return ""
+}
+
+// setCommitItems fills the commit details list with the changed files, from the combined
+// diff if it is toggled on and the commit is a merge
+func (m *model) setCommitItems() {
+	files := m.commitDetails.FilesChanged
+	title := fmt.Sprintf("📝 Commit: %s", m.commitDetails.Hash[:8])
+	if m.combinedDiff && m.commitDetails.isMerge() {
+		files = m.commitDetails.Combined
+		title += " (combined diff)"
+	}
+
+	items := make([]list.Item, len(files))
+	for i, fileChange := range files {
+		items[i] = FileChangeItem{change: fileChange}
 	}
+	m.commitList.SetItems(items)
+	m.commitList.Title = title
 }
 
-func loadFiles(path string) tea.Cmd {
+func loadFiles(repo *git.Repository, rev plumbing.Hash, path string) tea.Cmd {
 	return func() tea.Msg {
-		files, err := getRepositoryFiles(path)
+		files, err := getTrackedFiles(repo, rev, path)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -579,19 +877,20 @@ func loadFiles(path string) tea.Cmd {
 	}
 }
 
-func loadBlameAnalysis(filePath string) tea.Cmd {
+func loadBlameAnalysis(repo *git.Repository, root string, stats *gitservice.CommitStatsCache, follow bool, ignore ignoredRevs, rev plumbing.Hash, revLabel string, filePath string) tea.Cmd {
 	return func() tea.Msg {
-		analysis, err := analyzeFileBlame(filePath)
+		analysis, err := analyzeFileBlame(repo, root, stats, follow, ignore, rev, filePath)
 		if err != nil {
 			return errMsg{err}
 		}
+		analysis.Revision = revLabel
 		return blameAnalysisMsg{analysis}
 	}
 }
 
-func loadCommitDetails(commitHash string) tea.Cmd {
+func loadCommitDetails(repo *git.Repository, statsCache *gitservice.CommitStatsCache, verifier *gitservice.SignatureVerifier, commitHash string) tea.Cmd {
 	return func() tea.Msg {
-		details, err := analyzeCommitDetails(commitHash)
+		details, err := AnalyzeCommitDetails(repo, statsCache, verifier, commitHash)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -605,13 +904,20 @@ type FileCommitItem struct {
 }
 
 func (f FileCommitItem) Title() string {
-	return fmt.Sprintf("%s • %s", f.commit.Hash[:8], f.commit.Message)
+	title := fmt.Sprintf("%s • %s", f.commit.Hash[:8], f.commit.Message)
+	if f.commit.OldPath != "" && f.commit.OldPath != f.commit.Path {
+		title += fmt.Sprintf(" (renamed from %s)", f.commit.OldPath)
+	}
+	if f.commit.Ignored {
+		title += " (ignored by blame)"
+	}
+	return title
 }
 
 func (f FileCommitItem) Description() string {
 	return fmt.Sprintf("%s • %s • +%d -%d",
 		f.commit.Author,
-		f.commit.Date.Format("2006-01-02 15:04"),
+		gitservice.ListDate(f.commit.Date, "2006-01-02 15:04"),
 		f.commit.Additions,
 		f.commit.Deletions)
 }
@@ -621,19 +927,23 @@ func (f FileCommitItem) FilterValue() string {
 }
 
 // Analysis functions
-func getRepositoryFiles(rootPath string) ([]FileItem, error) {
-	repo, err := git.PlainOpen(".")
-	if err != nil {
-		return nil, err
-	}
 
-	// Get HEAD commit
-	ref, err := repo.Head()
-	if err != nil {
-		return nil, err
+// revisionCommit returns the commit at rev, or at HEAD when rev is the zero hash
+func revisionCommit(repo *git.Repository, rev plumbing.Hash) (*object.Commit, error) {
+	if rev.IsZero() {
+		ref, err := gitservice.Head(repo)
+		if err != nil {
+			return nil, err
+		}
+		rev = ref.Hash()
 	}
+	return repo.CommitObject(rev)
+}
 
-	commit, err := repo.CommitObject(ref.Hash())
+// getTrackedFiles returns every file at rev (HEAD if zero) under rootPath, at any depth,
+// named by its path relative to rootPath
+func getTrackedFiles(repo *git.Repository, rev plumbing.Hash, rootPath string) ([]FileItem, error) {
+	commit, err := revisionCommit(repo, rev)
 	if err != nil {
 		return nil, err
 	}
@@ -643,188 +953,112 @@ func getRepositoryFiles(rootPath string) ([]FileItem, error) {
 		return nil, err
 	}
 
-	var files []FileItem
-
-	// Add parent directory if not at root
+	prefix := ""
 	if rootPath != "." && rootPath != "" {
-		parentPath := filepath.Dir(rootPath)
-		if parentPath == "." {
-			parentPath = ""
-		}
-		files = append(files, FileItem{
-			path:        parentPath,
-			name:        "..",
-			isDirectory: true,
-		})
+		prefix = rootPath + "/"
 	}
 
-	// Get files from git tree
+	var files []FileItem
 	err = tree.Files().ForEach(func(file *object.File) error {
-		relPath := file.Name
-
-		// Filter by current path
-		if rootPath != "." && rootPath != "" {
-			if !strings.HasPrefix(relPath, rootPath+"/") {
-				return nil
-			}
-			relPath = strings.TrimPrefix(relPath, rootPath+"/")
-		}
-
-		// Skip nested directories for current view
-		if strings.Contains(relPath, "/") {
-			// This is in a subdirectory, add the directory if not already added
-			dirName := strings.Split(relPath, "/")[0]
-			dirPath := rootPath
-			if dirPath != "." && dirPath != "" {
-				dirPath = dirPath + "/" + dirName
-			} else {
-				dirPath = dirName
-			}
-
-			// Check if we already have this directory
-			found := false
-			for _, existing := range files {
-				if existing.path == dirPath && existing.isDirectory {
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				files = append(files, FileItem{
-					path:         dirPath,
-					name:         dirName,
-					isDirectory:  true,
-					lastModified: time.Now(),
-				})
-			}
+		if !strings.HasPrefix(file.Name, prefix) {
 			return nil
 		}
-
-		// Add file
-		filePath := rootPath
-		if filePath != "." && filePath != "" {
-			filePath = filePath + "/" + relPath
-		} else {
-			filePath = relPath
-		}
-
 		files = append(files, FileItem{
-			path:         filePath,
-			name:         relPath,
-			isDirectory:  false,
+			path:         file.Name,
+			name:         strings.TrimPrefix(file.Name, prefix),
 			size:         file.Size,
-			lastModified: time.Now(), // We could get this from git but it's expensive
+			lastModified: time.Now(),
 		})
-
 		return nil
 	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Sort files: directories first, then files, both alphabetically
-	sort.Slice(files, func(i, j int) bool {
-		if files[i].isDirectory != files[j].isDirectory {
-			return files[i].isDirectory
-		}
-		return files[i].name < files[j].name
-	})
-
-	return files, nil
+	return files, err
 }
 
-func analyzeFileBlame(filePath string) (BlameAnalysis, error) {
-	repo, err := git.PlainOpen(".")
+// analyzeFileBlame blames filePath as it is in the working tree, or as it was at rev
+// when rev isn't the zero hash. Each line is attributed to the commit that added it, and
+// the file's history is walked from HEAD or rev. The lines a commit in ignore changed
+// are attributed to the lines they replaced.
+func analyzeFileBlame(repo *git.Repository, root string, statsCache *gitservice.CommitStatsCache, follow bool, ignore ignoredRevs, rev plumbing.Hash, filePath string) (BlameAnalysis, error) {
+	// Get the latest commit info for the file
+	commit, err := revisionCommit(repo, rev)
 	if err != nil {
 		return BlameAnalysis{}, err
 	}
 
 	// Read file content first
-	// #nosec G304 - CLI tool reads user-specified files by design
-	content, err := os.ReadFile(filePath)
+	content, err := readBlameFile(commit, root, filePath, !rev.IsZero())
 	if err != nil {
-		return BlameAnalysis{}, fmt.Errorf("failed to read file %s: %w", filePath, err)
+		return BlameAnalysis{}, err
 	}
 
-	lines := strings.Split(string(content), "\n")
-
-	// For now, create a simple blame analysis without git blame
-	// This is a simplified version until we can get the git blame API working
-	var blameLines []BlameLine
-	authorContribs := make(map[string]*AuthorContribution)
-
-	// Get the latest commit info for the file
-	ref, err := repo.Head()
+	// Get file history
+	history, err := getFileHistory(repo, statsCache, commit.Hash, filePath, follow)
 	if err != nil {
-		return BlameAnalysis{}, err
+		history = []FileCommit{} // Don't fail if we can't get history
+	}
+	for i := range history {
+		history[i].Ignored = ignore[plumbing.NewHash(history[i].Hash)]
 	}
 
-	commit, err := repo.CommitObject(ref.Hash())
+	owners, err := blameFile(commit, filePath, content, rev.IsZero(), follow, ignore)
 	if err != nil {
 		return BlameAnalysis{}, err
 	}
 
-	// Create simplified blame lines (all attributed to the latest commit for now)
-	author := commit.Author.Name
-	authorEmail := commit.Author.Email
-	commitDate := commit.Author.When
-	commitHash := commit.Hash.String()
-	commitMsg := strings.Split(commit.Message, "\n")[0]
-
-	for i, line := range lines {
-		blameLines = append(blameLines, BlameLine{
-			LineNumber:  i + 1,
-			Content:     line,
-			Author:      author,
-			AuthorEmail: authorEmail,
-			CommitHash:  commitHash,
-			CommitDate:  commitDate,
-			CommitMsg:   commitMsg,
-		})
-	}
+	lines := newBlameLines(gitservice.DisplayText(content), nil)
+	lines.hunks = blameHunks(owners, lines.Len())
+	authorStats := authorContributions(lines.hunks, lines.Len())
 
-	// Track author contributions
-	authorContribs[author] = &AuthorContribution{
-		Author:      author,
-		Email:       authorEmail,
-		Lines:       len(lines),
-		FirstCommit: commitDate,
-		LastCommit:  commitDate,
-		Percentage:  100.0,
+	// The newest and oldest changes, the oldest from the history, which includes renames
+	// when following them
+	lastModified := commit.Author.When
+	if _, newest := lines.dateRange(); !newest.IsZero() {
+		lastModified = newest
 	}
-
-	// Get file history
-	history, err := getFileHistory(repo, filePath)
-	if err != nil {
-		history = []FileCommit{} // Don't fail if we can't get history
-	}
-
-	// Create author stats
-	var authorStats []AuthorContribution
-	for _, contrib := range authorContribs {
-		authorStats = append(authorStats, *contrib)
+	oldestChange := lastModified
+	if len(history) > 0 {
+		oldestChange = history[len(history)-1].Date
 	}
 
 	return BlameAnalysis{
 		FilePath:      filePath,
-		BlameLines:    blameLines,
+		Hunks:         lines.hunks,
 		AuthorStats:   authorStats,
 		FileHistory:   history,
-		TotalLines:    len(lines),
-		LastModified:  commitDate,
-		OldestChange:  commitDate,
+		TotalLines:    lines.Len(),
+		LastModified:  lastModified,
+		OldestChange:  oldestChange,
 		UniqueAuthors: len(authorStats),
+		lines:         lines,
 	}, nil
 }
 
-func analyzeCommitDetails(commitHash string) (CommitDetails, error) {
-	repo, err := git.PlainOpen(".")
+// readBlameFile reads filePath from the working tree under root, or from commit's tree
+// if fromCommit is set
+func readBlameFile(commit *object.Commit, root, filePath string, fromCommit bool) (string, error) {
+	if !fromCommit {
+		// #nosec G304 - CLI tool reads user-specified files by design
+		content, err := os.ReadFile(filepath.Join(root, filePath))
+		if err != nil {
+			return "", fmt.Errorf("failed to read file %s: %w", filePath, err)
+		}
+		return string(content), nil
+	}
+
+	file, err := commit.File(filePath)
 	if err != nil {
-		return CommitDetails{}, err
+		return "", fmt.Errorf("failed to find %s in %s: %w", filePath, commit.Hash.String()[:8], err)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
+	return content, nil
+}
 
+// AnalyzeCommitDetails loads a commit with its per-file stats and line changes against
+// its first parent. Its signature is verified with verifier, or only detected if nil.
+func AnalyzeCommitDetails(repo *git.Repository, statsCache *gitservice.CommitStatsCache, verifier *gitservice.SignatureVerifier, commitHash string) (CommitDetails, error) {
 	// Parse the commit hash
 	hash := plumbing.NewHash(commitHash)
 
@@ -846,7 +1080,7 @@ func analyzeCommitDetails(commitHash string) (CommitDetails, error) {
 	}
 
 	// Get commit stats
-	stats, err := commit.Stats()
+	stats, err := statsCache.Stats(commit)
 	if err != nil {
 		return CommitDetails{}, fmt.Errorf("failed to get commit stats: %w", err)
 	}
@@ -887,6 +1121,11 @@ func analyzeCommitDetails(commitHash string) (CommitDetails, error) {
 		})
 	}
 
+	combined, err := combinedFileChanges(commit)
+	if err != nil {
+		return CommitDetails{}, err
+	}
+
 	commitStats := CommitStats{
 		FilesChanged: len(filesChanged),
 		Additions:    totalAdditions,
@@ -903,10 +1142,46 @@ func analyzeCommitDetails(commitHash string) (CommitDetails, error) {
 		FullMessage:  commit.Message,
 		Parents:      parents,
 		FilesChanged: filesChanged,
+		Combined:     combined,
 		Stats:        commitStats,
+		Signature:    verifier.Check(commit),
 	}, nil
 }
 
+// isMerge reports whether the commit has more than one parent, and so a combined diff
+func (d CommitDetails) isMerge() bool {
+	return len(d.Parents) > 1
+}
+
+// combinedFileChanges returns the combined diff of a merge as file changes, with each
+// line's parent markers in front of it. It is nil for other commits.
+func combinedFileChanges(commit *object.Commit) ([]FileChange, error) {
+	files, err := gitservice.CombinedDiff(commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get combined diff: %w", err)
+	}
+
+	var changes []FileChange
+	for _, file := range files {
+		change := FileChange{Path: file.Path, Status: "merged"}
+		change.Additions, change.Deletions = file.Counts()
+		for _, line := range file.Lines {
+			lineType := "context"
+			switch {
+			case line.Skipped > 0:
+				lineType = "info"
+			case line.Added():
+				lineType = "added"
+			case line.Removed():
+				lineType = "deleted"
+			}
+			change.Changes = append(change.Changes, LineChange{Type: lineType, LineNum: line.LineNum, Content: line.String()})
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
 func generateFileChanges(repo *git.Repository, commit *object.Commit, parentCommit *object.Commit, filePath string) []LineChange {
 	var changes []LineChange
 
@@ -924,7 +1199,7 @@ func generateFileChanges(repo *git.Repository, commit *object.Commit, parentComm
 		// Show first few lines of the file as preview
 		if file, err := commit.File(filePath); err == nil {
 			if content, err := file.Contents(); err == nil {
-				lines := strings.Split(content, "\n")
+				lines := strings.Split(gitservice.DisplayText(content), "\n")
 				maxLines := 10
 				if len(lines) > maxLines {
 					changes = append(changes, LineChange{
@@ -973,7 +1248,7 @@ func generateFileChanges(repo *git.Repository, commit *object.Commit, parentComm
 		// Show last few lines of the deleted file
 		if parentFile, parentErr := parentCommit.File(filePath); parentErr == nil {
 			if content, contentErr := parentFile.Contents(); contentErr == nil {
-				lines := strings.Split(content, "\n")
+				lines := strings.Split(gitservice.DisplayText(content), "\n")
 				maxLines := 10
 				startLine := 0
 				if len(lines) > maxLines {
@@ -1037,37 +1312,40 @@ func generateFileChanges(repo *git.Repository, commit *object.Commit, parentComm
 	return changes
 }
 
-func getFileHistory(repo *git.Repository, filePath string) ([]FileCommit, error) {
-	// Get commit history for the file
-	commits, err := repo.Log(&git.LogOptions{
-		FileName: &filePath,
-	})
+// maxFileHistory caps the commits shown in the file history view
+const maxFileHistory = 50
+
+// getFileHistory returns the most recent commits up to from (HEAD if zero) that changed
+// filePath, newest first. If follow is set, the history continues under the file's old
+// name when a commit renamed it, like git log --follow.
+func getFileHistory(repo *git.Repository, statsCache *gitservice.CommitStatsCache, from plumbing.Hash, filePath string, follow bool) ([]FileCommit, error) {
+	start, err := revisionCommit(repo, from)
 	if err != nil {
 		return nil, err
 	}
 
+	commits, err := repo.Log(&git.LogOptions{From: start.Hash, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+
+	path := filePath
 	var history []FileCommit
 	err = commits.ForEach(func(commit *object.Commit) error {
-		// Get file stats for this commit
-		stats, err := commit.Stats()
-		if err != nil {
-			// If we can't get stats, still add the commit with minimal info
-			history = append(history, FileCommit{
-				Hash:    commit.Hash.String(),
-				Author:  commit.Author.Name,
-				Date:    commit.Author.When,
-				Message: strings.Split(commit.Message, "\n")[0], // First line only
-			})
-			return nil
+		change, err := fileChangeInCommit(commit, path)
+		if err != nil || change == nil {
+			return err
 		}
 
-		// Find stats for our specific file
+		// Find stats for the file under its name in this commit
 		var additions, deletions int
-		for _, stat := range stats {
-			if stat.Name == filePath {
-				additions = stat.Addition
-				deletions = stat.Deletion
-				break
+		if stats, err := statsCache.Stats(commit); err == nil {
+			for _, stat := range stats {
+				if stat.Name == path {
+					additions = stat.Addition
+					deletions = stat.Deletion
+					break
+				}
 			}
 		}
 
@@ -1076,32 +1354,100 @@ func getFileHistory(repo *git.Repository, filePath string) ([]FileCommit, error)
 			Author:    commit.Author.Name,
 			Date:      commit.Author.When,
 			Message:   strings.Split(commit.Message, "\n")[0], // First line only
+			Path:      path,
+			OldPath:   change.From.Name,
 			Changes:   additions + deletions,
 			Additions: additions,
 			Deletions: deletions,
 		})
 
-		// Limit to last 50 commits to avoid overwhelming the UI
-		if len(history) >= 50 {
-			return fmt.Errorf("limit reached") // Use error to break the loop
+		if len(history) >= maxFileHistory {
+			return storer.ErrStop
 		}
 
+		switch {
+		case change.From.Name == "":
+			// The file was created here, so it has no earlier history
+			return storer.ErrStop
+		case change.From.Name != path:
+			if !follow {
+				return storer.ErrStop
+			}
+			path = change.From.Name
+		}
 		return nil
 	})
-
-	// Filter out the "limit reached" error
-	if err != nil && !strings.Contains(err.Error(), "limit reached") {
+	if err != nil {
 		return nil, err
 	}
 
 	return history, nil
 }
 
+// fileChangeInCommit returns the change commit made to path compared to its first parent,
+// or nil if it left path alone. A file that appears at path is matched against the files
+// the commit removed, so a rename has From set to the old name. Merges only count when
+// path differs from every parent, as they otherwise repeat a change from a merged branch.
+func fileChangeInCommit(commit *object.Commit, path string) (*object.Change, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree for %s: %w", commit.Hash, err)
+	}
+
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		// The file is not at this path in this commit
+		return nil, nil
+	}
+
+	var parentTrees []*object.Tree
+	err = commit.Parents().ForEach(func(parent *object.Commit) error {
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return err
+		}
+		parentTrees = append(parentTrees, parentTree)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parents of %s: %w", commit.Hash, err)
+	}
+
+	for _, parentTree := range parentTrees {
+		if parentEntry, err := parentTree.FindEntry(path); err == nil && parentEntry.Hash == entry.Hash {
+			return nil, nil
+		}
+	}
+
+	change := &object.Change{To: object.ChangeEntry{Name: path}}
+	if len(parentTrees) == 0 {
+		return change, nil
+	}
+	if _, err := parentTrees[0].FindEntry(path); err == nil {
+		change.From.Name = path
+		return change, nil
+	}
+
+	// The file is new at this path; diff with rename detection to see if it was moved
+	changes, err := object.DiffTreeWithOptions(context.Background(), parentTrees[0], tree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s: %w", commit.Hash, err)
+	}
+	for _, c := range changes {
+		if c.To.Name == path {
+			change.From.Name = c.From.Name
+			break
+		}
+	}
+
+	return change, nil
+}
+
 // Rendering functions
 func (m model) renderLoading() string {
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
+		BorderForeground(theme.Current.Border).
 		Padding(2, 4).
 		Align(lipgloss.Center).
 		Width(50)
@@ -1119,12 +1465,13 @@ func (m model) renderLoading() string {
 func (m model) renderError() string {
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("196")).
+		BorderForeground(theme.Current.Error).
 		Padding(2, 4).
 		Align(lipgloss.Center).
 		Width(60)
 
-	content := "❌ Error\n\n" + m.err.Error()
+	help := lipgloss.NewStyle().Foreground(theme.Current.Muted).Render(m.keys.ErrorHelp())
+	content := "❌ Error\n\n" + m.err.Error() + "\n\n" + help
 	return lipgloss.Place(m.tuiHelper.GetWidth(), m.tuiHelper.GetHeight(), lipgloss.Center, lipgloss.Center, style.Render(content))
 }
 
@@ -1134,7 +1481,7 @@ func (m model) renderFileList() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	content.WriteString(headerStyle.Render("🔍 File Blame Viewer"))
@@ -1144,7 +1491,7 @@ func (m model) renderFileList() string {
 	if m.showSearch {
 		searchStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
+			BorderForeground(theme.Current.Border).
 			Padding(0, 1).
 			MarginBottom(1)
 
@@ -1158,12 +1505,14 @@ func (m model) renderFileList() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "enter: open • /: search • q: quit"
+	help := terminal.HelpLine(terminal.Help(m.keys.Select, "open"), "space: expand/collapse",
+		terminal.Help(m.keys.Filter, "search"), terminal.Help(m.keys.Quit, "quit"))
 	if m.selectedFile != "" {
-		help = "enter: open • 2: blame • 3: history • 4: authors • /: search • q: quit"
+		help = terminal.HelpLine(terminal.Help(m.keys.Select, "open"), "space: expand/collapse", "2: blame", "3: history",
+			"4: authors", terminal.Help(m.keys.Filter, "search"), terminal.Help(m.keys.Quit, "quit"))
 	}
 
 	content.WriteString(helpStyle.Render(help))
@@ -1177,7 +1526,7 @@ func (m model) renderBlameView() string {
 	// Header with file info
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	title := fmt.Sprintf("🔍 Blame: %s", m.analysis.FilePath)
@@ -1186,13 +1535,13 @@ func (m model) renderBlameView() string {
 
 	// Stats summary
 	statsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("242")).
+		Foreground(theme.Current.Muted).
 		MarginBottom(1)
 
 	stats := fmt.Sprintf("Lines: %d • Authors: %d • Last modified: %s",
 		m.analysis.TotalLines,
 		m.analysis.UniqueAuthors,
-		m.analysis.LastModified.Format("2006-01-02 15:04"))
+		gitservice.DetailDate(m.analysis.LastModified, "2006-01-02 15:04"))
 
 	content.WriteString(statsStyle.Render(stats))
 	content.WriteString("\n")
@@ -1203,10 +1552,13 @@ func (m model) renderBlameView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: files • 3: history • 4: authors • enter: commit details • esc: back • q: quit"
+	help := terminal.HelpLine("1: files", "3: history", "4: authors",
+		terminal.Help(m.keys.Select, "commit details"), "o: line origin", terminal.Help(m.keys.Copy, "copy hash"),
+		"c: color by "+m.colorMode.next().String(),
+		terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -1218,7 +1570,7 @@ func (m model) renderHistoryView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	title := fmt.Sprintf("📜 History: %s", m.analysis.FilePath)
@@ -1227,12 +1579,12 @@ func (m model) renderHistoryView() string {
 
 	// Stats
 	statsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("242")).
+		Foreground(theme.Current.Muted).
 		MarginBottom(1)
 
 	stats := fmt.Sprintf("Commits: %d • First change: %s",
 		len(m.analysis.FileHistory),
-		m.analysis.OldestChange.Format("2006-01-02"))
+		gitservice.DetailDate(m.analysis.OldestChange, "2006-01-02"))
 
 	content.WriteString(statsStyle.Render(stats))
 	content.WriteString("\n")
@@ -1243,10 +1595,12 @@ func (m model) renderHistoryView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: files • 2: blame • 4: authors • enter: commit details • esc: back • q: quit"
+	help := terminal.HelpLine("1: files", "2: blame", "4: authors",
+		terminal.Help(m.keys.Select, "commit details"), terminal.Help(m.keys.Copy, "copy hash"),
+		terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -1258,7 +1612,7 @@ func (m model) renderAuthorStatsView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	title := fmt.Sprintf("👥 Authors: %s", m.analysis.FilePath)
@@ -1268,7 +1622,7 @@ func (m model) renderAuthorStatsView() string {
 	// Author statistics
 	tableStyle := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("238")).
+		BorderForeground(theme.Current.Subtle).
 		Padding(1, 2).
 		MarginBottom(1)
 
@@ -1293,7 +1647,7 @@ func (m model) renderAuthorStatsView() string {
 
 	// Summary
 	summaryStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("242")).
+		Foreground(theme.Current.Muted).
 		MarginBottom(1)
 
 	if len(m.analysis.AuthorStats) > 0 {
@@ -1306,10 +1660,11 @@ func (m model) renderAuthorStatsView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: files • 2: blame • 3: history • esc: back • q: quit"
+	help := terminal.HelpLine("1: files", "2: blame", "3: history", terminal.Help(m.keys.Back, "back"),
+		terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -1321,7 +1676,7 @@ func (m model) renderCommitDetailsView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	title := fmt.Sprintf("📝 Commit: %s", m.commitDetails.Hash[:8])
@@ -1331,14 +1686,15 @@ func (m model) renderCommitDetailsView() string {
 	// Commit info
 	infoStyle := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("238")).
+		BorderForeground(theme.Current.Subtle).
 		Padding(1, 2).
 		MarginBottom(1)
 
 	var info strings.Builder
 	info.WriteString(fmt.Sprintf("Author:    %s <%s>\n", m.commitDetails.Author, m.commitDetails.AuthorEmail))
-	info.WriteString(fmt.Sprintf("Date:      %s\n", m.commitDetails.Date.Format("2006-01-02 15:04:05")))
+	info.WriteString(fmt.Sprintf("Date:      %s\n", gitservice.DetailDate(m.commitDetails.Date, "2006-01-02 15:04:05")))
 	info.WriteString(fmt.Sprintf("Hash:      %s\n", m.commitDetails.Hash))
+	info.WriteString(fmt.Sprintf("Signature: %s\n", strings.TrimSpace(m.commitDetails.Signature.Icon()+" "+m.commitDetails.Signature.String())))
 	if len(m.commitDetails.Parents) > 0 {
 		info.WriteString(fmt.Sprintf("Parents:   %s\n", strings.Join(m.commitDetails.Parents, ", ")[:40]+"..."))
 	}
@@ -1366,7 +1722,7 @@ func (m model) renderCommitDetailsView() string {
 
 	// Stats summary
 	statsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("242")).
+		Foreground(theme.Current.Muted).
 		MarginBottom(1)
 
 	stats := fmt.Sprintf("Files: %d • Additions: +%d • Deletions: -%d • Total: %d",
@@ -1379,18 +1735,30 @@ func (m model) renderCommitDetailsView() string {
 	content.WriteString("\n")
 
 	// File changes list
-	if len(m.commitDetails.FilesChanged) > 0 {
+	if len(m.commitList.Items()) > 0 {
 		content.WriteString(m.commitList.View())
 		content.WriteString("\n")
+	} else if m.combinedDiff && m.commitDetails.isMerge() {
+		content.WriteString(statsStyle.Render("No file differs from every parent: the merge took each file from one side"))
+		content.WriteString("\n")
 	}
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: files • 2: blame • 3: history • 4: authors • enter: file diff • esc: back • q: quit"
-	content.WriteString(helpStyle.Render(help))
+	help := []string{"1: files", "2: blame", "3: history", "4: authors",
+		terminal.Help(m.keys.Select, "file diff"), terminal.Help(m.keys.Copy, "copy hash")}
+	if m.commitDetails.isMerge() {
+		mode := "first parent"
+		if m.combinedDiff {
+			mode = "combined"
+		}
+		help = append(help, fmt.Sprintf("m: merge diff (%s)", mode))
+	}
+	help = append(help, terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit"))
+	content.WriteString(helpStyle.Render(terminal.HelpLine(help...)))
 
 	return content.String()
 }
@@ -1401,7 +1769,7 @@ func (m model) renderFileDiffView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	statusIcon := "📝"
@@ -1417,7 +1785,7 @@ func (m model) renderFileDiffView() string {
 
 	// File stats
 	statsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("242")).
+		Foreground(theme.Current.Muted).
 		MarginBottom(1)
 
 	stats := fmt.Sprintf("Additions: +%d • Deletions: -%d • Total: %d",
@@ -1432,7 +1800,7 @@ func (m model) renderFileDiffView() string {
 	if len(m.selectedFileChange.Changes) > 0 {
 		diffStyle := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("238")).
+			BorderForeground(theme.Current.Subtle).
 			Padding(1, 2).
 			MarginBottom(1)
 
@@ -1442,13 +1810,13 @@ func (m model) renderFileDiffView() string {
 			var lineStyle lipgloss.Style
 			switch change.Type {
 			case "added":
-				lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("34")) // green
+				lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Success)
 			case "deleted":
-				lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("31")) // red
+				lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Error)
 			case "context":
-				lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("245")) // gray
+				lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Muted)
 			case "info":
-				lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true) // yellow, bold
+				lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Info).Bold(true)
 			default:
 				lineStyle = lipgloss.NewStyle()
 			}
@@ -1461,7 +1829,7 @@ func (m model) renderFileDiffView() string {
 	} else {
 		// No detailed changes available
 		noChangesStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(theme.Current.Muted).
 			Italic(true).
 			MarginBottom(1)
 
@@ -1477,16 +1845,33 @@ func (m model) renderFileDiffView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: files • 2: blame • 3: history • 4: authors • 5: commit details • esc: back • q: quit"
+	help := terminal.HelpLine("1: files", "2: blame", "3: history", "4: authors", "5: commit details",
+		terminal.Help(m.keys.Copy, "copy hash"), terminal.Help(m.keys.Back, "back"),
+		terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
 }
 
 // Helper functions
+
+// isFileAt reports whether path names a file in the working tree under root, or in the
+// tree at rev when rev isn't the zero hash
+func isFileAt(repo *git.Repository, rev plumbing.Hash, root, path string) bool {
+	if rev.IsZero() {
+		return isFile(filepath.Join(root, path))
+	}
+	commit, err := repo.CommitObject(rev)
+	if err != nil {
+		return false
+	}
+	_, err = commit.File(path)
+	return err == nil
+}
+
 func isFile(path string) bool {
 	info, err := os.Stat(path)
 	if err != nil {
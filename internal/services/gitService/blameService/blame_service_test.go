@@ -0,0 +1,221 @@
+package blameService
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+// newRenameTestRepo creates a repository where a file is created as a.txt, renamed to
+// b.txt, edited, and renamed again to c.txt.
+func newRenameTestRepo(t *testing.T) *gittest.Repo {
+	t.Helper()
+
+	repo := gittest.New(t)
+	content := strings.Repeat("a line that makes the file easy to match across renames\n", 20)
+	write := func(name, extra string) {
+		t.Helper()
+		repo.WriteFile(name, content+extra)
+	}
+
+	write("a.txt", "")
+	repo.Commit("Create a.txt")
+	write("a.txt", "edit 1\n")
+	repo.Commit("Edit a.txt")
+	write("other.txt", "")
+	repo.Commit("Add an unrelated file")
+	repo.Move("a.txt", "b.txt")
+	repo.Commit("Rename a.txt to b.txt")
+	write("b.txt", "edit 2\n")
+	repo.Commit("Edit b.txt")
+	repo.Move("b.txt", "c.txt")
+	repo.Commit("Rename b.txt to c.txt")
+
+	return repo
+}
+
+// blameMessages describes hunks as their line ranges and commit subjects
+func blameMessages(hunks []BlameHunk) string {
+	var ranges []string
+	for _, h := range hunks {
+		ranges = append(ranges, fmt.Sprintf("%d-%d %s", h.StartLine, h.StartLine+h.Lines-1, h.CommitMsg))
+	}
+	return strings.Join(ranges, ", ")
+}
+
+func historyMessages(history []FileCommit) string {
+	var messages []string
+	for _, c := range history {
+		messages = append(messages, c.Message)
+	}
+	return strings.Join(messages, ", ")
+}
+
+func TestGetFileHistoryFollowsRenames(t *testing.T) {
+	repo := newRenameTestRepo(t)
+	stats := gitservice.NewCommitStatsCache(repo.Repository, false)
+
+	history, err := getFileHistory(repo.Repository, stats, plumbing.ZeroHash, "c.txt", true)
+	if err != nil {
+		t.Fatalf("getFileHistory() error: %v", err)
+	}
+
+	want := "Rename b.txt to c.txt, Edit b.txt, Rename a.txt to b.txt, Edit a.txt, Create a.txt"
+	if got := historyMessages(history); got != want {
+		t.Errorf("history = %s, want %s", got, want)
+	}
+
+	if history[0].Path != "c.txt" || history[0].OldPath != "b.txt" {
+		t.Errorf("newest rename = %s from %s, want c.txt from b.txt", history[0].Path, history[0].OldPath)
+	}
+	if last := history[len(history)-1]; last.Path != "a.txt" || last.OldPath != "" {
+		t.Errorf("creating commit = %s from %q, want a.txt from \"\"", last.Path, last.OldPath)
+	}
+}
+
+func TestGetFileHistoryNoFollow(t *testing.T) {
+	repo := newRenameTestRepo(t)
+	stats := gitservice.NewCommitStatsCache(repo.Repository, false)
+
+	history, err := getFileHistory(repo.Repository, stats, plumbing.ZeroHash, "c.txt", false)
+	if err != nil {
+		t.Fatalf("getFileHistory() error: %v", err)
+	}
+
+	// Without following, the history starts at the last rename
+	if got, want := historyMessages(history), "Rename b.txt to c.txt"; got != want {
+		t.Errorf("history = %s, want %s", got, want)
+	}
+}
+
+func TestAnalyzeFileBlameAtRevision(t *testing.T) {
+	repo := newRenameTestRepo(t)
+	stats := gitservice.NewCommitStatsCache(repo.Repository, false)
+
+	rev, err := gitservice.ResolveRef(repo.Repository, "HEAD~1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The file is read from the revision's tree, so root doesn't matter
+	analysis, err := analyzeFileBlame(repo.Repository, "", stats, true, nil, rev, "b.txt")
+	if err != nil {
+		t.Fatalf("analyzeFileBlame() error: %v", err)
+	}
+	if got := analysis.lines.Content(analysis.TotalLines - 1); got != "edit 2" {
+		t.Errorf("last line = %q, want the line added in HEAD~1", got)
+	}
+	// The first lines date from the file's creation, across the rename
+	if got := blameMessages(analysis.Hunks); got != "1-20 Create a.txt, 21-22 Edit b.txt" {
+		t.Errorf("hunks = %s, want the lines attributed to the commits that added them", got)
+	}
+	if got := analysis.AuthorStats; len(got) != 1 || got[0].Lines != 22 || got[0].Commits != 2 {
+		t.Errorf("author stats = %+v, want 22 lines from 2 commits", got)
+	}
+	if oldest, newest := analysis.lines.dateRange(); !newest.After(oldest) {
+		t.Errorf("dateRange() = %s, %s, want the lines' ages to differ", oldest, newest)
+	}
+	if got, want := historyMessages(analysis.FileHistory), "Edit b.txt, Rename a.txt to b.txt, Edit a.txt, Create a.txt"; got != want {
+		t.Errorf("history = %s, want %s", got, want)
+	}
+
+	if _, err := analyzeFileBlame(repo.Repository, "", stats, true, nil, rev, "c.txt"); err == nil {
+		t.Error("analyzeFileBlame() of a file added after the revision succeeded")
+	}
+}
+
+func TestAnalyzeFileBlameWorkingTree(t *testing.T) {
+	repo := newRenameTestRepo(t)
+	stats := gitservice.NewCommitStatsCache(repo.Repository, false)
+	root := repo.Dir
+
+	committed, err := os.ReadFile(filepath.Join(root, "c.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := "a changed line\n" + strings.SplitN(string(committed), "\n", 2)[1]
+	if err := os.WriteFile(filepath.Join(root, "c.txt"), []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	analysis, err := analyzeFileBlame(repo.Repository, root, stats, true, nil, plumbing.ZeroHash, "c.txt")
+	if err != nil {
+		t.Fatalf("analyzeFileBlame() error: %v", err)
+	}
+	if got := blameMessages(analysis.Hunks); got != "1-1 , 2-20 Create a.txt, 21-22 Edit b.txt" {
+		t.Errorf("hunks = %s, want the changed line not committed yet", got)
+	}
+	if got := analysis.Hunks[0]; got.Author != notCommittedAuthor || got.CommitHash != "" {
+		t.Errorf("first hunk = %+v, want it not committed", got)
+	}
+	if got := analysis.AuthorStats; len(got) != 1 || got[0].Lines != 21 {
+		t.Errorf("author stats = %+v, want only the 21 committed lines", got)
+	}
+}
+
+func TestSplitRevision(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "logo@2x.png"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		arg, path, rev string
+	}{
+		{"main.go", "main.go", ""},
+		{"main.go@v1.2.0", "main.go", "v1.2.0"},
+		{"main.go@HEAD@{1}", "main.go", "HEAD@{1}"},
+		{"main.go@@last-tag", "main.go", "@last-tag"},
+		{"internal@abc123", "internal", "abc123"},
+		{"@HEAD~3", ".", "HEAD~3"},
+		{"main.go@", "main.go", ""},
+		// A file in the working tree with an @ in its name isn't split
+		{"logo@2x.png", "logo@2x.png", ""},
+	}
+	for _, tt := range tests {
+		path, rev := splitRevision(dir, tt.arg)
+		if path != tt.path || rev != tt.rev {
+			t.Errorf("splitRevision(%q) = %q, %q, want %q, %q", tt.arg, path, rev, tt.path, tt.rev)
+		}
+	}
+}
+
+func TestAnalyzeFileBlameCRLFAndLatin1(t *testing.T) {
+	repo := gittest.New(t)
+	files := map[string]string{
+		"crlf.txt":   "first line\r\nsecond line\r\n",
+		"latin1.txt": "premi\xe8re ligne\nna\xefve\n",
+	}
+	for name, content := range files {
+		repo.WriteFile(name, content)
+	}
+	head := repo.Commit("Add files")
+	stats := gitservice.NewCommitStatsCache(repo.Repository, false)
+
+	// Lines keep their numbers, without a trailing "\r" and converted to UTF-8, whether
+	// read from the working tree or from a commit
+	for _, rev := range []plumbing.Hash{plumbing.ZeroHash, head} {
+		for name, want := range map[string][]string{
+			"crlf.txt":   {"first line", "second line", ""},
+			"latin1.txt": {"première ligne", "naïve", ""},
+		} {
+			analysis, err := analyzeFileBlame(repo.Repository, repo.Dir, stats, false, nil, rev, name)
+			if err != nil {
+				t.Fatalf("analyzeFileBlame(%s) error: %v", name, err)
+			}
+			var got []string
+			for n := 1; n <= analysis.TotalLines; n++ {
+				got = append(got, analysis.lines.Content(n))
+			}
+			if strings.Join(got, "|") != strings.Join(want, "|") {
+				t.Errorf("%s lines = %q, want %q", name, got, want)
+			}
+		}
+	}
+}
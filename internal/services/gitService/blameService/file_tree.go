@@ -0,0 +1,149 @@
+package blameService
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// fileTreeDir is a directory of the file tree, with the files directly in it and its
+// subdirectories by name
+type fileTreeDir struct {
+	dirs  map[string]*fileTreeDir
+	files []FileItem
+}
+
+// fileTree lists the files under rootPath as a tree: the entries of rootPath, with the
+// entries of each expanded directory right below it, a level deeper. Directories come
+// first, then files, both alphabetically. Below the repository root, a ".." entry leads
+// to the parent directory.
+func fileTree(files []FileItem, rootPath string, expanded map[string]bool) []FileItem {
+	prefix := ""
+	if !isRootPath(rootPath) {
+		prefix = rootPath + "/"
+	}
+
+	root := &fileTreeDir{dirs: map[string]*fileTreeDir{}}
+	for _, file := range files {
+		rel, ok := strings.CutPrefix(file.path, prefix)
+		if !ok {
+			continue
+		}
+		dir := root
+		parts := strings.Split(rel, "/")
+		for _, part := range parts[:len(parts)-1] {
+			if dir.dirs[part] == nil {
+				dir.dirs[part] = &fileTreeDir{dirs: map[string]*fileTreeDir{}}
+			}
+			dir = dir.dirs[part]
+		}
+		file.name = parts[len(parts)-1]
+		dir.files = append(dir.files, file)
+	}
+
+	var items []FileItem
+	if !isRootPath(rootPath) {
+		items = append(items, FileItem{path: parentPath(rootPath), name: "..", isDirectory: true})
+	}
+
+	var walk func(dir *fileTreeDir, path string, depth int)
+	walk = func(dir *fileTreeDir, path string, depth int) {
+		names := make([]string, 0, len(dir.dirs))
+		for name := range dir.dirs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			dirPath := name
+			if path != "" {
+				dirPath = path + "/" + name
+			}
+			open := expanded[dirPath]
+			items = append(items, FileItem{path: dirPath, name: name, isDirectory: true, depth: depth, expanded: open})
+			if open {
+				walk(dir.dirs[name], dirPath, depth+1)
+			}
+		}
+
+		sort.Slice(dir.files, func(i, j int) bool { return dir.files[i].name < dir.files[j].name })
+		for _, file := range dir.files {
+			file.depth = depth
+			items = append(items, file)
+		}
+	}
+	walk(root, strings.TrimSuffix(prefix, "/"), 0)
+
+	return items
+}
+
+// isRootPath reports whether a file browser path is the repository root
+func isRootPath(path string) bool {
+	return path == "" || path == "."
+}
+
+// parentPath returns the directory containing path, "" for the repository root
+func parentPath(path string) string {
+	parent := filepath.ToSlash(filepath.Dir(path))
+	if parent == "." {
+		return ""
+	}
+	return parent
+}
+
+// breadcrumb shows where the file browser is: the repository's name, then each
+// directory down to path
+func breadcrumb(repoName, path string) string {
+	crumbs := []string{repoName}
+	if !isRootPath(path) {
+		crumbs = append(crumbs, strings.Split(path, "/")...)
+	}
+	return strings.Join(crumbs, " › ")
+}
+
+// setFileItems fills the file list with the tree of the files under the current path,
+// titled with its breadcrumb
+func (m *model) setFileItems() {
+	repoName := filepath.Base(m.repoRoot)
+	if m.revLabel != "" {
+		repoName += "@" + m.revLabel
+	}
+	m.fileList.Title = "📁 " + breadcrumb(repoName, m.currentPath)
+
+	tree := fileTree(m.files, m.currentPath, m.expanded)
+	items := make([]list.Item, len(tree))
+	for i, file := range tree {
+		items[i] = file
+	}
+	m.fileList.SetItems(items)
+}
+
+// toggleDirectory expands a collapsed directory of the file tree or collapses an
+// expanded one. On a file, it collapses the directory the file is in.
+func (m *model) toggleDirectory(item FileItem) {
+	if item.name == ".." {
+		return
+	}
+	dir := item.path
+	if !item.isDirectory {
+		if item.depth == 0 {
+			return
+		}
+		dir = parentPath(item.path)
+	}
+	if m.expanded[dir] {
+		delete(m.expanded, dir)
+	} else {
+		m.expanded[dir] = true
+	}
+	m.setFileItems()
+
+	// Keep the toggled directory selected
+	for i, listItem := range m.fileList.Items() {
+		if file, ok := listItem.(FileItem); ok && file.isDirectory && file.path == dir {
+			m.fileList.Select(i)
+			break
+		}
+	}
+}
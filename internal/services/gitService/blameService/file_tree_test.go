@@ -0,0 +1,80 @@
+package blameService
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFileTree(t *testing.T) {
+	var files []FileItem
+	for _, path := range []string{
+		"README.md",
+		"go.mod",
+		"cmd/main.go",
+		"internal/services/gitService/repo.go",
+		"internal/services/gitService/blameService/blame_service.go",
+		"internal/utils/terminal/keymap.go",
+	} {
+		files = append(files, FileItem{path: path})
+	}
+
+	tree := func(rootPath string, expanded ...string) string {
+		open := map[string]bool{}
+		for _, dir := range expanded {
+			open[dir] = true
+		}
+		var titles []string
+		for _, item := range fileTree(files, rootPath, open) {
+			titles = append(titles, item.Title())
+		}
+		return strings.Join(titles, "\n")
+	}
+
+	tests := []struct {
+		name     string
+		rootPath string
+		expanded []string
+		want     []string
+	}{
+		{"collapsed", ".", nil, []string{
+			"▸ 📁 cmd", "▸ 📁 internal", "  📄 README.md", "  📄 go.mod",
+		}},
+		{"expanded", "", []string{"internal", "internal/services", "internal/utils/terminal"}, []string{
+			"▸ 📁 cmd",
+			"▾ 📁 internal",
+			"  ▾ 📁 services",
+			"    ▸ 📁 gitService",
+			"  ▸ 📁 utils",
+			"  📄 README.md",
+			"  📄 go.mod",
+		}},
+		{"subdirectory", "internal/services", []string{"internal/services/gitService"}, []string{
+			"📁 ..",
+			"▾ 📁 gitService",
+			"  ▸ 📁 blameService",
+			"    📄 repo.go",
+		}},
+	}
+	for _, tt := range tests {
+		if got, want := tree(tt.rootPath, tt.expanded...), strings.Join(tt.want, "\n"); got != want {
+			t.Errorf("%s: fileTree() =\n%s\nwant\n%s", tt.name, got, want)
+		}
+	}
+
+	items := fileTree(files, "internal/services", nil)
+	if items[0].path != "internal" {
+		t.Errorf(".. leads to %q, want internal", items[0].path)
+	}
+	if items[1].path != "internal/services/gitService" {
+		t.Errorf("directory path = %q, want internal/services/gitService", items[1].path)
+	}
+}
+
+func TestBreadcrumb(t *testing.T) {
+	if got := breadcrumb("syst", "."); got != "syst" {
+		t.Errorf("breadcrumb() at the root = %q", got)
+	}
+	if got := breadcrumb("syst@v1.0.0", "internal/services"); got != "syst@v1.0.0 › internal › services" {
+		t.Errorf("breadcrumb() = %q", got)
+	}
+}
@@ -0,0 +1,70 @@
+package blameService
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// DefaultIgnoreRevsFile is read from the repository root when no --ignore-revs-file is
+// given. GitHub's blame view reads the same file.
+const DefaultIgnoreRevsFile = ".git-blame-ignore-revs"
+
+// ignoredRevs is the set of commits blame skips, i.e. bulk reformatting commits, so lines
+// are attributed to the last meaningful change instead
+type ignoredRevs map[plumbing.Hash]bool
+
+// loadIgnoreRevs reads the revisions to ignore from path, or from DefaultIgnoreRevsFile
+// at root when path is empty. A missing default file just means nothing is ignored.
+func loadIgnoreRevs(root, path string) (ignoredRevs, error) {
+	explicit := path != ""
+	if !explicit {
+		path = filepath.Join(root, DefaultIgnoreRevsFile)
+	}
+
+	// #nosec G304 - CLI tool reads user-specified files by design
+	file, err := os.Open(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read ignore revs file: %w", err)
+	}
+	defer file.Close()
+
+	revs, err := parseIgnoreRevs(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return revs, nil
+}
+
+// parseIgnoreRevs parses a file in the format of git blame --ignore-revs-file: a full
+// commit hash per line. Blank lines and everything after a # are ignored.
+func parseIgnoreRevs(r io.Reader) (ignoredRevs, error) {
+	revs := make(ignoredRevs)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !plumbing.IsHash(line) {
+			return nil, fmt.Errorf("line %d: %q is not a full commit hash", n, line)
+		}
+		revs[plumbing.NewHash(line)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
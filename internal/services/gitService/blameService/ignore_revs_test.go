@@ -0,0 +1,107 @@
+package blameService
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestParseIgnoreRevs(t *testing.T) {
+	reformat := strings.Repeat("a", 40)
+	prettier := strings.Repeat("b", 40)
+	file := "# Bulk reformatting\n" +
+		reformat + "\n" +
+		"\n" +
+		"  " + prettier + "  # Run prettier\n"
+
+	revs, err := parseIgnoreRevs(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("parseIgnoreRevs() error: %v", err)
+	}
+	if len(revs) != 2 || !revs[plumbing.NewHash(reformat)] || !revs[plumbing.NewHash(prettier)] {
+		t.Errorf("revs = %v, want %s and %s", revs, reformat, prettier)
+	}
+
+	if _, err := parseIgnoreRevs(strings.NewReader(reformat + "\nabc123\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("parseIgnoreRevs() with an abbreviated hash error = %v, want one for line 2", err)
+	}
+}
+
+func TestLoadIgnoreRevs(t *testing.T) {
+	root := t.TempDir()
+
+	// Without the default file nothing is ignored, but a missing --ignore-revs-file fails
+	if revs, err := loadIgnoreRevs(root, ""); err != nil || len(revs) != 0 {
+		t.Errorf("loadIgnoreRevs() without a file = %v, %v, want nothing", revs, err)
+	}
+	if _, err := loadIgnoreRevs(root, filepath.Join(root, "missing")); err == nil {
+		t.Error("loadIgnoreRevs() of a missing --ignore-revs-file succeeded")
+	}
+
+	hash := strings.Repeat("c", 40)
+	if err := os.WriteFile(filepath.Join(root, DefaultIgnoreRevsFile), []byte(hash+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if revs, err := loadIgnoreRevs(root, ""); err != nil || !revs[plumbing.NewHash(hash)] {
+		t.Errorf("loadIgnoreRevs() = %v, %v, want %s from %s", revs, err, hash, DefaultIgnoreRevsFile)
+	}
+}
+
+func TestAnalyzeFileBlameIgnoreRevs(t *testing.T) {
+	repo := newRenameTestRepo(t)
+	stats := gitservice.NewCommitStatsCache(repo.Repository, false)
+
+	rev, err := gitservice.ResolveRef(repo.Repository, "HEAD~1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With "Edit b.txt" ignored, its lines go to the change before it
+	analysis, err := analyzeFileBlame(repo.Repository, "", stats, true, ignoredRevs{rev: true}, rev, "b.txt")
+	if err != nil {
+		t.Fatalf("analyzeFileBlame() error: %v", err)
+	}
+	// "edit 2" replaced "edit 1"
+	if got := blameMessages(analysis.Hunks); got != "1-20 Create a.txt, 21-22 Edit a.txt" {
+		t.Errorf("hunks = %s, want the ignored commit's line on the line it replaced", got)
+	}
+	if history := analysis.FileHistory; !history[0].Ignored || history[1].Ignored {
+		t.Errorf("history = %+v, want only the ignored commit marked", history[:2])
+	}
+}
+
+func TestAnalyzeFileBlameIgnoresOlderCommits(t *testing.T) {
+	repo := gittest.New(t)
+	commit := func(content, message string) plumbing.Hash {
+		t.Helper()
+		return repo.CommitFile("main.go", content, message)
+	}
+
+	commit("one\ntwo\nthree\nfour\n", "Add main.go")
+	reformat := commit("one\n  two\n  three\nfour\nfive\n", "Reformat")
+	commit("one\n  two\n  three\n4\nfive\n", "Edit four")
+	stats := gitservice.NewCommitStatsCache(repo.Repository, false)
+
+	// The reformat, older than HEAD, keeps the lines it only reindented off its name, but
+	// still adds "five"
+	analysis, err := analyzeFileBlame(repo.Repository, repo.Dir, stats, true, ignoredRevs{reformat: true}, plumbing.ZeroHash, "main.go")
+	if err != nil {
+		t.Fatalf("analyzeFileBlame() error: %v", err)
+	}
+	if got, want := blameMessages(analysis.Hunks), "1-3 Add main.go, 4-4 Edit four, 5-6 Reformat"; got != want {
+		t.Errorf("hunks = %s, want %s", got, want)
+	}
+
+	analysis, err = analyzeFileBlame(repo.Repository, repo.Dir, stats, true, nil, plumbing.ZeroHash, "main.go")
+	if err != nil {
+		t.Fatalf("analyzeFileBlame() error: %v", err)
+	}
+	if got, want := blameMessages(analysis.Hunks), "1-1 Add main.go, 2-3 Reformat, 4-4 Edit four, 5-6 Reformat"; got != want {
+		t.Errorf("hunks without ignoring = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,262 @@
+package blameService
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/redjax/syst/internal/services/gitService/diffService"
+)
+
+// notCommittedAuthor is the author of the lines changed in the working tree, like in
+// git blame
+const notCommittedAuthor = "Not Committed Yet"
+
+// fileVersion is a file as it was in a commit of its history
+type fileVersion struct {
+	commit  *object.Commit
+	path    string
+	content string
+}
+
+// parentVersion returns the file as it was in the first parent of v's commit, under the
+// name it had there when following renames. The returned version has no commit, and no
+// content, when v's commit created the file. unchanged is set when the commit didn't
+// change the file, though it may have renamed it; the parent then has v's content.
+func parentVersion(v fileVersion, follow bool) (parent fileVersion, unchanged bool, err error) {
+	file, err := v.commit.File(v.path)
+	if err != nil {
+		return fileVersion{}, false, fmt.Errorf("failed to find %s in %s: %w", v.path, v.commit.Hash.String()[:8], err)
+	}
+	if v.commit.NumParents() == 0 {
+		return fileVersion{}, false, nil
+	}
+
+	commit, err := v.commit.Parent(0)
+	if err != nil {
+		return fileVersion{}, false, fmt.Errorf("failed to get parent of %s: %w", v.commit.Hash, err)
+	}
+	path := v.path
+	parentFile, err := commit.File(path)
+	if err != nil && follow {
+		if change, changeErr := fileChangeInCommit(v.commit, path); changeErr == nil && change != nil && change.From.Name != "" {
+			path = change.From.Name
+			parentFile, err = commit.File(path)
+		}
+	}
+	if err != nil {
+		return fileVersion{}, false, nil
+	}
+
+	parent = fileVersion{commit: commit, path: path}
+	if parentFile.Hash == file.Hash {
+		parent.content = v.content
+		return parent, true, nil
+	}
+	if parent.content, err = parentFile.Contents(); err != nil {
+		return fileVersion{}, false, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	return parent, false, nil
+}
+
+// blameFile finds the commit that added each line of content, the text of filePath in
+// commit or, if worktree is set, in the working tree on top of commit. Like git blame,
+// it follows the first parent of each commit, moving each line up and down as the lines
+// above it change, until a commit's diff of the file adds it. A line added by a commit
+// in ignore is moved to the line it replaced instead, and blamed on the change before.
+// Lines changed in the working tree are blamed on no commit.
+func blameFile(commit *object.Commit, filePath, content string, worktree, follow bool, ignore ignoredRevs) ([]*object.Commit, error) {
+	owners := make([]*object.Commit, diffLineCount(content))
+	// at is where each line still to blame is in the version being looked at, 0 once
+	// it is blamed
+	at := make([]int, len(owners))
+	for i := range at {
+		at[i] = i + 1
+	}
+	pending := len(at)
+
+	v := fileVersion{commit: commit, path: filePath, content: content}
+	if worktree {
+		committed, err := fileContents(commit, filePath)
+		if err != nil {
+			return nil, err
+		}
+		hunks, err := diffService.TextHunks(committed, content, diffService.DefaultContextLines)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s: %w", filePath, err)
+		}
+		pending = traceLines(hunks, at, owners, nil, false)
+		v.content = committed
+	}
+
+	for pending > 0 {
+		parent, unchanged, err := parentVersion(v, follow)
+		if err != nil {
+			return nil, err
+		}
+		if unchanged {
+			v = parent
+			continue
+		}
+
+		hunks, err := diffService.TextHunks(parent.content, v.content, diffService.DefaultContextLines)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s in %s: %w", v.path, v.commit.Hash.String()[:8], err)
+		}
+		// The commit that created the file has to take its lines, ignored or not
+		first := parent.commit == nil || parent.content == ""
+		pending = traceLines(hunks, at, owners, v.commit, ignore[v.commit.Hash] && !first)
+		if first || len(hunks) == 0 {
+			// Without hunks to trace through, like for a binary file, the change takes
+			// every line left
+			for i := range at {
+				if at[i] != 0 {
+					owners[i], at[i] = v.commit, 0
+				}
+			}
+			break
+		}
+		v = parent
+	}
+	return owners, nil
+}
+
+// traceLines moves the lines still to blame back across hunks, the diff of a version of
+// the file against the version before. Lines the diff adds are blamed on owner, or if
+// ignored is set, moved to the line they replaced when there is one. It returns how many
+// lines are left to blame.
+func traceLines(hunks []diffService.Hunk, at []int, owners []*object.Commit, owner *object.Commit, ignored bool) int {
+	pending := 0
+	for i, n := range at {
+		if n == 0 {
+			continue
+		}
+		old, hunk := diffService.OldLine(hunks, n)
+		if hunk != nil && ignored {
+			old = replacedLine(*hunk, n)
+		}
+		if hunk != nil && old == 0 {
+			owners[i], at[i] = owner, 0
+			continue
+		}
+		at[i] = old
+		pending++
+	}
+	return pending
+}
+
+// replacedLine returns the old line the added line numbered n on the new side of hunk
+// replaced: the deleted line at the same place in the change, or the change's last
+// deleted line when it adds more lines than it deletes. It is 0 for a line added
+// without deleting any.
+func replacedLine(hunk diffService.Hunk, n int) int {
+	var deleted []int
+	added := 0
+	for _, line := range hunk.Lines {
+		switch line.Type {
+		case "deleted":
+			if added > 0 {
+				// A new change in the hunk
+				deleted, added = nil, 0
+			}
+			deleted = append(deleted, line.OldLine)
+		case "added":
+			if line.NewLine == n {
+				if len(deleted) == 0 {
+					return 0
+				}
+				return deleted[min(added, len(deleted)-1)]
+			}
+			added++
+		default:
+			deleted, added = nil, 0
+		}
+	}
+	return 0
+}
+
+// diffLineCount is the number of lines of content as a diff counts them, without the
+// empty line after a final newline
+func diffLineCount(content string) int {
+	if content == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimSuffix(content, "\n"), "\n") + 1
+}
+
+// blameHunks groups the owners of consecutive lines into hunks. Lines past the owners,
+// like the empty line after a final newline, go with the last line.
+func blameHunks(owners []*object.Commit, lines int) []BlameHunk {
+	var hunks []BlameHunk
+	for i, owner := range owners {
+		if i > 0 && sameCommit(owner, owners[i-1]) {
+			hunks[len(hunks)-1].Lines++
+			continue
+		}
+		hunk := BlameHunk{StartLine: i + 1, Lines: 1, Author: notCommittedAuthor, CommitDate: time.Now()}
+		if owner != nil {
+			hunk.Author = owner.Author.Name
+			hunk.AuthorEmail = owner.Author.Email
+			hunk.CommitHash = owner.Hash.String()
+			hunk.CommitDate = owner.Author.When
+			hunk.CommitMsg = strings.Split(owner.Message, "\n")[0]
+		}
+		hunks = append(hunks, hunk)
+	}
+	if len(hunks) > 0 && lines > len(owners) {
+		hunks[len(hunks)-1].Lines += lines - len(owners)
+	}
+	return hunks
+}
+
+// sameCommit reports whether a and b are the same commit, or both no commit
+func sameCommit(a, b *object.Commit) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Hash == b.Hash
+}
+
+// authorContributions sums the committed lines of each author of hunks, the authors with
+// the most lines first. totalLines is the file's length, for the percentages.
+func authorContributions(hunks []BlameHunk, totalLines int) []AuthorContribution {
+	byAuthor := make(map[string]*AuthorContribution)
+	commits := make(map[string]map[string]bool)
+	for _, h := range hunks {
+		if h.CommitHash == "" {
+			continue
+		}
+		contrib := byAuthor[h.Author]
+		if contrib == nil {
+			contrib = &AuthorContribution{Author: h.Author, Email: h.AuthorEmail, FirstCommit: h.CommitDate, LastCommit: h.CommitDate}
+			byAuthor[h.Author] = contrib
+			commits[h.Author] = make(map[string]bool)
+		}
+		contrib.Lines += h.Lines
+		if h.CommitDate.Before(contrib.FirstCommit) {
+			contrib.FirstCommit = h.CommitDate
+		}
+		if h.CommitDate.After(contrib.LastCommit) {
+			contrib.LastCommit = h.CommitDate
+		}
+		commits[h.Author][h.CommitHash] = true
+	}
+
+	stats := make([]AuthorContribution, 0, len(byAuthor))
+	for author, contrib := range byAuthor {
+		contrib.Commits = len(commits[author])
+		if totalLines > 0 {
+			contrib.Percentage = float64(contrib.Lines) / float64(totalLines) * 100
+		}
+		stats = append(stats, *contrib)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Lines != stats[j].Lines {
+			return stats[i].Lines > stats[j].Lines
+		}
+		return stats[i].Author < stats[j].Author
+	})
+	return stats
+}
@@ -0,0 +1,256 @@
+package blameService
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/diffService"
+	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// LineOrigin is the change that introduced a line of a blamed file
+type LineOrigin struct {
+	// Line is the line's number in the blamed file, and Content its text
+	Line    int
+	Content string
+	// CommitHash is the commit that added the line, or "" if the line isn't committed yet
+	CommitHash string
+	Author     string
+	Date       time.Time
+	Message    string
+	// Path is the file's name in that commit, and OriginLine the line's number there
+	Path       string
+	OriginLine int
+	// Hunk is the hunk of the commit's diff of the file that added the line
+	Hunk diffService.Hunk
+}
+
+// minOriginLines is the fewest lines of the hunk the line origin view shows
+const minOriginLines = 7
+
+type lineOriginMsg struct {
+	origin LineOrigin
+}
+
+func loadLineOrigin(repo *git.Repository, root string, follow bool, rev plumbing.Hash, filePath string, line int) tea.Cmd {
+	return func() tea.Msg {
+		origin, err := findLineOrigin(repo, root, follow, rev, filePath, line)
+		if err != nil {
+			return errMsg{err}
+		}
+		return lineOriginMsg{origin}
+	}
+}
+
+// findLineOrigin traces line n of filePath, as blamed at rev (the working tree if zero),
+// back to the commit that added it. It follows the first parent of each commit, moving
+// the line up and down as the lines above it change, until a commit's diff of the file
+// has the line as added. A line merged from another branch is traced to the merge.
+func findLineOrigin(repo *git.Repository, root string, follow bool, rev plumbing.Hash, filePath string, n int) (LineOrigin, error) {
+	commit, err := revisionCommit(repo, rev)
+	if err != nil {
+		return LineOrigin{}, err
+	}
+
+	content, err := readBlameFile(commit, root, filePath, !rev.IsZero())
+	if err != nil {
+		return LineOrigin{}, err
+	}
+	if gitservice.IsBinary(content) {
+		return LineOrigin{}, fmt.Errorf("%s is a binary file, so its lines can't be traced", filePath)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if n < 1 || n > len(lines) || content == "" {
+		return LineOrigin{}, fmt.Errorf("%s has no line %d", filePath, n)
+	}
+	origin := LineOrigin{Line: n, Content: gitservice.DisplayText(lines[n-1])}
+
+	v := fileVersion{commit: commit, path: filePath, content: content}
+	if rev.IsZero() {
+		// Lines changed in the working tree aren't in any commit yet
+		committed, err := fileContents(commit, filePath)
+		if err != nil {
+			return LineOrigin{}, err
+		}
+		hunks, err := diffService.TextHunks(committed, content, diffService.DefaultContextLines)
+		if err != nil {
+			return LineOrigin{}, fmt.Errorf("failed to diff %s: %w", filePath, err)
+		}
+		old, hunk := diffService.OldLine(hunks, n)
+		if hunk != nil {
+			origin.Path, origin.OriginLine, origin.Hunk = filePath, n, *hunk
+			return origin, nil
+		}
+		v.content, n = committed, old
+	}
+
+	for {
+		parent, unchanged, err := parentVersion(v, follow)
+		if err != nil {
+			return LineOrigin{}, err
+		}
+		if unchanged {
+			v = parent
+			continue
+		}
+
+		hunks, err := diffService.TextHunks(parent.content, v.content, diffService.DefaultContextLines)
+		if err != nil {
+			return LineOrigin{}, fmt.Errorf("failed to diff %s in %s: %w", v.path, v.commit.Hash.String()[:8], err)
+		}
+		old, hunk := diffService.OldLine(hunks, n)
+		if hunk != nil {
+			origin.CommitHash = v.commit.Hash.String()
+			origin.Author = v.commit.Author.Name
+			origin.Date = v.commit.Author.When
+			origin.Message = strings.Split(v.commit.Message, "\n")[0]
+			origin.Path, origin.OriginLine, origin.Hunk = v.path, n, *hunk
+			return origin, nil
+		}
+		if parent.commit == nil || parent.content == "" {
+			return LineOrigin{}, fmt.Errorf("failed to trace line %d of %s past %s", origin.Line, filePath, v.commit.Hash.String()[:8])
+		}
+
+		v, n = parent, old
+	}
+}
+
+// fileContents reads path from commit's tree, or returns "" if it isn't there
+func fileContents(commit *object.Commit, path string) (string, error) {
+	file, err := commit.File(path)
+	if err != nil {
+		return "", nil
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	return content, nil
+}
+
+func (m model) renderLineOriginView() string {
+	var content strings.Builder
+
+	// Header
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.Current.Primary).
+		MarginBottom(1)
+
+	title := fmt.Sprintf("🧭 Origin of %s:%d", m.selectedFile, m.origin.Line)
+	content.WriteString(headerStyle.Render(title))
+	content.WriteString("\n")
+
+	// The change that added the line
+	infoStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(theme.Current.Subtle).
+		Padding(1, 2).
+		MarginBottom(1)
+
+	var info strings.Builder
+	if m.origin.CommitHash == "" {
+		info.WriteString("Not committed yet\n")
+	} else {
+		info.WriteString(fmt.Sprintf("Commit:  %s\n", m.origin.CommitHash))
+		info.WriteString(fmt.Sprintf("Author:  %s\n", m.origin.Author))
+		info.WriteString(fmt.Sprintf("Date:    %s\n", gitservice.DetailDate(m.origin.Date, "2006-01-02 15:04:05")))
+		info.WriteString(fmt.Sprintf("Message: %s\n", m.origin.Message))
+	}
+	info.WriteString(fmt.Sprintf("Line:    %s:%d", m.origin.Path, m.origin.OriginLine))
+	content.WriteString(infoStyle.Render(info.String()))
+	content.WriteString("\n")
+
+	// The hunk, with the traced line highlighted
+	diffStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(theme.Current.Subtle).
+		Padding(1, 2).
+		MarginBottom(1)
+
+	// A long hunk, like the one of the commit that created the file, is cut down to the
+	// lines around the traced one
+	lines := m.origin.Hunk.Lines
+	start, end := hunkWindow(lines, m.origin.OriginLine, max(minOriginLines, m.tuiHelper.GetHeight()-20))
+	mutedStyle := lipgloss.NewStyle().Foreground(theme.Current.Muted)
+
+	var diff strings.Builder
+	diff.WriteString(lipgloss.NewStyle().Foreground(theme.Current.Info).Bold(true).Render(m.origin.Hunk.Header))
+	diff.WriteString("\n")
+	if start > 0 {
+		diff.WriteString(mutedStyle.Render(fmt.Sprintf("... %d lines above", start)))
+		diff.WriteString("\n")
+	}
+	for _, line := range lines[start:end] {
+		var lineStyle lipgloss.Style
+		switch line.Type {
+		case "added":
+			lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Success)
+		case "deleted":
+			lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Error)
+		default:
+			lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Muted)
+		}
+		if line.Type == "added" && line.NewLine == m.origin.OriginLine {
+			lineStyle = lineStyle.Bold(true).Reverse(true)
+		}
+
+		numbers := fmt.Sprintf("%4s %4s ", lineNumber(line.OldLine, line.Type != "added"), lineNumber(line.NewLine, line.Type != "deleted"))
+		diff.WriteString(mutedStyle.Render(numbers))
+		diff.WriteString(lineStyle.Render(gitservice.DisplayText(line.Content)))
+		diff.WriteString("\n")
+	}
+	if end < len(lines) {
+		diff.WriteString(mutedStyle.Render(fmt.Sprintf("... %d lines below", len(lines)-end)))
+		diff.WriteString("\n")
+	}
+	content.WriteString(diffStyle.Render(diff.String()))
+	content.WriteString("\n")
+
+	// Help
+	helpStyle := lipgloss.NewStyle().
+		Foreground(theme.Current.Muted).
+		MarginTop(1)
+
+	help := []string{"1: files", "2: blame", "3: history", "4: authors"}
+	if m.origin.CommitHash != "" {
+		help = append(help, terminal.Help(m.keys.Select, "commit details"), terminal.Help(m.keys.Copy, "copy hash"))
+	}
+	help = append(help, terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit"))
+	content.WriteString(helpStyle.Render(terminal.HelpLine(help...)))
+
+	return content.String()
+}
+
+// hunkWindow returns the range of at most height lines of a hunk to show, centered on
+// the added line numbered target on the new side
+func hunkWindow(lines []diffService.DiffLine, target, height int) (start, end int) {
+	if len(lines) <= height {
+		return 0, len(lines)
+	}
+	for i, line := range lines {
+		if line.Type == "added" && line.NewLine == target {
+			start = i - height/2
+			break
+		}
+	}
+	start = max(0, min(start, len(lines)-height))
+	return start, start + height
+}
+
+// lineNumber formats a line number of one side of a hunk, blank for a line not on it
+func lineNumber(n int, onSide bool) string {
+	if !onSide {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
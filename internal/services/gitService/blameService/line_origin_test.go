@@ -0,0 +1,89 @@
+package blameService
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/diffService"
+)
+
+func TestFindLineOrigin(t *testing.T) {
+	repo := newRenameTestRepo(t)
+	root := repo.Dir
+
+	// c.txt has 20 lines from "Create a.txt", then one from "Edit b.txt"
+	tests := []struct {
+		name    string
+		follow  bool
+		line    int
+		message string
+		path    string
+	}{
+		{"created", true, 1, "Create a.txt", "a.txt"},
+		{"edited between renames", true, 21, "Edit b.txt", "b.txt"},
+		{"not following renames", false, 21, "Rename b.txt to c.txt", "c.txt"},
+	}
+	for _, tt := range tests {
+		origin, err := findLineOrigin(repo.Repository, root, tt.follow, plumbing.ZeroHash, "c.txt", tt.line)
+		if err != nil {
+			t.Fatalf("%s: findLineOrigin() error: %v", tt.name, err)
+		}
+		if origin.Message != tt.message || origin.Path != tt.path || origin.OriginLine != tt.line {
+			t.Errorf("%s: line %d added by %q in %s:%d, want %q in %s:%d", tt.name, tt.line,
+				origin.Message, origin.Path, origin.OriginLine, tt.message, tt.path, tt.line)
+		}
+	}
+
+	// A line inserted in the working tree isn't committed, and moves the lines below it
+	content, err := os.ReadFile(filepath.Join(root, "c.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "c.txt"), []byte("new\n"+string(content)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	origin, err := findLineOrigin(repo.Repository, root, true, plumbing.ZeroHash, "c.txt", 1)
+	if err != nil {
+		t.Fatalf("findLineOrigin() error: %v", err)
+	}
+	if origin.CommitHash != "" || origin.Content != "new" {
+		t.Errorf("line 1 = %q from %s, want \"new\" not committed yet", origin.Content, origin.CommitHash)
+	}
+
+	origin, err = findLineOrigin(repo.Repository, root, true, plumbing.ZeroHash, "c.txt", 22)
+	if err != nil {
+		t.Fatalf("findLineOrigin() error: %v", err)
+	}
+	if origin.Message != "Edit b.txt" || origin.OriginLine != 21 {
+		t.Errorf("line 22 added by %q at line %d, want \"Edit b.txt\" at line 21", origin.Message, origin.OriginLine)
+	}
+	if added := origin.Hunk.Lines[len(origin.Hunk.Lines)-1]; added.Type != "added" || !strings.Contains(added.Content, "edit 2") {
+		t.Errorf("hunk ends with %s %q, want the added \"edit 2\"", added.Type, added.Content)
+	}
+
+	if _, err := findLineOrigin(repo.Repository, root, true, plumbing.ZeroHash, "c.txt", 100); err == nil {
+		t.Error("findLineOrigin() of a line past the end of the file succeeded")
+	}
+}
+
+func TestHunkWindow(t *testing.T) {
+	var lines []diffService.DiffLine
+	for n := 1; n <= 10; n++ {
+		lines = append(lines, diffService.DiffLine{Type: "added", NewLine: n})
+	}
+
+	for _, tt := range []struct{ target, height, start, end int }{
+		{5, 20, 0, 10},
+		{5, 4, 2, 6},
+		{1, 4, 0, 4},
+		{10, 4, 6, 10},
+	} {
+		if start, end := hunkWindow(lines, tt.target, tt.height); start != tt.start || end != tt.end {
+			t.Errorf("hunkWindow(%d, %d) = %d-%d, want %d-%d", tt.target, tt.height, start, end, tt.start, tt.end)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package gitservice
+
+import "strings"
+
+// DefaultBotPatterns match common automation accounts. Patterns are
+// case-insensitive and only support '*' as a wildcard, so "[bot]" is literal.
+var DefaultBotPatterns = []string{
+	"*[bot]",
+	"*-bot",
+	"dependabot*",
+	"renovate*",
+	"github-actions*",
+}
+
+// BotFilter identifies commits made by automation accounts.
+// A nil *BotFilter matches nothing.
+type BotFilter struct {
+	patterns []string
+}
+
+// NewBotFilter returns a filter for patterns, or DefaultBotPatterns if none are given
+func NewBotFilter(patterns []string) *BotFilter {
+	if len(patterns) == 0 {
+		patterns = DefaultBotPatterns
+	}
+
+	lowered := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		lowered[i] = strings.ToLower(pattern)
+	}
+
+	return &BotFilter{patterns: lowered}
+}
+
+// IsBot reports whether an author name or email matches any bot pattern
+func (f *BotFilter) IsBot(name, email string) bool {
+	if f == nil {
+		return false
+	}
+
+	name = strings.ToLower(name)
+	email = strings.ToLower(email)
+	for _, pattern := range f.patterns {
+		if matchWildcard(pattern, name) || matchWildcard(pattern, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard matches s against pattern where '*' matches any run of characters
+func matchWildcard(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+		if idx == -1 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
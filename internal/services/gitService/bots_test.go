@@ -0,0 +1,43 @@
+package gitservice
+
+import "testing"
+
+func TestBotFilterDefaults(t *testing.T) {
+	filter := NewBotFilter(nil)
+
+	tests := []struct {
+		name, email string
+		want        bool
+	}{
+		{"dependabot[bot]", "49699333+dependabot[bot]@users.noreply.github.com", true},
+		{"github-actions[bot]", "41898282+github-actions[bot]@users.noreply.github.com", true},
+		{"release-bot", "release@example.com", true},
+		{"Renovate Bot", "bot@renovateapp.com", true},
+		{"Jane Doe", "jane@example.com", false},
+		{"Abbot", "abbot@example.com", false},
+	}
+	for _, tt := range tests {
+		if got := filter.IsBot(tt.name, tt.email); got != tt.want {
+			t.Errorf("IsBot(%q, %q) = %v, want %v", tt.name, tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestBotFilterCustomPatterns(t *testing.T) {
+	filter := NewBotFilter([]string{"ci@*", "jenkins"})
+
+	if !filter.IsBot("Build", "ci@example.com") {
+		t.Error("expected email pattern to match")
+	}
+	if !filter.IsBot("Jenkins", "jenkins@example.com") {
+		t.Error("expected case-insensitive name match")
+	}
+	if filter.IsBot("dependabot[bot]", "dependabot@example.com") {
+		t.Error("custom patterns should replace the defaults")
+	}
+
+	var nilFilter *BotFilter
+	if nilFilter.IsBot("dependabot[bot]", "") {
+		t.Error("nil filter should match nothing")
+	}
+}
@@ -72,6 +72,16 @@ func getBranchesToDelete(mainBranch, currentBranch string) ([]string, error) {
 	return toDelete, nil
 }
 
+// branchHash returns the commit local branch name points at
+func branchHash(name string) (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--verify", "refs/heads/"+name).Output()
+	if err != nil {
+		return "", fmt.Errorf("could not resolve branch %s: %w", name, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
 func deleteBranch(name string, force bool) error {
 	args := []string{"branch"}
 
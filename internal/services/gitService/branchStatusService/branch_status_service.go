@@ -0,0 +1,253 @@
+package branchStatusService
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// BranchStatusOptions controls which repository is checked and how the status is printed
+type BranchStatusOptions struct {
+	// RepoPath is the repository to check; empty means the current directory
+	RepoPath string
+	// Base is the branch every branch is compared with; empty means the repository's
+	// default branch
+	Base string
+	// JSON prints the status as JSON instead of a table
+	JSON bool
+}
+
+// BranchStatus is where a local branch stands against its upstream and the base branch
+type BranchStatus struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	// Upstream is the branch this one tracks, i.e. "origin/main"; empty without one
+	Upstream string `json:"upstream,omitempty"`
+	// UpstreamGone is set when the upstream is configured but no longer exists
+	UpstreamGone   bool      `json:"upstream_gone,omitempty"`
+	AheadUpstream  int       `json:"ahead_upstream"`
+	BehindUpstream int       `json:"behind_upstream"`
+	AheadBase      int       `json:"ahead_base"`
+	BehindBase     int       `json:"behind_base"`
+	LastCommit     time.Time `json:"last_commit"`
+	// Merged is set when every commit on the branch is in the base branch
+	Merged bool `json:"merged"`
+}
+
+// BranchStatusReport is the status of every local branch, most recently committed first
+// (by name when their last commits are at the same time)
+type BranchStatusReport struct {
+	Base     string         `json:"base"`
+	Branches []BranchStatus `json:"branches"`
+}
+
+// Branches this many commits behind the base are drawn as far behind
+const (
+	behindWarning = 10
+	behindDanger  = 50
+)
+
+var (
+	currentStyle = lipgloss.NewStyle().Bold(true)
+	mergedStyle  = lipgloss.NewStyle().Foreground(theme.Current.Muted)
+	warningStyle = lipgloss.NewStyle().Foreground(theme.Current.Warning)
+	dangerStyle  = lipgloss.NewStyle().Foreground(theme.Current.Error)
+)
+
+// RunBranchStatus prints every local branch with its ahead/behind counts against its
+// upstream and against opts.Base, its last commit and whether it is merged into the
+// base: the state of all branches at once instead of one comparison at a time.
+func RunBranchStatus(opts BranchStatusOptions) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+	if _, err := gitservice.Head(repo); err != nil {
+		return err
+	}
+
+	base := opts.Base
+	if base == "" {
+		if base, err = gitservice.DefaultBranch(repo); err != nil {
+			return err
+		}
+	}
+
+	report, err := analyzeBranches(repo, base)
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	if len(report.Branches) == 0 {
+		fmt.Println("No local branches.")
+		return nil
+	}
+	printBranchStatus(os.Stdout, report, time.Now())
+	return nil
+}
+
+// analyzeBranches compares every local branch with its upstream and with base
+func analyzeBranches(repo *git.Repository, base string) (BranchStatusReport, error) {
+	baseHash, err := gitservice.ResolveBranch(repo, base)
+	if err != nil {
+		return BranchStatusReport{}, err
+	}
+	// Walked once and shared by every branch
+	baseAncestors, err := gitservice.Ancestors(repo, baseHash)
+	if err != nil {
+		return BranchStatusReport{}, err
+	}
+
+	var current string
+	if head, err := repo.Head(); err == nil && head.Name().IsBranch() {
+		current = head.Name().Short()
+	}
+
+	refs, err := repo.Branches()
+	if err != nil {
+		return BranchStatusReport{}, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	report := BranchStatusReport{Base: base}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+
+		commit, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to read tip of %s: %w", name, err)
+		}
+		ancestors, err := gitservice.Ancestors(repo, ref.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to walk %s: %w", name, err)
+		}
+
+		status := BranchStatus{
+			Name:       name,
+			Current:    name == current,
+			LastCommit: commit.Committer.When,
+		}
+		status.AheadBase, status.BehindBase = gitservice.CountDivergence(baseAncestors, ancestors)
+		status.Merged = status.AheadBase == 0
+
+		if upstream, err := gitservice.BranchUpstream(repo, name); err == nil {
+			status.Upstream = upstream
+			upstreamHash, err := gitservice.ResolveRef(repo, upstream)
+			if err != nil {
+				status.UpstreamGone = true
+			} else if status.AheadUpstream, status.BehindUpstream, err = gitservice.AheadBehind(repo, upstreamHash, ref.Hash()); err != nil {
+				return fmt.Errorf("failed to compare %s with %s: %w", name, upstream, err)
+			}
+		}
+
+		report.Branches = append(report.Branches, status)
+		return nil
+	})
+	if err != nil {
+		return BranchStatusReport{}, err
+	}
+
+	sort.Slice(report.Branches, func(i, j int) bool {
+		a, b := report.Branches[i], report.Branches[j]
+		if !a.LastCommit.Equal(b.LastCommit) {
+			return a.LastCommit.After(b.LastCommit)
+		}
+		return a.Name < b.Name
+	})
+	return report, nil
+}
+
+// printBranchStatus writes a table of the branches, coloring the ones far behind the base
+func printBranchStatus(w io.Writer, report BranchStatusReport, now time.Time) {
+	// The rows are aligned before they are colored, as tabwriter would count the escapes
+	var table bytes.Buffer
+	writer := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "  BRANCH\tUPSTREAM\t↑↓ UPSTREAM\t↑↓ %s\tLAST COMMIT\tMERGED\n", report.Base)
+	for _, b := range report.Branches {
+		marker := " "
+		if b.Current {
+			marker = "*"
+		}
+		fmt.Fprintf(writer, "%s %s\t%s\t%s\t+%d -%d\t%s (%d days ago)\t%s\n",
+			marker,
+			b.Name,
+			upstreamLabel(b),
+			upstreamDivergence(b),
+			b.AheadBase,
+			b.BehindBase,
+			b.LastCommit.Format("2006-01-02"),
+			int(now.Sub(b.LastCommit).Hours()/24),
+			mergedLabel(b, report.Base),
+		)
+	}
+	// #nosec G104 - Flushing to a buffer can't fail
+	writer.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+	fmt.Fprintln(w, lines[0])
+	for i, line := range lines[1:] {
+		fmt.Fprintln(w, rowStyle(report.Branches[i], report.Base).Render(line))
+	}
+}
+
+func upstreamLabel(b BranchStatus) string {
+	switch {
+	case b.Upstream == "":
+		return "-"
+	case b.UpstreamGone:
+		return b.Upstream + " (gone)"
+	}
+	return b.Upstream
+}
+
+func upstreamDivergence(b BranchStatus) string {
+	if b.Upstream == "" || b.UpstreamGone {
+		return "-"
+	}
+	return fmt.Sprintf("+%d -%d", b.AheadUpstream, b.BehindUpstream)
+}
+
+func mergedLabel(b BranchStatus, base string) string {
+	switch {
+	case b.Name == base:
+		return "base"
+	case b.Merged:
+		return "yes"
+	}
+	return "no"
+}
+
+// rowStyle colors a branch by how far behind the base it is: red from behindDanger
+// commits, yellow from behindWarning. Merged branches other than the base are dimmed.
+func rowStyle(b BranchStatus, base string) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	switch {
+	case b.BehindBase >= behindDanger:
+		style = dangerStyle
+	case b.BehindBase >= behindWarning:
+		style = warningStyle
+	case b.Merged && b.Name != base:
+		style = mergedStyle
+	}
+	if b.Current {
+		style = style.Inherit(currentStyle)
+	}
+	return style
+}
@@ -0,0 +1,88 @@
+package branchStatusService
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+var now = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestAnalyzeBranches(t *testing.T) {
+	// "feature" has 1 commit of its own and is 12 commits behind master, "merged" is 12
+	// behind with nothing of its own, and "tracking" tracks feature and is 1 behind it
+	repo := gittest.New(t)
+	repo.When = now.AddDate(0, -1, 0)
+	commit := func(message string) {
+		t.Helper()
+		repo.CommitFile("file.txt", message, message)
+	}
+
+	commit("Initial commit")
+	repo.Checkout("merged", true)
+	repo.Checkout("tracking", true)
+	repo.Checkout("feature", true)
+	commit("Feature work")
+
+	repo.Checkout("master", false)
+	for i := range 12 {
+		commit("Master work " + string(rune('a'+i)))
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Branches["tracking"] = &config.Branch{Name: "tracking", Remote: ".", Merge: plumbing.NewBranchReferenceName("feature")}
+	cfg.Branches["merged"] = &config.Branch{Name: "merged", Remote: "origin", Merge: plumbing.NewBranchReferenceName("merged")}
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := analyzeBranches(repo.Repository, "master")
+	if err != nil {
+		t.Fatalf("analyzeBranches() error: %v", err)
+	}
+
+	byName := make(map[string]BranchStatus)
+	var order []string
+	for _, b := range report.Branches {
+		byName[b.Name] = b
+		order = append(order, b.Name)
+	}
+	if got := strings.Join(order, " "); got != "master feature merged tracking" {
+		t.Errorf("branches = %s, want the most recently committed first", got)
+	}
+
+	if master := byName["master"]; !master.Current || master.AheadBase != 0 || master.BehindBase != 0 {
+		t.Errorf("master = %+v, want the current branch, level with itself", master)
+	}
+	if feature := byName["feature"]; feature.Merged || feature.AheadBase != 1 || feature.BehindBase != 12 || feature.Upstream != "" {
+		t.Errorf("feature = %+v, want unmerged, +1 -12 and no upstream", feature)
+	}
+	if merged := byName["merged"]; !merged.Merged || merged.Upstream != "origin/merged" || !merged.UpstreamGone {
+		t.Errorf("merged = %+v, want merged with a gone upstream", merged)
+	}
+	if tracking := byName["tracking"]; tracking.Upstream != "feature" || tracking.AheadUpstream != 0 || tracking.BehindUpstream != 1 {
+		t.Errorf("tracking = %+v, want 1 behind feature", tracking)
+	}
+
+	var out bytes.Buffer
+	printBranchStatus(&out, report, now)
+	for _, want := range []string{
+		"* master",
+		"feature   -",
+		"origin/merged (gone)",
+		"+0 -1",
+		"+1 -12",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("table is missing %q:\n%s", want, out.String())
+		}
+	}
+}
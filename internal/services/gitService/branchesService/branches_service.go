@@ -13,7 +13,9 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type ViewMode int
@@ -52,6 +54,7 @@ type model struct {
 	commitList     list.Model
 	viewMode       ViewMode
 	tuiHelper      *terminal.ResponsiveTUIHelper
+	keys           terminal.KeyMap
 	err            error
 	loading        bool
 	directBranch   string
@@ -111,30 +114,30 @@ type errMsg struct {
 
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#25A065")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1)
 
 	subtitleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			MarginBottom(1)
 
 	infoStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			MarginTop(1)
 
 	statsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575"))
+			Foreground(theme.Current.Success)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87"))
+			Foreground(theme.Current.Error)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 )
 
@@ -205,9 +208,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch m.viewMode {
 		case BranchListView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c", "esc"))):
+			case key.Matches(msg, m.keys.Quit, m.keys.Back):
 				return m, tea.Quit
-			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			case key.Matches(msg, m.keys.Select):
 				if selected := m.branchList.SelectedItem(); selected != nil {
 					branchItem := selected.(branchItem)
 					m.selectedBranch = &branchItem.branch
@@ -222,12 +225,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case BranchDetailView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+			case key.Matches(msg, m.keys.Quit):
 				return m, tea.Quit
-			case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "backspace"))):
+			case key.Matches(msg, m.keys.Back) || msg.String() == "backspace":
 				m.viewMode = BranchListView
 				return m, nil
-			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			case key.Matches(msg, m.keys.Select):
 				if selected := m.commitList.SelectedItem(); selected != nil {
 					commitItem := selected.(commitItem)
 					m.selectedCommit = &commitItem.commit
@@ -242,9 +245,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case CommitDetailView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+			case key.Matches(msg, m.keys.Quit):
 				return m, tea.Quit
-			case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "backspace"))):
+			case key.Matches(msg, m.keys.Back) || msg.String() == "backspace":
 				m.viewMode = BranchDetailView
 				return m, nil
 			}
@@ -285,7 +288,8 @@ func (m model) renderBranchList() string {
 	listContent := m.branchList.View()
 	sections = append(sections, listContent)
 
-	help := helpStyle.Render("↑/↓: navigate • enter: select branch • q: quit")
+	help := helpStyle.Render(terminal.HelpLine(terminal.HelpKey(m.keys.Up, m.keys.Down)+": navigate",
+		terminal.Help(m.keys.Select, "select branch"), terminal.Help(m.keys.Quit, "quit")))
 	sections = append(sections, help)
 
 	return m.tuiHelper.CenterContent(strings.Join(sections, "\n"))
@@ -316,7 +320,8 @@ func (m model) renderBranchDetail() string {
 		sections = append(sections, "  No commits found")
 	}
 
-	help := helpStyle.Render("↑/↓: navigate commits • enter: view commit • esc: back to branches • q: quit")
+	help := helpStyle.Render(terminal.HelpLine(terminal.HelpKey(m.keys.Up, m.keys.Down)+": navigate commits",
+		terminal.Help(m.keys.Select, "view commit"), terminal.Help(m.keys.Back, "back to branches"), terminal.Help(m.keys.Quit, "quit")))
 	sections = append(sections, help)
 
 	return m.tuiHelper.CenterContent(strings.Join(sections, "\n"))
@@ -370,7 +375,7 @@ func (m model) renderCommitDetail() string {
 	info := m.renderCommitInfo()
 	sections = append(sections, infoStyle.Render(info))
 
-	help := helpStyle.Render("esc: back to commits • q: quit")
+	help := helpStyle.Render(terminal.HelpLine(terminal.Help(m.keys.Back, "back to commits"), terminal.Help(m.keys.Quit, "quit")))
 	sections = append(sections, help)
 
 	return m.tuiHelper.CenterContent(strings.Join(sections, "\n"))
@@ -408,9 +413,9 @@ func loadCommitsForBranch(branchName string) tea.Cmd {
 }
 
 func gatherBranchData() ([]BranchInfo, error) {
-	repo, err := git.PlainOpen(".")
+	repo, err := gitservice.OpenRepo("")
 	if err != nil {
-		return nil, fmt.Errorf("failed to open repository: %w", err)
+		return nil, err
 	}
 
 	// Get current branch
@@ -533,9 +538,9 @@ func gatherBranchData() ([]BranchInfo, error) {
 }
 
 func gatherCommitsForBranch(branchName string) ([]CommitInfo, error) {
-	repo, err := git.PlainOpen(".")
+	repo, err := gitservice.OpenRepo("")
 	if err != nil {
-		return nil, fmt.Errorf("failed to open repository: %w", err)
+		return nil, err
 	}
 
 	// Get branch reference
@@ -617,12 +622,16 @@ func calculateAheadBehind(repo *git.Repository, baseBranch, compareBranch string
 
 // RunBranchesExplorer starts the interactive branch explorer TUI
 func RunBranchesExplorer(directBranch string) error {
+	if err := terminal.RequireTTY(""); err != nil {
+		return err
+	}
+
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("#01FAC6")).
-		BorderLeftForeground(lipgloss.Color("#01FAC6"))
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
 	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("#DDDDDD"))
+		Foreground(theme.Current.Text)
 
 	branchList := list.New([]list.Item{}, delegate, 0, 0)
 	branchList.Title = "Branches"
@@ -641,7 +650,10 @@ func RunBranchesExplorer(directBranch string) error {
 		loading:      true,
 		directBranch: directBranch,
 		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.Keys(),
 	}
+	m.keys.ApplyToList(&m.branchList)
+	m.keys.ApplyToList(&m.commitList)
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	_, err := p.Run()
@@ -0,0 +1,354 @@
+package changelogService
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// ChangelogOptions controls changelog generation
+type ChangelogOptions struct {
+	// RepoPath is the repository to read; empty means the current directory
+	RepoPath string
+	// Format is "markdown" (default) or "json"
+	Format string
+	// Output is the file to write; empty writes to stdout
+	Output string
+	// Copy also copies the changelog to the clipboard, see gitservice.CopyReport
+	Copy bool
+	// GroupByScope groups the entries of each section under their commit scope
+	GroupByScope bool
+}
+
+// Entry is a single changelog line parsed from a Conventional Commit
+type Entry struct {
+	Type        string `json:"type"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Hash        string `json:"hash"`
+	Breaking    bool   `json:"breaking"`
+	// BreakingNote is the text of a BREAKING CHANGE footer, if any
+	BreakingNote string `json:"breaking_note,omitempty"`
+}
+
+// Section is a Keep a Changelog section like "Added" or "Fixed"
+type Section struct {
+	Title   string  `json:"title"`
+	Entries []Entry `json:"entries"`
+}
+
+// Changelog is the release generated from the commits between two refs
+type Changelog struct {
+	Version  string    `json:"version"`
+	Date     time.Time `json:"date"`
+	FromRef  string    `json:"from_ref,omitempty"`
+	ToRef    string    `json:"to_ref"`
+	Breaking []Entry   `json:"breaking"`
+	Sections []Section `json:"sections"`
+}
+
+const unreleased = "Unreleased"
+
+// Sections in the order they are written, following Keep a Changelog
+const (
+	sectionAdded       = "Added"
+	sectionChanged     = "Changed"
+	sectionFixed       = "Fixed"
+	sectionSecurity    = "Security"
+	sectionMaintenance = "Maintenance"
+	sectionOther       = "Other"
+)
+
+var sectionOrder = []string{
+	sectionAdded,
+	sectionChanged,
+	sectionFixed,
+	sectionSecurity,
+	sectionMaintenance,
+	sectionOther,
+}
+
+// typeSections maps Conventional Commit types to changelog sections
+var typeSections = map[string]string{
+	"feat":     sectionAdded,
+	"perf":     sectionChanged,
+	"refactor": sectionChanged,
+	"revert":   sectionChanged,
+	"fix":      sectionFixed,
+	"security": sectionSecurity,
+	"build":    sectionMaintenance,
+	"chore":    sectionMaintenance,
+	"ci":       sectionMaintenance,
+	"docs":     sectionMaintenance,
+	"style":    sectionMaintenance,
+	"test":     sectionMaintenance,
+}
+
+// RunChangelog writes a changelog for the commits in fromRef..toRef. An empty fromRef
+// means the most recent tag before toRef (or the whole history if there is none), and
+// an empty toRef means HEAD.
+func RunChangelog(fromRef, toRef string, opts ChangelogOptions) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+
+	changelog, err := buildChangelog(repo, fromRef, toRef)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := writeChangelog(&buf, changelog, opts); err != nil {
+		return err
+	}
+
+	if opts.Output == "" {
+		if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	} else {
+		// #nosec G306 - Changelogs are meant to be committed and shared
+		if err := os.WriteFile(opts.Output, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to write changelog: %w", err)
+		}
+		fmt.Printf("Changelog written to %s\n", opts.Output)
+	}
+
+	if opts.Copy {
+		return gitservice.CopyReport(buf.Bytes())
+	}
+	return nil
+}
+
+// buildChangelog collects and groups the Conventional Commits in fromRef..toRef.
+func buildChangelog(repo *git.Repository, fromRef, toRef string) (Changelog, error) {
+	if toRef == "" {
+		toRef = "HEAD"
+	}
+
+	toHash, err := gitservice.ResolveRef(repo, toRef)
+	if err != nil {
+		return Changelog{}, fmt.Errorf("failed to resolve %s: %w", toRef, err)
+	}
+	toCommit, err := repo.CommitObject(toHash)
+	if err != nil {
+		return Changelog{}, fmt.Errorf("failed to get commit %s: %w", toRef, err)
+	}
+
+	tags, err := gitservice.TagsByCommit(repo)
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	if fromRef == "" {
+		fromRef = previousTag(repo, toHash, tags)
+	}
+
+	// Commits reachable from fromRef are already in an earlier release
+	exclude := make(map[plumbing.Hash]bool)
+	if fromRef != "" {
+		fromHash, err := gitservice.ResolveRef(repo, fromRef)
+		if err != nil {
+			return Changelog{}, fmt.Errorf("failed to resolve %s: %w", fromRef, err)
+		}
+		if err := walk(repo, fromHash, func(c *object.Commit) {
+			exclude[c.Hash] = true
+		}); err != nil {
+			return Changelog{}, err
+		}
+	}
+
+	changelog := Changelog{
+		Version: releaseVersion(tags[toHash]),
+		Date:    toCommit.Committer.When,
+		FromRef: fromRef,
+		ToRef:   toRef,
+	}
+
+	entries := make(map[string][]Entry)
+	err = walk(repo, toHash, func(c *object.Commit) {
+		// Merge commits only repeat the changes of the commits they merge
+		if exclude[c.Hash] || c.NumParents() > 1 {
+			return
+		}
+
+		entry, ok := parseCommit(c.Message)
+		entry.Hash = c.Hash.String()[:7]
+
+		section := sectionOther
+		if ok {
+			if s, known := typeSections[entry.Type]; known {
+				section = s
+			}
+		}
+		entries[section] = append(entries[section], entry)
+
+		if entry.Breaking {
+			changelog.Breaking = append(changelog.Breaking, entry)
+		}
+	})
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	for _, title := range sectionOrder {
+		if len(entries[title]) > 0 {
+			changelog.Sections = append(changelog.Sections, Section{Title: title, Entries: entries[title]})
+		}
+	}
+
+	return changelog, nil
+}
+
+// walk calls fn for every commit reachable from hash, newest first.
+func walk(repo *git.Repository, hash plumbing.Hash, fn func(*object.Commit)) error {
+	iter, err := repo.Log(&git.LogOptions{From: hash, Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return fmt.Errorf("failed to get log: %w", err)
+	}
+
+	return iter.ForEach(func(c *object.Commit) error {
+		fn(c)
+		return nil
+	})
+}
+
+// previousTag returns the most recent tag reachable from hash, excluding a tag on hash
+// itself, or "" if there is none.
+func previousTag(repo *git.Repository, hash plumbing.Hash, tags map[plumbing.Hash]string) string {
+	var found string
+
+	// #nosec G104 - An unreadable history just means no previous tag is found
+	walk(repo, hash, func(c *object.Commit) {
+		if found == "" && c.Hash != hash {
+			found = tags[c.Hash]
+		}
+	})
+
+	return found
+}
+
+// releaseVersion names the release after the tag at its last commit, or "Unreleased".
+func releaseVersion(tag string) string {
+	if tag != "" {
+		return tag
+	}
+	return unreleased
+}
+
+// parseCommit parses a Conventional Commit message into a changelog entry. It reports
+// false if the message is not a Conventional Commit, in which case the entry holds the subject.
+func parseCommit(message string) (Entry, bool) {
+	commit, ok := gitservice.ParseConventionalCommit(message)
+	return Entry{
+		Type:         commit.Type,
+		Scope:        commit.Scope,
+		Description:  commit.Description,
+		Breaking:     commit.Breaking,
+		BreakingNote: commit.BreakingNote,
+	}, ok
+}
+
+// writeChangelog writes the changelog to w in opts.Format.
+func writeChangelog(w io.Writer, changelog Changelog, opts ChangelogOptions) error {
+	switch strings.ToLower(opts.Format) {
+	case "", "markdown", "md":
+		return writeMarkdown(w, changelog, opts.GroupByScope)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(changelog)
+	default:
+		return fmt.Errorf("unsupported changelog format: %s", opts.Format)
+	}
+}
+
+// writeMarkdown writes the changelog in Keep a Changelog format.
+func writeMarkdown(w io.Writer, changelog Changelog, groupByScope bool) error {
+	var b strings.Builder
+
+	b.WriteString("# Changelog\n\n")
+	b.WriteString("All notable changes to this project are documented in this file.\n")
+	b.WriteString("The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).\n\n")
+
+	if changelog.Version == unreleased {
+		fmt.Fprintf(&b, "## [%s]\n", unreleased)
+	} else {
+		fmt.Fprintf(&b, "## [%s] - %s\n", changelog.Version, changelog.Date.Format("2006-01-02"))
+	}
+
+	if len(changelog.Breaking) == 0 && len(changelog.Sections) == 0 {
+		b.WriteString("\nNo changes.\n")
+	}
+
+	if len(changelog.Breaking) > 0 {
+		b.WriteString("\n### Breaking Changes\n\n")
+		for _, entry := range changelog.Breaking {
+			note := entry.BreakingNote
+			if note == "" {
+				note = entry.Description
+			}
+			b.WriteString(markdownEntry(entry, note, true))
+		}
+	}
+
+	for _, section := range changelog.Sections {
+		fmt.Fprintf(&b, "\n### %s\n\n", section.Title)
+
+		if !groupByScope {
+			for _, entry := range section.Entries {
+				b.WriteString(markdownEntry(entry, entry.Description, true))
+			}
+			continue
+		}
+
+		// Unscoped entries first, then one heading per scope
+		scopes := make(map[string][]Entry)
+		for _, entry := range section.Entries {
+			scopes[entry.Scope] = append(scopes[entry.Scope], entry)
+		}
+		for _, entry := range scopes[""] {
+			b.WriteString(markdownEntry(entry, entry.Description, false))
+		}
+		for _, scope := range sortedScopes(scopes) {
+			fmt.Fprintf(&b, "\n#### %s\n\n", scope)
+			for _, entry := range scopes[scope] {
+				b.WriteString(markdownEntry(entry, entry.Description, false))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEntry formats one list item, prefixed with its bold scope if showScope is set.
+func markdownEntry(entry Entry, text string, showScope bool) string {
+	if showScope && entry.Scope != "" {
+		return fmt.Sprintf("- **%s:** %s (%s)\n", entry.Scope, text, entry.Hash)
+	}
+	return fmt.Sprintf("- %s (%s)\n", text, entry.Hash)
+}
+
+// sortedScopes returns the non-empty scope names in alphabetical order.
+func sortedScopes(scopes map[string][]Entry) []string {
+	var names []string
+	for scope := range scopes {
+		if scope != "" {
+			names = append(names, scope)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,139 @@
+package changelogService
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestParseCommit(t *testing.T) {
+	tests := []struct {
+		message string
+		want    Entry
+		wantOK  bool
+	}{
+		{"feat: add changelog command", Entry{Type: "feat", Description: "add changelog command"}, true},
+		{"fix(git): handle detached HEAD\n\nDetails", Entry{Type: "fix", Scope: "git", Description: "handle detached HEAD"}, true},
+		{"feat(api)!: drop v1 endpoints", Entry{Type: "feat", Scope: "api", Description: "drop v1 endpoints", Breaking: true}, true},
+		{
+			"refactor: rename config keys\n\nBREAKING CHANGE: SYST_FOO is now SYST_BAR",
+			Entry{Type: "refactor", Description: "rename config keys", Breaking: true, BreakingNote: "SYST_FOO is now SYST_BAR"},
+			true,
+		},
+		{"Update README", Entry{Description: "Update README"}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseCommit(tt.message)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseCommit(%q) = %+v, %v, want %+v, %v", tt.message, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// newChangelogTestRepo creates a repository with a v1.0.0 tag followed by unreleased commits.
+func newChangelogTestRepo(t *testing.T) *gittest.Repo {
+	t.Helper()
+
+	repo := gittest.New(t)
+	commit := func(message string) {
+		t.Helper()
+		repo.CommitFile("file.txt", message, message)
+	}
+
+	commit("feat: initial release")
+	repo.Tag("v1.0.0")
+
+	commit("feat(cli): add --output flag")
+	commit("fix(git): resolve annotated tags")
+	commit("chore: bump dependencies")
+	commit("feat(api)!: remove legacy endpoint")
+	commit("Tidy up")
+
+	return repo
+}
+
+func TestBuildChangelogSinceLastTag(t *testing.T) {
+	repo := newChangelogTestRepo(t)
+
+	changelog, err := buildChangelog(repo.Repository, "", "")
+	if err != nil {
+		t.Fatalf("buildChangelog() error: %v", err)
+	}
+
+	if changelog.FromRef != "v1.0.0" {
+		t.Errorf("FromRef = %q, want v1.0.0", changelog.FromRef)
+	}
+	if changelog.Version != unreleased {
+		t.Errorf("Version = %q, want %q", changelog.Version, unreleased)
+	}
+
+	var titles []string
+	for _, s := range changelog.Sections {
+		titles = append(titles, s.Title)
+	}
+	if got, want := strings.Join(titles, ","), "Added,Fixed,Maintenance,Other"; got != want {
+		t.Errorf("sections = %s, want %s", got, want)
+	}
+
+	// The tagged commit belongs to the previous release
+	if added := changelog.Sections[0].Entries; len(added) != 2 {
+		t.Errorf("Added has %d entries, want 2", len(added))
+	}
+	if len(changelog.Breaking) != 1 || changelog.Breaking[0].Scope != "api" {
+		t.Errorf("Breaking = %+v, want the api entry", changelog.Breaking)
+	}
+}
+
+func TestBuildChangelogForTag(t *testing.T) {
+	repo := newChangelogTestRepo(t)
+
+	changelog, err := buildChangelog(repo.Repository, "", "v1.0.0")
+	if err != nil {
+		t.Fatalf("buildChangelog() error: %v", err)
+	}
+	if changelog.Version != "v1.0.0" {
+		t.Errorf("Version = %q, want v1.0.0", changelog.Version)
+	}
+	if changelog.FromRef != "" || len(changelog.Sections) != 1 {
+		t.Errorf("changelog for first tag = %+v, want whole history in one section", changelog)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	changelog := Changelog{
+		Version: "v1.1.0",
+		Date:    time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC),
+		Sections: []Section{{
+			Title: sectionAdded,
+			Entries: []Entry{
+				{Type: "feat", Scope: "cli", Description: "add --output flag", Hash: "abc1234"},
+				{Type: "feat", Description: "support json", Hash: "def5678"},
+			},
+		}},
+	}
+
+	var flat bytes.Buffer
+	if err := writeChangelog(&flat, changelog, ChangelogOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## [v1.1.0] - 2026-02-03", "### Added", "- **cli:** add --output flag (abc1234)", "- support json (def5678)"} {
+		if !strings.Contains(flat.String(), want) {
+			t.Errorf("markdown missing %q:\n%s", want, flat.String())
+		}
+	}
+
+	var grouped bytes.Buffer
+	if err := writeChangelog(&grouped, changelog, ChangelogOptions{GroupByScope: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(grouped.String(), "#### cli\n\n- add --output flag (abc1234)") {
+		t.Errorf("grouped markdown missing scope heading:\n%s", grouped.String())
+	}
+
+	if err := writeChangelog(&bytes.Buffer{}, changelog, ChangelogOptions{Format: "xml"}); err == nil {
+		t.Error("writeChangelog() with unknown format should fail")
+	}
+}
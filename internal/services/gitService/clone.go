@@ -2,15 +2,34 @@ package gitservice
 
 import (
 	"fmt"
+	"strconv"
 )
 
 func CloneNoCheckout(url, output string) error {
+	return CloneNoCheckoutWithDepth(url, output, "", 0)
+}
+
+// CloneNoCheckoutWithDepth clones without checking out files. When depth > 0 the
+// clone is shallow, and branch (if set) is fetched instead of the remote's default.
+func CloneNoCheckoutWithDepth(url, output, branch string, depth int) error {
 	if !CheckGitInstalled() {
 		fmt.Printf("Error: git is not installed")
 		return ErrGitNotInstalled
 	}
 
-	cmd := execCommand("git", "clone", "--no-checkout", url, output)
+	cmd := execCommand("git", CloneNoCheckoutArgs(url, output, branch, depth)...)
 
 	return cmd.Run()
 }
+
+// CloneNoCheckoutArgs returns the git arguments CloneNoCheckoutWithDepth runs
+func CloneNoCheckoutArgs(url, output, branch string, depth int) []string {
+	args := []string{"clone", "--no-checkout"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+		if branch != "" {
+			args = append(args, "--branch", branch)
+		}
+	}
+	return append(args, url, output)
+}
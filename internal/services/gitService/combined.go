@@ -0,0 +1,261 @@
+package gitservice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	utildiff "github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// combinedContext is how many unchanged lines are kept around changed ones in a
+// combined diff
+const combinedContext = 3
+
+// CombinedLine is a line of a combined diff. Markers has one column per parent of the
+// merge: '+' if the line was added relative to that parent, '-' if it was removed from
+// it, and ' ' if that parent has it unchanged.
+type CombinedLine struct {
+	Markers string
+	Content string
+	// LineNum is the line's number in the merge's version of the file; 0 for removed lines
+	LineNum int
+	// Skipped is set on a separator standing in for that many unchanged lines
+	Skipped int
+}
+
+// Added reports whether the line isn't in at least one parent's version of the file.
+func (l CombinedLine) Added() bool { return strings.Contains(l.Markers, "+") }
+
+// Removed reports whether the line was removed from at least one parent's version.
+func (l CombinedLine) Removed() bool { return strings.Contains(l.Markers, "-") }
+
+// String formats the line like git show -c, i.e. "+ line" for a line added relative to
+// the first of two parents.
+func (l CombinedLine) String() string {
+	if l.Skipped > 0 {
+		return fmt.Sprintf("@@ %d unchanged lines @@", l.Skipped)
+	}
+	return l.Markers + l.Content
+}
+
+// CombinedFile is a file in a combined diff
+type CombinedFile struct {
+	Path   string
+	Binary bool
+	Lines  []CombinedLine
+}
+
+// Counts returns how many lines were added and removed relative to any parent.
+func (f CombinedFile) Counts() (added, removed int) {
+	for _, line := range f.Lines {
+		if line.Added() {
+			added++
+		}
+		if line.Removed() {
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// CombinedDiff diffs a merge commit against all of its parents at once, like git show -c.
+// Only files that differ from every parent are listed: a file taken unchanged from one
+// side has nothing to show, so what remains is where the merge combined or resolved
+// changes. Commits with fewer than two parents have no combined diff.
+func CombinedDiff(commit *object.Commit) ([]CombinedFile, error) {
+	if commit.NumParents() < 2 {
+		return nil, nil
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+	var parentTrees []*object.Tree
+	err = commit.Parents().ForEach(func(parent *object.Commit) error {
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to get tree of %s: %w", parent.Hash.String()[:8], err)
+		}
+		parentTrees = append(parentTrees, parentTree)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A file that differs from every parent differs from the first one too
+	changes, err := object.DiffTree(parentTrees[0], tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff trees: %w", err)
+	}
+
+	var files []CombinedFile
+	for _, change := range changes {
+		path := change.To.Name
+		if path == "" {
+			path = change.From.Name
+		}
+
+		result := fileHash(tree, path)
+		differsFromAll := true
+		for _, parentTree := range parentTrees[1:] {
+			if fileHash(parentTree, path) == result {
+				differsFromAll = false
+				break
+			}
+		}
+		if !differsFromAll {
+			continue
+		}
+
+		content := fileContent(tree, path)
+		parents := make([]string, len(parentTrees))
+		binary := IsBinary(content)
+		for i, parentTree := range parentTrees {
+			parents[i] = fileContent(parentTree, path)
+			binary = binary || IsBinary(parents[i])
+		}
+
+		file := CombinedFile{Path: path, Binary: binary}
+		if !binary {
+			for i := range parents {
+				parents[i] = DisplayText(parents[i])
+			}
+			file.Lines = combineLines(parents, DisplayText(content))
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// combineLines marks each line of result against every parent's version, places the
+// lines removed from the parents before the line they preceded, and trims the unchanged
+// lines more than combinedContext away from a change.
+func combineLines(parents []string, result string) []CombinedLine {
+	resultLines := splitDiffLines(result)
+	marks := make([][]byte, len(resultLines))
+	for j := range marks {
+		marks[j] = []byte(strings.Repeat(" ", len(parents)))
+	}
+	removed := make([][]CombinedLine, len(resultLines)+1) // Removed before result line j
+
+	for i, parent := range parents {
+		j := 0
+		for _, d := range utildiff.Do(parent, result) {
+			lines := splitDiffLines(d.Text)
+			switch d.Type {
+			case diffmatchpatch.DiffEqual:
+				j += len(lines)
+			case diffmatchpatch.DiffInsert:
+				for k := range lines {
+					marks[j+k][i] = '+'
+				}
+				j += len(lines)
+			case diffmatchpatch.DiffDelete:
+				removed[j] = markRemoved(removed[j], lines, i, len(parents))
+			}
+		}
+	}
+
+	var all []CombinedLine
+	for j := 0; j <= len(resultLines); j++ {
+		all = append(all, removed[j]...)
+		if j < len(resultLines) {
+			all = append(all, CombinedLine{Markers: string(marks[j]), Content: resultLines[j], LineNum: j + 1})
+		}
+	}
+	return trimUnchanged(all)
+}
+
+// markRemoved records lines removed from parent i. A line already removed from another
+// parent at the same place is shared, so a line removed from both parents shows once
+// as "--", like git does.
+func markRemoved(group []CombinedLine, lines []string, i, parents int) []CombinedLine {
+	next := 0
+	for _, line := range lines {
+		shared := false
+		for k := next; k < len(group); k++ {
+			if group[k].Content == line && group[k].Markers[i] == ' ' {
+				markers := []byte(group[k].Markers)
+				markers[i] = '-'
+				group[k].Markers = string(markers)
+				next = k + 1
+				shared = true
+				break
+			}
+		}
+		if !shared {
+			markers := []byte(strings.Repeat(" ", parents))
+			markers[i] = '-'
+			group = append(group, CombinedLine{Markers: string(markers), Content: line})
+			next = len(group)
+		}
+	}
+	return group
+}
+
+// trimUnchanged replaces runs of unchanged lines further than combinedContext from a
+// change with a separator
+func trimUnchanged(lines []CombinedLine) []CombinedLine {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.Added() || line.Removed() {
+			for k := max(0, i-combinedContext); k <= min(len(lines)-1, i+combinedContext); k++ {
+				keep[k] = true
+			}
+		}
+	}
+
+	var trimmed []CombinedLine
+	skipped := 0
+	for i, line := range lines {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		if skipped > 0 {
+			trimmed = append(trimmed, CombinedLine{Skipped: skipped})
+			skipped = 0
+		}
+		trimmed = append(trimmed, line)
+	}
+	if skipped > 0 && len(trimmed) > 0 {
+		trimmed = append(trimmed, CombinedLine{Skipped: skipped})
+	}
+	return trimmed
+}
+
+// splitDiffLines splits text into lines without their newlines; empty text has none
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// fileHash returns the blob hash of path in tree, or the zero hash if it isn't there
+func fileHash(tree *object.Tree, path string) plumbing.Hash {
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		return plumbing.ZeroHash
+	}
+	return entry.Hash
+}
+
+// fileContent returns the content of path in tree, or "" if it isn't there
+func fileContent(tree *object.Tree, path string) string {
+	file, err := tree.File(path)
+	if err != nil {
+		return ""
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return ""
+	}
+	return content
+}
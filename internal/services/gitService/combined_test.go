@@ -0,0 +1,86 @@
+package gitservice
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestCombinedDiff(t *testing.T) {
+	// A merge of two branches that each changed a different line of f.txt, where the merge
+	// also adds a line of its own and takes other.txt from the second branch
+	repo := gittest.New(t)
+	commit := func(files map[string]string, parents ...plumbing.Hash) plumbing.Hash {
+		t.Helper()
+		for name, content := range files {
+			repo.WriteFile(name, content)
+		}
+		return repo.CommitWith("commit", &git.CommitOptions{Parents: parents})
+	}
+
+	base := commit(map[string]string{"f.txt": "1\n2\n3\n4\n5\n", "other.txt": "x\n"})
+	a := commit(map[string]string{"f.txt": "1\nA\n3\n4\n5\n"})
+	if err := repo.Worktree.Reset(&git.ResetOptions{Commit: base, Mode: git.HardReset}); err != nil {
+		t.Fatal(err)
+	}
+	b := commit(map[string]string{"f.txt": "1\n2\n3\nB\n5\n", "other.txt": "y\n"})
+	merge := commit(map[string]string{"f.txt": "1\nA\n3\nB\n5\nresolved\n"}, a, b)
+
+	mergeCommit, err := repo.CommitObject(merge)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := CombinedDiff(mergeCommit)
+	if err != nil {
+		t.Fatalf("CombinedDiff() error: %v", err)
+	}
+	// other.txt is the same as in the second parent, so it isn't listed
+	if len(files) != 1 || files[0].Path != "f.txt" {
+		t.Fatalf("CombinedDiff() = %+v, want only f.txt", files)
+	}
+
+	want := []string{"  1", " -2", " +A", "  3", "- 4", "+ B", "  5", "++resolved"}
+	lines := files[0].Lines
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %q, want %q", lines, want)
+	}
+	for i := range want {
+		if lines[i].String() != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i].String(), want[i])
+		}
+	}
+	if lines[7].LineNum != 6 || lines[1].LineNum != 0 {
+		t.Errorf("line numbers = %d and %d, want 6 for the added line and 0 for the removed one", lines[7].LineNum, lines[1].LineNum)
+	}
+	if added, removed := files[0].Counts(); added != 3 || removed != 2 {
+		t.Errorf("Counts() = +%d -%d, want +3 -2", added, removed)
+	}
+
+	// Commits with one parent have no combined diff
+	parent, err := mergeCommit.Parent(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files, err := CombinedDiff(parent); err != nil || files != nil {
+		t.Errorf("CombinedDiff() of a non-merge = %v, %v, want nothing", files, err)
+	}
+}
+
+func TestCombineLinesTrimsUnchanged(t *testing.T) {
+	parent := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	result := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n"
+
+	lines := combineLines([]string{parent, parent}, result)
+	want := []string{"@@ 7 unchanged lines @@", "  8", "  9", "  10", "++11"}
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %q, want %q", lines, want)
+	}
+	for i := range want {
+		if lines[i].String() != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i].String(), want[i])
+		}
+	}
+}
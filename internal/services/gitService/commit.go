@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 type CommitInfo struct {
@@ -14,6 +16,15 @@ type CommitInfo struct {
 	Message string
 }
 
+// ParentHashes returns the full hashes of commit's parents, first parent first
+func ParentHashes(commit *object.Commit) []string {
+	var parents []string
+	for _, parent := range commit.ParentHashes {
+		parents = append(parents, parent.String())
+	}
+	return parents
+}
+
 func getCommitCount(branch string) (int, error) {
 	cmd := exec.Command("git", "rev-list", "--count", branch)
 	out, err := cmd.Output()
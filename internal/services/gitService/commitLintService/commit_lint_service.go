@@ -0,0 +1,338 @@
+package commitLintService
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// DefaultMaxSubjectLength is the subject-length limit used when none is configured
+const DefaultMaxSubjectLength = 72
+
+// CommitLintOptions controls which commits are linted and which rules apply
+type CommitLintOptions struct {
+	// RepoPath is the repository to lint; empty means the current directory
+	RepoPath string
+	// Range limits linting to commits in "A..B" (reachable from B but not A)
+	Range string
+	// MaxCount stops after this many commits; 0 lints all of them
+	MaxCount int
+	// MaxSubjectLength is the longest allowed subject line
+	MaxSubjectLength int
+	// Conventional enables the conventional rule, requiring a "type(scope): " prefix
+	Conventional bool
+	// Disabled lists rule names to skip
+	Disabled []string
+	// IncludeMerges lints merge commits, whose messages are usually generated
+	IncludeMerges bool
+}
+
+// Violation is a rule broken by a commit
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// CommitResult holds the violations found in one commit
+type CommitResult struct {
+	Hash       string
+	Subject    string
+	Violations []Violation
+}
+
+// rule is a single lint check. check returns a description of the problem, or "" if
+// the message passes.
+type rule struct {
+	name        string
+	description string
+	// enabled reports whether an opt-in rule is switched on; nil means always on
+	enabled func(opts CommitLintOptions) bool
+	check   func(msg message, opts CommitLintOptions) string
+}
+
+// message is a commit message split into the parts rules look at
+type message struct {
+	subject string
+	// lines are all lines of the message, including the subject
+	lines []string
+}
+
+// rules are applied to every commit in order
+var rules = []rule{
+	{
+		name:        "subject-length",
+		description: "Subject line is at most --max-subject-length characters",
+		check: func(msg message, opts CommitLintOptions) string {
+			if n := len([]rune(msg.subject)); n > opts.MaxSubjectLength {
+				return fmt.Sprintf("subject is %d characters (max %d)", n, opts.MaxSubjectLength)
+			}
+			return ""
+		},
+	},
+	{
+		name:        "imperative-mood",
+		description: "Subject is written in the imperative mood (\"Add\", not \"Added\" or \"Adds\")",
+		check: func(msg message, opts CommitLintOptions) string {
+			word := firstWord(msg.subject)
+			if !isImperative(word) {
+				return fmt.Sprintf("subject should use the imperative mood, not %q", word)
+			}
+			return ""
+		},
+	},
+	{
+		name:        "body-separator",
+		description: "A blank line separates the subject from the body",
+		check: func(msg message, opts CommitLintOptions) string {
+			if len(msg.lines) > 1 && strings.TrimSpace(msg.lines[1]) != "" {
+				return "missing blank line between subject and body"
+			}
+			return ""
+		},
+	},
+	{
+		name:        "conventional",
+		description: "Subject has a Conventional Commit prefix, like \"feat(scope): \" (enable with --conventional)",
+		enabled:     func(opts CommitLintOptions) bool { return opts.Conventional },
+		check: func(msg message, opts CommitLintOptions) string {
+			if _, ok := gitservice.ParseConventionalCommit(msg.subject); !ok {
+				return "subject is missing a Conventional Commit prefix (type(scope): description)"
+			}
+			return ""
+		},
+	},
+}
+
+// RulesHelp lists the rules and what they check, for command help output.
+func RulesHelp() string {
+	var b strings.Builder
+	for _, r := range rules {
+		fmt.Fprintf(&b, "  %-16s %s\n", r.name, r.description)
+	}
+	return b.String()
+}
+
+// RunCommitLint lints the commits reachable from ref (HEAD if empty), or those in
+// opts.Range, and prints any violations. It reports whether any commit failed.
+func RunCommitLint(ref string, opts CommitLintOptions) (bool, error) {
+	results, checked, err := lintCommits(ref, opts)
+	if err != nil {
+		return false, err
+	}
+
+	printResults(os.Stdout, results, checked)
+	return len(results) > 0, nil
+}
+
+// lintCommits returns the commits with violations and the number of commits checked.
+func lintCommits(ref string, opts CommitLintOptions) ([]CommitResult, int, error) {
+	if opts.MaxSubjectLength <= 0 {
+		opts.MaxSubjectLength = DefaultMaxSubjectLength
+	}
+	if err := validateRules(opts.Disabled); err != nil {
+		return nil, 0, err
+	}
+
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	from, exclude, err := resolveRange(repo, ref, opts.Range)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cIter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get log: %w", err)
+	}
+
+	var results []CommitResult
+	var checked int
+
+	err = cIter.ForEach(func(c *object.Commit) error {
+		if exclude[c.Hash] {
+			return nil
+		}
+		if c.NumParents() > 1 && !opts.IncludeMerges {
+			return nil
+		}
+		if opts.MaxCount > 0 && checked >= opts.MaxCount {
+			return storer.ErrStop
+		}
+		checked++
+
+		if violations := lintMessage(c.Message, opts); len(violations) > 0 {
+			results = append(results, CommitResult{
+				Hash:       c.Hash.String(),
+				Subject:    strings.Split(c.Message, "\n")[0],
+				Violations: violations,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, checked, nil
+}
+
+// resolveRange returns the commit to walk from and the set of commits to skip. A range
+// "A..B" walks from B and skips everything reachable from A; an empty side means HEAD.
+func resolveRange(repo *git.Repository, ref, commitRange string) (plumbing.Hash, map[plumbing.Hash]bool, error) {
+	exclude := make(map[plumbing.Hash]bool)
+
+	if commitRange == "" {
+		if ref == "" {
+			ref = "HEAD"
+		}
+		hash, err := gitservice.ResolveRef(repo, ref)
+		if err != nil {
+			return plumbing.ZeroHash, nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+		}
+		return hash, exclude, nil
+	}
+
+	base, tip, ok := strings.Cut(commitRange, "..")
+	if !ok {
+		return plumbing.ZeroHash, nil, fmt.Errorf("invalid range %q, expected A..B", commitRange)
+	}
+	if base == "" {
+		base = "HEAD"
+	}
+	if tip == "" {
+		tip = "HEAD"
+	}
+
+	tipHash, err := gitservice.ResolveRef(repo, tip)
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("failed to resolve %s: %w", tip, err)
+	}
+	baseHash, err := gitservice.ResolveRef(repo, base)
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("failed to resolve %s: %w", base, err)
+	}
+
+	baseIter, err := repo.Log(&git.LogOptions{From: baseHash})
+	if err != nil {
+		return plumbing.ZeroHash, nil, fmt.Errorf("failed to get log: %w", err)
+	}
+	err = baseIter.ForEach(func(c *object.Commit) error {
+		exclude[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+
+	return tipHash, exclude, nil
+}
+
+// validateRules returns an error if names contains an unknown rule.
+func validateRules(names []string) error {
+	for _, name := range names {
+		known := false
+		for _, r := range rules {
+			if r.name == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown commit lint rule: %s", name)
+		}
+	}
+	return nil
+}
+
+// lintMessage applies every enabled rule to a commit message.
+func lintMessage(raw string, opts CommitLintOptions) []Violation {
+	lines := strings.Split(strings.TrimRight(raw, "\n"), "\n")
+	msg := message{subject: strings.TrimSpace(lines[0]), lines: lines}
+
+	var violations []Violation
+	for _, r := range rules {
+		if !ruleEnabled(r, opts) {
+			continue
+		}
+		if problem := r.check(msg, opts); problem != "" {
+			violations = append(violations, Violation{Rule: r.name, Message: problem})
+		}
+	}
+	return violations
+}
+
+func ruleEnabled(r rule, opts CommitLintOptions) bool {
+	for _, name := range opts.Disabled {
+		if name == r.name {
+			return false
+		}
+	}
+	return r.enabled == nil || r.enabled(opts)
+}
+
+// firstWord returns the first word of a subject, skipping a Conventional Commit prefix.
+func firstWord(subject string) string {
+	if commit, ok := gitservice.ParseConventionalCommit(subject); ok {
+		subject = commit.Description
+	}
+
+	fields := strings.Fields(subject)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], ".,:;!?\"'`")
+}
+
+// nonImperativeExceptions end like past tense, gerund or third person forms but are
+// fine at the start of a subject.
+var nonImperativeExceptions = map[string]bool{
+	"bring": true, "embed": true, "feed": true, "need": true, "proceed": true,
+	"seed": true, "shed": true, "speed": true, "string": true, "succeed": true,
+	"exceed": true, "bless": true, "address": true, "access": true, "process": true,
+	"pass": true, "bypass": true, "discuss": true, "express": true, "focus": true,
+	"redress": true, "suppress": true, "compress": true, "dismiss": true, "miss": true,
+}
+
+// isImperative is a heuristic: words ending in "ed", "ing" or a third person "s"
+// ("Added", "Adding", "Adds") are not in the imperative mood.
+func isImperative(word string) bool {
+	w := strings.ToLower(word)
+	if len(w) < 4 || nonImperativeExceptions[w] {
+		return true
+	}
+
+	switch {
+	case strings.HasSuffix(w, "ed"), strings.HasSuffix(w, "ing"):
+		return false
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is"):
+		return false
+	}
+	return true
+}
+
+// printResults writes a human-readable lint report.
+func printResults(w io.Writer, results []CommitResult, checked int) {
+	for _, result := range results {
+		fmt.Fprintf(w, "%s %s\n", result.Hash[:7], result.Subject)
+		for _, v := range result.Violations {
+			fmt.Fprintf(w, "  ✗ %s: %s\n", v.Rule, v.Message)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(w, "✅ %d commits checked, no problems found\n", checked)
+		return
+	}
+	fmt.Fprintf(w, "❌ %d of %d commits have problems\n", len(results), checked)
+}
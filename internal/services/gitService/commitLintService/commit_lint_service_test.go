@@ -0,0 +1,117 @@
+package commitLintService
+
+import (
+	"testing"
+
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestIsImperative(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"Add", true},
+		{"Fix", true},
+		{"Refactor", true},
+		{"Address", true},
+		{"Embed", true},
+		{"Added", false},
+		{"Adding", false},
+		{"Adds", false},
+		{"Fixes", false},
+	}
+	for _, tt := range tests {
+		if got := isImperative(tt.word); got != tt.want {
+			t.Errorf("isImperative(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestLintMessage(t *testing.T) {
+	long := "Add a subject line that goes on and on well past the default limit of characters"
+
+	tests := []struct {
+		name    string
+		message string
+		opts    CommitLintOptions
+		want    []string
+	}{
+		{"clean", "Add lint command\n\nExplain why.\n", CommitLintOptions{}, nil},
+		{"too long", long, CommitLintOptions{}, []string{"subject-length"}},
+		{"custom length", "Add lint command", CommitLintOptions{MaxSubjectLength: 10}, []string{"subject-length"}},
+		{"past tense", "Added lint command", CommitLintOptions{}, []string{"imperative-mood"}},
+		{"no separator", "Add lint command\nExplain why.", CommitLintOptions{}, []string{"body-separator"}},
+		{"conventional off", "Add lint command", CommitLintOptions{}, nil},
+		{"conventional missing", "Add lint command", CommitLintOptions{Conventional: true}, []string{"conventional"}},
+		{"conventional ok", "feat(git): add lint command", CommitLintOptions{Conventional: true}, nil},
+		{"conventional past tense", "fix: fixed lint command", CommitLintOptions{Conventional: true}, []string{"imperative-mood"}},
+		{"disabled", "Added lint command", CommitLintOptions{Disabled: []string{"imperative-mood"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opts.MaxSubjectLength == 0 {
+				tt.opts.MaxSubjectLength = DefaultMaxSubjectLength
+			}
+
+			var got []string
+			for _, v := range lintMessage(tt.message, tt.opts) {
+				got = append(got, v.Rule)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("violations = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("violations = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLintCommits(t *testing.T) {
+	// A "base" tag followed by two more commits
+	repo := gittest.New(t)
+	commit := func(message string) {
+		t.Helper()
+		repo.CommitFile("file.txt", message, message)
+	}
+
+	commit("Initial commit")
+	repo.Tag("base")
+
+	commit("Added feature")
+	commit("Fix bug")
+
+	results, checked, err := lintCommits("", CommitLintOptions{RepoPath: repo.Dir})
+	if err != nil {
+		t.Fatalf("lintCommits() error: %v", err)
+	}
+	if checked != 3 || len(results) != 1 || results[0].Subject != "Added feature" {
+		t.Errorf("lintCommits() = %+v, %d, want one failing commit of 3", results, checked)
+	}
+
+	_, checked, err = lintCommits("", CommitLintOptions{RepoPath: repo.Dir, Range: "base.."})
+	if err != nil {
+		t.Fatalf("lintCommits() with range error: %v", err)
+	}
+	if checked != 2 {
+		t.Errorf("commits checked in base..HEAD = %d, want 2", checked)
+	}
+
+	_, checked, err = lintCommits("", CommitLintOptions{RepoPath: repo.Dir, MaxCount: 1})
+	if err != nil {
+		t.Fatalf("lintCommits() with max count error: %v", err)
+	}
+	if checked != 1 {
+		t.Errorf("commits checked with MaxCount 1 = %d, want 1", checked)
+	}
+
+	if _, _, err := lintCommits("", CommitLintOptions{RepoPath: repo.Dir, Range: "base"}); err == nil {
+		t.Error("lintCommits() with a malformed range should fail")
+	}
+	if _, _, err := lintCommits("", CommitLintOptions{RepoPath: repo.Dir, Disabled: []string{"nope"}}); err == nil {
+		t.Error("lintCommits() with an unknown rule should fail")
+	}
+}
@@ -2,6 +2,8 @@ package compareService
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -10,11 +12,13 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	"github.com/redjax/syst/internal/utils/terminal"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type ViewMode int
@@ -42,6 +46,18 @@ type ComparisonAnalysis struct {
 
 	// Statistics
 	Stats ComparisonStats
+
+	// Refs has every compared ref. Comparisons of three or more refs only set Refs, the
+	// merge base of all of them, the shared commits and the stats that apply to them.
+	Refs []RefComparison
+}
+
+// shortMergeBase abbreviates the merge base, which is empty when the refs share no history
+func (a ComparisonAnalysis) shortMergeBase() string {
+	if len(a.MergeBase) < 8 {
+		return "none (unrelated histories)"
+	}
+	return a.MergeBase[:8]
 }
 
 type CommitInfo struct {
@@ -65,6 +81,8 @@ type model struct {
 	// Current state
 	currentView ViewMode
 	analysis    ComparisonAnalysis
+	repoPath    string
+	refs        []string
 
 	// UI components
 	overviewList   list.Model
@@ -79,6 +97,8 @@ type model struct {
 	err        error
 	tuiHelper *terminal.ResponsiveTUIHelper
 	showSearch bool
+	clipboard  terminal.ClipboardNotice
+	keys       terminal.KeyMap
 }
 
 // Messages
@@ -90,17 +110,45 @@ type errMsg struct {
 	err error
 }
 
-// RunComparison starts the comparison tools TUI
-func RunComparison(args []string) error {
-	// Parse arguments to determine what to compare
-	ref1 := "main"
-	ref2 := "HEAD"
+// CompareOptions controls how a comparison is run
+type CompareOptions struct {
+	// RepoPath is the repository to compare in; empty means the current directory
+	RepoPath string
+	// Report writes the comparison (json or markdown) instead of launching the TUI
+	Report gitservice.ReportWriter
+	// Upstream compares the current branch with the branch it tracks, failing if it has
+	// none rather than falling back to main
+	Upstream bool
+}
+
+// RunComparison starts the comparison tools TUI, or writes the comparison when
+// opts.Report is enabled
+func RunComparison(args []string, opts CompareOptions) error {
+	repoPath := opts.RepoPath
+	if err := gitservice.CheckHistory(repoPath); err != nil {
+		return err
+	}
 
-	if len(args) >= 1 {
-		ref1 = args[0]
+	refs, note, err := comparisonRefs(repoPath, args, opts.Upstream)
+	if err != nil {
+		return err
 	}
-	if len(args) >= 2 {
-		ref2 = args[1]
+	if note != "" {
+		fmt.Fprintln(os.Stderr, note)
+	}
+
+	if opts.Report.Enabled() {
+		analysis, err := analyzeComparison(repoPath, refs)
+		if err != nil {
+			return err
+		}
+		return opts.Report.Write(func(w io.Writer, format string) error {
+			return writeReport(w, analysis, format)
+		}, reportFormats...)
+	}
+
+	if err := terminal.RequireTTY("--format json (or -o FILE)"); err != nil {
+		return err
 	}
 
 	// Initialize model
@@ -108,6 +156,9 @@ func RunComparison(args []string) error {
 		currentView: OverviewView,
 		loading:     true,
 		tuiHelper: terminal.NewResponsiveTUIHelper(),
+		keys:        terminal.Keys(),
+		repoPath:    repoPath,
+		refs:        refs,
 	}
 
 	// Initialize UI components
@@ -131,6 +182,10 @@ func RunComparison(args []string) error {
 	m.branchInfoList.Title = "📊 Branch Information"
 	m.branchInfoList.SetShowHelp(false)
 
+	for _, l := range []*list.Model{&m.overviewList, &m.divergenceList, &m.sharedList, &m.mergeBaseList, &m.branchInfoList} {
+		m.keys.ApplyToList(l)
+	}
+
 	m.searchInput = textinput.New()
 	m.searchInput.Placeholder = "Search commits..."
 	m.searchInput.CharLimit = 100
@@ -140,13 +195,45 @@ func RunComparison(args []string) error {
 
 	// Load comparison analysis
 	go func() {
-		p.Send(loadComparisonAnalysis(ref1, ref2))
+		p.Send(loadComparisonAnalysis(repoPath, refs))
 	}()
 
-	_, err := p.Run()
+	_, err = p.Run()
 	return err
 }
 
+// comparisonRefs works out what to compare from the arguments. With none, the current
+// branch is compared with its upstream, or with main if it has none, in which case
+// the note says why. One ref is compared with HEAD, and three or more N-way.
+func comparisonRefs(repoPath string, args []string, upstream bool) ([]string, string, error) {
+	if upstream && len(args) > 0 {
+		return nil, "", fmt.Errorf("--upstream compares the current branch with its upstream and takes no refs")
+	}
+	if len(args) > 2 {
+		return args, "", nil
+	}
+
+	note := ""
+	if len(args) == 0 {
+		repo, err := gitservice.OpenRepo(repoPath)
+		if err != nil {
+			return nil, "", err
+		}
+		tracking, err := gitservice.Upstream(repo)
+		if err == nil {
+			return []string{tracking, "HEAD"}, "", nil
+		}
+		if upstream {
+			return nil, "", err
+		}
+		note = fmt.Sprintf("Comparing main with HEAD: %v", err)
+	}
+
+	refs := []string{"main", "HEAD"}
+	copy(refs, args)
+	return refs, note, nil
+}
+
 func (m model) Init() tea.Cmd {
 	return nil
 }
@@ -171,6 +258,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case comparisonAnalysisMsg:
 		m.loading = false
 		m.analysis = msg.analysis
+		if m.analysis.isMultiWay() {
+			m.setMultiWayItems()
+			break
+		}
 
 		// Update overview list
 		overviewItems := []list.Item{
@@ -178,7 +269,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			OverviewItem{title: fmt.Sprintf("📈 %s ahead", m.analysis.Ref1), desc: fmt.Sprintf("%d commits", m.analysis.Stats.Ref1AheadBy)},
 			OverviewItem{title: fmt.Sprintf("📈 %s ahead", m.analysis.Ref2), desc: fmt.Sprintf("%d commits", m.analysis.Stats.Ref2AheadBy)},
 			OverviewItem{title: "🤝 Shared commits", desc: fmt.Sprintf("%d commits", m.analysis.Stats.SharedCommits)},
-			OverviewItem{title: "🔗 Merge base", desc: m.analysis.MergeBase[:8]},
+			OverviewItem{title: "🔗 Merge base", desc: m.analysis.shortMergeBase()},
 		}
 		if m.analysis.Stats.DaysSinceBase > 0 {
 			overviewItems = append(overviewItems, OverviewItem{
@@ -225,9 +316,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var mergeBaseItems []list.Item
 		if m.analysis.MergeBaseInfo != nil {
 			mergeBaseItems = []list.Item{
-				MergeBaseItem{title: "📝 Commit", desc: m.analysis.MergeBase[:8]},
+				MergeBaseItem{title: "📝 Commit", desc: m.analysis.shortMergeBase()},
 				MergeBaseItem{title: "👤 Author", desc: m.analysis.MergeBaseInfo.Author.Name},
-				MergeBaseItem{title: "📅 Date", desc: m.analysis.MergeBaseInfo.Author.When.Format("2006-01-02 15:04:05")},
+				MergeBaseItem{title: "📅 Date", desc: gitservice.DetailDate(m.analysis.MergeBaseInfo.Author.When, "2006-01-02 15:04:05")},
 				MergeBaseItem{title: "💬 Message", desc: strings.Split(m.analysis.MergeBaseInfo.Message, "\n")[0]},
 			}
 		}
@@ -246,13 +337,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.err = msg.err
 
+	case terminal.ClipboardMsg, terminal.ClipboardClearMsg:
+		return m, m.clipboard.Update(msg)
+
 	case tea.KeyMsg:
+		// The error screen can only retry the load or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				m.err = nil
+				m.loading = true
+				return m, m.reload()
+			case key.Matches(msg, m.keys.Quit):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
 		// Handle global keys first
 		switch {
-		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		case key.Matches(msg, m.keys.Back):
 			if m.showSearch {
 				m.showSearch = false
 				m.searchInput.Blur()
@@ -263,7 +370,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("/"))):
+		case key.Matches(msg, m.keys.Filter):
 			if m.currentView == DivergenceView || m.currentView == SharedHistoryView {
 				m.showSearch = !m.showSearch
 				if m.showSearch {
@@ -294,11 +401,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentView = BranchInfoView
 			return m, nil
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
-			m.loading = true
-			return m, func() tea.Msg {
-				return loadComparisonAnalysis(m.analysis.Ref1, m.analysis.Ref2)
+		case key.Matches(msg, m.keys.Copy) && !m.showSearch:
+			if hash := m.selectedHash(); hash != "" {
+				return m, terminal.CopyCmd(hash)
 			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Refresh):
+			m.loading = true
+			return m, m.reload()
 		}
 
 		// Handle view-specific keys
@@ -342,6 +453,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// selectedHash returns the full hash of the commit selected in the current view, or ""
+// if the view has no commit to copy.
+func (m model) selectedHash() string {
+	switch m.currentView {
+	case DivergenceView:
+		if item, ok := m.divergenceList.SelectedItem().(CommitInfoItem); ok {
+			return item.commit.Hash
+		}
+	case SharedHistoryView:
+		if item, ok := m.sharedList.SelectedItem().(CommitInfoItem); ok {
+			return item.commit.Hash
+		}
+	case MergeBaseView:
+		return m.analysis.MergeBase
+	}
+	return ""
+}
+
 func (m *model) filterDivergenceList(query string) {
 	var filtered []list.Item
 	for _, item := range m.divergenceList.Items() {
@@ -395,52 +524,53 @@ func (m model) View() string {
 	}
 }
 
-func loadComparisonAnalysis(ref1, ref2 string) tea.Msg {
-	analysis, err := analyzeComparison(ref1, ref2)
+// reload reruns the comparison
+func (m model) reload() tea.Cmd {
+	repoPath, refs := m.repoPath, m.refs
+	return func() tea.Msg {
+		return loadComparisonAnalysis(repoPath, refs)
+	}
+}
+
+func loadComparisonAnalysis(repoPath string, refs []string) tea.Msg {
+	analysis, err := analyzeComparison(repoPath, refs)
 	if err != nil {
 		return errMsg{err}
 	}
 	return comparisonAnalysisMsg{analysis}
 }
 
-func analyzeComparison(ref1, ref2 string) (ComparisonAnalysis, error) {
-	repo, err := git.PlainOpen(".")
+// analyzeComparison compares refs: two refs against each other, or three or more
+// against the merge base of all of them
+func analyzeComparison(repoPath string, refs []string) (ComparisonAnalysis, error) {
+	repo, err := gitservice.OpenRepo(repoPath)
 	if err != nil {
 		return ComparisonAnalysis{}, err
 	}
+	if len(refs) != 2 {
+		return analyzeMultiComparison(repo, refs)
+	}
+	ref1, ref2 := refs[0], refs[1]
 
 	// Resolve references to commits
-	ref1Hash, err := resolveRef(repo, ref1)
+	ref1Hash, err := gitservice.ResolveRef(repo, ref1)
 	if err != nil {
 		return ComparisonAnalysis{}, fmt.Errorf("failed to resolve '%s': %w", ref1, err)
 	}
 
-	ref2Hash, err := resolveRef(repo, ref2)
+	ref2Hash, err := gitservice.ResolveRef(repo, ref2)
 	if err != nil {
 		return ComparisonAnalysis{}, fmt.Errorf("failed to resolve '%s': %w", ref2, err)
 	}
 
-	// Get commit objects
-	ref1Commit, err := repo.CommitObject(ref1Hash)
-	if err != nil {
-		return ComparisonAnalysis{}, err
-	}
-
-	ref2Commit, err := repo.CommitObject(ref2Hash)
-	if err != nil {
-		return ComparisonAnalysis{}, err
-	}
-
 	// Find merge base
-	mergeBaseHashes, err := ref1Commit.MergeBase(ref2Commit)
+	mergeBaseCommit, err := gitservice.MergeBase(repo, ref1Hash, ref2Hash)
 	if err != nil {
-		return ComparisonAnalysis{}, fmt.Errorf("failed to find merge base: %w", err)
+		return ComparisonAnalysis{}, err
 	}
 
 	var mergeBase string
-	var mergeBaseCommit *object.Commit
-	if len(mergeBaseHashes) > 0 {
-		mergeBaseCommit = mergeBaseHashes[0]
+	if mergeBaseCommit != nil {
 		mergeBase = mergeBaseCommit.Hash.String()
 	}
 
@@ -487,27 +617,13 @@ func analyzeComparison(ref1, ref2 string) (ComparisonAnalysis, error) {
 		Ref2Ahead:     ref2Ahead,
 		SharedCommits: sharedCommits,
 		Stats:         stats,
+		Refs: []RefComparison{
+			{Ref: ref1, Commit: ref1Hash.String(), AheadBy: len(ref1Ahead), Unique: ref1Ahead},
+			{Ref: ref2, Commit: ref2Hash.String(), AheadBy: len(ref2Ahead), Unique: ref2Ahead},
+		},
 	}, nil
 }
 
-func resolveRef(repo *git.Repository, ref string) (plumbing.Hash, error) {
-	// Try to resolve as a hash first
-	if hash := plumbing.NewHash(ref); !hash.IsZero() {
-		// Check if it's a valid commit
-		if _, err := repo.CommitObject(hash); err == nil {
-			return hash, nil
-		}
-	}
-
-	// Try to resolve as a reference
-	resolved, err := repo.ResolveRevision(plumbing.Revision(ref))
-	if err != nil {
-		return plumbing.ZeroHash, err
-	}
-
-	return *resolved, nil
-}
-
 func getCommitRange(repo *git.Repository, fromCommit, toCommit string) ([]CommitInfo, error) {
 	var commits []CommitInfo
 
@@ -541,7 +657,7 @@ func getCommitRange(repo *git.Repository, fromCommit, toCommit string) ([]Commit
 			Message:   strings.Split(commit.Message, "\n")[0],
 			Author:    commit.Author.Name,
 			Date:      commit.Author.When,
-			Parents:   getParentHashes(commit),
+			Parents:   gitservice.ParentHashes(commit),
 		})
 
 		// Limit to prevent too many commits
@@ -593,7 +709,7 @@ func getCommitHistory(repo *git.Repository, commitHash string, limit int) ([]Com
 			Message:   strings.Split(c.Message, "\n")[0],
 			Author:    c.Author.Name,
 			Date:      c.Author.When,
-			Parents:   getParentHashes(c),
+			Parents:   gitservice.ParentHashes(c),
 		})
 
 		count++
@@ -608,14 +724,6 @@ func getCommitHistory(repo *git.Repository, commitHash string, limit int) ([]Com
 	return commits, err
 }
 
-func getParentHashes(commit *object.Commit) []string {
-	var parents []string
-	for _, parent := range commit.ParentHashes {
-		parents = append(parents, parent.String())
-	}
-	return parents
-}
-
 // List item types
 type OverviewItem struct {
 	title string
@@ -641,7 +749,7 @@ func (c CommitInfoItem) Title() string {
 }
 
 func (c CommitInfoItem) Description() string {
-	return fmt.Sprintf("%s • %s", c.commit.Author, c.commit.Date.Format("2006-01-02 15:04"))
+	return fmt.Sprintf("%s • %s", c.commit.Author, gitservice.ListDate(c.commit.Date, "2006-01-02 15:04"))
 }
 
 func (c CommitInfoItem) FilterValue() string {
@@ -670,7 +778,7 @@ func (b BranchInfoItem) FilterValue() string { return b.title + " " + b.desc }
 func (m model) renderLoading() string {
 	style := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginTop(2).
 		MarginLeft(2)
 
@@ -680,11 +788,13 @@ func (m model) renderLoading() string {
 func (m model) renderError() string {
 	style := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("196")).
+		Foreground(theme.Current.Error).
 		MarginTop(2).
 		MarginLeft(2)
 
-	return style.Render(fmt.Sprintf("❌ Error: %v", m.err))
+	helpStyle := lipgloss.NewStyle().Foreground(theme.Current.Muted).MarginLeft(2)
+
+	return style.Render(fmt.Sprintf("❌ Error: %v", m.err)) + "\n\n" + helpStyle.Render(m.keys.ErrorHelp())
 }
 
 func (m model) renderOverview() string {
@@ -693,10 +803,10 @@ func (m model) renderOverview() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
-	title := fmt.Sprintf("⚖️ Comparison: %s ↔ %s", m.analysis.Ref1, m.analysis.Ref2)
+	title := fmt.Sprintf("⚖️ Comparison: %s", m.analysis.title())
 	content.WriteString(headerStyle.Render(title))
 	content.WriteString("\n")
 
@@ -706,10 +816,11 @@ func (m model) renderOverview() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: divergence • 3: shared • 4: merge base • 5: info • r: refresh • q: quit"
+	help := terminal.HelpLine("1: overview", "2: divergence", "3: shared", "4: merge base", "5: info",
+		terminal.Help(m.keys.Refresh, "refresh"), terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -721,12 +832,15 @@ func (m model) renderDivergenceView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
-	title := fmt.Sprintf("🔀 Divergence: %s (%d) ↔ %s (%d)",
-		m.analysis.Ref1, m.analysis.Stats.Ref1AheadBy,
-		m.analysis.Ref2, m.analysis.Stats.Ref2AheadBy)
+	// Each ref with its count: commits ahead, or unique commits in an N-way comparison
+	counts := make([]string, len(m.analysis.Refs))
+	for i, ref := range m.analysis.Refs {
+		counts[i] = fmt.Sprintf("%s (%d)", ref.Ref, len(ref.Unique))
+	}
+	title := "🔀 Divergence: " + strings.Join(counts, " ↔ ")
 	content.WriteString(headerStyle.Render(title))
 	content.WriteString("\n")
 
@@ -734,7 +848,7 @@ func (m model) renderDivergenceView() string {
 	if m.showSearch {
 		searchStyle := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("39")).
+			BorderForeground(theme.Current.Border).
 			Padding(0, 1).
 			MarginBottom(1)
 
@@ -748,11 +862,14 @@ func (m model) renderDivergenceView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: divergence • 3: shared • /: search • esc: back • q: quit"
+	help := terminal.HelpLine("1: overview", "2: divergence", "3: shared",
+		terminal.Help(m.keys.Filter, "search"), terminal.Help(m.keys.Copy, "copy hash"),
+		terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
+	content.WriteString("\n" + m.clipboard.View())
 
 	return content.String()
 }
@@ -763,7 +880,7 @@ func (m model) renderSharedHistoryView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	title := fmt.Sprintf("🤝 Shared History (%d commits)", m.analysis.Stats.SharedCommits)
@@ -774,7 +891,7 @@ func (m model) renderSharedHistoryView() string {
 	if m.showSearch {
 		searchStyle := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("39")).
+			BorderForeground(theme.Current.Border).
 			Padding(0, 1).
 			MarginBottom(1)
 
@@ -788,11 +905,14 @@ func (m model) renderSharedHistoryView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: divergence • 3: shared • /: search • esc: back • q: quit"
+	help := terminal.HelpLine("1: overview", "2: divergence", "3: shared",
+		terminal.Help(m.keys.Filter, "search"), terminal.Help(m.keys.Copy, "copy hash"),
+		terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
+	content.WriteString("\n" + m.clipboard.View())
 
 	return content.String()
 }
@@ -803,7 +923,7 @@ func (m model) renderMergeBaseView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	title := "🔗 Merge Base Analysis"
@@ -814,15 +934,15 @@ func (m model) renderMergeBaseView() string {
 	if m.analysis.MergeBase != "" {
 		infoStyle := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("238")).
+			BorderForeground(theme.Current.Subtle).
 			Padding(1, 2).
 			MarginBottom(1)
 
 		var info strings.Builder
-		info.WriteString(fmt.Sprintf("🔗 Merge Base: %s\n", m.analysis.MergeBase[:8]))
+		info.WriteString(fmt.Sprintf("🔗 Merge Base: %s\n", m.analysis.shortMergeBase()))
 		if m.analysis.MergeBaseInfo != nil {
 			info.WriteString(fmt.Sprintf("👤 Author: %s\n", m.analysis.MergeBaseInfo.Author.Name))
-			info.WriteString(fmt.Sprintf("📅 Date: %s\n", m.analysis.MergeBaseInfo.Author.When.Format("2006-01-02 15:04:05")))
+			info.WriteString(fmt.Sprintf("📅 Date: %s\n", gitservice.DetailDate(m.analysis.MergeBaseInfo.Author.When, "2006-01-02 15:04:05")))
 			info.WriteString(fmt.Sprintf("💬 Message: %s\n", strings.Split(m.analysis.MergeBaseInfo.Message, "\n")[0]))
 			info.WriteString(fmt.Sprintf("📅 Days ago: %d\n", m.analysis.Stats.DaysSinceBase))
 		}
@@ -830,7 +950,7 @@ func (m model) renderMergeBaseView() string {
 		content.WriteString(infoStyle.Render(info.String()))
 	} else {
 		noBaseStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(theme.Current.Muted).
 			Italic(true).
 			MarginBottom(1)
 		content.WriteString(noBaseStyle.Render("No common merge base found"))
@@ -843,11 +963,14 @@ func (m model) renderMergeBaseView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: divergence • 3: shared • 4: merge base • 5: info • esc: back • q: quit"
+	help := terminal.HelpLine("1: overview", "2: divergence", "3: shared", "4: merge base", "5: info",
+		terminal.Help(m.keys.Copy, "copy hash"), terminal.Help(m.keys.Back, "back"),
+		terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
+	content.WriteString("\n" + m.clipboard.View())
 
 	return content.String()
 }
@@ -858,7 +981,7 @@ func (m model) renderBranchInfoView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	title := "📊 Branch Information"
@@ -868,16 +991,23 @@ func (m model) renderBranchInfoView() string {
 	// Detailed comparison stats
 	statsStyle := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("238")).
+		BorderForeground(theme.Current.Subtle).
 		Padding(1, 2).
 		MarginBottom(1)
 
 	var stats strings.Builder
 	stats.WriteString("📊 Comparison Summary:\n\n")
-	stats.WriteString(fmt.Sprintf("📈 %s is ahead by: %d commits\n", m.analysis.Ref1, m.analysis.Stats.Ref1AheadBy))
-	stats.WriteString(fmt.Sprintf("📈 %s is ahead by: %d commits\n", m.analysis.Ref2, m.analysis.Stats.Ref2AheadBy))
+	divergence := 0
+	for _, ref := range m.analysis.Refs {
+		if m.analysis.isMultiWay() {
+			stats.WriteString(fmt.Sprintf("📈 %s is ahead of the merge base by: %d commits (%d unique)\n", ref.Ref, ref.AheadBy, len(ref.Unique)))
+		} else {
+			stats.WriteString(fmt.Sprintf("📈 %s is ahead by: %d commits\n", ref.Ref, ref.AheadBy))
+		}
+		divergence += len(ref.Unique)
+	}
 	stats.WriteString(fmt.Sprintf("🤝 Shared commits: %d\n", m.analysis.Stats.SharedCommits))
-	stats.WriteString(fmt.Sprintf("🔄 Total divergence: %d commits\n", m.analysis.Stats.Ref1AheadBy+m.analysis.Stats.Ref2AheadBy))
+	stats.WriteString(fmt.Sprintf("🔄 Total divergence: %d commits\n", divergence))
 	stats.WriteString(fmt.Sprintf("📈 Total analyzed: %d commits\n", m.analysis.Stats.TotalCommits))
 	if m.analysis.Stats.DaysSinceBase > 0 {
 		stats.WriteString(fmt.Sprintf("📅 Days since divergence: %d\n", m.analysis.Stats.DaysSinceBase))
@@ -891,10 +1021,11 @@ func (m model) renderBranchInfoView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: divergence • 3: shared • 4: merge base • 5: info • r: refresh • q: quit"
+	help := terminal.HelpLine("1: overview", "2: divergence", "3: shared", "4: merge base", "5: info",
+		terminal.Help(m.keys.Refresh, "refresh"), terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -0,0 +1,60 @@
+package compareService
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestComparisonRefs(t *testing.T) {
+	repo, _ := newCompareTestRepo(t)
+
+	// b has no upstream yet, so no arguments fall back to main with a note
+	refs, note, err := comparisonRefs(repo.Dir, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(refs, " ") != "main HEAD" || !strings.Contains(note, "no upstream configured for branch b") {
+		t.Errorf("comparisonRefs() = %v, %q, want main HEAD with a note", refs, note)
+	}
+	if _, _, err := comparisonRefs(repo.Dir, nil, true); err == nil {
+		t.Error("comparisonRefs() with --upstream and no upstream succeeded")
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Branches["b"] = &config.Branch{Name: "b", Remote: ".", Merge: plumbing.NewBranchReferenceName("a")}
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args     []string
+		upstream bool
+		want     string
+	}{
+		{nil, false, "a HEAD"},
+		{nil, true, "a HEAD"},
+		{[]string{"c"}, false, "c HEAD"},
+		{[]string{"a", "c"}, false, "a c"},
+		{[]string{"a", "b", "c"}, false, "a b c"},
+	}
+	for _, tt := range tests {
+		refs, note, err := comparisonRefs(repo.Dir, tt.args, tt.upstream)
+		if err != nil {
+			t.Errorf("comparisonRefs(%v, %v) error: %v", tt.args, tt.upstream, err)
+			continue
+		}
+		if got := strings.Join(refs, " "); got != tt.want || note != "" {
+			t.Errorf("comparisonRefs(%v, %v) = %s, %q, want %s", tt.args, tt.upstream, got, note, tt.want)
+		}
+	}
+
+	if _, _, err := comparisonRefs(repo.Dir, []string{"a"}, true); err == nil {
+		t.Error("comparisonRefs() with --upstream and a ref succeeded")
+	}
+}
@@ -0,0 +1,216 @@
+package compareService
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// RefComparison is one ref of a comparison
+type RefComparison struct {
+	Ref    string
+	Commit string
+	// AheadBy counts the commits since the merge base of all the refs
+	AheadBy int
+	// Unique are the commits no other compared ref contains, newest first
+	Unique []CommitInfo
+}
+
+// isMultiWay reports whether the analysis compares three or more refs
+func (a ComparisonAnalysis) isMultiWay() bool {
+	return len(a.Refs) > 2
+}
+
+// refNames returns the compared refs, in the order they were given
+func (a ComparisonAnalysis) refNames() []string {
+	names := make([]string, len(a.Refs))
+	for i, ref := range a.Refs {
+		names[i] = ref.Ref
+	}
+	return names
+}
+
+// title joins the compared refs, i.e. "main ↔ develop ↔ release"
+func (a ComparisonAnalysis) title() string {
+	return strings.Join(a.refNames(), " ↔ ")
+}
+
+// analyzeMultiComparison compares three or more refs against the merge base of all of
+// them: each ref's commits since that base, and the ones no other ref has.
+func analyzeMultiComparison(repo *git.Repository, refs []string) (ComparisonAnalysis, error) {
+	hashes := make([]plumbing.Hash, len(refs))
+	for i, ref := range refs {
+		hash, err := gitservice.ResolveRef(repo, ref)
+		if err != nil {
+			return ComparisonAnalysis{}, fmt.Errorf("failed to resolve '%s': %w", ref, err)
+		}
+		hashes[i] = hash
+	}
+
+	mergeBaseCommit, err := gitservice.OctopusMergeBase(repo, hashes...)
+	if err != nil {
+		return ComparisonAnalysis{}, err
+	}
+
+	var mergeBase string
+	baseAncestors := map[plumbing.Hash]bool{}
+	if mergeBaseCommit != nil {
+		mergeBase = mergeBaseCommit.Hash.String()
+		baseAncestors, err = gitservice.Ancestors(repo, mergeBaseCommit.Hash)
+		if err != nil {
+			return ComparisonAnalysis{}, err
+		}
+	}
+
+	// A commit since the base that only one ref reaches is unique to that ref
+	ahead := make([][]CommitInfo, len(refs))
+	reachedBy := make(map[string]int)
+	for i, hash := range hashes {
+		ahead[i], err = commitsSince(repo, hash, baseAncestors)
+		if err != nil {
+			return ComparisonAnalysis{}, fmt.Errorf("failed to get commits ahead of the merge base for '%s': %w", refs[i], err)
+		}
+		for _, c := range ahead[i] {
+			reachedBy[c.Hash]++
+		}
+	}
+
+	analysis := ComparisonAnalysis{
+		MergeBase:     mergeBase,
+		MergeBaseInfo: mergeBaseCommit,
+		Refs:          make([]RefComparison, len(refs)),
+	}
+	for i, ref := range refs {
+		comparison := RefComparison{Ref: ref, Commit: hashes[i].String(), AheadBy: len(ahead[i])}
+		for _, c := range ahead[i] {
+			if reachedBy[c.Hash] == 1 {
+				comparison.Unique = append(comparison.Unique, c)
+			}
+		}
+		analysis.Refs[i] = comparison
+	}
+
+	analysis.SharedCommits, err = getSharedCommits(repo, mergeBase, 20) // Limit to recent 20
+	if err != nil {
+		return ComparisonAnalysis{}, fmt.Errorf("failed to get shared commits: %w", err)
+	}
+
+	if mergeBaseCommit != nil {
+		analysis.Stats.DaysSinceBase = int(time.Since(mergeBaseCommit.Author.When).Hours() / 24)
+	}
+	analysis.Stats.SharedCommits = len(analysis.SharedCommits)
+	analysis.Stats.TotalCommits = len(reachedBy) + len(analysis.SharedCommits)
+
+	return analysis, nil
+}
+
+// commitsSince returns the commits reachable from tip that are not in exclude, newest
+// first. The walk stops at excluded commits, so it never descends below the merge base.
+func commitsSince(repo *git.Repository, tip plumbing.Hash, exclude map[plumbing.Hash]bool) ([]CommitInfo, error) {
+	var commits []CommitInfo
+	seen := map[plumbing.Hash]bool{}
+	pending := []plumbing.Hash{tip}
+
+	for len(pending) > 0 {
+		hash := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if seen[hash] || exclude[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, toCommitInfo(commit))
+		pending = append(pending, commit.ParentHashes...)
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Date.After(commits[j].Date)
+	})
+	return commits, nil
+}
+
+func toCommitInfo(commit *object.Commit) CommitInfo {
+	return CommitInfo{
+		Hash:      commit.Hash.String(),
+		ShortHash: commit.Hash.String()[:8],
+		Message:   strings.Split(commit.Message, "\n")[0],
+		Author:    commit.Author.Name,
+		Date:      commit.Author.When,
+		Parents:   gitservice.ParentHashes(commit),
+	}
+}
+
+// setMultiWayItems fills the lists for a comparison of three or more refs. The
+// divergence view lists the commits unique to each ref.
+func (m *model) setMultiWayItems() {
+	overviewItems := []list.Item{
+		OverviewItem{title: "⚖️ Comparison", desc: m.analysis.title()},
+	}
+	for _, ref := range m.analysis.Refs {
+		overviewItems = append(overviewItems, OverviewItem{
+			title: fmt.Sprintf("📈 %s ahead", ref.Ref),
+			desc:  fmt.Sprintf("%d commits since the merge base, %d unique", ref.AheadBy, len(ref.Unique)),
+		})
+	}
+	overviewItems = append(overviewItems,
+		OverviewItem{title: "🤝 Shared commits", desc: fmt.Sprintf("%d commits", m.analysis.Stats.SharedCommits)},
+		OverviewItem{title: "🔗 Merge base of all refs", desc: m.analysis.shortMergeBase()},
+	)
+	if m.analysis.Stats.DaysSinceBase > 0 {
+		overviewItems = append(overviewItems, OverviewItem{
+			title: "📅 Days since base",
+			desc:  fmt.Sprintf("%d days", m.analysis.Stats.DaysSinceBase),
+		})
+	}
+	m.overviewList.SetItems(overviewItems)
+
+	var divergenceItems []list.Item
+	for _, ref := range m.analysis.Refs {
+		for _, commit := range ref.Unique {
+			divergenceItems = append(divergenceItems, CommitInfoItem{commit: commit, branch: ref.Ref, icon: "📈"})
+		}
+	}
+	sort.SliceStable(divergenceItems, func(i, j int) bool {
+		return divergenceItems[i].(CommitInfoItem).commit.Date.After(divergenceItems[j].(CommitInfoItem).commit.Date)
+	})
+	m.divergenceList.SetItems(divergenceItems)
+
+	sharedItems := make([]list.Item, len(m.analysis.SharedCommits))
+	for i, commit := range m.analysis.SharedCommits {
+		sharedItems[i] = CommitInfoItem{commit: commit, branch: "shared", icon: "🤝"}
+	}
+	m.sharedList.SetItems(sharedItems)
+
+	var mergeBaseItems []list.Item
+	if base := m.analysis.MergeBaseInfo; base != nil {
+		mergeBaseItems = []list.Item{
+			MergeBaseItem{title: "📝 Commit", desc: m.analysis.shortMergeBase()},
+			MergeBaseItem{title: "👤 Author", desc: base.Author.Name},
+			MergeBaseItem{title: "📅 Date", desc: gitservice.DetailDate(base.Author.When, "2006-01-02 15:04:05")},
+			MergeBaseItem{title: "💬 Message", desc: strings.Split(base.Message, "\n")[0]},
+		}
+	}
+	m.mergeBaseList.SetItems(mergeBaseItems)
+
+	var branchInfoItems []list.Item
+	for _, ref := range m.analysis.Refs {
+		branchInfoItems = append(branchInfoItems, BranchInfoItem{
+			title: fmt.Sprintf("📊 %s Info", ref.Ref),
+			desc:  fmt.Sprintf("Commit: %s", ref.Commit[:8]),
+		})
+	}
+	branchInfoItems = append(branchInfoItems,
+		BranchInfoItem{title: "📈 Total analyzed", desc: fmt.Sprintf("%d commits", m.analysis.Stats.TotalCommits)})
+	m.branchInfoList.SetItems(branchInfoItems)
+}
@@ -0,0 +1,91 @@
+package compareService
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+// newCompareTestRepo creates a repository with an "Initial commit" on master and three
+// branches off it: "a" with one commit, "b" with two, and "c" branched from "a" with one
+// more.
+func newCompareTestRepo(t *testing.T) (*gittest.Repo, plumbing.Hash) {
+	t.Helper()
+
+	repo := gittest.New(t)
+	base := repo.Commit("Initial commit")
+	repo.Checkout("a", true)
+	repo.Commit("A work")
+	repo.Checkout("c", true)
+	repo.Commit("C work")
+
+	repo.Checkout("master", false)
+	repo.Checkout("b", true)
+	repo.Commit("B work")
+	repo.Commit("More B work")
+
+	return repo, base
+}
+
+func TestAnalyzeMultiComparison(t *testing.T) {
+	repo, base := newCompareTestRepo(t)
+
+	analysis, err := analyzeComparison(repo.Dir, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !analysis.isMultiWay() {
+		t.Fatal("isMultiWay() = false for three refs")
+	}
+	if analysis.MergeBase != base.String() {
+		t.Errorf("MergeBase = %s, want the initial commit", analysis.MergeBase)
+	}
+
+	tests := []struct {
+		ref     string
+		aheadBy int
+		unique  []string
+	}{
+		// "A work" is also in c, so a has nothing of its own
+		{"a", 1, nil},
+		{"b", 2, []string{"More B work", "B work"}},
+		{"c", 2, []string{"C work"}},
+	}
+	for i, tt := range tests {
+		got := analysis.Refs[i]
+		if got.Ref != tt.ref || got.AheadBy != tt.aheadBy {
+			t.Errorf("Refs[%d] = %s ahead by %d, want %s ahead by %d", i, got.Ref, got.AheadBy, tt.ref, tt.aheadBy)
+		}
+		if len(got.Unique) != len(tt.unique) {
+			t.Errorf("%s: %d unique commits, want %d", tt.ref, len(got.Unique), len(tt.unique))
+			continue
+		}
+		for j, c := range got.Unique {
+			if c.Message != tt.unique[j] {
+				t.Errorf("%s: Unique[%d] = %q, want %q", tt.ref, j, c.Message, tt.unique[j])
+			}
+		}
+	}
+}
+
+func TestAnalyzeComparisonTwoRefs(t *testing.T) {
+	repo, _ := newCompareTestRepo(t)
+
+	analysis, err := analyzeComparison(repo.Dir, []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.isMultiWay() {
+		t.Error("isMultiWay() = true for two refs")
+	}
+	if analysis.Ref1 != "a" || analysis.Ref2 != "b" {
+		t.Errorf("Ref1, Ref2 = %s, %s, want a, b", analysis.Ref1, analysis.Ref2)
+	}
+	if len(analysis.Refs) != 2 {
+		t.Fatalf("len(Refs) = %d, want 2", len(analysis.Refs))
+	}
+	if analysis.Stats.Ref2AheadBy != 2 || len(analysis.Ref2Ahead) != 2 {
+		t.Errorf("b ahead by %d with %d commits listed, want 2", analysis.Stats.Ref2AheadBy, len(analysis.Ref2Ahead))
+	}
+}
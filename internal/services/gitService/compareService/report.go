@@ -0,0 +1,168 @@
+package compareService
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// reportFormats are the formats writeReport supports, the default first
+var reportFormats = []string{gitservice.FormatJSON, gitservice.FormatMarkdown}
+
+// comparisonReport is the exported form of a ComparisonAnalysis
+type comparisonReport struct {
+	Ref1          string         `json:"ref1"`
+	Ref2          string         `json:"ref2"`
+	Ref1Commit    string         `json:"ref1_commit"`
+	Ref2Commit    string         `json:"ref2_commit"`
+	MergeBase     string         `json:"merge_base,omitempty"` // Empty for unrelated histories
+	Ref1AheadBy   int            `json:"ref1_ahead_by"`
+	Ref2AheadBy   int            `json:"ref2_ahead_by"`
+	DaysSinceBase int            `json:"days_since_base"`
+	Ref1Ahead     []reportCommit `json:"ref1_ahead"`
+	Ref2Ahead     []reportCommit `json:"ref2_ahead"`
+	SharedCommits []reportCommit `json:"shared_commits"`
+}
+
+// multiComparisonReport is the exported form of a comparison of three or more refs
+type multiComparisonReport struct {
+	Refs          []reportRef    `json:"refs"`
+	MergeBase     string         `json:"merge_base,omitempty"` // Empty for unrelated histories
+	DaysSinceBase int            `json:"days_since_base"`
+	SharedCommits []reportCommit `json:"shared_commits"`
+}
+
+type reportRef struct {
+	Ref     string         `json:"ref"`
+	Commit  string         `json:"commit"`
+	AheadBy int            `json:"ahead_by"` // Commits since the merge base of all refs
+	Unique  []reportCommit `json:"unique"`   // Commits no other ref contains
+}
+
+type reportCommit struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+}
+
+func toReportCommits(commits []CommitInfo) []reportCommit {
+	out := make([]reportCommit, len(commits))
+	for i, c := range commits {
+		out[i] = reportCommit{Hash: c.Hash, Message: c.Message, Author: c.Author, Date: c.Date}
+	}
+	return out
+}
+
+// writeReport writes the comparison to w as "json" or "markdown"
+func writeReport(w io.Writer, analysis ComparisonAnalysis, format string) error {
+	if analysis.isMultiWay() {
+		return writeMultiReport(w, analysis, format)
+	}
+
+	report := comparisonReport{
+		Ref1:          analysis.Ref1,
+		Ref2:          analysis.Ref2,
+		Ref1Commit:    analysis.Ref1Commit,
+		Ref2Commit:    analysis.Ref2Commit,
+		MergeBase:     analysis.MergeBase,
+		Ref1AheadBy:   analysis.Stats.Ref1AheadBy,
+		Ref2AheadBy:   analysis.Stats.Ref2AheadBy,
+		DaysSinceBase: analysis.Stats.DaysSinceBase,
+		Ref1Ahead:     toReportCommits(analysis.Ref1Ahead),
+		Ref2Ahead:     toReportCommits(analysis.Ref2Ahead),
+		SharedCommits: toReportCommits(analysis.SharedCommits),
+	}
+
+	switch format {
+	case gitservice.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case gitservice.FormatMarkdown:
+		return writeMarkdown(w, report, analysis.shortMergeBase())
+	default:
+		return fmt.Errorf("unsupported comparison format: %s", format)
+	}
+}
+
+func writeMarkdown(w io.Writer, report comparisonReport, mergeBase string) error {
+	fmt.Fprintf(w, "# %s ↔ %s\n\n", report.Ref1, report.Ref2)
+	fmt.Fprintf(w, "- Merge base: %s\n", mergeBase)
+	fmt.Fprintf(w, "- %s is %d commits ahead\n", report.Ref1, report.Ref1AheadBy)
+	fmt.Fprintf(w, "- %s is %d commits ahead\n", report.Ref2, report.Ref2AheadBy)
+
+	sections := []struct {
+		title   string
+		commits []reportCommit
+	}{
+		{"Only in " + report.Ref1, report.Ref1Ahead},
+		{"Only in " + report.Ref2, report.Ref2Ahead},
+		{"Shared History", report.SharedCommits},
+	}
+	for _, section := range sections {
+		if err := writeCommitSection(w, section.title, section.commits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCommitSection writes a Markdown section listing commits, or nothing if there are
+// none
+func writeCommitSection(w io.Writer, title string, commits []reportCommit) error {
+	if len(commits) == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "\n## %s\n\n", title)
+	rows := make([][]string, len(commits))
+	for i, c := range commits {
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		rows[i] = []string{c.Hash[:8], subject, c.Author, c.Date.Format("2006-01-02")}
+	}
+	return gitservice.WriteMarkdownTable(w, []string{"Commit", "Message", "Author", "Date"}, rows)
+}
+
+// writeMultiReport writes a comparison of three or more refs to w as "json" or "markdown"
+func writeMultiReport(w io.Writer, analysis ComparisonAnalysis, format string) error {
+	report := multiComparisonReport{
+		MergeBase:     analysis.MergeBase,
+		DaysSinceBase: analysis.Stats.DaysSinceBase,
+		SharedCommits: toReportCommits(analysis.SharedCommits),
+	}
+	for _, ref := range analysis.Refs {
+		report.Refs = append(report.Refs, reportRef{
+			Ref:     ref.Ref,
+			Commit:  ref.Commit,
+			AheadBy: ref.AheadBy,
+			Unique:  toReportCommits(ref.Unique),
+		})
+	}
+
+	switch format {
+	case gitservice.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case gitservice.FormatMarkdown:
+	default:
+		return fmt.Errorf("unsupported comparison format: %s", format)
+	}
+
+	fmt.Fprintf(w, "# %s\n\n", analysis.title())
+	fmt.Fprintf(w, "- Merge base of all refs: %s\n", analysis.shortMergeBase())
+	for _, ref := range report.Refs {
+		fmt.Fprintf(w, "- %s is %d commits ahead of the merge base, %d unique\n", ref.Ref, ref.AheadBy, len(ref.Unique))
+	}
+
+	for _, ref := range report.Refs {
+		if err := writeCommitSection(w, "Only in "+ref.Ref, ref.Unique); err != nil {
+			return err
+		}
+	}
+	return writeCommitSection(w, "Shared History", report.SharedCommits)
+}
@@ -0,0 +1,36 @@
+package gitservice
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// Confirm asks question, with a [y/N] suffix, and reads the answer from reader. Only y
+// or yes confirm: an empty answer, anything else or a closed input declines, so a
+// destructive command run without a terminal does nothing.
+func Confirm(reader *bufio.Reader, question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+
+	answer, err := reader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// UndoBranchHint returns the command that recreates branch name at hash, to print when
+// the branch is deleted. The commits stay in the repository until they are garbage
+// collected, so the branch can be restored for a while.
+func UndoBranchHint(name, hash string) string {
+	return fmt.Sprintf("git branch %s %s", name, hash)
+}
+
+// UndoTagHint returns the command that recreates tag name, pointing at hash, to print
+// when the tag is deleted. For an annotated tag, hash is the tag object, so the tag
+// comes back with its message.
+func UndoTagHint(name, hash string) string {
+	return fmt.Sprintf("git tag %s %s", name, hash)
+}
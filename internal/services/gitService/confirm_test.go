@@ -0,0 +1,35 @@
+package gitservice
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := map[string]bool{
+		"y\n":     true,
+		"YES\n":   true,
+		" y \n":   true,
+		"\n":      false,
+		"n\n":     false,
+		"yep\n":   false,
+		"":        false,
+		"y":       false, // Closed before the answer ended
+		"no\ny\n": false,
+	}
+	for input, want := range tests {
+		if got := Confirm(bufio.NewReader(strings.NewReader(input)), "Delete?"); got != want {
+			t.Errorf("Confirm(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestUndoHints(t *testing.T) {
+	if got, want := UndoBranchHint("feature", "0123abcd"), "git branch feature 0123abcd"; got != want {
+		t.Errorf("UndoBranchHint() = %q, want %q", got, want)
+	}
+	if got, want := UndoTagHint("v1.0", "0123abcd"), "git tag v1.0 0123abcd"; got != want {
+		t.Errorf("UndoTagHint() = %q, want %q", got, want)
+	}
+}
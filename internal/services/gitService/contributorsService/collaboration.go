@@ -0,0 +1,152 @@
+package contributorsService
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redjax/syst/internal/utils/terminal"
+)
+
+// collaborationLimit is how many pairs and siloed contributors the view lists
+const collaborationLimit = 10
+
+// CollaborationPair is two contributors and the number of files both have modified
+type CollaborationPair struct {
+	A           string
+	B           string
+	SharedFiles int
+}
+
+// SiloStat describes how much of a contributor's work nobody else has touched
+type SiloStat struct {
+	Name string
+	// Files is how many files the contributor modified
+	Files int
+	// SoloFiles is how many of those no other contributor modified
+	SoloFiles int
+}
+
+// SoloPercentage is the share of the contributor's files only they have modified.
+func (s SiloStat) SoloPercentage() float64 {
+	if s.Files == 0 {
+		return 0
+	}
+	return float64(s.SoloFiles) / float64(s.Files) * 100
+}
+
+// Collaboration is the author overlap graph as an adjacency list of pairs, plus the
+// contributors who work most in isolation
+type Collaboration struct {
+	Pairs []CollaborationPair
+	Silos []SiloStat
+}
+
+// analyzeCollaboration builds the overlap graph from the files each contributor modified
+// during the history walk, so no second walk is needed. Pairs are sorted by shared
+// files, and silos by the share of files only that contributor touched.
+func analyzeCollaboration(contributors []ContributorData) Collaboration {
+	fileContributors := make(map[string][]string) // file -> contributors
+	for _, contributor := range contributors {
+		for file := range contributor.Files {
+			fileContributors[file] = append(fileContributors[file], contributor.Name)
+		}
+	}
+
+	type pairKey struct{ a, b string }
+	shared := make(map[pairKey]int)
+	solo := make(map[string]int)
+	for _, names := range fileContributors {
+		if len(names) == 1 {
+			solo[names[0]]++
+			continue
+		}
+		sort.Strings(names)
+		for i := range names {
+			for j := i + 1; j < len(names); j++ {
+				shared[pairKey{names[i], names[j]}]++
+			}
+		}
+	}
+
+	var collab Collaboration
+	for key, count := range shared {
+		collab.Pairs = append(collab.Pairs, CollaborationPair{A: key.a, B: key.b, SharedFiles: count})
+	}
+	sort.Slice(collab.Pairs, func(i, j int) bool {
+		a, b := collab.Pairs[i], collab.Pairs[j]
+		if a.SharedFiles != b.SharedFiles {
+			return a.SharedFiles > b.SharedFiles
+		}
+		if a.A != b.A {
+			return a.A < b.A
+		}
+		return a.B < b.B
+	})
+
+	for _, contributor := range contributors {
+		if len(contributor.Files) == 0 {
+			continue
+		}
+		collab.Silos = append(collab.Silos, SiloStat{
+			Name:      contributor.Name,
+			Files:     len(contributor.Files),
+			SoloFiles: solo[contributor.Name],
+		})
+	}
+	sort.Slice(collab.Silos, func(i, j int) bool {
+		a, b := collab.Silos[i], collab.Silos[j]
+		if a.SoloPercentage() != b.SoloPercentage() {
+			return a.SoloPercentage() > b.SoloPercentage()
+		}
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+		return a.Name < b.Name
+	})
+
+	return collab
+}
+
+func (m model) renderCollaborationView() string {
+	var sections []string
+
+	title := titleStyle.Render("🤝 Collaboration")
+	sections = append(sections, title)
+
+	var pairs strings.Builder
+	pairs.WriteString(headerStyle.Render("🔗 Most Collaborating Pairs"))
+	pairs.WriteString("\n\n")
+	if len(m.collaboration.Pairs) == 0 {
+		pairs.WriteString("No files have been modified by more than one contributor\n")
+	}
+	for i, pair := range m.collaboration.Pairs {
+		if i >= collaborationLimit {
+			break
+		}
+		pairs.WriteString(fmt.Sprintf("%s ↔ %s: %s shared files\n",
+			highlightStyle.Render(pair.A), highlightStyle.Render(pair.B),
+			statsStyle.Render(fmt.Sprintf("%d", pair.SharedFiles))))
+	}
+	sections = append(sections, sectionStyle.Render(pairs.String()))
+
+	var silos strings.Builder
+	silos.WriteString(headerStyle.Render("🏝️  Most Siloed Contributors"))
+	silos.WriteString("\n\n")
+	if len(m.collaboration.Silos) == 0 {
+		silos.WriteString("No file changes recorded\n")
+	}
+	for i, silo := range m.collaboration.Silos {
+		if i >= collaborationLimit {
+			break
+		}
+		silos.WriteString(fmt.Sprintf("%s: %s of %d files touched by no one else\n",
+			silo.Name, statsStyle.Render(fmt.Sprintf("%.0f%%", silo.SoloPercentage())), silo.Files))
+	}
+	sections = append(sections, sectionStyle.Render(silos.String()))
+
+	help := helpStyle.Render(terminal.HelpLine("o: back to list", terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit")))
+	sections = append(sections, help)
+
+	return m.tuiHelper.CenterContent(strings.Join(sections, "\n"))
+}
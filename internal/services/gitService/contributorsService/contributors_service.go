@@ -1,26 +1,63 @@
 package contributorsService
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/utils/profile"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type ViewMode int
 
+// ContributorsOptions controls how contributors are analyzed
+type ContributorsOptions struct {
+	// RepoPath is the repository to analyze; empty means the current directory
+	RepoPath string
+	// MailmapFile is an extra .mailmap applied on top of the repository's own
+	MailmapFile string
+	// CoAuthorCredit shares commit credit with Co-authored-by trailers by default
+	CoAuthorCredit bool
+	// Report exports statistics (json, csv, markdown or prom) instead of launching the TUI
+	Report gitservice.ReportWriter
+	// ExcludeBots hides commits from authors matching BotPatterns
+	ExcludeBots bool
+	// BotPatterns overrides gitservice.DefaultBotPatterns
+	BotPatterns []string
+	// NoCache disables the on-disk commit stats cache under .git/syst-cache
+	NoCache bool
+	// Ref is the branch, tag or commit whose history is analyzed; empty means HEAD
+	Ref string
+	// Limit caps how many commits are walked from Ref; 0 means the whole history
+	Limit int
+	// RecentDays is how many days back a commit counts as recent; 0 means
+	// DefaultRecentDays
+	RecentDays int
+}
+
 const (
 	ContributorListView ViewMode = iota
 	ContributorDetailView
 	TimelineView
+	CollaborationView
+	RecentView
 )
 
 type ContributorData struct {
@@ -41,6 +78,15 @@ type ContributorData struct {
 	AverageCommitSize int
 	LargestCommit     CommitSummary
 	Percentage        float64
+	// Commits authored in the last RecentDays and quarterDays, for the recently active view
+	CommitsRecent int
+	CommitsLast90 int
+	// Co-author credit: each commit is split evenly between its author and co-authors
+	CoAuthoredCommits  int
+	CreditedCommits    float64
+	CreditedPercentage float64
+	// Files counts the commits that modified each file, for the collaboration view
+	Files map[string]int
 }
 
 type CommitSummary struct {
@@ -64,8 +110,29 @@ type OverallStats struct {
 	DateRange         string
 	MostActive        string
 	RecentActivity    []ContributorActivity
+	RecentDays        int // Window of RecentActivity and RecentCommits
+	BotCommits        int // Commits hidden by bot filtering
+	BotAuthors        int
+	LimitNote         string        // Set when --limit cut the walk short
+	EmailDomains      []EmailDomain // Most commits first
+}
+
+// EmailDomain counts the commits authored from one email domain, i.e. a company's
+// domain against a personal email provider
+type EmailDomain struct {
+	Domain       string
+	Commits      int
+	Contributors int
+	Percentage   float64
 }
 
+const (
+	// githubNoreplyDomain buckets GitHub's private commit emails, which hide the real domain
+	githubNoreplyDomain = "github-noreply"
+	// unknownDomain buckets commits without a usable email
+	unknownDomain = "unknown"
+)
+
 type ContributorActivity struct {
 	Name   string
 	Period string
@@ -73,28 +140,41 @@ type ContributorActivity struct {
 }
 
 type model struct {
+	allContributors []ContributorData
 	contributors    []ContributorData
 	selectedIndex   int
+	scroll          int // Line offset into the detail, timeline and recently active views
 	overallStats    OverallStats
 	contributorList list.Model
 	viewMode        ViewMode
 	tuiHelper       *terminal.ResponsiveTUIHelper
+	keys            terminal.KeyMap
 	err             error
 	loading         bool
+	opts            ContributorsOptions
+	coAuthorCredit  bool
+	sortBy          int // Index of the list's order in contributorSorts
+	loader          gitservice.Loading
+	collaboration   Collaboration
 }
 
 type contributorItem struct {
-	contributor ContributorData
+	contributor    ContributorData
+	coAuthorCredit bool
 }
 
 func (i contributorItem) FilterValue() string { return i.contributor.Name }
 func (i contributorItem) Title() string {
+	if i.coAuthorCredit {
+		return fmt.Sprintf("%s <%s> (%.1f credited commits, %.1f%%)", i.contributor.Name, i.contributor.Email,
+			i.contributor.CreditedCommits, i.contributor.CreditedPercentage)
+	}
 	commits := i.contributor.TotalCommits
 	percentage := i.contributor.Percentage
 	return fmt.Sprintf("%s <%s> (%d commits, %.1f%%)", i.contributor.Name, i.contributor.Email, commits, percentage)
 }
 func (i contributorItem) Description() string {
-	lastActive := i.contributor.LastCommit.Format("2006-01-02")
+	lastActive := gitservice.ListDate(i.contributor.LastCommit, "2006-01-02")
 	linesChanged := i.contributor.LinesAdded + i.contributor.LinesDeleted
 	return fmt.Sprintf("Last active: %s • %d lines changed • %d files",
 		lastActive, linesChanged, i.contributor.FilesModified)
@@ -111,39 +191,42 @@ type errMsg struct {
 
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#FF6B35")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1)
 
 	sectionStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			MarginTop(1)
 
 	headerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			MarginBottom(1)
 
 	statsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575"))
+			Foreground(theme.Current.Success)
 
 	highlightStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#01FAC6")).
+			Foreground(theme.Current.Accent).
 			Bold(true)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87"))
+			Foreground(theme.Current.Error)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 )
 
 func (m model) Init() tea.Cmd {
-	return loadContributorData
+	return tea.Batch(
+		m.loader.Init(),
+		loadContributorData(m.opts, m.loader.Progress),
+	)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -153,18 +236,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		width, height := m.tuiHelper.GetSize()
 		m.contributorList.SetWidth(width)
 		m.contributorList.SetHeight(height - 10)
-		return m, nil
+		return m.scrollTo(m.scroll), nil
+
+	case spinner.TickMsg, gitservice.ProgressMsg:
+		var cmd tea.Cmd
+		m.loader, cmd = m.loader.Update(msg)
+		return m, cmd
 
 	case dataLoadedMsg:
-		m.contributors = msg.contributors
+		m.allContributors = msg.contributors
 		m.overallStats = msg.overallStats
 		m.loading = false
-
-		items := make([]list.Item, len(m.contributors))
-		for i, contributor := range m.contributors {
-			items[i] = contributorItem{contributor: contributor}
-		}
-		m.contributorList.SetItems(items)
+		m.applyCreditMode()
 
 		return m, nil
 
@@ -174,19 +257,49 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// The error screen can only retry the analysis or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				return m.retry()
+			case key.Matches(msg, m.keys.Quit, m.keys.Back):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
 		switch m.viewMode {
 		case ContributorListView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c", "esc"))):
+			case key.Matches(msg, m.keys.Quit, m.keys.Back):
 				return m, tea.Quit
-			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			case key.Matches(msg, m.keys.Select):
 				if selected := m.contributorList.SelectedItem(); selected != nil {
 					m.selectedIndex = m.contributorList.Index()
 					m.viewMode = ContributorDetailView
+					m.scroll = 0
 					return m, nil
 				}
 			case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
 				m.viewMode = TimelineView
+				m.scroll = 0
+				return m, nil
+			case key.Matches(msg, key.NewBinding(key.WithKeys("o"))):
+				m.viewMode = CollaborationView
+				return m, nil
+			case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+				m.viewMode = RecentView
+				m.scroll = 0
+				return m, nil
+			case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+				m.coAuthorCredit = !m.coAuthorCredit
+				m.applyCreditMode()
+				return m, nil
+			case m.contributorList.FilterState() != list.Filtering && key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+				m.sortBy = gitservice.NextSort(m.sortBy, len(contributorSorts))
+				m.contributors = gitservice.SortList(m.visibleContributors(), m.contributorSort())
+				m.setContributorItems()
+				m.contributorList.ResetSelected()
 				return m, nil
 			default:
 				var cmd tea.Cmd
@@ -194,29 +307,51 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 
-		case ContributorDetailView, TimelineView:
+		case ContributorDetailView, TimelineView, RecentView:
+			if offset, ok := m.tuiHelper.ScrollKey(msg, m.scroll, m.reservedLines()); ok {
+				return m.scrollTo(offset), nil
+			}
+
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+			case key.Matches(msg, m.keys.Quit):
 				return m, tea.Quit
-			case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "backspace"))):
+			case key.Matches(msg, m.keys.Back) || msg.String() == "backspace":
 				m.viewMode = ContributorListView
 				return m, nil
-			case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+			case m.viewMode == RecentView && key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+				m.viewMode = ContributorListView
+				return m, nil
+			case m.viewMode != RecentView && key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
 				if m.viewMode == ContributorDetailView {
 					m.viewMode = TimelineView
 				} else {
 					m.viewMode = ContributorDetailView
 				}
+				m.scroll = 0
 				return m, nil
-			case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
-				if m.selectedIndex > 0 {
+			case key.Matches(msg, m.keys.Up):
+				// Past the first contributor, or in the timeline and recently active views, scroll instead
+				if m.viewMode == ContributorDetailView && m.selectedIndex > 0 {
 					m.selectedIndex--
+					m.scroll = 0
+					return m, nil
 				}
-				return m, nil
-			case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
-				if m.selectedIndex < len(m.contributors)-1 {
+				return m.scrollTo(m.scroll - 1), nil
+			case key.Matches(msg, m.keys.Down):
+				if m.viewMode == ContributorDetailView && m.selectedIndex < len(m.contributors)-1 {
 					m.selectedIndex++
+					m.scroll = 0
+					return m, nil
 				}
+				return m.scrollTo(m.scroll + 1), nil
+			}
+
+		case CollaborationView:
+			switch {
+			case key.Matches(msg, m.keys.Quit):
+				return m, tea.Quit
+			case key.Matches(msg, m.keys.Back, key.NewBinding(key.WithKeys("backspace", "o"))):
+				m.viewMode = ContributorListView
 				return m, nil
 			}
 		}
@@ -225,13 +360,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// retry starts the analysis over after it failed, with a fresh progress stream as the
+// last one was closed
+func (m model) retry() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.loading = true
+	m.loader = gitservice.NewLoading()
+	return m, m.Init()
+}
+
 func (m model) View() string {
 	if m.loading {
-		return m.tuiHelper.CenterContent("Analyzing contributor data...")
+		return m.tuiHelper.CenterContent(m.loader.View("Analyzing contributor data...", helpStyle))
 	}
 
 	if m.err != nil {
-		return m.tuiHelper.CenterContent(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		return m.tuiHelper.CenterContent(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n" + helpStyle.Render(m.keys.ErrorHelp()))
 	}
 
 	switch m.viewMode {
@@ -241,6 +385,10 @@ func (m model) View() string {
 		return m.renderContributorDetail()
 	case TimelineView:
 		return m.renderTimelineView()
+	case CollaborationView:
+		return m.renderCollaborationView()
+	case RecentView:
+		return m.renderScrolled()
 	}
 
 	return ""
@@ -250,6 +398,9 @@ func (m model) renderContributorList() string {
 	var sections []string
 
 	title := titleStyle.Render("👥 Contributors Analysis")
+	if m.opts.Ref != "" {
+		title = titleStyle.Render("👥 Contributors Analysis - " + m.opts.Ref)
+	}
 	sections = append(sections, title)
 
 	// Overall stats
@@ -259,12 +410,66 @@ func (m model) renderContributorList() string {
 	// Contributors list
 	sections = append(sections, m.contributorList.View())
 
-	help := helpStyle.Render("↑/↓: navigate • enter: details • t: timeline • q: quit")
+	creditMode := "off"
+	if m.coAuthorCredit {
+		creditMode = "on"
+	}
+	help := helpStyle.Render(terminal.HelpLine(
+		terminal.HelpKey(m.keys.Up, m.keys.Down)+": navigate", terminal.Help(m.keys.Select, "details"),
+		"t: timeline", "o: collaboration", "r: recently active",
+		fmt.Sprintf("c: co-author credit (%s)", creditMode), fmt.Sprintf("s: sort (%s)", contributorSorts[m.sortBy].Name),
+		terminal.Help(m.keys.Quit, "quit")))
 	sections = append(sections, help)
 
 	return m.tuiHelper.CenterContent(strings.Join(sections, "\n"))
 }
 
+// applyCreditMode rebuilds the visible contributor list for the current co-author
+// credit setting. Co-author-only contributors are hidden when credit is off.
+func (m *model) applyCreditMode() {
+	defer profile.Phase(profile.Render)()
+
+	visible := m.visibleContributors()
+	m.contributors = gitservice.SortList(visible, m.contributorSort())
+	m.collaboration = analyzeCollaboration(visible)
+	m.selectedIndex = 0
+	m.overallStats.TotalContributors = len(visible)
+	if len(visible) > 0 {
+		m.overallStats.MostActive = visible[0].Name
+	}
+	m.setContributorItems()
+}
+
+// visibleContributors returns the contributors shown for the current co-author credit
+// setting, most commits first
+func (m model) visibleContributors() []ContributorData {
+	var visible []ContributorData
+	for _, contributor := range m.allContributors {
+		if m.coAuthorCredit || contributor.TotalCommits > 0 {
+			visible = append(visible, contributor)
+		}
+	}
+
+	sort.SliceStable(visible, func(i, j int) bool {
+		if m.coAuthorCredit {
+			return visible[i].CreditedCommits > visible[j].CreditedCommits
+		}
+		return visible[i].TotalCommits > visible[j].TotalCommits
+	})
+
+	return visible
+}
+
+// setContributorItems fills the list with the visible contributors in their current order
+func (m *model) setContributorItems() {
+	items := make([]list.Item, len(m.contributors))
+	for i, contributor := range m.contributors {
+		items[i] = contributorItem{contributor: contributor, coAuthorCredit: m.coAuthorCredit}
+	}
+	m.contributorList.SetItems(items)
+	m.contributorList.Title = "Contributors • sorted by " + contributorSorts[m.sortBy].Name
+}
+
 func (m model) renderOverallStats() string {
 	stats := m.overallStats
 	var content strings.Builder
@@ -280,9 +485,31 @@ func (m model) renderOverallStats() string {
 		statsStyle.Render(stats.DateRange)))
 	content.WriteString(fmt.Sprintf("Most Active: %s\n",
 		highlightStyle.Render(stats.MostActive)))
+	if stats.BotCommits > 0 {
+		content.WriteString(fmt.Sprintf("Bot Commits Hidden: %s (%d bots)\n",
+			statsStyle.Render(fmt.Sprintf("%d", stats.BotCommits)), stats.BotAuthors))
+	}
+	if stats.LimitNote != "" {
+		content.WriteString(fmt.Sprintf("Stats are %s\n", stats.LimitNote))
+	}
+
+	if len(stats.EmailDomains) > 0 {
+		content.WriteString("\nEmail Domains:\n")
+		for _, domain := range stats.EmailDomains[:min(len(stats.EmailDomains), 3)] { // Show top 3
+			people := "contributors"
+			if domain.Contributors == 1 {
+				people = "contributor"
+			}
+			content.WriteString(fmt.Sprintf("  %s: %s commits (%.1f%%, %d %s)\n",
+				domain.Domain, statsStyle.Render(fmt.Sprintf("%d", domain.Commits)), domain.Percentage, domain.Contributors, people))
+		}
+		if more := len(stats.EmailDomains) - 3; more > 0 {
+			content.WriteString(fmt.Sprintf("  ...and %d more\n", more))
+		}
+	}
 
 	if len(stats.RecentActivity) > 0 {
-		content.WriteString("\nRecent Activity (last 30 days):\n")
+		content.WriteString(fmt.Sprintf("\nRecent Activity (last %d days):\n", stats.RecentDays))
 		for i, activity := range stats.RecentActivity {
 			if i >= 3 { // Show top 3
 				break
@@ -300,28 +527,64 @@ func (m model) renderContributorDetail() string {
 		return m.tuiHelper.CenterContent("No contributor selected")
 	}
 
-	contributor := m.contributors[m.selectedIndex]
-	var sections []string
+	return m.renderScrolled()
+}
 
-	title := titleStyle.Render(fmt.Sprintf("👤 %s", contributor.Name))
-	sections = append(sections, title)
+// renderScrolled renders the detail, timeline or recently active view with its body scrolled, when it
+// doesn't fit between the title and help
+func (m model) renderScrolled() string {
+	title, help := m.viewChrome()
+	body := m.tuiHelper.ScrollContent(m.renderBody(), m.scroll, m.reservedLines())
+	return m.tuiHelper.CenterContent(strings.Join([]string{title, body, help}, "\n"))
+}
 
-	// Contributor stats
-	detailContent := m.renderContributorStats(contributor)
-	sections = append(sections, sectionStyle.Render(detailContent))
+// viewChrome renders the title and help of the detail, timeline or recently active view
+func (m model) viewChrome() (string, string) {
+	switch m.viewMode {
+	case TimelineView:
+		return titleStyle.Render("📈 Activity Timeline"),
+			helpStyle.Render(terminal.HelpLine("t: details", "pgup/pgdn: scroll", terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit")))
+	case RecentView:
+		return titleStyle.Render("🕒 Recently Active"),
+			helpStyle.Render(terminal.HelpLine(terminal.HelpKey(m.keys.Up, m.keys.Down)+"/pgup/pgdn: scroll",
+				"r/"+terminal.HelpKey(m.keys.Back)+": back", terminal.Help(m.keys.Quit, "quit")))
+	}
+	return titleStyle.Render(fmt.Sprintf("👤 %s", m.contributors[m.selectedIndex].Name)),
+		helpStyle.Render(terminal.HelpLine(terminal.HelpKey(m.keys.Up, m.keys.Down)+": switch contributor", "pgup/pgdn: scroll",
+			"t: timeline", terminal.Help(m.keys.Back, "back"), terminal.Help(m.keys.Quit, "quit")))
+}
 
-	// Activity patterns
-	activityContent := m.renderActivityPatterns(contributor)
-	sections = append(sections, sectionStyle.Render(activityContent))
+// reservedLines is the height of the title and help around the scrolled body
+func (m model) reservedLines() int {
+	title, help := m.viewChrome()
+	return lipgloss.Height(title) + lipgloss.Height(help)
+}
 
-	// Recent work
-	recentContent := m.renderRecentWork(contributor)
-	sections = append(sections, sectionStyle.Render(recentContent))
+// renderBody renders the sections of the detail, timeline or recently active view
+func (m model) renderBody() string {
+	switch m.viewMode {
+	case TimelineView:
+		return m.renderTimeline()
+	case RecentView:
+		return m.renderRecent()
+	}
 
-	help := helpStyle.Render("↑/↓: switch contributor • t: timeline • esc: back • q: quit")
-	sections = append(sections, help)
+	contributor := m.contributors[m.selectedIndex]
+	return strings.Join([]string{
+		sectionStyle.Render(m.renderContributorStats(contributor)),
+		sectionStyle.Render(m.renderActivityPatterns(contributor)),
+		sectionStyle.Render(m.renderRecentWork(contributor)),
+	}, "\n")
+}
 
-	return m.tuiHelper.CenterContent(strings.Join(sections, "\n"))
+// scrollTo scrolls the detail, timeline or recently active view to offset, kept within
+// the lines hidden below the fold
+func (m model) scrollTo(offset int) model {
+	if m.viewMode != TimelineView && m.viewMode != RecentView && (m.viewMode != ContributorDetailView || m.selectedIndex >= len(m.contributors)) {
+		return m
+	}
+	m.scroll = m.tuiHelper.ClampScroll(m.renderBody(), offset, m.reservedLines())
+	return m
 }
 
 func (m model) renderContributorStats(contributor ContributorData) string {
@@ -333,6 +596,12 @@ func (m model) renderContributorStats(contributor ContributorData) string {
 	content.WriteString(fmt.Sprintf("Email: %s\n", contributor.Email))
 	content.WriteString(fmt.Sprintf("Total Commits: %s (%.1f%%)\n",
 		statsStyle.Render(fmt.Sprintf("%d", contributor.TotalCommits)), contributor.Percentage))
+	if contributor.CoAuthoredCommits > 0 || m.coAuthorCredit {
+		content.WriteString(fmt.Sprintf("Co-authored Commits: %s\n",
+			statsStyle.Render(fmt.Sprintf("%d", contributor.CoAuthoredCommits))))
+		content.WriteString(fmt.Sprintf("Credited Commits: %s (%.1f%%)\n",
+			statsStyle.Render(fmt.Sprintf("%.1f", contributor.CreditedCommits)), contributor.CreditedPercentage))
+	}
 	content.WriteString(fmt.Sprintf("Lines Added: %s\n",
 		statsStyle.Render(fmt.Sprintf("%d", contributor.LinesAdded))))
 	content.WriteString(fmt.Sprintf("Lines Deleted: %s\n",
@@ -342,9 +611,9 @@ func (m model) renderContributorStats(contributor ContributorData) string {
 	content.WriteString(fmt.Sprintf("Average Commit Size: %s lines\n",
 		statsStyle.Render(fmt.Sprintf("%d", contributor.AverageCommitSize))))
 	content.WriteString(fmt.Sprintf("First Commit: %s\n",
-		contributor.FirstCommit.Format("2006-01-02")))
+		gitservice.DetailDate(contributor.FirstCommit, "2006-01-02")))
 	content.WriteString(fmt.Sprintf("Last Commit: %s\n",
-		contributor.LastCommit.Format("2006-01-02")))
+		gitservice.DetailDate(contributor.LastCommit, "2006-01-02")))
 
 	return content.String()
 }
@@ -422,11 +691,11 @@ func (m model) renderRecentWork(contributor ContributorData) string {
 }
 
 func (m model) renderTimelineView() string {
-	var sections []string
-
-	title := titleStyle.Render("📈 Activity Timeline")
-	sections = append(sections, title)
+	return m.renderScrolled()
+}
 
+// renderTimeline renders the monthly activity of all contributors
+func (m model) renderTimeline() string {
 	// Monthly activity for all contributors
 	monthlyData := make(map[string]int)
 	for _, contributor := range m.contributors {
@@ -461,47 +730,84 @@ func (m model) renderTimelineView() string {
 		}
 	}
 
-	sections = append(sections, sectionStyle.Render(content.String()))
+	return sectionStyle.Render(content.String())
+}
 
-	help := helpStyle.Render("↑/↓: navigate • t: details • esc: back • q: quit")
-	sections = append(sections, help)
+func loadContributorData(opts ContributorsOptions, progress *gitservice.Progress) tea.Cmd {
+	return func() tea.Msg {
+		defer progress.Close()
 
-	return m.tuiHelper.CenterContent(strings.Join(sections, "\n"))
+		contributors, overallStats, err := analyzeContributors(opts, progress)
+		if err != nil {
+			return errMsg{err}
+		}
+		return dataLoadedMsg{contributors, overallStats}
+	}
 }
 
-func loadContributorData() tea.Msg {
-	contributors, overallStats, err := analyzeContributors()
+// analyzeContributors walks the history once, reporting progress to progress (which may be nil)
+func analyzeContributors(opts ContributorsOptions, progress *gitservice.Progress) ([]ContributorData, OverallStats, error) {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
 	if err != nil {
-		return errMsg{err}
+		return nil, OverallStats{}, err
 	}
-	return dataLoadedMsg{contributors, overallStats}
-}
 
-func analyzeContributors() ([]ContributorData, OverallStats, error) {
-	repo, err := git.PlainOpen(".")
+	mailmap, err := gitservice.LoadRepoMailmap(repo, opts.MailmapFile)
 	if err != nil {
-		return nil, OverallStats{}, fmt.Errorf("failed to open repository: %w", err)
+		return nil, OverallStats{}, err
+	}
+
+	var bots *gitservice.BotFilter
+	if opts.ExcludeBots {
+		bots = gitservice.NewBotFilter(opts.BotPatterns)
 	}
+	botAuthors := make(map[string]bool)
+	var botCommits int
 
-	ref, err := repo.Head()
+	// Commits and authors per email domain
+	domainCommits := make(map[string]int)
+	domainAuthors := make(map[string]map[string]bool)
+
+	from, err := gitservice.StartCommit(repo, opts.Ref)
 	if err != nil {
-		return nil, OverallStats{}, fmt.Errorf("failed to get HEAD: %w", err)
+		return nil, OverallStats{}, err
 	}
 
-	cIter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
+	cIter, err := repo.Log(&git.LogOptions{From: from})
 	if err != nil {
 		return nil, OverallStats{}, fmt.Errorf("failed to get log: %w", err)
 	}
+	progress.CountCommits(repo, from, opts.Limit)
+	limit := gitservice.NewCommitLimit(opts.Limit)
+
+	statsCache := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+	// #nosec G104 - A failed cache write only means the next run recomputes stats
+	defer statsCache.Save()
 
 	contributorMap := make(map[string]*ContributorData)
 	var totalCommits int
 	var oldestCommit, newestCommit time.Time
-	recentCutoff := time.Now().AddDate(0, 0, -30) // Last 30 days
+	recentDays := opts.RecentDays
+	if recentDays <= 0 {
+		recentDays = DefaultRecentDays
+	}
+	recentCutoff := time.Now().AddDate(0, 0, -recentDays)
+	quarterCutoff := time.Now().AddDate(0, 0, -quarterDays)
 
+	walked := profile.Phase(profile.LogWalk)
 	err = cIter.ForEach(func(c *object.Commit) error {
+		if !limit.Take() {
+			return storer.ErrStop
+		}
+		progress.Commit()
+		authorName, authorEmail := mailmap.Canonicalize(c.Author.Name, c.Author.Email)
+		if bots.IsBot(authorName, authorEmail) {
+			botCommits++
+			botAuthors[authorName] = true
+			return nil
+		}
+
 		totalCommits++
-		authorName := c.Author.Name
-		authorEmail := c.Author.Email
 		commitTime := c.Author.When
 
 		// Track date range
@@ -513,20 +819,55 @@ func analyzeContributors() ([]ContributorData, OverallStats, error) {
 		}
 
 		// Get or create contributor data
-		if contributorMap[authorName] == nil {
-			contributorMap[authorName] = &ContributorData{
-				Name:           authorName,
-				Email:          authorEmail,
-				CommitsByMonth: make(map[string]int),
-				CommitsByHour:  make(map[int]int),
-				CommitsByDay:   make(map[int]int),
-				FirstCommit:    commitTime,
-				LastCommit:     commitTime,
+		getContributor := func(name, email string) *ContributorData {
+			if contributorMap[name] == nil {
+				contributorMap[name] = &ContributorData{
+					Name:           name,
+					Email:          email,
+					CommitsByMonth: make(map[string]int),
+					CommitsByHour:  make(map[int]int),
+					CommitsByDay:   make(map[int]int),
+					FirstCommit:    commitTime,
+					LastCommit:     commitTime,
+				}
 			}
+			return contributorMap[name]
 		}
 
-		contributor := contributorMap[authorName]
+		contributor := getContributor(authorName, authorEmail)
 		contributor.TotalCommits++
+		if commitTime.After(quarterCutoff) {
+			contributor.CommitsLast90++
+		}
+		if commitTime.After(recentCutoff) {
+			contributor.CommitsRecent++
+		}
+
+		domain := emailDomain(authorEmail)
+		domainCommits[domain]++
+		if domainAuthors[domain] == nil {
+			domainAuthors[domain] = make(map[string]bool)
+		}
+		domainAuthors[domain][authorName] = true
+
+		// Split credit evenly between the author and any co-authors. Bot co-authors get
+		// none, so the people's shares still add up to the whole commit.
+		coAuthors := slices.DeleteFunc(parseCoAuthors(c.Message, authorName, mailmap), func(a coAuthor) bool {
+			return bots.IsBot(a.name, a.email)
+		})
+		share := 1.0 / float64(len(coAuthors)+1)
+		contributor.CreditedCommits += share
+		for _, coAuthor := range coAuthors {
+			credited := getContributor(coAuthor.name, coAuthor.email)
+			credited.CoAuthoredCommits++
+			credited.CreditedCommits += share
+			if commitTime.Before(credited.FirstCommit) {
+				credited.FirstCommit = commitTime
+			}
+			if commitTime.After(credited.LastCommit) {
+				credited.LastCommit = commitTime
+			}
+		}
 
 		// Update date range
 		if commitTime.Before(contributor.FirstCommit) {
@@ -543,15 +884,19 @@ func analyzeContributors() ([]ContributorData, OverallStats, error) {
 		contributor.CommitsByDay[int(commitTime.Weekday())]++
 
 		// Get commit stats
-		stats, err := c.Stats()
+		stats, err := statsCache.Stats(c)
 		if err == nil {
 			additions := 0
 			deletions := 0
 			filesModified := len(stats)
 
+			if contributor.Files == nil {
+				contributor.Files = make(map[string]int)
+			}
 			for _, stat := range stats {
 				additions += stat.Addition
 				deletions += stat.Deletion
+				contributor.Files[stat.Name]++
 			}
 
 			contributor.LinesAdded += additions
@@ -588,6 +933,8 @@ func analyzeContributors() ([]ContributorData, OverallStats, error) {
 
 		return nil
 	})
+	walked()
+	defer profile.Phase(profile.Render)()
 
 	if err != nil {
 		return nil, OverallStats{}, fmt.Errorf("failed to iterate commits: %w", err)
@@ -597,6 +944,7 @@ func analyzeContributors() ([]ContributorData, OverallStats, error) {
 	var contributors []ContributorData
 	for _, contributor := range contributorMap {
 		contributor.Percentage = float64(contributor.TotalCommits) / float64(totalCommits) * 100
+		contributor.CreditedPercentage = contributor.CreditedCommits / float64(totalCommits) * 100
 		if contributor.TotalCommits > 0 {
 			contributor.AverageCommitSize = (contributor.LinesAdded + contributor.LinesDeleted) / contributor.TotalCommits
 		}
@@ -614,7 +962,7 @@ func analyzeContributors() ([]ContributorData, OverallStats, error) {
 		return contributors[i].TotalCommits > contributors[j].TotalCommits
 	})
 
-	// Calculate overall stats
+	// Calculate overall stats (the TUI recomputes these when co-author credit is toggled)
 	var mostActive string
 	if len(contributors) > 0 {
 		mostActive = contributors[0].Name
@@ -627,7 +975,7 @@ func analyzeContributors() ([]ContributorData, OverallStats, error) {
 		if recentCount > 0 {
 			recentActivity = append(recentActivity, ContributorActivity{
 				Name:   contributor.Name,
-				Period: "30 days",
+				Period: fmt.Sprintf("%d days", recentDays),
 				Count:  recentCount,
 			})
 		}
@@ -644,19 +992,221 @@ func analyzeContributors() ([]ContributorData, OverallStats, error) {
 		DateRange:         fmt.Sprintf("%s to %s", oldestCommit.Format("2006-01-02"), newestCommit.Format("2006-01-02")),
 		MostActive:        mostActive,
 		RecentActivity:    recentActivity,
+		RecentDays:        recentDays,
+		BotCommits:        botCommits,
+		BotAuthors:        len(botAuthors),
+		LimitNote:         limit.Note(),
+		EmailDomains:      calculateEmailDomains(domainCommits, domainAuthors, totalCommits),
 	}
 
 	return contributors, overallStats, nil
 }
 
+// emailDomain returns the lowercased domain of an author email. GitHub's noreply
+// addresses (i.e. 123+octocat@users.noreply.github.com) are bucketed as
+// githubNoreplyDomain, and missing or malformed emails as unknownDomain.
+func emailDomain(email string) string {
+	_, domain, ok := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	domain = strings.Trim(domain, "<> ")
+	switch {
+	case !ok || domain == "":
+		return unknownDomain
+	case domain == "users.noreply.github.com" || domain == "noreply.github.com":
+		return githubNoreplyDomain
+	default:
+		return domain
+	}
+}
+
+// calculateEmailDomains turns the commits and authors seen per domain into a breakdown,
+// with the most commits first
+func calculateEmailDomains(commits map[string]int, authors map[string]map[string]bool, totalCommits int) []EmailDomain {
+	var domains []EmailDomain
+	for domain, count := range commits {
+		domains = append(domains, EmailDomain{
+			Domain:       domain,
+			Commits:      count,
+			Contributors: len(authors[domain]),
+			Percentage:   float64(count) / float64(totalCommits) * 100,
+		})
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].Commits != domains[j].Commits {
+			return domains[i].Commits > domains[j].Commits
+		}
+		return domains[i].Domain < domains[j].Domain
+	})
+	return domains
+}
+
+// contributorExport is the per-contributor record written by AnalyzeContributorsExport
+type contributorExport struct {
+	Name               string         `json:"name"`
+	Email              string         `json:"email"`
+	Commits            int            `json:"commits"`
+	Percentage         float64        `json:"percentage"`
+	CoAuthoredCommits  int            `json:"co_authored_commits"`
+	CreditedCommits    float64        `json:"credited_commits"`
+	CreditedPercentage float64        `json:"credited_percentage"`
+	LinesAdded         int            `json:"lines_added"`
+	LinesDeleted       int            `json:"lines_deleted"`
+	FilesModified      int            `json:"files_modified"`
+	FirstCommit        time.Time      `json:"first_commit"`
+	LastCommit         time.Time      `json:"last_commit"`
+	CommitsLast30Days  int            `json:"commits_last_30_days"` // In the last RecentDays, 30 by default
+	CommitsLast90Days  int            `json:"commits_last_90_days"`
+	AverageCommitSize  int            `json:"average_commit_size"`
+	CommitsByMonth     map[string]int `json:"commits_by_month"`
+	CommitsByHour      map[int]int    `json:"commits_by_hour"`
+	CommitsByDay       map[int]int    `json:"commits_by_day"`
+}
+
+// exportFormats are the formats AnalyzeContributorsExport supports, the default first
+var exportFormats = []string{gitservice.FormatJSON, gitservice.FormatCSV, gitservice.FormatMarkdown, gitservice.FormatPrometheus}
+
+// AnalyzeContributors gathers the contributor statistics without the TUI, i.e. for
+// the summary
+func AnalyzeContributors(opts ContributorsOptions) ([]ContributorData, OverallStats, error) {
+	return analyzeContributors(opts, nil)
+}
+
+// AnalyzeContributorsExport writes contributor statistics to w as "json", "csv", "markdown"
+// or "prom"
+func AnalyzeContributorsExport(format string, w io.Writer, opts ContributorsOptions) error {
+	contributors, overall, err := analyzeContributors(opts, nil)
+	if err != nil {
+		return err
+	}
+	// Like the TUI, leave out co-authors without commits of their own when they get no credit
+	if !opts.CoAuthorCredit {
+		contributors = slices.DeleteFunc(contributors, func(c ContributorData) bool { return c.TotalCommits == 0 })
+		overall.TotalContributors = len(contributors)
+	}
+	if strings.ToLower(format) == gitservice.FormatPrometheus {
+		return gitservice.WritePrometheus(w, gitservice.PromRepoName(opts.RepoPath), contributorMetrics(contributors, overall))
+	}
+
+	records := make([]contributorExport, len(contributors))
+	for i, c := range contributors {
+		records[i] = contributorExport{
+			Name:               c.Name,
+			Email:              c.Email,
+			Commits:            c.TotalCommits,
+			Percentage:         c.Percentage,
+			CoAuthoredCommits:  c.CoAuthoredCommits,
+			CreditedCommits:    c.CreditedCommits,
+			CreditedPercentage: c.CreditedPercentage,
+			LinesAdded:         c.LinesAdded,
+			LinesDeleted:       c.LinesDeleted,
+			FilesModified:      c.FilesModified,
+			FirstCommit:        c.FirstCommit,
+			LastCommit:         c.LastCommit,
+			CommitsLast30Days:  c.CommitsRecent,
+			CommitsLast90Days:  c.CommitsLast90,
+			AverageCommitSize:  c.AverageCommitSize,
+			CommitsByMonth:     c.CommitsByMonth,
+			CommitsByHour:      c.CommitsByHour,
+			CommitsByDay:       c.CommitsByDay,
+		}
+	}
+
+	switch strings.ToLower(format) {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case "csv":
+		return writeContributorsCSV(w, records)
+	case gitservice.FormatMarkdown:
+		return writeContributorsMarkdown(w, records)
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func writeContributorsCSV(w io.Writer, records []contributorExport) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		"name", "email", "commits", "percentage", "co_authored_commits", "credited_commits",
+		"lines_added", "lines_deleted", "files_modified", "first_commit", "last_commit", "average_commit_size",
+		"commits_last_30_days", "commits_last_90_days",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		row := []string{
+			r.Name,
+			r.Email,
+			strconv.Itoa(r.Commits),
+			strconv.FormatFloat(r.Percentage, 'f', 2, 64),
+			strconv.Itoa(r.CoAuthoredCommits),
+			strconv.FormatFloat(r.CreditedCommits, 'f', 2, 64),
+			strconv.Itoa(r.LinesAdded),
+			strconv.Itoa(r.LinesDeleted),
+			strconv.Itoa(r.FilesModified),
+			r.FirstCommit.Format(time.RFC3339),
+			r.LastCommit.Format(time.RFC3339),
+			strconv.Itoa(r.AverageCommitSize),
+			strconv.Itoa(r.CommitsLast30Days),
+			strconv.Itoa(r.CommitsLast90Days),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeContributorsMarkdown writes the main statistics as a Markdown table
+func writeContributorsMarkdown(w io.Writer, records []contributorExport) error {
+	rows := make([][]string, len(records))
+	for i, r := range records {
+		rows[i] = []string{
+			r.Name,
+			strconv.Itoa(r.Commits),
+			strconv.FormatFloat(r.Percentage, 'f', 1, 64) + "%",
+			"+" + strconv.Itoa(r.LinesAdded),
+			"-" + strconv.Itoa(r.LinesDeleted),
+			strconv.Itoa(r.FilesModified),
+			r.FirstCommit.Format("2006-01-02"),
+			r.LastCommit.Format("2006-01-02"),
+		}
+	}
+
+	if _, err := io.WriteString(w, "# Contributors\n\n"); err != nil {
+		return err
+	}
+	return gitservice.WriteMarkdownTable(w,
+		[]string{"Name", "Commits", "Share", "Added", "Deleted", "Files", "First Commit", "Last Commit"}, rows)
+}
+
 // RunContributorsAnalysis starts the contributors analysis TUI
-func RunContributorsAnalysis() error {
+func RunContributorsAnalysis(opts ContributorsOptions) error {
+	if err := gitservice.CheckHistoryFrom(opts.RepoPath, opts.Ref); err != nil {
+		return err
+	}
+
+	if opts.Report.Enabled() {
+		return opts.Report.Write(func(w io.Writer, format string) error {
+			return AnalyzeContributorsExport(format, w, opts)
+		}, exportFormats...)
+	}
+
+	if err := terminal.RequireTTY("--format json (or -o FILE)"); err != nil {
+		return err
+	}
+
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("#01FAC6")).
-		BorderLeftForeground(lipgloss.Color("#01FAC6"))
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
 	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("#DDDDDD"))
+		Foreground(theme.Current.Text)
 
 	contributorList := list.New([]list.Item{}, delegate, 0, 0)
 	contributorList.Title = "Contributors"
@@ -668,9 +1218,51 @@ func RunContributorsAnalysis() error {
 		viewMode:        ContributorListView,
 		loading:         true,
 		tuiHelper:       terminal.NewResponsiveTUIHelper(),
+		keys:            terminal.Keys(),
+		opts:            opts,
+		coAuthorCredit:  opts.CoAuthorCredit,
+		loader:          gitservice.NewLoading(),
 	}
+	m.keys.ApplyToList(&m.contributorList)
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	_, err := p.Run()
 	return err
 }
+
+type coAuthor struct {
+	name  string
+	email string
+}
+
+// parseCoAuthors extracts "Co-authored-by: Name <email>" trailers from a commit message,
+// canonicalized through the mailmap. The commit author and duplicates are skipped.
+func parseCoAuthors(message, authorName string, mailmap *gitservice.Mailmap) []coAuthor {
+	const trailer = "co-authored-by:"
+
+	seen := map[string]bool{authorName: true}
+	var coAuthors []coAuthor
+
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(strings.ToLower(line), trailer) {
+			continue
+		}
+
+		value := strings.TrimSpace(line[len(trailer):])
+		open := strings.IndexByte(value, '<')
+		closing := strings.LastIndexByte(value, '>')
+		if open == -1 || closing < open {
+			continue
+		}
+
+		name, email := mailmap.Canonicalize(strings.TrimSpace(value[:open]), strings.TrimSpace(value[open+1:closing]))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		coAuthors = append(coAuthors, coAuthor{name: name, email: email})
+	}
+
+	return coAuthors
+}
@@ -0,0 +1,323 @@
+package contributorsService
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-git/go-git/v5"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestParseCoAuthors(t *testing.T) {
+	message := `Add pairing support
+
+Implements the thing we paired on.
+
+Co-authored-by: Bob Builder <bob@example.com>
+co-authored-by: Carol <carol@old.example.com>
+Co-Authored-By: Alice <alice@example.com>
+Co-authored-by: Bob Builder <bob@example.com>
+Co-authored-by: not a trailer
+`
+	mailmap, err := gitservice.ParseMailmap(strings.NewReader("Carol Smith <carol@example.com> <carol@old.example.com>\n"))
+	if err != nil {
+		t.Fatalf("ParseMailmap() error: %v", err)
+	}
+
+	got := parseCoAuthors(message, "Alice", mailmap)
+	want := []coAuthor{
+		{name: "Bob Builder", email: "bob@example.com"},
+		{name: "Carol Smith", email: "carol@example.com"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("parseCoAuthors() returned %d co-authors, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("co-author %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWriteContributorsCSVQuoting(t *testing.T) {
+	var buf strings.Builder
+	records := []contributorExport{{Name: "Doe, Jane", Email: "jane@example.com", Commits: 3}}
+
+	if err := writeContributorsCSV(&buf, records); err != nil {
+		t.Fatalf("writeContributorsCSV() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[1], `"Doe, Jane",jane@example.com,3,`) {
+		t.Errorf("name with comma was not quoted: %s", lines[1])
+	}
+}
+
+func TestAnalyzeCollaboration(t *testing.T) {
+	contributors := []ContributorData{
+		{Name: "Alice", Files: map[string]int{"a.go": 3, "b.go": 1, "c.go": 1}},
+		{Name: "Bob", Files: map[string]int{"a.go": 1, "b.go": 2, "d.go": 4}},
+		{Name: "Carol", Files: map[string]int{"a.go": 1, "e.go": 1, "f.go": 1, "g.go": 1}},
+		{Name: "Dave"},
+	}
+
+	collab := analyzeCollaboration(contributors)
+
+	wantPairs := []CollaborationPair{
+		{A: "Alice", B: "Bob", SharedFiles: 2},
+		{A: "Alice", B: "Carol", SharedFiles: 1},
+		{A: "Bob", B: "Carol", SharedFiles: 1},
+	}
+	if len(collab.Pairs) != len(wantPairs) {
+		t.Fatalf("Pairs = %v, want %v", collab.Pairs, wantPairs)
+	}
+	for i := range wantPairs {
+		if collab.Pairs[i] != wantPairs[i] {
+			t.Errorf("pair %d = %v, want %v", i, collab.Pairs[i], wantPairs[i])
+		}
+	}
+
+	// Carol touched 3 of 4 files alone, Alice and Bob 1 of 3; Dave has no file changes
+	wantSilos := []SiloStat{
+		{Name: "Carol", Files: 4, SoloFiles: 3},
+		{Name: "Alice", Files: 3, SoloFiles: 1},
+		{Name: "Bob", Files: 3, SoloFiles: 1},
+	}
+	if len(collab.Silos) != len(wantSilos) {
+		t.Fatalf("Silos = %v, want %v", collab.Silos, wantSilos)
+	}
+	for i := range wantSilos {
+		if collab.Silos[i] != wantSilos[i] {
+			t.Errorf("silo %d = %v, want %v", i, collab.Silos[i], wantSilos[i])
+		}
+	}
+}
+
+func TestEmailDomains(t *testing.T) {
+	tests := map[string]string{
+		"alice@Company.com":                      "company.com",
+		" bob@gmail.com ":                        "gmail.com",
+		"12345+octocat@users.noreply.github.com": githubNoreplyDomain,
+		"octocat@noreply.github.com":             githubNoreplyDomain,
+		"":                                       unknownDomain,
+		"root":                                   unknownDomain,
+		"carol@":                                 unknownDomain,
+	}
+	for email, want := range tests {
+		if got := emailDomain(email); got != want {
+			t.Errorf("emailDomain(%q) = %q, want %q", email, got, want)
+		}
+	}
+
+	commits := map[string]int{"company.com": 6, "gmail.com": 3, githubNoreplyDomain: 1}
+	authors := map[string]map[string]bool{
+		"company.com":       {"alice": true, "dave": true},
+		"gmail.com":         {"bob": true},
+		githubNoreplyDomain: {"octocat": true},
+	}
+	got := calculateEmailDomains(commits, authors, 10)
+	want := []EmailDomain{
+		{Domain: "company.com", Commits: 6, Contributors: 2, Percentage: 60},
+		{Domain: "gmail.com", Commits: 3, Contributors: 1, Percentage: 30},
+		{Domain: githubNoreplyDomain, Commits: 1, Contributors: 1, Percentage: 10},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("calculateEmailDomains() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("domain %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRecentlyActive(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	contributors := []ContributorData{
+		{Name: "Alice", LastCommit: now.AddDate(0, -3, 0)},
+		{Name: "Bob", LastCommit: now.AddDate(0, 0, -3)},
+		{Name: "Carol", LastCommit: now.Add(-2 * time.Hour)},
+	}
+
+	got := recentlyActive(contributors)
+	for i, name := range []string{"Carol", "Bob", "Alice"} {
+		if got[i].Name != name {
+			t.Errorf("contributor %d = %s, want %s", i, got[i].Name, name)
+		}
+	}
+	if contributors[0].Name != "Alice" {
+		t.Error("recentlyActive() reordered its input")
+	}
+
+}
+
+func TestCycleContributorSort(t *testing.T) {
+	m := model{
+		allContributors: []ContributorData{
+			{Name: "alice", TotalCommits: 10, LinesAdded: 5},
+			{Name: "bob", TotalCommits: 3, LinesAdded: 400},
+			{Name: "carol", TotalCommits: 7, LinesAdded: 50},
+		},
+		contributorList: list.New(nil, list.NewDefaultDelegate(), 80, 40),
+	}
+	m.applyCreditMode()
+
+	names := func(m model) string {
+		var names []string
+		for _, c := range m.contributors {
+			names = append(names, c.Name)
+		}
+		return strings.Join(names, " ")
+	}
+	pressS := func(m model) model {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		return updated.(model)
+	}
+
+	if got := names(m); got != "alice carol bob" {
+		t.Errorf("default order = %s, want alice carol bob", got)
+	}
+	m = pressS(m)
+	if got := names(m); got != "bob carol alice" {
+		t.Errorf("by lines changed = %s, want bob carol alice", got)
+	}
+	if !strings.Contains(m.contributorList.Title, "lines changed") {
+		t.Errorf("list title = %q, want the sort in it", m.contributorList.Title)
+	}
+	// The most active contributor doesn't depend on the list order
+	if m.overallStats.MostActive != "alice" {
+		t.Errorf("most active = %s, want alice", m.overallStats.MostActive)
+	}
+
+	// Cycling all the way round restores the commit order
+	for range len(contributorSorts) - 1 {
+		m = pressS(m)
+	}
+	if got := names(m); got != "alice carol bob" {
+		t.Errorf("order after a full cycle = %s, want alice carol bob", got)
+	}
+}
+
+func TestAnalyzeContributorsRecentDays(t *testing.T) {
+	repo := gittest.New(t)
+	// The windows are counted back from now, so the commits are too
+	for _, daysAgo := range []int{60, 20, 5} {
+		message := fmt.Sprintf("Commit from %d days ago", daysAgo)
+		repo.WriteFile("file.txt", message)
+		repo.CommitWith(message, &git.CommitOptions{Author: gittest.Signature(time.Now().AddDate(0, 0, -daysAgo))})
+	}
+
+	tests := []struct {
+		recentDays int
+		wantDays   int
+		want       int
+	}{
+		{0, DefaultRecentDays, 2},
+		{14, 14, 1},
+		{120, 120, 3},
+	}
+	for _, tt := range tests {
+		contributors, stats, err := analyzeContributors(ContributorsOptions{RepoPath: repo.Dir, NoCache: true, RecentDays: tt.recentDays}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(contributors) != 1 {
+			t.Fatalf("got %d contributors, want 1", len(contributors))
+		}
+		c := contributors[0]
+		if c.CommitsRecent != tt.want || len(c.RecentCommits) != tt.want {
+			t.Errorf("--recent-days %d: %d recent commits (%d listed), want %d", tt.recentDays, c.CommitsRecent, len(c.RecentCommits), tt.want)
+		}
+		if c.CommitsLast90 != 3 {
+			t.Errorf("--recent-days %d: %d commits in 90 days, want 3", tt.recentDays, c.CommitsLast90)
+		}
+		if stats.RecentDays != tt.wantDays {
+			t.Errorf("--recent-days %d: window = %d days, want %d", tt.recentDays, stats.RecentDays, tt.wantDays)
+		}
+	}
+}
+
+func TestAnalyzeContributorsRef(t *testing.T) {
+	repo := gittest.New(t)
+	repo.When = time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	for _, author := range []string{"Alice", "Bob"} {
+		repo.WriteFile("file.txt", author)
+		repo.CommitAs(author, strings.ToLower(author)+"@example.com", "Commit by "+author)
+		// The release is cut before Bob's commit
+		if author == "Alice" {
+			repo.Tag("v1.0.0")
+		}
+	}
+
+	for _, tt := range []struct {
+		ref  string
+		want int
+	}{
+		{"", 2},
+		{"v1.0.0", 1},
+	} {
+		contributors, stats, err := analyzeContributors(ContributorsOptions{RepoPath: repo.Dir, NoCache: true, Ref: tt.ref}, nil)
+		if err != nil {
+			t.Fatalf("analyzeContributors(%q) error: %v", tt.ref, err)
+		}
+		if len(contributors) != tt.want || stats.TotalCommits != tt.want {
+			t.Errorf("ref %q: %d contributors with %d commits, want %d of each", tt.ref, len(contributors), stats.TotalCommits, tt.want)
+		}
+	}
+
+	if _, _, err := analyzeContributors(ContributorsOptions{RepoPath: repo.Dir, NoCache: true, Ref: "no-such-branch"}, nil); err == nil {
+		t.Error("analyzeContributors() of a ref that doesn't exist should fail")
+	}
+}
+
+func TestAnalyzeContributorsBotCoAuthors(t *testing.T) {
+	repo := gittest.New(t)
+	repo.WriteFile("file.txt", "pair")
+	repo.CommitAs("Alice", "alice@example.com", "Pair on a fix\n\n"+
+		"Co-authored-by: Bob <bob@example.com>\n"+
+		"Co-authored-by: dependabot[bot] <support@github.com>\n")
+
+	// The bot co-author gets no share, so the people's shares make up the whole commit
+	contributors, _, err := analyzeContributors(ContributorsOptions{RepoPath: repo.Dir, NoCache: true, ExcludeBots: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total float64
+	for _, c := range contributors {
+		if c.Name != "Alice" && c.Name != "Bob" {
+			t.Errorf("unexpected contributor %s", c.Name)
+		}
+		total += c.CreditedCommits
+	}
+	if len(contributors) != 2 || total != 1 {
+		t.Errorf("%d contributors credited with %v commits, want Alice and Bob with 1", len(contributors), total)
+	}
+}
+
+func TestAnalyzeContributorsExportCoAuthors(t *testing.T) {
+	repo := gittest.New(t)
+	repo.WriteFile("file.txt", "pair")
+	repo.CommitAs("Alice", "alice@example.com", "Pair on a fix\n\nCo-authored-by: Bob <bob@example.com>\n")
+
+	for _, credit := range []bool{false, true} {
+		for _, format := range []string{"csv", "json"} {
+			var out strings.Builder
+			opts := ContributorsOptions{RepoPath: repo.Dir, NoCache: true, CoAuthorCredit: credit}
+			if err := AnalyzeContributorsExport(format, &out, opts); err != nil {
+				t.Fatalf("AnalyzeContributorsExport(%s) error: %v", format, err)
+			}
+			if got := strings.Contains(out.String(), "bob@example.com"); got != credit {
+				t.Errorf("%s export with co-author credit %v lists Bob = %v, want %v:\n%s", format, credit, got, credit, out.String())
+			}
+		}
+	}
+}
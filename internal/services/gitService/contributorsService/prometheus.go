@@ -0,0 +1,29 @@
+package contributorsService
+
+import gitservice "github.com/redjax/syst/internal/services/gitService"
+
+// contributorMetrics are the metrics of --format prom, every one labeled with the repo.
+// Scrapers depend on their names, so they must not change:
+//
+//	git_contributors_total          contributors, bots excluded with --exclude-bots
+//	git_contributor_commits         commits of each contributor, labeled with contributor
+//	git_contributor_lines_added     lines each contributor added, labeled with contributor
+//	git_contributor_lines_deleted   lines each contributor deleted, labeled with contributor
+func contributorMetrics(contributors []ContributorData, overall OverallStats) []gitservice.PromMetric {
+	commits := gitservice.PromMetric{Name: "git_contributor_commits", Help: "Commits authored by each contributor."}
+	added := gitservice.PromMetric{Name: "git_contributor_lines_added", Help: "Lines added by each contributor."}
+	deleted := gitservice.PromMetric{Name: "git_contributor_lines_deleted", Help: "Lines deleted by each contributor."}
+	for _, c := range contributors {
+		labels := map[string]string{"contributor": c.Name}
+		commits.Samples = append(commits.Samples, gitservice.PromSample{Labels: labels, Value: float64(c.TotalCommits)})
+		added.Samples = append(added.Samples, gitservice.PromSample{Labels: labels, Value: float64(c.LinesAdded)})
+		deleted.Samples = append(deleted.Samples, gitservice.PromSample{Labels: labels, Value: float64(c.LinesDeleted)})
+	}
+
+	return []gitservice.PromMetric{
+		gitservice.Gauge("git_contributors_total", "Contributors in the analyzed history.", float64(overall.TotalContributors)),
+		commits,
+		added,
+		deleted,
+	}
+}
@@ -0,0 +1,52 @@
+package contributorsService
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// DefaultRecentDays is how many days back a commit counts as recent when no other
+// window is given
+const DefaultRecentDays = 30
+
+// quarterDays is the longer recency bucket counted per contributor during the history walk
+const quarterDays = 90
+
+// recentlyActive returns the contributors with the most recent commit first, so the
+// people most likely to respond to an issue or review are at the top
+func recentlyActive(contributors []ContributorData) []ContributorData {
+	sorted := make([]ContributorData, len(contributors))
+	copy(sorted, contributors)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LastCommit.After(sorted[j].LastCommit)
+	})
+	return sorted
+}
+
+// renderRecent renders the contributors by most recent commit, with their commits in
+// the recent window and the last 90 days
+func (m model) renderRecent() string {
+	var content strings.Builder
+	content.WriteString(headerStyle.Render("🙋 Most Recent Commits"))
+	content.WriteString("\n\n")
+
+	if len(m.contributors) == 0 {
+		content.WriteString("No contributors found\n")
+	}
+
+	now := time.Now()
+	for _, contributor := range recentlyActive(m.contributors) {
+		content.WriteString(fmt.Sprintf("%s %s\n", highlightStyle.Render(contributor.Name),
+			helpStyle.Render(fmt.Sprintf("last commit %s (%s)",
+				gitservice.HumanizeTime(contributor.LastCommit, now), contributor.LastCommit.Format("2006-01-02")))))
+		content.WriteString(fmt.Sprintf("  %s in %d days • %s in %d days\n",
+			statsStyle.Render(fmt.Sprintf("%d", contributor.CommitsRecent)), m.overallStats.RecentDays,
+			statsStyle.Render(fmt.Sprintf("%d", contributor.CommitsLast90)), quarterDays))
+	}
+
+	return sectionStyle.Render(content.String())
+}
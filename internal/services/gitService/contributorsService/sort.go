@@ -0,0 +1,23 @@
+package contributorsService
+
+import (
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// contributorSorts are the orders the contributor list can be cycled through with s. The
+// first is the analysis order: most commits first, or most credited commits when
+// co-author credit is on.
+var contributorSorts = []gitservice.ListSort[ContributorData]{
+	{Name: "commits"},
+	{Name: "lines changed", Less: func(a, b ContributorData) bool {
+		return a.LinesAdded+a.LinesDeleted > b.LinesAdded+b.LinesDeleted
+	}},
+	{Name: "files", Less: func(a, b ContributorData) bool { return a.FilesModified > b.FilesModified }},
+	{Name: "last active", Less: func(a, b ContributorData) bool { return a.LastCommit.After(b.LastCommit) }},
+	{Name: "first commit", Less: func(a, b ContributorData) bool { return a.FirstCommit.Before(b.FirstCommit) }},
+	{Name: "name", Less: func(a, b ContributorData) bool { return a.Name < b.Name }},
+}
+
+func (m model) contributorSort() gitservice.ListSort[ContributorData] {
+	return contributorSorts[m.sortBy]
+}
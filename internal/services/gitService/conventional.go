@@ -0,0 +1,51 @@
+package gitservice
+
+import (
+	"regexp"
+	"strings"
+)
+
+// conventionalHeader matches a Conventional Commit header: "type(scope)!: description"
+var conventionalHeader = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// ConventionalCommit is a commit message parsed per https://www.conventionalcommits.org
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+	// BreakingNote is the text of a BREAKING CHANGE footer, if any
+	BreakingNote string
+}
+
+// ParseConventionalCommit parses a commit message as a Conventional Commit. It reports
+// false if the subject is not in "type(scope)!: description" form, in which case only
+// Description is set, to the subject.
+func ParseConventionalCommit(message string) (ConventionalCommit, bool) {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	subject := strings.TrimSpace(lines[0])
+
+	match := conventionalHeader.FindStringSubmatch(subject)
+	if match == nil {
+		return ConventionalCommit{Description: subject}, false
+	}
+
+	commit := ConventionalCommit{
+		Type:        strings.ToLower(match[1]),
+		Scope:       strings.TrimSpace(match[2]),
+		Description: strings.TrimSpace(match[4]),
+		Breaking:    match[3] == "!",
+	}
+
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		for _, prefix := range []string{"BREAKING CHANGE:", "BREAKING-CHANGE:"} {
+			if note, ok := strings.CutPrefix(line, prefix); ok {
+				commit.Breaking = true
+				commit.BreakingNote = strings.TrimSpace(note)
+			}
+		}
+	}
+
+	return commit, true
+}
@@ -0,0 +1,61 @@
+package gitservice
+
+import (
+	"fmt"
+	"time"
+)
+
+// RelativeDates switches the dates the TUIs show to relative times, like "3 hours ago".
+// It is set from --relative-dates before a TUI starts. Reports always have absolute dates.
+var RelativeDates bool
+
+// HumanizeTime describes how long before now t was, i.e. "3 hours ago" or "2 weeks ago".
+// A t after now, like a commit from a machine with a fast clock, is "just now".
+func HumanizeTime(t, now time.Time) string {
+	elapsed := now.Sub(t)
+	days := int(elapsed.Hours() / 24)
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return plural(int(elapsed.Minutes()), "minute") + " ago"
+	case days < 1:
+		return plural(int(elapsed.Hours()), "hour") + " ago"
+	case days == 1:
+		return "yesterday"
+	case days < 14:
+		return fmt.Sprintf("%d days ago", days)
+	case days < 60:
+		return fmt.Sprintf("%d weeks ago", days/7)
+	case days < 365:
+		return fmt.Sprintf("%d months ago", days/30)
+	default:
+		return plural(days/365, "year") + " ago"
+	}
+}
+
+// plural counts n of unit, i.e. "1 hour" or "3 hours"
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// ListDate formats t for a list row: with layout, or as the time since t when
+// RelativeDates is set
+func ListDate(t time.Time, layout string) string {
+	if RelativeDates {
+		return HumanizeTime(t, time.Now())
+	}
+	return t.Format(layout)
+}
+
+// DetailDate formats t for a detail screen. The date is always shown with layout, so the
+// exact timestamp stays at hand, followed by the time since t when RelativeDates is set.
+func DetailDate(t time.Time, layout string) string {
+	if RelativeDates {
+		return fmt.Sprintf("%s (%s)", t.Format(layout), HumanizeTime(t, time.Now()))
+	}
+	return t.Format(layout)
+}
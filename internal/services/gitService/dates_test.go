@@ -0,0 +1,55 @@
+package gitservice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizeTime(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		when time.Time
+		want string
+	}{
+		{now.Add(time.Hour), "just now"},
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-time.Minute), "1 minute ago"},
+		{now.Add(-45 * time.Minute), "45 minutes ago"},
+		{now.Add(-time.Hour), "1 hour ago"},
+		{now.Add(-2 * time.Hour), "2 hours ago"},
+		{now.AddDate(0, 0, -1), "yesterday"},
+		{now.AddDate(0, 0, -3), "3 days ago"},
+		{now.AddDate(0, 0, -21), "3 weeks ago"},
+		{now.AddDate(0, 0, -100), "3 months ago"},
+		{now.AddDate(-1, -1, 0), "1 year ago"},
+		{now.AddDate(-3, 0, 0), "3 years ago"},
+	}
+	for _, tt := range tests {
+		if got := HumanizeTime(tt.when, now); got != tt.want {
+			t.Errorf("HumanizeTime(%s) = %q, want %q", tt.when.Format(time.RFC3339), got, tt.want)
+		}
+	}
+}
+
+func TestRelativeDates(t *testing.T) {
+	t.Cleanup(func() { RelativeDates = false })
+	when := time.Now().Add(-3 * time.Hour)
+	abs := when.Format("2006-01-02 15:04")
+
+	if got := ListDate(when, "2006-01-02 15:04"); got != abs {
+		t.Errorf("ListDate() = %q, want %q", got, abs)
+	}
+	if got := DetailDate(when, "2006-01-02 15:04"); got != abs {
+		t.Errorf("DetailDate() = %q, want %q", got, abs)
+	}
+
+	RelativeDates = true
+	if got := ListDate(when, "2006-01-02 15:04"); got != "3 hours ago" {
+		t.Errorf("relative ListDate() = %q, want 3 hours ago", got)
+	}
+	// Detail screens keep the absolute date
+	if got, want := DetailDate(when, "2006-01-02 15:04"), abs+" (3 hours ago)"; got != want {
+		t.Errorf("relative DetailDate() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,184 @@
+package diffService
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// maxDiffAuthors caps the contributors listed in the stats view
+const maxDiffAuthors = 10
+
+// AuthorDiffStat is one author's share of the changes in a diff
+type AuthorDiffStat struct {
+	Name      string
+	Email     string
+	Commits   int
+	Additions int
+	Deletions int
+}
+
+// DiffAuthors attributes a diff to the commits in the range that touched its files
+type DiffAuthors struct {
+	Authors   []AuthorDiffStat // Sorted by lines changed, most first
+	Commits   int
+	FirstDate time.Time
+	LastDate  time.Time
+}
+
+// analyzeDiffAuthors walks the commits reachable from to but not from, like
+// 'git log from..to', and credits their changes to the files in the diff to each
+// commit's author. Merge commits are skipped, as their changes are already counted in
+// the commits they merge.
+//
+// Lines are summed per commit, so they can add up to more than the diff itself when
+// later commits rework earlier ones.
+func analyzeDiffAuthors(repo *git.Repository, from, to plumbing.Hash, files []FileDiff, stats *gitservice.CommitStatsCache) (*DiffAuthors, error) {
+	paths := make(map[string]bool, len(files))
+	for _, file := range files {
+		paths[file.Path] = true
+		if file.OldPath != "" {
+			paths[file.OldPath] = true
+		}
+	}
+
+	excluded := make(map[plumbing.Hash]bool)
+	fromIter, err := repo.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log: %w", err)
+	}
+	err = fromIter.ForEach(func(c *object.Commit) error {
+		excluded[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mailmap, err := gitservice.LoadRepoMailmap(repo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log: %w", err)
+	}
+
+	result := &DiffAuthors{}
+	byAuthor := make(map[string]*AuthorDiffStat)
+	err = iter.ForEach(func(c *object.Commit) error {
+		if excluded[c.Hash] || c.NumParents() > 1 {
+			return nil
+		}
+
+		fileStats, err := stats.Stats(c)
+		if err != nil {
+			return nil
+		}
+
+		additions, deletions, touched := 0, 0, false
+		for _, fs := range fileStats {
+			if !paths[fs.Name] {
+				continue
+			}
+			touched = true
+			additions += fs.Addition
+			deletions += fs.Deletion
+		}
+		if !touched {
+			return nil
+		}
+
+		name, email := mailmap.Canonicalize(c.Author.Name, c.Author.Email)
+		key := strings.ToLower(email)
+		author, ok := byAuthor[key]
+		if !ok {
+			author = &AuthorDiffStat{Name: name, Email: email}
+			byAuthor[key] = author
+		}
+		author.Commits++
+		author.Additions += additions
+		author.Deletions += deletions
+
+		result.Commits++
+		when := c.Author.When
+		if result.FirstDate.IsZero() || when.Before(result.FirstDate) {
+			result.FirstDate = when
+		}
+		if when.After(result.LastDate) {
+			result.LastDate = when
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, author := range byAuthor {
+		result.Authors = append(result.Authors, *author)
+	}
+	sort.Slice(result.Authors, func(i, j int) bool {
+		a, b := result.Authors[i], result.Authors[j]
+		if a.Additions+a.Deletions != b.Additions+b.Deletions {
+			return a.Additions+a.Deletions > b.Additions+b.Deletions
+		}
+		return a.Name < b.Name
+	})
+
+	return result, nil
+}
+
+// renderAuthors renders the top contributors box of the stats view
+func renderAuthors(authors *DiffAuthors) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(theme.Current.Subtle).
+		Padding(1, 2).
+		MarginBottom(1)
+
+	var b strings.Builder
+	b.WriteString("👥 Top Contributors:\n\n")
+
+	if len(authors.Authors) == 0 {
+		b.WriteString("  No commits in the range touched these files\n")
+		return style.Render(b.String())
+	}
+
+	shown := authors.Authors
+	if len(shown) > maxDiffAuthors {
+		shown = shown[:maxDiffAuthors]
+	}
+	for _, author := range shown {
+		commits := "commits"
+		if author.Commits == 1 {
+			commits = "commit"
+		}
+		b.WriteString(fmt.Sprintf("  %-24s +%-6d -%-6d %d %s\n",
+			truncate(author.Name, 24), author.Additions, author.Deletions, author.Commits, commits))
+	}
+	if hidden := len(authors.Authors) - len(shown); hidden > 0 {
+		b.WriteString(fmt.Sprintf("  ...and %d more\n", hidden))
+	}
+
+	b.WriteString(fmt.Sprintf("\n📅 %d commits from %s to %s\n", authors.Commits,
+		authors.FirstDate.Format("2006-01-02"), authors.LastDate.Format("2006-01-02")))
+
+	return style.Render(b.String())
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,65 @@
+package diffService
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestAnalyzeDiffAuthors(t *testing.T) {
+	repo := gittest.New(t)
+	repo.Step = 24 * time.Hour
+	commit := func(author, name, content string) {
+		t.Helper()
+		repo.WriteFile(name, content)
+		repo.CommitAs(author, author+"@example.com", "Update "+name)
+	}
+
+	commit("alice", "a.go", "one\n")
+	commit("bob", "a.go", "one\ntwo\nthree\n")
+	commit("alice", "b.go", "one\n")
+	commit("carol", "a.go", "one\ntwo\nthree\nfour\n")
+
+	analysis, err := analyzeDiff("HEAD~3", "HEAD", DiffOptions{RepoPath: repo.Dir, Authors: true, NoCache: true})
+	if err != nil {
+		t.Fatalf("analyzeDiff() error: %v", err)
+	}
+
+	authors := analysis.Authors
+	if authors == nil {
+		t.Fatal("Authors is nil with DiffOptions.Authors set")
+	}
+	if authors.Commits != 3 {
+		t.Errorf("Commits = %d, want 3", authors.Commits)
+	}
+	if got := authors.FirstDate.Format("2006-01-02"); got != "2026-01-03" {
+		t.Errorf("FirstDate = %s, want 2026-01-03", got)
+	}
+	if got := authors.LastDate.Format("2006-01-02"); got != "2026-01-05" {
+		t.Errorf("LastDate = %s, want 2026-01-05", got)
+	}
+
+	want := []AuthorDiffStat{
+		{Name: "bob", Email: "bob@example.com", Commits: 1, Additions: 2},
+		{Name: "alice", Email: "alice@example.com", Commits: 1, Additions: 1},
+		{Name: "carol", Email: "carol@example.com", Commits: 1, Additions: 1},
+	}
+	if len(authors.Authors) != len(want) {
+		t.Fatalf("got %d authors, want %d: %+v", len(authors.Authors), len(want), authors.Authors)
+	}
+	for i := range want {
+		if authors.Authors[i] != want[i] {
+			t.Errorf("author %d = %+v, want %+v", i, authors.Authors[i], want[i])
+		}
+	}
+
+	// Without the option, no log walk is done
+	analysis, err = analyzeDiff("HEAD~3", "HEAD", DiffOptions{RepoPath: repo.Dir})
+	if err != nil {
+		t.Fatalf("analyzeDiff() error: %v", err)
+	}
+	if analysis.Authors != nil {
+		t.Error("Authors is set without DiffOptions.Authors")
+	}
+}
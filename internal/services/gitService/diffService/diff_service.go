@@ -1,7 +1,9 @@
 package diffService
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -10,11 +12,12 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	"github.com/redjax/syst/internal/utils/terminal"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type ViewMode int
@@ -26,14 +29,53 @@ const (
 	StatsView
 )
 
+// DiffOptions controls how a diff is computed
+type DiffOptions struct {
+	// RepoPath is the repository to diff; empty means the current directory
+	RepoPath string
+	// IgnoreWhitespace drops changes that only differ in leading/trailing whitespace or indentation
+	IgnoreWhitespace bool
+	// Authors credits the changed lines to the authors of the commits in the range
+	Authors bool
+	// NoCache skips the commit stats cache used by Authors
+	NoCache bool
+	// ContextLines is how many unchanged lines are shown around changes; 0 uses
+	// DefaultContextLines
+	ContextLines int
+	// Patch prints the diff as a patch git apply accepts instead of launching the TUI
+	Patch bool
+}
+
+// DefaultContextLines is git's default number of context lines around changes
+const DefaultContextLines = fdiff.DefaultContextLines
+
+// contextLines returns the number of context lines to show
+func (o DiffOptions) contextLines() int {
+	if o.ContextLines <= 0 {
+		return DefaultContextLines
+	}
+	return o.ContextLines
+}
+
+// unifiedDiff renders patch as a unified diff with opts' context lines
+func unifiedDiff(patch fdiff.Patch, opts DiffOptions) (string, error) {
+	var buf bytes.Buffer
+	if err := fdiff.NewUnifiedEncoder(&buf, opts.contextLines()).Encode(patch); err != nil {
+		return "", fmt.Errorf("failed to build patch: %w", err)
+	}
+	return buf.String(), nil
+}
+
 type DiffAnalysis struct {
-	FromRef      string
-	ToRef        string
-	FromCommit   string
-	ToCommit     string
-	FilesChanged []FileDiff
-	Stats        DiffStats
-	Summary      string
+	FromRef          string
+	ToRef            string
+	FromCommit       string
+	ToCommit         string
+	FilesChanged     []FileDiff
+	Stats            DiffStats
+	Summary          string
+	IgnoreWhitespace bool
+	Authors          *DiffAuthors // Set when DiffOptions.Authors is
 }
 
 type FileDiff struct {
@@ -70,13 +112,20 @@ type model struct {
 	// UI components
 	overviewList list.Model
 	filesList    list.Model
+	listDelegate list.ItemDelegate
 	searchInput  textinput.Model
 
 	// UI state
 	loading    bool
 	err        error
-	tuiHelper *terminal.ResponsiveTUIHelper
+	tuiHelper  *terminal.ResponsiveTUIHelper
 	showSearch bool
+	opts       DiffOptions
+	keys       terminal.KeyMap
+	// fromRef and toRef are what is compared, as given, so a failed load can be retried
+	fromRef, toRef string
+	// onDisk is set when comparing two files on disk rather than refs, see RunFileDiff
+	onDisk bool
 }
 
 // Messages
@@ -88,11 +137,12 @@ type errMsg struct {
 	err error
 }
 
-// RunDiffExplorer starts the interactive diff explorer TUI
-func RunDiffExplorer(args []string) error {
-	// Parse arguments to determine what to compare
-	fromRef := "HEAD^"
-	toRef := "HEAD"
+// diffRefs returns what the arguments compare. The refs default to HEAD^ and HEAD, and
+// a single "ref:path" is compared against the same file in the working tree of the
+// repository at repoPath.
+func diffRefs(args []string, repoPath string) (fromRef, toRef string, err error) {
+	fromRef = "HEAD^"
+	toRef = "HEAD"
 
 	if len(args) >= 1 {
 		fromRef = args[0]
@@ -100,37 +150,77 @@ func RunDiffExplorer(args []string) error {
 	if len(args) >= 2 {
 		toRef = args[1]
 	}
+	if len(args) == 1 && isFileDiff(fromRef, "") {
+		toRef, err = worktreeSpec(repoPath, parseFileSpec(fromRef).Path)
+	}
+	return fromRef, toRef, err
+}
+
+// RunDiffExplorer starts the interactive diff explorer TUI, or prints the diff as a
+// patch when opts.Patch is set
+func RunDiffExplorer(args []string, opts DiffOptions) error {
+	fromRef, toRef, err := diffRefs(args, opts.RepoPath)
+	if err != nil {
+		return err
+	}
+	// Files from the working tree can be compared before the first commit
+	if !isFileDiff(fromRef, toRef) {
+		if err := gitservice.CheckHistory(opts.RepoPath); err != nil {
+			return err
+		}
+	}
 
-	// Initialize model
+	if opts.Patch {
+		return writePatch(os.Stdout, fromRef, toRef, opts)
+	}
+
+	if err := terminal.RequireTTY("--patch"); err != nil {
+		return err
+	}
+
+	m := newModel(opts)
+	m.fromRef, m.toRef = fromRef, toRef
+
+	// Start the TUI
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+
+	// Load diff analysis
+	go func() {
+		p.Send(loadDiffAnalysis(fromRef, toRef, opts))
+	}()
+
+	_, err = p.Run()
+	return err
+}
+
+// newModel returns the diff viewer, loading until the analysis arrives
+func newModel(opts DiffOptions) model {
 	m := model{
 		currentView: OverviewView,
 		loading:     true,
-		tuiHelper: terminal.NewResponsiveTUIHelper(),
+		tuiHelper:   terminal.NewResponsiveTUIHelper(),
+		keys:        terminal.Keys(),
+		opts:        opts,
 	}
 
 	// Initialize UI components
-	m.overviewList = list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	m.listDelegate = list.NewDefaultDelegate()
+	m.overviewList = list.New([]list.Item{}, m.listDelegate, 0, 0)
 	m.overviewList.Title = "📊 Diff Overview"
 	m.overviewList.SetShowHelp(false)
 
-	m.filesList = list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	m.filesList = list.New([]list.Item{}, m.listDelegate, 0, 0)
 	m.filesList.Title = "📁 Changed Files"
 	m.filesList.SetShowHelp(false)
 
+	for _, l := range []*list.Model{&m.overviewList, &m.filesList} {
+		m.keys.ApplyToList(l)
+	}
+
 	m.searchInput = textinput.New()
 	m.searchInput.Placeholder = "Search files..."
 	m.searchInput.CharLimit = 100
-
-	// Start the TUI
-	p := tea.NewProgram(m, tea.WithAltScreen())
-
-	// Load diff analysis
-	go func() {
-		p.Send(loadDiffAnalysis(fromRef, toRef))
-	}()
-
-	_, err := p.Run()
-	return err
+	return m
 }
 
 func (m model) Init() tea.Cmd {
@@ -172,17 +262,59 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.filesList.SetItems(fileItems)
 
+		// Keep showing the open file, i.e. after changing the context lines
+		for i, file := range m.analysis.FilesChanged {
+			if file.Path == m.selectedFile.Path {
+				m.selectedFile = file
+				m.selectedFileIdx = i
+				break
+			}
+		}
+		// Two files on disk open straight on their diff
+		if m.onDisk && m.selectedFile.Path == "" && len(m.analysis.FilesChanged) > 0 {
+			m.selectedFile = m.analysis.FilesChanged[0]
+			m.currentView = DiffView
+		}
+
 	case errMsg:
 		m.loading = false
 		m.err = msg.err
 
+	case tea.MouseMsg:
+		if m.loading || m.showSearch {
+			return m, nil
+		}
+		switch m.currentView {
+		case OverviewView:
+			terminal.HandleListMouse(&m.overviewList, m.listDelegate, m.listTop(), msg)
+		case FilesView:
+			if terminal.HandleListMouse(&m.filesList, m.listDelegate, m.listTop(), msg) {
+				// Clicking a file opens its diff, like pressing enter
+				return m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			}
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		// The error screen can only retry the load or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				m.err = nil
+				m.loading = true
+				return m, m.reload(m.opts)
+			case key.Matches(msg, m.keys.Quit):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
 		// Handle global keys first
 		switch {
-		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		case key.Matches(msg, m.keys.Back):
 			if m.showSearch {
 				m.showSearch = false
 				m.searchInput.Blur()
@@ -193,7 +325,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("/"))):
+		case key.Matches(msg, m.keys.Filter):
 			if m.currentView == FilesView {
 				m.showSearch = !m.showSearch
 				if m.showSearch {
@@ -222,11 +354,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentView = StatsView
 			return m, nil
 
-		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		case key.Matches(msg, m.keys.Refresh):
 			m.loading = true
-			return m, func() tea.Msg {
-				return loadDiffAnalysis(m.analysis.FromRef, m.analysis.ToRef)
-			}
+			return m, m.reload(m.opts)
 		}
 
 		// Handle view-specific keys
@@ -236,13 +366,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Perform search
 				query := m.searchInput.Value()
 				if query != "" {
-					// Filter file list
-					filteredFiles := make([]FileDiff, 0)
-					for _, file := range m.analysis.FilesChanged {
-						if strings.Contains(strings.ToLower(file.Path), strings.ToLower(query)) {
-							filteredFiles = append(filteredFiles, file)
-						}
-					}
+					// Fuzzy-match the changed files, best match first
+					filteredFiles := terminal.FuzzySort(query, m.analysis.FilesChanged, func(f FileDiff) string { return f.Path })
 					items := make([]list.Item, len(filteredFiles))
 					for i, file := range filteredFiles {
 						items[i] = FileDiffItem{diff: file}
@@ -263,7 +388,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case FilesView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			case key.Matches(msg, m.keys.Select):
 				if item, ok := m.filesList.SelectedItem().(FileDiffItem); ok {
 					m.selectedFile = item.diff
 					m.selectedFileIdx = m.filesList.Index()
@@ -275,18 +400,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case DiffView:
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+			case key.Matches(msg, m.keys.Left):
 				if m.selectedFileIdx > 0 {
 					m.selectedFileIdx--
 					m.selectedFile = m.analysis.FilesChanged[m.selectedFileIdx]
 				}
 				return m, nil
-			case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
+			case key.Matches(msg, m.keys.Right):
 				if m.selectedFileIdx < len(m.analysis.FilesChanged)-1 {
 					m.selectedFileIdx++
 					m.selectedFile = m.analysis.FilesChanged[m.selectedFileIdx]
 				}
 				return m, nil
+			case msg.String() == "+" || msg.String() == "=" || msg.String() == "-":
+				// Recompute the diff in the background, the current one stays on screen
+				// until it is done
+				contextLines := m.opts.contextLines() + 1
+				if msg.String() == "-" {
+					contextLines = max(1, contextLines-2)
+				}
+				m.opts.ContextLines = contextLines
+				return m, m.reload(m.opts)
 			}
 
 		case StatsView:
@@ -321,27 +455,42 @@ func (m model) View() string {
 	}
 }
 
-func loadDiffAnalysis(fromRef, toRef string) tea.Msg {
-	analysis, err := analyzeDiff(fromRef, toRef)
+// reload recomputes the diff with opts
+func (m model) reload(opts DiffOptions) tea.Cmd {
+	fromRef, toRef := m.fromRef, m.toRef
+	if m.onDisk {
+		return func() tea.Msg { return loadDiskDiff(fromRef, toRef, opts) }
+	}
+	return func() tea.Msg { return loadDiffAnalysis(fromRef, toRef, opts) }
+}
+
+func loadDiffAnalysis(fromRef, toRef string, opts DiffOptions) tea.Msg {
+	analysis, err := analyzeDiff(fromRef, toRef, opts)
 	if err != nil {
 		return errMsg{err}
 	}
 	return diffAnalysisMsg{analysis}
 }
 
-func analyzeDiff(fromRef, toRef string) (DiffAnalysis, error) {
-	repo, err := git.PlainOpen(".")
+// analyzeDiff compares two commits, or two single files if either side uses the
+// "ref:path" syntax.
+func analyzeDiff(fromRef, toRef string, opts DiffOptions) (DiffAnalysis, error) {
+	if isFileDiff(fromRef, toRef) {
+		return analyzeFileDiff(fromRef, toRef, opts)
+	}
+
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
 	if err != nil {
 		return DiffAnalysis{}, err
 	}
 
 	// Resolve references to commits
-	fromCommit, err := resolveRef(repo, fromRef)
+	fromCommit, err := gitservice.ResolveRef(repo, fromRef)
 	if err != nil {
 		return DiffAnalysis{}, fmt.Errorf("failed to resolve '%s': %w", fromRef, err)
 	}
 
-	toCommit, err := resolveRef(repo, toRef)
+	toCommit, err := gitservice.ResolveRef(repo, toRef)
 	if err != nil {
 		return DiffAnalysis{}, fmt.Errorf("failed to resolve '%s': %w", toRef, err)
 	}
@@ -380,7 +529,14 @@ func analyzeDiff(fromRef, toRef string) (DiffAnalysis, error) {
 	totalDeletions := 0
 
 	for _, change := range changes {
-		fileDiff := processFileDiff(change)
+		fileDiff := processFileDiff(change, opts)
+
+		// Skip files whose only modifications were whitespace
+		if opts.IgnoreWhitespace && fileDiff.Status == "modified" && !fileDiff.IsBinary &&
+			fileDiff.Additions == 0 && fileDiff.Deletions == 0 {
+			continue
+		}
+
 		filesChanged = append(filesChanged, fileDiff)
 		totalAdditions += fileDiff.Additions
 		totalDeletions += fileDiff.Deletions
@@ -399,37 +555,35 @@ func analyzeDiff(fromRef, toRef string) (DiffAnalysis, error) {
 	}
 
 	summary := fmt.Sprintf("Comparing %s → %s", fromRef, toRef)
-
-	return DiffAnalysis{
-		FromRef:      fromRef,
-		ToRef:        toRef,
-		FromCommit:   fromCommit.String(),
-		ToCommit:     toCommit.String(),
-		FilesChanged: filesChanged,
-		Stats:        stats,
-		Summary:      summary,
-	}, nil
-}
-
-func resolveRef(repo *git.Repository, ref string) (plumbing.Hash, error) {
-	// Try to resolve as a hash first
-	if hash := plumbing.NewHash(ref); hash.IsZero() == false {
-		// Check if it's a valid commit
-		if _, err := repo.CommitObject(hash); err == nil {
-			return hash, nil
-		}
+	if opts.IgnoreWhitespace {
+		summary += " (ignoring whitespace)"
 	}
 
-	// Try to resolve as a reference
-	resolved, err := repo.ResolveRevision(plumbing.Revision(ref))
-	if err != nil {
-		return plumbing.ZeroHash, err
+	var authors *DiffAuthors
+	if opts.Authors {
+		statsCache := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+		authors, err = analyzeDiffAuthors(repo, fromCommit, toCommit, filesChanged, statsCache)
+		if err != nil {
+			return DiffAnalysis{}, fmt.Errorf("failed to analyze authors: %w", err)
+		}
+		// #nosec G104 - A failed cache write only means the next run recomputes stats
+		statsCache.Save()
 	}
 
-	return *resolved, nil
+	return DiffAnalysis{
+		FromRef:          fromRef,
+		ToRef:            toRef,
+		FromCommit:       fromCommit.String(),
+		ToCommit:         toCommit.String(),
+		FilesChanged:     filesChanged,
+		Stats:            stats,
+		Summary:          summary,
+		IgnoreWhitespace: opts.IgnoreWhitespace,
+		Authors:          authors,
+	}, nil
 }
 
-func processFileDiff(change *object.Change) FileDiff {
+func processFileDiff(change *object.Change, opts DiffOptions) FileDiff {
 	// Determine status and paths
 	var status, path, oldPath string
 	var additions, deletions int
@@ -454,14 +608,10 @@ func processFileDiff(change *object.Change) FileDiff {
 		path = change.To.Name
 	}
 
-	// Get patch for line counts (simplified)
+	// Get patch for line counts
 	patch, err := change.Patch()
 	if err == nil {
-		stats := patch.Stats()
-		if len(stats) > 0 {
-			additions = stats[0].Addition
-			deletions = stats[0].Deletion
-		}
+		additions, deletions = fileStat(patch.Stats(), path, oldPath)
 	}
 
 	// Check if binary file
@@ -473,7 +623,16 @@ func processFileDiff(change *object.Change) FileDiff {
 	// Generate diff lines for display (simplified)
 	var diffLines []DiffLine
 	if !isBinary && patch != nil {
-		diffLines = generateDiffLines(patch.String())
+		if text, err := unifiedDiff(patch, opts); err == nil {
+			if opts.IgnoreWhitespace {
+				// Recount from the filtered lines so stats match what is displayed
+				allLines := dropWhitespaceChanges(parseDiffLines(text))
+				additions, deletions = countDiffLines(allLines)
+				diffLines = truncateDiffLines(allLines)
+			} else {
+				diffLines = generateDiffLines(text)
+			}
+		}
 	}
 
 	return FileDiff{
@@ -487,9 +646,30 @@ func processFileDiff(change *object.Change) FileDiff {
 	}
 }
 
+// fileStat returns the additions and deletions of path in stats, matched by name rather
+// than position. Renames are named "old => new", like go-git names them.
+func fileStat(stats object.FileStats, path, oldPath string) (int, int) {
+	name := path
+	if oldPath != "" {
+		name = oldPath + " => " + path
+	}
+	for _, stat := range stats {
+		if stat.Name == name {
+			return stat.Addition, stat.Deletion
+		}
+	}
+	return 0, 0
+}
+
 func generateDiffLines(patchStr string) []DiffLine {
+	return truncateDiffLines(parseDiffLines(patchStr))
+}
+
+// parseDiffLines converts a patch string into typed diff lines. CRLF endings and
+// non-UTF-8 content are converted for display with gitservice.DisplayText.
+func parseDiffLines(patchStr string) []DiffLine {
 	var lines []DiffLine
-	patchLines := strings.Split(patchStr, "\n")
+	patchLines := strings.Split(gitservice.DisplayText(patchStr), "\n")
 
 	oldLine := 0
 	newLine := 0
@@ -535,7 +715,10 @@ func generateDiffLines(patchStr string) []DiffLine {
 				}
 			}
 		case '@':
-			// Hunk header
+			// Hunk header, which numbers the lines after it
+			if hunk, ok := parseHunkHeader(line); ok {
+				oldLine, newLine = hunk.OldStart-1, hunk.NewStart-1
+			}
 			diffLine = DiffLine{
 				Type:    "header",
 				Content: line,
@@ -553,18 +736,122 @@ func generateDiffLines(patchStr string) []DiffLine {
 		}
 
 		lines = append(lines, diffLine)
+	}
 
-		// Limit lines to avoid overwhelming the UI
-		if len(lines) > 200 {
-			lines = append(lines, DiffLine{
-				Type:    "header",
-				Content: "... (truncated, showing first 200 lines)",
-			})
-			break
+	return lines
+}
+
+// truncateDiffLines limits lines to avoid overwhelming the UI
+func truncateDiffLines(lines []DiffLine) []DiffLine {
+	if len(lines) <= 200 {
+		return lines
+	}
+
+	truncated := append([]DiffLine{}, lines[:201]...)
+	return append(truncated, DiffLine{
+		Type:    "header",
+		Content: "... (truncated, showing first 200 lines)",
+	})
+}
+
+// countDiffLines returns the number of added and deleted lines
+func countDiffLines(lines []DiffLine) (int, int) {
+	additions, deletions := 0, 0
+	for _, line := range lines {
+		switch line.Type {
+		case "added":
+			additions++
+		case "deleted":
+			deletions++
 		}
 	}
+	return additions, deletions
+}
 
-	return lines
+// normalizeWhitespace strips the diff marker and surrounding whitespace from a line
+func normalizeWhitespace(content string) string {
+	if len(content) > 0 {
+		content = content[1:]
+	}
+	return strings.TrimSpace(content)
+}
+
+// dropWhitespaceChanges removes deleted/added line pairs within a hunk that only
+// differ in leading/trailing whitespace. The surviving added line is kept as context.
+// Hunks left without any real changes are removed entirely.
+func dropWhitespaceChanges(lines []DiffLine) []DiffLine {
+	var result []DiffLine
+	var hunk []DiffLine
+	inHunk := false
+
+	flush := func() {
+		if !inHunk {
+			result = append(result, hunk...)
+		} else if filtered, changed := filterWhitespaceHunk(hunk); changed {
+			result = append(result, filtered...)
+		}
+		hunk = nil
+	}
+
+	for _, line := range lines {
+		if line.Type == "header" && strings.HasPrefix(line.Content, "@@") {
+			flush()
+			inHunk = true
+		}
+		hunk = append(hunk, line)
+	}
+	flush()
+
+	// Drop the file headers if no hunks survived
+	for _, line := range result {
+		if line.Type == "added" || line.Type == "deleted" {
+			return result
+		}
+	}
+	return nil
+}
+
+// filterWhitespaceHunk filters a single hunk and reports whether it still contains changes
+func filterWhitespaceHunk(hunk []DiffLine) ([]DiffLine, bool) {
+	// Index deleted lines by their normalized content
+	deleted := make(map[string][]int)
+	for i, line := range hunk {
+		if line.Type == "deleted" {
+			key := normalizeWhitespace(line.Content)
+			deleted[key] = append(deleted[key], i)
+		}
+	}
+
+	dropped := make(map[int]bool)
+	filtered := make([]DiffLine, len(hunk))
+	copy(filtered, hunk)
+
+	for i, line := range hunk {
+		if line.Type != "added" {
+			continue
+		}
+		key := normalizeWhitespace(line.Content)
+		if idx := deleted[key]; len(idx) > 0 {
+			dropped[idx[0]] = true
+			deleted[key] = idx[1:]
+			filtered[i].Type = "context"
+			filtered[i].Content = " " + line.Content[1:]
+		}
+	}
+
+	var kept []DiffLine
+	changed := false
+	for i, line := range filtered {
+		if dropped[i] {
+			continue
+		}
+		if line.Type == "added" || line.Type == "deleted" {
+			changed = true
+		}
+		kept = append(kept, line)
+	}
+
+	return kept, changed
 }
 
 // List item types
@@ -616,7 +903,7 @@ func (f FileDiffItem) FilterValue() string {
 func (m model) renderLoading() string {
 	style := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginTop(2).
 		MarginLeft(2)
 
@@ -626,31 +913,34 @@ func (m model) renderLoading() string {
 func (m model) renderError() string {
 	style := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("196")).
+		Foreground(theme.Current.Error).
 		MarginTop(2).
 		MarginLeft(2)
 
-	return style.Render(fmt.Sprintf("❌ Error: %v", m.err))
+	helpStyle := lipgloss.NewStyle().Foreground(theme.Current.Muted).MarginLeft(2)
+
+	return style.Render(fmt.Sprintf("❌ Error: %v", m.err)) + "\n\n" + helpStyle.Render(m.keys.ErrorHelp())
 }
 
-func (m model) renderOverview() string {
+// renderListHeader renders the title and optional search box drawn above the overview
+// and files lists.
+func (m model) renderListHeader(title string) string {
 	var content strings.Builder
 
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
-	title := fmt.Sprintf("📊 Diff Overview: %s → %s", m.analysis.FromRef, m.analysis.ToRef)
 	content.WriteString(headerStyle.Render(title))
 	content.WriteString("\n")
 
-	// Overview list
+	// Search input
 	if m.showSearch {
 		searchStyle := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("39")).
+			BorderForeground(theme.Current.Border).
 			Padding(0, 1).
 			MarginBottom(1)
 
@@ -658,15 +948,32 @@ func (m model) renderOverview() string {
 		content.WriteString("\n")
 	}
 
+	return content.String()
+}
+
+// listTop returns the screen row where the overview and files lists start.
+func (m model) listTop() int {
+	return strings.Count(m.renderListHeader(""), "\n")
+}
+
+func (m model) renderOverview() string {
+	var content strings.Builder
+
+	title := fmt.Sprintf("📊 Diff Overview: %s → %s", m.analysis.FromRef, m.analysis.ToRef)
+	content.WriteString(m.renderListHeader(title))
+
+	// Overview list
 	content.WriteString(m.overviewList.View())
 	content.WriteString("\n")
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: files • 3: diff • 4: stats • /: search • r: refresh • q: quit"
+	help := terminal.HelpLine("1: overview", "2: files", "3: diff", "4: stats",
+		terminal.Help(m.keys.Filter, "search"), terminal.Help(m.keys.Refresh, "refresh"),
+		terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -675,27 +982,8 @@ func (m model) renderOverview() string {
 func (m model) renderFilesView() string {
 	var content strings.Builder
 
-	// Header
-	headerStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("39")).
-		MarginBottom(1)
-
 	title := fmt.Sprintf("📁 Changed Files (%d files)", len(m.analysis.FilesChanged))
-	content.WriteString(headerStyle.Render(title))
-	content.WriteString("\n")
-
-	// Search input
-	if m.showSearch {
-		searchStyle := lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("39")).
-			Padding(0, 1).
-			MarginBottom(1)
-
-		content.WriteString(searchStyle.Render("🔍 " + m.searchInput.View()))
-		content.WriteString("\n")
-	}
+	content.WriteString(m.renderListHeader(title))
 
 	// Files list
 	content.WriteString(m.filesList.View())
@@ -703,10 +991,12 @@ func (m model) renderFilesView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: files • 3: diff • enter: view diff • /: search • r: refresh • q: quit"
+	help := terminal.HelpLine("1: overview", "2: files", "3: diff", terminal.Help(m.keys.Select, "view diff"),
+		terminal.Help(m.keys.Filter, "search"), terminal.Help(m.keys.Refresh, "refresh"),
+		terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -718,7 +1008,7 @@ func (m model) renderDiffView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	statusIcon := "📝"
@@ -741,7 +1031,7 @@ func (m model) renderDiffView() string {
 
 	// File stats
 	statsStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("242")).
+		Foreground(theme.Current.Muted).
 		MarginBottom(1)
 
 	fileNavigation := fmt.Sprintf("File %d of %d", m.selectedFileIdx+1, len(m.analysis.FilesChanged))
@@ -756,7 +1046,7 @@ func (m model) renderDiffView() string {
 	// Diff content
 	if m.selectedFile.IsBinary {
 		binaryStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(theme.Current.Muted).
 			Italic(true).
 			MarginBottom(1)
 		content.WriteString(binaryStyle.Render("📄 Binary file - no diff preview available"))
@@ -764,7 +1054,7 @@ func (m model) renderDiffView() string {
 	} else if len(m.selectedFile.Changes) > 0 {
 		diffStyle := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("238")).
+			BorderForeground(theme.Current.Subtle).
 			Padding(1, 2).
 			MaxHeight(m.tuiHelper.GetHeight() - 10)
 
@@ -772,20 +1062,20 @@ func (m model) renderDiffView() string {
 
 		for i, line := range m.selectedFile.Changes {
 			if i > 50 { // Limit display to avoid overwhelming
-				diff.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("... (showing first 50 lines, use git for full diff)\n"))
+				diff.WriteString(lipgloss.NewStyle().Foreground(theme.Current.Muted).Render("... (showing first 50 lines, use git for full diff)\n"))
 				break
 			}
 
 			var lineStyle lipgloss.Style
 			switch line.Type {
 			case "added":
-				lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("34")) // green
+				lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Success)
 			case "deleted":
-				lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("31")) // red
+				lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Error)
 			case "context":
-				lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("245")) // gray
+				lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Muted)
 			case "header":
-				lineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Bold(true) // yellow
+				lineStyle = lipgloss.NewStyle().Foreground(theme.Current.Info).Bold(true)
 			default:
 				lineStyle = lipgloss.NewStyle()
 			}
@@ -798,7 +1088,7 @@ func (m model) renderDiffView() string {
 	} else {
 		// No changes to show
 		noChangesStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(theme.Current.Muted).
 			Italic(true).
 			MarginBottom(1)
 
@@ -818,10 +1108,13 @@ func (m model) renderDiffView() string {
 
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: files • ←/→: prev/next file • esc: back • q: quit"
+	help := terminal.HelpLine("1: overview", "2: files",
+		terminal.HelpKey(m.keys.Left, m.keys.Right)+": prev/next file",
+		fmt.Sprintf("+/-: context (%d lines)", m.opts.contextLines()), terminal.Help(m.keys.Back, "back"),
+		terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -833,7 +1126,7 @@ func (m model) renderStatsView() string {
 	// Header
 	headerStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Current.Primary).
 		MarginBottom(1)
 
 	title := "📊 Diff Statistics"
@@ -843,7 +1136,7 @@ func (m model) renderStatsView() string {
 	// Statistics
 	statsStyle := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("238")).
+		BorderForeground(theme.Current.Subtle).
 		Padding(1, 2).
 		MarginBottom(1)
 
@@ -853,8 +1146,11 @@ func (m model) renderStatsView() string {
 	stats.WriteString(fmt.Sprintf("➖ Lines Deleted: %d\n", m.analysis.Stats.Deletions))
 	stats.WriteString(fmt.Sprintf("🔄 Total Changes: %d\n", m.analysis.Stats.TotalChanges))
 	stats.WriteString("\n")
-	stats.WriteString(fmt.Sprintf("📝 From: %s (%s)\n", m.analysis.FromRef, m.analysis.FromCommit[:8]))
-	stats.WriteString(fmt.Sprintf("📝 To: %s (%s)\n", m.analysis.ToRef, m.analysis.ToCommit[:8]))
+	stats.WriteString(fmt.Sprintf("📝 From: %s (%s)\n", m.analysis.FromRef, shortCommit(m.analysis.FromCommit)))
+	stats.WriteString(fmt.Sprintf("📝 To: %s (%s)\n", m.analysis.ToRef, shortCommit(m.analysis.ToCommit)))
+	if m.analysis.IgnoreWhitespace {
+		stats.WriteString("\n⚙️  Whitespace-only changes ignored\n")
+	}
 
 	content.WriteString(statsStyle.Render(stats.String()))
 
@@ -871,7 +1167,7 @@ func (m model) renderStatsView() string {
 
 		breakdownStyle := lipgloss.NewStyle().
 			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("238")).
+			BorderForeground(theme.Current.Subtle).
 			Padding(1, 2).
 			MarginBottom(1)
 
@@ -898,12 +1194,17 @@ func (m model) renderStatsView() string {
 		content.WriteString(breakdownStyle.Render(breakdown.String()))
 	}
 
+	if m.analysis.Authors != nil {
+		content.WriteString(renderAuthors(m.analysis.Authors))
+	}
+
 	// Help
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(theme.Current.Muted).
 		MarginTop(1)
 
-	help := "1: overview • 2: files • 3: diff • 4: stats • r: refresh • q: quit"
+	help := terminal.HelpLine("1: overview", "2: files", "3: diff", "4: stats",
+		terminal.Help(m.keys.Refresh, "refresh"), terminal.Help(m.keys.Quit, "quit"))
 	content.WriteString(helpStyle.Render(help))
 
 	return content.String()
@@ -0,0 +1,146 @@
+package diffService
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestDropWhitespaceChanges(t *testing.T) {
+	patch := "--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" package main\n" +
+		"-func main() {}\n" +
+		"+    func main() {}\n" +
+		"@@ -10,2 +10,2 @@\n" +
+		"-var x = 1\n" +
+		"+var x = 2\n"
+
+	lines := dropWhitespaceChanges(parseDiffLines(patch))
+	additions, deletions := countDiffLines(lines)
+	if additions != 1 || deletions != 1 {
+		t.Errorf("got +%d -%d, want +1 -1", additions, deletions)
+	}
+
+	for _, line := range lines {
+		if line.Content == "@@ -1,3 +1,3 @@" {
+			t.Error("whitespace-only hunk was not dropped")
+		}
+	}
+}
+
+func TestDropWhitespaceChangesOnlyWhitespace(t *testing.T) {
+	patch := "--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1,1 +1,1 @@\n" +
+		"-\tx := 1\n" +
+		"+  x := 1  \n"
+
+	if lines := dropWhitespaceChanges(parseDiffLines(patch)); len(lines) != 0 {
+		t.Errorf("expected no lines, got %d", len(lines))
+	}
+}
+
+func TestAnalyzeDiffContextLines(t *testing.T) {
+	repo := newFileDiffTestRepo(t)
+
+	// Compare two working tree files that differ in the middle of their 21 lines; the
+	// empty ref of ":b.go" reads it from the working tree too
+	var lines []string
+	for i := 1; i <= 21; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	if err := os.WriteFile(filepath.Join(repo.Dir, "b.go"), []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lines[10] = "changed"
+	if err := os.WriteFile(filepath.Join(repo.Dir, "c.go"), []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		contextLines int
+		want         int
+	}{
+		{0, 2 * DefaultContextLines},
+		{1, 2},
+		{5, 10},
+	} {
+		analysis, err := analyzeDiff(":b.go", "c.go", DiffOptions{RepoPath: repo.Dir, ContextLines: tt.contextLines})
+		if err != nil {
+			t.Fatal(err)
+		}
+		context := 0
+		for _, line := range analysis.FilesChanged[0].Changes {
+			if line.Type == "context" && strings.HasPrefix(strings.TrimSpace(line.Content), "line ") {
+				context++
+			}
+		}
+		if context != tt.want {
+			t.Errorf("ContextLines %d: got %d context lines, want %d", tt.contextLines, context, tt.want)
+		}
+	}
+}
+
+func TestFileStat(t *testing.T) {
+	// Stats listed in a different order than the files, as a patch covering several
+	// files may list them
+	stats := object.FileStats{
+		{Name: "b.go", Addition: 7, Deletion: 1},
+		{Name: "old.go => new.go", Addition: 2},
+		{Name: "a.go", Addition: 3, Deletion: 4},
+	}
+
+	tests := []struct {
+		path, oldPath                string
+		wantAdditions, wantDeletions int
+	}{
+		{"a.go", "", 3, 4},
+		{"b.go", "", 7, 1},
+		{"new.go", "old.go", 2, 0},
+		{"new.go", "", 0, 0},
+		{"missing.go", "", 0, 0},
+	}
+	for _, tt := range tests {
+		additions, deletions := fileStat(stats, tt.path, tt.oldPath)
+		if additions != tt.wantAdditions || deletions != tt.wantDeletions {
+			t.Errorf("fileStat(%s, %q) = +%d -%d, want +%d -%d", tt.path, tt.oldPath, additions, deletions, tt.wantAdditions, tt.wantDeletions)
+		}
+	}
+}
+
+func TestRetryAfterError(t *testing.T) {
+	dir := t.TempDir()
+	pathA, pathB := filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")
+
+	m := newModel(DiffOptions{})
+	m.fromRef, m.toRef = pathA, pathB
+	m.onDisk = true
+
+	// Neither file exists yet, so the load fails
+	updated, _ := m.Update(loadDiskDiff(pathA, pathB, m.opts))
+	m = updated.(model)
+	if m.err == nil {
+		t.Fatal("loading two missing files should fail")
+	}
+
+	if err := os.WriteFile(pathB, []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(model)
+	if m.err != nil || !m.loading || cmd == nil {
+		t.Fatalf("retry: err %v, loading %v, cmd %v", m.err, m.loading, cmd != nil)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(model)
+	if m.err != nil || len(m.analysis.FilesChanged) != 1 || m.currentView != DiffView {
+		t.Errorf("after retry: err %v, %d files, view %v", m.err, len(m.analysis.FilesChanged), m.currentView)
+	}
+}
@@ -0,0 +1,115 @@
+package diffService
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/redjax/syst/internal/utils/terminal"
+)
+
+// missingFile is shown in place of a commit hash for a side of a file diff that doesn't exist
+const missingFile = "missing"
+
+// RunFileDiff compares two files on disk, which don't have to be in a repository, like
+// diff or git diff --no-index. When only one of them exists the other is shown as added
+// or deleted. It opens the diff viewer on the file, or prints a patch with opts.Patch.
+func RunFileDiff(pathA, pathB string, opts DiffOptions) error {
+	if opts.Patch {
+		return writeDiskPatch(os.Stdout, pathA, pathB, opts)
+	}
+
+	if err := terminal.RequireTTY("--patch"); err != nil {
+		return err
+	}
+
+	m := newModel(opts)
+	m.fromRef, m.toRef = pathA, pathB
+	m.onDisk = true
+
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	go func() {
+		p.Send(loadDiskDiff(pathA, pathB, opts))
+	}()
+
+	_, err := p.Run()
+	return err
+}
+
+func loadDiskDiff(pathA, pathB string, opts DiffOptions) tea.Msg {
+	analysis, err := analyzeDiskDiff(pathA, pathB, opts)
+	if err != nil {
+		return errMsg{err}
+	}
+	return diffAnalysisMsg{analysis}
+}
+
+// analyzeDiskDiff compares two files on disk, producing a DiffAnalysis with one FileDiff
+func analyzeDiskDiff(pathA, pathB string, opts DiffOptions) (DiffAnalysis, error) {
+	from, to, err := readDiskFiles(pathA, pathB)
+	if err != nil {
+		return DiffAnalysis{}, err
+	}
+	return compareFiles(from, to, opts)
+}
+
+// writeDiskPatch writes the diff between two files on disk to w as a patch
+func writeDiskPatch(w io.Writer, pathA, pathB string, opts DiffOptions) error {
+	from, to, err := readDiskFiles(pathA, pathB)
+	if err != nil {
+		return err
+	}
+	if from.hash == to.hash {
+		return nil
+	}
+	readContent := func(f fdiff.File) ([]byte, error) {
+		return []byte(f.(fileContent).content), nil
+	}
+	return writeFilePatch(w, newFilePatch(from, to), opts.contextLines(), readContent)
+}
+
+// readDiskFiles reads both sides of a disk diff, at least one of which has to exist
+func readDiskFiles(pathA, pathB string) (from, to fileContent, err error) {
+	from, err = readDiskFile(pathA)
+	if err != nil {
+		return fileContent{}, fileContent{}, err
+	}
+	to, err = readDiskFile(pathB)
+	if err != nil {
+		return fileContent{}, fileContent{}, err
+	}
+	if from.hash.IsZero() && to.hash.IsZero() {
+		return fileContent{}, fileContent{}, fmt.Errorf("neither %s nor %s exists", pathA, pathB)
+	}
+	return from, to, nil
+}
+
+// readDiskFile reads a file for a disk diff. A file that doesn't exist is empty and has
+// no hash, so it is diffed as added or deleted.
+func readDiskFile(path string) (fileContent, error) {
+	spec := fileSpec{Path: path}
+
+	// #nosec G304 - CLI tool reads user-specified files by design
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fileContent{spec: spec, path: path, commit: missingFile, mode: filemode.Empty}, nil
+	}
+	if err != nil {
+		return fileContent{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return fileContent{
+		spec:    spec,
+		path:    path,
+		commit:  workingTree,
+		hash:    plumbing.ComputeHash(plumbing.BlobObject, data),
+		mode:    filemode.Regular,
+		content: string(data),
+	}, nil
+}
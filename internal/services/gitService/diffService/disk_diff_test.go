@@ -0,0 +1,96 @@
+package diffService
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeDiskDiff(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	a := write("a.yml", "name: a\nport: 80\n")
+	b := write("b.yml", "name: a\nport: 8080\n")
+	binary := write("logo.png", "\x89PNG\r\n\x00\x00")
+	missing := filepath.Join(dir, "missing.yml")
+
+	analysis, err := analyzeDiskDiff(a, b, DiffOptions{})
+	if err != nil {
+		t.Fatalf("analyzeDiskDiff() error: %v", err)
+	}
+	if len(analysis.FilesChanged) != 1 {
+		t.Fatalf("got %d files, want 1", len(analysis.FilesChanged))
+	}
+	if file := analysis.FilesChanged[0]; file.Path != b || file.OldPath != a || file.Additions != 1 || file.Deletions != 1 {
+		t.Errorf("file = %s from %s, +%d -%d, want %s from %s, +1 -1", file.Path, file.OldPath, file.Additions, file.Deletions, b, a)
+	}
+
+	analysis, err = analyzeDiskDiff(a, a, DiffOptions{})
+	if err != nil {
+		t.Fatalf("analyzeDiskDiff() error: %v", err)
+	}
+	if len(analysis.FilesChanged) != 0 {
+		t.Errorf("a file compared with itself has %d changed files", len(analysis.FilesChanged))
+	}
+
+	// A missing side adds or deletes the whole file
+	for _, tt := range []struct {
+		from, to, status, path string
+		additions, deletions   int
+	}{
+		{missing, a, "added", a, 2, 0},
+		{a, missing, "deleted", a, 0, 2},
+	} {
+		analysis, err := analyzeDiskDiff(tt.from, tt.to, DiffOptions{})
+		if err != nil {
+			t.Fatalf("analyzeDiskDiff(%s, %s) error: %v", tt.from, tt.to, err)
+		}
+		if len(analysis.FilesChanged) != 1 {
+			t.Fatalf("got %d files, want 1", len(analysis.FilesChanged))
+		}
+		file := analysis.FilesChanged[0]
+		if file.Status != tt.status || file.Path != tt.path || file.Additions != tt.additions || file.Deletions != tt.deletions {
+			t.Errorf("file = %s (%s), +%d -%d, want %s (%s), +%d -%d", file.Path, file.Status, file.Additions, file.Deletions,
+				tt.path, tt.status, tt.additions, tt.deletions)
+		}
+	}
+
+	analysis, err = analyzeDiskDiff(a, binary, DiffOptions{})
+	if err != nil {
+		t.Fatalf("analyzeDiskDiff() error: %v", err)
+	}
+	if len(analysis.FilesChanged) != 1 || !analysis.FilesChanged[0].IsBinary {
+		t.Errorf("a binary file should be one binary change, got %+v", analysis.FilesChanged)
+	}
+
+	if _, err := analyzeDiskDiff(missing, missing, DiffOptions{}); err == nil {
+		t.Error("analyzeDiskDiff() of two missing files should fail")
+	}
+}
+
+func TestWriteDiskPatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "new.txt")
+	if err := os.WriteFile(path, []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var patch strings.Builder
+	if err := writeDiskPatch(&patch, filepath.Join(dir, "old.txt"), path, DiffOptions{}); err != nil {
+		t.Fatalf("writeDiskPatch() error: %v", err)
+	}
+	text := patch.String()
+	for _, want := range []string{"new file mode 100644\n", "--- /dev/null\n", "+hello\n"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("patch is missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package diffService
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	utildiff "github.com/go-git/go-git/v5/utils/diff"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// workingTree is shown in place of a commit hash for files read from disk
+const workingTree = "working tree"
+
+// shortCommit abbreviates a commit hash, leaving other labels like workingTree alone.
+func shortCommit(commit string) string {
+	if len(commit) == 40 {
+		return commit[:8]
+	}
+	return commit
+}
+
+// fileSpec is one side of a file diff: the file at Path in Ref, like git's "ref:path"
+// syntax, or a file in the working tree when Ref is empty
+type fileSpec struct {
+	Ref  string
+	Path string
+}
+
+func (s fileSpec) String() string {
+	if s.Ref == "" {
+		return s.Path
+	}
+	return s.Ref + ":" + s.Path
+}
+
+// isFileDiff reports whether either side of a diff names a single file with "ref:path".
+func isFileDiff(fromRef, toRef string) bool {
+	return strings.Contains(fromRef, ":") || strings.Contains(toRef, ":")
+}
+
+// parseFileSpec splits "ref:path" into its parts. Anything without a colon is a path in
+// the working tree.
+func parseFileSpec(spec string) fileSpec {
+	ref, path, ok := strings.Cut(spec, ":")
+	if !ok {
+		return fileSpec{Path: spec}
+	}
+	return fileSpec{Ref: ref, Path: strings.TrimPrefix(path, "/")}
+}
+
+// fileContent is a file read from a ref or the working tree
+type fileContent struct {
+	spec fileSpec
+	// path is where the file is in the repository, relative to its root, whichever
+	// directory a working tree spec was given from
+	path    string
+	commit  string
+	hash    plumbing.Hash
+	mode    filemode.FileMode
+	content string
+}
+
+func (f fileContent) Hash() plumbing.Hash     { return f.hash }
+func (f fileContent) Mode() filemode.FileMode { return f.mode }
+func (f fileContent) Path() string            { return f.path }
+
+// worktreeSpec returns the working tree spec of the file at path, relative to the root
+// of the repository at repoPath like the path of a "ref:path" spec, so that it names the
+// same file whether the diff is run from the root, a subdirectory or with -C.
+func worktreeSpec(repoPath, path string) (string, error) {
+	repo, err := gitservice.OpenRepo(repoPath)
+	if err != nil {
+		return "", err
+	}
+	root, err := gitservice.RepoRoot(repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve repository root: %w", err)
+	}
+
+	base := repoPath
+	if base == "" {
+		base = "."
+	}
+	base, err = filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(base, filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// readFileSpecs reads both sides of a file diff, at least one of which has to exist
+func readFileSpecs(repo *git.Repository, repoPath, fromSpec, toSpec string) (from, to fileContent, err error) {
+	from, err = readFileSpec(repo, repoPath, parseFileSpec(fromSpec))
+	if err != nil {
+		return fileContent{}, fileContent{}, err
+	}
+	to, err = readFileSpec(repo, repoPath, parseFileSpec(toSpec))
+	if err != nil {
+		return fileContent{}, fileContent{}, err
+	}
+	if from.hash.IsZero() && to.hash.IsZero() {
+		return fileContent{}, fileContent{}, fmt.Errorf("neither %s nor %s exists", fromSpec, toSpec)
+	}
+	return from, to, nil
+}
+
+// readFileSpec reads the file a spec points at. Working tree paths are relative to
+// repoPath, like the rest of the -C handling; ref paths are relative to the repository
+// root, like git show. A file that doesn't exist is empty and has no hash, so it is
+// diffed as added or deleted.
+func readFileSpec(repo *git.Repository, repoPath string, spec fileSpec) (fileContent, error) {
+	missing := fileContent{spec: spec, path: spec.Path, commit: missingFile, mode: filemode.Empty}
+	if spec.Ref == "" {
+		path := spec.Path
+		if !filepath.IsAbs(path) && repoPath != "" {
+			path = filepath.Join(repoPath, path)
+		}
+		missing.path = repoRelPath(repo, path)
+		// #nosec G304 - CLI tool reads user-specified files by design
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return missing, nil
+		}
+		if err != nil {
+			return fileContent{}, fmt.Errorf("failed to read %s: %w", spec.Path, err)
+		}
+		return fileContent{
+			spec:    spec,
+			path:    missing.path,
+			commit:  workingTree,
+			hash:    plumbing.ComputeHash(plumbing.BlobObject, data),
+			mode:    filemode.Regular,
+			content: string(data),
+		}, nil
+	}
+
+	hash, err := gitservice.ResolveRef(repo, spec.Ref)
+	if err != nil {
+		return fileContent{}, fmt.Errorf("failed to resolve '%s': %w", spec.Ref, err)
+	}
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return fileContent{}, err
+	}
+	file, err := commit.File(spec.Path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return missing, nil
+	}
+	if err != nil {
+		return fileContent{}, fmt.Errorf("failed to find %s in %s: %w", spec.Path, spec.Ref, err)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return fileContent{}, fmt.Errorf("failed to read %s: %w", spec, err)
+	}
+
+	return fileContent{
+		spec:    spec,
+		path:    spec.Path,
+		commit:  hash.String(),
+		hash:    file.Hash,
+		mode:    file.Mode,
+		content: content,
+	}, nil
+}
+
+// repoRelPath returns where the working tree file at path is in repo, relative to its
+// root. A path outside the working tree is returned as it is.
+func repoRelPath(repo *git.Repository, path string) string {
+	root, err := gitservice.RepoRoot(repo)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// textChunk is a run of equal, added or deleted text in a file patch
+type textChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c textChunk) Content() string       { return c.content }
+func (c textChunk) Type() fdiff.Operation { return c.op }
+
+// filePatch is a single-file patch between two fileContents, which can be encoded as a
+// unified diff like the patches go-git builds between trees
+type filePatch struct {
+	from, to fileContent
+	binary   bool
+	chunks   []fdiff.Chunk
+}
+
+func (p filePatch) IsBinary() bool                 { return p.binary }
+func (p filePatch) Chunks() []fdiff.Chunk          { return p.chunks }
+func (p filePatch) FilePatches() []fdiff.FilePatch { return []fdiff.FilePatch{p} }
+func (p filePatch) Message() string                { return "" }
+
+// Files returns both sides of the patch, leaving out a missing one so the patch adds or
+// deletes the file
+func (p filePatch) Files() (from, to fdiff.File) {
+	if !p.from.hash.IsZero() {
+		from = p.from
+	}
+	if !p.to.hash.IsZero() {
+		to = p.to
+	}
+	return from, to
+}
+
+func newFilePatch(from, to fileContent) filePatch {
+	patch := filePatch{from: from, to: to}
+	if gitservice.IsBinary(from.content) || gitservice.IsBinary(to.content) {
+		patch.binary = true
+		return patch
+	}
+
+	for _, d := range utildiff.Do(from.content, to.content) {
+		chunk := textChunk{content: d.Text}
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			chunk.op = fdiff.Add
+		case diffmatchpatch.DiffDelete:
+			chunk.op = fdiff.Delete
+		default:
+			chunk.op = fdiff.Equal
+		}
+		patch.chunks = append(patch.chunks, chunk)
+	}
+	return patch
+}
+
+// analyzeFileDiff compares two single files, each given as "ref:path" or a working tree
+// path, producing a DiffAnalysis with one FileDiff.
+func analyzeFileDiff(fromSpec, toSpec string, opts DiffOptions) (DiffAnalysis, error) {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return DiffAnalysis{}, err
+	}
+
+	from, to, err := readFileSpecs(repo, opts.RepoPath, fromSpec, toSpec)
+	if err != nil {
+		return DiffAnalysis{}, err
+	}
+	return compareFiles(from, to, opts)
+}
+
+// compareFiles diffs two file contents into a DiffAnalysis with one FileDiff, or none
+// when they are the same. A missing side, which has no hash, makes the file added or
+// deleted.
+func compareFiles(from, to fileContent, opts DiffOptions) (DiffAnalysis, error) {
+	fileDiff := FileDiff{Path: to.path, Status: "modified"}
+	switch {
+	case from.hash.IsZero():
+		fileDiff.Status = "added"
+	case to.hash.IsZero():
+		fileDiff.Status = "deleted"
+		fileDiff.Path = from.path
+	case from.path != to.path:
+		fileDiff.Status = "renamed"
+		fileDiff.OldPath = from.path
+	}
+
+	patch := newFilePatch(from, to)
+	fileDiff.IsBinary = patch.binary
+
+	var files []FileDiff
+	if from.hash != to.hash {
+		if !patch.binary {
+			text, err := unifiedDiff(patch, opts)
+			if err != nil {
+				return DiffAnalysis{}, err
+			}
+
+			lines := parseDiffLines(text)
+			if opts.IgnoreWhitespace {
+				lines = dropWhitespaceChanges(lines)
+			}
+			fileDiff.Additions, fileDiff.Deletions = countDiffLines(lines)
+			fileDiff.Changes = truncateDiffLines(lines)
+		}
+
+		if patch.binary || !opts.IgnoreWhitespace || fileDiff.Additions+fileDiff.Deletions > 0 {
+			files = append(files, fileDiff)
+		}
+	}
+
+	summary := fmt.Sprintf("Comparing %s → %s", from.spec, to.spec)
+	if opts.IgnoreWhitespace {
+		summary += " (ignoring whitespace)"
+	}
+
+	return DiffAnalysis{
+		FromRef:      from.spec.String(),
+		ToRef:        to.spec.String(),
+		FromCommit:   from.commit,
+		ToCommit:     to.commit,
+		FilesChanged: files,
+		Stats: DiffStats{
+			FilesChanged: len(files),
+			Additions:    fileDiff.Additions,
+			Deletions:    fileDiff.Deletions,
+			TotalChanges: fileDiff.Additions + fileDiff.Deletions,
+		},
+		Summary:          summary,
+		IgnoreWhitespace: opts.IgnoreWhitespace,
+	}, nil
+}
@@ -0,0 +1,173 @@
+package diffService
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestParseFileSpec(t *testing.T) {
+	tests := []struct {
+		spec string
+		want fileSpec
+	}{
+		{"HEAD:path/a.go", fileSpec{Ref: "HEAD", Path: "path/a.go"}},
+		{"feature:/b.go", fileSpec{Ref: "feature", Path: "b.go"}},
+		{"HEAD~2:config.yml", fileSpec{Ref: "HEAD~2", Path: "config.yml"}},
+		{"config.yml", fileSpec{Path: "config.yml"}},
+	}
+	for _, tt := range tests {
+		if got := parseFileSpec(tt.spec); got != tt.want {
+			t.Errorf("parseFileSpec(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+
+	if isFileDiff("main", "HEAD") {
+		t.Error("commit refs should not be treated as a file diff")
+	}
+	if !isFileDiff("main:a.go", "b.go") {
+		t.Error("ref:path should be treated as a file diff")
+	}
+}
+
+// newFileDiffTestRepo commits a.go, then renames it to b.go with one line changed.
+func newFileDiffTestRepo(t *testing.T) *gittest.Repo {
+	t.Helper()
+
+	repo := gittest.New(t)
+	repo.CommitFile("a.go", "package main\n\nconst version = 1\n", "Add a.go")
+	repo.Remove("a.go")
+	repo.CommitFile("b.go", "package main\n\nconst version = 2\n", "Rename a.go to b.go")
+
+	return repo
+}
+
+func TestAnalyzeDiffBetweenRefPaths(t *testing.T) {
+	repo := newFileDiffTestRepo(t)
+
+	analysis, err := analyzeDiff("HEAD~1:a.go", "HEAD:b.go", DiffOptions{RepoPath: repo.Dir})
+	if err != nil {
+		t.Fatalf("analyzeDiff() error: %v", err)
+	}
+	if len(analysis.FilesChanged) != 1 {
+		t.Fatalf("got %d files, want 1", len(analysis.FilesChanged))
+	}
+
+	file := analysis.FilesChanged[0]
+	if file.Path != "b.go" || file.OldPath != "a.go" || file.Status != "renamed" {
+		t.Errorf("file = %s from %s (%s), want b.go from a.go (renamed)", file.Path, file.OldPath, file.Status)
+	}
+	if file.Additions != 1 || file.Deletions != 1 {
+		t.Errorf("got +%d -%d, want +1 -1", file.Additions, file.Deletions)
+	}
+
+	var added, deleted string
+	for _, line := range file.Changes {
+		switch line.Type {
+		case "added":
+			added = line.Content
+		case "deleted":
+			deleted = line.Content
+		}
+	}
+	if added != "+const version = 2" || deleted != "-const version = 1" {
+		t.Errorf("changed lines = %q / %q", deleted, added)
+	}
+}
+
+func TestAnalyzeDiffAgainstWorkingTree(t *testing.T) {
+	repo := newFileDiffTestRepo(t)
+
+	// An unchanged working copy has no differences
+	analysis, err := analyzeDiff("HEAD:b.go", "b.go", DiffOptions{RepoPath: repo.Dir})
+	if err != nil {
+		t.Fatalf("analyzeDiff() error: %v", err)
+	}
+	if len(analysis.FilesChanged) != 0 || analysis.ToCommit != workingTree {
+		t.Errorf("unchanged working copy: %d files, ToCommit %q", len(analysis.FilesChanged), analysis.ToCommit)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo.Dir, "b.go"), []byte("package main\n\nconst version = 2\nconst name = \"b\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	analysis, err = analyzeDiff("HEAD:b.go", "b.go", DiffOptions{RepoPath: repo.Dir})
+	if err != nil {
+		t.Fatalf("analyzeDiff() error: %v", err)
+	}
+	if analysis.Stats.Additions != 1 || analysis.Stats.Deletions != 0 {
+		t.Errorf("got +%d -%d, want +1 -0", analysis.Stats.Additions, analysis.Stats.Deletions)
+	}
+
+	if _, err := analyzeDiff("HEAD:missing.go", "missing.go", DiffOptions{RepoPath: repo.Dir}); err == nil {
+		t.Error("analyzeDiff() with the file missing on both sides should fail")
+	}
+}
+
+func TestAnalyzeDiffMissingSide(t *testing.T) {
+	repo := newFileDiffTestRepo(t)
+
+	tests := []struct {
+		from, to  string
+		path      string
+		status    string
+		additions int
+		deletions int
+	}{
+		{"HEAD~1:b.go", "HEAD:b.go", "b.go", "added", 3, 0},
+		{"HEAD:a.go", "HEAD~1:a.go", "a.go", "added", 3, 0},
+		{"HEAD~1:a.go", "HEAD:a.go", "a.go", "deleted", 0, 3},
+		{"HEAD:b.go", "missing.go", "b.go", "deleted", 0, 3},
+	}
+	for _, tt := range tests {
+		analysis, err := analyzeDiff(tt.from, tt.to, DiffOptions{RepoPath: repo.Dir})
+		if err != nil {
+			t.Fatalf("analyzeDiff(%s, %s) error: %v", tt.from, tt.to, err)
+		}
+		if len(analysis.FilesChanged) != 1 {
+			t.Fatalf("analyzeDiff(%s, %s): got %d files, want 1", tt.from, tt.to, len(analysis.FilesChanged))
+		}
+		file := analysis.FilesChanged[0]
+		if file.Path != tt.path || file.Status != tt.status || file.Additions != tt.additions || file.Deletions != tt.deletions {
+			t.Errorf("analyzeDiff(%s, %s) = %s (%s) +%d -%d, want %s (%s) +%d -%d", tt.from, tt.to,
+				file.Path, file.Status, file.Additions, file.Deletions, tt.path, tt.status, tt.additions, tt.deletions)
+		}
+	}
+}
+
+func TestDiffRefsFromSubdirectory(t *testing.T) {
+	repo := newFileDiffTestRepo(t)
+	sub := filepath.Join(repo.Dir, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A single ref:path is relative to the root on both sides, wherever it runs from
+	fromRef, toRef, err := diffRefs([]string{"HEAD:b.go"}, sub)
+	if err != nil {
+		t.Fatalf("diffRefs() error: %v", err)
+	}
+	if fromRef != "HEAD:b.go" || toRef != "../b.go" {
+		t.Errorf("diffRefs() = %q, %q, want HEAD:b.go, ../b.go", fromRef, toRef)
+	}
+
+	analysis, err := analyzeDiff(fromRef, toRef, DiffOptions{RepoPath: sub})
+	if err != nil {
+		t.Fatalf("analyzeDiff() error: %v", err)
+	}
+	if len(analysis.FilesChanged) != 0 {
+		t.Errorf("unchanged working copy from a subdirectory: got %d files, want 0", len(analysis.FilesChanged))
+	}
+
+	if err := os.WriteFile(filepath.Join(repo.Dir, "b.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	analysis, err = analyzeDiff(fromRef, toRef, DiffOptions{RepoPath: sub})
+	if err != nil {
+		t.Fatalf("analyzeDiff() error: %v", err)
+	}
+	if len(analysis.FilesChanged) != 1 || analysis.FilesChanged[0].Path != "b.go" || analysis.FilesChanged[0].Status != "modified" {
+		t.Errorf("changed working copy from a subdirectory: got %+v, want b.go modified", analysis.FilesChanged)
+	}
+}
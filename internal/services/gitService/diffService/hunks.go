@@ -0,0 +1,113 @@
+package diffService
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+)
+
+// hunkHeaderPattern matches a hunk header like "@@ -12,4 +12,5 @@", where a range
+// without a count is one line long
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Hunk is one hunk of a unified diff. A side with no lines starts at the line before
+// the hunk, like in the header.
+type Hunk struct {
+	Header   string
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	// Lines are the hunk's lines without the header, numbered on the sides they are on
+	Lines []DiffLine
+}
+
+// parseHunkHeader reads the line ranges of a hunk header
+func parseHunkHeader(line string) (Hunk, bool) {
+	m := hunkHeaderPattern.FindStringSubmatch(line)
+	if m == nil {
+		return Hunk{}, false
+	}
+	count := func(s string) int {
+		if s == "" {
+			return 1
+		}
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	oldStart, _ := strconv.Atoi(m[1])
+	newStart, _ := strconv.Atoi(m[3])
+	return Hunk{
+		Header:   line,
+		OldStart: oldStart,
+		OldLines: count(m[2]),
+		NewStart: newStart,
+		NewLines: count(m[4]),
+	}, true
+}
+
+// TextHunks diffs two versions of a text file into the hunks of a unified diff, with
+// contextLines lines of context around each change. An empty from diffs a new file.
+func TextHunks(from, to string, contextLines int) ([]Hunk, error) {
+	if from == to {
+		return nil, nil
+	}
+
+	side := func(content string) fileContent {
+		return fileContent{
+			spec:    fileSpec{Path: "file"},
+			hash:    plumbing.ComputeHash(plumbing.BlobObject, []byte(content)),
+			mode:    filemode.Regular,
+			content: content,
+		}
+	}
+	text, err := unifiedDiff(newFilePatch(side(from), side(to)), DiffOptions{ContextLines: contextLines})
+	if err != nil {
+		return nil, err
+	}
+
+	var hunks []Hunk
+	for _, line := range parseDiffLines(text) {
+		if line.Type == "header" {
+			if hunk, ok := parseHunkHeader(line.Content); ok {
+				hunks = append(hunks, hunk)
+			}
+			continue
+		}
+		if len(hunks) > 0 {
+			last := &hunks[len(hunks)-1]
+			last.Lines = append(last.Lines, line)
+		}
+	}
+	return hunks, nil
+}
+
+// OldLine traces line n on the new side of a diff back to the old side. It returns the
+// line's number there, or the hunk that added the line when it is new.
+func OldLine(hunks []Hunk, n int) (int, *Hunk) {
+	shift := 0
+	for i, hunk := range hunks {
+		start := hunk.NewStart
+		if hunk.NewLines == 0 {
+			start++
+		}
+		if n < start {
+			break
+		}
+		if n < start+hunk.NewLines {
+			for _, line := range hunk.Lines {
+				if line.NewLine != n {
+					continue
+				}
+				if line.Type == "added" {
+					return 0, &hunks[i]
+				}
+				return line.OldLine, nil
+			}
+		}
+		shift += hunk.NewLines - hunk.OldLines
+	}
+	return n - shift, nil
+}
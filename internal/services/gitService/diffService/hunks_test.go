@@ -0,0 +1,58 @@
+package diffService
+
+import "testing"
+
+func TestTextHunks(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+
+	hunks, err := TextHunks(from, to, 1)
+	if err != nil {
+		t.Fatalf("TextHunks() error: %v", err)
+	}
+	if len(hunks) != 2 {
+		t.Fatalf("got %d hunks, want 2", len(hunks))
+	}
+
+	// Lines are numbered from the hunk headers, not from the top of the diff
+	second := hunks[1]
+	if second.OldStart != 12 || second.OldLines != 1 || second.NewStart != 12 || second.NewLines != 2 {
+		t.Errorf("second hunk = -%d,%d +%d,%d, want -12,1 +12,2", second.OldStart, second.OldLines, second.NewStart, second.NewLines)
+	}
+	last := second.Lines[len(second.Lines)-1]
+	if last.Type != "added" || last.Content != "+m" || last.NewLine != 13 {
+		t.Errorf("last line = %s %q at %d, want added \"+m\" at 13", last.Type, last.Content, last.NewLine)
+	}
+
+	if hunks, _ := TextHunks(from, from, 1); len(hunks) != 0 {
+		t.Errorf("a file diffed with itself has %d hunks", len(hunks))
+	}
+}
+
+func TestOldLine(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	// Inserts x after b, drops e and changes h
+	to := "a\nb\nx\nc\nd\nf\ng\nH\ni\nj\n"
+
+	hunks, err := TextHunks(from, to, 0)
+	if err != nil {
+		t.Fatalf("TextHunks() error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		line, want int
+		added      bool
+	}{
+		{1, 1, false},
+		{3, 0, true},
+		{4, 3, false},
+		{6, 6, false},
+		{8, 0, true},
+		{10, 10, false},
+	} {
+		got, hunk := OldLine(hunks, tt.line)
+		if got != tt.want || (hunk != nil) != tt.added {
+			t.Errorf("OldLine(%d) = %d, added %v, want %d, added %v", tt.line, got, hunk != nil, tt.want, tt.added)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package diffService
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// singleFilePatch is one file of a patch, so each file can be encoded on its own
+type singleFilePatch struct {
+	fdiff.FilePatch
+}
+
+func (p singleFilePatch) FilePatches() []fdiff.FilePatch { return []fdiff.FilePatch{p.FilePatch} }
+func (p singleFilePatch) Message() string                { return "" }
+
+// blobReader returns the content of one side of a file patch
+type blobReader func(fdiff.File) ([]byte, error)
+
+// writePatch writes the diff between fromRef and toRef to w as a patch git apply
+// accepts. Either side can be a single file with "ref:path", like the TUI.
+func writePatch(w io.Writer, fromRef, toRef string, opts DiffOptions) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+
+	if isFileDiff(fromRef, toRef) {
+		from, to, err := readFileSpecs(repo, opts.RepoPath, fromRef, toRef)
+		if err != nil {
+			return err
+		}
+		if from.hash == to.hash && from.path == to.path {
+			return nil
+		}
+		readContent := func(f fdiff.File) ([]byte, error) {
+			return []byte(f.(fileContent).content), nil
+		}
+		return writeFilePatch(w, newFilePatch(from, to), opts.contextLines(), readContent)
+	}
+
+	fromTree, err := refTree(repo, fromRef)
+	if err != nil {
+		return err
+	}
+	toTree, err := refTree(repo, toRef)
+	if err != nil {
+		return err
+	}
+	changes, err := object.DiffTreeWithOptions(context.Background(), fromTree, toTree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return fmt.Errorf("failed to diff trees: %w", err)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changePath(changes[i]) < changePath(changes[j])
+	})
+
+	readBlob := func(f fdiff.File) ([]byte, error) {
+		blob, err := repo.BlobObject(f.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Path(), err)
+		}
+		reader, err := blob.Reader()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Path(), err)
+		}
+		defer reader.Close() // #nosec G307 - Read-only blob reader
+		return io.ReadAll(reader)
+	}
+	for _, change := range changes {
+		patch, err := change.Patch()
+		if err != nil {
+			return fmt.Errorf("failed to build patch for %s: %w", changePath(change), err)
+		}
+		for _, filePatch := range patch.FilePatches() {
+			if err := writeFilePatch(w, filePatch, opts.contextLines(), readBlob); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// refTree returns the tree of the commit ref points at
+func refTree(repo *git.Repository, ref string) (*object.Tree, error) {
+	hash, err := gitservice.ResolveRef(repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve '%s': %w", ref, err)
+	}
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+// changePath is the path a change is listed under: the new path, or the old one for a
+// deleted file
+func changePath(change *object.Change) string {
+	if change.To.Name != "" {
+		return change.To.Name
+	}
+	return change.From.Name
+}
+
+// writeFilePatch writes one file's patch. Text files are encoded by go-git's unified
+// encoder, which writes git's extended headers for new, deleted and renamed files and
+// mode changes. For binary files it only writes "Binary files ... differ", which git
+// apply can't use, so that line is replaced with a "GIT binary patch" holding both
+// versions of the file.
+func writeFilePatch(w io.Writer, filePatch fdiff.FilePatch, contextLines int, read blobReader) error {
+	var buf bytes.Buffer
+	if err := fdiff.NewUnifiedEncoder(&buf, contextLines).Encode(singleFilePatch{filePatch}); err != nil {
+		return fmt.Errorf("failed to build patch: %w", err)
+	}
+
+	text := buf.String()
+	if filePatch.IsBinary() && strings.HasSuffix(text, " differ\n") {
+		from, to := filePatch.Files()
+		oldContent, err := readSide(from, read)
+		if err != nil {
+			return err
+		}
+		newContent, err := readSide(to, read)
+		if err != nil {
+			return err
+		}
+
+		binaryLine := strings.LastIndex(strings.TrimSuffix(text, "\n"), "\n") + 1
+		var binary strings.Builder
+		binary.WriteString(text[:binaryLine])
+		binary.WriteString("GIT binary patch\n")
+		if err := writeBinaryLiteral(&binary, newContent); err != nil {
+			return err
+		}
+		binary.WriteString("\n")
+		if err := writeBinaryLiteral(&binary, oldContent); err != nil {
+			return err
+		}
+		binary.WriteString("\n")
+		text = binary.String()
+	}
+
+	_, err := io.WriteString(w, text)
+	return err
+}
+
+// readSide reads one side of a file patch; a missing side (an added or deleted file)
+// is empty
+func readSide(f fdiff.File, read blobReader) ([]byte, error) {
+	if f == nil || f.Hash() == plumbing.ZeroHash {
+		return nil, nil
+	}
+	return read(f)
+}
+
+// writeBinaryLiteral writes content as a "literal" hunk of a git binary patch: its size,
+// then the zlib-compressed content in git's base85, up to 52 bytes a line
+func writeBinaryLiteral(sb *strings.Builder, content []byte) error {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(content); err != nil {
+		return fmt.Errorf("failed to compress binary file: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress binary file: %w", err)
+	}
+
+	fmt.Fprintf(sb, "literal %d\n", len(content))
+	data := compressed.Bytes()
+	for len(data) > 0 {
+		n := min(len(data), 52)
+		if n <= 26 {
+			sb.WriteByte(byte('A' + n - 1))
+		} else {
+			sb.WriteByte(byte('a' + n - 27))
+		}
+		sb.WriteString(encodeBase85(data[:n]))
+		sb.WriteByte('\n')
+		data = data[n:]
+	}
+	return nil
+}
+
+// base85Alphabet is the alphabet git uses for binary patches, which differs from ASCII85
+const base85Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+
+// encodeBase85 encodes data like git's encode_85: every 4 bytes, zero padded, become 5
+// characters, most significant first
+func encodeBase85(data []byte) string {
+	var sb strings.Builder
+	for i := 0; i < len(data); i += 4 {
+		var group uint32
+		for j := 0; j < 4; j++ {
+			group <<= 8
+			if i+j < len(data) {
+				group |= uint32(data[i+j])
+			}
+		}
+		var chars [5]byte
+		for j := 4; j >= 0; j-- {
+			chars[j] = base85Alphabet[group%85]
+			group /= 85
+		}
+		sb.Write(chars[:])
+	}
+	return sb.String()
+}
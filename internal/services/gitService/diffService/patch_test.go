@@ -0,0 +1,152 @@
+package diffService
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestEncodeBase85(t *testing.T) {
+	// The compressed empty file git diff --binary writes as "HcmV?d00001"
+	data := []byte{0x78, 0x01, 0x03, 0x00, 0x00, 0x00, 0x00, 0x01}
+	if got, want := encodeBase85(data), "cmV?d00001"; got != want {
+		t.Errorf("encodeBase85() = %q, want %q", got, want)
+	}
+	// Partial groups are zero padded
+	if got, want := encodeBase85([]byte{0xff}), encodeBase85([]byte{0xff, 0, 0, 0}); got != want {
+		t.Errorf("encodeBase85(0xff) = %q, want %q", got, want)
+	}
+}
+
+func TestWritePatch(t *testing.T) {
+	// A commit of a set of files, then a second commit that modifies, renames, deletes,
+	// adds and changes the mode of some of them, binary files included
+	repo := gittest.New(t)
+	repo.WriteFile("main.go", "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n")
+	repo.WriteFile("old.txt", "moved without changes\nline two\nline three\n")
+	repo.WriteFile("run.sh", "#!/bin/sh\necho run\n")
+	repo.WriteFile("image.bin", "\x00\x01\x02binary\x00")
+	repo.WriteFile("gone.txt", "deleted\n")
+	repo.WriteFile("nonl.txt", "no newline")
+	from := repo.Commit("Add files")
+
+	repo.WriteFile("main.go", "package main\n\nfunc main() {\n\tprintln(\"hello, world\")\n}\n")
+	repo.Move("old.txt", "new.txt")
+	if err := os.Chmod(repo.Path("run.sh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	repo.Add("run.sh")
+	repo.WriteFile("image.bin", "\x00\x01\x02changed\x00\xff")
+	repo.WriteFile("added.bin", "\x00new binary")
+	repo.Remove("gone.txt")
+	repo.WriteFile("nonl.txt", "still no newline")
+	to := repo.Commit("Change files")
+
+	var patch strings.Builder
+	if err := writePatch(&patch, from.String(), to.String(), DiffOptions{RepoPath: repo.Dir}); err != nil {
+		t.Fatalf("writePatch() error: %v", err)
+	}
+	text := patch.String()
+	for _, want := range []string{
+		"rename from old.txt\nrename to new.txt\n",
+		"old mode 100644\nnew mode 100755\n",
+		"deleted file mode 100644\n",
+		"new file mode 100644\n",
+		"GIT binary patch\nliteral ",
+		"\\ No newline at end of file\n",
+		"@@ -1,5 +1,5 @@\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("patch is missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Binary files") {
+		t.Errorf("patch has a binary file without its content:\n%s", text)
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	// Apply the patch to a copy of the first commit outside the repository, where git
+	// can't take the new binary files from its object store, and compare with the second
+	tree := func(hash plumbing.Hash) *object.Tree {
+		t.Helper()
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree, err := commit.Tree()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tree
+	}
+
+	applyDir := t.TempDir()
+	if err := tree(from).Files().ForEach(func(f *object.File) error {
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		mode, err := f.Mode.ToOSFileMode()
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(applyDir, f.Name), []byte(content), mode)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command("git", "apply", "-")
+	cmd.Dir = applyDir
+	cmd.Env = append(os.Environ(), "GIT_CEILING_DIRECTORIES="+filepath.Dir(applyDir))
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git apply: %v\n%s\n%s", err, out, text)
+	}
+
+	entries, err := os.ReadDir(applyDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	var want []string
+	if err := tree(to).Files().ForEach(func(f *object.File) error {
+		want = append(want, f.Name)
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		got, err := os.ReadFile(filepath.Join(applyDir, f.Name))
+		if err != nil {
+			return err
+		}
+		if string(got) != content {
+			t.Errorf("%s after git apply = %q, want %q", f.Name, got, content)
+		}
+		info, err := os.Stat(filepath.Join(applyDir, f.Name))
+		if err != nil {
+			return err
+		}
+		if executable := info.Mode()&0o100 != 0; executable != (f.Mode == filemode.Executable) {
+			t.Errorf("%s after git apply has mode %v, want %v", f.Name, info.Mode(), f.Mode)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(files, " ") != strings.Join(want, " ") {
+		t.Errorf("files after git apply = %v, want %v", files, want)
+	}
+}
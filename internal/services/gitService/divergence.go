@@ -0,0 +1,129 @@
+package gitservice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// MergeBase returns the best common ancestor of two commits, or nil if their histories
+// are unrelated.
+func MergeBase(repo *git.Repository, a, b plumbing.Hash) (*object.Commit, error) {
+	aCommit, err := repo.CommitObject(a)
+	if err != nil {
+		return nil, err
+	}
+	bCommit, err := repo.CommitObject(b)
+	if err != nil {
+		return nil, err
+	}
+
+	bases, err := aCommit.MergeBase(bCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+	if len(bases) == 0 {
+		return nil, nil
+	}
+	return bases[0], nil
+}
+
+// OctopusMergeBase returns the best common ancestor of all of hashes, found by folding
+// MergeBase over them, or nil if they share no history.
+func OctopusMergeBase(repo *git.Repository, hashes ...plumbing.Hash) (*object.Commit, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	base, err := repo.CommitObject(hashes[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, hash := range hashes[1:] {
+		base, err = MergeBase(repo, base.Hash, hash)
+		if err != nil || base == nil {
+			return nil, err
+		}
+	}
+	return base, nil
+}
+
+// AheadBehind counts the commits reachable from tip but not base (ahead), and from base
+// but not tip (behind), like git rev-list --left-right --count base...tip.
+func AheadBehind(repo *git.Repository, base, tip plumbing.Hash) (ahead, behind int, err error) {
+	baseAncestors, err := Ancestors(repo, base)
+	if err != nil {
+		return 0, 0, err
+	}
+	tipAncestors, err := Ancestors(repo, tip)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ahead, behind = CountDivergence(baseAncestors, tipAncestors)
+	return ahead, behind, nil
+}
+
+// CountDivergence is AheadBehind for ancestor sets from Ancestors, so comparing many
+// branches with the same base only walks the base's history once.
+func CountDivergence(baseAncestors, tipAncestors map[plumbing.Hash]bool) (ahead, behind int) {
+	for hash := range tipAncestors {
+		if !baseAncestors[hash] {
+			ahead++
+		}
+	}
+	for hash := range baseAncestors {
+		if !tipAncestors[hash] {
+			behind++
+		}
+	}
+	return ahead, behind
+}
+
+// Ancestors returns the set of commits reachable from hash, including hash itself.
+func Ancestors(repo *git.Repository, hash plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	iter, err := repo.Log(&git.LogOptions{From: hash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log: %w", err)
+	}
+
+	seen := make(map[plumbing.Hash]bool)
+	err = iter.ForEach(func(c *object.Commit) error {
+		seen[c.Hash] = true
+		return nil
+	})
+	return seen, err
+}
+
+// DefaultBranch returns the repository's default branch: the branch origin/HEAD points
+// at, or else the first of "main" and "master" that exists locally.
+func DefaultBranch(repo *git.Repository) (string, error) {
+	originHead := plumbing.NewRemoteHEADReferenceName("origin")
+	if ref, err := repo.Reference(originHead, false); err == nil && ref.Type() == plumbing.SymbolicReference {
+		return strings.TrimPrefix(ref.Target().String(), "refs/remotes/origin/"), nil
+	}
+
+	for _, name := range []string{"main", "master"} {
+		if _, err := repo.Reference(plumbing.NewBranchReferenceName(name), false); err == nil {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not determine the default branch, pass one explicitly")
+}
+
+// ResolveBranch resolves a branch to compare against, i.e. the default branch, falling
+// back to its origin remote-tracking branch when there is no local branch of that name.
+func ResolveBranch(repo *git.Repository, name string) (plumbing.Hash, error) {
+	hash, err := ResolveRef(repo, name)
+	if err == nil {
+		return hash, nil
+	}
+	if remoteHash, remoteErr := ResolveRef(repo, "origin/"+name); remoteErr == nil {
+		return remoteHash, nil
+	}
+	return plumbing.ZeroHash, fmt.Errorf("failed to resolve %s: %w", name, err)
+}
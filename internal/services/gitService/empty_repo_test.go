@@ -0,0 +1,87 @@
+package gitservice_test
+
+import (
+	"errors"
+	"testing"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/activity"
+	"github.com/redjax/syst/internal/services/gitService/blameService"
+	"github.com/redjax/syst/internal/services/gitService/changelogService"
+	"github.com/redjax/syst/internal/services/gitService/commitLintService"
+	"github.com/redjax/syst/internal/services/gitService/compareService"
+	"github.com/redjax/syst/internal/services/gitService/contributorsService"
+	"github.com/redjax/syst/internal/services/gitService/diffService"
+	"github.com/redjax/syst/internal/services/gitService/filesService"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+	"github.com/redjax/syst/internal/services/gitService/healthService"
+	"github.com/redjax/syst/internal/services/gitService/historyService"
+	"github.com/redjax/syst/internal/services/gitService/reflogService"
+	"github.com/redjax/syst/internal/services/gitService/searchService"
+	"github.com/redjax/syst/internal/services/gitService/staleBranchesService"
+	"github.com/redjax/syst/internal/services/gitService/tagsService"
+)
+
+// TestEmptyRepository runs each command against a repository with no commits. They
+// should all fail with ErrNoCommits before starting a TUI, rather than panic or report
+// a missing reference.
+func TestEmptyRepository(t *testing.T) {
+	dir := gittest.New(t).Dir
+
+	runs := map[string]func() error{
+		"activity": func() error {
+			return activity.RunActivityDashboard(activity.ActivityOptions{RepoPath: dir})
+		},
+		"blame": func() error {
+			return blameService.RunBlameViewer(blameService.BlameOptions{RepoPath: dir}, nil)
+		},
+		"changelog": func() error {
+			return changelogService.RunChangelog("", "", changelogService.ChangelogOptions{RepoPath: dir})
+		},
+		"compare": func() error {
+			return compareService.RunComparison(nil, compareService.CompareOptions{RepoPath: dir})
+		},
+		"contributors": func() error {
+			return contributorsService.RunContributorsAnalysis(contributorsService.ContributorsOptions{RepoPath: dir})
+		},
+		"diff": func() error {
+			return diffService.RunDiffExplorer(nil, diffService.DiffOptions{RepoPath: dir})
+		},
+		"files": func() error {
+			return filesService.RunFileAnalysis(filesService.FileAnalysisOptions{RepoPath: dir})
+		},
+		"health": func() error {
+			return healthService.RunHealthCheck(healthService.HealthOptions{RepoPath: dir})
+		},
+		"history": func() error {
+			return historyService.RunHistoryExplorer(historyService.HistoryOptions{RepoPath: dir})
+		},
+		"hotspots": func() error {
+			return filesService.RunHotspots(filesService.HotspotOptions{RepoPath: dir})
+		},
+		"lint-commits": func() error {
+			_, err := commitLintService.RunCommitLint("", commitLintService.CommitLintOptions{RepoPath: dir})
+			return err
+		},
+		"reflog": func() error {
+			return reflogService.RunReflogViewer(reflogService.ReflogOptions{RepoPath: dir})
+		},
+		"search": func() error {
+			return searchService.RunAdvancedSearchWithOptions(searchService.SearchOptions{RepoPath: dir})
+		},
+		"stale-branches": func() error {
+			return staleBranchesService.RunStaleBranches("", 0, staleBranchesService.StaleBranchesOptions{RepoPath: dir})
+		},
+		"tags": func() error {
+			return tagsService.RunTagManager(tagsService.TagManagerOptions{RepoPath: dir})
+		},
+	}
+
+	for name, run := range runs {
+		t.Run(name, func(t *testing.T) {
+			if err := run(); !errors.Is(err, gitservice.ErrNoCommits) {
+				t.Errorf("error = %v, want ErrNoCommits", err)
+			}
+		})
+	}
+}
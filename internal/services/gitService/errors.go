@@ -5,5 +5,13 @@ import (
 )
 
 // NotARepoError is returned when path is not a git repository
-var ErrNotGitRepo = errors.New("path is not a git repository")
+var ErrNotGitRepo = errors.New("not inside a git repository")
 var ErrGitNotInstalled = errors.New("git is not installed")
+
+// ErrNoCommits is returned when HEAD does not point at a commit yet, as in a freshly
+// initialized repository
+var ErrNoCommits = errors.New("repository has no commits yet")
+
+// ErrDetachedHead is returned by operations that need a current branch when HEAD points
+// straight at a commit
+var ErrDetachedHead = errors.New("HEAD is detached, check out a branch first")
@@ -0,0 +1,185 @@
+package filesService
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// maxDirectoryDepth is how many levels of directories the ownership rollup goes down;
+// deeper files count towards their ancestor at that depth
+const maxDirectoryDepth = 3
+
+// directoryOwnerLimit is how many contributors an expanded directory lists
+const directoryOwnerLimit = 5
+
+// DirectoryOwnerInfo is the ownership of a directory: the changes of every file under
+// it, added up per contributor
+type DirectoryOwnerInfo struct {
+	Path         string            `json:"path"`
+	Depth        int               `json:"depth"` // 0 for top-level directories
+	Files        int               `json:"files"`
+	Contributors []ContributorStat `json:"contributors"`
+	TotalChanges int               `json:"total_changes"`
+	Ownership    string            `json:"ownership"` // Most active contributor
+}
+
+// directoryContributor is a contributor row shown under an expanded directory
+type directoryContributor struct {
+	Dir   string
+	Depth int
+	Stat  ContributorStat
+}
+
+// directoryOwnership rolls the per-file contributor counts up into every ancestor
+// directory of each file, down to maxDirectoryDepth. Files at the repository root have
+// no directory and are left out. Directories are returned in tree order, each followed
+// by its subdirectories.
+func directoryOwnership(fileContributors map[string]map[string]int) []DirectoryOwnerInfo {
+	dirChanges := make(map[string]map[string]int) // directory -> contributor -> changes
+	dirFiles := make(map[string]int)
+
+	for file, contributors := range fileContributors {
+		parts := strings.Split(path.Dir(file), "/")
+		if parts[0] == "." {
+			continue
+		}
+		for depth := 1; depth <= min(len(parts), maxDirectoryDepth); depth++ {
+			dir := strings.Join(parts[:depth], "/")
+			if dirChanges[dir] == nil {
+				dirChanges[dir] = make(map[string]int)
+			}
+			for contributor, changes := range contributors {
+				dirChanges[dir][contributor] += changes
+			}
+			dirFiles[dir]++
+		}
+	}
+
+	var dirs []DirectoryOwnerInfo
+	for dir, contributors := range dirChanges {
+		info := DirectoryOwnerInfo{
+			Path:  dir,
+			Depth: strings.Count(dir, "/"),
+			Files: dirFiles[dir],
+		}
+		for contributor, changes := range contributors {
+			info.TotalChanges += changes
+			info.Contributors = append(info.Contributors, ContributorStat{Name: contributor, Changes: changes})
+		}
+		for i := range info.Contributors {
+			info.Contributors[i].Percentage = float64(info.Contributors[i].Changes) / float64(info.TotalChanges) * 100
+		}
+		// Most changes first, name breaks ties for stable results
+		sort.Slice(info.Contributors, func(i, j int) bool {
+			if info.Contributors[i].Changes != info.Contributors[j].Changes {
+				return info.Contributors[i].Changes > info.Contributors[j].Changes
+			}
+			return info.Contributors[i].Name < info.Contributors[j].Name
+		})
+		info.Ownership = info.Contributors[0].Name
+		dirs = append(dirs, info)
+	}
+
+	sortDirectoryTree(dirs)
+	return dirs
+}
+
+// sortDirectoryTree sorts directories so each one is followed by its subdirectories.
+// Comparing the path elements rather than the strings keeps "a/b" ahead of "a-b".
+func sortDirectoryTree(dirs []DirectoryOwnerInfo) {
+	sort.Slice(dirs, func(i, j int) bool {
+		a, b := strings.Split(dirs[i].Path, "/"), strings.Split(dirs[j].Path, "/")
+		for k := 0; k < min(len(a), len(b)); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+}
+
+// limitDirectoryOwnership keeps the limit directories with the most changes, in tree
+// order. A directory has at least as many changes as any of its subdirectories, so the
+// parents of the kept directories are kept too.
+func limitDirectoryOwnership(dirs []DirectoryOwnerInfo, limit int) []DirectoryOwnerInfo {
+	if len(dirs) <= limit {
+		return dirs
+	}
+	sort.SliceStable(dirs, func(i, j int) bool {
+		return dirs[i].TotalChanges > dirs[j].TotalChanges
+	})
+	dirs = dirs[:limit]
+	sortDirectoryTree(dirs)
+	return dirs
+}
+
+// directoryItems lists the directories in tree order, with the top contributors of the
+// expanded ones below them
+func directoryItems(dirs []DirectoryOwnerInfo, expanded map[string]bool) []fileItem {
+	var items []fileItem
+	for _, dir := range dirs {
+		items = append(items, fileItem{file: dir, expanded: expanded[dir.Path]})
+		if !expanded[dir.Path] {
+			continue
+		}
+		for _, stat := range dir.Contributors[:min(len(dir.Contributors), directoryOwnerLimit)] {
+			items = append(items, fileItem{file: directoryContributor{Dir: dir.Path, Depth: dir.Depth, Stat: stat}})
+		}
+	}
+	return items
+}
+
+// toggleDirectory expands or collapses the directory of the selected row, which is
+// either the directory itself or one of its contributors, and keeps it selected
+func (m *model) toggleDirectory() {
+	var dir string
+	switch f := m.fileList.SelectedItem().(fileItem).file.(type) {
+	case DirectoryOwnerInfo:
+		dir = f.Path
+	case directoryContributor:
+		dir = f.Dir
+	default:
+		return
+	}
+
+	if m.expandedDirs == nil {
+		m.expandedDirs = make(map[string]bool)
+	}
+	m.expandedDirs[dir] = !m.expandedDirs[dir]
+	m.updateListItems()
+
+	for i, item := range m.fileList.Items() {
+		if f, ok := item.(fileItem).file.(DirectoryOwnerInfo); ok && f.Path == dir {
+			m.fileList.Select(i)
+			break
+		}
+	}
+}
+
+func directoryIndent(depth int) string {
+	return strings.Repeat("  ", depth)
+}
+
+func (d DirectoryOwnerInfo) title(expanded bool) string {
+	marker := "▸"
+	if expanded {
+		marker = "▾"
+	}
+	owner := d.Contributors[0]
+	return fmt.Sprintf("%s%s %s/ (%.0f%% %s)", directoryIndent(d.Depth), marker, d.Path, owner.Percentage, owner.Name)
+}
+
+func (d DirectoryOwnerInfo) description() string {
+	return fmt.Sprintf("%s  %d files • %d contributors • %d total changes",
+		directoryIndent(d.Depth), d.Files, len(d.Contributors), d.TotalChanges)
+}
+
+func (c directoryContributor) title() string {
+	return fmt.Sprintf("%s    👤 %s", directoryIndent(c.Depth), c.Stat.Name)
+}
+
+func (c directoryContributor) description() string {
+	return fmt.Sprintf("%s    %.0f%% • %d changes", directoryIndent(c.Depth), c.Stat.Percentage, c.Stat.Changes)
+}
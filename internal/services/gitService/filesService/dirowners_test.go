@@ -0,0 +1,98 @@
+package filesService
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/syst/internal/utils/terminal"
+)
+
+func TestDirectoryOwnership(t *testing.T) {
+	fileContributors := map[string]map[string]int{
+		"README.md":              {"alice": 4},
+		"cmd/main.go":            {"alice": 3, "bob": 1},
+		"internal/a/b/c/deep.go": {"bob": 2},
+		"internal/a/x.go":        {"carol": 5, "bob": 1},
+		"internal-tools/run.sh":  {"carol": 1},
+	}
+
+	dirs := directoryOwnership(fileContributors)
+
+	var paths []string
+	for _, d := range dirs {
+		paths = append(paths, d.Path)
+	}
+	want := []string{"cmd", "internal", "internal/a", "internal/a/b", "internal-tools"}
+	if len(paths) != len(want) {
+		t.Fatalf("directories = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("directories = %v, want %v", paths, want)
+		}
+	}
+
+	internal := dirs[1]
+	if internal.Files != 2 || internal.TotalChanges != 8 || internal.Ownership != "carol" || internal.Depth != 0 {
+		t.Errorf("internal = %+v, want 2 files, 8 changes, owned by carol at depth 0", internal)
+	}
+	if got := internal.Contributors[1]; got.Name != "bob" || got.Changes != 3 || got.Percentage != 37.5 {
+		t.Errorf("internal second contributor = %+v, want bob with 3 changes (37.5%%)", got)
+	}
+	// Files below maxDirectoryDepth count towards their ancestor at that depth
+	if deep := dirs[3]; deep.Depth != 2 || deep.Ownership != "bob" || deep.Files != 1 {
+		t.Errorf("internal/a/b = %+v, want one file owned by bob at depth 2", deep)
+	}
+
+	limited := limitDirectoryOwnership(dirs, 2)
+	if len(limited) != 2 || limited[0].Path != "internal" || limited[1].Path != "internal/a" {
+		t.Errorf("limitDirectoryOwnership() = %+v, want internal and internal/a", limited)
+	}
+}
+
+func TestToggleDirectory(t *testing.T) {
+	delegate := list.NewDefaultDelegate()
+	m := model{
+		fileList:     list.New([]list.Item{}, delegate, 0, 0),
+		listDelegate: delegate,
+		loading:      true,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.DefaultKeyMap(),
+	}
+	analysis := FileAnalysis{DirectoryOwnership: directoryOwnership(map[string]map[string]int{
+		"cmd/main.go":     {"alice": 3, "bob": 1},
+		"internal/x.go":   {"carol": 1},
+		"internal/y/z.go": {"carol": 2},
+	})}
+
+	var updated tea.Model = m
+	for _, msg := range []tea.Msg{
+		tea.WindowSizeMsg{Width: 120, Height: 60},
+		dataLoadedMsg{analysis: analysis},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")},
+		tea.KeyMsg{Type: tea.KeyEnter},
+	} {
+		updated, _ = updated.Update(msg)
+	}
+
+	// cmd is expanded, with its two contributors listed below it
+	items := updated.(model).fileList.Items()
+	if len(items) != 5 {
+		t.Fatalf("items after expanding cmd = %d, want 5", len(items))
+	}
+	if c, ok := items[2].(fileItem).file.(directoryContributor); !ok || c.Stat.Name != "bob" {
+		t.Errorf("items[2] = %+v, want bob under cmd", items[2])
+	}
+
+	// Collapsing from a contributor row selects its directory again
+	for _, msg := range []tea.Msg{tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter}} {
+		updated, _ = updated.Update(msg)
+	}
+	if got := len(updated.(model).fileList.Items()); got != 3 {
+		t.Errorf("items after collapsing cmd = %d, want 3", got)
+	}
+	if got := updated.(model).fileList.Index(); got != 0 {
+		t.Errorf("selected index after collapsing = %d, want 0", got)
+	}
+}
@@ -1,7 +1,10 @@
 package filesService
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -9,11 +12,16 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/redjax/syst/internal/utils/terminal"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/blameService"
+	"github.com/redjax/syst/internal/utils/profile"
+	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type ViewMode int
@@ -24,78 +32,156 @@ const (
 	FrequentFilesView
 	ExtensionsView
 	ContributorsView
+	OwnershipView
+	StaleFilesView
+	LinesView
+	DirectoryOwnersView
 )
 
+// FileAnalysisOptions controls how the file analysis is computed
+type FileAnalysisOptions struct {
+	// RepoPath is the repository to analyze; empty means the current directory
+	RepoPath string
+	// StaleMonths is how long a tracked file must go unmodified to be considered stale
+	StaleMonths int
+	// Report writes the analysis (json or markdown) instead of launching the TUI
+	Report gitservice.ReportWriter
+	// NoLimit disables the caps applied to result lists
+	NoLimit bool
+	// NoCache disables the on-disk commit stats cache under .git/syst-cache
+	NoCache bool
+	// IncludeUntracked scans the working tree for untracked and gitignored files
+	IncludeUntracked bool
+	// Lines prints the lines of code per language, as a cloc-style table or in the
+	// Report format, instead of launching the TUI
+	Lines bool
+}
+
+// ownershipRiskThreshold is the share of changes (in percent) a single author must
+// hold for a file to be flagged as an ownership risk
+const ownershipRiskThreshold = 80.0
+
+// knowledgeThreshold is the share of changes (in percent) an author needs on a file
+// to be considered knowledgeable about it when computing the bus factor
+const knowledgeThreshold = 25.0
+
 type FileAnalysis struct {
-	Overview           FileOverview
-	LargeFiles         []LargeFileInfo
-	FrequentFiles      []FrequentFileInfo
-	ExtensionBreakdown []ExtensionInfo
-	FileContributors   []FileContributorInfo
+	Overview           FileOverview          `json:"overview"`
+	LargeFiles         []LargeFileInfo       `json:"large_files"`
+	FrequentFiles      []FrequentFileInfo    `json:"frequent_files"`
+	ExtensionBreakdown []ExtensionInfo       `json:"extension_breakdown"`
+	FileContributors   []FileContributorInfo `json:"file_contributors"`
+	OwnershipRisk      OwnershipRisk         `json:"ownership_risk"`
+	StaleFiles         []StaleFileInfo       `json:"stale_files"`
+	LineCounts         []LanguageLines       `json:"line_counts"`
+	DirectoryOwnership []DirectoryOwnerInfo  `json:"directory_ownership"`
+}
+
+type OwnershipRisk struct {
+	BusFactor   int              `json:"bus_factor"`
+	AtRiskFiles []AtRiskFileInfo `json:"at_risk_files"`
+}
+
+type AtRiskFileInfo struct {
+	Path          string  `json:"path"`
+	Owner         string  `json:"owner"`
+	Concentration float64 `json:"concentration"` // Owner's share of changes, in percent
+	TotalChanges  int     `json:"total_changes"`
+	Contributors  int     `json:"contributors"`
 }
 
 type FileOverview struct {
-	TotalFiles      int
-	TotalSize       int64
-	AverageSize     int64
-	LargestFile     string
-	LargestFileSize int64
-	ExtensionCount  int
-	BinaryFiles     int
-	TextFiles       int
+	TotalFiles      int    `json:"total_files"`
+	TotalSize       int64  `json:"total_size"`
+	AverageSize     int64  `json:"average_size"`
+	LargestFile     string `json:"largest_file"`
+	LargestFileSize int64  `json:"largest_file_size"`
+	ExtensionCount  int    `json:"extension_count"`
+	BinaryFiles     int    `json:"binary_files"`
+	TextFiles       int    `json:"text_files"`
+	// WorkingTree is only set when the working tree was scanned (IncludeUntracked)
+	WorkingTree *WorkingTreeFiles `json:"working_tree,omitempty"`
 }
 
 type LargeFileInfo struct {
-	Path      string
-	Size      int64
-	Extension string
-	Type      string // "binary" or "text"
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	Extension string `json:"extension"`
+	Type      string `json:"type"` // "binary" or "text"
 }
 
 type FrequentFileInfo struct {
-	Path           string
-	ChangeCount    int
-	Contributors   int
-	LastModified   time.Time
-	LastCommitHash string
-	LastCommitMsg  string
-	TotalAdditions int
-	TotalDeletions int
+	Path           string    `json:"path"`
+	ChangeCount    int       `json:"change_count"`
+	Contributors   int       `json:"contributors"`
+	LastModified   time.Time `json:"last_modified"`
+	LastCommitHash string    `json:"last_commit_hash"`
+	LastCommitMsg  string    `json:"last_commit_msg"`
+	TotalAdditions int       `json:"total_additions"`
+	TotalDeletions int       `json:"total_deletions"`
+	// ChangesByMonth counts the commits changing the file per month, keyed like "2026-01"
+	ChangesByMonth map[string]int `json:"changes_by_month"`
+}
+
+type StaleFileInfo struct {
+	Path           string    `json:"path"`
+	LastModified   time.Time `json:"last_modified"`
+	LastCommitHash string    `json:"last_commit_hash"`
+	LastCommitMsg  string    `json:"last_commit_msg"`
+	Unknown        bool      `json:"unknown"` // File is tracked but was never seen in history
 }
 
 type ExtensionInfo struct {
-	Extension   string
-	FileCount   int
-	TotalSize   int64
-	AverageSize int64
-	Language    string
+	Extension   string `json:"extension"`
+	FileCount   int    `json:"file_count"`
+	TotalSize   int64  `json:"total_size"`
+	AverageSize int64  `json:"average_size"`
+	Language    string `json:"language"`
 }
 
 type FileContributorInfo struct {
-	Path         string
-	Contributors []ContributorStat
-	TotalChanges int
-	Ownership    string // Most active contributor
+	Path         string            `json:"path"`
+	Contributors []ContributorStat `json:"contributors"`
+	TotalChanges int               `json:"total_changes"`
+	Ownership    string            `json:"ownership"` // Most active contributor
 }
 
 type ContributorStat struct {
-	Name       string
-	Changes    int
-	Percentage float64
+	Name       string  `json:"name"`
+	Changes    int     `json:"changes"`
+	Percentage float64 `json:"percentage"`
 }
 
 type model struct {
-	analysis    FileAnalysis
-	currentView ViewMode
-	fileList    list.Model
-	loading     bool
-	err         error
-	tuiHelper *terminal.ResponsiveTUIHelper
-	sections    []string
+	analysis     FileAnalysis
+	currentView  ViewMode
+	fileList     list.Model
+	listDelegate list.ItemDelegate
+	loading      bool
+	err          error
+	tuiHelper    *terminal.ResponsiveTUIHelper
+	keys         terminal.KeyMap
+	sections     []string
+	opts         FileAnalysisOptions
+	// expandedDirs are the directories whose contributors are listed in the
+	// directory owners view
+	expandedDirs map[string]bool
+	// sortBy is the index of each list view's order in its sorts; 0 is the analysis order
+	sortBy map[ViewMode]int
+	// openFile is the file to open in the blame viewer once the TUI quits; openErr is
+	// why the last one couldn't be opened
+	openFile string
+	openErr  error
+
+	loader gitservice.Loading
 }
 
 type fileItem struct {
 	file interface{}
+	// expanded is set on a DirectoryOwnerInfo whose contributors are listed below it
+	expanded bool
+	// sparkline is the changes per month of a FrequentFileInfo, sized to the terminal
+	sparkline string
 }
 
 func (i fileItem) FilterValue() string {
@@ -108,6 +194,14 @@ func (i fileItem) FilterValue() string {
 		return f.Extension
 	case FileContributorInfo:
 		return f.Path
+	case AtRiskFileInfo:
+		return f.Path + " " + f.Owner
+	case StaleFileInfo:
+		return f.Path
+	case DirectoryOwnerInfo:
+		return f.Path + " " + f.Ownership
+	case directoryContributor:
+		return f.Dir + " " + f.Stat.Name
 	default:
 		return ""
 	}
@@ -123,6 +217,17 @@ func (i fileItem) Title() string {
 		return fmt.Sprintf("%s (%d files)", f.Extension, f.FileCount)
 	case FileContributorInfo:
 		return fmt.Sprintf("%s (%d contributors)", f.Path, len(f.Contributors))
+	case AtRiskFileInfo:
+		return fmt.Sprintf("%s (%.0f%% %s)", f.Path, f.Concentration, f.Owner)
+	case StaleFileInfo:
+		if f.Unknown {
+			return fmt.Sprintf("%s (unknown)", f.Path)
+		}
+		return fmt.Sprintf("%s (%s)", f.Path, f.LastModified.Format("2006-01-02"))
+	case DirectoryOwnerInfo:
+		return f.title(i.expanded)
+	case directoryContributor:
+		return f.title()
 	default:
 		return "Unknown"
 	}
@@ -133,11 +238,26 @@ func (i fileItem) Description() string {
 	case LargeFileInfo:
 		return fmt.Sprintf("Type: %s • Extension: %s", f.Type, f.Extension)
 	case FrequentFileInfo:
-		return fmt.Sprintf("Contributors: %d • Last: %s", f.Contributors, f.LastModified.Format("2006-01-02"))
+		description := fmt.Sprintf("Contributors: %d • Last: %s", f.Contributors, f.LastModified.Format("2006-01-02"))
+		if i.sparkline != "" {
+			description += " • " + i.sparkline
+		}
+		return description
 	case ExtensionInfo:
 		return fmt.Sprintf("Language: %s • Total: %s", f.Language, formatBytes(f.TotalSize))
 	case FileContributorInfo:
 		return fmt.Sprintf("Main contributor: %s • %d total changes", f.Ownership, f.TotalChanges)
+	case AtRiskFileInfo:
+		return fmt.Sprintf("Contributors: %d • %d total changes", f.Contributors, f.TotalChanges)
+	case StaleFileInfo:
+		if f.Unknown {
+			return "No commits found for this file"
+		}
+		return fmt.Sprintf("%s • %s", f.LastCommitHash, f.LastCommitMsg)
+	case DirectoryOwnerInfo:
+		return f.description()
+	case directoryContributor:
+		return f.description()
 	default:
 		return ""
 	}
@@ -153,39 +273,46 @@ type errMsg struct {
 
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#F25D94")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1)
 
 	sectionStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			MarginTop(1)
 
 	headerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			MarginBottom(1)
 
 	statsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575"))
+			Foreground(theme.Current.Success)
 
 	highlightStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#01FAC6")).
+			Foreground(theme.Current.Accent).
 			Bold(true)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87"))
+			Foreground(theme.Current.Error)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 )
 
 func (m model) Init() tea.Cmd {
-	return loadFileAnalysis
+	// Back from the blame viewer, the analysis is already loaded
+	if !m.loading {
+		return nil
+	}
+	return tea.Batch(
+		m.loader.Init(),
+		loadFileAnalysis(m.opts, m.loader.Progress),
+	)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -193,9 +320,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.tuiHelper.HandleWindowSizeMsg(msg)
 		m.fileList.SetWidth(m.tuiHelper.GetWidth())
+		// The sparklines are as wide as the terminal allows
+		if !m.loading && m.currentView == FrequentFilesView {
+			m.updateListItems()
+		}
 		m.fileList.SetHeight(m.tuiHelper.GetHeight() - 12)
 		return m, nil
 
+	case spinner.TickMsg, gitservice.ProgressMsg:
+		var cmd tea.Cmd
+		m.loader, cmd = m.loader.Update(msg)
+		return m, cmd
+
 	case dataLoadedMsg:
 		m.analysis = msg.analysis
 		m.loading = false
@@ -205,6 +341,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			"Frequent Changes",
 			"Extensions",
 			"Contributors",
+			"Ownership Risk",
+			"Stale Files",
+			"Lines of Code",
+			"Directory Owners",
 		}
 		m.updateListItems()
 		return m, nil
@@ -214,9 +354,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		return m, nil
 
+	case tea.MouseMsg:
+		// The overview and line counts have no list; every other view shows fileList
+		if !m.loading && m.currentView != OverviewView && m.currentView != LinesView && len(m.fileList.Items()) > 0 &&
+			terminal.HandleListMouse(&m.fileList, m.listDelegate, m.listTop(), msg) {
+			// Clicking an item acts like pressing enter on it: it opens a file, or
+			// expands a directory of the owners view
+			return m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		// The error screen can only retry the analysis or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				return m.retry()
+			case key.Matches(msg, m.keys.Quit, m.keys.Back):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
 		switch {
-		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c", "esc"))):
+		case key.Matches(msg, m.keys.Quit, m.keys.Back):
 			return m, tea.Quit
 		case key.Matches(msg, key.NewBinding(key.WithKeys("1"))):
 			m.currentView = OverviewView
@@ -238,13 +399,42 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentView = ContributorsView
 			m.updateListItems()
 			return m, nil
-		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+		case key.Matches(msg, key.NewBinding(key.WithKeys("6"))):
+			m.currentView = OwnershipView
+			m.updateListItems()
+			return m, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("7"))):
+			m.currentView = StaleFilesView
+			m.updateListItems()
+			return m, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("8"))):
+			m.currentView = LinesView
+			m.updateListItems()
+			return m, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("9"))):
+			m.currentView = DirectoryOwnersView
+			m.updateListItems()
+			return m, nil
+		case m.fileList.FilterState() != list.Filtering && key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+			m.cycleSort()
+			return m, nil
+		case m.currentView == DirectoryOwnersView && m.fileList.FilterState() != list.Filtering &&
+			len(m.fileList.Items()) > 0 && key.Matches(msg, m.keys.Select, key.NewBinding(key.WithKeys(" "))):
+			m.toggleDirectory()
+			return m, nil
+		case m.fileList.FilterState() != list.Filtering && key.Matches(msg, m.keys.Select):
+			if path := m.selectedPath(); path != "" {
+				m.openFile = path
+				return m, tea.Quit
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Left):
 			if m.currentView > 0 {
 				m.currentView--
 				m.updateListItems()
 			}
 			return m, nil
-		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
+		case key.Matches(msg, m.keys.Right):
 			if int(m.currentView) < len(m.sections)-1 {
 				m.currentView++
 				m.updateListItems()
@@ -265,64 +455,108 @@ func (m *model) updateListItems() {
 
 	switch m.currentView {
 	case LargeFilesView:
-		for _, file := range m.analysis.LargeFiles {
+		for _, file := range gitservice.SortList(m.analysis.LargeFiles, largeFileSorts[m.sortBy[LargeFilesView]]) {
 			items = append(items, fileItem{file: file})
 		}
 	case FrequentFilesView:
-		for _, file := range m.analysis.FrequentFiles {
-			items = append(items, fileItem{file: file})
+		months, now := sparklineMonths(m.tuiHelper.GetWidth()), time.Now()
+		for _, file := range gitservice.SortList(m.analysis.FrequentFiles, frequentFileSorts[m.sortBy[FrequentFilesView]]) {
+			items = append(items, fileItem{file: file, sparkline: changeSparkline(file.ChangesByMonth, months, now)})
 		}
 	case ExtensionsView:
-		for _, ext := range m.analysis.ExtensionBreakdown {
+		for _, ext := range gitservice.SortList(m.analysis.ExtensionBreakdown, extensionSorts[m.sortBy[ExtensionsView]]) {
 			items = append(items, fileItem{file: ext})
 		}
 	case ContributorsView:
-		for _, file := range m.analysis.FileContributors {
+		for _, file := range gitservice.SortList(m.analysis.FileContributors, fileContributorSorts[m.sortBy[ContributorsView]]) {
 			items = append(items, fileItem{file: file})
 		}
+	case OwnershipView:
+		for _, file := range gitservice.SortList(m.analysis.OwnershipRisk.AtRiskFiles, atRiskFileSorts[m.sortBy[OwnershipView]]) {
+			items = append(items, fileItem{file: file})
+		}
+	case StaleFilesView:
+		for _, file := range gitservice.SortList(m.analysis.StaleFiles, staleFileSorts[m.sortBy[StaleFilesView]]) {
+			items = append(items, fileItem{file: file})
+		}
+	case DirectoryOwnersView:
+		for _, item := range directoryItems(m.analysis.DirectoryOwnership, m.expandedDirs) {
+			items = append(items, item)
+		}
 	}
 
 	m.fileList.SetItems(items)
 }
 
+// retry starts the analysis over after it failed, with a fresh progress stream as the
+// last one was closed
+func (m model) retry() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.loading = true
+	m.loader = gitservice.NewLoading()
+	return m, m.Init()
+}
+
 func (m model) View() string {
 	if m.loading {
-		return "\n  Analyzing repository files...\n"
+		return "\n  " + m.loader.View("Analyzing repository files...", helpStyle.PaddingLeft(2)) + "\n"
 	}
 
 	if m.err != nil {
-		return errorStyle.Render(fmt.Sprintf("\n  Error: %v\n", m.err))
+		return errorStyle.Render(fmt.Sprintf("\n  Error: %v\n", m.err)) + helpStyle.Render("  "+m.keys.ErrorHelp()) + "\n"
 	}
 
 	var sections []string
 
-	// Title
-	title := titleStyle.Render("📁 File Analysis")
-	sections = append(sections, title)
-
-	// Navigation tabs
-	tabs := m.renderTabs()
-	sections = append(sections, tabs)
+	// Title and navigation tabs
+	sections = append(sections, m.renderHeader())
 
 	// Content based on current view
 	content := m.renderCurrentView()
 	sections = append(sections, sectionStyle.Render(content))
 
 	// Instructions
-	help := helpStyle.Render("1-5: sections • ←/→: navigate • ↑/↓: scroll • q: quit")
+	helpEntries := []string{"1-9: sections",
+		terminal.HelpKey(m.keys.Left, m.keys.Right) + ": navigate",
+		terminal.HelpKey(m.keys.Up, m.keys.Down) + ": scroll"}
+	switch {
+	case m.currentView == DirectoryOwnersView:
+		helpEntries = append(helpEntries, terminal.Help(m.keys.Select, "expand/collapse"))
+	case m.currentView == FrequentFilesView || m.currentView == ContributorsView:
+		helpEntries = append(helpEntries, "s: change sort", terminal.Help(m.keys.Select, "blame & history"))
+	case m.sortName() != "":
+		helpEntries = append(helpEntries, "s: change sort")
+	}
+	helpText := terminal.HelpLine(append(helpEntries, terminal.Help(m.keys.Quit, "quit"))...)
+	if m.openErr != nil {
+		sections = append(sections, errorStyle.Render(fmt.Sprintf("Couldn't open the blame viewer: %v", m.openErr)))
+	}
+	help := helpStyle.Render(helpText)
 	sections = append(sections, help)
 
 	return strings.Join(sections, "\n")
 }
 
+func (m model) renderHeader() string {
+	title := titleStyle.Render("📁 File Analysis")
+	return title + "\n" + m.renderTabs()
+}
+
+// listTop returns the screen row where fileList starts. renderWithList draws the list
+// last, so the text before it is the view's content with the list's output trimmed off.
+func (m model) listTop() int {
+	prefix := strings.TrimSuffix(m.renderCurrentView(), m.fileList.View())
+	return lipgloss.Height(m.renderHeader()) + terminal.StyleTopOffset(sectionStyle) + strings.Count(prefix, "\n")
+}
+
 func (m model) renderTabs() string {
 	var tabs []string
 
 	for i, section := range m.sections {
 		style := lipgloss.NewStyle().Padding(0, 1)
 		if ViewMode(i) == m.currentView {
-			style = style.Foreground(lipgloss.Color("#01FAC6")).Bold(true).
-				Background(lipgloss.Color("#874BFD"))
+			style = style.Foreground(theme.Current.Accent).Bold(true).
+				Background(theme.Current.Primary)
 		}
 		tabs = append(tabs, style.Render(fmt.Sprintf("%d. %s", i+1, section)))
 	}
@@ -337,11 +571,24 @@ func (m model) renderCurrentView() string {
 	case LargeFilesView:
 		return m.renderWithList("📦 Large Files", "Files larger than 100KB")
 	case FrequentFilesView:
-		return m.renderWithList("🔄 Frequently Changed Files", "Files with the most commits")
+		return m.renderWithList("🔄 Frequently Changed Files",
+			fmt.Sprintf("Files with the most commits • changes per month over the last %d months", sparklineMonths(m.tuiHelper.GetWidth())))
 	case ExtensionsView:
 		return m.renderWithList("🗂️ File Extensions", "File types and their distribution")
 	case ContributorsView:
 		return m.renderWithList("👥 File Contributors", "Files with multiple contributors")
+	case OwnershipView:
+		return m.renderWithList("⚠️ Ownership Risk",
+			fmt.Sprintf("Bus factor: %d • Files where one author made >%.0f%% of changes",
+				m.analysis.OwnershipRisk.BusFactor, ownershipRiskThreshold))
+	case StaleFilesView:
+		return m.renderWithList("🕸️ Stale Files",
+			fmt.Sprintf("Tracked files not modified in the last %d months", m.opts.StaleMonths))
+	case LinesView:
+		return m.renderLines()
+	case DirectoryOwnersView:
+		return m.renderWithList("🏠 Directory Owners",
+			fmt.Sprintf("Changes under each directory, %d levels deep, added up per contributor", maxDirectoryDepth))
 	default:
 		return "Unknown view"
 	}
@@ -370,6 +617,20 @@ func (m model) renderOverview() string {
 	content.WriteString(fmt.Sprintf("Text Files: %s\n",
 		statsStyle.Render(fmt.Sprintf("%d", overview.TextFiles))))
 
+	if wt := overview.WorkingTree; wt != nil {
+		content.WriteString("\n")
+		content.WriteString(headerStyle.Render("🗂️  Working Tree (not committed)"))
+		content.WriteString("\n\n")
+		content.WriteString(fmt.Sprintf("Untracked: %s (%s)\n",
+			statsStyle.Render(fmt.Sprintf("%d files", wt.UntrackedFiles)),
+			statsStyle.Render(formatBytes(wt.UntrackedSize))))
+		writeLargestWorkingFiles(&content, wt.Untracked)
+		content.WriteString(fmt.Sprintf("Ignored: %s (%s)\n",
+			statsStyle.Render(fmt.Sprintf("%d files", wt.IgnoredFiles)),
+			statsStyle.Render(formatBytes(wt.IgnoredSize))))
+		writeLargestWorkingFiles(&content, wt.Ignored)
+	}
+
 	// Quick stats from other views
 	content.WriteString("\n")
 	content.WriteString(headerStyle.Render("📈 Quick Statistics"))
@@ -392,15 +653,60 @@ func (m model) renderOverview() string {
 			highlightStyle.Render(mostCommon.Extension), mostCommon.FileCount))
 	}
 
+	if m.analysis.OwnershipRisk.BusFactor > 0 {
+		content.WriteString(fmt.Sprintf("Bus Factor: %s (%d single-owner files)\n",
+			highlightStyle.Render(fmt.Sprintf("%d", m.analysis.OwnershipRisk.BusFactor)),
+			len(m.analysis.OwnershipRisk.AtRiskFiles)))
+	}
+
+	return content.String()
+}
+
+// renderLines renders the cloc-style line counts per language
+func (m model) renderLines() string {
+	var content strings.Builder
+
+	content.WriteString(headerStyle.Render("🧮 Lines of Code"))
+	content.WriteString("\n")
+	content.WriteString("Code, comment and blank lines of the text files in each language")
+	content.WriteString("\n\n")
+
+	counts := m.analysis.LineCounts
+	if len(counts) == 0 {
+		content.WriteString("No text files in a known language")
+		return content.String()
+	}
+
+	content.WriteString(highlightStyle.Render(fmt.Sprintf("%-16s %8s %8s %8s %8s", "Language", "Files", "Blank", "Comment", "Code")))
+	content.WriteString("\n")
+	for _, lines := range counts {
+		content.WriteString(fmt.Sprintf("%-16s %8d %8d %8d %s\n", lines.Language, lines.Files, lines.Blank, lines.Comment,
+			statsStyle.Render(fmt.Sprintf("%8d", lines.Code))))
+	}
+	total := totalLines(counts)
+	content.WriteString(highlightStyle.Render(fmt.Sprintf("%-16s %8d %8d %8d %8d", total.Language, total.Files, total.Blank, total.Comment, total.Code)))
+	content.WriteString("\n")
+
 	return content.String()
 }
 
+// writeLargestWorkingFiles lists the largest few of files, which are sorted largest first
+func writeLargestWorkingFiles(content *strings.Builder, files []WorkingFileInfo) {
+	for _, f := range files[:min(len(files), 3)] {
+		content.WriteString(fmt.Sprintf("  %s (%s)\n", highlightStyle.Render(f.Path), formatBytes(f.Size)))
+	}
+}
+
 func (m model) renderWithList(title, subtitle string) string {
 	var content strings.Builder
 
 	content.WriteString(headerStyle.Render(title))
 	content.WriteString("\n")
 	content.WriteString(subtitle)
+	if sortName := m.sortName(); sortName != "" {
+		content.WriteString(" • sorted by ")
+		content.WriteString(highlightStyle.Render(sortName))
+	}
 	content.WriteString("\n\n")
 
 	if len(m.fileList.Items()) == 0 {
@@ -412,23 +718,29 @@ func (m model) renderWithList(title, subtitle string) string {
 	return content.String()
 }
 
-func loadFileAnalysis() tea.Msg {
-	analysis, err := analyzeFiles()
-	if err != nil {
-		return errMsg{err}
+func loadFileAnalysis(opts FileAnalysisOptions, progress *gitservice.Progress) tea.Cmd {
+	return func() tea.Msg {
+		defer progress.Close()
+
+		analysis, err := analyzeFiles(opts, progress)
+		if err != nil {
+			return errMsg{err}
+		}
+		return dataLoadedMsg{analysis}
 	}
-	return dataLoadedMsg{analysis}
 }
 
-func analyzeFiles() (FileAnalysis, error) {
-	repo, err := git.PlainOpen(".")
+// analyzeFiles runs the full file analysis, reporting history progress to progress
+// (which may be nil)
+func analyzeFiles(opts FileAnalysisOptions, progress *gitservice.Progress) (FileAnalysis, error) {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
 	if err != nil {
-		return FileAnalysis{}, fmt.Errorf("failed to open repository: %w", err)
+		return FileAnalysis{}, err
 	}
 
-	ref, err := repo.Head()
+	ref, err := gitservice.Head(repo)
 	if err != nil {
-		return FileAnalysis{}, fmt.Errorf("failed to get HEAD: %w", err)
+		return FileAnalysis{}, err
 	}
 
 	commit, err := repo.CommitObject(ref.Hash())
@@ -444,24 +756,43 @@ func analyzeFiles() (FileAnalysis, error) {
 	analysis := FileAnalysis{}
 
 	// Analyze current files in git tree
-	err = analyzeCurrentFiles(tree, &analysis)
+	trackedFiles, err := analyzeCurrentFiles(tree, &analysis)
 	if err != nil {
 		return FileAnalysis{}, fmt.Errorf("failed to analyze current files: %w", err)
 	}
 
+	if opts.IncludeUntracked {
+		analysis.Overview.WorkingTree, err = scanWorkingTree(repo)
+		if err != nil {
+			return FileAnalysis{}, err
+		}
+	}
+
 	// Analyze file history
-	err = analyzeFileHistory(repo, &analysis)
+	stats := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+	err = analyzeFileHistory(repo, &analysis, stats, progress)
+	// #nosec G104 - A failed cache write only means the next run recomputes stats
+	stats.Save()
 	if err != nil {
 		return FileAnalysis{}, fmt.Errorf("failed to analyze file history: %w", err)
 	}
 
+	// Ownership risk is computed before results are truncated for display
+	analysis.OwnershipRisk = analyzeOwnershipRisk(analysis.FileContributors, trackedFiles)
+	analysis.StaleFiles = findStaleFiles(analysis.FrequentFiles, trackedFiles, opts.StaleMonths, time.Now())
+
 	// Process and sort results
-	processAnalysisResults(&analysis)
+	if !opts.NoLimit {
+		processAnalysisResults(&analysis)
+	}
 
 	return analysis, nil
 }
 
-func analyzeCurrentFiles(tree *object.Tree, analysis *FileAnalysis) error {
+// analyzeCurrentFiles gathers size/type statistics for the files in tree and returns
+// the set of tracked file paths
+func analyzeCurrentFiles(tree *object.Tree, analysis *FileAnalysis) (map[string]bool, error) {
+	trackedFiles := make(map[string]bool)
 	var totalSize int64
 	var fileCount int
 	var largestFile string
@@ -469,9 +800,11 @@ func analyzeCurrentFiles(tree *object.Tree, analysis *FileAnalysis) error {
 	var binaryCount int
 
 	extensionStats := make(map[string]*ExtensionInfo)
+	lineCounts := make(lineCounter)
 	var largeFiles []LargeFileInfo
 
 	err := tree.Files().ForEach(func(file *object.File) error {
+		trackedFiles[file.Name] = true
 		fileCount++
 		totalSize += file.Size
 
@@ -481,26 +814,43 @@ func analyzeCurrentFiles(tree *object.Tree, analysis *FileAnalysis) error {
 			largestFile = file.Name
 		}
 
+		// Sniff the start of the file for binary/shebang detection
+		head, headErr := readFileHead(file)
+
 		// File extension analysis
 		ext := strings.ToLower(filepath.Ext(file.Name))
 		if ext == "" {
 			ext = "no extension"
 		}
 
-		if extensionStats[ext] == nil {
-			extensionStats[ext] = &ExtensionInfo{
-				Extension: ext,
-				Language:  getLanguageForExtension(ext),
+		language := getLanguageForExtension(ext)
+		key := ext
+		if language == "Unknown" {
+			// Group unknown/extensionless files by their sniffed language
+			if sniffed := detectLanguageFromContent(file.Name, head); sniffed != "" {
+				language = sniffed
+				key = fmt.Sprintf("%s (%s)", ext, sniffed)
 			}
 		}
-		extensionStats[ext].FileCount++
-		extensionStats[ext].TotalSize += file.Size
 
-		// Check if binary
+		if extensionStats[key] == nil {
+			extensionStats[key] = &ExtensionInfo{
+				Extension: key,
+				Language:  language,
+			}
+		}
+		extensionStats[key].FileCount++
+		extensionStats[key].TotalSize += file.Size
+
+		// Check if binary, falling back to the extension if the blob can't be read
 		isBinary := isBinaryFile(file.Name)
+		if headErr == nil {
+			isBinary = isBinaryContent(head)
+		}
 		if isBinary {
 			binaryCount++
 		}
+		lineCounts.add(file, language, isBinary)
 
 		// Large files (>100KB)
 		if file.Size > 100*1024 {
@@ -520,7 +870,7 @@ func analyzeCurrentFiles(tree *object.Tree, analysis *FileAnalysis) error {
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Calculate averages for extensions
@@ -561,12 +911,13 @@ func analyzeCurrentFiles(tree *object.Tree, analysis *FileAnalysis) error {
 
 	analysis.LargeFiles = largeFiles
 	analysis.ExtensionBreakdown = extensions
+	analysis.LineCounts = lineCounts.sorted()
 
-	return nil
+	return trackedFiles, nil
 }
 
-func analyzeFileHistory(repo *git.Repository, analysis *FileAnalysis) error {
-	ref, err := repo.Head()
+func analyzeFileHistory(repo *git.Repository, analysis *FileAnalysis, statsCache *gitservice.CommitStatsCache, progress *gitservice.Progress) error {
+	ref, err := gitservice.Head(repo)
 	if err != nil {
 		return err
 	}
@@ -575,12 +926,15 @@ func analyzeFileHistory(repo *git.Repository, analysis *FileAnalysis) error {
 	if err != nil {
 		return err
 	}
+	progress.CountCommits(repo, ref.Hash(), 0)
 
 	fileChangeCount := make(map[string]*FrequentFileInfo)
 	fileContributors := make(map[string]map[string]int) // file -> contributor -> count
 
+	walked := profile.Phase(profile.LogWalk)
 	err = cIter.ForEach(func(c *object.Commit) error {
-		stats, err := c.Stats()
+		progress.Commit()
+		stats, err := statsCache.Stats(c)
 		if err != nil {
 			return nil // Skip commits we can't analyze
 		}
@@ -592,6 +946,7 @@ func analyzeFileHistory(repo *git.Repository, analysis *FileAnalysis) error {
 			if fileChangeCount[fileName] == nil {
 				fileChangeCount[fileName] = &FrequentFileInfo{
 					Path:           fileName,
+					ChangesByMonth: make(map[string]int),
 					LastModified:   c.Author.When,
 					LastCommitHash: c.Hash.String()[:8],
 					LastCommitMsg:  strings.Split(c.Message, "\n")[0],
@@ -603,6 +958,7 @@ func analyzeFileHistory(repo *git.Repository, analysis *FileAnalysis) error {
 			fileInfo.ChangeCount++
 			fileInfo.TotalAdditions += stat.Addition
 			fileInfo.TotalDeletions += stat.Deletion
+			fileInfo.ChangesByMonth[c.Author.When.Format(monthKey)]++
 
 			// Update last modified if this commit is newer
 			if c.Author.When.After(fileInfo.LastModified) {
@@ -620,6 +976,8 @@ func analyzeFileHistory(repo *git.Repository, analysis *FileAnalysis) error {
 
 		return nil
 	})
+	walked()
+	defer profile.Phase(profile.Render)()
 
 	if err != nil {
 		return err
@@ -681,10 +1039,149 @@ func analyzeFileHistory(repo *git.Repository, analysis *FileAnalysis) error {
 
 	analysis.FrequentFiles = frequentFiles
 	analysis.FileContributors = fileContribData
+	analysis.DirectoryOwnership = directoryOwnership(fileContributors)
 
 	return nil
 }
 
+// analyzeOwnershipRisk flags files dominated by a single author and computes the
+// repository bus factor: the minimum number of authors whose removal leaves more
+// than half of the tracked files without a knowledgeable contributor.
+func analyzeOwnershipRisk(fileContributors []FileContributorInfo, trackedFiles map[string]bool) OwnershipRisk {
+	var atRisk []AtRiskFileInfo
+	fileAuthors := make(map[string][]string) // file -> knowledgeable authors
+
+	for _, file := range fileContributors {
+		if !trackedFiles[file.Path] || len(file.Contributors) == 0 {
+			continue
+		}
+
+		// Contributors are sorted by changes, so the first one is the owner
+		for i, contributor := range file.Contributors {
+			if i == 0 || contributor.Percentage >= knowledgeThreshold {
+				fileAuthors[file.Path] = append(fileAuthors[file.Path], contributor.Name)
+			}
+		}
+
+		owner := file.Contributors[0]
+		if owner.Percentage > ownershipRiskThreshold {
+			atRisk = append(atRisk, AtRiskFileInfo{
+				Path:          file.Path,
+				Owner:         owner.Name,
+				Concentration: owner.Percentage,
+				TotalChanges:  file.TotalChanges,
+				Contributors:  len(file.Contributors),
+			})
+		}
+	}
+
+	// Most concentrated first, then by amount of history at stake
+	sort.Slice(atRisk, func(i, j int) bool {
+		if atRisk[i].Concentration != atRisk[j].Concentration {
+			return atRisk[i].Concentration > atRisk[j].Concentration
+		}
+		return atRisk[i].TotalChanges > atRisk[j].TotalChanges
+	})
+
+	return OwnershipRisk{
+		BusFactor:   calculateBusFactor(fileAuthors),
+		AtRiskFiles: atRisk,
+	}
+}
+
+// calculateBusFactor greedily removes the author covering the most remaining files
+// until more than half of the files are orphaned, returning the number removed.
+func calculateBusFactor(fileAuthors map[string][]string) int {
+	if len(fileAuthors) == 0 {
+		return 0
+	}
+
+	removed := make(map[string]bool)
+	orphaned := 0
+	busFactor := 0
+
+	for orphaned*2 <= len(fileAuthors) {
+		// Count files each remaining author still covers
+		coverage := make(map[string]int)
+		for _, authors := range fileAuthors {
+			for _, author := range authors {
+				if !removed[author] {
+					coverage[author]++
+				}
+			}
+		}
+		if len(coverage) == 0 {
+			break
+		}
+
+		// Pick the author with the most coverage (name breaks ties for stable results)
+		top := ""
+		for author, count := range coverage {
+			if top == "" || count > coverage[top] || (count == coverage[top] && author < top) {
+				top = author
+			}
+		}
+		removed[top] = true
+		busFactor++
+
+		orphaned = 0
+		for _, authors := range fileAuthors {
+			alive := false
+			for _, author := range authors {
+				if !removed[author] {
+					alive = true
+					break
+				}
+			}
+			if !alive {
+				orphaned++
+			}
+		}
+	}
+
+	return busFactor
+}
+
+// findStaleFiles returns tracked files whose last change is older than staleMonths,
+// oldest first. Tracked files with no history are listed last as unknown.
+func findStaleFiles(history []FrequentFileInfo, trackedFiles map[string]bool, staleMonths int, now time.Time) []StaleFileInfo {
+	cutoff := now.AddDate(0, -staleMonths, 0)
+	seen := make(map[string]bool)
+
+	var stale []StaleFileInfo
+	for _, file := range history {
+		if !trackedFiles[file.Path] {
+			continue
+		}
+		seen[file.Path] = true
+
+		if file.LastModified.Before(cutoff) {
+			stale = append(stale, StaleFileInfo{
+				Path:           file.Path,
+				LastModified:   file.LastModified,
+				LastCommitHash: file.LastCommitHash,
+				LastCommitMsg:  file.LastCommitMsg,
+			})
+		}
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].LastModified.Before(stale[j].LastModified)
+	})
+
+	var unknown []StaleFileInfo
+	for path := range trackedFiles {
+		if !seen[path] {
+			unknown = append(unknown, StaleFileInfo{Path: path, Unknown: true})
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		return unknown[i].Path < unknown[j].Path
+	})
+
+	return append(stale, unknown...)
+}
+
 func processAnalysisResults(analysis *FileAnalysis) {
 	// Limit results to prevent overwhelming display
 	if len(analysis.LargeFiles) > 50 {
@@ -699,6 +1196,21 @@ func processAnalysisResults(analysis *FileAnalysis) {
 	if len(analysis.FileContributors) > 50 {
 		analysis.FileContributors = analysis.FileContributors[:50]
 	}
+	if len(analysis.OwnershipRisk.AtRiskFiles) > 50 {
+		analysis.OwnershipRisk.AtRiskFiles = analysis.OwnershipRisk.AtRiskFiles[:50]
+	}
+	if len(analysis.StaleFiles) > 50 {
+		analysis.StaleFiles = analysis.StaleFiles[:50]
+	}
+	analysis.DirectoryOwnership = limitDirectoryOwnership(analysis.DirectoryOwnership, 50)
+	if wt := analysis.Overview.WorkingTree; wt != nil {
+		if len(wt.Untracked) > 50 {
+			wt.Untracked = wt.Untracked[:50]
+		}
+		if len(wt.Ignored) > 50 {
+			wt.Ignored = wt.Ignored[:50]
+		}
+	}
 }
 
 func getLanguageForExtension(ext string) string {
@@ -745,6 +1257,112 @@ func getLanguageForExtension(ext string) string {
 	return "Unknown"
 }
 
+// sniffSize is the number of bytes inspected when classifying file contents,
+// matching the heuristic git itself uses
+const sniffSize = 8000
+
+// readFileHead returns up to sniffSize bytes from the start of a file
+func readFileHead(file *object.File) ([]byte, error) {
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return head[:n], nil
+}
+
+// isBinaryContent reports whether data looks binary (contains a null byte)
+func isBinaryContent(data []byte) bool {
+	return bytes.IndexByte(data, 0) != -1
+}
+
+// detectLanguageFromContent guesses the language of a file with no known extension
+// using well-known filenames and shebang lines. Returns "" if nothing matched.
+func detectLanguageFromContent(path string, head []byte) string {
+	filenames := map[string]string{
+		"makefile":    "Makefile",
+		"gnumakefile": "Makefile",
+		"dockerfile":  "Dockerfile",
+		"jenkinsfile": "Groovy",
+		"vagrantfile": "Ruby",
+		"gemfile":     "Ruby",
+		"rakefile":    "Ruby",
+		"justfile":    "Just",
+	}
+
+	base := strings.ToLower(filepath.Base(path))
+	if lang, exists := filenames[base]; exists {
+		return lang
+	}
+	if strings.HasPrefix(base, "dockerfile.") || strings.HasSuffix(base, ".dockerfile") {
+		return "Dockerfile"
+	}
+
+	return detectShebangLanguage(head)
+}
+
+// detectShebangLanguage maps a "#!" interpreter line to a language name
+func detectShebangLanguage(head []byte) string {
+	if !bytes.HasPrefix(head, []byte("#!")) {
+		return ""
+	}
+
+	line := string(head[2:])
+	if idx := strings.IndexByte(line, '\n'); idx != -1 {
+		line = line[:idx]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	// Handle "#!/usr/bin/env [-S] interpreter"
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") && !strings.Contains(field, "=") {
+				interpreter = filepath.Base(field)
+				break
+			}
+		}
+	}
+
+	// Strip version suffixes like python3.12
+	interpreter = strings.TrimRight(interpreter, "0123456789.")
+
+	interpreters := map[string]string{
+		"sh":     "Shell",
+		"bash":   "Shell",
+		"zsh":    "Shell",
+		"dash":   "Shell",
+		"ksh":    "Shell",
+		"fish":   "Fish",
+		"python": "Python",
+		"node":   "JavaScript",
+		"deno":   "TypeScript",
+		"ruby":   "Ruby",
+		"perl":   "Perl",
+		"php":    "PHP",
+		"lua":    "Lua",
+		"pwsh":   "PowerShell",
+		"awk":    "Awk",
+		"gawk":   "Awk",
+	}
+
+	if lang, exists := interpreters[interpreter]; exists {
+		return lang
+	}
+	return ""
+}
+
 func isBinaryFile(path string) bool {
 	ext := strings.ToLower(filepath.Ext(path))
 	binaryExts := []string{
@@ -775,27 +1393,105 @@ func formatBytes(bytes int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
+// AnalyzeFilesJSON runs the file analysis without the TUI, for machine-readable output
+func AnalyzeFilesJSON(opts FileAnalysisOptions) (FileAnalysis, error) {
+	if opts.StaleMonths <= 0 {
+		opts.StaleMonths = 12
+	}
+	return analyzeFiles(opts, nil)
+}
+
 // RunFileAnalysis starts the file analysis TUI
-func RunFileAnalysis() error {
+func RunFileAnalysis(opts FileAnalysisOptions) error {
+	if opts.StaleMonths <= 0 {
+		opts.StaleMonths = 12
+	}
+
+	if err := gitservice.CheckHistory(opts.RepoPath); err != nil {
+		return err
+	}
+
+	if opts.Lines {
+		counts, err := countLinesAtHead(opts.RepoPath)
+		if err != nil {
+			return err
+		}
+		if !opts.Report.Enabled() {
+			return writeLinesReport(os.Stdout, counts, "")
+		}
+		return opts.Report.Write(func(w io.Writer, format string) error {
+			return writeLinesReport(w, counts, format)
+		}, linesReportFormats...)
+	}
+
+	if opts.Report.Enabled() {
+		analysis, err := AnalyzeFilesJSON(opts)
+		if err != nil {
+			return err
+		}
+		return opts.Report.Write(func(w io.Writer, format string) error {
+			return writeFilesReport(w, analysis, format)
+		}, filesReportFormats...)
+	}
+
+	if err := terminal.RequireTTY("--format json (or -o FILE, or --lines)"); err != nil {
+		return err
+	}
+
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("#01FAC6")).
-		BorderLeftForeground(lipgloss.Color("#01FAC6"))
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
 	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("#DDDDDD"))
+		Foreground(theme.Current.Text)
 
 	fileList := list.New([]list.Item{}, delegate, 0, 0)
 	fileList.SetShowStatusBar(false)
 	fileList.SetShowHelp(false)
+	fileList.Filter = terminal.FuzzyFilter
 
 	m := model{
-		fileList:    fileList,
-		currentView: OverviewView,
-		loading:     true,
-		tuiHelper: terminal.NewResponsiveTUIHelper(),
+		fileList:     fileList,
+		listDelegate: delegate,
+		currentView:  OverviewView,
+		loading:      true,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.Keys(),
+		opts:         opts,
+		loader:       gitservice.NewLoading(),
 	}
+	m.keys.ApplyToList(&m.fileList)
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
-	return err
+	// The blame viewer for a selected file runs in between two runs of the TUI, which
+	// picks up where it left off
+	for {
+		p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+		final, err := p.Run()
+		if err != nil {
+			return err
+		}
+		m = final.(model)
+		if m.openFile == "" {
+			return nil
+		}
+
+		m.openErr = blameService.RunFileBlame(blameService.BlameOptions{RepoPath: opts.RepoPath, NoCache: opts.NoCache}, m.openFile)
+		m.openFile = ""
+	}
+}
+
+// selectedPath returns the file selected in the frequent changes or contributors view,
+// the views that drill down into the blame viewer, or "" in other views
+func (m model) selectedPath() string {
+	item, ok := m.fileList.SelectedItem().(fileItem)
+	if !ok {
+		return ""
+	}
+	switch f := item.file.(type) {
+	case FrequentFileInfo:
+		return f.Path
+	case FileContributorInfo:
+		return f.Path
+	}
+	return ""
 }
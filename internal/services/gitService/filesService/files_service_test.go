@@ -0,0 +1,244 @@
+package filesService
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/syst/internal/utils/terminal"
+)
+
+func TestDetectLanguageFromContent(t *testing.T) {
+	tests := []struct {
+		path, head, want string
+	}{
+		{"Makefile", "all:\n\tgo build\n", "Makefile"},
+		{"build/Dockerfile.dev", "FROM golang\n", "Dockerfile"},
+		{"scripts/install", "#!/bin/bash\necho hi\n", "Shell"},
+		{"bin/tool", "#!/usr/bin/env python3\nprint()\n", "Python"},
+		{"bin/run", "#!/usr/bin/env -S node --no-warnings\n", "JavaScript"},
+		{"LICENSE", "MIT License\n", ""},
+	}
+	for _, tt := range tests {
+		if got := detectLanguageFromContent(tt.path, []byte(tt.head)); got != tt.want {
+			t.Errorf("detectLanguageFromContent(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestIsBinaryContent(t *testing.T) {
+	if isBinaryContent([]byte("plain text\n")) {
+		t.Error("text content classified as binary")
+	}
+	if !isBinaryContent([]byte{0x89, 'P', 'N', 'G', 0x00, 0x01}) {
+		t.Error("binary content classified as text")
+	}
+}
+
+func TestCalculateBusFactor(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileAuthors map[string][]string
+		want        int
+	}{
+		{"empty", map[string][]string{}, 0},
+		{"single owner", map[string][]string{
+			"a.go": {"alice"},
+			"b.go": {"alice"},
+			"c.go": {"bob"},
+		}, 1},
+		{"shared knowledge", map[string][]string{
+			"a.go": {"alice", "bob"},
+			"b.go": {"bob", "carol"},
+			"c.go": {"carol", "alice"},
+		}, 3},
+	}
+	for _, tt := range tests {
+		if got := calculateBusFactor(tt.fileAuthors); got != tt.want {
+			t.Errorf("%s: calculateBusFactor() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindStaleFiles(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	history := []FrequentFileInfo{
+		{Path: "fresh.go", LastModified: now.AddDate(0, -1, 0)},
+		{Path: "old.go", LastModified: now.AddDate(-2, 0, 0)},
+		{Path: "older.go", LastModified: now.AddDate(-3, 0, 0)},
+		{Path: "deleted.go", LastModified: now.AddDate(-5, 0, 0)},
+	}
+	tracked := map[string]bool{"fresh.go": true, "old.go": true, "older.go": true, "vendored.go": true}
+
+	stale := findStaleFiles(history, tracked, 12, now)
+
+	want := []string{"older.go", "old.go", "vendored.go"}
+	if len(stale) != len(want) {
+		t.Fatalf("got %d stale files, want %d", len(stale), len(want))
+	}
+	for i, path := range want {
+		if stale[i].Path != path {
+			t.Errorf("stale[%d] = %q, want %q", i, stale[i].Path, path)
+		}
+	}
+	if !stale[2].Unknown {
+		t.Error("file with no history should be marked unknown")
+	}
+}
+
+func TestMouseClickSelectsFile(t *testing.T) {
+	delegate := list.NewDefaultDelegate()
+	m := model{
+		fileList:     list.New([]list.Item{}, delegate, 0, 0),
+		listDelegate: delegate,
+		currentView:  OverviewView,
+		loading:      true,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.DefaultKeyMap(),
+	}
+
+	var largeFiles []LargeFileInfo
+	for i := 0; i < 5; i++ {
+		largeFiles = append(largeFiles, LargeFileInfo{Path: fmt.Sprintf("file-%d.bin", i), Size: 200 * 1024})
+	}
+
+	var updated tea.Model = m
+	for _, msg := range []tea.Msg{
+		tea.WindowSizeMsg{Width: 120, Height: 60},
+		dataLoadedMsg{analysis: FileAnalysis{LargeFiles: largeFiles}},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")},
+	} {
+		updated, _ = updated.Update(msg)
+	}
+
+	// Click the row where file-3 is drawn in the rendered view
+	row := -1
+	for i, line := range strings.Split(updated.View(), "\n") {
+		if strings.Contains(line, "file-3.bin") {
+			row = i
+			break
+		}
+	}
+	if row < 0 {
+		t.Fatal("file-3.bin not found in view")
+	}
+
+	updated, _ = updated.Update(tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionPress, Y: row})
+	if got := updated.(model).fileList.Index(); got != 3 {
+		t.Errorf("selected index after click = %d, want 3", got)
+	}
+}
+
+func TestCycleSort(t *testing.T) {
+	delegate := list.NewDefaultDelegate()
+	m := model{
+		fileList:     list.New([]list.Item{}, delegate, 0, 0),
+		listDelegate: delegate,
+		loading:      true,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.DefaultKeyMap(),
+	}
+	when := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	frequent := []FrequentFileInfo{
+		{Path: "b.go", ChangeCount: 9, LastModified: when},
+		{Path: "a.go", ChangeCount: 5, LastModified: when.AddDate(0, 1, 0)},
+	}
+
+	var updated tea.Model = m
+	for _, msg := range []tea.Msg{
+		tea.WindowSizeMsg{Width: 120, Height: 60},
+		dataLoadedMsg{analysis: FileAnalysis{FrequentFiles: frequent}},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")},
+	} {
+		updated, _ = updated.Update(msg)
+	}
+
+	// The second order is the most recently modified first
+	first := updated.(model).fileList.Items()[0].(fileItem).file.(FrequentFileInfo)
+	if first.Path != "a.go" {
+		t.Errorf("first file sorted by last modified = %s, want a.go", first.Path)
+	}
+	if view := updated.View(); !strings.Contains(view, "sorted by") || !strings.Contains(view, "last modified") {
+		t.Errorf("view doesn't show the sort:\n%s", view)
+	}
+	// The analysis keeps its own order
+	if updated.(model).analysis.FrequentFiles[0].Path != "b.go" {
+		t.Error("sorting the list reordered the analysis")
+	}
+
+	// Other views keep their own order
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if got := updated.(model).sortName(); got != "size" {
+		t.Errorf("large files sort = %q, want size", got)
+	}
+}
+
+func TestEnterOpensBlame(t *testing.T) {
+	delegate := list.NewDefaultDelegate()
+	m := model{
+		fileList:     list.New([]list.Item{}, delegate, 0, 0),
+		listDelegate: delegate,
+		loading:      true,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.DefaultKeyMap(),
+	}
+	analysis := FileAnalysis{
+		LargeFiles:       []LargeFileInfo{{Path: "big.bin"}},
+		FrequentFiles:    []FrequentFileInfo{{Path: "main.go"}, {Path: "util.go"}},
+		FileContributors: []FileContributorInfo{{Path: "README.md"}},
+	}
+
+	press := func(m tea.Model, keys ...tea.KeyMsg) (tea.Model, tea.Cmd) {
+		var cmd tea.Cmd
+		for _, k := range keys {
+			m, cmd = m.Update(k)
+		}
+		return m, cmd
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	view := func(n string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(n)} }
+
+	var loaded tea.Model = m
+	loaded, _ = loaded.Update(tea.WindowSizeMsg{Width: 120, Height: 60})
+	loaded, _ = loaded.Update(dataLoadedMsg{analysis: analysis})
+
+	updated, cmd := press(loaded, view("3"), down, enter)
+	if got := updated.(model).openFile; got != "util.go" || cmd == nil {
+		t.Errorf("enter in frequent changes opens %q, want util.go and quit", got)
+	}
+
+	// Clicking a file opens it too
+	updated, _ = press(loaded, view("3"))
+	row := -1
+	for i, line := range strings.Split(updated.View(), "\n") {
+		if strings.Contains(line, "util.go") {
+			row = i
+			break
+		}
+	}
+	updated, cmd = updated.Update(tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionPress, Y: row})
+	if got := updated.(model).openFile; got != "util.go" || cmd == nil {
+		t.Errorf("clicking in frequent changes opens %q, want util.go and quit", got)
+	}
+
+	updated, _ = press(loaded, view("5"), enter)
+	if got := updated.(model).openFile; got != "README.md" {
+		t.Errorf("enter in contributors opens %q, want README.md", got)
+	}
+
+	// Other lists don't drill down
+	updated, _ = press(loaded, view("2"), enter)
+	if got := updated.(model).openFile; got != "" {
+		t.Errorf("enter in large files opens %q", got)
+	}
+
+	// Back from the blame viewer the analysis isn't loaded again
+	if cmd := updated.(model).Init(); cmd != nil {
+		t.Error("Init() reloads an analysis that is already loaded")
+	}
+}
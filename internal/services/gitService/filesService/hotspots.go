@@ -0,0 +1,457 @@
+package filesService
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// HotspotOptions controls the hotspot analysis
+type HotspotOptions struct {
+	// RepoPath is the repository to analyze; empty means the current directory
+	RepoPath string
+	// Report writes the hotspots (json, csv or markdown) instead of launching the TUI
+	Report gitservice.ReportWriter
+	// Limit caps the number of files reported; 0 reports all of them
+	Limit int
+	// NoCache disables the on-disk commit stats cache under .git/syst-cache
+	NoCache bool
+}
+
+// HotspotInfo is a tracked file ranked by how much it changes and how large it is
+type HotspotInfo struct {
+	Path         string    `json:"path"`
+	Changes      int       `json:"changes"`
+	Additions    int       `json:"additions"`
+	Deletions    int       `json:"deletions"`
+	Churn        int       `json:"churn"` // Additions + deletions across history
+	Lines        int       `json:"lines"`
+	Score        float64   `json:"score"` // 0-100, high when both churn and lines are high
+	LastModified time.Time `json:"last_modified"`
+}
+
+type hotspotSort int
+
+const (
+	sortByScore hotspotSort = iota
+	sortByChurn
+	sortByLines
+	sortByChanges
+)
+
+var hotspotSortNames = []string{"score", "churn", "lines", "changes"}
+
+func (s hotspotSort) String() string { return hotspotSortNames[s] }
+
+type hotspotModel struct {
+	hotspots     []HotspotInfo
+	sortBy       hotspotSort
+	hotspotList  list.Model
+	listDelegate list.ItemDelegate
+	loading      bool
+	err          error
+	tuiHelper    *terminal.ResponsiveTUIHelper
+	keys         terminal.KeyMap
+	opts         HotspotOptions
+
+	loader gitservice.Loading
+}
+
+type hotspotItem struct {
+	hotspot HotspotInfo
+}
+
+func (i hotspotItem) FilterValue() string { return i.hotspot.Path }
+func (i hotspotItem) Title() string {
+	return fmt.Sprintf("%s (score %.1f)", i.hotspot.Path, i.hotspot.Score)
+}
+func (i hotspotItem) Description() string {
+	return fmt.Sprintf("Churn: %d (+%d -%d) • %d changes • %d lines • Last: %s",
+		i.hotspot.Churn, i.hotspot.Additions, i.hotspot.Deletions, i.hotspot.Changes,
+		i.hotspot.Lines, i.hotspot.LastModified.Format("2006-01-02"))
+}
+
+type hotspotsLoadedMsg struct {
+	hotspots []HotspotInfo
+}
+
+func (m hotspotModel) Init() tea.Cmd {
+	return tea.Batch(
+		m.loader.Init(),
+		loadHotspots(m.opts, m.loader.Progress),
+	)
+}
+
+func (m hotspotModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.tuiHelper.HandleWindowSizeMsg(msg)
+		m.hotspotList.SetWidth(m.tuiHelper.GetWidth())
+		m.hotspotList.SetHeight(m.tuiHelper.GetHeight() - 12)
+		return m, nil
+
+	case spinner.TickMsg, gitservice.ProgressMsg:
+		var cmd tea.Cmd
+		m.loader, cmd = m.loader.Update(msg)
+		return m, cmd
+
+	case hotspotsLoadedMsg:
+		m.hotspots = msg.hotspots
+		m.loading = false
+		m.updateListItems()
+		return m, nil
+
+	case errMsg:
+		m.err = msg.err
+		m.loading = false
+		return m, nil
+
+	case tea.MouseMsg:
+		if !m.loading && len(m.hotspotList.Items()) > 0 {
+			terminal.HandleListMouse(&m.hotspotList, m.listDelegate, m.listTop(), msg)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		// The error screen can only retry the analysis or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				return m.retry()
+			case key.Matches(msg, m.keys.Quit, m.keys.Back):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.hotspotList.FilterState() == list.Filtering {
+			var cmd tea.Cmd
+			m.hotspotList, cmd = m.hotspotList.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Quit, m.keys.Back):
+			return m, tea.Quit
+		case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+			m.sortBy = (m.sortBy + 1) % hotspotSort(len(hotspotSortNames))
+			m.updateListItems()
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.hotspotList, cmd = m.hotspotList.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m *hotspotModel) updateListItems() {
+	sortHotspots(m.hotspots, m.sortBy)
+
+	items := make([]list.Item, len(m.hotspots))
+	for i, hotspot := range m.hotspots {
+		items[i] = hotspotItem{hotspot: hotspot}
+	}
+	m.hotspotList.SetItems(items)
+	m.hotspotList.ResetSelected()
+}
+
+// retry starts the analysis over after it failed, with a fresh progress stream as the
+// last one was closed
+func (m hotspotModel) retry() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.loading = true
+	m.loader = gitservice.NewLoading()
+	return m, m.Init()
+}
+
+func (m hotspotModel) View() string {
+	if m.loading {
+		return "\n  " + m.loader.View("Analyzing file churn...", helpStyle.PaddingLeft(2)) + "\n"
+	}
+
+	if m.err != nil {
+		return errorStyle.Render(fmt.Sprintf("\n  Error: %v\n", m.err)) + helpStyle.Render("  "+m.keys.ErrorHelp()) + "\n"
+	}
+
+	sections := []string{
+		m.renderHeader(),
+		sectionStyle.Render(m.renderContent()),
+		helpStyle.Render(terminal.HelpLine("s: change sort", terminal.Help(m.keys.Filter, "filter"),
+			terminal.HelpKey(m.keys.Up, m.keys.Down)+": scroll", terminal.Help(m.keys.Quit, "quit"))),
+	}
+	return strings.Join(sections, "\n")
+}
+
+func (m hotspotModel) renderHeader() string {
+	return titleStyle.Render("🔥 Code Hotspots")
+}
+
+func (m hotspotModel) renderContent() string {
+	var content strings.Builder
+
+	content.WriteString(headerStyle.Render("Refactoring candidates"))
+	content.WriteString("\n")
+	content.WriteString("Large files that change often • sorted by ")
+	content.WriteString(highlightStyle.Render(m.sortBy.String()))
+	content.WriteString("\n\n")
+
+	if len(m.hotspotList.Items()) == 0 {
+		content.WriteString("No items to display")
+		return content.String()
+	}
+
+	content.WriteString(m.hotspotList.View())
+	return content.String()
+}
+
+// listTop returns the screen row where hotspotList starts.
+func (m hotspotModel) listTop() int {
+	prefix := strings.TrimSuffix(m.renderContent(), m.hotspotList.View())
+	return lipgloss.Height(m.renderHeader()) + terminal.StyleTopOffset(sectionStyle) + strings.Count(prefix, "\n")
+}
+
+func loadHotspots(opts HotspotOptions, progress *gitservice.Progress) tea.Cmd {
+	return func() tea.Msg {
+		defer progress.Close()
+
+		hotspots, err := analyzeHotspots(opts, progress)
+		if err != nil {
+			return errMsg{err}
+		}
+		return hotspotsLoadedMsg{hotspots}
+	}
+}
+
+// analyzeHotspots combines the change history of each tracked text file with its line
+// count at HEAD, reporting history progress to progress (which may be nil).
+func analyzeHotspots(opts HotspotOptions, progress *gitservice.Progress) ([]HotspotInfo, error) {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := gitservice.Head(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	lines, err := countTreeLines(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count lines: %w", err)
+	}
+
+	// The file history walk reuses the shared commit stats cache
+	var analysis FileAnalysis
+	stats := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+	err = analyzeFileHistory(repo, &analysis, stats, progress)
+	// #nosec G104 - A failed cache write only means the next run recomputes stats
+	stats.Save()
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze file history: %w", err)
+	}
+
+	hotspots := rankHotspots(analysis.FrequentFiles, lines)
+	if opts.Limit > 0 && len(hotspots) > opts.Limit {
+		hotspots = hotspots[:opts.Limit]
+	}
+
+	return hotspots, nil
+}
+
+// countTreeLines returns the line count of every text file in tree. Binary files are
+// left out.
+func countTreeLines(tree *object.Tree) (map[string]int, error) {
+	lines := make(map[string]int)
+
+	err := tree.Files().ForEach(func(file *object.File) error {
+		n, binary, err := countLines(file)
+		if err != nil {
+			return err
+		}
+		if !binary {
+			lines[file.Name] = n
+		}
+		return nil
+	})
+
+	return lines, err
+}
+
+// countLines counts the lines in a file and reports whether it looks binary, in which
+// case the count is not meaningful.
+func countLines(file *object.File) (int, bool, error) {
+	reader, err := file.Reader()
+	if err != nil {
+		return 0, false, err
+	}
+	defer reader.Close()
+
+	buf := make([]byte, 32*1024)
+	var count int
+	var last byte
+	first := true
+
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if first && isBinaryContent(buf[:min(n, sniffSize)]) {
+				return 0, true, nil
+			}
+			first = false
+			count += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	// A final line without a trailing newline still counts
+	if !first && last != '\n' {
+		count++
+	}
+	return count, false, nil
+}
+
+// rankHotspots scores the tracked text files in history. The score is the product of a
+// file's churn and line count, each relative to the largest in the repository, so only
+// files that are both large and frequently changed score highly. The result is sorted
+// by score.
+func rankHotspots(history []FrequentFileInfo, lines map[string]int) []HotspotInfo {
+	var hotspots []HotspotInfo
+	var maxChurn, maxLines int
+
+	for _, file := range history {
+		n, tracked := lines[file.Path]
+		if !tracked {
+			continue
+		}
+
+		hotspot := HotspotInfo{
+			Path:         file.Path,
+			Changes:      file.ChangeCount,
+			Additions:    file.TotalAdditions,
+			Deletions:    file.TotalDeletions,
+			Churn:        file.TotalAdditions + file.TotalDeletions,
+			Lines:        n,
+			LastModified: file.LastModified,
+		}
+		maxChurn = max(maxChurn, hotspot.Churn)
+		maxLines = max(maxLines, hotspot.Lines)
+		hotspots = append(hotspots, hotspot)
+	}
+
+	if maxChurn > 0 && maxLines > 0 {
+		for i := range hotspots {
+			churn := float64(hotspots[i].Churn) / float64(maxChurn)
+			size := float64(hotspots[i].Lines) / float64(maxLines)
+			hotspots[i].Score = math.Round(churn*size*1000) / 10
+		}
+	}
+
+	sortHotspots(hotspots, sortByScore)
+	return hotspots
+}
+
+// sortHotspots sorts hotspots in descending order of the given field, breaking ties by path.
+func sortHotspots(hotspots []HotspotInfo, by hotspotSort) {
+	value := func(h HotspotInfo) float64 {
+		switch by {
+		case sortByChurn:
+			return float64(h.Churn)
+		case sortByLines:
+			return float64(h.Lines)
+		case sortByChanges:
+			return float64(h.Changes)
+		default:
+			return h.Score
+		}
+	}
+
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		a, b := value(hotspots[i]), value(hotspots[j])
+		if a != b {
+			return a > b
+		}
+		return hotspots[i].Path < hotspots[j].Path
+	})
+}
+
+// RunHotspots ranks files by churn and size, in a TUI or as a report
+func RunHotspots(opts HotspotOptions) error {
+	if err := gitservice.CheckHistory(opts.RepoPath); err != nil {
+		return err
+	}
+
+	if opts.Report.Enabled() {
+		hotspots, err := analyzeHotspots(opts, nil)
+		if err != nil {
+			return err
+		}
+		return opts.Report.Write(func(w io.Writer, format string) error {
+			return writeHotspotsReport(w, hotspots, format)
+		}, hotspotReportFormats...)
+	}
+
+	if err := terminal.RequireTTY("--format json (or -o FILE)"); err != nil {
+		return err
+	}
+
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
+		Foreground(theme.Current.Text)
+
+	hotspotList := list.New([]list.Item{}, delegate, 0, 0)
+	hotspotList.SetShowTitle(false)
+	hotspotList.SetShowStatusBar(false)
+	hotspotList.SetShowHelp(false)
+	hotspotList.Filter = terminal.FuzzyFilter
+
+	m := hotspotModel{
+		hotspotList:  hotspotList,
+		listDelegate: delegate,
+		loading:      true,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.Keys(),
+		opts:         opts,
+		loader:       gitservice.NewLoading(),
+	}
+	m.keys.ApplyToList(&m.hotspotList)
+
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err := p.Run()
+	return err
+}
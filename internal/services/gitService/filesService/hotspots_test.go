@@ -0,0 +1,64 @@
+package filesService
+
+import (
+	"testing"
+)
+
+func TestRankHotspots(t *testing.T) {
+	history := []FrequentFileInfo{
+		{Path: "big-and-busy.go", ChangeCount: 40, TotalAdditions: 900, TotalDeletions: 100},
+		{Path: "big-and-quiet.go", ChangeCount: 2, TotalAdditions: 50},
+		{Path: "small-and-busy.go", ChangeCount: 30, TotalAdditions: 500, TotalDeletions: 300},
+		{Path: "deleted.go", ChangeCount: 50, TotalAdditions: 2000},
+	}
+	lines := map[string]int{
+		"big-and-busy.go":   1000,
+		"big-and-quiet.go":  1000,
+		"small-and-busy.go": 50,
+	}
+
+	hotspots := rankHotspots(history, lines)
+
+	if len(hotspots) != 3 {
+		t.Fatalf("rankHotspots() returned %d files, want 3 (deleted files are skipped)", len(hotspots))
+	}
+	if hotspots[0].Path != "big-and-busy.go" || hotspots[0].Score != 100 {
+		t.Errorf("top hotspot = %+v, want big-and-busy.go with score 100", hotspots[0])
+	}
+	if hotspots[0].Churn != 1000 {
+		t.Errorf("Churn = %d, want additions + deletions = 1000", hotspots[0].Churn)
+	}
+	for _, h := range hotspots[1:] {
+		if h.Score >= 10 {
+			t.Errorf("%s scored %.1f, want a low score for files that are only large or only busy", h.Path, h.Score)
+		}
+	}
+}
+
+func TestSortHotspots(t *testing.T) {
+	hotspots := []HotspotInfo{
+		{Path: "a", Churn: 10, Lines: 300, Changes: 1, Score: 5},
+		{Path: "b", Churn: 30, Lines: 100, Changes: 2, Score: 50},
+		{Path: "c", Churn: 20, Lines: 200, Changes: 3, Score: 20},
+	}
+
+	tests := []struct {
+		by   hotspotSort
+		want string
+	}{
+		{sortByScore, "bca"},
+		{sortByChurn, "bca"},
+		{sortByLines, "acb"},
+		{sortByChanges, "cba"},
+	}
+	for _, tt := range tests {
+		sortHotspots(hotspots, tt.by)
+		var got string
+		for _, h := range hotspots {
+			got += h.Path
+		}
+		if got != tt.want {
+			t.Errorf("sort by %s = %s, want %s", tt.by, got, tt.want)
+		}
+	}
+}
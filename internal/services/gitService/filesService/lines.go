@@ -0,0 +1,263 @@
+package filesService
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// maxLineCountSize is the largest file whose lines are counted; bigger files are
+// usually generated or vendored and slow to read
+const maxLineCountSize = 1024 * 1024
+
+// LanguageLines is the cloc-style line count of one language's files
+type LanguageLines struct {
+	Language string `json:"language"`
+	Files    int    `json:"files"`
+	Code     int    `json:"code"`
+	Comment  int    `json:"comment"`
+	Blank    int    `json:"blank"`
+}
+
+// commentSyntax is how a language writes comments: prefixes that comment out the rest
+// of a line, and the delimiters of block comments
+type commentSyntax struct {
+	line       []string
+	blockStart string
+	blockEnd   string
+}
+
+var (
+	cStyle    = commentSyntax{line: []string{"//"}, blockStart: "/*", blockEnd: "*/"}
+	hashStyle = commentSyntax{line: []string{"#"}}
+	xmlStyle  = commentSyntax{blockStart: "<!--", blockEnd: "-->"}
+)
+
+// commentSyntaxes are the comment rules of the languages getLanguageForExtension and
+// detectLanguageFromContent return. Languages without comments, like JSON, have an empty
+// syntax; languages missing from the map aren't counted.
+var commentSyntaxes = map[string]commentSyntax{
+	"Go":          cStyle,
+	"JavaScript":  cStyle,
+	"TypeScript":  cStyle,
+	"Java":        cStyle,
+	"C":           cStyle,
+	"C++":         cStyle,
+	"C#":          cStyle,
+	"Rust":        cStyle,
+	"Swift":       cStyle,
+	"Kotlin":      cStyle,
+	"Scala":       cStyle,
+	"Dart":        cStyle,
+	"Objective-C": cStyle,
+	"Groovy":      cStyle,
+	"SCSS":        cStyle,
+	"LESS":        cStyle,
+	"PHP":         {line: []string{"//", "#"}, blockStart: "/*", blockEnd: "*/"},
+	"CSS":         {blockStart: "/*", blockEnd: "*/"},
+	"Python":      hashStyle,
+	"Shell":       hashStyle,
+	"Fish":        hashStyle,
+	"Perl":        hashStyle,
+	"R":           hashStyle,
+	"YAML":        hashStyle,
+	"Makefile":    hashStyle,
+	"Dockerfile":  hashStyle,
+	"Just":        hashStyle,
+	"Awk":         hashStyle,
+	"Ruby":        {line: []string{"#"}, blockStart: "=begin", blockEnd: "=end"},
+	"PowerShell":  {line: []string{"#"}, blockStart: "<#", blockEnd: "#>"},
+	"SQL":         {line: []string{"--"}, blockStart: "/*", blockEnd: "*/"},
+	"Lua":         {line: []string{"--"}, blockStart: "--[[", blockEnd: "]]"},
+	"Batch":       {line: []string{"::", "REM ", "rem ", "@REM ", "@rem "}},
+	"HTML":        xmlStyle,
+	"XML":         xmlStyle,
+	"Markdown":    xmlStyle,
+	"JSON":        {},
+	"Text":        {},
+}
+
+// classifyLines splits content into code, comment and blank lines. A line is a comment if
+// it only holds comments; code followed by a comment counts as code.
+func classifyLines(content string, syntax commentSyntax) (code, comment, blank int) {
+	inBlock := false
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case inBlock:
+			comment++
+			inBlock = !strings.Contains(line, syntax.blockEnd)
+		case line == "":
+			blank++
+		case syntax.blockStart != "" && strings.HasPrefix(line, syntax.blockStart):
+			comment++
+			inBlock = !strings.Contains(line[len(syntax.blockStart):], syntax.blockEnd)
+		case hasLineComment(line, syntax):
+			comment++
+		default:
+			code++
+			inBlock = opensBlock(line, syntax)
+		}
+	}
+	return code, comment, blank
+}
+
+// opensBlock reports whether a line of code opens a block comment that runs on into the
+// next lines. Delimiters inside double-quoted strings and after a line comment don't
+// count, so "src/*.go" isn't taken for a comment.
+func opensBlock(line string, syntax commentSyntax) bool {
+	if syntax.blockStart == "" {
+		return false
+	}
+
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && inString:
+			i++
+		case line[i] == '"':
+			inString = !inString
+		case inString:
+		case strings.HasPrefix(line[i:], syntax.blockStart):
+			end := strings.Index(line[i+len(syntax.blockStart):], syntax.blockEnd)
+			if end == -1 {
+				return true
+			}
+			i += len(syntax.blockStart) + end + len(syntax.blockEnd) - 1
+		case hasLineComment(line[i:], syntax):
+			return false
+		}
+	}
+	return false
+}
+
+func hasLineComment(line string, syntax commentSyntax) bool {
+	for _, prefix := range syntax.line {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// lineCounter adds up the lines of each language's files
+type lineCounter map[string]*LanguageLines
+
+// add counts the lines of a text file in language. Binary files, files over
+// maxLineCountSize and languages without comment rules are skipped.
+func (c lineCounter) add(file *object.File, language string, binary bool) {
+	syntax, ok := commentSyntaxes[language]
+	if !ok || binary || file.Size > maxLineCountSize {
+		return
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return
+	}
+
+	lines := c[language]
+	if lines == nil {
+		lines = &LanguageLines{Language: language}
+		c[language] = lines
+	}
+	code, comment, blank := classifyLines(content, syntax)
+	lines.Files++
+	lines.Code += code
+	lines.Comment += comment
+	lines.Blank += blank
+}
+
+// sorted returns the line counts with the most code first
+func (c lineCounter) sorted() []LanguageLines {
+	var counts []LanguageLines
+	for _, lines := range c {
+		counts = append(counts, *lines)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Code != counts[j].Code {
+			return counts[i].Code > counts[j].Code
+		}
+		return counts[i].Language < counts[j].Language
+	})
+	return counts
+}
+
+// totalLines adds up the line counts of every language
+func totalLines(counts []LanguageLines) LanguageLines {
+	total := LanguageLines{Language: "Total"}
+	for _, lines := range counts {
+		total.Files += lines.Files
+		total.Code += lines.Code
+		total.Comment += lines.Comment
+		total.Blank += lines.Blank
+	}
+	return total
+}
+
+// countLinesAtHead counts the lines of the files committed at HEAD per language, without
+// the history analysis
+func countLinesAtHead(repoPath string) ([]LanguageLines, error) {
+	repo, err := gitservice.OpenRepo(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := gitservice.Head(repo)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	var analysis FileAnalysis
+	if _, err := analyzeCurrentFiles(tree, &analysis); err != nil {
+		return nil, fmt.Errorf("failed to analyze current files: %w", err)
+	}
+	return analysis.LineCounts, nil
+}
+
+// writeLinesReport writes line counts as a cloc-style table, or as "json" or "markdown"
+func writeLinesReport(w io.Writer, counts []LanguageLines, format string) error {
+	switch format {
+	case "":
+	case gitservice.FormatJSON:
+		return writeJSON(w, counts)
+	case gitservice.FormatMarkdown:
+		var rows [][]string
+		for _, lines := range append(counts, totalLines(counts)) {
+			rows = append(rows, []string{lines.Language, strconv.Itoa(lines.Files), strconv.Itoa(lines.Blank),
+				strconv.Itoa(lines.Comment), strconv.Itoa(lines.Code)})
+		}
+		fmt.Fprintf(w, "# Lines of Code\n\n")
+		return gitservice.WriteMarkdownTable(w, []string{"Language", "Files", "Blank", "Comment", "Code"}, rows)
+	default:
+		return fmt.Errorf("unsupported line count format: %s", format)
+	}
+
+	rule := strings.Repeat("-", 60)
+	fmt.Fprintln(w, rule)
+	fmt.Fprintf(w, "%-20s %9s %9s %9s %9s\n", "Language", "files", "blank", "comment", "code")
+	fmt.Fprintln(w, rule)
+	for _, lines := range counts {
+		writeLineCountRow(w, lines)
+	}
+	fmt.Fprintln(w, rule)
+	writeLineCountRow(w, totalLines(counts))
+	fmt.Fprintln(w, rule)
+	return nil
+}
+
+func writeLineCountRow(w io.Writer, lines LanguageLines) {
+	fmt.Fprintf(w, "%-20s %9d %9d %9d %9d\n", lines.Language, lines.Files, lines.Blank, lines.Comment, lines.Code)
+}
@@ -0,0 +1,55 @@
+package filesService
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassifyLines(t *testing.T) {
+	tests := []struct {
+		name                 string
+		language             string
+		content              string
+		code, comment, blank int
+	}{
+		{"go", "Go", `// Package x does things
+package x
+
+/*
+Block comment
+*/
+import "path/filepath"
+
+var glob = filepath.Join("src/*.go") // not a block comment
+var y = 1 /* opens a block
+still in it */
+`, 4, 5, 2},
+		{"python", "Python", "#!/usr/bin/env python3\n\nimport os  # trailing\n# comment\n", 1, 2, 1},
+		{"lua", "Lua", "--[[ block\nstill ]]\n-- line\nprint(1)\n", 1, 3, 0},
+		{"html", "HTML", "<!-- one -->\n<p>hi</p>\n\n<!--\ntwo\n-->\n", 1, 4, 1},
+		{"json", "JSON", "{\n  \"a\": \"// not a comment\"\n}\n", 3, 0, 0},
+	}
+	for _, tt := range tests {
+		code, comment, blank := classifyLines(tt.content, commentSyntaxes[tt.language])
+		if code != tt.code || comment != tt.comment || blank != tt.blank {
+			t.Errorf("%s: classifyLines() = %d code, %d comment, %d blank; want %d, %d, %d",
+				tt.name, code, comment, blank, tt.code, tt.comment, tt.blank)
+		}
+	}
+}
+
+func TestWriteLinesReport(t *testing.T) {
+	counts := []LanguageLines{
+		{Language: "Go", Files: 2, Code: 30, Comment: 5, Blank: 4},
+		{Language: "Shell", Files: 1, Code: 10, Comment: 2, Blank: 1},
+	}
+
+	var b strings.Builder
+	if err := writeLinesReport(&b, counts, ""); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if total := strings.Fields(lines[len(lines)-2]); strings.Join(total, " ") != "Total 3 5 7 40" {
+		t.Errorf("total row = %q, want Total 3 5 7 40", lines[len(lines)-2])
+	}
+}
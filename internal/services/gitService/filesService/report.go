@@ -0,0 +1,150 @@
+package filesService
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// filesReportFormats, hotspotReportFormats and linesReportFormats are the formats each
+// report supports, the default first
+var (
+	filesReportFormats   = []string{gitservice.FormatJSON, gitservice.FormatMarkdown}
+	hotspotReportFormats = []string{gitservice.FormatJSON, gitservice.FormatCSV, gitservice.FormatMarkdown}
+	linesReportFormats   = []string{gitservice.FormatJSON, gitservice.FormatMarkdown}
+)
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeFilesReport writes the file analysis to w as "json" or "markdown". The Markdown
+// report has the overview, the lines of code per language, the largest, most changed, at-risk and stale files and the
+// directory owners, plus the untracked and ignored files when the working tree was scanned.
+func writeFilesReport(w io.Writer, analysis FileAnalysis, format string) error {
+	if format == gitservice.FormatJSON {
+		return writeJSON(w, analysis)
+	}
+	if format != gitservice.FormatMarkdown {
+		return fmt.Errorf("unsupported file analysis format: %s", format)
+	}
+
+	o := analysis.Overview
+	fmt.Fprintf(w, "# File Analysis\n\n")
+	fmt.Fprintf(w, "- Files: %d (%d text, %d binary)\n", o.TotalFiles, o.TextFiles, o.BinaryFiles)
+	fmt.Fprintf(w, "- Total size: %s (average %s)\n", formatBytes(o.TotalSize), formatBytes(o.AverageSize))
+	fmt.Fprintf(w, "- Largest file: %s (%s)\n", o.LargestFile, formatBytes(o.LargestFileSize))
+	fmt.Fprintf(w, "- Bus factor: %d\n", analysis.OwnershipRisk.BusFactor)
+	if wt := o.WorkingTree; wt != nil {
+		fmt.Fprintf(w, "- Untracked: %d files (%s)\n", wt.UntrackedFiles, formatBytes(wt.UntrackedSize))
+		fmt.Fprintf(w, "- Ignored: %d files (%s)\n", wt.IgnoredFiles, formatBytes(wt.IgnoredSize))
+	}
+
+	sections := []struct {
+		title   string
+		headers []string
+		rows    [][]string
+	}{
+		{"Lines of Code", []string{"Language", "Files", "Blank", "Comment", "Code"}, nil},
+		{"Largest Files", []string{"File", "Size", "Type"}, nil},
+		{"Most Changed Files", []string{"File", "Changes", "Contributors", "Added", "Deleted", "Last Modified"}, nil},
+		{"Ownership Risk", []string{"File", "Owner", "Share", "Changes"}, nil},
+		{"Stale Files", []string{"File", "Last Modified"}, nil},
+		{"Directory Owners", []string{"Directory", "Owner", "Share", "Files", "Changes"}, nil},
+		{"Untracked Files", []string{"File", "Size"}, nil},
+		{"Ignored Files", []string{"File", "Size"}, nil},
+	}
+	for _, lines := range analysis.LineCounts {
+		sections[0].rows = append(sections[0].rows, []string{
+			lines.Language, strconv.Itoa(lines.Files), strconv.Itoa(lines.Blank), strconv.Itoa(lines.Comment), strconv.Itoa(lines.Code),
+		})
+	}
+	for _, f := range analysis.LargeFiles {
+		sections[1].rows = append(sections[1].rows, []string{f.Path, formatBytes(f.Size), f.Type})
+	}
+	for _, f := range analysis.FrequentFiles {
+		sections[2].rows = append(sections[2].rows, []string{
+			f.Path, strconv.Itoa(f.ChangeCount), strconv.Itoa(f.Contributors),
+			"+" + strconv.Itoa(f.TotalAdditions), "-" + strconv.Itoa(f.TotalDeletions), f.LastModified.Format("2006-01-02"),
+		})
+	}
+	for _, f := range analysis.OwnershipRisk.AtRiskFiles {
+		sections[3].rows = append(sections[3].rows, []string{
+			f.Path, f.Owner, fmt.Sprintf("%.0f%%", f.Concentration), strconv.Itoa(f.TotalChanges),
+		})
+	}
+	for _, f := range analysis.StaleFiles {
+		modified := "unknown"
+		if !f.Unknown {
+			modified = f.LastModified.Format("2006-01-02")
+		}
+		sections[4].rows = append(sections[4].rows, []string{f.Path, modified})
+	}
+	for _, d := range analysis.DirectoryOwnership {
+		sections[5].rows = append(sections[5].rows, []string{
+			d.Path + "/", d.Ownership, fmt.Sprintf("%.0f%%", d.Contributors[0].Percentage), strconv.Itoa(d.Files), strconv.Itoa(d.TotalChanges),
+		})
+	}
+	if wt := o.WorkingTree; wt != nil {
+		for _, f := range wt.Untracked {
+			sections[6].rows = append(sections[6].rows, []string{f.Path, formatBytes(f.Size)})
+		}
+		for _, f := range wt.Ignored {
+			sections[7].rows = append(sections[7].rows, []string{f.Path, formatBytes(f.Size)})
+		}
+	}
+
+	for _, section := range sections {
+		if len(section.rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n## %s\n\n", section.title)
+		if err := gitservice.WriteMarkdownTable(w, section.headers, section.rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeHotspotsReport writes the hotspots to w as "json", "csv" or "markdown"
+func writeHotspotsReport(w io.Writer, hotspots []HotspotInfo, format string) error {
+	if format == gitservice.FormatJSON {
+		return writeJSON(w, hotspots)
+	}
+
+	headers := []string{"path", "score", "churn", "lines", "changes", "additions", "deletions", "last_modified"}
+	rows := make([][]string, len(hotspots))
+	for i, h := range hotspots {
+		rows[i] = []string{
+			h.Path,
+			strconv.FormatFloat(h.Score, 'f', 1, 64),
+			strconv.Itoa(h.Churn),
+			strconv.Itoa(h.Lines),
+			strconv.Itoa(h.Changes),
+			strconv.Itoa(h.Additions),
+			strconv.Itoa(h.Deletions),
+			h.LastModified.Format(time.RFC3339),
+		}
+	}
+
+	switch format {
+	case gitservice.FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(headers); err != nil {
+			return err
+		}
+		return writer.WriteAll(rows)
+	case gitservice.FormatMarkdown:
+		fmt.Fprintf(w, "# Hotspots\n\n")
+		return gitservice.WriteMarkdownTable(w, headers, rows)
+	default:
+		return fmt.Errorf("unsupported hotspots format: %s", format)
+	}
+}
@@ -0,0 +1,89 @@
+package filesService
+
+import (
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// The orders each list view can be cycled through with s. The first is the order the
+// analysis produces.
+var (
+	largeFileSorts = []gitservice.ListSort[LargeFileInfo]{
+		{Name: "size"},
+		{Name: "name", Less: func(a, b LargeFileInfo) bool { return a.Path < b.Path }},
+		{Name: "extension", Less: func(a, b LargeFileInfo) bool { return a.Extension < b.Extension }},
+	}
+	frequentFileSorts = []gitservice.ListSort[FrequentFileInfo]{
+		{Name: "changes"},
+		{Name: "last modified", Less: func(a, b FrequentFileInfo) bool { return a.LastModified.After(b.LastModified) }},
+		{Name: "lines changed", Less: func(a, b FrequentFileInfo) bool {
+			return a.TotalAdditions+a.TotalDeletions > b.TotalAdditions+b.TotalDeletions
+		}},
+		{Name: "contributors", Less: func(a, b FrequentFileInfo) bool { return a.Contributors > b.Contributors }},
+		{Name: "name", Less: func(a, b FrequentFileInfo) bool { return a.Path < b.Path }},
+	}
+	extensionSorts = []gitservice.ListSort[ExtensionInfo]{
+		{Name: "files"},
+		{Name: "size", Less: func(a, b ExtensionInfo) bool { return a.TotalSize > b.TotalSize }},
+		{Name: "average size", Less: func(a, b ExtensionInfo) bool { return a.AverageSize > b.AverageSize }},
+		{Name: "name", Less: func(a, b ExtensionInfo) bool { return a.Extension < b.Extension }},
+	}
+	fileContributorSorts = []gitservice.ListSort[FileContributorInfo]{
+		{Name: "changes"},
+		{Name: "contributors", Less: func(a, b FileContributorInfo) bool { return len(a.Contributors) > len(b.Contributors) }},
+		{Name: "name", Less: func(a, b FileContributorInfo) bool { return a.Path < b.Path }},
+	}
+	atRiskFileSorts = []gitservice.ListSort[AtRiskFileInfo]{
+		{Name: "share"},
+		{Name: "changes", Less: func(a, b AtRiskFileInfo) bool { return a.TotalChanges > b.TotalChanges }},
+		{Name: "owner", Less: func(a, b AtRiskFileInfo) bool { return a.Owner < b.Owner }},
+		{Name: "name", Less: func(a, b AtRiskFileInfo) bool { return a.Path < b.Path }},
+	}
+	staleFileSorts = []gitservice.ListSort[StaleFileInfo]{
+		{Name: "oldest"},
+		{Name: "name", Less: func(a, b StaleFileInfo) bool { return a.Path < b.Path }},
+	}
+)
+
+// sortNames returns the names of the orders view can be sorted in. The overview, line
+// counts and directory tree can't be re-sorted.
+func sortNames(view ViewMode) []string {
+	switch view {
+	case LargeFilesView:
+		return gitservice.SortNames(largeFileSorts)
+	case FrequentFilesView:
+		return gitservice.SortNames(frequentFileSorts)
+	case ExtensionsView:
+		return gitservice.SortNames(extensionSorts)
+	case ContributorsView:
+		return gitservice.SortNames(fileContributorSorts)
+	case OwnershipView:
+		return gitservice.SortNames(atRiskFileSorts)
+	case StaleFilesView:
+		return gitservice.SortNames(staleFileSorts)
+	default:
+		return nil
+	}
+}
+
+// cycleSort moves the current view to its next order
+func (m *model) cycleSort() {
+	names := sortNames(m.currentView)
+	if len(names) == 0 {
+		return
+	}
+	if m.sortBy == nil {
+		m.sortBy = make(map[ViewMode]int)
+	}
+	m.sortBy[m.currentView] = gitservice.NextSort(m.sortBy[m.currentView], len(names))
+	m.updateListItems()
+	m.fileList.ResetSelected()
+}
+
+// sortName returns the name of the current view's order, or "" if it can't be re-sorted
+func (m model) sortName() string {
+	names := sortNames(m.currentView)
+	if len(names) == 0 {
+		return ""
+	}
+	return names[m.sortBy[m.currentView]]
+}
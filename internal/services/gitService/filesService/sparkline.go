@@ -0,0 +1,47 @@
+package filesService
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// monthKey formats the month of a commit in FrequentFileInfo.ChangesByMonth
+	monthKey = "2006-01"
+	// minSparklineMonths and maxSparklineMonths bound how many months a sparkline covers
+	minSparklineMonths = 6
+	maxSparklineMonths = 24
+)
+
+// sparkBlocks are the bars of a sparkline, from a month without changes to the busiest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparklineMonths is how many months fit in the sparkline of a terminal width wide,
+// at one character per month.
+func sparklineMonths(width int) int {
+	return min(maxSparklineMonths, max(minSparklineMonths, width/6))
+}
+
+// changeSparkline draws the changes per month over the months up to and including
+// now's, oldest first. Bars are relative to the file's busiest month in that window,
+// and any change at all rises above the baseline of a month without one.
+func changeSparkline(changesByMonth map[string]int, months int, now time.Time) string {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -(months - 1), 0)
+
+	counts := make([]int, months)
+	busiest := 0
+	for i := range counts {
+		counts[i] = changesByMonth[start.AddDate(0, i, 0).Format(monthKey)]
+		busiest = max(busiest, counts[i])
+	}
+
+	var sparkline strings.Builder
+	for _, count := range counts {
+		level := 0
+		if count > 0 {
+			level = (count*(len(sparkBlocks)-1) + busiest - 1) / busiest
+		}
+		sparkline.WriteRune(sparkBlocks[level])
+	}
+	return sparkline.String()
+}
@@ -0,0 +1,39 @@
+package filesService
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChangeSparkline(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		changes map[string]int
+		want    string
+	}{
+		{"no changes", nil, "▁▁▁▁▁▁"},
+		{"heating up", map[string]int{"2026-04": 1, "2026-05": 4, "2026-06": 7}, "▁▁▁▂▅█"},
+		{"cooling down", map[string]int{"2026-01": 7, "2026-02": 1}, "█▂▁▁▁▁"},
+		// Months before the window don't scale the bars in it
+		{"older months", map[string]int{"2025-12": 50, "2026-03": 2}, "▁▁█▁▁▁"},
+	}
+	for _, tt := range tests {
+		if got := changeSparkline(tt.changes, 6, now); got != tt.want {
+			t.Errorf("%s: changeSparkline() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSparklineMonths(t *testing.T) {
+	for _, tt := range []struct{ width, want int }{
+		{0, minSparklineMonths},
+		{80, 13},
+		{300, maxSparklineMonths},
+	} {
+		if got := sparklineMonths(tt.width); got != tt.want {
+			t.Errorf("sparklineMonths(%d) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package filesService
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// WorkingTreeFiles summarizes the files on disk that are not committed. They are kept
+// out of the committed-tree totals in FileOverview.
+type WorkingTreeFiles struct {
+	UntrackedFiles int               `json:"untracked_files"`
+	UntrackedSize  int64             `json:"untracked_size"`
+	IgnoredFiles   int               `json:"ignored_files"`
+	IgnoredSize    int64             `json:"ignored_size"`
+	Untracked      []WorkingFileInfo `json:"untracked"` // Largest first
+	Ignored        []WorkingFileInfo `json:"ignored"`   // Largest first
+}
+
+type WorkingFileInfo struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// scanWorkingTree finds the untracked and gitignored files in repo's working tree.
+// Untracked files come from Worktree.Status; it leaves ignored files out entirely, so
+// those are found by walking the working tree and matching against the .gitignore files.
+func scanWorkingTree(repo *git.Repository) (*WorkingTreeFiles, error) {
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	root := wt.Filesystem.Root()
+
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+
+	patterns, err := gitignore.ReadPatterns(wt.Filesystem, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .gitignore files: %w", err)
+	}
+	matcher := gitignore.NewMatcher(append(patterns, wt.Excludes...))
+
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	indexed := make(map[string]bool, len(idx.Entries))
+	for _, entry := range idx.Entries {
+		indexed[entry.Name] = true
+	}
+
+	files := &WorkingTreeFiles{}
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == git.GitDirName && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if indexed[rel] {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		file := WorkingFileInfo{Path: rel, Size: info.Size()}
+
+		switch {
+		case status.IsUntracked(rel):
+			files.UntrackedFiles++
+			files.UntrackedSize += file.Size
+			files.Untracked = append(files.Untracked, file)
+		case matcher.Match(strings.Split(rel, "/"), false):
+			files.IgnoredFiles++
+			files.IgnoredSize += file.Size
+			files.Ignored = append(files.Ignored, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan working tree: %w", err)
+	}
+
+	for _, list := range [][]WorkingFileInfo{files.Untracked, files.Ignored} {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Size != list[j].Size {
+				return list[i].Size > list[j].Size
+			}
+			return list[i].Path < list[j].Path
+		})
+	}
+	return files, nil
+}
@@ -0,0 +1,48 @@
+package filesService
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestScanWorkingTree(t *testing.T) {
+	repo := gittest.New(t)
+	repo.WriteFile(".gitignore", "build/\n*.log\n")
+	repo.WriteFile("main.go", "package main\n")
+	repo.Commit("Initial commit")
+
+	write := func(name, content string) {
+		t.Helper()
+		path := repo.Path(name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("notes.txt", "todo")
+	write("build/app", "a large build artifact")
+	write("logs/debug.log", "debug")
+	// Staged but not committed: neither untracked nor ignored
+	repo.WriteFile("staged.go", "package main\n")
+
+	files, err := scanWorkingTree(repo.Repository)
+	if err != nil {
+		t.Fatalf("scanWorkingTree() error: %v", err)
+	}
+
+	if want := []WorkingFileInfo{{"notes.txt", 4}}; !reflect.DeepEqual(files.Untracked, want) {
+		t.Errorf("untracked = %v, want %v", files.Untracked, want)
+	}
+	if want := []WorkingFileInfo{{"build/app", 22}, {"logs/debug.log", 5}}; !reflect.DeepEqual(files.Ignored, want) {
+		t.Errorf("ignored = %v, want %v", files.Ignored, want)
+	}
+	if files.UntrackedFiles != 1 || files.UntrackedSize != 4 || files.IgnoredFiles != 2 || files.IgnoredSize != 27 {
+		t.Errorf("totals = %+v", files)
+	}
+}
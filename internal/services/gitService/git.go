@@ -1,7 +1,6 @@
 package gitservice
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
@@ -21,20 +20,6 @@ func ensureGitInstalled() error {
 	return nil
 }
 
-// Prompt user for confirmation
-func promptUser(reader *bufio.Reader, branch string) bool {
-	fmt.Printf("Delete branch %s? [y/N]: ", branch)
-
-	answer, err := reader.ReadString('\n')
-	if err != nil {
-		return false
-	}
-
-	answer = strings.ToLower(strings.TrimSpace(answer))
-
-	return answer == "y" || answer == "yes"
-}
-
 // IsGitRepo checks if the current working directory is part of a Git repository.
 func IsGitRepo() (bool, error) {
 	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
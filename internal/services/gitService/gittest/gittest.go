@@ -0,0 +1,194 @@
+// Package gittest builds small git repositories for the tests of the git services.
+package gittest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Start is the time a new repository's clock starts at, so commit dates are the same on
+// every run
+var Start = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// Repo is a repository in a temporary directory. Its commits are made by Signature's
+// identity, unless a test says otherwise, at the times of a clock that moves on by Step
+// for each commit.
+type Repo struct {
+	*git.Repository
+	Worktree *git.Worktree
+	// Dir is the root of the working tree
+	Dir string
+	// When is the time of the last commit, or Start before the first
+	When time.Time
+	// Step is how far the clock moves on for each commit, an hour unless set
+	Step time.Duration
+
+	tb testing.TB
+}
+
+// New creates an empty repository with a working tree for the test
+func New(tb testing.TB) *Repo {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		tb.Fatalf("PlainInit: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return &Repo{Repository: repo, Worktree: wt, Dir: dir, When: Start, Step: time.Hour, tb: tb}
+}
+
+// NewBare creates an empty bare repository in the directory name of a temporary
+// directory, and returns it with its path
+func NewBare(tb testing.TB, name string) (*git.Repository, string) {
+	tb.Helper()
+
+	dir := filepath.Join(tb.TempDir(), name)
+	repo, err := git.PlainInit(dir, true)
+	if err != nil {
+		tb.Fatalf("PlainInit: %v", err)
+	}
+	return repo, dir
+}
+
+// Signature returns the identity test commits are made by, at when
+func Signature(when time.Time) *object.Signature {
+	return &object.Signature{Name: "Test", Email: "test@example.com", When: when}
+}
+
+// Tick moves the clock on by Step and returns the new time
+func (r *Repo) Tick() time.Time {
+	r.When = r.When.Add(r.Step)
+	return r.When
+}
+
+// Path returns the path of the working tree file name, given with slashes
+func (r *Repo) Path(name string) string {
+	return filepath.Join(r.Dir, filepath.FromSlash(name))
+}
+
+// WriteFile writes content to the working tree file name, creating its directories, and
+// stages it
+func (r *Repo) WriteFile(name, content string) {
+	r.tb.Helper()
+
+	path := r.Path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		r.tb.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		r.tb.Fatal(err)
+	}
+	r.Add(name)
+}
+
+// Add stages the working tree file name, like git add
+func (r *Repo) Add(name string) {
+	r.tb.Helper()
+
+	if _, err := r.Worktree.Add(name); err != nil {
+		r.tb.Fatal(err)
+	}
+}
+
+// Remove deletes the file name and stages its removal, like git rm
+func (r *Repo) Remove(name string) {
+	r.tb.Helper()
+
+	if _, err := r.Worktree.Remove(name); err != nil {
+		r.tb.Fatal(err)
+	}
+}
+
+// Move renames the file from to to and stages the rename, like git mv
+func (r *Repo) Move(from, to string) {
+	r.tb.Helper()
+
+	if _, err := r.Worktree.Move(from, to); err != nil {
+		r.tb.Fatal(err)
+	}
+}
+
+// Commit commits what is staged, which may be nothing, at the next tick of the clock
+func (r *Repo) Commit(message string) plumbing.Hash {
+	r.tb.Helper()
+	return r.CommitWith(message, &git.CommitOptions{})
+}
+
+// CommitFile writes and stages a file, then commits it
+func (r *Repo) CommitFile(name, content, message string) plumbing.Hash {
+	r.tb.Helper()
+
+	r.WriteFile(name, content)
+	return r.Commit(message)
+}
+
+// CommitAs commits what is staged like Commit, by another author
+func (r *Repo) CommitAs(name, email, message string) plumbing.Hash {
+	r.tb.Helper()
+
+	author := &object.Signature{Name: name, Email: email, When: r.Tick()}
+	return r.CommitWith(message, &git.CommitOptions{Author: author})
+}
+
+// CommitWith commits what is staged with opts, such as the parents of a merge. Without
+// an author the commit is made by Signature's identity at the next tick of the clock, and
+// without a committer it is committed by its author.
+func (r *Repo) CommitWith(message string, opts *git.CommitOptions) plumbing.Hash {
+	r.tb.Helper()
+
+	if opts.Author == nil {
+		opts.Author = Signature(r.Tick())
+	}
+	if opts.Committer == nil {
+		opts.Committer = opts.Author
+	}
+	opts.AllowEmptyCommits = true
+
+	hash, err := r.Worktree.Commit(message, opts)
+	if err != nil {
+		r.tb.Fatal(err)
+	}
+	return hash
+}
+
+// Head returns the commit HEAD points at
+func (r *Repo) Head() plumbing.Hash {
+	r.tb.Helper()
+
+	head, err := r.Repository.Head()
+	if err != nil {
+		r.tb.Fatal(err)
+	}
+	return head.Hash()
+}
+
+// Tag creates a lightweight tag of HEAD
+func (r *Repo) Tag(name string) {
+	r.tb.Helper()
+
+	if _, err := r.CreateTag(name, r.Head(), nil); err != nil {
+		r.tb.Fatal(err)
+	}
+}
+
+// Checkout switches the working tree to branch, creating it at HEAD first when create
+// is set
+func (r *Repo) Checkout(branch string, create bool) {
+	r.tb.Helper()
+
+	err := r.Worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch), Create: create})
+	if err != nil {
+		r.tb.Fatal(err)
+	}
+}
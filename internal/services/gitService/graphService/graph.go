@@ -0,0 +1,294 @@
+package graphService
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// GraphOptions controls which commits the graph shows
+type GraphOptions struct {
+	// RepoPath is the repository to graph; empty means the current directory
+	RepoPath string
+	// NoCache recomputes commit stats for the details view instead of reading the cache
+	NoCache bool
+	// MaxCount is how many commits to show, newest first; 0 shows all of them
+	MaxCount int
+	// All graphs every branch and tag instead of only HEAD
+	All bool
+}
+
+// GraphCommit is one commit of the graph
+type GraphCommit struct {
+	Hash    string
+	Parents []string // First parent first
+	Author  string
+	Date    time.Time
+	Message string   // Subject line
+	Refs    []string // Branches and tags pointing at the commit
+}
+
+// IsMerge reports whether the commit has more than one parent
+func (c GraphCommit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// loadGraphCommits returns the newest commits reachable from HEAD, or from every ref with
+// opts.All, ordered by commit date.
+func loadGraphCommits(repo *git.Repository, opts GraphOptions) ([]GraphCommit, error) {
+	logOptions := &git.LogOptions{Order: git.LogOrderCommitterTime, All: opts.All}
+	if !opts.All {
+		head, err := gitservice.Head(repo)
+		if err != nil {
+			return nil, err
+		}
+		logOptions.From = head.Hash()
+	}
+
+	iter, err := repo.Log(logOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit history: %w", err)
+	}
+	defer iter.Close()
+
+	refs, err := refNames(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []GraphCommit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if opts.MaxCount > 0 && len(commits) >= opts.MaxCount {
+			return storer.ErrStop
+		}
+		subject, _, _ := strings.Cut(c.Message, "\n")
+		commits = append(commits, GraphCommit{
+			Hash:    c.Hash.String(),
+			Parents: gitservice.ParentHashes(c),
+			Author:  c.Author.Name,
+			Date:    c.Author.When,
+			Message: subject,
+			Refs:    refs[c.Hash],
+		})
+		return nil
+	})
+	if err != nil && !errors.Is(err, storer.ErrStop) {
+		return nil, fmt.Errorf("failed to walk commit history: %w", err)
+	}
+	return commits, nil
+}
+
+// refNames maps commits to the branches and tags pointing at them, sorted by name
+func refNames(repo *git.Repository) (map[plumbing.Hash][]string, error) {
+	iter, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+	defer iter.Close()
+
+	names := make(map[plumbing.Hash][]string)
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || !(ref.Name().IsBranch() || ref.Name().IsTag() || ref.Name().IsRemote()) {
+			return nil
+		}
+		hash := ref.Hash()
+		// Annotated tags point at a tag object
+		if tag, err := repo.TagObject(hash); err == nil {
+			hash = tag.Target
+		}
+		names[hash] = append(names[hash], ref.Name().Short())
+		return nil
+	})
+	for _, n := range names {
+		sort.Strings(n)
+	}
+	return names, err
+}
+
+// graphCell is one character of the graph, in the color of a lane
+type graphCell struct {
+	char rune
+	lane int // Column whose color the cell is drawn in
+}
+
+// graphRow is the graph drawn next to one commit: the line with the commit's node, and
+// the line leading to the next commit, where lanes branch off and join
+type graphRow struct {
+	node  []graphCell
+	edges []graphCell
+}
+
+// graphEdge is a horizontal line on an edges line, between columns a and b, drawn in the
+// color of lane
+type graphEdge struct {
+	a, b, lane int
+}
+
+// Flags of the lines meeting in a cell of an edges line
+const (
+	up = 1 << iota
+	down
+	left
+	right
+)
+
+// boxChars draws the lines meeting in a cell. Lines that only go up or down end in the
+// cell, and are left blank.
+var boxChars = map[int]rune{
+	up | down:                '│',
+	up | down | right:        '├',
+	up | down | left:         '┤',
+	up | down | left | right: '┼',
+	left | right:             '─',
+	down | right:             '╭',
+	down | left:              '╮',
+	up | right:               '╰',
+	up | left:                '╯',
+	down | left | right:      '┬',
+	up | left | right:        '┴',
+}
+
+// layoutGraph lays out commits, newest first, in lanes like git log --graph. Each lane
+// holds the commit expected further down the graph. A commit takes the lane expecting
+// it, or a new one if it is the tip of a branch; its first parent then continues in that
+// lane, and its other parents branch off into lanes of their own. Lanes expecting the
+// same commit are joined.
+func layoutGraph(commits []GraphCommit) []graphRow {
+	var lanes []string
+	rows := make([]graphRow, len(commits))
+
+	for n, c := range commits {
+		col := slices.Index(lanes, c.Hash)
+		if col < 0 {
+			col = freeLane(lanes, 0)
+			if col == len(lanes) {
+				lanes = append(lanes, "")
+			}
+			lanes[col] = c.Hash
+		}
+
+		after := slices.Clone(lanes)
+		after[col] = ""
+		if len(c.Parents) > 0 {
+			after[col] = c.Parents[0]
+		}
+
+		var edges []graphEdge
+		for _, parent := range c.Parents[min(1, len(c.Parents)):] {
+			if j := slices.Index(after, parent); j >= 0 {
+				edges = append(edges, graphEdge{col, j, j})
+				continue
+			}
+			k := freeLane(after, col+1)
+			if k == len(after) {
+				after = append(after, "")
+			}
+			after[k] = parent
+			edges = append(edges, graphEdge{col, k, k})
+		}
+
+		// Join lanes that now expect the same commit into the leftmost one
+		for j, hash := range after {
+			if hash == "" {
+				continue
+			}
+			if i := slices.Index(after[:j], hash); i >= 0 {
+				after[j] = ""
+				edges = append(edges, graphEdge{j, i, j})
+			}
+		}
+
+		rows[n] = graphRow{
+			node:  nodeLine(lanes, col, c.IsMerge()),
+			edges: edgesLine(lanes, after, edges),
+		}
+
+		// Drop lanes left empty at the right edge, so the graph narrows again
+		for len(after) > 0 && after[len(after)-1] == "" {
+			after = after[:len(after)-1]
+		}
+		lanes = after
+	}
+
+	return rows
+}
+
+// freeLane returns the first empty lane from start, or len(lanes) if there is none
+func freeLane(lanes []string, start int) int {
+	for i := start; i < len(lanes); i++ {
+		if lanes[i] == "" {
+			return i
+		}
+	}
+	return len(lanes)
+}
+
+// nodeLine draws the commit in column col and the lanes passing by it
+func nodeLine(lanes []string, col int, merge bool) []graphCell {
+	cells := make([]graphCell, 2*len(lanes))
+	for x, hash := range lanes {
+		cells[2*x] = graphCell{' ', x}
+		cells[2*x+1] = graphCell{' ', x}
+		switch {
+		case x == col && merge:
+			cells[2*x].char = '◆'
+		case x == col:
+			cells[2*x].char = '●'
+		case hash != "":
+			cells[2*x].char = '│'
+		}
+	}
+	return cells
+}
+
+// edgesLine draws the lanes going from before to after, with edges between them
+func edgesLine(before, after []string, edges []graphEdge) []graphCell {
+	width := max(len(before), len(after))
+	flags := make([]int, width)
+	for x := range flags {
+		if x < len(before) && before[x] != "" {
+			flags[x] |= up
+		}
+		if x < len(after) && after[x] != "" {
+			flags[x] |= down
+		}
+	}
+
+	cells := make([]graphCell, 2*width)
+	for x := range width {
+		cells[2*x] = graphCell{' ', x}
+		cells[2*x+1] = graphCell{' ', x}
+	}
+
+	for _, e := range edges {
+		lo, hi := min(e.a, e.b), max(e.a, e.b)
+		flags[lo] |= right
+		flags[hi] |= left
+		for x := lo + 1; x < hi; x++ {
+			// Lanes crossed by the edge keep their color
+			if flags[x]&(up|down) == 0 {
+				cells[2*x].lane = e.lane
+			}
+			flags[x] |= left | right
+		}
+		for x := lo; x < hi; x++ {
+			cells[2*x+1] = graphCell{'─', e.lane}
+		}
+	}
+
+	for x, f := range flags {
+		if char, ok := boxChars[f]; ok {
+			cells[2*x].char = char
+		}
+	}
+	return cells
+}
@@ -0,0 +1,144 @@
+package graphService
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+// plain draws a graph line without colors, trimming the trailing blanks
+func plain(cells []graphCell) string {
+	var s strings.Builder
+	for _, c := range cells {
+		s.WriteRune(c.char)
+	}
+	return strings.TrimRight(s.String(), " ")
+}
+
+func TestLayoutGraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []GraphCommit
+		want    []string // Node and edges lines of each commit
+	}{
+		{
+			name: "merged feature branch",
+			commits: []GraphCommit{
+				{Hash: "merge", Parents: []string{"main", "feature2"}},
+				{Hash: "feature2", Parents: []string{"feature1"}},
+				{Hash: "main", Parents: []string{"base"}},
+				{Hash: "feature1", Parents: []string{"base"}},
+				{Hash: "base", Parents: []string{"root"}},
+				{Hash: "root"},
+			},
+			want: []string{
+				"◆", "├─╮",
+				"│ ●", "│ │",
+				"● │", "│ │",
+				"│ ●", "├─╯",
+				"●", "│",
+				"●", "",
+			},
+		},
+		{
+			name: "two branch tips",
+			commits: []GraphCommit{
+				{Hash: "tip1", Parents: []string{"base"}},
+				{Hash: "tip2", Parents: []string{"base"}},
+				{Hash: "base"},
+			},
+			want: []string{
+				"●", "│",
+				"│ ●", "├─╯",
+				"●", "",
+			},
+		},
+		{
+			name: "octopus merge",
+			commits: []GraphCommit{
+				{Hash: "merge", Parents: []string{"a", "b", "c"}},
+				{Hash: "c", Parents: []string{"root"}},
+				{Hash: "b", Parents: []string{"root"}},
+				{Hash: "a", Parents: []string{"root"}},
+				{Hash: "root"},
+			},
+			want: []string{
+				"◆", "├─┬─╮",
+				"│ │ ●", "│ │ │",
+				"│ ● │", "│ ├─╯",
+				"● │", "├─╯",
+				"●", "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		rows := layoutGraph(tt.commits)
+		var got []string
+		for _, row := range rows {
+			got = append(got, plain(row.node), plain(row.edges))
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: graph =\n%s\nwant\n%s", tt.name, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+}
+
+func TestLayoutGraphLaneColors(t *testing.T) {
+	rows := layoutGraph([]GraphCommit{
+		{Hash: "merge", Parents: []string{"main", "feature"}},
+		{Hash: "feature", Parents: []string{"base"}},
+		{Hash: "main", Parents: []string{"base"}},
+		{Hash: "base"},
+	})
+
+	// The edge branching off to the feature lane is drawn in that lane's color
+	edges := rows[0].edges
+	if edges[1].lane != 1 || edges[2].lane != 1 {
+		t.Errorf("edge lanes = %d, %d, want 1", edges[1].lane, edges[2].lane)
+	}
+	if node := rows[1].node; node[2].char != '●' || node[2].lane != 1 {
+		t.Errorf("feature node = %q in lane %d, want ● in lane 1", node[2].char, node[2].lane)
+	}
+}
+
+func TestLoadGraphCommits(t *testing.T) {
+	repo := gittest.New(t)
+	base := repo.Commit("Initial commit")
+	repo.Checkout("feature", true)
+	feature := repo.Commit("Add feature")
+	repo.Checkout("master", false)
+	fix := repo.Commit("Fix bug")
+	merge := repo.CommitWith("Merge feature", &git.CommitOptions{Parents: []plumbing.Hash{fix, feature}})
+	if _, err := repo.CreateTag("v1.0", merge, &git.CreateTagOptions{Tagger: gittest.Signature(repo.When), Message: "v1.0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := loadGraphCommits(repo.Repository, GraphOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 4 {
+		t.Fatalf("got %d commits, want 4", len(commits))
+	}
+	if !commits[0].IsMerge() || commits[0].Parents[1] != feature.String() {
+		t.Errorf("first commit = %+v, want the merge of feature", commits[0])
+	}
+	if strings.Join(commits[0].Refs, ",") != "master,v1.0" {
+		t.Errorf("merge refs = %v, want master and the annotated tag", commits[0].Refs)
+	}
+	if commits[3].Hash != base.String() {
+		t.Errorf("last commit = %s, want the initial commit", commits[3].Message)
+	}
+
+	limited, err := loadGraphCommits(repo.Repository, GraphOptions{MaxCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("got %d commits with MaxCount 2, want 2", len(limited))
+	}
+}
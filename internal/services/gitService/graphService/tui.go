@@ -0,0 +1,392 @@
+package graphService
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/blameService"
+	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+type viewMode int
+
+const (
+	graphView viewMode = iota
+	detailsView
+)
+
+type model struct {
+	repo         *git.Repository
+	stats        *gitservice.CommitStatsCache
+	opts         GraphOptions
+	currentView  viewMode
+	commitList   list.Model
+	listDelegate list.ItemDelegate
+	details      blameService.CommitDetails
+	tuiHelper    *terminal.ResponsiveTUIHelper
+	loading      bool
+	err          error
+	clipboard    terminal.ClipboardNotice
+	keys         terminal.KeyMap
+}
+
+// graphItem is a commit with the graph drawn next to it
+type graphItem struct {
+	commit GraphCommit
+	row    graphRow
+}
+
+func (i graphItem) FilterValue() string { return i.commit.Message }
+
+type graphLoadedMsg struct {
+	commits []GraphCommit
+}
+
+type detailsLoadedMsg struct {
+	details blameService.CommitDetails
+}
+
+type errMsg struct {
+	err error
+}
+
+// laneStyles are cycled through to color the lanes of the graph
+var laneStyles = func() []lipgloss.Style {
+	// A theme without a palette draws every lane in one plain style
+	styles := make([]lipgloss.Style, max(len(theme.Current.Palette), 1))
+	for i := range styles {
+		styles[i] = lipgloss.NewStyle().Foreground(theme.Current.PaletteColor(i))
+	}
+	return styles
+}()
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
+			Padding(0, 1)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Muted).
+			Padding(1, 0)
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Error)
+
+	hashStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Warning)
+
+	refStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Success).
+			Bold(true)
+
+	mergeStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Info).
+			Bold(true)
+
+	selectedStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Accent).
+			Bold(true)
+
+	dimStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Muted)
+
+	detailsStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.Current.Border).
+			Padding(1, 2).
+			MarginTop(1)
+)
+
+// renderCells draws graph cells in the colors of their lanes, padded to width cells
+func renderCells(cells []graphCell, width int) string {
+	var s strings.Builder
+	for _, c := range cells {
+		s.WriteString(laneStyles[c.lane%len(laneStyles)].Render(string(c.char)))
+	}
+	if width > len(cells) {
+		s.WriteString(strings.Repeat(" ", width-len(cells)))
+	}
+	return s.String()
+}
+
+// graphDelegate draws each commit on two lines, with the graph continuing down the left
+// of both: the node and subject, then the edges and the author and date.
+type graphDelegate struct{}
+
+func (d graphDelegate) Height() int                             { return 2 }
+func (d graphDelegate) Spacing() int                            { return 0 }
+func (d graphDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d graphDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i, ok := item.(graphItem)
+	if !ok {
+		return
+	}
+
+	c := i.commit
+	graphWidth := max(len(i.row.node), len(i.row.edges))
+
+	subject := c.Message
+	switch {
+	case index == m.Index():
+		subject = selectedStyle.Render(subject)
+	case c.IsMerge():
+		subject = mergeStyle.Render(subject)
+	}
+	title := renderCells(i.row.node, graphWidth) + " " + hashStyle.Render(c.Hash[:min(7, len(c.Hash))]) + " "
+	if len(c.Refs) > 0 {
+		title += refStyle.Render("("+strings.Join(c.Refs, ", ")+")") + " "
+	}
+	title += subject
+
+	desc := renderCells(i.row.edges, graphWidth) + " " +
+		dimStyle.Render(fmt.Sprintf("%s • %s", c.Author, c.Date.Format("2006-01-02 15:04")))
+
+	// Cut long lines at the list's width instead of wrapping them
+	line := lipgloss.NewStyle().MaxWidth(m.Width())
+	fmt.Fprintf(w, "%s\n%s", line.Render(title), line.Render(desc))
+}
+
+func (m model) Init() tea.Cmd {
+	return loadGraphCmd(m.repo, m.opts)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.tuiHelper.HandleWindowSizeMsg(msg)
+		m.commitList.SetSize(m.tuiHelper.GetWidth(), m.tuiHelper.GetHeight()-6)
+		return m, nil
+
+	case graphLoadedMsg:
+		m.loading = false
+		m.err = nil
+		rows := layoutGraph(msg.commits)
+		items := make([]list.Item, len(msg.commits))
+		for i, c := range msg.commits {
+			items[i] = graphItem{commit: c, row: rows[i]}
+		}
+		m.commitList.ResetSelected()
+		return m, m.commitList.SetItems(items)
+
+	case detailsLoadedMsg:
+		m.details = msg.details
+		m.currentView = detailsView
+		return m, nil
+
+	case errMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+
+	case terminal.ClipboardMsg, terminal.ClipboardClearMsg:
+		return m, m.clipboard.Update(msg)
+
+	case tea.MouseMsg:
+		if m.currentView == graphView && len(m.commitList.Items()) > 0 &&
+			terminal.HandleListMouse(&m.commitList, m.listDelegate, m.listTop(), msg) {
+			// Clicking a commit opens it, like pressing enter
+			return m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.currentView == detailsView {
+			switch {
+			case key.Matches(msg, m.keys.Quit):
+				return m, tea.Quit
+			case key.Matches(msg, m.keys.Back) || msg.String() == "backspace":
+				m.currentView = graphView
+			case key.Matches(msg, m.keys.Copy):
+				return m, terminal.CopyCmd(m.details.Hash)
+			}
+			return m, nil
+		}
+		return m.handleGraphViewKeys(msg)
+	}
+
+	return m, nil
+}
+
+func (m model) handleGraphViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.Select):
+		if item, ok := m.commitList.SelectedItem().(graphItem); ok {
+			m.err = nil
+			return m, loadDetailsCmd(m.repo, m.stats, item.commit.Hash)
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Copy):
+		if item, ok := m.commitList.SelectedItem().(graphItem); ok {
+			return m, terminal.CopyCmd(item.commit.Hash)
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Refresh):
+		m.loading = true
+		return m, loadGraphCmd(m.repo, m.opts)
+	}
+
+	var cmd tea.Cmd
+	m.commitList, cmd = m.commitList.Update(msg)
+	return m, cmd
+}
+
+func (m model) View() string {
+	if m.currentView == detailsView {
+		return m.renderDetailsView()
+	}
+	return m.renderGraphView()
+}
+
+func (m model) renderHeader() string {
+	var s strings.Builder
+
+	scope := "HEAD"
+	if m.opts.All {
+		scope = "all branches and tags"
+	}
+	s.WriteString(titleStyle.Render("🌳 Commit Graph") + " " + dimStyle.Render(scope) + "\n\n")
+	if m.err != nil {
+		s.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n")
+	}
+
+	return s.String()
+}
+
+func (m model) renderGraphView() string {
+	var s strings.Builder
+
+	s.WriteString(m.renderHeader())
+
+	if m.loading {
+		s.WriteString("Loading commits...\n")
+	} else if len(m.commitList.Items()) == 0 {
+		s.WriteString("No commits found\n")
+	} else {
+		s.WriteString(m.commitList.View() + "\n")
+	}
+
+	k := m.keys
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(%s) show commit  (%s) copy hash  (%s) refresh  (%s) quit",
+		terminal.HelpKey(k.Select), terminal.HelpKey(k.Copy), terminal.HelpKey(k.Refresh), terminal.HelpKey(k.Quit))))
+	s.WriteString("\n" + m.clipboard.View())
+	return s.String()
+}
+
+// listTop returns the screen row where commitList starts.
+func (m model) listTop() int {
+	return strings.Count(m.renderHeader(), "\n")
+}
+
+func (m model) renderDetailsView() string {
+	var s strings.Builder
+
+	d := m.details
+	s.WriteString(titleStyle.Render(fmt.Sprintf("📝 Commit: %s", d.Hash[:min(7, len(d.Hash))])) + "\n")
+
+	var info strings.Builder
+	info.WriteString(fmt.Sprintf("Hash:      %s\n", d.Hash))
+	info.WriteString(fmt.Sprintf("Author:    %s <%s>\n", d.Author, d.AuthorEmail))
+	info.WriteString(fmt.Sprintf("Date:      %s\n", d.Date.Format("2006-01-02 15:04:05")))
+	for i, parent := range d.Parents {
+		label := "Parent:"
+		if len(d.Parents) > 1 {
+			label = fmt.Sprintf("Parent %d:", i+1)
+		}
+		info.WriteString(fmt.Sprintf("%-10s %s\n", label, parent))
+	}
+	info.WriteString(fmt.Sprintf("Message:   %s\n", d.Message))
+	info.WriteString(fmt.Sprintf("\nFiles: %d • Additions: +%d • Deletions: -%d\n",
+		d.Stats.FilesChanged, d.Stats.Additions, d.Stats.Deletions))
+
+	for i, file := range d.FilesChanged {
+		if i == 15 {
+			info.WriteString(fmt.Sprintf("  ... and %d more\n", len(d.FilesChanged)-i))
+			break
+		}
+		info.WriteString(fmt.Sprintf("  %-8s %s (+%d -%d)\n", file.Status, file.Path, file.Additions, file.Deletions))
+	}
+
+	s.WriteString(detailsStyle.Width(m.tuiHelper.GetContentWidth()).Render(info.String()) + "\n")
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(%s) copy hash  (%s) back  (%s) quit",
+		terminal.HelpKey(m.keys.Copy), terminal.HelpKey(m.keys.Back), terminal.HelpKey(m.keys.Quit))))
+	s.WriteString("\n" + m.clipboard.View())
+	return s.String()
+}
+
+// Commands
+func loadGraphCmd(repo *git.Repository, opts GraphOptions) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := loadGraphCommits(repo, opts)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return graphLoadedMsg{commits: commits}
+	}
+}
+
+func loadDetailsCmd(repo *git.Repository, stats *gitservice.CommitStatsCache, hash string) tea.Cmd {
+	return func() tea.Msg {
+		details, err := blameService.AnalyzeCommitDetails(repo, stats, nil, hash)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return detailsLoadedMsg{details: details}
+	}
+}
+
+// RunGraph starts the interactive commit graph TUI
+func RunGraph(opts GraphOptions) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+	if _, err := gitservice.Head(repo); err != nil {
+		return err
+	}
+
+	if err := terminal.RequireTTY(""); err != nil {
+		return err
+	}
+
+	stats := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+	// #nosec G104 - A failed cache write only means the next run recomputes stats
+	defer stats.Save()
+
+	delegate := graphDelegate{}
+	commitList := list.New([]list.Item{}, delegate, 0, 0)
+	commitList.SetShowTitle(false)
+	commitList.SetShowStatusBar(false)
+	commitList.SetShowHelp(false)
+	// Filtering would hide commits the graph's lines run through
+	commitList.SetFilteringEnabled(false)
+
+	m := model{
+		repo:         repo,
+		stats:        stats,
+		opts:         opts,
+		currentView:  graphView,
+		commitList:   commitList,
+		listDelegate: delegate,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.Keys(),
+		loading:      true,
+	}
+
+	m.keys.ApplyToList(&m.commitList)
+
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
+	return err
+}
@@ -0,0 +1,83 @@
+package healthService
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// Commit hygiene is flagged when at least poorMessageRatio of the commits, out of at
+// least minCommitsForHygiene, have a poor message
+const (
+	poorMessageRatio     = 0.2
+	minCommitsForHygiene = 10
+)
+
+// poorPrefixes are message prefixes of work in progress or commits meant to be squashed
+var poorPrefixes = map[string]bool{"wip": true, "fixup": true, "squash": true, "amend": true}
+
+// commitPattern is how many commit messages start with a word
+type commitPattern struct {
+	Prefix  string
+	Commits int
+}
+
+// messagePrefix returns the word a commit message starts with, lowercased and without
+// punctuation, so "Fix typo", "fix: typo" and "fix(ui)!: typo" all count as "fix", and
+// "WIP:", "[wip]" and "fixup! ..." as "wip" and "fixup". It is "" for an empty message.
+func messagePrefix(message string) string {
+	if commit, ok := gitservice.ParseConventionalCommit(message); ok {
+		return commit.Type
+	}
+	words := strings.Fields(message)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimFunc(words[0], func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}))
+}
+
+// isPoorMessage reports whether a commit message says little about the change: work in
+// progress, a fixup or squash commit, or a single word like "update"
+func isPoorMessage(message string) bool {
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return poorPrefixes[messagePrefix(message)] || len(strings.Fields(subject)) <= 1
+}
+
+// topPatterns returns the n most common message prefixes, most common first
+func topPatterns(patterns map[string]int, n int) []commitPattern {
+	var top []commitPattern
+	for prefix, commits := range patterns {
+		top = append(top, commitPattern{Prefix: prefix, Commits: commits})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Commits != top[j].Commits {
+			return top[i].Commits > top[j].Commits
+		}
+		return top[i].Prefix < top[j].Prefix
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// commitHygieneIssue flags a history where many commits have poor messages, or returns
+// false if it is fine
+func commitHygieneIssue(ch CommitHealthAnalysis) (HealthIssue, bool) {
+	if ch.Commits < minCommitsForHygiene || float64(ch.PoorMessages) < poorMessageRatio*float64(ch.Commits) {
+		return HealthIssue{}, false
+	}
+	return HealthIssue{
+		Severity: "low",
+		Category: "Commit Hygiene",
+		Title: fmt.Sprintf("%d%% of commits have WIP, fixup or single-word messages",
+			ch.PoorMessages*100/ch.Commits),
+		Description: fmt.Sprintf("%d of %d commits have messages that say little about what changed or why", ch.PoorMessages, ch.Commits),
+		Suggestion:  "Squash WIP and fixup commits before merging (i.e. git rebase -i --autosquash), and describe what each commit changes and why",
+	}, true
+}
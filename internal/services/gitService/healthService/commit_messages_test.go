@@ -0,0 +1,54 @@
+package healthService
+
+import "testing"
+
+func TestMessagePrefix(t *testing.T) {
+	tests := []struct {
+		message, want string
+		poor          bool
+	}{
+		{"Fix typo in README", "fix", false},
+		{"fix(ui)!: drop the old theme\n\nBREAKING CHANGE: gone", "fix", false},
+		{"WIP: half of the parser", "wip", true},
+		{"[wip] parser", "wip", true},
+		{"fixup! Add the parser", "fixup", true},
+		{"update", "update", true},
+		{"Update.\n\nA body doesn't make the subject any better", "update", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		if got := messagePrefix(tt.message); got != tt.want {
+			t.Errorf("messagePrefix(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+		if got := isPoorMessage(tt.message); got != tt.poor {
+			t.Errorf("isPoorMessage(%q) = %v, want %v", tt.message, got, tt.poor)
+		}
+	}
+}
+
+func TestTopPatterns(t *testing.T) {
+	top := topPatterns(map[string]int{"fix": 5, "add": 5, "update": 9, "wip": 1}, 3)
+	if len(top) != 3 || top[0].Prefix != "update" || top[1].Prefix != "add" || top[2].Prefix != "fix" {
+		t.Errorf("topPatterns() = %+v, want update, then add and fix by name", top)
+	}
+}
+
+func TestCommitHygieneIssue(t *testing.T) {
+	tests := []struct {
+		commits, poor int
+		want          bool
+	}{
+		{100, 19, false},
+		{100, 20, true},
+		{5, 5, false}, // Too few commits to judge
+	}
+	for _, tt := range tests {
+		issue, got := commitHygieneIssue(CommitHealthAnalysis{Commits: tt.commits, PoorMessages: tt.poor})
+		if got != tt.want {
+			t.Errorf("commitHygieneIssue(%d of %d) = %v, want %v", tt.poor, tt.commits, got, tt.want)
+		}
+		if got && issue.Severity != "low" {
+			t.Errorf("commitHygieneIssue() severity = %s, want low", issue.Severity)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package healthService
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -9,88 +10,140 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/utils/profile"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type HealthReport struct {
-	OverallScore    int
-	Issues          []HealthIssue
-	LargeFiles      []LargeFile
-	RepositoryStats RepositoryStats
-	SecurityIssues  []SecurityIssue
-	BestPractices   []BestPracticeCheck
-	GitIgnoreStatus GitIgnoreAnalysis
-	CommitHealth    CommitHealthAnalysis
+	OverallScore    int                  `json:"overall_score"`
+	Issues          []HealthIssue        `json:"issues"`
+	LargeFiles      []LargeFile          `json:"large_files"`
+	RepositoryStats RepositoryStats      `json:"repository_stats"`
+	SecurityIssues  []SecurityIssue      `json:"security_issues"`
+	BestPractices   []BestPracticeCheck  `json:"best_practices"`
+	GitIgnoreStatus GitIgnoreAnalysis    `json:"gitignore_status"`
+	CommitHealth    CommitHealthAnalysis `json:"commit_health"`
 }
 
 type HealthIssue struct {
-	Severity    string // "high", "medium", "low"
-	Category    string
-	Title       string
-	Description string
-	Suggestion  string
+	Severity    string `json:"severity"` // "high", "medium", "low"
+	Category    string `json:"category"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Suggestion  string `json:"suggestion"`
+	File        string `json:"file,omitempty"` // Path the issue is about, if any
 }
 
 type LargeFile struct {
-	Path string
-	Size int64
-	Type string
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	Type string `json:"type"`
 }
 
 type RepositoryStats struct {
-	TotalFiles      int
-	TotalSize       int64
-	BinaryFiles     int
-	TextFiles       int
-	AverageFileSize int64
-	OldestFile      time.Time
-	NewestFile      time.Time
+	TotalFiles      int       `json:"total_files"`
+	TotalSize       int64     `json:"total_size"`
+	BinaryFiles     int       `json:"binary_files"`
+	TextFiles       int       `json:"text_files"`
+	AverageFileSize int64     `json:"average_file_size"`
+	OldestFile      time.Time `json:"oldest_file"`
+	NewestFile      time.Time `json:"newest_file"`
+	// CaseCollisions groups tracked paths that differ only in case
+	CaseCollisions [][]string `json:"case_collisions,omitempty"`
 }
 
 type SecurityIssue struct {
-	Type        string
-	File        string
-	Description string
-	Risk        string
+	Type        string `json:"type"`
+	File        string `json:"file"`
+	Description string `json:"description"`
+	Risk        string `json:"risk"`
 }
 
 type BestPracticeCheck struct {
-	Name        string
-	Status      string // "pass", "fail", "warning"
-	Description string
-	Suggestion  string
+	Name        string `json:"name"`
+	Status      string `json:"status"` // "pass", "fail", "warning"
+	Description string `json:"description"`
+	Suggestion  string `json:"suggestion"`
 }
 
 type GitIgnoreAnalysis struct {
-	Exists          bool
-	MissingPatterns []string
-	UnusedPatterns  []string
-	RecommendedAdds []string
+	Exists          bool     `json:"exists"`
+	MissingPatterns []string `json:"missing_patterns"`
+	UnusedPatterns  []string `json:"unused_patterns"`
+	RecommendedAdds []string `json:"recommended_adds"`
 }
 
+// HealthOptions controls how the health report is generated
+type HealthOptions struct {
+	// RepoPath is the repository to check; empty means the current directory
+	RepoPath string
+	// ExcludeBots hides commits from authors matching BotPatterns
+	ExcludeBots bool
+	// BotPatterns overrides gitservice.DefaultBotPatterns
+	BotPatterns []string
+	// NoCache disables the on-disk commit stats cache under .git/syst-cache
+	NoCache bool
+	// Remember restores the section the report was last closed on
+	Remember bool
+	// Limit caps how many commits the commit health check walks; 0 means the whole history
+	Limit int
+	// AllowEmptyCommits stops flagging commits that change no files, for repositories
+	// that use them as markers
+	AllowEmptyCommits bool
+	// SigningWindow is how many of the latest commits are checked for signatures, and
+	// SigningThreshold the percentage of them that should be signed; 0 means
+	// DefaultSigningWindow and DefaultSigningThreshold
+	SigningWindow    int
+	SigningThreshold int
+	// Report writes the report (json, sarif, markdown or prom) instead of launching the TUI
+	Report gitservice.ReportWriter
+}
+
+// viewStateName identifies the health report's saved view state
+const viewStateName = "health"
+
 type CommitHealthAnalysis struct {
-	AverageMessageLength int
-	LargeCommits         []LargeCommit
-	FrequentAuthors      []AuthorStats
-	CommitPatterns       map[string]int
+	AverageMessageLength int           `json:"average_message_length"`
+	BotCommits           int           `json:"bot_commits"`          // Commits hidden by bot filtering
+	LimitNote            string        `json:"limit_note,omitempty"` // Set when --limit cut the walk short
+	LargeCommits         []LargeCommit `json:"large_commits"`
+	FrequentAuthors      []AuthorStats `json:"frequent_authors"`
+	// CommitPatterns counts the commits by the word their message starts with, i.e. "fix"
+	CommitPatterns map[string]int `json:"commit_patterns"`
+	// Commits is the number of commits analyzed, bots excluded
+	Commits int `json:"commits"`
+	// PoorMessages counts the commits with a WIP, fixup or single-word message
+	PoorMessages int `json:"poor_messages"`
+	// EmptyCommits and WhitespaceCommits are the hashes of the commits that change no
+	// files, and of those that only change whitespace
+	EmptyCommits      []string `json:"empty_commits"`
+	WhitespaceCommits []string `json:"whitespace_only_commits"`
+	// SignedCommits counts the signed commits among the SigningCommits latest ones, bots
+	// excluded
+	SignedCommits  int `json:"signed_commits"`
+	SigningCommits int `json:"signing_commits"`
 }
 
 type LargeCommit struct {
-	Hash         string
-	Size         int
-	FilesChanged int
-	Date         time.Time
-	Message      string
+	Hash         string    `json:"hash"`
+	Size         int       `json:"size"`
+	FilesChanged int       `json:"files_changed"`
+	Date         time.Time `json:"date"`
+	Message      string    `json:"message"`
 }
 
 type AuthorStats struct {
-	Name       string
-	Commits    int
-	Percentage float64
+	Name       string  `json:"name"`
+	Commits    int     `json:"commits"`
+	Percentage float64 `json:"percentage"`
 }
 
 type model struct {
@@ -98,8 +151,15 @@ type model struct {
 	err       error
 	loading   bool
 	tuiHelper *terminal.ResponsiveTUIHelper
+	keys      terminal.KeyMap
 	sections  []string
 	selected  int
+	scroll    int // Line offset into sections taller than the terminal
+	opts      HealthOptions
+
+	loader gitservice.Loading
+	// restore is the saved view state to apply once the report has loaded
+	restore *gitservice.ViewState
 }
 
 type reportLoadedMsg struct {
@@ -112,48 +172,58 @@ type errMsg struct {
 
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#FF5F87")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1)
 
 	sectionStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			MarginTop(1)
 
 	headerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			MarginBottom(1)
 
 	goodStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575"))
+			Foreground(theme.Current.Success)
 
 	warningStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFAA00"))
+			Foreground(theme.Current.Warning)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87"))
+			Foreground(theme.Current.Error)
 
+	// Bold to tell it apart from errorStyle, as both use the theme's error color
 	criticalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF0000"))
+			Foreground(theme.Current.Error).
+			Bold(true)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 )
 
 func (m model) Init() tea.Cmd {
-	return loadHealthReport
+	return tea.Batch(
+		m.loader.Init(),
+		loadHealthReport(m.opts, m.loader.Progress),
+	)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.tuiHelper.HandleWindowSizeMsg(msg)
-		return m, nil
+		return m.scrollTo(m.scroll), nil
+
+	case spinner.TickMsg, gitservice.ProgressMsg:
+		var cmd tea.Cmd
+		m.loader, cmd = m.loader.Update(msg)
+		return m, cmd
 
 	case reportLoadedMsg:
 		m.report = msg.report
@@ -167,6 +237,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			"Git Ignore",
 			"Commit Health",
 		}
+		if m.restore != nil && m.restore.View >= 0 && m.restore.View < len(m.sections) {
+			m.selected = m.restore.View
+		}
+		m.restore = nil
 		return m, nil
 
 	case errMsg:
@@ -175,16 +249,38 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// The error screen can only retry the check or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				return m.retry()
+			case key.Matches(msg, m.keys.Quit, m.keys.Back):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if offset, ok := m.tuiHelper.ScrollKey(msg, m.scroll, m.reservedLines()); ok {
+			return m.scrollTo(offset), nil
+		}
+
 		switch {
-		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c", "esc"))):
+		case key.Matches(msg, m.keys.Quit, m.keys.Back):
 			return m, tea.Quit
-		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+		case key.Matches(msg, m.keys.Up):
+			// Past the first section, scroll its content instead
 			if m.selected > 0 {
 				m.selected--
+				m.scroll = 0
+			} else {
+				m = m.scrollTo(m.scroll - 1)
 			}
-		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+		case key.Matches(msg, m.keys.Down):
 			if m.selected < len(m.sections)-1 {
 				m.selected++
+				m.scroll = 0
+			} else {
+				m = m.scrollTo(m.scroll + 1)
 			}
 		}
 	}
@@ -192,18 +288,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// retry starts the check over after it failed, with a fresh progress stream as the
+// last one was closed
+func (m model) retry() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.loading = true
+	m.loader = gitservice.NewLoading()
+	return m, m.Init()
+}
+
 func (m model) View() string {
 	if m.loading {
-		return "\n  Analyzing repository health...\n"
+		return "\n  " + m.loader.View("Analyzing repository health...", helpStyle.PaddingLeft(2)) + "\n"
 	}
 
 	if m.err != nil {
-		return errorStyle.Render(fmt.Sprintf("\n  Error: %v\n", m.err))
+		return errorStyle.Render(fmt.Sprintf("\n  Error: %v\n", m.err)) + helpStyle.Render("  "+m.keys.ErrorHelp()) + "\n"
 	}
 
-	var sections []string
+	title, menu, instructions := m.viewChrome()
 
-	// Title with overall score
+	// Selected section content, scrolled when it doesn't fit in the terminal
+	content := m.tuiHelper.ScrollContent(m.renderSelectedSection(), m.scroll, m.reservedLines())
+
+	return strings.Join([]string{title, menu, sectionStyle.Render(content), instructions}, "\n")
+}
+
+// viewChrome renders the title with the overall score, the navigation menu and the
+// instructions shown around the selected section
+func (m model) viewChrome() (string, string, string) {
 	score := m.report.OverallScore
 	scoreColor := goodStyle
 	if score < 70 {
@@ -215,29 +328,40 @@ func (m model) View() string {
 
 	title := titleStyle.Render(fmt.Sprintf("🏥 Repository Health - Score: %s",
 		scoreColor.Render(fmt.Sprintf("%d/100", score))))
-	sections = append(sections, title)
 
-	// Navigation menu
 	var menuItems []string
 	for i, section := range m.sections {
 		style := lipgloss.NewStyle()
 		if i == m.selected {
-			style = style.Foreground(lipgloss.Color("#01FAC6")).Bold(true)
+			style = style.Foreground(theme.Current.Accent).Bold(true)
 		}
 		menuItems = append(menuItems, style.Render(fmt.Sprintf("%d. %s", i+1, section)))
 	}
-	menu := strings.Join(menuItems, " | ")
-	sections = append(sections, menu)
+	// Wrapped here rather than by the terminal, so its height is known
+	menu := lipgloss.NewStyle().Width(m.tuiHelper.GetWidth()).Render(strings.Join(menuItems, " | "))
 
-	// Selected section content
-	content := m.renderSelectedSection()
-	sections = append(sections, sectionStyle.Render(content))
+	instructions := helpStyle.Render(terminal.HelpLine(
+		terminal.HelpKey(m.keys.Up, m.keys.Down)+": navigate sections", "pgup/pgdn: scroll", terminal.Help(m.keys.Quit, "quit")))
 
-	// Instructions
-	instructions := helpStyle.Render("↑/↓: navigate sections • q: quit")
-	sections = append(sections, instructions)
+	return title, menu, instructions
+}
 
-	return strings.Join(sections, "\n")
+// reservedLines is the height of everything but the selected section's content: the
+// title, menu and instructions, and the section's border and padding
+func (m model) reservedLines() int {
+	title, menu, instructions := m.viewChrome()
+	return lipgloss.Height(title) + lipgloss.Height(menu) + lipgloss.Height(instructions) +
+		sectionStyle.GetVerticalFrameSize()
+}
+
+// scrollTo scrolls the selected section to offset, kept within the lines hidden below the
+// fold
+func (m model) scrollTo(offset int) model {
+	if m.loading || m.err != nil || len(m.sections) == 0 {
+		return m
+	}
+	m.scroll = m.tuiHelper.ClampScroll(m.renderSelectedSection(), offset, m.reservedLines())
+	return m
 }
 
 func (m model) renderSelectedSection() string {
@@ -483,6 +607,48 @@ func (m model) renderCommitHealth() string {
 
 	content.WriteString(fmt.Sprintf("Average Message Length: %s characters\n",
 		goodStyle.Render(fmt.Sprintf("%d", ch.AverageMessageLength))))
+	if ch.BotCommits > 0 {
+		content.WriteString(fmt.Sprintf("Bot commits hidden: %s\n",
+			goodStyle.Render(fmt.Sprintf("%d", ch.BotCommits))))
+	}
+	if ch.LimitNote != "" {
+		content.WriteString(fmt.Sprintf("Stats are %s\n", ch.LimitNote))
+	}
+
+	if ch.Commits > 0 {
+		poor := fmt.Sprintf("%d (%.1f%%)", ch.PoorMessages, float64(ch.PoorMessages)/float64(ch.Commits)*100)
+		style := goodStyle
+		if _, flagged := commitHygieneIssue(ch); flagged {
+			style = warningStyle
+		}
+		content.WriteString(fmt.Sprintf("WIP, fixup or single-word messages: %s\n", style.Render(poor)))
+	}
+	if ch.SigningCommits > 0 {
+		signed := fmt.Sprintf("%d of the last %d (%.1f%%)", ch.SignedCommits, ch.SigningCommits, signingRate(ch))
+		style := goodStyle
+		if check, ok := signingCheck(ch, m.opts.signingThreshold()); ok && check.Status != "pass" {
+			style = warningStyle
+		}
+		content.WriteString(fmt.Sprintf("Signed commits: %s\n", style.Render(signed)))
+	}
+	if n := len(ch.EmptyCommits); n > 0 {
+		content.WriteString(fmt.Sprintf("Empty commits: %s\n", warningStyle.Render(fmt.Sprintf("%d", n))))
+	}
+	if n := len(ch.WhitespaceCommits); n > 0 {
+		content.WriteString(fmt.Sprintf("Whitespace-only commits: %s\n", warningStyle.Render(fmt.Sprintf("%d", n))))
+	}
+
+	if patterns := topPatterns(ch.CommitPatterns, 8); len(patterns) > 0 {
+		content.WriteString("\nMessages most often start with:\n")
+		width := 0
+		for _, pattern := range patterns {
+			width = max(width, len(pattern.Prefix))
+		}
+		for _, pattern := range patterns {
+			bar := strings.Repeat("█", max(1, pattern.Commits*20/patterns[0].Commits))
+			content.WriteString(fmt.Sprintf("%-*s %s %d\n", width, pattern.Prefix, goodStyle.Render(bar), pattern.Commits))
+		}
+	}
 
 	if len(ch.LargeCommits) > 0 {
 		content.WriteString("\nLarge commits (>100 files):\n")
@@ -510,18 +676,32 @@ func (m model) renderCommitHealth() string {
 	return content.String()
 }
 
-func loadHealthReport() tea.Msg {
-	report, err := analyzeRepositoryHealth()
-	if err != nil {
-		return errMsg{err}
+func loadHealthReport(opts HealthOptions, progress *gitservice.Progress) tea.Cmd {
+	return func() tea.Msg {
+		defer progress.Close()
+
+		report, err := analyzeRepositoryHealth(opts, progress)
+		if err != nil {
+			return errMsg{err}
+		}
+		return reportLoadedMsg{report}
 	}
-	return reportLoadedMsg{report}
 }
 
-func analyzeRepositoryHealth() (HealthReport, error) {
-	repo, err := git.PlainOpen(".")
+// AnalyzeHealth runs the health check without the TUI, i.e. for the summary
+func AnalyzeHealth(opts HealthOptions) (HealthReport, error) {
+	return analyzeRepositoryHealth(opts, nil)
+}
+
+func analyzeRepositoryHealth(opts HealthOptions, progress *gitservice.Progress) (HealthReport, error) {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return HealthReport{}, err
+	}
+
+	root, err := gitservice.RepoRoot(repo)
 	if err != nil {
-		return HealthReport{}, fmt.Errorf("failed to open repository: %w", err)
+		return HealthReport{}, fmt.Errorf("failed to resolve repository root: %w", err)
 	}
 
 	report := HealthReport{
@@ -535,19 +715,32 @@ func analyzeRepositoryHealth() (HealthReport, error) {
 	report.RepositoryStats = analyzeRepositoryStats(repo)
 
 	// Check for large files
-	report.LargeFiles = findLargeFiles()
+	report.LargeFiles = findLargeFiles(repo)
 
 	// Analyze gitignore
-	report.GitIgnoreStatus = analyzeGitIgnore()
+	report.GitIgnoreStatus = analyzeGitIgnore(repo, root)
 
 	// Analyze commit health
-	report.CommitHealth = analyzeCommitHealth(repo)
+	var bots *gitservice.BotFilter
+	if opts.ExcludeBots {
+		bots = gitservice.NewBotFilter(opts.BotPatterns)
+	}
+	stats := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+	report.CommitHealth, err = analyzeCommitHealth(repo, bots, stats, opts.Limit, opts.AllowEmptyCommits, opts.signingWindow(), progress)
+	if err != nil {
+		return HealthReport{}, err
+	}
+	// #nosec G104 - A failed cache write only means the next run recomputes stats
+	stats.Save()
 
 	// Run best practice checks
-	report.BestPractices = runBestPracticeChecks(repo)
+	report.BestPractices = runBestPracticeChecks(root)
+	if check, ok := signingCheck(report.CommitHealth, opts.signingThreshold()); ok {
+		report.BestPractices = append(report.BestPractices, check)
+	}
 
 	// Check for security issues
-	report.SecurityIssues = checkSecurityIssues()
+	report.SecurityIssues = checkSecurityIssues(repo)
 
 	// Generate issues based on analysis
 	report.Issues = generateHealthIssues(report)
@@ -580,11 +773,13 @@ func analyzeRepositoryStats(repo *git.Repository) RepositoryStats {
 	var totalSize int64
 	var fileCount int
 	var binaryCount int
+	var paths []string
 
 	// Walk the git tree (only tracked files)
 	err = tree.Files().ForEach(func(file *object.File) error {
 		fileCount++
 		totalSize += file.Size
+		paths = append(paths, file.Name)
 
 		// Simple binary file detection based on file extension
 		if isBinaryFile(file.Name) {
@@ -605,20 +800,56 @@ func analyzeRepositoryStats(repo *git.Repository) RepositoryStats {
 	if fileCount > 0 {
 		stats.AverageFileSize = totalSize / int64(fileCount)
 	}
+	stats.CaseCollisions = findCaseCollisions(paths)
 
 	return stats
 }
 
-func findLargeFiles() []LargeFile {
-	var largeFiles []LargeFile
-	const threshold = 1024 * 1024 // 1MB
+// findCaseCollisions groups paths that only differ in case, like README.md and
+// Readme.md. Only one of them can be checked out on a case-insensitive filesystem
+// (the macOS and Windows defaults). Directories are compared too, so src/Foo/a.go and
+// src/foo/b.go collide as src/Foo and src/foo.
+func findCaseCollisions(paths []string) [][]string {
+	variants := make(map[string]map[string]bool)
+	for _, path := range paths {
+		// Check every directory on the way to the file, then the file itself
+		for i := 0; i <= len(path); i++ {
+			if i < len(path) && path[i] != '/' {
+				continue
+			}
+			prefix := path[:i]
+			key := strings.ToLower(prefix)
+			if variants[key] == nil {
+				variants[key] = make(map[string]bool)
+			}
+			variants[key][prefix] = true
+		}
+	}
 
-	// Open repository to get tracked files only
-	repo, err := git.PlainOpen(".")
-	if err != nil {
-		return largeFiles
+	var collisions [][]string
+	for _, set := range variants {
+		if len(set) < 2 {
+			continue
+		}
+		group := make([]string, 0, len(set))
+		for path := range set {
+			group = append(group, path)
+		}
+		sort.Strings(group)
+		collisions = append(collisions, group)
 	}
 
+	sort.Slice(collisions, func(i, j int) bool {
+		return collisions[i][0] < collisions[j][0]
+	})
+	return collisions
+}
+
+func findLargeFiles(repo *git.Repository) []LargeFile {
+	var largeFiles []LargeFile
+	const threshold = 1024 * 1024 // 1MB
+
+	// Use the HEAD tree to only consider tracked files
 	ref, err := repo.Head()
 	if err != nil {
 		return largeFiles
@@ -664,16 +895,16 @@ func findLargeFiles() []LargeFile {
 	return largeFiles
 }
 
-func analyzeGitIgnore() GitIgnoreAnalysis {
+func analyzeGitIgnore(repo *git.Repository, root string) GitIgnoreAnalysis {
 	analysis := GitIgnoreAnalysis{}
+	gitignorePath := filepath.Join(root, ".gitignore")
 
 	// Check if .gitignore exists
-	if _, err := os.Stat(".gitignore"); err == nil {
+	if _, err := os.Stat(gitignorePath); err == nil {
 		analysis.Exists = true
 	}
 
 	// Recommend common patterns based on what we find in the repo
-	repo, _ := git.PlainOpen(".")
 	var foundFiles []string
 
 	if repo != nil {
@@ -735,7 +966,7 @@ func analyzeGitIgnore() GitIgnoreAnalysis {
 		analysis.RecommendedAdds = append(basicPatterns, recommendedAdds...)
 	} else {
 		// Check which patterns are missing from existing .gitignore
-		content, err := os.ReadFile(".gitignore")
+		content, err := os.ReadFile(gitignorePath)
 		if err == nil {
 			gitignoreContent := string(content)
 			for _, pattern := range recommendedAdds {
@@ -767,33 +998,75 @@ func removeDuplicates(slice []string) []string {
 	return result
 }
 
-func analyzeCommitHealth(repo *git.Repository) CommitHealthAnalysis {
+func analyzeCommitHealth(repo *git.Repository, bots *gitservice.BotFilter, statsCache *gitservice.CommitStatsCache, maxCommits int, allowEmpty bool, signingWindow int, progress *gitservice.Progress) (CommitHealthAnalysis, error) {
 	analysis := CommitHealthAnalysis{
 		CommitPatterns: make(map[string]int),
 	}
 
 	ref, err := repo.Head()
 	if err != nil {
-		return analysis
+		return analysis, nil
 	}
 
 	cIter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
 	if err != nil {
-		return analysis
+		return analysis, nil
 	}
+	progress.CountCommits(repo, ref.Hash(), maxCommits)
+	limit := gitservice.NewCommitLimit(maxCommits)
 
 	var totalMessageLength int
 	var commitCount int
 	authorStats := make(map[string]int)
 
+	mailmap, err := gitservice.LoadRepoMailmap(repo, "")
+	if err != nil {
+		return analysis, err
+	}
+
+	walked := profile.Phase(profile.LogWalk)
 	err = cIter.ForEach(func(c *object.Commit) error {
+		if !limit.Take() {
+			return storer.ErrStop
+		}
+		progress.Commit()
+		authorName, authorEmail := mailmap.Canonicalize(c.Author.Name, c.Author.Email)
+		if bots.IsBot(authorName, authorEmail) {
+			analysis.BotCommits++
+			return nil
+		}
+
 		commitCount++
 		totalMessageLength += len(c.Message)
-		authorStats[c.Author.Name]++
+		authorStats[authorName]++
+		if prefix := messagePrefix(c.Message); prefix != "" {
+			analysis.CommitPatterns[prefix]++
+		}
+		if isPoorMessage(c.Message) {
+			analysis.PoorMessages++
+		}
+		if analysis.SigningCommits < signingWindow {
+			analysis.SigningCommits++
+			if isSigned(c) {
+				analysis.SignedCommits++
+			}
+		}
+
+		stats, err := statsCache.Stats(c)
+		if err != nil {
+			return nil
+		}
+		switch {
+		case isEmptyCommit(c, stats):
+			if !allowEmpty {
+				analysis.EmptyCommits = append(analysis.EmptyCommits, c.Hash.String())
+			}
+		case isWhitespaceOnly(c, stats):
+			analysis.WhitespaceCommits = append(analysis.WhitespaceCommits, c.Hash.String())
+		}
 
 		// Check for large commits (simplified)
-		stats, err := c.Stats()
-		if err == nil && len(stats) > 100 {
+		if len(stats) > 100 {
 			analysis.LargeCommits = append(analysis.LargeCommits, LargeCommit{
 				Hash:         c.Hash.String(),
 				FilesChanged: len(stats),
@@ -804,7 +1077,11 @@ func analyzeCommitHealth(repo *git.Repository) CommitHealthAnalysis {
 
 		return nil
 	})
+	walked()
+	defer profile.Phase(profile.Render)()
 
+	analysis.LimitNote = limit.Note()
+	analysis.Commits = commitCount
 	if commitCount > 0 {
 		analysis.AverageMessageLength = totalMessageLength / commitCount
 	}
@@ -826,10 +1103,10 @@ func analyzeCommitHealth(repo *git.Repository) CommitHealthAnalysis {
 
 	analysis.FrequentAuthors = authors
 
-	return analysis
+	return analysis, nil
 }
 
-func runBestPracticeChecks(repo *git.Repository) []BestPracticeCheck {
+func runBestPracticeChecks(root string) []BestPracticeCheck {
 	var checks []BestPracticeCheck
 
 	// Check for README
@@ -837,9 +1114,9 @@ func runBestPracticeChecks(repo *git.Repository) []BestPracticeCheck {
 		Name:        "README file",
 		Description: "Repository should have a README file",
 	}
-	if _, err := os.Stat("README.md"); err == nil {
+	if _, err := os.Stat(filepath.Join(root, "README.md")); err == nil {
 		readme.Status = "pass"
-	} else if _, err := os.Stat("README.txt"); err == nil {
+	} else if _, err := os.Stat(filepath.Join(root, "README.txt")); err == nil {
 		readme.Status = "pass"
 	} else {
 		readme.Status = "fail"
@@ -852,7 +1129,7 @@ func runBestPracticeChecks(repo *git.Repository) []BestPracticeCheck {
 		Name:        ".gitignore file",
 		Description: "Repository should have a .gitignore file",
 	}
-	if _, err := os.Stat(".gitignore"); err == nil {
+	if _, err := os.Stat(filepath.Join(root, ".gitignore")); err == nil {
 		gitignore.Status = "pass"
 	} else {
 		gitignore.Status = "fail"
@@ -865,9 +1142,9 @@ func runBestPracticeChecks(repo *git.Repository) []BestPracticeCheck {
 		Name:        "License file",
 		Description: "Repository should have a license file",
 	}
-	if _, err := os.Stat("LICENSE"); err == nil {
+	if _, err := os.Stat(filepath.Join(root, "LICENSE")); err == nil {
 		license.Status = "pass"
-	} else if _, err := os.Stat("LICENSE.txt"); err == nil {
+	} else if _, err := os.Stat(filepath.Join(root, "LICENSE.txt")); err == nil {
 		license.Status = "pass"
 	} else {
 		license.Status = "warning"
@@ -878,15 +1155,10 @@ func runBestPracticeChecks(repo *git.Repository) []BestPracticeCheck {
 	return checks
 }
 
-func checkSecurityIssues() []SecurityIssue {
+func checkSecurityIssues(repo *git.Repository) []SecurityIssue {
 	var issues []SecurityIssue
 
 	// Check for common sensitive files only in tracked files
-	repo, err := git.PlainOpen(".")
-	if err != nil {
-		return issues
-	}
-
 	ref, err := repo.Head()
 	if err != nil {
 		return issues
@@ -957,6 +1229,7 @@ func generateHealthIssues(report HealthReport) []HealthIssue {
 				Title:       fmt.Sprintf("Very large file: %s", file.Path),
 				Description: fmt.Sprintf("File is %s, which may impact repository performance", formatBytes(file.Size)),
 				Suggestion:  "Consider using Git LFS for large files",
+				File:        file.Path,
 			})
 		}
 	}
@@ -974,6 +1247,28 @@ func generateHealthIssues(report HealthReport) []HealthIssue {
 		}
 	}
 
+	// Issues from paths that collide on case-insensitive filesystems
+	for _, group := range report.RepositoryStats.CaseCollisions {
+		issues = append(issues, HealthIssue{
+			Severity:    "medium",
+			Category:    "Best Practice",
+			Title:       fmt.Sprintf("Case-insensitive name collision: %s", strings.Join(group, ", ")),
+			Description: "These paths differ only in case, so only one of them can be checked out on macOS and Windows",
+			Suggestion:  "Rename or remove all but one of them",
+			File:        group[0],
+		})
+	}
+
+	// Issues from commit messages
+	if issue, ok := commitHygieneIssue(report.CommitHealth); ok {
+		issues = append(issues, issue)
+	}
+
+	// Issues from empty and whitespace-only commits
+	if issue, ok := noiseCommitsIssue(report.CommitHealth); ok {
+		issues = append(issues, issue)
+	}
+
 	// Issues from security
 	for _, security := range report.SecurityIssues {
 		severity := "low"
@@ -989,6 +1284,7 @@ func generateHealthIssues(report HealthReport) []HealthIssue {
 			Title:       fmt.Sprintf("%s: %s", security.Type, security.File),
 			Description: security.Description,
 			Suggestion:  "Review and remove sensitive information if present",
+			File:        security.File,
 		})
 	}
 
@@ -1057,14 +1353,55 @@ func formatBytes(bytes int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
-// RunHealthCheck starts the repository health check TUI
-func RunHealthCheck() error {
+// RunHealthCheck starts the repository health check TUI, or writes the report when opts.Report is enabled
+func RunHealthCheck(opts HealthOptions) error {
+	if err := gitservice.CheckHistory(opts.RepoPath); err != nil {
+		return err
+	}
+
+	if opts.Report.Enabled() {
+		report, err := analyzeRepositoryHealth(opts, nil)
+		if err != nil {
+			return err
+		}
+		return opts.Report.Write(func(w io.Writer, format string) error {
+			return writeReport(w, gitservice.PromRepoName(opts.RepoPath), report, format)
+		}, reportFormats...)
+	}
+
+	if err := terminal.RequireTTY("--format json (or -o FILE)"); err != nil {
+		return err
+	}
+
 	m := model{
 		loading:   true,
 		tuiHelper: terminal.NewResponsiveTUIHelper(),
+		keys:      terminal.Keys(),
+		opts:      opts,
+		loader:    gitservice.NewLoading(),
+	}
+
+	if opts.Remember {
+		repo, err := gitservice.OpenRepo(opts.RepoPath)
+		if err != nil {
+			return err
+		}
+		if state, ok := gitservice.LoadViewState(repo, viewStateName); ok {
+			m.restore = &state
+		}
+		defer func() {
+			// Quitting before the report loaded leaves nothing worth remembering
+			if !m.loading && m.err == nil {
+				// #nosec G104 - Failing to save only means the next run starts fresh
+				gitservice.SaveViewState(repo, viewStateName, gitservice.ViewState{View: m.selected})
+			}
+		}()
 	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	final, err := p.Run()
+	if fm, ok := final.(model); ok {
+		m = fm
+	}
 	return err
 }
@@ -0,0 +1,83 @@
+package healthService
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+	"github.com/redjax/syst/internal/utils/terminal"
+)
+
+func TestCaseCollisions(t *testing.T) {
+	// The fixture can't be written on a case-insensitive filesystem
+	probe := t.TempDir()
+	if err := os.WriteFile(filepath.Join(probe, "A"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(probe, "a")); err == nil {
+		t.Skip("filesystem is case-insensitive")
+	}
+
+	// Paths that only differ in case, which is only possible on a case-sensitive
+	// filesystem
+	repo := gittest.New(t)
+	for _, name := range []string{"README.md", "Readme.md", "src/Util/a.go", "src/util/b.go", "src/main.go"} {
+		repo.WriteFile(name, name+"\n")
+	}
+	repo.Commit("Add files")
+
+	stats := analyzeRepositoryStats(repo.Repository)
+
+	want := [][]string{
+		{"README.md", "Readme.md"},
+		{"src/Util", "src/util"},
+	}
+	if !reflect.DeepEqual(stats.CaseCollisions, want) {
+		t.Fatalf("CaseCollisions = %v, want %v", stats.CaseCollisions, want)
+	}
+
+	issues := generateHealthIssues(HealthReport{RepositoryStats: stats})
+	var titles []string
+	for _, issue := range issues {
+		titles = append(titles, issue.Title)
+	}
+	got := strings.Join(titles, "\n")
+	for _, group := range want {
+		if !strings.Contains(got, strings.Join(group, ", ")) {
+			t.Errorf("issues %q don't list collision %v", got, group)
+		}
+	}
+}
+
+func TestFindCaseCollisionsNone(t *testing.T) {
+	if got := findCaseCollisions([]string{"README.md", "docs/readme-extra.md", "src/a.go"}); got != nil {
+		t.Errorf("findCaseCollisions() = %v, want none", got)
+	}
+}
+
+func TestErrorScreenUsesKeyMap(t *testing.T) {
+	keys := terminal.DefaultKeyMap()
+	keys.Quit.SetKeys("Q")
+	m := model{err: errors.New("boom"), keys: keys, tuiHelper: terminal.NewResponsiveTUIHelper()}
+
+	press := func(k string) tea.Cmd {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return cmd
+	}
+	if press("q") != nil {
+		t.Error("q quit the error screen after quit was remapped")
+	}
+	if cmd := press("Q"); cmd == nil {
+		t.Error("the remapped quit key didn't quit the error screen")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("the remapped quit key didn't quit the error screen")
+	}
+	if view := m.View(); !strings.Contains(view, "Q: quit") {
+		t.Errorf("error screen help doesn't show the remapped quit key:\n%s", view)
+	}
+}
@@ -0,0 +1,108 @@
+package healthService
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// maxListedNoiseCommits is how many hashes of empty or whitespace-only commits an issue lists
+const maxListedNoiseCommits = 5
+
+// isEmptyCommit reports whether c changes no files. Merge commits are never empty, as
+// their changes are the branch they bring in.
+func isEmptyCommit(c *object.Commit, stats object.FileStats) bool {
+	return c.NumParents() <= 1 && len(stats) == 0
+}
+
+// isWhitespaceOnly reports whether every file c changes differs from its first parent's
+// version only in whitespace: indentation, trailing spaces or line endings. Only
+// commits that change lines in place, adding as many lines to each file as they delete,
+// are read, so the blobs of most commits are never loaded.
+func isWhitespaceOnly(c *object.Commit, stats object.FileStats) bool {
+	if c.NumParents() != 1 || len(stats) == 0 {
+		return false
+	}
+	for _, stat := range stats {
+		// Renames are shown as "old => new" and change more than whitespace
+		if stat.Addition == 0 || stat.Addition != stat.Deletion || strings.Contains(stat.Name, " => ") {
+			return false
+		}
+	}
+
+	parent, err := c.Parent(0)
+	if err != nil {
+		return false
+	}
+	for _, stat := range stats {
+		newFile, err := c.File(stat.Name)
+		if err != nil {
+			return false
+		}
+		oldFile, err := parent.File(stat.Name)
+		if err != nil || oldFile.Hash == newFile.Hash {
+			return false
+		}
+		newContent, err := newFile.Contents()
+		if err != nil {
+			return false
+		}
+		oldContent, err := oldFile.Contents()
+		if err != nil {
+			return false
+		}
+		if !sameIgnoringWhitespace(oldContent, newContent) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameIgnoringWhitespace reports whether a and b have the same lines once the
+// whitespace around and inside each line is collapsed
+func sameIgnoringWhitespace(a, b string) bool {
+	aLines, bLines := strings.Split(a, "\n"), strings.Split(b, "\n")
+	if len(aLines) != len(bLines) {
+		return false
+	}
+	for i := range aLines {
+		if strings.Join(strings.Fields(aLines[i]), " ") != strings.Join(strings.Fields(bLines[i]), " ") {
+			return false
+		}
+	}
+	return true
+}
+
+// noiseCommitsIssue flags empty and whitespace-only commits, which are usually left
+// behind by rebases or tooling, or returns false when there are none
+func noiseCommitsIssue(ch CommitHealthAnalysis) (HealthIssue, bool) {
+	var parts []string
+	if n := len(ch.EmptyCommits); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d empty", n))
+	}
+	if n := len(ch.WhitespaceCommits); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d whitespace-only", n))
+	}
+	if len(parts) == 0 {
+		return HealthIssue{}, false
+	}
+
+	hashes := append(append([]string{}, ch.EmptyCommits...), ch.WhitespaceCommits...)
+	var listed []string
+	for _, hash := range hashes[:min(len(hashes), maxListedNoiseCommits)] {
+		listed = append(listed, hash[:min(len(hash), 8)])
+	}
+	description := "Commits that change nothing, or only whitespace, add noise to the history: " + strings.Join(listed, ", ")
+	if more := len(hashes) - len(listed); more > 0 {
+		description += fmt.Sprintf(" and %d more", more)
+	}
+
+	return HealthIssue{
+		Severity:    "low",
+		Category:    "Best Practice",
+		Title:       fmt.Sprintf("%s commits", strings.Join(parts, " and ")),
+		Description: description,
+		Suggestion:  "Squash them into the commits they belong to (i.e. git rebase -i), or pass --allow-empty-commits if empty marker commits are intended",
+	}, true
+}
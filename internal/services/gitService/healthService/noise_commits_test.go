@@ -0,0 +1,102 @@
+package healthService
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+// newNoiseTestRepo commits a file, reindents it, leaves an empty commit, and changes a
+// line for real, in that order
+func newNoiseTestRepo(t *testing.T) *gittest.Repo {
+	t.Helper()
+
+	repo := gittest.New(t)
+	repo.When = time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	commit := func(message, content string) {
+		t.Helper()
+		if content != "" {
+			repo.WriteFile("main.go", content)
+		}
+		repo.Commit(message)
+	}
+
+	commit("Add main", "func main() {\n  println(\"hi\")\n}\n")
+	commit("Reindent main", "func main() {\n\tprintln(\"hi\")   \r\n}\n")
+	commit("Release marker", "")
+	commit("Say hello", "func main() {\n\tprintln(\"hello\")\n}\n")
+	return repo
+}
+
+func TestAnalyzeCommitHealthNoiseCommits(t *testing.T) {
+	repo := newNoiseTestRepo(t)
+	messages := func(hashes []string) []string {
+		var messages []string
+		for _, hash := range hashes {
+			for _, c := range mustLog(t, repo.Repository) {
+				if c.Hash.String() == hash {
+					messages = append(messages, c.Message)
+				}
+			}
+		}
+		return messages
+	}
+
+	ch, err := analyzeCommitHealth(repo.Repository, nil, nil, 0, false, DefaultSigningWindow, nil)
+	if err != nil {
+		t.Fatalf("analyzeCommitHealth() error: %v", err)
+	}
+	if got := messages(ch.EmptyCommits); len(got) != 1 || got[0] != "Release marker" {
+		t.Errorf("empty commits = %v, want the release marker", got)
+	}
+	if got := messages(ch.WhitespaceCommits); len(got) != 1 || got[0] != "Reindent main" {
+		t.Errorf("whitespace-only commits = %v, want the reindent", got)
+	}
+	issue, ok := noiseCommitsIssue(ch)
+	if !ok || issue.Severity != "low" || issue.Title != "1 empty and 1 whitespace-only commits" {
+		t.Errorf("noiseCommitsIssue() = %+v, %v", issue, ok)
+	}
+
+	// Allowed empty commits aren't flagged, but whitespace-only ones still are
+	ch, err = analyzeCommitHealth(repo.Repository, nil, nil, 0, true, DefaultSigningWindow, nil)
+	if err != nil {
+		t.Fatalf("analyzeCommitHealth() error: %v", err)
+	}
+	if len(ch.EmptyCommits) != 0 || len(ch.WhitespaceCommits) != 1 {
+		t.Errorf("with empty commits allowed: %d empty, %d whitespace-only, want 0 and 1", len(ch.EmptyCommits), len(ch.WhitespaceCommits))
+	}
+
+	if _, ok := noiseCommitsIssue(CommitHealthAnalysis{}); ok {
+		t.Error("noiseCommitsIssue() flags a clean history")
+	}
+}
+
+func mustLog(t *testing.T, repo *git.Repository) []*object.Commit {
+	t.Helper()
+	iter, err := repo.Log(&git.LogOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var commits []*object.Commit
+	if err := iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return commits
+}
+
+func TestAnalyzeCommitHealthMailmapError(t *testing.T) {
+	repo := gittest.New(t)
+	// A line longer than the scanner's buffer can't be read
+	repo.CommitFile(".mailmap", strings.Repeat("x", 100*1024)+"\n", "Add a broken .mailmap")
+
+	if _, err := analyzeCommitHealth(repo.Repository, nil, nil, 0, false, DefaultSigningWindow, nil); err == nil {
+		t.Error("analyzeCommitHealth() with an unreadable .mailmap succeeded")
+	}
+}
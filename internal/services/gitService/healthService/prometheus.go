@@ -0,0 +1,42 @@
+package healthService
+
+import gitservice "github.com/redjax/syst/internal/services/gitService"
+
+// issueSeverities are the severities of health issues, each a sample of git_health_issues
+var issueSeverities = []string{"high", "medium", "low"}
+
+// healthMetrics are the metrics of --format prom, every one labeled with the repo.
+// Scrapers depend on their names, so they must not change:
+//
+//	git_health_score            overall score, from 0 to 100
+//	git_health_issues           issues, labeled with severity="high", "medium" or "low"
+//	git_health_large_files      tracked files over 1MB
+//	git_health_security_issues  tracked files that look sensitive
+//	git_health_tracked_files    files in HEAD
+//	git_health_tracked_bytes    size of the files in HEAD
+//	git_health_signed_commits   signed commits among the latest git_health_signing_window_commits
+func healthMetrics(report HealthReport) []gitservice.PromMetric {
+	counts := make(map[string]int)
+	for _, issue := range report.Issues {
+		counts[issue.Severity]++
+	}
+	issues := gitservice.PromMetric{Name: "git_health_issues", Help: "Health issues found, by severity."}
+	for _, severity := range issueSeverities {
+		issues.Samples = append(issues.Samples, gitservice.PromSample{
+			Labels: map[string]string{"severity": severity},
+			Value:  float64(counts[severity]),
+		})
+	}
+
+	ch := report.CommitHealth
+	return []gitservice.PromMetric{
+		gitservice.Gauge("git_health_score", "Overall repository health score, from 0 to 100.", float64(report.OverallScore)),
+		issues,
+		gitservice.Gauge("git_health_large_files", "Tracked files over 1MB.", float64(len(report.LargeFiles))),
+		gitservice.Gauge("git_health_security_issues", "Tracked files that look sensitive, like keys or .env files.", float64(len(report.SecurityIssues))),
+		gitservice.Gauge("git_health_tracked_files", "Files in HEAD.", float64(report.RepositoryStats.TotalFiles)),
+		gitservice.Gauge("git_health_tracked_bytes", "Size of the files in HEAD, in bytes.", float64(report.RepositoryStats.TotalSize)),
+		gitservice.Gauge("git_health_signed_commits", "Signed commits among the latest commits checked for signatures.", float64(ch.SignedCommits)),
+		gitservice.Gauge("git_health_signing_window_commits", "Latest commits checked for signatures.", float64(ch.SigningCommits)),
+	}
+}
@@ -0,0 +1,203 @@
+package healthService
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// sarifSchema and sarifVersion identify the SARIF 2.1.0 format
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// reportFormats are the formats writeReport supports, the default first
+var reportFormats = []string{gitservice.FormatJSON, gitservice.FormatSARIF, gitservice.FormatMarkdown, gitservice.FormatPrometheus}
+
+// writeReport writes the health report of the repository named repo to w as "json",
+// "sarif", "markdown" or "prom".
+func writeReport(w io.Writer, repo string, report HealthReport, format string) error {
+	var out any
+	switch strings.ToLower(format) {
+	case gitservice.FormatJSON:
+		out = report
+	case gitservice.FormatSARIF:
+		out = toSARIF(report)
+	case gitservice.FormatMarkdown:
+		return writeMarkdown(w, report)
+	case gitservice.FormatPrometheus:
+		return gitservice.WritePrometheus(w, repo, healthMetrics(report))
+	default:
+		return fmt.Errorf("unsupported health report format: %s", format)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// toSARIF maps the report's issues to SARIF results, with one rule per issue category
+// (i.e. "health/security"). Issues about a file are located at that file, relative to
+// the repository root.
+func toSARIF(report HealthReport) sarifLog {
+	driver := sarifDriver{
+		Name:           "syst",
+		InformationURI: "https://github.com/redjax/syst",
+		Rules:          []sarifRule{},
+	}
+	ruleIndex := make(map[string]int)
+	results := []sarifResult{}
+
+	for _, issue := range report.Issues {
+		id := sarifRuleID(issue.Category)
+		index, ok := ruleIndex[id]
+		if !ok {
+			index = len(driver.Rules)
+			ruleIndex[id] = index
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:               id,
+				Name:             strings.ReplaceAll(issue.Category, " ", ""),
+				ShortDescription: sarifMessage{Text: issue.Category + " issue found by syst git health"},
+			})
+		}
+
+		text := issue.Title + ". " + issue.Description
+		if issue.Suggestion != "" {
+			text += ". " + issue.Suggestion
+		}
+
+		result := sarifResult{
+			RuleID:    id,
+			RuleIndex: index,
+			Level:     sarifLevel(issue.Severity),
+			Message:   sarifMessage{Text: text},
+		}
+		if issue.File != "" {
+			// The checks work on whole files; code scanning needs a region, so point at the first line
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: issue.File, URIBaseID: "%SRCROOT%"},
+					Region:           sarifRegion{StartLine: 1},
+				},
+			}}
+		}
+		results = append(results, result)
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+// sarifRuleID turns an issue category like "Best Practice" into "health/best-practice".
+func sarifRuleID(category string) string {
+	return "health/" + strings.ReplaceAll(strings.ToLower(category), " ", "-")
+}
+
+// sarifLevel maps an issue severity to a SARIF level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// writeMarkdown writes the score, issues and large files as a Markdown document, for
+// pasting into an issue or wiki page.
+func writeMarkdown(w io.Writer, report HealthReport) error {
+	fmt.Fprintf(w, "# Repository Health\n\nOverall score: **%d/100**\n\n", report.OverallScore)
+
+	fmt.Fprintf(w, "## Issues (%d)\n\n", len(report.Issues))
+	if len(report.Issues) == 0 {
+		fmt.Fprint(w, "No issues found.\n\n")
+	} else {
+		rows := make([][]string, len(report.Issues))
+		for i, issue := range report.Issues {
+			rows[i] = []string{issue.Severity, issue.Category, issue.Title, issue.File, issue.Suggestion}
+		}
+		if err := gitservice.WriteMarkdownTable(w, []string{"Severity", "Category", "Issue", "File", "Suggestion"}, rows); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(report.LargeFiles) > 0 {
+		fmt.Fprintf(w, "## Large Files (%d)\n\n", len(report.LargeFiles))
+		rows := make([][]string, len(report.LargeFiles))
+		for i, file := range report.LargeFiles {
+			rows[i] = []string{file.Path, formatBytes(file.Size)}
+		}
+		if err := gitservice.WriteMarkdownTable(w, []string{"File", "Size"}, rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
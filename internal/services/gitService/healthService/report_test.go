@@ -0,0 +1,137 @@
+package healthService
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func sampleReport() HealthReport {
+	return HealthReport{
+		Issues: []HealthIssue{
+			{
+				Severity:    "high",
+				Category:    "Security",
+				Title:       "Sensitive File: config/id_rsa",
+				Description: "File may contain sensitive information and is tracked in git",
+				Suggestion:  "Review and remove sensitive information if present",
+				File:        "config/id_rsa",
+			},
+			{
+				Severity:    "medium",
+				Category:    "Best Practice",
+				Title:       "Missing LICENSE file",
+				Description: "Repository should have a license",
+				Suggestion:  "Add a LICENSE file",
+			},
+			{
+				Severity:    "low",
+				Category:    "Security",
+				Title:       "Sensitive File: docs/token.md",
+				Description: "File may contain sensitive information and is tracked in git",
+				File:        "docs/token.md",
+			},
+		},
+	}
+}
+
+func TestWriteReportSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeReport(&buf, "repo", sampleReport(), "sarif"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Decode generically so the test checks the JSON shape consumers see, not our structs
+	var log map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if log["version"] != "2.1.0" || log["$schema"] != sarifSchema {
+		t.Errorf("version = %v, $schema = %v", log["version"], log["$schema"])
+	}
+
+	runs := log["runs"].([]any)
+	if len(runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(runs))
+	}
+	run := runs[0].(map[string]any)
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	if driver["name"] != "syst" {
+		t.Errorf("driver name = %v, want syst", driver["name"])
+	}
+
+	rules := driver["rules"].([]any)
+	var ruleIDs []string
+	for _, r := range rules {
+		ruleIDs = append(ruleIDs, r.(map[string]any)["id"].(string))
+	}
+	if got := strings.Join(ruleIDs, ","); got != "health/security,health/best-practice" {
+		t.Errorf("rule ids = %s, want one rule per category", got)
+	}
+
+	results := run["results"].([]any)
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	wantLevels := []string{"error", "warning", "note"}
+	wantRules := []float64{0, 1, 0}
+	for i, r := range results {
+		result := r.(map[string]any)
+		if result["level"] != wantLevels[i] {
+			t.Errorf("result %d level = %v, want %s", i, result["level"], wantLevels[i])
+		}
+		if result["ruleIndex"] != wantRules[i] || result["ruleId"] != ruleIDs[int(wantRules[i])] {
+			t.Errorf("result %d rule = %v/%v", i, result["ruleId"], result["ruleIndex"])
+		}
+		if result["message"].(map[string]any)["text"] == "" {
+			t.Errorf("result %d has no message", i)
+		}
+	}
+
+	location := results[0].(map[string]any)["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	artifact := location["artifactLocation"].(map[string]any)
+	if artifact["uri"] != "config/id_rsa" || artifact["uriBaseId"] != "%SRCROOT%" {
+		t.Errorf("artifactLocation = %v", artifact)
+	}
+	if location["region"].(map[string]any)["startLine"] != float64(1) {
+		t.Errorf("region = %v, want startLine 1", location["region"])
+	}
+
+	if _, ok := results[1].(map[string]any)["locations"]; ok {
+		t.Error("issue without a file should have no locations")
+	}
+}
+
+func TestWriteReportFormats(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeReport(&buf, "repo", sampleReport(), "json"); err != nil {
+		t.Fatal(err)
+	}
+	var report HealthReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil || len(report.Issues) != 3 {
+		t.Errorf("json round trip = %d issues, err %v", len(report.Issues), err)
+	}
+
+	buf.Reset()
+	if err := writeReport(&buf, "repo", sampleReport(), "markdown"); err != nil {
+		t.Fatal(err)
+	}
+	if md := buf.String(); !strings.Contains(md, "## Issues (3)") || !strings.Contains(md, "| Severity | Category |") {
+		t.Errorf("markdown report is missing the issues table:\n%s", md)
+	}
+
+	buf.Reset()
+	if err := writeReport(&buf, "repo", sampleReport(), "prom"); err != nil {
+		t.Fatal(err)
+	}
+	if prom := buf.String(); !strings.Contains(prom, `git_health_score{repo="repo"} `) ||
+		!strings.Contains(prom, `git_health_issues{repo="repo",severity="high"} `) {
+		t.Errorf("prom report is missing the score or issues:\n%s", prom)
+	}
+
+	if err := writeReport(&buf, "repo", sampleReport(), "xml"); err == nil {
+		t.Error("writeReport(xml) succeeded, want an unsupported format error")
+	}
+}
@@ -0,0 +1,63 @@
+package healthService
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Defaults of the commit signing check, see HealthOptions
+const (
+	DefaultSigningWindow    = 100
+	DefaultSigningThreshold = 80
+)
+
+// signingWindow is how many of the latest commits the signing check covers
+func (o HealthOptions) signingWindow() int {
+	if o.SigningWindow <= 0 {
+		return DefaultSigningWindow
+	}
+	return o.SigningWindow
+}
+
+// signingThreshold is the percentage of the commits in the window that should be signed
+func (o HealthOptions) signingThreshold() int {
+	if o.SigningThreshold <= 0 {
+		return DefaultSigningThreshold
+	}
+	return min(o.SigningThreshold, 100)
+}
+
+// isSigned reports whether c has a GPG, SSH or X.509 signature, checked or not
+func isSigned(c *object.Commit) bool {
+	return c.PGPSignature != ""
+}
+
+// signingRate is the percentage of the commits in the signing window that are signed
+func signingRate(ch CommitHealthAnalysis) float64 {
+	if ch.SigningCommits == 0 {
+		return 0
+	}
+	return float64(ch.SignedCommits) / float64(ch.SigningCommits) * 100
+}
+
+// signingCheck checks that at least threshold percent of the latest commits are signed.
+// A repository without a single signed commit among them doesn't appear to sign its
+// commits, so it isn't held to the threshold and there is no check.
+func signingCheck(ch CommitHealthAnalysis, threshold int) (BestPracticeCheck, bool) {
+	if ch.SignedCommits == 0 {
+		return BestPracticeCheck{}, false
+	}
+
+	check := BestPracticeCheck{
+		Name: "Commit signing",
+		Description: fmt.Sprintf("%d of the last %d commits are signed (%.0f%%), and at least %d%% should be",
+			ch.SignedCommits, ch.SigningCommits, signingRate(ch), threshold),
+		Status: "pass",
+	}
+	if signingRate(ch) < float64(threshold) {
+		check.Status = "warning"
+		check.Suggestion = "Sign every commit with a GPG or SSH key (git config commit.gpgsign true), or lower --signing-threshold"
+	}
+	return check, true
+}
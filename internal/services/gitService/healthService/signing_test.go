@@ -0,0 +1,45 @@
+package healthService
+
+import "testing"
+
+func TestSigningCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		signed, commits int
+		want            string // "" for no check
+	}{
+		{"never signs", 0, 100, ""},
+		{"signs everything", 100, 100, "pass"},
+		{"at the threshold", 80, 100, "pass"},
+		{"below the threshold", 79, 100, "warning"},
+		{"signed once", 1, 100, "warning"},
+	}
+	for _, tt := range tests {
+		check, ok := signingCheck(CommitHealthAnalysis{SignedCommits: tt.signed, SigningCommits: tt.commits}, DefaultSigningThreshold)
+		got := ""
+		if ok {
+			got = check.Status
+		}
+		if got != tt.want {
+			t.Errorf("%s: signingCheck() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSigningOptions(t *testing.T) {
+	if got := (HealthOptions{}).signingWindow(); got != DefaultSigningWindow {
+		t.Errorf("default signingWindow() = %d", got)
+	}
+	if got := (HealthOptions{SigningThreshold: 150}).signingThreshold(); got != 100 {
+		t.Errorf("signingThreshold() over 100 = %d, want 100", got)
+	}
+
+	// Only the latest commits are checked for signatures
+	ch, err := analyzeCommitHealth(newNoiseTestRepo(t).Repository, nil, nil, 0, false, 2, nil)
+	if err != nil {
+		t.Fatalf("analyzeCommitHealth() error: %v", err)
+	}
+	if ch.Commits != 4 || ch.SigningCommits != 2 || ch.SignedCommits != 0 {
+		t.Errorf("%d commits, %d checked for signatures, %d signed, want 4, 2 and 0", ch.Commits, ch.SigningCommits, ch.SignedCommits)
+	}
+}
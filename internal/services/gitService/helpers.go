@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // BytesToHumanReadable converts bytes number to human-readable string.
@@ -78,3 +79,28 @@ func ParseByteSize(s string) int64 {
 
 	return int64(val * float64(mult))
 }
+
+// ParseAge parses durations like "90d", "2w" or any time.ParseDuration string ("36h").
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			val, err := strconv.ParseFloat(n, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+			return time.Duration(val * float64(unit)), nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q, expected e.g. 90d, 2w or 36h", s)
+	}
+	return d, nil
+}
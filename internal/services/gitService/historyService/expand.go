@@ -0,0 +1,148 @@
+package historyService
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/blameService"
+)
+
+// maxExpandedFiles caps the changed files listed under an expanded timeline commit
+const maxExpandedFiles = 20
+
+// commitDetailsMsg delivers the details of the expanded timeline commit
+type commitDetailsMsg struct {
+	hash    string
+	details blameService.CommitDetails
+	err     error
+}
+
+// detailItem is a row of an expanded timeline commit's details, listed right after it
+type detailItem struct {
+	commit      TimelineCommit
+	title, desc string
+}
+
+// FilterValue matches the commit's, so a filter that keeps the commit keeps its details
+func (i detailItem) FilterValue() string { return i.commit.Message }
+func (i detailItem) Title() string       { return "   " + i.title }
+func (i detailItem) Description() string { return "   " + i.desc }
+
+// loadCommitDetails loads hash's details with blameService.AnalyzeCommitDetails. The
+// signature is already on the timeline, so it is only detected.
+func loadCommitDetails(repoPath, hash string) tea.Cmd {
+	return func() tea.Msg {
+		repo, err := gitservice.OpenRepo(repoPath)
+		if err != nil {
+			return commitDetailsMsg{hash: hash, err: err}
+		}
+		details, err := blameService.AnalyzeCommitDetails(repo, gitservice.NewCommitStatsCache(repo, false), nil, hash)
+		return commitDetailsMsg{hash: hash, details: details, err: err}
+	}
+}
+
+// toggleExpanded expands the selected timeline commit, or collapses it when it (or one
+// of its detail rows) is selected and already expanded. The commit stays selected.
+func (m *model) toggleExpanded() tea.Cmd {
+	hash := m.selectedHash()
+	if hash == "" {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if hash == m.expanded {
+		m.expanded = ""
+	} else {
+		m.expanded = hash
+		cmd = loadCommitDetails(m.opts.RepoPath, hash)
+	}
+	m.details, m.detailsErr = nil, nil
+	m.updateListItems()
+
+	for i, item := range m.timelineList.Items() {
+		if c, ok := item.(timelineItem); ok && c.commit.Hash == hash {
+			m.timelineList.Select(i)
+			break
+		}
+	}
+	return cmd
+}
+
+// expandedItems returns the detail rows of the expanded commit: a loading row until its
+// details arrive
+func (m model) expandedItems(commit TimelineCommit) []list.Item {
+	switch {
+	case m.detailsErr != nil:
+		return []list.Item{detailItem{commit: commit, title: "⚠ Failed to load the commit", desc: m.detailsErr.Error()}}
+	case m.details == nil:
+		return []list.Item{detailItem{commit: commit, title: "⏳ Loading commit details..."}}
+	}
+	return detailItems(commit, *m.details)
+}
+
+// detailItems lists a commit's author and date, the body of its message a paragraph
+// per row, and the files it changed
+func detailItems(commit TimelineCommit, d blameService.CommitDetails) []list.Item {
+	items := []list.Item{detailItem{
+		commit: commit,
+		title:  fmt.Sprintf("👤 %s <%s>", d.Author, d.AuthorEmail),
+		desc: fmt.Sprintf("📅 %s • 📊 %d files • +%d -%d",
+			gitservice.DetailDate(d.Date, "2006-01-02 15:04:05 -0700"), d.Stats.FilesChanged, d.Stats.Additions, d.Stats.Deletions),
+	}}
+
+	// The subject is already the commit's title
+	_, body, _ := strings.Cut(strings.TrimSpace(d.FullMessage), "\n")
+	for _, paragraph := range strings.Split(strings.TrimSpace(body), "\n\n") {
+		lines := strings.Split(strings.TrimSpace(paragraph), "\n")
+		if lines[0] == "" {
+			continue
+		}
+		items = append(items, detailItem{
+			commit: commit,
+			title:  "💬 " + lines[0],
+			desc:   strings.Join(lines[1:], " "),
+		})
+	}
+
+	for i, file := range d.FilesChanged {
+		if i == maxExpandedFiles {
+			items = append(items, detailItem{commit: commit, title: fmt.Sprintf("   ... and %d more files", len(d.FilesChanged)-i)})
+			break
+		}
+		items = append(items, detailItem{
+			commit: commit,
+			title:  fileStatusIcon(file.Status) + " " + file.Path,
+			desc:   fmt.Sprintf("%s • +%d -%d", file.Status, file.Additions, file.Deletions),
+		})
+	}
+	return items
+}
+
+func fileStatusIcon(status string) string {
+	switch status {
+	case "added":
+		return "➕"
+	case "deleted":
+		return "❌"
+	default:
+		return "📝"
+	}
+}
+
+// commitIndex returns the position of the selected timeline commit among the commits,
+// not counting detail rows, so a saved selection doesn't depend on what was expanded
+func (m model) commitIndex() int {
+	commits := 0
+	for i, item := range m.timelineList.Items() {
+		if i > m.timelineList.Index() {
+			break
+		}
+		if _, ok := item.(timelineItem); ok {
+			commits++
+		}
+	}
+	return max(commits-1, 0)
+}
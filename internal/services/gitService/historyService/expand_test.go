@@ -0,0 +1,129 @@
+package historyService
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/syst/internal/services/gitService/blameService"
+	"github.com/redjax/syst/internal/utils/terminal"
+)
+
+func TestExpandedTimelineCommit(t *testing.T) {
+	when := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := model{
+		timelineList: list.New(nil, list.NewDefaultDelegate(), 80, 40),
+		analysis: HistoryAnalysis{Timeline: []TimelineCommit{
+			{Hash: "c3", Message: "Third", Date: when.Add(2 * time.Hour)},
+			{Hash: "c2", Message: "Second", Date: when.Add(time.Hour)},
+			{Hash: "c1", Message: "First", Date: when},
+		}},
+		expanded: "c2",
+	}
+
+	titles := func() string {
+		var titles []string
+		for _, item := range m.timelineList.Items() {
+			switch item := item.(type) {
+			case timelineItem:
+				titles = append(titles, item.commit.Hash)
+			case detailItem:
+				titles = append(titles, strings.TrimSpace(item.Title()))
+			}
+		}
+		return strings.Join(titles, " | ")
+	}
+
+	// A loading row until the details arrive
+	m.updateListItems()
+	if got, want := titles(), "c3 | c2 | ⏳ Loading commit details... | c1"; got != want {
+		t.Errorf("timeline = %s, want %s", got, want)
+	}
+
+	m.details = &blameService.CommitDetails{
+		Author:      "Test",
+		AuthorEmail: "test@example.com",
+		Date:        when.Add(time.Hour),
+		FullMessage: "Second\n\nWhy it changed,\nover two lines.\n\nRefs #12\n",
+		FilesChanged: []blameService.FileChange{
+			{Path: "main.go", Status: "modified", Additions: 2, Deletions: 1},
+			{Path: "new.go", Status: "added", Additions: 10},
+		},
+	}
+	m.updateListItems()
+	want := "c3 | c2 | 👤 Test <test@example.com> | 💬 Why it changed, | 💬 Refs #12 | 📝 main.go | ➕ new.go | c1"
+	if got := titles(); got != want {
+		t.Errorf("timeline = %s, want %s", got, want)
+	}
+	if desc := m.timelineList.Items()[3].(detailItem).desc; desc != "over two lines." {
+		t.Errorf("paragraph description = %q, want its second line", desc)
+	}
+
+	// A detail row belongs to its commit, and isn't counted in the saved selection
+	m.timelineList.Select(4)
+	if hash := m.selectedHash(); hash != "c2" {
+		t.Errorf("selectedHash() on a detail row = %s, want c2", hash)
+	}
+	if index := m.commitIndex(); index != 1 {
+		t.Errorf("commitIndex() on a detail row = %d, want 1", index)
+	}
+	m.timelineList.Select(7)
+	if index := m.commitIndex(); index != 2 {
+		t.Errorf("commitIndex() after the details = %d, want 2", index)
+	}
+
+	// Collapsing from a detail row selects the commit again
+	m.timelineList.Select(5)
+	if cmd := m.toggleExpanded(); cmd != nil {
+		t.Error("collapsing loads details")
+	}
+	if got, want := titles(), "c3 | c2 | c1"; got != want || m.timelineList.Index() != 1 {
+		t.Errorf("timeline after collapsing = %s with %d selected, want %s with 1", got, m.timelineList.Index(), want)
+	}
+}
+
+func TestMouseClickExpandsCommit(t *testing.T) {
+	when := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	delegate := list.NewDefaultDelegate()
+	m := model{
+		timelineList: list.New(nil, delegate, 0, 0),
+		tagsList:     list.New(nil, delegate, 0, 0),
+		mergesList:   list.New(nil, delegate, 0, 0),
+		largestList:  list.New(nil, delegate, 0, 0),
+		listDelegate: delegate,
+		loading:      true,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.DefaultKeyMap(),
+	}
+
+	var updated tea.Model = m
+	for _, msg := range []tea.Msg{
+		tea.WindowSizeMsg{Width: 120, Height: 60},
+		dataLoadedMsg{analysis: HistoryAnalysis{Timeline: []TimelineCommit{
+			{Hash: "c3", Message: "Third", Date: when.Add(2 * time.Hour)},
+			{Hash: "c2", Message: "Second", Date: when.Add(time.Hour)},
+			{Hash: "c1", Message: "First", Date: when},
+		}}},
+	} {
+		updated, _ = updated.Update(msg)
+	}
+
+	row := -1
+	for i, line := range strings.Split(updated.View(), "\n") {
+		if strings.Contains(line, "Second") {
+			row = i
+			break
+		}
+	}
+	if row < 0 {
+		t.Fatal("the second commit not found in view")
+	}
+
+	// Clicking a commit expands it, like pressing enter
+	updated, cmd := updated.Update(tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionPress, Y: row})
+	if got := updated.(model).expanded; got != "c2" || cmd == nil {
+		t.Errorf("expanded commit after click = %q, want c2 with its details loading", got)
+	}
+}
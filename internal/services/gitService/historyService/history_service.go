@@ -8,12 +8,18 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/blameService"
+	"github.com/redjax/syst/internal/utils/profile"
 	"github.com/redjax/syst/internal/utils/terminal"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type ViewMode int
@@ -23,6 +29,7 @@ const (
 	FrequencyView
 	TagsView
 	MergesView
+	LargestView
 )
 
 type HistoryAnalysis struct {
@@ -46,6 +53,7 @@ type TimelineCommit struct {
 	Files       []string
 	Additions   int
 	Deletions   int
+	Signature   gitservice.CommitSignature
 }
 
 type FrequencyData struct {
@@ -102,18 +110,57 @@ type OverallHistoryStats struct {
 	MostActiveAuthor string
 	TotalTags        int
 	TotalMerges      int
+	LimitNote        string // Set when --limit cut the walk short
 }
 
+// HistoryOptions controls the history explorer
+type HistoryOptions struct {
+	// RepoPath is the repository to explore; empty means the current directory
+	RepoPath string
+	// NoCache disables the on-disk commit stats cache under .git/syst-cache
+	NoCache bool
+	// Remember restores the section and selection the explorer was last closed with
+	Remember bool
+	// Ref is the branch, tag or commit whose history is explored; empty means HEAD
+	Ref string
+	// Limit caps how many commits are walked from Ref; 0 means the whole history
+	Limit int
+	// Signatures controls whether commit signatures are verified or only detected
+	Signatures gitservice.SignatureOptions
+	// ReviewRiskLines is how many changed lines flag a commit as a review risk in the
+	// largest commits view; 0 means DefaultReviewRiskLines
+	ReviewRiskLines int
+}
+
+// viewStateName identifies the history explorer's saved view state
+const viewStateName = "history"
+
 type model struct {
 	analysis     HistoryAnalysis
 	currentView  ViewMode
 	timelineList list.Model
 	tagsList     list.Model
 	mergesList   list.Model
+	largestList  list.Model
+	listDelegate list.ItemDelegate
 	loading      bool
 	err          error
 	tuiHelper *terminal.ResponsiveTUIHelper
 	sections     []string
+	opts         HistoryOptions
+	loader       gitservice.Loading
+	clipboard    terminal.ClipboardNotice
+	// restore is the saved view state to apply once the data has loaded
+	restore *gitservice.ViewState
+	keys    terminal.KeyMap
+	// sortBy is the index of each list view's order in its sorts; 0 is newest first,
+	// or largest first in the largest commits view
+	sortBy map[ViewMode]int
+	// expanded is the hash of the timeline commit whose details are listed under it, with
+	// details (or detailsErr) set once they have loaded
+	expanded   string
+	details    *blameService.CommitDetails
+	detailsErr error
 }
 
 type timelineItem struct {
@@ -126,11 +173,15 @@ func (i timelineItem) Title() string {
 	if i.commit.IsMerge {
 		prefix = "🔀"
 	}
-	return fmt.Sprintf("%s %s %s", prefix, i.commit.ShortHash, i.commit.Message)
+	title := fmt.Sprintf("%s %s %s", prefix, i.commit.ShortHash, i.commit.Message)
+	if icon := i.commit.Signature.Icon(); icon != "" {
+		title += " " + icon
+	}
+	return title
 }
 func (i timelineItem) Description() string {
 	return fmt.Sprintf("%s • %s • %d files",
-		i.commit.Author, i.commit.Date.Format("2006-01-02 15:04"), len(i.commit.Files))
+		i.commit.Author, gitservice.ListDate(i.commit.Date, "2006-01-02 15:04"), len(i.commit.Files))
 }
 
 type tagItem struct {
@@ -147,7 +198,7 @@ func (i tagItem) Title() string {
 }
 func (i tagItem) Description() string {
 	return fmt.Sprintf("%s • %s • %d commits since",
-		i.tag.Tagger, i.tag.Date.Format("2006-01-02"), i.tag.CommitsSince)
+		i.tag.Tagger, gitservice.ListDate(i.tag.Date, "2006-01-02"), i.tag.CommitsSince)
 }
 
 type mergeItem struct {
@@ -160,7 +211,7 @@ func (i mergeItem) Title() string {
 }
 func (i mergeItem) Description() string {
 	return fmt.Sprintf("%s • %s • %d files • +%d -%d",
-		i.merge.Author, i.merge.Date.Format("2006-01-02 15:04"),
+		i.merge.Author, gitservice.ListDate(i.merge.Date, "2006-01-02 15:04"),
 		i.merge.FilesChanged, i.merge.Additions, i.merge.Deletions)
 }
 
@@ -174,39 +225,42 @@ type errMsg struct {
 
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#9B59B6")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1)
 
 	sectionStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			MarginTop(1)
 
 	headerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			MarginBottom(1)
 
 	statsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575"))
+			Foreground(theme.Current.Success)
 
 	highlightStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#01FAC6")).
+			Foreground(theme.Current.Accent).
 			Bold(true)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87"))
+			Foreground(theme.Current.Error)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 )
 
 func (m model) Init() tea.Cmd {
-	return loadHistoryData
+	return tea.Batch(
+		m.loader.Init(),
+		loadHistoryData(m.opts, m.loader.Progress),
+	)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -219,8 +273,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.tagsList.SetHeight(m.tuiHelper.GetHeight() - 12)
 		m.mergesList.SetWidth(m.tuiHelper.GetWidth())
 		m.mergesList.SetHeight(m.tuiHelper.GetHeight() - 12)
+		m.largestList.SetWidth(m.tuiHelper.GetWidth())
+		m.largestList.SetHeight(m.tuiHelper.GetHeight() - 12)
 		return m, nil
 
+	case spinner.TickMsg, gitservice.ProgressMsg:
+		var cmd tea.Cmd
+		m.loader, cmd = m.loader.Update(msg)
+		return m, cmd
+
 	case dataLoadedMsg:
 		m.analysis = msg.analysis
 		m.loading = false
@@ -229,6 +290,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			"Frequency",
 			"Tags",
 			"Merges",
+			"Largest",
+		}
+		if m.restore != nil {
+			m.applyViewState(*m.restore)
+			m.restore = nil
 		}
 		m.updateListItems()
 		return m, nil
@@ -238,9 +304,39 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		return m, nil
 
+	case commitDetailsMsg:
+		// Drop the details of a commit that was collapsed while they loaded
+		if msg.hash == m.expanded {
+			m.details, m.detailsErr = &msg.details, msg.err
+			m.updateListItems()
+		}
+		return m, nil
+
+	case terminal.ClipboardMsg, terminal.ClipboardClearMsg:
+		return m, m.clipboard.Update(msg)
+
+	case tea.MouseMsg:
+		if l := m.activeList(); l != nil && !m.loading &&
+			terminal.HandleListMouse(l, m.listDelegate, m.listTop(*l), msg) {
+			// Clicking an item acts like pressing enter on it, i.e. expands a commit
+			return m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		// The error screen can only retry the load or quit
+		if m.err != nil {
+			switch {
+			case key.Matches(msg, m.keys.Refresh):
+				return m.retry()
+			case key.Matches(msg, m.keys.Quit, m.keys.Back):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
 		switch {
-		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c", "esc"))):
+		case key.Matches(msg, m.keys.Quit, m.keys.Back):
 			return m, tea.Quit
 		case key.Matches(msg, key.NewBinding(key.WithKeys("1"))):
 			m.currentView = TimelineView
@@ -258,13 +354,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentView = MergesView
 			m.updateListItems()
 			return m, nil
-		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h"))):
+		case key.Matches(msg, key.NewBinding(key.WithKeys("5"))):
+			m.currentView = LargestView
+			m.updateListItems()
+			return m, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("s"))) && !m.filtering():
+			m.cycleSort()
+			return m, nil
+		case key.Matches(msg, m.keys.Select) && m.currentView == TimelineView && !m.filtering():
+			return m, m.toggleExpanded()
+		case key.Matches(msg, m.keys.Copy):
+			if hash := m.selectedHash(); hash != "" {
+				return m, terminal.CopyCmd(hash)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Left):
 			if m.currentView > 0 {
 				m.currentView--
 				m.updateListItems()
 			}
 			return m, nil
-		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
+		case key.Matches(msg, m.keys.Right):
 			if int(m.currentView) < len(m.sections)-1 {
 				m.currentView++
 				m.updateListItems()
@@ -280,6 +390,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.tagsList, cmd = m.tagsList.Update(msg)
 			case MergesView:
 				m.mergesList, cmd = m.mergesList.Update(msg)
+			case LargestView:
+				m.largestList, cmd = m.largestList.Update(msg)
 			}
 			return m, cmd
 		}
@@ -288,67 +400,179 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// viewState returns the current section and the selection in its list.
+func (m model) viewState() gitservice.ViewState {
+	state := gitservice.ViewState{View: int(m.currentView)}
+	if m.currentView == TimelineView {
+		state.Index = m.commitIndex()
+	} else if l := m.activeList(); l != nil {
+		state.Index = l.Index()
+	}
+	return state
+}
+
+// applyViewState switches to a saved section and selects the saved item once the lists
+// are filled. Out of range values from an older run are ignored.
+func (m *model) applyViewState(state gitservice.ViewState) {
+	if state.View < 0 || state.View >= len(m.sections) {
+		return
+	}
+	m.currentView = ViewMode(state.View)
+	m.updateListItems()
+	if l := m.activeList(); l != nil && state.Index >= 0 && state.Index < len(l.Items()) {
+		l.Select(state.Index)
+	}
+}
+
+// selectedHash returns the full hash of the commit selected in the current view, or ""
+// if the view has no commits.
+func (m model) selectedHash() string {
+	switch m.currentView {
+	case TimelineView:
+		switch item := m.timelineList.SelectedItem().(type) {
+		case timelineItem:
+			return item.commit.Hash
+		case detailItem:
+			return item.commit.Hash
+		}
+	case MergesView:
+		if item, ok := m.mergesList.SelectedItem().(mergeItem); ok {
+			return item.merge.Hash
+		}
+	case LargestView:
+		if item, ok := m.largestList.SelectedItem().(largestItem); ok {
+			return item.commit.Hash
+		}
+	}
+	return ""
+}
+
 func (m *model) updateListItems() {
 	switch m.currentView {
 	case TimelineView:
 		var items []list.Item
-		for _, commit := range m.analysis.Timeline {
+		for _, commit := range gitservice.SortList(m.analysis.Timeline, timelineSorts[m.sortBy[TimelineView]]) {
 			items = append(items, timelineItem{commit: commit})
+			if commit.Hash == m.expanded {
+				items = append(items, m.expandedItems(commit)...)
+			}
 		}
 		m.timelineList.SetItems(items)
 	case TagsView:
 		var items []list.Item
-		for _, tag := range m.analysis.Tags {
+		for _, tag := range gitservice.SortList(m.analysis.Tags, tagSorts[m.sortBy[TagsView]]) {
 			items = append(items, tagItem{tag: tag})
 		}
 		m.tagsList.SetItems(items)
 	case MergesView:
 		var items []list.Item
-		for _, merge := range m.analysis.Merges {
+		for _, merge := range gitservice.SortList(m.analysis.Merges, mergeSorts[m.sortBy[MergesView]]) {
 			items = append(items, mergeItem{merge: merge})
 		}
 		m.mergesList.SetItems(items)
+	case LargestView:
+		var items []list.Item
+		threshold := m.opts.reviewRiskLines()
+		for _, commit := range gitservice.SortList(largestCommits(m.analysis.Timeline), largestSorts[m.sortBy[LargestView]]) {
+			items = append(items, largestItem{commit: commit, risky: linesChanged(commit) >= threshold})
+		}
+		m.largestList.SetItems(items)
 	}
 }
 
+// retry starts the analysis over after it failed, with a fresh progress stream as the
+// last one was closed
+func (m model) retry() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.loading = true
+	m.loader = gitservice.NewLoading()
+	return m, m.Init()
+}
+
 func (m model) View() string {
 	if m.loading {
-		return "\n  Analyzing repository history...\n"
+		return "\n  " + m.loader.View("Analyzing repository history...", helpStyle.PaddingLeft(2)) + "\n"
 	}
 
 	if m.err != nil {
-		return errorStyle.Render(fmt.Sprintf("\n  Error: %v\n", m.err))
+		return errorStyle.Render(fmt.Sprintf("\n  Error: %v\n", m.err)) + helpStyle.Render("  "+m.keys.ErrorHelp()) + "\n"
 	}
 
 	var sections []string
 
-	// Title
-	title := titleStyle.Render("📈 Git History Explorer")
-	sections = append(sections, title)
-
-	// Navigation tabs
-	tabs := m.renderTabs()
-	sections = append(sections, tabs)
+	// Title and navigation tabs
+	sections = append(sections, m.renderHeader())
 
 	// Content based on current view
 	content := m.renderCurrentView()
 	sections = append(sections, sectionStyle.Render(content))
 
 	// Instructions
-	help := helpStyle.Render("1-4: sections • ←/→: navigate • ↑/↓: scroll • q: quit")
+	helpEntries := []string{"1-5: sections",
+		terminal.HelpKey(m.keys.Left, m.keys.Right) + ": navigate",
+		terminal.HelpKey(m.keys.Up, m.keys.Down) + ": scroll"}
+	if m.currentView == TimelineView {
+		helpEntries = append(helpEntries, terminal.Help(m.keys.Select, "expand"))
+	}
+	if sortNames(m.currentView) != nil {
+		helpEntries = append(helpEntries, "s: change sort")
+	}
+	helpEntries = append(helpEntries, terminal.Help(m.keys.Copy, "copy hash"), terminal.Help(m.keys.Quit, "quit"))
+	help := helpStyle.Render(terminal.HelpLine(helpEntries...))
 	sections = append(sections, help)
+	if notice := m.clipboard.View(); notice != "" {
+		sections = append(sections, notice)
+	}
 
 	return strings.Join(sections, "\n")
 }
 
+func (m model) renderHeader() string {
+	title := titleStyle.Render("📈 Git History Explorer")
+	if m.opts.Ref != "" {
+		title = titleStyle.Render("📈 Git History Explorer - " + m.opts.Ref)
+	}
+	return title + "\n" + m.renderTabs()
+}
+
+// activeList returns the list shown in the current view, if any.
+func (m *model) activeList() *list.Model {
+	switch m.currentView {
+	case TimelineView:
+		return &m.timelineList
+	case TagsView:
+		return &m.tagsList
+	case MergesView:
+		return &m.mergesList
+	case LargestView:
+		return &m.largestList
+	default:
+		return nil
+	}
+}
+
+// filtering reports whether the current view's list is taking filter input, so keys
+// should go to it
+func (m *model) filtering() bool {
+	l := m.activeList()
+	return l != nil && l.FilterState() == list.Filtering
+}
+
+// listTop returns the screen row where l starts. Each view renders l last, so the text
+// before it is the view's content with l's own output trimmed off.
+func (m model) listTop(l list.Model) int {
+	prefix := strings.TrimSuffix(m.renderCurrentView(), l.View())
+	return lipgloss.Height(m.renderHeader()) + terminal.StyleTopOffset(sectionStyle) + strings.Count(prefix, "\n")
+}
+
 func (m model) renderTabs() string {
 	var tabs []string
 
 	for i, section := range m.sections {
 		style := lipgloss.NewStyle().Padding(0, 1)
 		if ViewMode(i) == m.currentView {
-			style = style.Foreground(lipgloss.Color("#01FAC6")).Bold(true).
-				Background(lipgloss.Color("#874BFD"))
+			style = style.Foreground(theme.Current.Accent).Bold(true).
+				Background(theme.Current.Primary)
 		}
 		tabs = append(tabs, style.Render(fmt.Sprintf("%d. %s", i+1, section)))
 	}
@@ -366,6 +590,8 @@ func (m model) renderCurrentView() string {
 		return m.renderTagsView()
 	case MergesView:
 		return m.renderMergesView()
+	case LargestView:
+		return m.renderLargestView()
 	default:
 		return "Unknown view"
 	}
@@ -376,18 +602,27 @@ func (m model) renderTimelineView() string {
 
 	content.WriteString(headerStyle.Render("📅 Commit Timeline"))
 	content.WriteString("\n")
-	content.WriteString("Chronological view of repository commits")
+	content.WriteString("Chronological view of repository commits" + m.sortLabel())
 	content.WriteString("\n\n")
 
 	// Overall stats
 	stats := m.analysis.OverallStats
 	content.WriteString(fmt.Sprintf("📊 %s total commits from %s to %s\n",
 		statsStyle.Render(fmt.Sprintf("%d", stats.TotalCommits)),
-		stats.FirstCommit.Format("2006-01-02"),
-		stats.LastCommit.Format("2006-01-02")))
-	content.WriteString(fmt.Sprintf("👥 %s authors • 📈 %.1f commits/day average\n\n",
+		gitservice.DetailDate(stats.FirstCommit, "2006-01-02"),
+		gitservice.DetailDate(stats.LastCommit, "2006-01-02")))
+	content.WriteString(fmt.Sprintf("👥 %s authors • 📈 %.1f commits/day average",
 		statsStyle.Render(fmt.Sprintf("%d", stats.TotalAuthors)),
 		stats.AveragePerDay))
+	// Kept on the same line so the list below doesn't move
+	if stats.LimitNote != "" {
+		content.WriteString(" • " + stats.LimitNote)
+	}
+	content.WriteString("\n")
+	if m.opts.Signatures.Verify {
+		content.WriteString(m.renderSignatureSummary())
+	}
+	content.WriteString("\n")
 
 	if len(m.timelineList.Items()) == 0 {
 		content.WriteString("No commits to display")
@@ -398,6 +633,26 @@ func (m model) renderTimelineView() string {
 	return content.String()
 }
 
+// renderSignatureSummary counts the verified commits, and the unsigned and unverifiable
+// ones, in the timeline
+func (m model) renderSignatureSummary() string {
+	var verified, unsigned, unverified int
+	for _, commit := range m.analysis.Timeline {
+		switch commit.Signature.Status {
+		case gitservice.SignatureVerified:
+			verified++
+		case gitservice.SignatureUnsigned:
+			unsigned++
+		default:
+			unverified++
+		}
+	}
+	return fmt.Sprintf("🔏 %s verified • %s unsigned • ⚠ %s unverifiable or bad\n",
+		statsStyle.Render(fmt.Sprintf("%d", verified)),
+		statsStyle.Render(fmt.Sprintf("%d", unsigned)),
+		statsStyle.Render(fmt.Sprintf("%d", unverified)))
+}
+
 func (m model) renderFrequencyView() string {
 	var content strings.Builder
 	freq := m.analysis.FrequencyData
@@ -412,8 +667,12 @@ func (m model) renderFrequencyView() string {
 	content.WriteString(fmt.Sprintf("🔥 Current streak: %s days (longest: %s)\n",
 		highlightStyle.Render(fmt.Sprintf("%d", freq.CommitStreak.Current)),
 		statsStyle.Render(fmt.Sprintf("%d", freq.CommitStreak.Longest))))
-	content.WriteString(fmt.Sprintf("📈 Max commits per day: %s\n\n",
+	content.WriteString(fmt.Sprintf("📈 Max commits per day: %s\n",
 		statsStyle.Render(fmt.Sprintf("%d", freq.MaxCommitsPerDay))))
+	if note := m.analysis.OverallStats.LimitNote; note != "" {
+		content.WriteString(fmt.Sprintf("✂️  Stats are %s\n", note))
+	}
+	content.WriteString("\n")
 
 	// Weekday pattern
 	content.WriteString(headerStyle.Render("📅 Weekly Pattern"))
@@ -473,7 +732,7 @@ func (m model) renderTagsView() string {
 
 	content.WriteString(headerStyle.Render("🏷️ Tags & Releases"))
 	content.WriteString("\n")
-	content.WriteString("Repository tags and release history")
+	content.WriteString("Repository tags and release history" + m.sortLabel())
 	content.WriteString("\n\n")
 
 	if len(m.analysis.Tags) == 0 {
@@ -493,7 +752,7 @@ func (m model) renderMergesView() string {
 
 	content.WriteString(headerStyle.Render("🔀 Merge Commits"))
 	content.WriteString("\n")
-	content.WriteString("Analysis of merge commits and branch integration")
+	content.WriteString("Analysis of merge commits and branch integration" + m.sortLabel())
 	content.WriteString("\n\n")
 
 	if len(m.analysis.Merges) == 0 {
@@ -523,32 +782,45 @@ func (m model) renderMergesView() string {
 	return content.String()
 }
 
-func loadHistoryData() tea.Msg {
-	analysis, err := analyzeHistory()
-	if err != nil {
-		return errMsg{err}
+func loadHistoryData(opts HistoryOptions, progress *gitservice.Progress) tea.Cmd {
+	return func() tea.Msg {
+		defer progress.Close()
+
+		analysis, err := analyzeHistory(opts, progress)
+		if err != nil {
+			return errMsg{err}
+		}
+		return dataLoadedMsg{analysis}
 	}
-	return dataLoadedMsg{analysis}
 }
 
-func analyzeHistory() (HistoryAnalysis, error) {
-	repo, err := git.PlainOpen(".")
+func analyzeHistory(opts HistoryOptions, progress *gitservice.Progress) (HistoryAnalysis, error) {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
 	if err != nil {
-		return HistoryAnalysis{}, fmt.Errorf("failed to open repository: %w", err)
+		return HistoryAnalysis{}, err
 	}
 
-	ref, err := repo.Head()
+	from, err := gitservice.StartCommit(repo, opts.Ref)
 	if err != nil {
-		return HistoryAnalysis{}, fmt.Errorf("failed to get HEAD: %w", err)
+		return HistoryAnalysis{}, err
 	}
 
 	analysis := HistoryAnalysis{}
 
 	// Analyze commits for timeline and frequency
-	err = analyzeCommits(repo, ref.Hash(), &analysis)
+	stats := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+	progress.CountCommits(repo, from, opts.Limit)
+	limit := gitservice.NewCommitLimit(opts.Limit)
+	verifier, err := opts.Signatures.Verifier()
+	if err != nil {
+		return HistoryAnalysis{}, err
+	}
+	err = analyzeCommits(repo, from, &analysis, stats, verifier, limit, progress)
 	if err != nil {
 		return HistoryAnalysis{}, fmt.Errorf("failed to analyze commits: %w", err)
 	}
+	// #nosec G104 - A failed cache write only means the next run recomputes stats
+	stats.Save()
 
 	// Analyze tags
 	err = analyzeTags(repo, &analysis)
@@ -558,11 +830,12 @@ func analyzeHistory() (HistoryAnalysis, error) {
 
 	// Calculate overall stats
 	calculateOverallStats(&analysis)
+	analysis.OverallStats.LimitNote = limit.Note()
 
 	return analysis, nil
 }
 
-func analyzeCommits(repo *git.Repository, fromHash plumbing.Hash, analysis *HistoryAnalysis) error {
+func analyzeCommits(repo *git.Repository, fromHash plumbing.Hash, analysis *HistoryAnalysis, statsCache *gitservice.CommitStatsCache, verifier *gitservice.SignatureVerifier, limit *gitservice.CommitLimit, progress *gitservice.Progress) error {
 	cIter, err := repo.Log(&git.LogOptions{From: fromHash})
 	if err != nil {
 		return err
@@ -581,21 +854,34 @@ func analyzeCommits(repo *git.Repository, fromHash plumbing.Hash, analysis *Hist
 	var commitDates []time.Time
 	activeDaysSet := make(map[string]bool)
 
+	mailmap, err := gitservice.LoadRepoMailmap(repo, "")
+	if err != nil {
+		return err
+	}
+
+	walked := profile.Phase(profile.LogWalk)
 	err = cIter.ForEach(func(c *object.Commit) error {
+		if !limit.Take() {
+			return storer.ErrStop
+		}
+		progress.Commit()
+		authorName, authorEmail := mailmap.Canonicalize(c.Author.Name, c.Author.Email)
+
 		// Timeline data
 		timelineCommit := TimelineCommit{
 			Hash:        c.Hash.String(),
 			ShortHash:   c.Hash.String()[:8],
 			Message:     strings.Split(c.Message, "\n")[0],
-			Author:      c.Author.Name,
-			Email:       c.Author.Email,
+			Author:      authorName,
+			Email:       authorEmail,
 			Date:        c.Author.When,
 			ParentCount: c.NumParents(),
 			IsMerge:     c.NumParents() > 1,
+			Signature:   verifier.Check(c),
 		}
 
 		// Get file stats
-		if stats, err := c.Stats(); err == nil {
+		if stats, err := statsCache.Stats(c); err == nil {
 			for _, stat := range stats {
 				timelineCommit.Files = append(timelineCommit.Files, stat.Name)
 				timelineCommit.Additions += stat.Addition
@@ -654,6 +940,8 @@ func analyzeCommits(repo *git.Repository, fromHash plumbing.Hash, analysis *Hist
 
 		return nil
 	})
+	walked()
+	defer profile.Phase(profile.Render)()
 
 	if err != nil {
 		return err
@@ -690,18 +978,35 @@ func analyzeCommits(repo *git.Repository, fromHash plumbing.Hash, analysis *Hist
 }
 
 func analyzeTags(repo *git.Repository, analysis *HistoryAnalysis) error {
-	tagRefs, err := repo.Tags()
+	tags, err := LoadTags(repo)
 	if err != nil {
 		return err
 	}
 
+	analysis.Tags = tags
+	return nil
+}
+
+// LoadTags returns the repository's tags, newest first, with the number of commits on
+// HEAD since each one.
+func LoadTags(repo *git.Repository) ([]TagInfo, error) {
+	tagRefs, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var head plumbing.Hash
+	if ref, err := repo.Head(); err == nil {
+		head = ref.Hash()
+	}
+
 	var tags []TagInfo
 
 	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
 		tag := TagInfo{
 			Name: strings.TrimPrefix(ref.Name().String(), "refs/tags/"),
-			Hash: ref.Hash().String()[:8],
 		}
+		commitHash := ref.Hash()
 
 		// Try to get tag object for annotated tags
 		tagObj, err := repo.TagObject(ref.Hash())
@@ -711,6 +1016,7 @@ func analyzeTags(repo *git.Repository, analysis *HistoryAnalysis) error {
 			tag.Date = tagObj.Tagger.When
 			tag.Tagger = tagObj.Tagger.Name
 			tag.Message = tagObj.Message
+			commitHash = tagObj.Target
 		} else {
 			// Lightweight tag - points directly to commit
 			tag.Type = "lightweight"
@@ -721,18 +1027,21 @@ func analyzeTags(repo *git.Repository, analysis *HistoryAnalysis) error {
 				tag.Message = commit.Message
 			}
 		}
+		tag.Hash = commitHash.String()[:8]
 
-		// Calculate commits since this tag
-		// This is a simplified version - in a real implementation you might want
-		// to count commits from tag to HEAD
-		tag.CommitsSince = 0
+		// Commits on HEAD that are not in the tagged commit's history
+		if !head.IsZero() {
+			if ahead, _, err := gitservice.AheadBehind(repo, commitHash, head); err == nil {
+				tag.CommitsSince = ahead
+			}
+		}
 
 		tags = append(tags, tag)
 		return nil
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Sort tags by date (newest first)
@@ -740,8 +1049,7 @@ func analyzeTags(repo *git.Repository, analysis *HistoryAnalysis) error {
 		return tags[i].Date.After(tags[j].Date)
 	})
 
-	analysis.Tags = tags
-	return nil
+	return tags, nil
 }
 
 func calculateCommitStreak(commitDates []time.Time) StreakInfo {
@@ -861,13 +1169,25 @@ func calculateOverallStats(analysis *HistoryAnalysis) {
 }
 
 // RunHistoryExplorer starts the advanced history explorer TUI
-func RunHistoryExplorer() error {
+func RunHistoryExplorer(opts HistoryOptions) error {
+	if err := gitservice.CheckHistoryFrom(opts.RepoPath, opts.Ref); err != nil {
+		return err
+	}
+	if err := terminal.RequireTTY(""); err != nil {
+		return err
+	}
+
+	// Fail on a missing or unreadable keyring before starting the TUI
+	if _, err := opts.Signatures.Verifier(); err != nil {
+		return err
+	}
+
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("#01FAC6")).
-		BorderLeftForeground(lipgloss.Color("#01FAC6"))
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
 	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("#DDDDDD"))
+		Foreground(theme.Current.Text)
 
 	timelineList := list.New([]list.Item{}, delegate, 0, 0)
 	timelineList.SetShowStatusBar(false)
@@ -881,16 +1201,49 @@ func RunHistoryExplorer() error {
 	mergesList.SetShowStatusBar(false)
 	mergesList.SetShowHelp(false)
 
+	largestList := list.New([]list.Item{}, delegate, 0, 0)
+	largestList.SetShowStatusBar(false)
+	largestList.SetShowHelp(false)
+
 	m := model{
 		timelineList: timelineList,
 		tagsList:     tagsList,
 		mergesList:   mergesList,
+		largestList:  largestList,
+		listDelegate: delegate,
 		currentView:  TimelineView,
 		loading:      true,
 		tuiHelper: terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.Keys(),
+		opts:         opts,
+		loader:       gitservice.NewLoading(),
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	for _, l := range []*list.Model{&m.timelineList, &m.tagsList, &m.mergesList, &m.largestList} {
+		m.keys.ApplyToList(l)
+	}
+
+	if opts.Remember {
+		repo, err := gitservice.OpenRepo(opts.RepoPath)
+		if err != nil {
+			return err
+		}
+		if state, ok := gitservice.LoadViewState(repo, viewStateName); ok {
+			m.restore = &state
+		}
+		defer func() {
+			// Quitting before the data loaded leaves nothing worth remembering
+			if !m.loading && m.err == nil {
+				// #nosec G104 - Failing to save only means the next run starts fresh
+				gitservice.SaveViewState(repo, viewStateName, m.viewState())
+			}
+		}()
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	final, err := p.Run()
+	if fm, ok := final.(model); ok {
+		m = fm
+	}
 	return err
 }
@@ -0,0 +1,102 @@
+package historyService
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// DefaultReviewRiskLines is how many changed lines make a commit a review risk when
+// HistoryOptions doesn't say
+const DefaultReviewRiskLines = 500
+
+// reviewRiskStyle marks the commits too large to review well
+var reviewRiskStyle = lipgloss.NewStyle().
+	Foreground(theme.Current.Warning).
+	Bold(true)
+
+// reviewRiskLines returns the changed lines at which a commit is a review risk
+func (opts HistoryOptions) reviewRiskLines() int {
+	if opts.ReviewRiskLines > 0 {
+		return opts.ReviewRiskLines
+	}
+	return DefaultReviewRiskLines
+}
+
+// linesChanged is the size of a commit's diff: the lines it added plus those it deleted
+func linesChanged(commit TimelineCommit) int {
+	return commit.Additions + commit.Deletions
+}
+
+// largestCommits returns the timeline's commits that changed anything, largest diff
+// first. Merges are left out: their diff against the first parent is the whole merged
+// branch, which the merges view already covers.
+func largestCommits(timeline []TimelineCommit) []TimelineCommit {
+	var commits []TimelineCommit
+	for _, commit := range timeline {
+		if commit.IsMerge || (linesChanged(commit) == 0 && len(commit.Files) == 0) {
+			continue
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// reviewRisks counts the commits that changed at least threshold lines
+func reviewRisks(commits []TimelineCommit, threshold int) int {
+	risks := 0
+	for _, commit := range commits {
+		if linesChanged(commit) >= threshold {
+			risks++
+		}
+	}
+	return risks
+}
+
+type largestItem struct {
+	commit TimelineCommit
+	// risky is set when the commit changed enough lines to be a review risk
+	risky bool
+}
+
+func (i largestItem) FilterValue() string { return i.commit.Message }
+func (i largestItem) Title() string {
+	title := fmt.Sprintf("📦 %s %s", i.commit.ShortHash, i.commit.Message)
+	if i.risky {
+		title = reviewRiskStyle.Render("⚠ " + i.commit.ShortHash + " " + i.commit.Message)
+	}
+	return title
+}
+func (i largestItem) Description() string {
+	return fmt.Sprintf("%d lines • +%d -%d • %d files • %s • %s",
+		linesChanged(i.commit), i.commit.Additions, i.commit.Deletions, len(i.commit.Files),
+		i.commit.Author, gitservice.ListDate(i.commit.Date, "2006-01-02 15:04"))
+}
+
+func (m model) renderLargestView() string {
+	var content strings.Builder
+
+	content.WriteString(headerStyle.Render("📦 Largest Commits"))
+	content.WriteString("\n")
+	content.WriteString("Commits ranked by the size of their diff" + m.sortLabel())
+	content.WriteString("\n\n")
+
+	commits := largestCommits(m.analysis.Timeline)
+	if len(commits) == 0 {
+		content.WriteString("No commits with changes found")
+		return content.String()
+	}
+
+	threshold := m.opts.reviewRiskLines()
+	risks := reviewRisks(commits, threshold)
+	content.WriteString(fmt.Sprintf("📦 %s commits with changes • ⚠ %s change %s lines or more (review risk)\n\n",
+		statsStyle.Render(fmt.Sprintf("%d", len(commits))),
+		reviewRiskStyle.Render(fmt.Sprintf("%d", risks)),
+		statsStyle.Render(fmt.Sprintf("%d", threshold))))
+
+	content.WriteString(m.largestList.View())
+	return content.String()
+}
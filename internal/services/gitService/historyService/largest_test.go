@@ -0,0 +1,65 @@
+package historyService
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func TestLargestCommits(t *testing.T) {
+	m := model{
+		largestList: list.New(nil, list.NewDefaultDelegate(), 80, 40),
+		currentView: LargestView,
+		opts:        HistoryOptions{ReviewRiskLines: 100},
+		analysis: HistoryAnalysis{Timeline: []TimelineCommit{
+			{Hash: "c5", Files: []string{"a.go"}, Additions: 30, Deletions: 10},
+			// Merges are covered by the merges view
+			{Hash: "c4", IsMerge: true, Files: []string{"a.go"}, Additions: 900},
+			{Hash: "c3", Files: []string{"a.go", "b.go", "c.go"}, Additions: 5, Deletions: 5},
+			{Hash: "c2", Files: []string{"a.go", "b.go"}, Additions: 80, Deletions: 20},
+			// An empty commit changed nothing
+			{Hash: "c1"},
+		}},
+	}
+
+	hashes := func() string {
+		var hashes []string
+		for _, item := range m.largestList.Items() {
+			item := item.(largestItem)
+			hash := item.commit.Hash
+			if item.risky {
+				hash += "⚠"
+			}
+			hashes = append(hashes, hash)
+		}
+		return strings.Join(hashes, " ")
+	}
+
+	m.updateListItems()
+	if got, want := hashes(), "c2⚠ c5 c3"; got != want {
+		t.Errorf("by lines changed = %s, want %s", got, want)
+	}
+	if risks := reviewRisks(largestCommits(m.analysis.Timeline), 100); risks != 1 {
+		t.Errorf("reviewRisks() = %d, want 1", risks)
+	}
+
+	m.cycleSort()
+	if got, want := hashes(), "c3 c2⚠ c5"; got != want {
+		t.Errorf("by files = %s, want %s", got, want)
+	}
+
+	m.largestList.Select(1)
+	if hash := m.selectedHash(); hash != "c2" {
+		t.Errorf("selectedHash() = %s, want c2", hash)
+	}
+}
+
+func TestReviewRiskLines(t *testing.T) {
+	if got := (HistoryOptions{}).reviewRiskLines(); got != DefaultReviewRiskLines {
+		t.Errorf("default reviewRiskLines() = %d, want %d", got, DefaultReviewRiskLines)
+	}
+	if got := (HistoryOptions{ReviewRiskLines: 50}).reviewRiskLines(); got != 50 {
+		t.Errorf("reviewRiskLines() = %d, want 50", got)
+	}
+}
@@ -0,0 +1,82 @@
+package historyService
+
+import (
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// The orders each list view can be cycled through with s. The first is the order the
+// analysis produces, newest first, except for the largest commits, which are ranked by
+// their diff.
+var (
+	timelineSorts = []gitservice.ListSort[TimelineCommit]{
+		{Name: "newest"},
+		{Name: "oldest", Less: func(a, b TimelineCommit) bool { return a.Date.Before(b.Date) }},
+		{Name: "author", Less: func(a, b TimelineCommit) bool { return a.Author < b.Author }},
+		{Name: "lines changed", Less: func(a, b TimelineCommit) bool {
+			return a.Additions+a.Deletions > b.Additions+b.Deletions
+		}},
+		{Name: "files", Less: func(a, b TimelineCommit) bool { return len(a.Files) > len(b.Files) }},
+	}
+	tagSorts = []gitservice.ListSort[TagInfo]{
+		{Name: "newest"},
+		{Name: "oldest", Less: func(a, b TagInfo) bool { return a.Date.Before(b.Date) }},
+		{Name: "name", Less: func(a, b TagInfo) bool { return a.Name < b.Name }},
+		{Name: "commits since", Less: func(a, b TagInfo) bool { return a.CommitsSince > b.CommitsSince }},
+	}
+	mergeSorts = []gitservice.ListSort[MergeCommit]{
+		{Name: "newest"},
+		{Name: "oldest", Less: func(a, b MergeCommit) bool { return a.Date.Before(b.Date) }},
+		{Name: "author", Less: func(a, b MergeCommit) bool { return a.Author < b.Author }},
+		{Name: "lines changed", Less: func(a, b MergeCommit) bool {
+			return a.Additions+a.Deletions > b.Additions+b.Deletions
+		}},
+		{Name: "files", Less: func(a, b MergeCommit) bool { return a.FilesChanged > b.FilesChanged }},
+	}
+	largestSorts = []gitservice.ListSort[TimelineCommit]{
+		{Name: "lines changed", Less: func(a, b TimelineCommit) bool { return linesChanged(a) > linesChanged(b) }},
+		{Name: "files", Less: func(a, b TimelineCommit) bool { return len(a.Files) > len(b.Files) }},
+	}
+)
+
+// sortNames returns the names of the orders view can be sorted in. The frequency view
+// has no list to sort.
+func sortNames(view ViewMode) []string {
+	switch view {
+	case TimelineView:
+		return gitservice.SortNames(timelineSorts)
+	case TagsView:
+		return gitservice.SortNames(tagSorts)
+	case MergesView:
+		return gitservice.SortNames(mergeSorts)
+	case LargestView:
+		return gitservice.SortNames(largestSorts)
+	default:
+		return nil
+	}
+}
+
+// cycleSort moves the current view to its next order
+func (m *model) cycleSort() {
+	names := sortNames(m.currentView)
+	if len(names) == 0 {
+		return
+	}
+	if m.sortBy == nil {
+		m.sortBy = make(map[ViewMode]int)
+	}
+	m.sortBy[m.currentView] = gitservice.NextSort(m.sortBy[m.currentView], len(names))
+	m.updateListItems()
+	if l := m.activeList(); l != nil {
+		l.ResetSelected()
+	}
+}
+
+// sortLabel returns " • sorted by <order>" for the current view's header, or "" if it
+// can't be re-sorted
+func (m model) sortLabel() string {
+	names := sortNames(m.currentView)
+	if len(names) == 0 {
+		return ""
+	}
+	return " • sorted by " + highlightStyle.Render(names[m.sortBy[m.currentView]])
+}
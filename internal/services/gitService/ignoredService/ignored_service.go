@@ -9,10 +9,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 // IgnoredOptions configures the ignored files display
@@ -35,6 +37,7 @@ type model struct {
 	ignoredFiles []IgnoredFile
 	list         list.Model
 	tuiHelper    *terminal.ResponsiveTUIHelper
+	keys         terminal.KeyMap
 	err          error
 	loading      bool
 	options      IgnoredOptions
@@ -67,23 +70,23 @@ func (i ignoredItem) Description() string {
 // Styles
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#874BFD")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			Bold(true)
 
 	sectionStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			Margin(1, 0)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 
 	ignoredStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#808080")) // Gray for ignored files
+			Foreground(theme.Current.Muted)
 )
 
 // TUI Messages
@@ -128,10 +131,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
-		case "enter":
+		case key.Matches(msg, m.keys.Select):
 			// Open selected file/directory in default editor/file manager
 			if selected := m.list.SelectedItem(); selected != nil {
 				if item, ok := selected.(ignoredItem); ok {
@@ -139,7 +142,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, nil
-		case "e":
+		case msg.String() == "e":
 			// Export to file
 			if m.options.OutputPath != "" {
 				return m, func() tea.Msg {
@@ -196,11 +199,12 @@ func (m model) View() string {
 	sections = append(sections, m.list.View())
 
 	// Help
-	helpText := "↑/↓: navigate • enter: open • e: export • q: quit"
+	export := "e: export"
 	if m.options.OutputPath != "" {
-		helpText = "↑/↓: navigate • enter: open • e: export to " + m.options.OutputPath + " • q: quit"
+		export = "e: export to " + m.options.OutputPath
 	}
-	help := helpStyle.Render(helpText)
+	help := helpStyle.Render(terminal.HelpLine(terminal.HelpKey(m.keys.Up, m.keys.Down)+": navigate",
+		terminal.Help(m.keys.Select, "open"), export, terminal.Help(m.keys.Quit, "quit")))
 	sections = append(sections, help)
 
 	return strings.Join(sections, "\n")
@@ -224,13 +228,17 @@ func RunIgnoredFiles(opts IgnoredOptions) error {
 		return nil
 	}
 
+	if err := terminal.RequireTTY("-o FILE"); err != nil {
+		return err
+	}
+
 	// Otherwise run interactive TUI
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("#874BFD")).
-		BorderLeftForeground(lipgloss.Color("#874BFD"))
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
 	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("#DDDDDD"))
+		Foreground(theme.Current.Text)
 
 	ignoredList := list.New([]list.Item{}, delegate, 0, 0)
 	ignoredList.Title = "Ignored Files"
@@ -242,7 +250,9 @@ func RunIgnoredFiles(opts IgnoredOptions) error {
 		loading:   true,
 		options:   opts,
 		tuiHelper: terminal.NewResponsiveTUIHelper(),
+		keys:      terminal.Keys(),
 	}
+	m.keys.ApplyToList(&m.list)
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	_, err := p.Run()
@@ -7,19 +7,23 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 var (
-	titleStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true).Margin(1, 0)
-	sectionStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
-	valueStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
-	labelStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	titleStyle    = lipgloss.NewStyle().Foreground(theme.Current.Primary).Bold(true).Margin(1, 0)
+	sectionStyle  = lipgloss.NewStyle().Foreground(theme.Current.Primary).Bold(true)
+	valueStyle    = lipgloss.NewStyle().Foreground(theme.Current.Text)
+	labelStyle    = lipgloss.NewStyle().Foreground(theme.Current.Muted)
 	quitTextStyle = lipgloss.NewStyle().Margin(1, 0, 2, 4)
 	contentStyle  = lipgloss.NewStyle().Padding(1, 2)
 )
@@ -39,6 +43,7 @@ type model struct {
 	sections   []SectionItem
 	detailMode bool
 	selected   SectionItem
+	keys       terminal.KeyMap
 	quitting   bool
 }
 
@@ -53,12 +58,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch keypress := msg.String(); keypress {
-		case "q", "ctrl+c":
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			m.quitting = true
 			return m, tea.Quit
 
-		case "enter":
+		case key.Matches(msg, m.keys.Select):
 			if !m.detailMode {
 				if selectedItem := m.list.SelectedItem(); selectedItem != nil {
 					if section, ok := selectedItem.(SectionItem); ok {
@@ -69,7 +74,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-		case "esc":
+		case key.Matches(msg, m.keys.Back):
 			if m.detailMode {
 				m.detailMode = false
 				return m, nil
@@ -97,7 +102,7 @@ func (m model) View() string {
 
 	content := titleStyle.Render("Repository Information")
 	content += "\n\n" + m.list.View()
-	content += "\n\nPress Enter to view section details, q to quit"
+	content += fmt.Sprintf("\n\nPress %s to view section details, %s to quit", terminal.HelpKey(m.keys.Select), terminal.HelpKey(m.keys.Quit))
 
 	return content
 }
@@ -108,7 +113,7 @@ func (m model) renderSectionDetail() string {
 	content.WriteString(titleStyle.Render(m.selected.name))
 	content.WriteString("\n\n")
 	content.WriteString(contentStyle.Render(m.selected.content))
-	content.WriteString("\n\nPress Esc to go back")
+	content.WriteString(fmt.Sprintf("\n\nPress %s to go back", terminal.HelpKey(m.keys.Back)))
 
 	return content.String()
 }
@@ -151,6 +156,10 @@ type CommitInfo struct {
 }
 
 func RunRepoInfoTUI() error {
+	if err := terminal.RequireTTY(""); err != nil {
+		return err
+	}
+
 	stats, err := gatherRepoStats()
 	if err != nil {
 		return err
@@ -177,7 +186,9 @@ func RunRepoInfoTUI() error {
 		list:       l,
 		sections:   sections,
 		detailMode: false,
+		keys:       terminal.Keys(),
 	}
+	m.keys.ApplyToList(&m.list)
 
 	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
 		return err
@@ -187,7 +198,7 @@ func RunRepoInfoTUI() error {
 }
 
 func gatherRepoStats() (*RepoStats, error) {
-	r, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{})
+	r, err := gitservice.OpenRepo("")
 	if err != nil {
 		return nil, err
 	}
@@ -213,8 +224,11 @@ func gatherRepoStats() (*RepoStats, error) {
 
 	// Get branches
 	branches, _ := r.Branches()
-	head, _ := r.Head()
-	currentBranch := head.Name().Short()
+	// A repository without commits has no current branch to mark
+	var currentBranch string
+	if head, err := gitservice.Head(r); err == nil {
+		currentBranch = head.Name().Short()
+	}
 
 	// #nosec G104 - ForEach callback errors are handled by returning nil in all cases
 	branches.ForEach(func(ref *plumbing.Reference) error {
@@ -237,34 +251,36 @@ func gatherRepoStats() (*RepoStats, error) {
 	})
 
 	// Get contributors and commit count
-	commitIter, _ := r.Log(&git.LogOptions{})
 	contributors := make(map[string]*ContributorInfo)
 	commitCount := 0
 
 	var lastCommit *object.Commit
-	// #nosec G104 - ForEach callback errors are handled by returning nil in all cases
-	commitIter.ForEach(func(c *object.Commit) error {
-		if lastCommit == nil {
-			lastCommit = c
-		}
-
-		commitCount++
-		key := c.Author.Email
-		if contrib, exists := contributors[key]; exists {
-			contrib.CommitCount++
-			if c.Author.When.After(contrib.LastCommit) {
-				contrib.LastCommit = c.Author.When
+	// The log fails without commits, leaving the counts at zero
+	if commitIter, err := r.Log(&git.LogOptions{}); err == nil {
+		// #nosec G104 - ForEach callback errors are handled by returning nil in all cases
+		commitIter.ForEach(func(c *object.Commit) error {
+			if lastCommit == nil {
+				lastCommit = c
 			}
-		} else {
-			contributors[key] = &ContributorInfo{
-				Name:        c.Author.Name,
-				Email:       c.Author.Email,
-				CommitCount: 1,
-				LastCommit:  c.Author.When,
+
+			commitCount++
+			key := c.Author.Email
+			if contrib, exists := contributors[key]; exists {
+				contrib.CommitCount++
+				if c.Author.When.After(contrib.LastCommit) {
+					contrib.LastCommit = c.Author.When
+				}
+			} else {
+				contributors[key] = &ContributorInfo{
+					Name:        c.Author.Name,
+					Email:       c.Author.Email,
+					CommitCount: 1,
+					LastCommit:  c.Author.When,
+				}
 			}
-		}
-		return nil
-	})
+			return nil
+		})
+	}
 
 	// Convert contributors map to slice and sort
 	for _, contrib := range contributors {
@@ -0,0 +1,49 @@
+package gitservice
+
+import "fmt"
+
+// CommitLimit caps how many commits an analysis walks. Max 0 means unlimited.
+//
+// Call Take for each commit before processing it; once it returns false the walk
+// should stop (i.e. by returning storer.ErrStop from ForEach). Truncated reports
+// whether any commits were left out, so derived stats can say they are partial.
+type CommitLimit struct {
+	Max       int
+	taken     int
+	truncated bool
+}
+
+// NewCommitLimit creates a limit of max commits, where 0 or less means unlimited.
+func NewCommitLimit(max int) *CommitLimit {
+	if max < 0 {
+		max = 0
+	}
+	return &CommitLimit{Max: max}
+}
+
+// Take reports whether another commit may be processed.
+func (l *CommitLimit) Take() bool {
+	if l == nil || l.Max == 0 {
+		return true
+	}
+	if l.taken >= l.Max {
+		l.truncated = true
+		return false
+	}
+	l.taken++
+	return true
+}
+
+// Truncated reports whether the walk stopped before the end of history.
+func (l *CommitLimit) Truncated() bool {
+	return l != nil && l.truncated
+}
+
+// Note describes a truncated walk, like "limited to the last 500 commits", or
+// returns "" when the whole history was walked.
+func (l *CommitLimit) Note() string {
+	if !l.Truncated() {
+		return ""
+	}
+	return fmt.Sprintf("limited to the last %s commits", formatCount(l.Max))
+}
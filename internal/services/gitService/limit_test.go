@@ -0,0 +1,45 @@
+package gitservice
+
+import "testing"
+
+func TestCommitLimit(t *testing.T) {
+	tests := []struct {
+		max       int
+		commits   int
+		taken     int
+		truncated bool
+		note      string
+	}{
+		{max: 0, commits: 10, taken: 10},
+		{max: -1, commits: 10, taken: 10},
+		{max: 10, commits: 10, taken: 10},
+		{max: 3, commits: 10, taken: 3, truncated: true, note: "limited to the last 3 commits"},
+		{max: 1500, commits: 2000, taken: 1500, truncated: true, note: "limited to the last 1,500 commits"},
+	}
+	for _, tt := range tests {
+		limit := NewCommitLimit(tt.max)
+		taken := 0
+		for i := 0; i < tt.commits; i++ {
+			if !limit.Take() {
+				break
+			}
+			taken++
+		}
+		if taken != tt.taken || limit.Truncated() != tt.truncated || limit.Note() != tt.note {
+			t.Errorf("limit %d over %d commits: took %d, truncated %v, note %q; want %d, %v, %q",
+				tt.max, tt.commits, taken, limit.Truncated(), limit.Note(), tt.taken, tt.truncated, tt.note)
+		}
+	}
+}
+
+func TestProgressCountCommitsLimit(t *testing.T) {
+	repo := newStatsTestRepo(t, 20)
+
+	for limit, want := range map[int]int{0: 20, 5: 5, 20: 20, 50: 20} {
+		p := NewProgress()
+		p.CountCommits(repo.Repository, repo.Head(), limit)
+		if p.total != want {
+			t.Errorf("CountCommits(limit %d) total = %d, want %d", limit, p.total, want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package gitservice
+
+import "sort"
+
+// ListSort is an order a TUI list can be shown in. Less reports whether a comes before
+// b; a nil Less keeps the order the analysis produced.
+type ListSort[T any] struct {
+	Name string
+	Less func(a, b T) bool
+}
+
+// SortList returns a copy of items in order. The sort is stable, so items that compare
+// equal keep the analysis order.
+func SortList[T any](items []T, order ListSort[T]) []T {
+	sorted := append([]T(nil), items...)
+	if order.Less != nil {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return order.Less(sorted[i], sorted[j])
+		})
+	}
+	return sorted
+}
+
+// NextSort returns the index of the order after current in a list of n orders, wrapping
+// back to the first
+func NextSort(current, n int) int {
+	if n == 0 {
+		return 0
+	}
+	return (current + 1) % n
+}
+
+// SortNames returns the names of orders, for headers and help
+func SortNames[T any](orders []ListSort[T]) []string {
+	names := make([]string, len(orders))
+	for i, order := range orders {
+		names[i] = order.Name
+	}
+	return names
+}
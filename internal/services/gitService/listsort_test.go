@@ -0,0 +1,40 @@
+package gitservice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortList(t *testing.T) {
+	type file struct {
+		name string
+		size int
+	}
+	files := []file{{"b", 3}, {"a", 1}, {"c", 3}}
+	orders := []ListSort[file]{
+		{Name: "size"},
+		{Name: "name", Less: func(a, b file) bool { return a.name < b.name }},
+		{Name: "smallest", Less: func(a, b file) bool { return a.size < b.size }},
+	}
+
+	if got := SortList(files, orders[0]); !reflect.DeepEqual(got, files) {
+		t.Errorf("default order = %v, want the analysis order %v", got, files)
+	}
+	if got, want := SortList(files, orders[1]), []file{{"a", 1}, {"b", 3}, {"c", 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("by name = %v, want %v", got, want)
+	}
+	// Ties keep the analysis order, and the input is left alone
+	if got, want := SortList(files, orders[2]), []file{{"a", 1}, {"b", 3}, {"c", 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("by size = %v, want %v", got, want)
+	}
+	if files[0].name != "b" {
+		t.Errorf("SortList modified its input: %v", files)
+	}
+
+	if got := NextSort(2, len(orders)); got != 0 {
+		t.Errorf("NextSort(2, 3) = %d, want 0", got)
+	}
+	if got := SortNames(orders); !reflect.DeepEqual(got, []string{"size", "name", "smallest"}) {
+		t.Errorf("SortNames() = %v", got)
+	}
+}
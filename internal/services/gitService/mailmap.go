@@ -0,0 +1,162 @@
+package gitservice
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// Mailmap canonicalizes author identities using git's .mailmap format.
+// A nil *Mailmap is valid and leaves identities unchanged.
+type Mailmap struct {
+	byEmail     map[string]mailmapEntry
+	byNameEmail map[string]mailmapEntry
+}
+
+type mailmapEntry struct {
+	name  string
+	email string
+}
+
+// LoadMailmap loads the .mailmap in repoPath (if set and present) followed by extraFile
+// (if set). Entries from extraFile take precedence.
+func LoadMailmap(repoPath, extraFile string) (*Mailmap, error) {
+	mailmap := &Mailmap{
+		byEmail:     make(map[string]mailmapEntry),
+		byNameEmail: make(map[string]mailmapEntry),
+	}
+
+	if repoPath != "" {
+		repoMailmap := filepath.Join(repoPath, ".mailmap")
+		if file, err := os.Open(repoMailmap); err == nil {
+			defer file.Close()
+
+			if err := mailmap.parse(file); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", repoMailmap, err)
+			}
+		}
+	}
+
+	if extraFile != "" {
+		file, err := os.Open(extraFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open mailmap: %w", err)
+		}
+		defer file.Close()
+
+		if err := mailmap.parse(file); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", extraFile, err)
+		}
+	}
+
+	return mailmap, nil
+}
+
+// ParseMailmap parses mailmap entries from r
+func ParseMailmap(r io.Reader) (*Mailmap, error) {
+	mailmap := &Mailmap{
+		byEmail:     make(map[string]mailmapEntry),
+		byNameEmail: make(map[string]mailmapEntry),
+	}
+	if err := mailmap.parse(r); err != nil {
+		return nil, err
+	}
+	return mailmap, nil
+}
+
+// Canonicalize returns the canonical name and email for a commit identity
+func (m *Mailmap) Canonicalize(name, email string) (string, string) {
+	if m == nil {
+		return name, email
+	}
+
+	entry, ok := m.byNameEmail[mailmapKey(name, email)]
+	if !ok {
+		entry, ok = m.byEmail[strings.ToLower(email)]
+	}
+	if !ok {
+		return name, email
+	}
+
+	if entry.name != "" {
+		name = entry.name
+	}
+	if entry.email != "" {
+		email = entry.email
+	}
+	return name, email
+}
+
+func (m *Mailmap) parse(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.IndexByte(line, '#'); idx != -1 {
+			line = line[:idx]
+		}
+
+		properName, properEmail, rest, ok := splitMailmapIdentity(line)
+		if !ok {
+			continue
+		}
+
+		commitName, commitEmail, _, hasCommitEmail := splitMailmapIdentity(rest)
+		if !hasCommitEmail {
+			// "Proper Name <commit@email>" only replaces the name
+			m.byEmail[strings.ToLower(properEmail)] = mailmapEntry{name: properName}
+			continue
+		}
+
+		entry := mailmapEntry{name: properName, email: properEmail}
+		if commitName != "" {
+			m.byNameEmail[mailmapKey(commitName, commitEmail)] = entry
+		} else {
+			m.byEmail[strings.ToLower(commitEmail)] = entry
+		}
+	}
+
+	return scanner.Err()
+}
+
+// splitMailmapIdentity reads "Name <email>" from the start of s and returns the remainder
+func splitMailmapIdentity(s string) (name, email, rest string, ok bool) {
+	open := strings.IndexByte(s, '<')
+	if open == -1 {
+		return "", "", s, false
+	}
+	closing := strings.IndexByte(s[open:], '>')
+	if closing == -1 {
+		return "", "", s, false
+	}
+	closing += open
+
+	return strings.TrimSpace(s[:open]), strings.TrimSpace(s[open+1 : closing]), s[closing+1:], true
+}
+
+func mailmapKey(name, email string) string {
+	return strings.ToLower(name) + "\x00" + strings.ToLower(email)
+}
+
+// LoadRepoMailmap is LoadMailmap for an opened repository, reading .mailmap from
+// its working tree root rather than the current directory. A bare repository has no
+// working tree, so no .mailmap: only extraFile is read, and without one the mailmap is
+// nil, which leaves identities as they are.
+func LoadRepoMailmap(repo *git.Repository, extraFile string) (*Mailmap, error) {
+	root, err := RepoRoot(repo)
+	if errors.Is(err, git.ErrIsBareRepository) {
+		if extraFile == "" {
+			return nil, nil
+		}
+		return LoadMailmap("", extraFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve repository root: %w", err)
+	}
+	return LoadMailmap(root, extraFile)
+}
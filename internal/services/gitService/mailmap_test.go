@@ -0,0 +1,129 @@
+package gitservice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+const testMailmap = `# Collapse two addresses into one identity
+Jane Doe <jane@example.com> <jane.doe@old-company.com>
+Jane Doe <jane@example.com> jdoe <jdoe@laptop.local>
+<ops@example.com> <root@buildbox>
+Bob Builder <bob@example.com>
+`
+
+func TestMailmapCanonicalize(t *testing.T) {
+	mailmap, err := ParseMailmap(strings.NewReader(testMailmap))
+	if err != nil {
+		t.Fatalf("ParseMailmap() error: %v", err)
+	}
+
+	tests := []struct {
+		name, email         string
+		wantName, wantEmail string
+	}{
+		{"Jane D", "Jane.Doe@Old-Company.com", "Jane Doe", "jane@example.com"},
+		{"jdoe", "jdoe@laptop.local", "Jane Doe", "jane@example.com"},
+		{"someone", "jdoe@laptop.local", "someone", "jdoe@laptop.local"},
+		{"root", "root@buildbox", "root", "ops@example.com"},
+		{"bob", "bob@example.com", "Bob Builder", "bob@example.com"},
+		{"Unmapped", "x@example.com", "Unmapped", "x@example.com"},
+	}
+	for _, tt := range tests {
+		gotName, gotEmail := mailmap.Canonicalize(tt.name, tt.email)
+		if gotName != tt.wantName || gotEmail != tt.wantEmail {
+			t.Errorf("Canonicalize(%q, %q) = (%q, %q), want (%q, %q)",
+				tt.name, tt.email, gotName, gotEmail, tt.wantName, tt.wantEmail)
+		}
+	}
+}
+
+func TestMailmapCollapsesIdentities(t *testing.T) {
+	mailmap, err := ParseMailmap(strings.NewReader(testMailmap))
+	if err != nil {
+		t.Fatalf("ParseMailmap() error: %v", err)
+	}
+
+	identities := make(map[string]int)
+	for _, commit := range [][2]string{
+		{"Jane Doe", "jane@example.com"},
+		{"Jane Doe", "jane.doe@old-company.com"},
+		{"jdoe", "jdoe@laptop.local"},
+	} {
+		name, email := mailmap.Canonicalize(commit[0], commit[1])
+		identities[name+" <"+email+">"]++
+	}
+
+	if len(identities) != 1 || identities["Jane Doe <jane@example.com>"] != 3 {
+		t.Errorf("expected a single identity with 3 commits, got %v", identities)
+	}
+}
+
+func TestLoadMailmap(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".mailmap"), []byte(testMailmap), 0o600); err != nil {
+		t.Fatalf("failed to write .mailmap: %v", err)
+	}
+	extra := filepath.Join(dir, "extra.mailmap")
+	if err := os.WriteFile(extra, []byte("Robert Builder <bob@example.com>\n"), 0o600); err != nil {
+		t.Fatalf("failed to write extra mailmap: %v", err)
+	}
+
+	mailmap, err := LoadMailmap(dir, extra)
+	if err != nil {
+		t.Fatalf("LoadMailmap() error: %v", err)
+	}
+
+	// Extra file overrides the repository .mailmap
+	if name, _ := mailmap.Canonicalize("bob", "bob@example.com"); name != "Robert Builder" {
+		t.Errorf("expected extra mailmap to take precedence, got %q", name)
+	}
+
+	if _, err := LoadMailmap(dir, filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing mailmap file")
+	}
+}
+
+func TestLoadRepoMailmapBare(t *testing.T) {
+	repo, path := gittest.NewBare(t, "repo.git")
+	dir := filepath.Dir(path)
+	// A .mailmap in the current directory isn't the repository's
+	t.Chdir(dir)
+	if err := os.WriteFile(".mailmap", []byte(testMailmap), 0o600); err != nil {
+		t.Fatalf("failed to write .mailmap: %v", err)
+	}
+
+	mailmap, err := LoadRepoMailmap(repo, "")
+	if err != nil {
+		t.Fatalf("LoadRepoMailmap() error: %v", err)
+	}
+	if mailmap != nil {
+		t.Error("expected no mailmap for a bare repository")
+	}
+
+	extra := filepath.Join(dir, "extra.mailmap")
+	if err := os.WriteFile(extra, []byte("Robert Builder <bob@example.com>\n"), 0o600); err != nil {
+		t.Fatalf("failed to write extra mailmap: %v", err)
+	}
+	mailmap, err = LoadRepoMailmap(repo, extra)
+	if err != nil {
+		t.Fatalf("LoadRepoMailmap() with extra file error: %v", err)
+	}
+	if name, _ := mailmap.Canonicalize("bob", "bob@example.com"); name != "Robert Builder" {
+		t.Errorf("expected the extra mailmap to apply, got %q", name)
+	}
+	if name, _ := mailmap.Canonicalize("jdoe", "jdoe@laptop.local"); name != "jdoe" {
+		t.Errorf("expected the current directory's .mailmap to be skipped, got %q", name)
+	}
+}
+
+func TestNilMailmap(t *testing.T) {
+	var mailmap *Mailmap
+	if name, email := mailmap.Canonicalize("a", "b"); name != "a" || email != "b" {
+		t.Errorf("nil Mailmap changed identity to (%q, %q)", name, email)
+	}
+}
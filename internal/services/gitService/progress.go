@@ -0,0 +1,209 @@
+package gitservice
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// progressEvery is how many commits are processed between progress updates
+const progressEvery = 100
+
+// ProgressMsg reports how many commits a running analysis has processed. Total is 0
+// when the commit count is not known.
+type ProgressMsg struct {
+	Done  int
+	Total int
+}
+
+// String formats the progress like "processed 1,200/8,000 commits".
+func (p ProgressMsg) String() string {
+	if p.Total > 0 {
+		return fmt.Sprintf("processed %s/%s commits", formatCount(p.Done), formatCount(p.Total))
+	}
+	return fmt.Sprintf("processed %s commits", formatCount(p.Done))
+}
+
+// Progress streams ProgressMsg updates from an analysis goroutine to a Bubble Tea
+// program. The analysis calls Commit for each commit it walks and Close when it
+// finishes; the TUI re-issues Wait after every ProgressMsg it receives.
+//
+// A nil *Progress is valid and discards updates, so analyses can also run headless.
+type Progress struct {
+	mu     sync.Mutex
+	ch     chan ProgressMsg
+	done   int
+	total  int
+	closed bool
+}
+
+// NewProgress creates a progress stream.
+func NewProgress() *Progress {
+	return &Progress{ch: make(chan ProgressMsg, 1)}
+}
+
+// CountCommits sets the expected total to the number of commits reachable from hash, up
+// to limit for analyses that stop early. The walk stops at the limit, so a short
+// analysis of a long history doesn't count all of it. A limit of 0 counts every commit.
+// Walking commit objects is cheap next to diffing them, so this is done up front to
+// give a meaningful "n/total" display.
+func (p *Progress) CountCommits(repo *git.Repository, hash plumbing.Hash, limit int) {
+	if p == nil {
+		return
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: hash})
+	if err != nil {
+		return
+	}
+
+	total := 0
+	// #nosec G104 - An incomplete count only affects the progress display
+	iter.ForEach(func(*object.Commit) error {
+		total++
+		if limit > 0 && total >= limit {
+			return storer.ErrStop
+		}
+		return nil
+	})
+
+	p.mu.Lock()
+	p.total = total
+	p.mu.Unlock()
+}
+
+// Commit records one processed commit, sending an update every progressEvery commits.
+func (p *Progress) Commit() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	if p.closed || p.done%progressEvery != 0 {
+		return
+	}
+
+	// Drop the update if the TUI hasn't consumed the previous one yet
+	select {
+	case p.ch <- ProgressMsg{Done: p.done, Total: p.total}:
+	default:
+	}
+}
+
+// Close ends the stream. It is safe to call more than once.
+func (p *Progress) Close() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.closed {
+		p.closed = true
+		close(p.ch)
+	}
+}
+
+// isClosed reports whether the stream has ended.
+func (p *Progress) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.closed
+}
+
+// Wait returns a command that delivers the next ProgressMsg, or nothing once the
+// stream is closed.
+func (p *Progress) Wait() tea.Cmd {
+	if p == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		msg, ok := <-p.ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// Loading is what an analysis TUI shows while it loads: a spinner and the latest update
+// from the progress stream the analysis reports to. The TUI batches Init with the command
+// that runs the analysis, and hands Update the spinner.TickMsg and ProgressMsg messages.
+type Loading struct {
+	// Progress is the stream the analysis reports to
+	Progress *Progress
+
+	spinner spinner.Model
+	last    ProgressMsg
+}
+
+// NewLoading creates a loading spinner with a fresh progress stream.
+func NewLoading() Loading {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(theme.Current.Accent)
+
+	return Loading{Progress: NewProgress(), spinner: s}
+}
+
+// Init starts the spinner and waits for the first progress update.
+func (l Loading) Init() tea.Cmd {
+	return tea.Batch(l.spinner.Tick, l.Progress.Wait())
+}
+
+// Update records a progress update and waits for the next, or advances the spinner. The
+// spinner stops once the analysis closes its progress stream.
+func (l Loading) Update(msg tea.Msg) (Loading, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ProgressMsg:
+		l.last = msg
+		return l, l.Progress.Wait()
+
+	case spinner.TickMsg:
+		if l.Progress.isClosed() {
+			return l, nil
+		}
+		var cmd tea.Cmd
+		l.spinner, cmd = l.spinner.Update(msg)
+		return l, cmd
+	}
+	return l, nil
+}
+
+// View renders the spinner followed by label and, once the analysis has reported any,
+// its progress on the next line in style.
+func (l Loading) View(label string, style lipgloss.Style) string {
+	view := l.spinner.View() + " " + label
+	if l.last.Done > 0 {
+		view += "\n" + style.Render(l.last.String())
+	}
+	return view
+}
+
+// formatCount formats n with thousands separators.
+func formatCount(n int) string {
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package gitservice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestProgressMsgString(t *testing.T) {
+	tests := []struct {
+		msg  ProgressMsg
+		want string
+	}{
+		{ProgressMsg{Done: 0}, "processed 0 commits"},
+		{ProgressMsg{Done: 1200}, "processed 1,200 commits"},
+		{ProgressMsg{Done: 1200, Total: 8000}, "processed 1,200/8,000 commits"},
+		{ProgressMsg{Done: 999, Total: 1234567}, "processed 999/1,234,567 commits"},
+	}
+	for _, tt := range tests {
+		if got := tt.msg.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestProgressStream(t *testing.T) {
+	repo := newStatsTestRepo(t, 250)
+
+	p := NewProgress()
+	p.CountCommits(repo.Repository, repo.Head(), 0)
+
+	wait := p.Wait()
+	for i := 0; i < progressEvery; i++ {
+		p.Commit()
+	}
+	if msg, ok := wait().(ProgressMsg); !ok || msg.Done != progressEvery || msg.Total != 250 {
+		t.Errorf("Wait() = %+v, want Done=%d Total=250", msg, progressEvery)
+	}
+
+	p.Close()
+	p.Close()
+	p.Commit()
+	if msg := p.Wait()(); msg != nil {
+		t.Errorf("Wait() after Close = %v, want nil", msg)
+	}
+}
+
+func TestNilProgress(t *testing.T) {
+	var p *Progress
+	p.Commit()
+	p.Close()
+	if cmd := p.Wait(); cmd != nil {
+		t.Error("nil Progress Wait() should return a nil command")
+	}
+}
+
+func TestLoading(t *testing.T) {
+	l := NewLoading()
+	if view := l.View("Loading...", lipgloss.NewStyle()); strings.Contains(view, "\n") || !strings.HasSuffix(view, " Loading...") {
+		t.Errorf("View() before any progress = %q, want only the spinner and label", view)
+	}
+
+	l, _ = l.Update(ProgressMsg{Done: 1200, Total: 8000})
+	if view := l.View("Loading...", lipgloss.NewStyle()); !strings.HasSuffix(view, " Loading...\nprocessed 1,200/8,000 commits") {
+		t.Errorf("View() = %q, want the progress below the label", view)
+	}
+
+	if _, cmd := l.Update(spinner.TickMsg{}); cmd == nil {
+		t.Error("Update(TickMsg) while loading should schedule the next tick")
+	}
+	l.Progress.Close()
+	if _, cmd := l.Update(spinner.TickMsg{}); cmd != nil {
+		t.Error("Update(TickMsg) after the stream closed should stop the spinner")
+	}
+}
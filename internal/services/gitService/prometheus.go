@@ -0,0 +1,93 @@
+package gitservice
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PromMetric is a gauge written in the Prometheus text format by --format prom. Metric
+// names are what dashboards and alerts query, so once released they must not change.
+type PromMetric struct {
+	Name    string
+	Help    string
+	Samples []PromSample
+}
+
+// PromSample is one value of a metric, with the labels that tell it from the others
+type PromSample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Gauge returns a metric with a single unlabeled sample
+func Gauge(name, help string, value float64) PromMetric {
+	return PromMetric{Name: name, Help: help, Samples: []PromSample{{Value: value}}}
+}
+
+// WritePrometheus writes metrics in the Prometheus text format, as read by the textfile
+// collector of node_exporter. Every sample is labeled with repo, so the files of several
+// repositories can be collected side by side. Metrics without samples are left out.
+func WritePrometheus(w io.Writer, repo string, metrics []PromMetric) error {
+	var b strings.Builder
+	for _, metric := range metrics {
+		if len(metric.Samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", metric.Name, escapePromHelp(metric.Help))
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", metric.Name)
+		for _, sample := range metric.Samples {
+			b.WriteString(metric.Name)
+			b.WriteString(promLabels(repo, sample.Labels))
+			b.WriteString(" ")
+			b.WriteString(strconv.FormatFloat(sample.Value, 'g', -1, 64))
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// PromRepoName is the repo label of the metrics of the repository at path: the name of
+// its root directory
+func PromRepoName(path string) string {
+	if repo, err := OpenRepo(path); err == nil {
+		if root, err := RepoRoot(repo); err == nil {
+			return filepath.Base(root)
+		}
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return filepath.Base(abs)
+}
+
+// promLabels formats a sample's labels, repo first and the others by name
+func promLabels(repo string, labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := []string{`repo="` + escapePromLabel(repo) + `"`}
+	for _, name := range names {
+		pairs = append(pairs, name+`="`+escapePromLabel(labels[name])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// escapePromLabel escapes a label value, in which backslashes, quotes and newlines are special
+func escapePromLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// escapePromHelp escapes a HELP line, in which backslashes and newlines are special
+func escapePromHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
@@ -0,0 +1,38 @@
+package gitservice
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	metrics := []PromMetric{
+		Gauge("git_total_commits", "Commits in the analyzed history.", 42),
+		{
+			Name: "git_author_commits",
+			Help: "Commits by author.\nOne sample per author.",
+			Samples: []PromSample{
+				{Labels: map[string]string{"author": `Ann "the \ dev"`}, Value: 30},
+				{Labels: map[string]string{"author": "Bob", "bot": "false"}, Value: 12.5},
+			},
+		},
+		{Name: "git_empty", Help: "Left out without samples."},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePrometheus(&buf, "syst", metrics); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP git_total_commits Commits in the analyzed history.
+# TYPE git_total_commits gauge
+git_total_commits{repo="syst"} 42
+# HELP git_author_commits Commits by author.\nOne sample per author.
+# TYPE git_author_commits gauge
+git_author_commits{repo="syst",author="Ann \"the \\ dev\""} 30
+git_author_commits{repo="syst",author="Bob",bot="false"} 12.5
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WritePrometheus() =\n%s\nwant\n%s", got, want)
+	}
+}
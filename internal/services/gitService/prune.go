@@ -24,13 +24,19 @@ func PruneBranches(mainBranch string, confirm bool, force bool, dryRun bool) err
 	if err != nil {
 		return err
 	}
+	// Restoring a detached HEAD afterwards would check out a branch instead
+	if currentBranch == "HEAD" {
+		return ErrDetachedHead
+	}
 
 	var deleted []string
 	var skipped []string
+	// The commit each deleted branch pointed at, for the undo hints
+	hashes := make(map[string]string)
 
 	defer func() {
 		_ = checkoutBranch(currentBranch)
-		printPruneResults(deleted, skipped, dryRun)
+		printPruneResults(deleted, skipped, hashes, dryRun)
 	}()
 
 	if err := checkoutBranch(mainBranch); err != nil {
@@ -59,24 +65,30 @@ func PruneBranches(mainBranch string, confirm bool, force bool, dryRun bool) err
 
 	for _, branch := range branchesToDelete {
 		if confirm {
-			if !promptUser(reader, branch) {
+			if !Confirm(reader, fmt.Sprintf("Delete branch %s?", branch)) {
 				fmt.Printf("Skipping %s\n", branch)
 				skipped = append(skipped, branch)
 				continue
 			}
 		}
 
+		hash, err := branchHash(branch)
+		if err != nil {
+			fmt.Printf("Failed to delete %s: %v\n", branch, err)
+			continue
+		}
 		if err := deleteBranch(branch, force); err != nil {
 			fmt.Printf("Failed to delete %s: %v\n", branch, err)
 		} else {
 			deleted = append(deleted, branch)
+			hashes[branch] = hash
 		}
 	}
 
 	return nil
 }
 
-func printPruneResults(deleted, skipped []string, dryRun bool) {
+func printPruneResults(deleted, skipped []string, hashes map[string]string, dryRun bool) {
 	if dryRun {
 		fmt.Println("\nDry run – branches that would be deleted:")
 		for _, b := range deleted {
@@ -91,6 +103,10 @@ func printPruneResults(deleted, skipped []string, dryRun bool) {
 		for _, b := range deleted {
 			fmt.Printf("  - %s\n", b)
 		}
+		fmt.Println("\nTo restore a branch:")
+		for _, b := range deleted {
+			fmt.Printf("  %s\n", UndoBranchHint(b, hashes[b]))
+		}
 	} else {
 		fmt.Println("\nNo branches were deleted.")
 	}
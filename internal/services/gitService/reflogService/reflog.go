@@ -0,0 +1,249 @@
+package reflogService
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// ReflogOptions controls the reflog viewer
+type ReflogOptions struct {
+	// RepoPath is the repository to read; empty means the current directory
+	RepoPath string
+	// NoCache skips the on-disk commit stats cache when showing commit details
+	NoCache bool
+}
+
+// ReflogEntry is one move of a ref, like a commit, checkout or reset
+type ReflogEntry struct {
+	// Ref is the ref that moved, like "HEAD" or "main"
+	Ref string
+	// Index is n in Ref@{n}; 0 is the most recent entry
+	Index   int
+	OldHash plumbing.Hash
+	NewHash plumbing.Hash
+	Name    string
+	Email   string
+	Date    time.Time
+	// Action is the kind of move, like "commit", "checkout" or "reset"
+	Action  string
+	Message string
+	// Lost is set when NewHash is not reachable from any branch, tag or HEAD, so the
+	// reflog is the only way back to it
+	Lost bool
+}
+
+// Selector returns the revision syntax for the entry, like HEAD@{2}.
+func (e ReflogEntry) Selector() string {
+	return fmt.Sprintf("%s@{%d}", e.Ref, e.Index)
+}
+
+// gitDir returns the repository's .git directory.
+func gitDir(repo *git.Repository) (string, error) {
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", fmt.Errorf("reflogs are only available for repositories on disk")
+	}
+	return storage.Filesystem().Root(), nil
+}
+
+// loadReflogs reads the reflogs of HEAD and every local branch. It returns the refs in
+// display order (HEAD first, then branches alphabetically) and their entries, newest first.
+func loadReflogs(repo *git.Repository) ([]string, map[string][]ReflogEntry, error) {
+	dir, err := gitDir(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := map[string]string{"HEAD": filepath.Join(dir, "logs", "HEAD")}
+	headsDir := filepath.Join(dir, "logs", "refs", "heads")
+	err = filepath.WalkDir(headsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			name, err := filepath.Rel(headsDir, path)
+			if err != nil {
+				return err
+			}
+			files[filepath.ToSlash(name)] = path
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("failed to list branch reflogs: %w", err)
+	}
+
+	reachable, err := reachableCommits(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var refs []string
+	reflogs := make(map[string][]ReflogEntry)
+	for ref, path := range files {
+		entries, err := readReflogFile(path, ref)
+		if errors.Is(err, fs.ErrNotExist) || (err == nil && len(entries) == 0) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i := range entries {
+			entries[i].Lost = !entries[i].NewHash.IsZero() && !reachable[entries[i].NewHash]
+		}
+		reflogs[ref] = entries
+		refs = append(refs, ref)
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i] == "HEAD" || refs[j] == "HEAD" {
+			return refs[i] == "HEAD"
+		}
+		return refs[i] < refs[j]
+	})
+
+	return refs, reflogs, nil
+}
+
+func readReflogFile(path, ref string) ([]ReflogEntry, error) {
+	// #nosec G304 - The path is built from the repository's own .git directory
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseReflog(f, ref)
+}
+
+// parseReflog parses a reflog file, whose lines are oldest first and look like
+// "<old> <new> Name <email> <unix time> <tz>\t<message>". Entries are returned newest first.
+func parseReflog(r io.Reader, ref string) ([]ReflogEntry, error) {
+	var entries []ReflogEntry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		entry, err := parseReflogLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s reflog line %q: %w", ref, line, err)
+		}
+		entry.Ref = ref
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Newest first, numbered like ref@{n}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	for i := range entries {
+		entries[i].Index = i
+	}
+
+	return entries, nil
+}
+
+func parseReflogLine(line string) (ReflogEntry, error) {
+	header, message, _ := strings.Cut(line, "\t")
+
+	fields := strings.SplitN(header, " ", 3)
+	if len(fields) < 3 || len(fields[0]) != 40 || len(fields[1]) != 40 {
+		return ReflogEntry{}, fmt.Errorf("missing hashes")
+	}
+
+	// The identity is "Name <email> <unix time> <tz>"
+	identity := fields[2]
+	emailStart := strings.LastIndex(identity, "<")
+	emailEnd := strings.LastIndex(identity, ">")
+	if emailStart < 0 || emailEnd < emailStart {
+		return ReflogEntry{}, fmt.Errorf("missing identity")
+	}
+
+	when := strings.Fields(identity[emailEnd+1:])
+	if len(when) != 2 {
+		return ReflogEntry{}, fmt.Errorf("missing timestamp")
+	}
+	seconds, err := strconv.ParseInt(when[0], 10, 64)
+	if err != nil {
+		return ReflogEntry{}, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	date := time.Unix(seconds, 0)
+	if tz, err := time.Parse("-0700", when[1]); err == nil {
+		date = date.In(tz.Location())
+	}
+
+	return ReflogEntry{
+		OldHash: plumbing.NewHash(fields[0]),
+		NewHash: plumbing.NewHash(fields[1]),
+		Name:    strings.TrimSpace(identity[:emailStart]),
+		Email:   identity[emailStart+1 : emailEnd],
+		Date:    date,
+		Action:  reflogAction(message),
+		Message: message,
+	}, nil
+}
+
+// reflogAction returns the kind of move from a reflog message like "reset: moving to
+// HEAD~1" or "commit (amend): Fix typo".
+func reflogAction(message string) string {
+	action, _, found := strings.Cut(message, ":")
+	if !found {
+		return "other"
+	}
+
+	action = strings.TrimSpace(action)
+	// "rebase (finish)", "commit (amend)", "pull --rebase (start)" and so on
+	if base, detail, ok := strings.Cut(action, " ("); ok {
+		detail = strings.TrimSuffix(detail, ")")
+		if base == "commit" {
+			return "commit " + detail
+		}
+		action = base
+	}
+	if fields := strings.Fields(action); len(fields) > 0 {
+		action = fields[0]
+	}
+
+	return action
+}
+
+// reachableCommits returns the commits reachable from any ref.
+func reachableCommits(repo *git.Repository) (map[plumbing.Hash]bool, error) {
+	reachable := make(map[plumbing.Hash]bool)
+
+	cIter, err := repo.Log(&git.LogOptions{All: true})
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return reachable, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log: %w", err)
+	}
+
+	err = cIter.ForEach(func(c *object.Commit) error {
+		reachable[c.Hash] = true
+		return nil
+	})
+	return reachable, err
+}
@@ -0,0 +1,133 @@
+package reflogService
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+const (
+	hashA = "1111111111111111111111111111111111111111"
+	hashB = "2222222222222222222222222222222222222222"
+	hashC = "3333333333333333333333333333333333333333"
+	zero  = "0000000000000000000000000000000000000000"
+)
+
+func TestParseReflog(t *testing.T) {
+	log := strings.Join([]string{
+		zero + " " + hashA + " Test User <test@example.com> 1767268800 +0000\tcommit (initial): first",
+		hashA + " " + hashB + " Test User <test@example.com> 1767272400 +0200\tcommit: second",
+		hashB + " " + hashA + " Test User <test@example.com> 1767276000 +0000\treset: moving to HEAD~1",
+		"",
+	}, "\n")
+
+	entries, err := parseReflog(strings.NewReader(log), "HEAD")
+	if err != nil {
+		t.Fatalf("parseReflog() error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	newest := entries[0]
+	if newest.Selector() != "HEAD@{0}" || newest.Action != "reset" || newest.Message != "reset: moving to HEAD~1" {
+		t.Errorf("newest entry = %+v, want HEAD@{0} reset", newest)
+	}
+	if newest.OldHash != plumbing.NewHash(hashB) || newest.NewHash != plumbing.NewHash(hashA) {
+		t.Errorf("newest hashes = %s → %s", newest.OldHash, newest.NewHash)
+	}
+
+	second := entries[1]
+	if second.Name != "Test User" || second.Email != "test@example.com" {
+		t.Errorf("identity = %q <%q>", second.Name, second.Email)
+	}
+	if _, offset := second.Date.Zone(); offset != 2*60*60 || second.Date.Unix() != 1767272400 {
+		t.Errorf("date = %v, want 1767272400 at +0200", second.Date)
+	}
+
+	if entries[2].Index != 2 || entries[2].Action != "commit initial" {
+		t.Errorf("oldest entry = %+v, want HEAD@{2} commit initial", entries[2])
+	}
+
+	if _, err := parseReflog(strings.NewReader("not a reflog line\n"), "HEAD"); err == nil {
+		t.Error("parseReflog() with a malformed line should fail")
+	}
+}
+
+func TestReflogAction(t *testing.T) {
+	tests := []struct {
+		message, want string
+	}{
+		{"commit: Add feature", "commit"},
+		{"commit (amend): Add feature", "commit amend"},
+		{"commit (merge): Merge branch 'dev'", "commit merge"},
+		{"checkout: moving from main to dev", "checkout"},
+		{"reset: moving to HEAD~1", "reset"},
+		{"rebase (finish): returning to refs/heads/dev", "rebase"},
+		{"rebase -i (start): checkout main", "rebase"},
+		{"pull --rebase (pick): Fix bug", "pull"},
+		{"merge dev: Fast-forward", "merge"},
+		{"cherry-pick: Fix bug", "cherry-pick"},
+		{"branch: Created from HEAD", "branch"},
+		{"clone: from https://example.com/repo.git", "clone"},
+		{"", "other"},
+	}
+	for _, tt := range tests {
+		if got := reflogAction(tt.message); got != tt.want {
+			t.Errorf("reflogAction(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestLoadReflogsMarksLostCommits(t *testing.T) {
+	repo := gittest.New(t)
+	commit := func(message string) plumbing.Hash {
+		t.Helper()
+		return repo.CommitFile("file.txt", message, message)
+	}
+
+	first := commit("first")
+	second := commit("second")
+
+	// go-git does not write reflogs, so write the ones git would leave after
+	// "git reset --hard HEAD~1"
+	logs := strings.Join([]string{
+		zero + " " + first.String() + " Test <test@example.com> 1767272400 +0000\tcommit (initial): first",
+		first.String() + " " + second.String() + " Test <test@example.com> 1767276000 +0000\tcommit: second",
+		second.String() + " " + first.String() + " Test <test@example.com> 1767279600 +0000\treset: moving to HEAD~1",
+		"",
+	}, "\n")
+	for _, path := range []string{"logs/HEAD", "logs/refs/heads/master"} {
+		full := repo.Path(".git/" + path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(logs), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/master", first)); err != nil {
+		t.Fatal(err)
+	}
+
+	refs, reflogs, err := loadReflogs(repo.Repository)
+	if err != nil {
+		t.Fatalf("loadReflogs() error: %v", err)
+	}
+	if strings.Join(refs, ",") != "HEAD,master" {
+		t.Errorf("refs = %v, want [HEAD master]", refs)
+	}
+
+	head := reflogs["HEAD"]
+	if len(head) != 3 {
+		t.Fatalf("got %d HEAD entries, want 3", len(head))
+	}
+	if head[0].Lost || !head[1].Lost || head[2].Lost {
+		t.Errorf("lost flags = %v %v %v, want only HEAD@{1} (the reset-away commit) lost",
+			head[0].Lost, head[1].Lost, head[2].Lost)
+	}
+}
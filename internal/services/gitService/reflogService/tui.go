@@ -0,0 +1,406 @@
+package reflogService
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/blameService"
+	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+type viewMode int
+
+const (
+	listView viewMode = iota
+	detailsView
+)
+
+type model struct {
+	repo         *git.Repository
+	stats        *gitservice.CommitStatsCache
+	currentView  viewMode
+	refs         []string
+	reflogs      map[string][]ReflogEntry
+	refIndex     int
+	entryList    list.Model
+	listDelegate list.ItemDelegate
+	selected     ReflogEntry
+	details      blameService.CommitDetails
+	tuiHelper    *terminal.ResponsiveTUIHelper
+	loading      bool
+	err          error
+	clipboard    terminal.ClipboardNotice
+	keys         terminal.KeyMap
+}
+
+type entryItem struct {
+	entry ReflogEntry
+}
+
+func (i entryItem) FilterValue() string { return i.entry.Message }
+func (i entryItem) Title() string {
+	title := fmt.Sprintf("%s %s %s", actionIcon(i.entry.Action), i.entry.Selector(), i.entry.Message)
+	if i.entry.Lost {
+		title += " " + lostStyle.Render("(unreachable)")
+	}
+	return title
+}
+func (i entryItem) Description() string {
+	return fmt.Sprintf("%s → %s • %s • %s",
+		shortHash(i.entry.OldHash.String()), shortHash(i.entry.NewHash.String()),
+		i.entry.Name, i.entry.Date.Format("2006-01-02 15:04:05"))
+}
+
+type reflogsLoadedMsg struct {
+	refs    []string
+	reflogs map[string][]ReflogEntry
+}
+
+type detailsLoadedMsg struct {
+	details blameService.CommitDetails
+}
+
+type errMsg struct {
+	err error
+}
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
+			Padding(0, 1)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Muted).
+			Padding(1, 0)
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Error)
+
+	lostStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Warning)
+
+	tabStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Muted).
+			Padding(0, 1)
+
+	activeTabStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Accent).
+			Bold(true).
+			Padding(0, 1)
+
+	detailsStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.Current.Border).
+			Padding(1, 2).
+			MarginTop(1)
+)
+
+// actionIcon returns a marker for the kind of reflog entry.
+func actionIcon(action string) string {
+	switch {
+	case strings.HasPrefix(action, "commit"):
+		return "●"
+	case action == "checkout":
+		return "↪"
+	case action == "reset":
+		return "⟲"
+	case action == "rebase":
+		return "⇅"
+	case action == "merge", action == "pull":
+		return "⑂"
+	case action == "cherry-pick":
+		return "🍒"
+	default:
+		return "•"
+	}
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func (m model) Init() tea.Cmd {
+	return loadReflogsCmd(m.repo)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.tuiHelper.HandleWindowSizeMsg(msg)
+		m.entryList.SetSize(m.tuiHelper.GetWidth(), m.tuiHelper.GetHeight()-8)
+		return m, nil
+
+	case reflogsLoadedMsg:
+		m.loading = false
+		m.err = nil
+		m.refs = msg.refs
+		m.reflogs = msg.reflogs
+		m.refIndex = 0
+		return m, m.showRef()
+
+	case detailsLoadedMsg:
+		m.details = msg.details
+		m.currentView = detailsView
+		return m, nil
+
+	case errMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+
+	case terminal.ClipboardMsg, terminal.ClipboardClearMsg:
+		return m, m.clipboard.Update(msg)
+
+	case tea.MouseMsg:
+		if m.currentView == listView && len(m.entryList.Items()) > 0 {
+			terminal.HandleListMouse(&m.entryList, m.listDelegate, m.listTop(), msg)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.currentView == detailsView {
+			switch {
+			case key.Matches(msg, m.keys.Quit):
+				return m, tea.Quit
+			case key.Matches(msg, m.keys.Back) || msg.String() == "backspace":
+				m.currentView = listView
+			case key.Matches(msg, m.keys.Copy):
+				return m, terminal.CopyCmd(m.details.Hash)
+			}
+			return m, nil
+		}
+		return m.handleListViewKeys(msg)
+	}
+
+	return m, nil
+}
+
+func (m model) handleListViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// While filtering, keys are typed into the filter
+	if m.entryList.FilterState() == list.Filtering {
+		var cmd tea.Cmd
+		m.entryList, cmd = m.entryList.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.NextView, m.keys.PrevView):
+		if len(m.refs) > 1 {
+			step := 1
+			if key.Matches(msg, m.keys.PrevView) {
+				step = len(m.refs) - 1
+			}
+			m.refIndex = (m.refIndex + step) % len(m.refs)
+			return m, m.showRef()
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Select):
+		if item, ok := m.entryList.SelectedItem().(entryItem); ok && !item.entry.NewHash.IsZero() {
+			m.selected = item.entry
+			m.err = nil
+			return m, loadDetailsCmd(m.repo, m.stats, item.entry)
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Copy):
+		if item, ok := m.entryList.SelectedItem().(entryItem); ok && !item.entry.NewHash.IsZero() {
+			return m, terminal.CopyCmd(item.entry.NewHash.String())
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Refresh):
+		return m, loadReflogsCmd(m.repo)
+	}
+
+	var cmd tea.Cmd
+	m.entryList, cmd = m.entryList.Update(msg)
+	return m, cmd
+}
+
+// showRef fills the list with the entries of the current reflog.
+func (m *model) showRef() tea.Cmd {
+	if len(m.refs) == 0 {
+		return m.entryList.SetItems(nil)
+	}
+
+	entries := m.reflogs[m.refs[m.refIndex]]
+	items := make([]list.Item, len(entries))
+	for i, entry := range entries {
+		items[i] = entryItem{entry: entry}
+	}
+	m.entryList.ResetSelected()
+	return m.entryList.SetItems(items)
+}
+
+func (m model) View() string {
+	if m.currentView == detailsView {
+		return m.renderDetailsView()
+	}
+	return m.renderListView()
+}
+
+func (m model) renderHeader() string {
+	var s strings.Builder
+
+	s.WriteString(titleStyle.Render("📜 Reflog") + "\n\n")
+	if m.err != nil {
+		s.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n")
+	}
+
+	if len(m.refs) > 0 {
+		tabs := make([]string, len(m.refs))
+		for i, ref := range m.refs {
+			if i == m.refIndex {
+				tabs[i] = activeTabStyle.Render(ref)
+			} else {
+				tabs[i] = tabStyle.Render(ref)
+			}
+		}
+		s.WriteString(strings.Join(tabs, "") + "\n\n")
+	}
+
+	return s.String()
+}
+
+func (m model) renderListView() string {
+	var s strings.Builder
+
+	s.WriteString(m.renderHeader())
+
+	if m.loading {
+		s.WriteString("Loading reflog...\n")
+	} else if len(m.refs) == 0 {
+		s.WriteString("No reflog found in repository\n")
+	} else {
+		s.WriteString(m.entryList.View() + "\n")
+	}
+
+	k := m.keys
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(%s) show commit  (%s) copy hash  (%s) next reflog  (%s) filter  (%s) refresh  (%s) quit",
+		terminal.HelpKey(k.Select), terminal.HelpKey(k.Copy), terminal.HelpKey(k.NextView),
+		terminal.HelpKey(k.Filter), terminal.HelpKey(k.Refresh), terminal.HelpKey(k.Quit))))
+	s.WriteString("\n" + m.clipboard.View())
+	return s.String()
+}
+
+// listTop returns the screen row where entryList starts.
+func (m model) listTop() int {
+	return strings.Count(m.renderHeader(), "\n")
+}
+
+func (m model) renderDetailsView() string {
+	var s strings.Builder
+
+	d := m.details
+	s.WriteString(titleStyle.Render(fmt.Sprintf("📝 %s: %s", m.selected.Selector(), shortHash(d.Hash))) + "\n")
+
+	var info strings.Builder
+	info.WriteString(fmt.Sprintf("Reflog:    %s (%s)\n", m.selected.Message, m.selected.Date.Format("2006-01-02 15:04:05")))
+	info.WriteString(fmt.Sprintf("Moved:     %s → %s\n\n", shortHash(m.selected.OldHash.String()), shortHash(m.selected.NewHash.String())))
+	info.WriteString(fmt.Sprintf("Hash:      %s\n", d.Hash))
+	info.WriteString(fmt.Sprintf("Author:    %s <%s>\n", d.Author, d.AuthorEmail))
+	info.WriteString(fmt.Sprintf("Date:      %s\n", d.Date.Format("2006-01-02 15:04:05")))
+	info.WriteString(fmt.Sprintf("Message:   %s\n", d.Message))
+	info.WriteString(fmt.Sprintf("\nFiles: %d • Additions: +%d • Deletions: -%d\n",
+		d.Stats.FilesChanged, d.Stats.Additions, d.Stats.Deletions))
+
+	for i, file := range d.FilesChanged {
+		if i == 15 {
+			info.WriteString(fmt.Sprintf("  ... and %d more\n", len(d.FilesChanged)-i))
+			break
+		}
+		info.WriteString(fmt.Sprintf("  %-8s %s (+%d -%d)\n", file.Status, file.Path, file.Additions, file.Deletions))
+	}
+
+	if m.selected.Lost {
+		info.WriteString("\n" + lostStyle.Render("This commit is not on any branch. To recover it, run:") + "\n")
+		info.WriteString(fmt.Sprintf("  git branch recover-%s %s\n", shortHash(d.Hash), d.Hash))
+	}
+
+	s.WriteString(detailsStyle.Render(info.String()) + "\n")
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(%s) copy hash  (%s) back  (%s) quit",
+		terminal.HelpKey(m.keys.Copy), terminal.HelpKey(m.keys.Back), terminal.HelpKey(m.keys.Quit))))
+	s.WriteString("\n" + m.clipboard.View())
+	return s.String()
+}
+
+// Commands
+func loadReflogsCmd(repo *git.Repository) tea.Cmd {
+	return func() tea.Msg {
+		refs, reflogs, err := loadReflogs(repo)
+		if err != nil {
+			return errMsg{err: fmt.Errorf("failed to load reflog: %w", err)}
+		}
+		return reflogsLoadedMsg{refs: refs, reflogs: reflogs}
+	}
+}
+
+func loadDetailsCmd(repo *git.Repository, stats *gitservice.CommitStatsCache, entry ReflogEntry) tea.Cmd {
+	return func() tea.Msg {
+		details, err := blameService.AnalyzeCommitDetails(repo, stats, nil, entry.NewHash.String())
+		if err != nil {
+			// Reflog entries can outlive their commits once git gc prunes them
+			return errMsg{err: fmt.Errorf("%s is no longer in the repository: %w", entry.Selector(), err)}
+		}
+		return detailsLoadedMsg{details: details}
+	}
+}
+
+// RunReflogViewer starts the interactive reflog viewer TUI
+func RunReflogViewer(opts ReflogOptions) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+	if _, err := gitservice.Head(repo); err != nil {
+		return err
+	}
+
+	if err := terminal.RequireTTY(""); err != nil {
+		return err
+	}
+
+	stats := gitservice.NewCommitStatsCache(repo, !opts.NoCache)
+	// #nosec G104 - A failed cache write only means the next run recomputes stats
+	defer stats.Save()
+
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
+		Foreground(theme.Current.Text)
+
+	entryList := list.New([]list.Item{}, delegate, 0, 0)
+	entryList.SetShowTitle(false)
+	entryList.SetShowStatusBar(false)
+	entryList.SetShowHelp(false)
+
+	m := model{
+		repo:         repo,
+		stats:        stats,
+		currentView:  listView,
+		entryList:    entryList,
+		listDelegate: delegate,
+		tuiHelper:    terminal.NewResponsiveTUIHelper(),
+		keys:         terminal.Keys(),
+		loading:      true,
+	}
+
+	m.keys.ApplyToList(&m.entryList)
+
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
+	return err
+}
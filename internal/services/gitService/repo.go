@@ -0,0 +1,97 @@
+package gitservice
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/utils/profile"
+)
+
+// OpenRepo opens the git repository containing path, searching parent directories
+// for the .git directory like git does, or the bare repository at path. An empty path
+// means the current directory. If no repository is found, the error wraps ErrNotGitRepo.
+func OpenRepo(path string) (*git.Repository, error) {
+	defer profile.Phase(profile.OpenRepo)()
+
+	if path == "" {
+		path = "."
+	}
+
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		// Detection only looks for a .git directory, so a bare repository has to be
+		// opened as is
+		repo, err = git.PlainOpen(path)
+	}
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		if path == "." {
+			return nil, ErrNotGitRepo
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNotGitRepo, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	return repo, nil
+}
+
+// RepoRoot returns the working tree root of repo, for resolving files and .mailmap.
+func RepoRoot(repo *git.Repository) (string, error) {
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	return wt.Filesystem.Root(), nil
+}
+
+// Head returns the reference HEAD points to, like repo.Head(). A detached HEAD is not an
+// error: the returned reference is then named HEAD and holds the checked out commit.
+// If HEAD points at a branch with no commits yet, the error is ErrNoCommits.
+func Head(repo *git.Repository) (*plumbing.Reference, error) {
+	ref, err := repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, ErrNoCommits
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	return ref, nil
+}
+
+// StartCommit returns the commit a history walk starts from: the one ref resolves to
+// with ResolveRef, or HEAD when ref is empty.
+func StartCommit(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	if ref == "" {
+		head, err := Head(repo)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return head.Hash(), nil
+	}
+
+	hash, err := ResolveRef(repo, ref)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return hash, nil
+}
+
+// CheckHistory opens the repository containing path and checks it has a commit to walk
+// history from, so commands can fail with a clear message before starting a TUI.
+func CheckHistory(path string) error {
+	return CheckHistoryFrom(path, "")
+}
+
+// CheckHistoryFrom is CheckHistory for a walk starting at ref instead of HEAD, which
+// also catches a ref that doesn't resolve.
+func CheckHistoryFrom(path, ref string) error {
+	repo, err := OpenRepo(path)
+	if err != nil {
+		return err
+	}
+	_, err = StartCommit(repo, ref)
+	return err
+}
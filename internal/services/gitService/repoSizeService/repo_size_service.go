@@ -0,0 +1,267 @@
+package repoSizeService
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// RepoSizeOptions controls the repository size report
+type RepoSizeOptions struct {
+	// RepoPath is the repository to analyze; empty means the current directory
+	RepoPath string
+	// Top is how many paths to report; 0 reports all of them
+	Top int
+	// JSON prints the report as JSON instead of a table
+	JSON bool
+}
+
+// PathSize is the space taken by every version of a path across history
+type PathSize struct {
+	Path string `json:"path"`
+	// Versions is the number of distinct blobs committed at this path
+	Versions int `json:"versions"`
+	// LargestSize is the size of the largest version
+	LargestSize int64 `json:"largest_size"`
+	// TotalSize is the combined size of all versions
+	TotalSize int64 `json:"total_size"`
+	// InHead is set when a version of the path is in HEAD, at this path or, after a
+	// rename or move, at another
+	InHead bool `json:"in_head"`
+	// HeadSize is the size of the version in HEAD, 0 if none of them is
+	HeadSize int64 `json:"head_size"`
+}
+
+// RepoSizeReport lists the paths whose blobs take the most space in history
+type RepoSizeReport struct {
+	// TotalBlobs and TotalSize cover every unique blob reachable from any ref
+	TotalBlobs int   `json:"total_blobs"`
+	TotalSize  int64 `json:"total_size"`
+	// HistoryOnlySize is the size of blobs that are not in HEAD. Rewriting history is
+	// the only way to reclaim it.
+	HistoryOnlySize int64      `json:"history_only_size"`
+	Paths           []PathSize `json:"paths"`
+}
+
+// blobSizer reads an object's size without inflating its content
+type blobSizer interface {
+	EncodedObjectSize(plumbing.Hash) (int64, error)
+}
+
+// RunRepoSize reports the largest files ever committed, grouped by path, to find what
+// makes a repository's .git directory large.
+func RunRepoSize(opts RepoSizeOptions) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+
+	report, err := analyzeRepoSize(repo)
+	if err != nil {
+		return err
+	}
+	if opts.Top > 0 && len(report.Paths) > opts.Top {
+		report.Paths = report.Paths[:opts.Top]
+	}
+
+	if opts.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	printReport(os.Stdout, report)
+	return nil
+}
+
+// analyzeRepoSize walks the trees of every commit reachable from any ref. A tree is
+// walked once for each directory it appears at, so every path a blob is committed under
+// is recorded, but each blob's size is read and counted in the totals once. Only sizes
+// are read, so memory stays bounded by the number of unique objects rather than their
+// content.
+func analyzeRepoSize(repo *git.Repository) (RepoSizeReport, error) {
+	headBlobs, err := headBlobs(repo)
+	if err != nil {
+		return RepoSizeReport{}, err
+	}
+
+	sizer, _ := repo.Storer.(blobSizer)
+	blobSize := func(hash plumbing.Hash) (int64, error) {
+		if sizer != nil {
+			return sizer.EncodedObjectSize(hash)
+		}
+		blob, err := repo.BlobObject(hash)
+		if err != nil {
+			return 0, err
+		}
+		return blob.Size, nil
+	}
+
+	// A tree or blob is only skipped when it was already seen at the same path
+	type pathObject struct {
+		path string
+		hash plumbing.Hash
+	}
+	seenTrees := make(map[pathObject]bool)
+	seenVersions := make(map[pathObject]bool)
+	sizes := make(map[plumbing.Hash]int64)
+	paths := make(map[string]*PathSize)
+	var report RepoSizeReport
+
+	var walkTree func(hash plumbing.Hash, dir string) error
+	walkTree = func(hash plumbing.Hash, dir string) error {
+		if seenTrees[pathObject{dir, hash}] {
+			return nil
+		}
+		seenTrees[pathObject{dir, hash}] = true
+
+		tree, err := repo.TreeObject(hash)
+		if err != nil {
+			return fmt.Errorf("failed to read tree %s: %w", hash, err)
+		}
+
+		for _, entry := range tree.Entries {
+			name := path.Join(dir, entry.Name)
+
+			switch {
+			case entry.Mode == filemode.Dir:
+				if err := walkTree(entry.Hash, name); err != nil {
+					return err
+				}
+			case entry.Mode == filemode.Submodule:
+				// Submodule commits live in another repository
+			default:
+				if seenVersions[pathObject{name, entry.Hash}] {
+					continue
+				}
+				seenVersions[pathObject{name, entry.Hash}] = true
+
+				size, seen := sizes[entry.Hash]
+				if !seen {
+					if size, err = blobSize(entry.Hash); err != nil {
+						return fmt.Errorf("failed to read size of %s: %w", name, err)
+					}
+					sizes[entry.Hash] = size
+
+					report.TotalBlobs++
+					report.TotalSize += size
+					if !headBlobs[entry.Hash] {
+						report.HistoryOnlySize += size
+					}
+				}
+
+				p := paths[name]
+				if p == nil {
+					p = &PathSize{Path: name}
+					paths[name] = p
+				}
+				p.Versions++
+				p.TotalSize += size
+				p.LargestSize = max(p.LargestSize, size)
+				if headBlobs[entry.Hash] {
+					p.InHead = true
+					p.HeadSize = size
+				}
+			}
+		}
+		return nil
+	}
+
+	cIter, err := repo.Log(&git.LogOptions{All: true})
+	if err != nil {
+		return RepoSizeReport{}, fmt.Errorf("failed to get log: %w", err)
+	}
+	err = cIter.ForEach(func(c *object.Commit) error {
+		return walkTree(c.TreeHash, "")
+	})
+	if err != nil {
+		return RepoSizeReport{}, err
+	}
+
+	for _, p := range paths {
+		report.Paths = append(report.Paths, *p)
+	}
+	sort.Slice(report.Paths, func(i, j int) bool {
+		if report.Paths[i].TotalSize != report.Paths[j].TotalSize {
+			return report.Paths[i].TotalSize > report.Paths[j].TotalSize
+		}
+		return report.Paths[i].Path < report.Paths[j].Path
+	})
+
+	return report, nil
+}
+
+// headTree returns the tree of HEAD, or nil if the repository has no commits.
+func headTree(repo *git.Repository) (*object.Tree, error) {
+	ref, err := repo.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+	return commit.Tree()
+}
+
+// headBlobs returns the set of blobs in HEAD's tree.
+func headBlobs(repo *git.Repository) (map[plumbing.Hash]bool, error) {
+	blobs := make(map[plumbing.Hash]bool)
+
+	tree, err := headTree(repo)
+	if err != nil || tree == nil {
+		return blobs, err
+	}
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		blobs[f.Hash] = true
+		return nil
+	})
+	return blobs, err
+}
+
+// printReport writes the report as a table.
+func printReport(w io.Writer, report RepoSizeReport) {
+	fmt.Fprintf(w, "%d unique files in history, %s total (%s only in history)\n\n",
+		report.TotalBlobs,
+		gitservice.BytesToHumanReadable(uint64(report.TotalSize)),
+		gitservice.BytesToHumanReadable(uint64(report.HistoryOnlySize)))
+
+	if len(report.Paths) == 0 {
+		fmt.Fprintln(w, "No files found in history.")
+		return
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "PATH\tTOTAL\tLARGEST\tVERSIONS\tSTATUS")
+	for _, p := range report.Paths {
+		status := "deleted, history only"
+		if p.InHead {
+			status = "in HEAD (" + gitservice.BytesToHumanReadable(uint64(p.HeadSize)) + ")"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\n",
+			p.Path,
+			gitservice.BytesToHumanReadable(uint64(p.TotalSize)),
+			gitservice.BytesToHumanReadable(uint64(p.LargestSize)),
+			p.Versions,
+			status,
+		)
+	}
+	// #nosec G104 - Flushing to stdout only fails if stdout is closed
+	writer.Flush()
+}
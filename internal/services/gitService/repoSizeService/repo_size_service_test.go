@@ -0,0 +1,83 @@
+package repoSizeService
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestAnalyzeRepoSize(t *testing.T) {
+	// A large file that is later deleted, and a small file that is edited twice
+	repo := gittest.New(t)
+	repo.WriteFile("assets/big.bin", strings.Repeat("x", 100*1024))
+	repo.WriteFile("notes.txt", "one")
+	repo.Commit("Add files")
+
+	repo.Remove("assets/big.bin")
+	repo.WriteFile("notes.txt", "one two")
+	repo.Commit("Remove big file")
+
+	report, err := analyzeRepoSize(repo.Repository)
+	if err != nil {
+		t.Fatalf("analyzeRepoSize() error: %v", err)
+	}
+
+	if report.TotalBlobs != 3 {
+		t.Errorf("TotalBlobs = %d, want 3", report.TotalBlobs)
+	}
+	if len(report.Paths) != 2 {
+		t.Fatalf("Paths = %+v, want big.bin and notes.txt", report.Paths)
+	}
+
+	big := report.Paths[0]
+	if big.Path != "assets/big.bin" || big.InHead || big.LargestSize != 100*1024 {
+		t.Errorf("largest path = %+v, want deleted assets/big.bin of 100KiB", big)
+	}
+
+	notes := report.Paths[1]
+	if notes.Path != "notes.txt" || !notes.InHead || notes.Versions != 2 || notes.HeadSize != 7 {
+		t.Errorf("notes.txt = %+v, want 2 versions, in HEAD at 7 bytes", notes)
+	}
+
+	if want := int64(100*1024 + 3); report.HistoryOnlySize != want {
+		t.Errorf("HistoryOnlySize = %d, want %d", report.HistoryOnlySize, want)
+	}
+
+	var out bytes.Buffer
+	printReport(&out, report)
+	if !strings.Contains(out.String(), "deleted, history only") {
+		t.Errorf("report does not mark deleted files:\n%s", out.String())
+	}
+}
+
+func TestAnalyzeRepoSizeRenamedFile(t *testing.T) {
+	repo := gittest.New(t)
+	big := strings.Repeat("x", 100*1024)
+	repo.WriteFile("big.bin", big)
+	repo.Commit("Add big file")
+	repo.Move("big.bin", "assets/big.bin")
+	repo.Commit("Move big file")
+	// The same content committed under a third name takes no more space
+	repo.WriteFile("copy.bin", big)
+	repo.Commit("Copy big file")
+
+	report, err := analyzeRepoSize(repo.Repository)
+	if err != nil {
+		t.Fatalf("analyzeRepoSize() error: %v", err)
+	}
+
+	if report.TotalBlobs != 1 || report.TotalSize != 100*1024 || report.HistoryOnlySize != 0 {
+		t.Errorf("report totals = %d blobs, %d bytes, %d only in history, want 1 blob of 100KiB in HEAD",
+			report.TotalBlobs, report.TotalSize, report.HistoryOnlySize)
+	}
+	if len(report.Paths) != 3 {
+		t.Fatalf("Paths = %+v, want every path the file was committed under", report.Paths)
+	}
+	for _, p := range report.Paths {
+		if !p.InHead || p.HeadSize != 100*1024 {
+			t.Errorf("%s = %+v, want its blob in HEAD", p.Path, p)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package gitservice
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestOpenRepoDiscoversParent(t *testing.T) {
+	dir := gittest.New(t).Dir
+
+	sub := filepath.Join(dir, "a", "b")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := OpenRepo(sub)
+	if err != nil {
+		t.Fatalf("OpenRepo(%q) error: %v", sub, err)
+	}
+
+	root, err := RepoRoot(repo)
+	if err != nil {
+		t.Fatalf("RepoRoot() error: %v", err)
+	}
+
+	want, _ := filepath.EvalSymlinks(dir)
+	got, _ := filepath.EvalSymlinks(root)
+	if got != want {
+		t.Errorf("RepoRoot() = %q, want %q", got, want)
+	}
+}
+
+func TestOpenRepoBare(t *testing.T) {
+	_, dir := gittest.NewBare(t, "b.git")
+
+	repo, err := OpenRepo(dir)
+	if err != nil {
+		t.Fatalf("OpenRepo(%q) error: %v", dir, err)
+	}
+	if _, err := repo.Worktree(); !errors.Is(err, git.ErrIsBareRepository) {
+		t.Errorf("Worktree() error = %v, want ErrIsBareRepository", err)
+	}
+}
+
+func TestOpenRepoNotARepo(t *testing.T) {
+	_, err := OpenRepo(t.TempDir())
+	if !errors.Is(err, ErrNotGitRepo) {
+		t.Errorf("OpenRepo() error = %v, want ErrNotGitRepo", err)
+	}
+}
+
+func TestHead(t *testing.T) {
+	empty := gittest.New(t)
+	if _, err := Head(empty.Repository); !errors.Is(err, ErrNoCommits) {
+		t.Errorf("Head() of an empty repository error = %v, want ErrNoCommits", err)
+	}
+	if err := CheckHistory(empty.Dir); !errors.Is(err, ErrNoCommits) {
+		t.Errorf("CheckHistory() of an empty repository error = %v, want ErrNoCommits", err)
+	}
+
+	repo := newStatsTestRepo(t, 2)
+	commits := headCommits(t, repo.Repository)
+	if err := repo.Worktree.Checkout(&git.CheckoutOptions{Hash: commits[1].Hash}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A detached HEAD still has history to walk
+	ref, err := Head(repo.Repository)
+	if err != nil {
+		t.Fatalf("Head() with a detached HEAD error: %v", err)
+	}
+	if ref.Hash() != commits[1].Hash {
+		t.Errorf("Head() = %s, want %s", ref.Hash(), commits[1].Hash)
+	}
+	if err := CheckHistory(repo.Dir); err != nil {
+		t.Errorf("CheckHistory() with a detached HEAD error: %v", err)
+	}
+	if _, err := ResolveRef(repo.Repository, "@{upstream}"); !errors.Is(err, ErrDetachedHead) {
+		t.Errorf("ResolveRef(@{upstream}) with a detached HEAD error = %v, want ErrDetachedHead", err)
+	}
+}
+
+func TestStartCommit(t *testing.T) {
+	repo := newStatsTestRepo(t, 2)
+	commits := headCommits(t, repo.Repository)
+
+	for _, tt := range []struct {
+		ref  string
+		want int
+	}{
+		{"", 0},
+		{"HEAD~1", 1},
+		{commits[1].Hash.String()[:7], 1},
+	} {
+		hash, err := StartCommit(repo.Repository, tt.ref)
+		if err != nil {
+			t.Fatalf("StartCommit(%q) error: %v", tt.ref, err)
+		}
+		if hash != commits[tt.want].Hash {
+			t.Errorf("StartCommit(%q) = %s, want %s", tt.ref, hash, commits[tt.want].Hash)
+		}
+	}
+
+	if err := CheckHistoryFrom(repo.Dir, "no-such-branch"); err == nil {
+		t.Error("CheckHistoryFrom() of a ref that doesn't exist should fail")
+	}
+}
@@ -0,0 +1,148 @@
+package gitservice
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/redjax/syst/internal/utils/terminal"
+)
+
+// Report formats accepted by --format
+const (
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+	FormatSARIF    = "sarif"
+	// FormatPrometheus is the Prometheus text format, for node_exporter's textfile collector
+	FormatPrometheus = "prom"
+)
+
+// reportExtensions maps --output file extensions to the format they imply
+var reportExtensions = map[string]string{
+	".json":     FormatJSON,
+	".csv":      FormatCSV,
+	".md":       FormatMarkdown,
+	".markdown": FormatMarkdown,
+	".sarif":    FormatSARIF,
+	".prom":     FormatPrometheus,
+}
+
+// ReportWriter sends the report behind a TUI to stdout or a file instead of launching
+// the TUI. It holds the values of the shared --format and --output flags.
+type ReportWriter struct {
+	// Format is the report format; empty infers it from Output's extension
+	Format string
+	// Output is the file to write; empty or "-" means stdout
+	Output string
+	// Copy also copies the report to the clipboard, see CopyReport
+	Copy bool
+}
+
+// Enabled reports whether a report was asked for instead of the TUI.
+func (r ReportWriter) Enabled() bool {
+	return r.Format != "" || r.Output != "" || r.Copy
+}
+
+// ResolveFormat returns the format to write, out of the formats a report supports. An
+// explicit Format takes precedence over Output's extension; with neither, as for
+// '--output -', the first supported format is used.
+func (r ReportWriter) ResolveFormat(supported ...string) (string, error) {
+	format := strings.ToLower(r.Format)
+	switch format {
+	case "md":
+		format = FormatMarkdown
+	case "prometheus":
+		format = FormatPrometheus
+	}
+
+	if format == "" && r.Output != "" && r.Output != "-" {
+		ext := strings.ToLower(filepath.Ext(r.Output))
+		inferred, ok := reportExtensions[ext]
+		if !ok {
+			return "", fmt.Errorf("cannot infer the report format from %s, pass --format (%s)", r.Output, strings.Join(supported, ", "))
+		}
+		format = inferred
+	}
+	if format == "" {
+		return supported[0], nil
+	}
+
+	if !slices.Contains(supported, format) {
+		return "", fmt.Errorf("unsupported report format %s (supported: %s)", format, strings.Join(supported, ", "))
+	}
+	return format, nil
+}
+
+// Write renders the report in the resolved format with render. A file is only written
+// once the whole report has rendered, so a failed report doesn't clobber an existing one.
+func (r ReportWriter) Write(render func(w io.Writer, format string) error, supported ...string) error {
+	format, err := r.ResolveFormat(supported...)
+	if err != nil {
+		return err
+	}
+
+	toStdout := r.Output == "" || r.Output == "-"
+	if toStdout && !r.Copy {
+		return render(os.Stdout, format)
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, format); err != nil {
+		return err
+	}
+	if toStdout {
+		if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	} else {
+		// #nosec G306 - Reports are meant to be shared
+		if err := os.WriteFile(r.Output, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Printf("Report written to %s\n", r.Output)
+	}
+
+	if r.Copy {
+		return CopyReport(buf.Bytes())
+	}
+	return nil
+}
+
+// copyToClipboard is swapped out in tests
+var copyToClipboard = terminal.CopyToClipboard
+
+// CopyReport copies a report that has been written to the clipboard, for --copy. Over
+// SSH it is sent to the local terminal with OSC 52 (see terminal.CopyToClipboard). The
+// confirmation goes to stderr, so a report printed to stdout can still be piped.
+func CopyReport(report []byte) error {
+	if err := copyToClipboard(string(report)); err != nil {
+		return fmt.Errorf("failed to copy the report to the clipboard: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Report copied to the clipboard")
+	return nil
+}
+
+// WriteMarkdownTable writes a GitHub-flavored Markdown table. Pipes and newlines in cells
+// are escaped so they can't break the table.
+func WriteMarkdownTable(w io.Writer, headers []string, rows [][]string) error {
+	escape := strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(headers)) + "\n")
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escape.Replace(cell)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,132 @@
+package gitservice
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redjax/syst/internal/utils/terminal"
+)
+
+func TestReportWriterResolveFormat(t *testing.T) {
+	supported := []string{FormatJSON, FormatCSV, FormatMarkdown}
+
+	tests := []struct {
+		report ReportWriter
+		want   string
+	}{
+		{ReportWriter{Format: "csv"}, FormatCSV},
+		{ReportWriter{Format: "MD"}, FormatMarkdown},
+		{ReportWriter{Output: "report.json"}, FormatJSON},
+		{ReportWriter{Output: "out/stats.CSV"}, FormatCSV},
+		{ReportWriter{Output: "README.md"}, FormatMarkdown},
+		// An explicit format wins over the extension
+		{ReportWriter{Format: "json", Output: "report.md"}, FormatJSON},
+		{ReportWriter{Format: "csv", Output: "report.txt"}, FormatCSV},
+		// Nothing to infer from: the default format
+		{ReportWriter{Output: "-"}, FormatJSON},
+		{ReportWriter{Output: "/var/lib/node_exporter/syst.prom"}, FormatPrometheus},
+		{ReportWriter{Format: "prometheus"}, FormatPrometheus},
+	}
+	for _, tt := range tests {
+		got, err := tt.report.ResolveFormat(append(supported, FormatPrometheus)...)
+		if err != nil {
+			t.Errorf("%+v: ResolveFormat() error: %v", tt.report, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%+v: ResolveFormat() = %q, want %q", tt.report, got, tt.want)
+		}
+	}
+
+	for _, report := range []ReportWriter{{Output: "report.txt"}, {Format: "sarif"}, {Output: "report.sarif"}} {
+		if format, err := report.ResolveFormat(supported...); err == nil {
+			t.Errorf("%+v: ResolveFormat() = %q, want an error", report, format)
+		}
+	}
+
+	if (ReportWriter{}).Enabled() {
+		t.Error("a ReportWriter without a format or output should not be enabled")
+	}
+}
+
+func TestReportWriterWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.md")
+	if err := os.WriteFile(path, []byte("previous report"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report := ReportWriter{Output: path}
+
+	// A failed render leaves the previous report in place
+	renderErr := errors.New("render failed")
+	err := report.Write(func(w io.Writer, format string) error {
+		io.WriteString(w, "partial")
+		return renderErr
+	}, FormatJSON, FormatMarkdown)
+	if !errors.Is(err, renderErr) {
+		t.Fatalf("Write() error = %v, want the render error", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "previous report" {
+		t.Errorf("file after a failed render = %q", data)
+	}
+
+	err = report.Write(func(w io.Writer, format string) error {
+		_, err := io.WriteString(w, "# "+format)
+		return err
+	}, FormatJSON, FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# markdown" {
+		t.Errorf("file = %q, want the markdown report", data)
+	}
+}
+
+func TestReportWriterCopy(t *testing.T) {
+	var copied string
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyToClipboard = terminal.CopyToClipboard }()
+
+	// --copy alone asks for the report
+	if !(ReportWriter{Copy: true}).Enabled() {
+		t.Error("Enabled() with --copy = false")
+	}
+
+	path := filepath.Join(t.TempDir(), "report.md")
+	report := ReportWriter{Output: path, Copy: true}
+	err := report.Write(func(w io.Writer, format string) error {
+		_, err := io.WriteString(w, "# "+format)
+		return err
+	}, FormatJSON, FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# markdown" || copied != "# markdown" {
+		t.Errorf("wrote %q and copied %q, want the markdown report in both", data, copied)
+	}
+
+	copyErr := errors.New("no clipboard")
+	copyToClipboard = func(string) error { return copyErr }
+	if err := CopyReport([]byte("report")); !errors.Is(err, copyErr) {
+		t.Errorf("CopyReport() error = %v, want the clipboard error", err)
+	}
+}
+
+func TestWriteMarkdownTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteMarkdownTable(&buf, []string{"Name", "Note"}, [][]string{{"a|b", "line 1\nline 2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "| Name | Note |\n| --- | --- |\n| a\\|b | line 1 line 2 |\n"
+	if got := buf.String(); got != want {
+		t.Errorf("table = %q, want %q", got, want)
+	}
+}
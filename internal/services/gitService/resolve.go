@@ -0,0 +1,160 @@
+package gitservice
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	// upstreamPattern matches "branch@{upstream}", "@{u}" and "@{push}"; an empty
+	// branch means the current one
+	upstreamPattern = regexp.MustCompile(`^([^@~^:]*)@\{(?i:upstream|u|push)\}`)
+	// peelPattern matches "^{}" and "^{commit}", which peel a tag to its commit
+	peelPattern = regexp.MustCompile(`\^\{(commit)?\}`)
+	// objectTypePattern matches peeling to anything else, like "^{tree}"
+	objectTypePattern = regexp.MustCompile(`\^\{(\w+)\}`)
+)
+
+// ResolveRef resolves a revision to a commit hash, like 'git rev-parse <ref>^{commit}'.
+// It accepts full and short hashes, branches, tags (annotated tags are peeled to their
+// commit, through tags of tags), relative refs like HEAD~3 and main^2, "@" for HEAD,
+// "@{upstream}"/"@{u}"/"@{push}" for the branch a local branch tracks, "@last-tag" for
+// the most recent tag reachable from HEAD, and the "^{}" peel suffix.
+func ResolveRef(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	rev := strings.TrimSpace(ref)
+	if rev == "" {
+		return plumbing.ZeroHash, fmt.Errorf("empty revision")
+	}
+
+	// Fast path for full hashes
+	if len(rev) == 40 {
+		if hash := plumbing.NewHash(rev); !hash.IsZero() {
+			if _, err := repo.CommitObject(hash); err == nil {
+				return hash, nil
+			}
+		}
+	}
+
+	// Everything resolves to a commit, so peeling to one needs no extra work
+	rev = peelPattern.ReplaceAllString(rev, "")
+	if m := objectTypePattern.FindStringSubmatch(rev); m != nil {
+		return plumbing.ZeroHash, fmt.Errorf("cannot resolve %s: only commits are supported, not %s objects", ref, m[1])
+	}
+
+	if rest, ok := strings.CutPrefix(rev, LastTagRef); ok && (rest == "" || strings.ContainsRune("~^", rune(rest[0]))) {
+		_, hash, err := LastTag(repo)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		rev = hash.String() + rest
+	}
+
+	// A lone "@" is short for HEAD
+	if rev == "@" || strings.HasPrefix(rev, "@~") || strings.HasPrefix(rev, "@^") {
+		rev = "HEAD" + rev[1:]
+	}
+
+	if m := upstreamPattern.FindStringSubmatch(rev); m != nil {
+		tracking, err := upstreamRef(repo, m[1])
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		rev = tracking + rev[len(m[0]):]
+	}
+
+	rev = peelTagBase(repo, rev)
+
+	resolved, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		// Nothing resolves before the first commit, so say why
+		if _, headErr := Head(repo); errors.Is(headErr, ErrNoCommits) {
+			return plumbing.ZeroHash, ErrNoCommits
+		}
+		return plumbing.ZeroHash, err
+	}
+
+	return *resolved, nil
+}
+
+// Upstream returns the short name of the branch the current branch tracks, i.e.
+// "origin/main", like 'git rev-parse --abbrev-ref @{upstream}'. It fails on a detached
+// HEAD or a branch without an upstream configured.
+func Upstream(repo *git.Repository) (string, error) {
+	return BranchUpstream(repo, "")
+}
+
+// BranchUpstream returns the short name of the branch a local branch tracks, or the
+// current branch's when branch is empty. The upstream may no longer exist, i.e. after
+// its remote branch was deleted and pruned.
+func BranchUpstream(repo *git.Repository, branch string) (string, error) {
+	ref, err := upstreamRef(repo, branch)
+	if err != nil {
+		return "", err
+	}
+	return plumbing.ReferenceName(ref).Short(), nil
+}
+
+// upstreamRef returns the remote-tracking ref that branch is configured to track, or
+// the current branch's when branch is empty. @{push} resolves the same way, which is
+// right unless pushes go to a different remote than fetches.
+func upstreamRef(repo *git.Repository, branch string) (string, error) {
+	if branch == "" {
+		head, err := repo.Reference(plumbing.HEAD, false)
+		if err != nil {
+			return "", fmt.Errorf("failed to read HEAD: %w", err)
+		}
+		if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+			return "", fmt.Errorf("no upstream branch: %w", ErrDetachedHead)
+		}
+		branch = head.Target().Short()
+	}
+	branch = strings.TrimPrefix(strings.TrimPrefix(branch, "refs/heads/"), "heads/")
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return "", fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	b, ok := cfg.Branches[branch]
+	if !ok || b.Remote == "" || b.Merge == "" {
+		return "", fmt.Errorf("no upstream configured for branch %s", branch)
+	}
+
+	// A remote of "." tracks another local branch
+	if b.Remote == "." {
+		return b.Merge.String(), nil
+	}
+	return "refs/remotes/" + b.Remote + "/" + b.Merge.Short(), nil
+}
+
+// peelTagBase replaces a tag at the start of rev with the hash of the commit it points
+// to. go-git only peels one level of annotated tag, so a tag of a tag would otherwise
+// fail to resolve.
+func peelTagBase(repo *git.Repository, rev string) string {
+	end := strings.IndexAny(rev, "~^:@")
+	if end == -1 {
+		end = len(rev)
+	}
+	base := rev[:end]
+
+	name := plumbing.ReferenceName(base)
+	if !name.IsTag() {
+		name = plumbing.NewTagReferenceName(strings.TrimPrefix(base, "tags/"))
+	}
+	ref, err := repo.Reference(name, true)
+	if err != nil {
+		return rev
+	}
+
+	hash, ok := peelTag(repo, ref.Hash())
+	if !ok {
+		return rev
+	}
+
+	return hash.String() + rev[end:]
+}
@@ -0,0 +1,179 @@
+package gitservice
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+// newResolveTestRepo creates a five-commit repository on master, tracking origin/master
+// (which is two commits behind), with a lightweight tag, an annotated tag and a tag of
+// that annotated tag. It returns the commits newest first.
+func newResolveTestRepo(t *testing.T) (*gittest.Repo, []*object.Commit) {
+	t.Helper()
+
+	repo := newStatsTestRepo(t, 5)
+	commits := headCommits(t, repo.Repository)
+
+	sig := &object.Signature{Name: "Test", Email: "test@example.com", When: time.Unix(1700000000, 0)}
+	if _, err := repo.CreateTag("light", commits[1].Hash, nil); err != nil {
+		t.Fatal(err)
+	}
+	annotated, err := repo.CreateTag("v1.0", commits[2].Hash, &git.CreateTagOptions{Tagger: sig, Message: "v1.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A tag of a tag, which 'git tag nested v1.0' creates when v1.0 is annotated
+	nested := &object.Tag{Name: "nested", Tagger: *sig, Message: "nested\n", TargetType: plumbing.TagObject, Target: annotated.Hash()}
+	obj := repo.Storer.NewEncodedObject()
+	if err := nested.Encode(obj); err != nil {
+		t.Fatal(err)
+	}
+	nestedHash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewTagReferenceName("nested"), nestedHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	remote := plumbing.NewRemoteReferenceName("origin", "master")
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(remote, commits[2].Hash)); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Branches["master"] = &config.Branch{Name: "master", Remote: "origin", Merge: plumbing.NewBranchReferenceName("master")}
+	cfg.Branches["local"] = &config.Branch{Name: "local", Remote: ".", Merge: plumbing.NewBranchReferenceName("master")}
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("local"), commits[4].Hash)); err != nil {
+		t.Fatal(err)
+	}
+
+	return repo, commits
+}
+
+func TestResolveRef(t *testing.T) {
+	repo, commits := newResolveTestRepo(t)
+
+	tests := []struct {
+		ref  string
+		want int // index into commits, newest first
+	}{
+		{"HEAD", 0},
+		{"@", 0},
+		{"master", 0},
+		{"refs/heads/master", 0},
+		{commits[3].Hash.String(), 3},
+		{commits[3].Hash.String()[:7], 3},
+		{"HEAD~3", 3},
+		{"@~2", 2},
+		{"master^", 1},
+		{"light", 1},
+		{"v1.0", 2},
+		{"tags/v1.0", 2},
+		{"v1.0^{}", 2},
+		{"v1.0^{commit}", 2},
+		{"v1.0~1", 3},
+		{"nested", 2},
+		{"nested^{}~2", 4},
+		{"@{upstream}", 2},
+		{"@{u}~1", 3},
+		{"master@{u}", 2},
+		{"master@{push}", 2},
+		{"local@{upstream}", 0},
+		{"@last-tag", 1},
+		{"@last-tag~1", 2},
+	}
+	for _, tt := range tests {
+		got, err := ResolveRef(repo.Repository, tt.ref)
+		if err != nil {
+			t.Errorf("ResolveRef(%q) error: %v", tt.ref, err)
+			continue
+		}
+		if got != commits[tt.want].Hash {
+			t.Errorf("ResolveRef(%q) = %s, want commit %d (%s)", tt.ref, got, tt.want, commits[tt.want].Hash)
+		}
+	}
+}
+
+func TestResolveRefErrors(t *testing.T) {
+	repo, _ := newResolveTestRepo(t)
+
+	for _, ref := range []string{"", "missing", "HEAD~10", "v1.0^{tree}", "light@{u}", "@last-tagged"} {
+		if hash, err := ResolveRef(repo.Repository, ref); err == nil {
+			t.Errorf("ResolveRef(%q) = %s, want an error", ref, hash)
+		}
+	}
+}
+
+func TestUpstream(t *testing.T) {
+	repo, commits := newResolveTestRepo(t)
+
+	if got, err := Upstream(repo.Repository); err != nil || got != "origin/master" {
+		t.Errorf("Upstream() = %q, %v, want origin/master", got, err)
+	}
+
+	// A branch tracking a local branch has that one as its upstream
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("local"))); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Upstream(repo.Repository); err != nil || got != "master" {
+		t.Errorf("Upstream() on local = %q, %v, want master", got, err)
+	}
+
+	// A branch without an upstream, and a detached HEAD, have none
+	topic := plumbing.NewBranchReferenceName("topic")
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(topic, commits[0].Hash)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, topic)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Upstream(repo.Repository); err == nil {
+		t.Errorf("Upstream() on a branch without an upstream = %q, want an error", got)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, commits[0].Hash)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Upstream(repo.Repository); !errors.Is(err, ErrDetachedHead) {
+		t.Errorf("Upstream() with a detached HEAD error = %v, want ErrDetachedHead", err)
+	}
+}
+
+func TestLastTag(t *testing.T) {
+	repo, commits := newResolveTestRepo(t)
+
+	name, hash, err := LastTag(repo.Repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "light" || hash != commits[1].Hash {
+		t.Errorf("LastTag() = %s (%s), want light (%s)", name, hash, commits[1].Hash)
+	}
+
+	tags, err := TagsByCommit(repo.Repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// v1.0 and the tag of it both peel to the same commit
+	if tag := tags[commits[2].Hash]; tag != "v1.0" && tag != "nested" {
+		t.Errorf("TagsByCommit()[%s] = %q, want v1.0 or nested", commits[2].Hash, tag)
+	}
+
+	untagged := newStatsTestRepo(t, 2)
+	if _, err := ResolveRef(untagged.Repository, LastTagRef); !errors.Is(err, ErrNoTags) {
+		t.Errorf("ResolveRef(%s) without tags = %v, want ErrNoTags", LastTagRef, err)
+	}
+}
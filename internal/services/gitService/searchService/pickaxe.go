@@ -0,0 +1,165 @@
+package searchService
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// pickaxeChange is how the number of occurrences of the query in a file changed in a commit
+type pickaxeChange struct {
+	Before int
+	After  int
+}
+
+// verb describes the change, i.e. "Introduced 2 occurrences"
+func (c pickaxeChange) verb() string {
+	switch {
+	case c.Before == 0:
+		return fmt.Sprintf("Introduced %s", occurrences(c.After))
+	case c.After == 0:
+		return fmt.Sprintf("Removed %s", occurrences(c.Before))
+	case c.After > c.Before:
+		return fmt.Sprintf("Added %s", occurrences(c.After-c.Before))
+	default:
+		return fmt.Sprintf("Removed %s", occurrences(c.Before-c.After))
+	}
+}
+
+func (c pickaxeChange) icon() string {
+	switch {
+	case c.Before == 0:
+		return "➕"
+	case c.After == 0:
+		return "➖"
+	default:
+		return "✏️"
+	}
+}
+
+func occurrences(n int) string {
+	if n == 1 {
+		return "1 occurrence"
+	}
+	return fmt.Sprintf("%d occurrences", n)
+}
+
+// searchPickaxe finds the commits that changed the number of occurrences of query in a
+// file, like git log -S: the commits that introduced or removed it. Each commit is
+// compared with its parent, or with an empty tree if it is the first commit; merges are
+// skipped, as git log does by default. The match is case sensitive.
+func searchPickaxe(repo *git.Repository, query string) ([]SearchResult, error) {
+	var results []SearchResult
+	if query == "" {
+		return results, nil
+	}
+
+	ref, err := gitservice.Head(repo)
+	if err != nil {
+		return results, err
+	}
+
+	cIter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return results, err
+	}
+
+	err = cIter.ForEach(func(c *object.Commit) error {
+		if c.NumParents() > 1 {
+			return nil
+		}
+
+		tree, err := c.Tree()
+		if err != nil {
+			return nil // Continue with other commits
+		}
+		var parentTree *object.Tree
+		if c.NumParents() == 1 {
+			parent, err := c.Parent(0)
+			if err != nil {
+				return nil
+			}
+			if parentTree, err = parent.Tree(); err != nil {
+				return nil
+			}
+		}
+
+		changes, err := object.DiffTree(parentTree, tree)
+		if err != nil {
+			return nil
+		}
+		for _, change := range changes {
+			path, counts, ok := pickaxeCount(change, query)
+			if !ok || counts.Before == counts.After {
+				continue
+			}
+			results = append(results, SearchResult{
+				Type:      "pickaxe",
+				ItemTitle: fmt.Sprintf("%s %s (commit %s)", counts.icon(), path, c.Hash.String()[:8]),
+				ItemDesc: fmt.Sprintf("%s • %s • %s", counts.verb(), c.Author.Name,
+					gitservice.ListDate(c.Author.When, "2006-01-02")),
+				Hash:     c.Hash.String(),
+				Author:   c.Author.Name,
+				Date:     c.Author.When,
+				FilePath: path,
+				Content:  c.Message,
+				Commit:   c,
+				pickaxe:  counts,
+			})
+		}
+		return nil
+	})
+
+	return results, err
+}
+
+// pickaxeCount counts the occurrences of query on both sides of a change. ok is false for
+// binary and large files, which aren't searched.
+func pickaxeCount(change *object.Change, query string) (string, pickaxeChange, bool) {
+	from, to, err := change.Files()
+	if err != nil {
+		return "", pickaxeChange{}, false
+	}
+
+	var counts pickaxeChange
+	path := change.To.Name
+	if to == nil {
+		path = change.From.Name
+	}
+	for _, side := range []struct {
+		file  *object.File
+		count *int
+	}{{from, &counts.Before}, {to, &counts.After}} {
+		if side.file == nil {
+			continue
+		}
+		content, err := fileText(side.file)
+		if err != nil {
+			return "", pickaxeChange{}, false
+		}
+		*side.count = strings.Count(content, query)
+	}
+	return path, counts, true
+}
+
+var errNotSearchable = errors.New("binary or large file")
+
+// fileText returns the content of a text file, skipping large and binary files like the
+// content search does
+func fileText(f *object.File) (string, error) {
+	if f.Size > 512*1024 { // 512KB limit
+		return "", errNotSearchable
+	}
+	content, err := f.Contents()
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(content, "\x00") {
+		return "", errNotSearchable
+	}
+	return gitservice.DisplayText(content), nil
+}
@@ -6,9 +6,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -17,22 +20,55 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type SearchOptions struct {
+	RepoPath      string // Repository to search; empty means the current directory
 	Query         []string
 	SearchCommits bool
 	SearchFiles   bool
 	SearchContent bool
 	SearchAuthors bool
 	SearchCurrent bool
+	// SearchPickaxe finds the commits that introduced or removed the query, like git log -S
+	SearchPickaxe bool
 	CaseSensitive bool
 	MaxResults    int
 	SinceDate     string
 	UntilDate     string
 	AuthorFilter  string
 	FileFilter    string
+	// MatchesPerFile is how many content matches are listed for each file; 0 lists every
+	// match. The content searches stop at MaxResults results either way.
+	MatchesPerFile int
+	// ContextLines is how many lines are shown around a content match; 0 uses
+	// DefaultContextLines
+	ContextLines int
+	// Signatures controls whether commit signatures are verified or only detected
+	Signatures gitservice.SignatureOptions
+}
+
+// DefaultContextLines is how many lines are shown around a content match by default
+const DefaultContextLines = 5
+
+// matchLimits caps the results of the content searches. A limit of 0 means no limit.
+type matchLimits struct {
+	perFile int // matches listed for each file
+	total   int // results of the search type
+}
+
+// fileFull reports whether a file with this many matches listed is at the per-file limit
+func (l matchLimits) fileFull(matches int) bool {
+	return l.perFile > 0 && matches >= l.perFile
+}
+
+// full reports whether results are at the limit for the search type
+func (l matchLimits) full(results []SearchResult) bool {
+	return l.total > 0 && len(results) >= l.total
 }
 
 type SearchResult struct {
@@ -46,13 +82,17 @@ type SearchResult struct {
 	LineNumber int
 	Content    string
 	Commit     *object.Commit
+	// Signature is filled in when a commit result is opened
+	Signature *gitservice.CommitSignature
+	// pickaxe is how a pickaxe result's commit changed the occurrences of the query
+	pickaxe pickaxeChange
 }
 
 func (s SearchResult) Title() string       { return s.ItemTitle }
 func (s SearchResult) Description() string { return s.ItemDesc }
 func (s SearchResult) FilterValue() string {
 	// Return all searchable content in lowercase for case-insensitive filtering
-	return strings.ToLower(s.ItemTitle + " " + s.ItemDesc + " " + s.Content + " " + s.Author + " " + s.FilePath)
+	return strings.ToLower(s.ItemTitle + " " + s.ItemDesc + " " + s.Content + " " + s.Author + " " + s.FilePath + " " + s.Hash)
 }
 
 type SearchMode int
@@ -66,20 +106,40 @@ const (
 type model struct {
 	searchInput    textinput.Model
 	resultsList    list.Model
+	listDelegate   list.ItemDelegate
 	spinner        spinner.Model
 	currentMode    SearchMode
 	searchQuery    string
 	results        []SearchResult
 	selectedResult *SearchResult
 	loading        bool
+	// search identifies the running search, so batches of an abandoned one are dropped
+	search         int
+	batches        <-chan searchResultBatchMsg
+	resultsBy      [numSearchCategories][]SearchResult
 	searchProgress string
 	err            error
 	tuiHelper      *terminal.ResponsiveTUIHelper
 	searchOptions  SearchOptions
+	contextLines   int  // Lines shown around content matches, changed with +/-
+	combinedDiff   bool // Show merges' combined diff instead of the first-parent changes, toggled with m
+	repoRoot       string
+	clipboard      terminal.ClipboardNotice
+	keys           terminal.KeyMap
+	verifier       *gitservice.SignatureVerifier
+}
+
+// searchResultBatchMsg delivers the results of one search category as soon as it
+// completes
+type searchResultBatchMsg struct {
+	search   int
+	category searchCategory
+	results  []SearchResult
 }
 
+// searchCompletedMsg is sent once every category of a search has delivered its batch
 type searchCompletedMsg struct {
-	results []SearchResult
+	search int
 }
 
 type searchProgressMsg struct {
@@ -98,39 +158,39 @@ func (e errMsg) Error() string { return e.err.Error() }
 
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("39")).
+			Foreground(theme.Current.Primary).
 			Bold(true).
 			Padding(0, 1)
 
 	statusStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(theme.Current.Muted).
 			Italic(true)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 
 	detailStyle = lipgloss.NewStyle().
 			Padding(1).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("39"))
+			BorderForeground(theme.Current.Border)
 
 	searchStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("211"))
+			Foreground(theme.Current.Accent)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
+			Foreground(theme.Current.Error).
 			Bold(true)
 
 	matchStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("226")).
-			Background(lipgloss.Color("235")).
+			Foreground(theme.Current.Highlight).
+			Background(theme.Current.Subtle).
 			Bold(true)
 )
 
 func initialModelWithOptions(opts SearchOptions) model {
 	searchInput := textinput.New()
-	searchInput.Placeholder = "Enter search query (commits, files, content, authors)..."
+	searchInput.Placeholder = "Enter search query (commits, files, content, authors), or nothing for recent commits..."
 	searchInput.CharLimit = 256
 	searchInput.Focus()
 
@@ -140,7 +200,8 @@ func initialModelWithOptions(opts SearchOptions) model {
 		searchInput.SetValue(query)
 	}
 
-	resultsList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	delegate := list.NewDefaultDelegate()
+	resultsList := list.New([]list.Item{}, delegate, 0, 0)
 	resultsList.Title = "Search Results"
 	resultsList.SetShowStatusBar(false)
 	resultsList.SetFilteringEnabled(true) // Enable built-in filtering
@@ -148,16 +209,34 @@ func initialModelWithOptions(opts SearchOptions) model {
 	// Initialize spinner
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Style = lipgloss.NewStyle().Foreground(theme.Current.Accent)
+
+	// Current-file results are relative to the repository root; fall back to RepoPath
+	// if it can't be resolved so performAdvancedSearch reports the error instead
+	repoRoot := opts.RepoPath
+	if repo, err := gitservice.OpenRepo(opts.RepoPath); err == nil {
+		if root, err := gitservice.RepoRoot(repo); err == nil {
+			repoRoot = root
+		}
+	}
 
 	m := model{
 		searchInput:   searchInput,
 		resultsList:   resultsList,
+		listDelegate:  delegate,
 		spinner:       s,
 		currentMode:   InputMode,
 		tuiHelper:     terminal.NewResponsiveTUIHelper(),
+		keys:          terminal.Keys(),
 		searchOptions: opts,
+		contextLines:  opts.ContextLines,
+		repoRoot:      repoRoot,
 	}
+	if m.contextLines <= 0 {
+		m.contextLines = DefaultContextLines
+	}
+
+	m.keys.ApplyToList(&m.resultsList)
 
 	return m
 }
@@ -180,59 +259,219 @@ func (m model) Init() tea.Cmd {
 	return textinput.Blink
 }
 
-func performAdvancedSearch(query string, options SearchOptions) tea.Msg {
-	// This function performs a comprehensive search based on specified options:
-	// - Git history (commits, messages, authors)
-	// - Historical file names across all commits
-	// - File content (both current and historical)
-	// - Current filesystem
+// searchCategory is one kind of search. Results are listed in category order, whatever
+// order the categories complete in.
+type searchCategory int
+
+const (
+	commitSearch searchCategory = iota
+	historicalFileSearch
+	historicalContentSearch
+	pickaxeSearch
+	currentFileSearch
+	authorSearch
+	numSearchCategories
+)
+
+// enabled reports whether options turn the category on
+func (c searchCategory) enabled(options SearchOptions) bool {
+	switch c {
+	case commitSearch:
+		return options.SearchCommits
+	case historicalFileSearch:
+		return options.SearchFiles
+	case historicalContentSearch:
+		return options.SearchContent
+	case pickaxeSearch:
+		return options.SearchPickaxe
+	case currentFileSearch:
+		return options.SearchCurrent
+	case authorSearch:
+		return options.SearchAuthors
+	}
+	return false
+}
 
-	var allResults []SearchResult
+// run searches the category. Each category opens the repository itself, as a go-git
+// repository is not safe for concurrent use.
+func (c searchCategory) run(options SearchOptions, root, query string) ([]SearchResult, error) {
+	limits := matchLimits{perFile: options.MatchesPerFile, total: options.MaxResults}
+	if c == currentFileSearch {
+		return searchCurrentFiles(root, query, limits)
+	}
 
-	repo, err := git.PlainOpen(".")
+	repo, err := gitservice.OpenRepo(options.RepoPath)
+	if err != nil {
+		return nil, err
+	}
+	switch c {
+	case commitSearch:
+		if query == "" {
+			return searchRecentCommits(repo, options.MaxResults)
+		}
+		return searchCommits(repo, query)
+	case historicalFileSearch:
+		return searchHistoricalFiles(repo, query)
+	case historicalContentSearch:
+		return searchHistoricalContent(repo, query, limits)
+	case pickaxeSearch:
+		return searchPickaxe(repo, query)
+	case authorSearch:
+		return searchAuthors(repo, query)
+	}
+	return nil, nil
+}
+
+// startSearch starts a search, returning the channel its batches arrive on and the
+// command that runs it.
+func startSearch(search int, query string, options SearchOptions) (<-chan searchResultBatchMsg, tea.Cmd) {
+	// Buffered for every category, so an abandoned search never blocks
+	batches := make(chan searchResultBatchMsg, numSearchCategories)
+	return batches, func() tea.Msg {
+		return performAdvancedSearch(search, query, options, batches)
+	}
+}
+
+// performAdvancedSearch runs the enabled categories concurrently, sending each one's
+// results to batches as it completes and closing batches when all are done:
+// - Git history (commits, messages, authors)
+// - Historical file names across all commits
+// - File content (both current and historical)
+// - Current filesystem
+//
+// Quick categories like file names are shown while slow ones like historical content are
+// still running.
+func performAdvancedSearch(search int, query string, options SearchOptions, batches chan<- searchResultBatchMsg) tea.Msg {
+	defer close(batches)
+
+	repo, err := gitservice.OpenRepo(options.RepoPath)
 	if err != nil {
 		return errMsg{err}
 	}
 
-	// Search based on enabled options
-	if options.SearchCommits {
-		if commitResults, err := searchCommits(repo, query); err == nil {
-			allResults = append(allResults, commitResults...)
-		}
+	root, err := gitservice.RepoRoot(repo)
+	if err != nil {
+		return errMsg{err}
 	}
 
-	if options.SearchFiles {
-		if fileResults, err := searchHistoricalFiles(repo, query); err == nil {
-			allResults = append(allResults, fileResults...)
+	var wg sync.WaitGroup
+	for category := range numSearchCategories {
+		enabled := category.enabled(options)
+		if query == "" {
+			// An empty query lists the recent commits, whatever categories are enabled
+			enabled = category == commitSearch
 		}
+		if !enabled {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A category that fails just has no results
+			results, err := category.run(options, root, query)
+			if err != nil {
+				results = nil
+			}
+			batches <- searchResultBatchMsg{search: search, category: category, results: results}
+		}()
 	}
+	wg.Wait()
 
-	if options.SearchContent {
-		if contentResults, err := searchHistoricalContent(repo, query); err == nil {
-			allResults = append(allResults, contentResults...)
+	return nil
+}
+
+// waitForBatch returns a command that delivers the next batch of a search, or
+// searchCompletedMsg once all have arrived.
+func waitForBatch(search int, batches <-chan searchResultBatchMsg) tea.Cmd {
+	return func() tea.Msg {
+		batch, ok := <-batches
+		if !ok {
+			return searchCompletedMsg{search: search}
 		}
+		return batch
 	}
+}
 
-	if options.SearchCurrent {
-		if currentResults, err := searchCurrentFiles(query); err == nil {
-			allResults = append(allResults, currentResults...)
-		}
+// startSearch resets the results and starts searching for query
+func (m *model) startSearch(query string) tea.Cmd {
+	m.loading = true
+	m.searchQuery = query
+	m.search++
+	m.results = nil
+	m.resultsBy = [numSearchCategories][]SearchResult{}
+	m.resultsList.SetItems(nil)
+
+	var run tea.Cmd
+	m.batches, run = startSearch(m.search, query, m.searchOptions)
+	return tea.Batch(m.spinner.Tick, run, waitForBatch(m.search, m.batches))
+}
+
+// stopSearch stops showing the running search, dropping the batches still to come
+func (m *model) stopSearch() {
+	if m.loading {
+		m.loading = false
+		m.search++
 	}
+}
 
-	if options.SearchAuthors {
-		if authorResults, err := searchAuthors(repo, query); err == nil {
-			allResults = append(allResults, authorResults...)
-		}
+// addBatch adds the results of one category, keeping the list in category order
+func (m *model) addBatch(batch searchResultBatchMsg) {
+	m.resultsBy[batch.category] = batch.results
+
+	m.results = nil
+	for _, results := range m.resultsBy {
+		m.results = append(m.results, results...)
+	}
+
+	items := make([]list.Item, len(m.results))
+	for i, result := range m.results {
+		items[i] = result
 	}
+	m.resultsList.SetItems(items)
 
-	return searchCompletedMsg{results: allResults}
+	if len(m.results) > 0 && m.currentMode == InputMode {
+		m.currentMode = ResultsMode
+	}
 }
 
+// hashPrefixPattern matches queries that could be an abbreviated commit hash
+var hashPrefixPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
 func searchCommits(repo *git.Repository, query string) ([]SearchResult, error) {
 	var results []SearchResult
 	queryLower := strings.ToLower(query)
 
-	ref, err := repo.Head()
+	// Commits found by hash come first and are not repeated as message matches
+	found := make(map[plumbing.Hash]bool)
+	if hashPrefixPattern.MatchString(query) {
+		hashResults, err := searchCommitHash(repo, queryLower)
+		if err != nil {
+			return results, err
+		}
+		for _, result := range hashResults {
+			found[result.Commit.Hash] = true
+		}
+		results = append(results, hashResults...)
+	}
+
+	messageResults, err := logCommits(repo, "📝", 0, func(c *object.Commit) bool {
+		return strings.Contains(strings.ToLower(c.Message), queryLower) && !found[c.Hash]
+	})
+	return append(results, messageResults...), err
+}
+
+// searchRecentCommits lists the last limit commits from HEAD, newest first, for browsing
+// the history without a query. A limit of 0 lists every commit.
+func searchRecentCommits(repo *git.Repository, limit int) ([]SearchResult, error) {
+	return logCommits(repo, "🕒", limit, func(*object.Commit) bool { return true })
+}
+
+// logCommits walks the history from HEAD and returns the commits match accepts as
+// results titled with icon, stopping after limit results (0 for no limit).
+func logCommits(repo *git.Repository, icon string, limit int, match func(*object.Commit) bool) ([]SearchResult, error) {
+	var results []SearchResult
+
+	ref, err := gitservice.Head(repo)
 	if err != nil {
 		return results, err
 	}
@@ -243,19 +482,11 @@ func searchCommits(repo *git.Repository, query string) ([]SearchResult, error) {
 	}
 
 	err = cIter.ForEach(func(c *object.Commit) error {
-		messageLower := strings.ToLower(c.Message)
-		if strings.Contains(messageLower, queryLower) {
-			firstLine := strings.Split(c.Message, "\n")[0]
-			results = append(results, SearchResult{
-				Type:      "commit",
-				ItemTitle: fmt.Sprintf("📝 %s", firstLine),
-				ItemDesc:  fmt.Sprintf("%s • %s • %s", c.Hash.String()[:8], c.Author.Name, c.Author.When.Format("2006-01-02")),
-				Hash:      c.Hash.String(),
-				Author:    c.Author.Name,
-				Date:      c.Author.When,
-				Content:   c.Message,
-				Commit:    c,
-			})
+		if limit > 0 && len(results) >= limit {
+			return storer.ErrStop
+		}
+		if match(c) {
+			results = append(results, commitResult(c, icon))
 		}
 		return nil
 	})
@@ -263,12 +494,67 @@ func searchCommits(repo *git.Repository, query string) ([]SearchResult, error) {
 	return results, err
 }
 
+// searchCommitHash returns the commits whose hash starts with prefix, including commits
+// that are no longer on any branch. A unique prefix gives its one commit, even when a
+// ref has the same name; an ambiguous one lists every candidate so the user can pick the
+// right commit.
+func searchCommitHash(repo *git.Repository, prefix string) ([]SearchResult, error) {
+	var candidates []*object.Commit
+
+	cIter, err := repo.CommitObjects()
+	if err != nil {
+		return nil, err
+	}
+	err = cIter.ForEach(func(c *object.Commit) error {
+		if strings.HasPrefix(c.Hash.String(), prefix) {
+			candidates = append(candidates, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return []SearchResult{commitResult(candidates[0], "🔑")}, nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Committer.When.After(candidates[j].Committer.When)
+	})
+
+	results := make([]SearchResult, len(candidates))
+	for i, c := range candidates {
+		results[i] = commitResult(c, "🔑")
+		results[i].ItemDesc += fmt.Sprintf(" • ambiguous: %d commits start with %s", len(candidates), prefix)
+	}
+	return results, nil
+}
+
+// commitResult builds a commit search result, titled with icon and the commit's subject.
+func commitResult(c *object.Commit, icon string) SearchResult {
+	firstLine := strings.Split(c.Message, "\n")[0]
+	return SearchResult{
+		Type:      "commit",
+		ItemTitle: fmt.Sprintf("%s %s", icon, firstLine),
+		ItemDesc:  fmt.Sprintf("%s • %s • %s", c.Hash.String()[:8], c.Author.Name, gitservice.ListDate(c.Author.When, "2006-01-02")),
+		Hash:      c.Hash.String(),
+		Author:    c.Author.Name,
+		Date:      c.Author.When,
+		Content:   c.Message,
+		Commit:    c,
+	}
+}
+
 func searchAuthors(repo *git.Repository, query string) ([]SearchResult, error) {
 	var results []SearchResult
 	queryLower := strings.ToLower(query)
 	authorCommits := make(map[string][]*object.Commit)
 
-	ref, err := repo.Head()
+	ref, err := gitservice.Head(repo)
 	if err != nil {
 		return results, err
 	}
@@ -309,7 +595,7 @@ func searchHistoricalFiles(repo *git.Repository, query string) ([]SearchResult,
 	queryLower := strings.ToLower(query)
 	seenFiles := make(map[string]bool)
 
-	ref, err := repo.Head()
+	ref, err := gitservice.Head(repo)
 	if err != nil {
 		return results, err
 	}
@@ -347,13 +633,14 @@ func searchHistoricalFiles(repo *git.Repository, query string) ([]SearchResult,
 	return results, err
 }
 
-// searchHistoricalContent searches through file content across git history
-func searchHistoricalContent(repo *git.Repository, query string) ([]SearchResult, error) {
+// searchHistoricalContent searches through file content across git history, listing up
+// to limits.perFile matches for each file in each commit
+func searchHistoricalContent(repo *git.Repository, query string, limits matchLimits) ([]SearchResult, error) {
 	var results []SearchResult
 	queryLower := strings.ToLower(query)
 	regex, _ := regexp.Compile("(?i)" + regexp.QuoteMeta(query))
 
-	ref, err := repo.Head()
+	ref, err := gitservice.Head(repo)
 	if err != nil {
 		return results, err
 	}
@@ -368,8 +655,8 @@ func searchHistoricalContent(repo *git.Repository, query string) ([]SearchResult
 	maxCommits := 100
 
 	err = cIter.ForEach(func(c *object.Commit) error {
-		if commitCount >= maxCommits {
-			return fmt.Errorf("reached commit limit") // Stop iteration
+		if commitCount >= maxCommits || limits.full(results) {
+			return storer.ErrStop
 		}
 		commitCount++
 
@@ -379,6 +666,9 @@ func searchHistoricalContent(repo *git.Repository, query string) ([]SearchResult
 		}
 
 		_ = tree.Files().ForEach(func(f *object.File) error {
+			if limits.full(results) {
+				return storer.ErrStop
+			}
 			// Skip large files and binary files
 			if f.Size > 512*1024 { // 512KB limit
 				return nil
@@ -388,10 +678,12 @@ func searchHistoricalContent(repo *git.Repository, query string) ([]SearchResult
 			if err != nil || strings.Contains(content, "\x00") {
 				return nil // Skip binary files
 			}
+			content = gitservice.DisplayText(content)
 
 			contentLower := strings.ToLower(content)
 			if strings.Contains(contentLower, queryLower) {
 				lines := strings.Split(content, "\n")
+				matches := 0
 				for i, line := range lines {
 					lineLower := strings.ToLower(line)
 					if strings.Contains(lineLower, queryLower) {
@@ -405,7 +697,7 @@ func searchHistoricalContent(repo *git.Repository, query string) ([]SearchResult
 						results = append(results, SearchResult{
 							Type:       "historical-content",
 							ItemTitle:  fmt.Sprintf("🔍 %s:%d (commit %s)", f.Name, i+1, c.Hash.String()[:8]),
-							ItemDesc:   fmt.Sprintf("Historical content • Line %d • %s", i+1, c.Author.When.Format("2006-01-02")),
+							ItemDesc:   fmt.Sprintf("Historical content • Line %d • %s", i+1, gitservice.ListDate(c.Author.When, "2006-01-02")),
 							FilePath:   f.Name,
 							LineNumber: i + 1,
 							Hash:       c.Hash.String(),
@@ -413,8 +705,10 @@ func searchHistoricalContent(repo *git.Repository, query string) ([]SearchResult
 							Content:    strings.TrimSpace(highlightedLine),
 						})
 
-						// Limit results per file
-						return nil
+						matches++
+						if limits.fileFull(matches) || limits.full(results) {
+							return nil
+						}
 					}
 				}
 			}
@@ -426,20 +720,33 @@ func searchHistoricalContent(repo *git.Repository, query string) ([]SearchResult
 	return results, err
 }
 
-// searchCurrentFiles searches through current filesystem files
-func searchCurrentFiles(query string) ([]SearchResult, error) {
+// searchCurrentFiles searches through current filesystem files under root, listing up to
+// limits.perFile content matches for each file. Result paths are relative to root.
+func searchCurrentFiles(root, query string, limits matchLimits) ([]SearchResult, error) {
 	var results []SearchResult
 	queryLower := strings.ToLower(query)
 	regex, _ := regexp.Compile("(?i)" + regexp.QuoteMeta(query))
 
-	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+	err := filepath.WalkDir(root, func(absPath string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil // Continue walking
 		}
+		if limits.full(results) {
+			return filepath.SkipAll
+		}
+
+		// Skip root itself, even if its name starts with "."
+		if absPath == root {
+			return nil
+		}
+
+		path, err := filepath.Rel(root, absPath)
+		if err != nil {
+			return nil
+		}
 
 		// Skip hidden directories and files, and common ignore patterns
-		// But don't skip the current directory "."
-		if (strings.HasPrefix(d.Name(), ".") && d.Name() != ".") ||
+		if strings.HasPrefix(d.Name(), ".") ||
 			strings.Contains(path, "node_modules") ||
 			strings.Contains(path, "vendor") ||
 			strings.Contains(path, "dist") ||
@@ -469,18 +776,19 @@ func searchCurrentFiles(query string) ([]SearchResult, error) {
 		// Check file content for text files
 		if isTextFile(path) {
 			// #nosec G304 - CLI tool reads files from git repository by design
-			content, err := os.ReadFile(path)
+			content, err := os.ReadFile(absPath)
 			if err != nil || len(content) > 1024*1024 { // 1MB limit
 				return nil
 			}
-			contentStr := string(content)
-			if strings.Contains(contentStr, "\x00") {
+			if strings.Contains(string(content), "\x00") {
 				return nil // Skip binary files
 			}
+			contentStr := gitservice.DisplayText(string(content))
 
 			contentLower := strings.ToLower(contentStr)
 			if strings.Contains(contentLower, queryLower) {
 				lines := strings.Split(contentStr, "\n")
+				matches := 0
 				for i, line := range lines {
 					lineLower := strings.ToLower(line)
 					if strings.Contains(lineLower, queryLower) {
@@ -500,8 +808,10 @@ func searchCurrentFiles(query string) ([]SearchResult, error) {
 							Content:    strings.TrimSpace(highlightedLine),
 						})
 
-						// Limit results per file
-						break
+						matches++
+						if limits.fileFull(matches) || limits.full(results) {
+							break
+						}
 					}
 				}
 			}
@@ -542,6 +852,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.resultsList.SetHeight(m.tuiHelper.GetHeight() - 8)
 		return m, nil
 
+	case tea.MouseMsg:
+		// The results list is drawn at the top of the screen in results mode
+		if m.currentMode == ResultsMode &&
+			terminal.HandleListMouse(&m.resultsList, m.listDelegate, 0, msg) {
+			// Clicking a result opens it, like pressing enter
+			return m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		}
+		return m, nil
+
 	case spinner.TickMsg:
 		if m.loading {
 			m.spinner, cmd = m.spinner.Update(msg)
@@ -549,34 +868,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case initialSearchMsg:
-		m.loading = true
-		m.searchQuery = msg.query
-		return m, tea.Batch(
-			m.spinner.Tick,
-			func() tea.Msg {
-				return performAdvancedSearch(msg.query, m.searchOptions)
-			},
-		)
+		return m, m.startSearch(msg.query)
 
 	case searchProgressMsg:
 		m.searchProgress = msg.message
 		return m, nil
 
-	case searchCompletedMsg:
-		m.loading = false
-		m.searchProgress = ""
-		m.results = msg.results
-
-		// Convert to list items
-		items := make([]list.Item, len(msg.results))
-		for i, result := range msg.results {
-			items[i] = result
+	case searchResultBatchMsg:
+		if msg.search != m.search {
+			return m, nil
 		}
-		m.resultsList.SetItems(items)
+		m.addBatch(msg)
+		return m, waitForBatch(m.search, m.batches)
 
-		if len(msg.results) > 0 {
-			m.currentMode = ResultsMode
+	case searchCompletedMsg:
+		if msg.search != m.search {
+			return m, nil
 		}
+		m.loading = false
+		m.searchProgress = ""
 		return m, nil
 
 	case errMsg:
@@ -585,23 +895,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		return m, nil
 
+	case terminal.ClipboardMsg, terminal.ClipboardClearMsg:
+		return m, m.clipboard.Update(msg)
+
 	case tea.KeyMsg:
 		switch m.currentMode {
 		case InputMode:
-			switch msg.String() {
-			case "ctrl+c":
+			// Letter keys are typed into the query, so only ctrl+c quits here
+			switch {
+			case msg.String() == "ctrl+c":
 				return m, tea.Quit
-			case "enter":
-				if m.searchInput.Value() != "" {
-					m.loading = true
-					m.searchQuery = m.searchInput.Value()
-					return m, tea.Batch(
-						m.spinner.Tick,
-						func() tea.Msg {
-							return performAdvancedSearch(m.searchQuery, m.searchOptions)
-						},
-					)
-				}
+			case key.Matches(msg, m.keys.Select):
+				// An empty query lists the recent commits
+				return m, m.startSearch(m.searchInput.Value())
 			default:
 				var cmd tea.Cmd
 				m.searchInput, cmd = m.searchInput.Update(msg)
@@ -611,10 +917,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case ResultsMode:
 			// If we're in filter mode, let the list handle all input except esc
 			if m.resultsList.FilterState() == list.Filtering {
-				switch msg.String() {
-				case "q", "ctrl+c":
+				switch {
+				case key.Matches(msg, m.keys.Quit):
 					return m, tea.Quit
-				case "esc":
+				case key.Matches(msg, m.keys.Back):
 					// Exit filter mode but stay in results
 					var cmd tea.Cmd
 					m.resultsList, cmd = m.resultsList.Update(msg)
@@ -628,24 +934,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			// Normal results mode (not filtering)
-			switch msg.String() {
-			case "q", "ctrl+c":
+			switch {
+			case key.Matches(msg, m.keys.Quit):
 				return m, tea.Quit
-			case "esc":
+			case key.Matches(msg, m.keys.Back):
 				// Go back to input mode
+				m.stopSearch()
 				m.currentMode = InputMode
 				m.searchInput.Focus()
 				return m, nil
-			case "enter":
+			case key.Matches(msg, m.keys.Select):
 				if selected := m.resultsList.SelectedItem(); selected != nil {
 					if result, ok := selected.(SearchResult); ok {
+						if result.Commit != nil {
+							signature := m.verifier.Check(result.Commit)
+							result.Signature = &signature
+						}
 						m.selectedResult = &result
 						m.currentMode = DetailMode
 					}
 				}
 				return m, nil
-			case "n":
+			case key.Matches(msg, m.keys.Copy):
+				if result, ok := m.resultsList.SelectedItem().(SearchResult); ok && result.Hash != "" {
+					return m, terminal.CopyCmd(result.Hash)
+				}
+				return m, nil
+			case msg.String() == "n":
 				// New search
+				m.stopSearch()
 				m.currentMode = InputMode
 				m.searchInput.SetValue("")
 				m.searchInput.Focus()
@@ -657,11 +974,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case DetailMode:
-			switch msg.String() {
-			case "esc", "q":
+			switch {
+			case key.Matches(msg, m.keys.Back, m.keys.Quit):
 				m.currentMode = ResultsMode
 				m.selectedResult = nil
 				return m, nil
+			case key.Matches(msg, m.keys.Copy):
+				if m.selectedResult != nil && m.selectedResult.Hash != "" {
+					return m, terminal.CopyCmd(m.selectedResult.Hash)
+				}
+				return m, nil
+			case msg.String() == "+" || msg.String() == "=":
+				m.contextLines++
+				return m, nil
+			case msg.String() == "-":
+				m.contextLines = max(1, m.contextLines-1)
+				return m, nil
+			case msg.String() == "m" && m.selectedResult != nil && isMerge(m.selectedResult.Commit):
+				m.combinedDiff = !m.combinedDiff
+				return m, nil
 			}
 		}
 	}
@@ -670,7 +1001,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) View() string {
-	if m.loading {
+	// Results are shown as they arrive
+	if m.loading && m.currentMode != ResultsMode {
 		loadingText := fmt.Sprintf("%s Searching...", m.spinner.View())
 		if m.searchProgress != "" {
 			loadingText += fmt.Sprintf("\n%s", statusStyle.Render(m.searchProgress))
@@ -688,7 +1020,8 @@ func (m model) View() string {
 			"%s\n\n%s\n\n%s",
 			titleStyle.Render("🔍 Advanced Repository Search"),
 			searchStyle.Render("Search: "+m.searchInput.View()),
-			helpStyle.Render("enter: search • q: quit"),
+			helpStyle.Render(terminal.HelpLine(terminal.Help(m.keys.Select, "search (recent commits when empty)"),
+				terminal.Help(m.keys.Quit, "quit"))),
 		)
 
 	case DetailMode:
@@ -701,18 +1034,30 @@ func (m model) View() string {
 		// Check if we're in filter mode
 		filterHelp := ""
 		if m.resultsList.FilterState() == list.Filtering {
-			filterHelp = " • filtering: type to filter, esc to exit filter"
+			filterHelp = fmt.Sprintf(" • filtering: type to filter, %s to exit filter", terminal.HelpKey(m.keys.Back))
 		} else {
-			filterHelp = " • /: filter results"
+			filterHelp = " • " + terminal.Help(m.keys.Filter, "filter results")
 		}
 
-		help := fmt.Sprintf("Found %d results for '%s' • enter: details • n: new search • esc: back%s • q: quit",
-			len(m.results), m.searchQuery, filterHelp)
+		found := "Found"
+		if m.loading {
+			found = m.spinner.View() + " Searching... found"
+		}
+		summary := fmt.Sprintf("%d results for '%s'", len(m.results), m.searchQuery)
+		if m.searchQuery == "" {
+			summary = fmt.Sprintf("%d recent commits", len(m.results))
+		}
+		help := fmt.Sprintf("%s %s • %s%s • %s",
+			found, summary,
+			terminal.HelpLine(terminal.Help(m.keys.Select, "details"),
+				terminal.Help(m.keys.Copy, "copy hash"), "n: new search", terminal.Help(m.keys.Back, "back")),
+			filterHelp, terminal.Help(m.keys.Quit, "quit"))
 
 		return fmt.Sprintf(
-			"%s\n%s",
+			"%s\n%s\n%s",
 			m.resultsList.View(),
 			helpStyle.Render(help),
+			m.clipboard.View(),
 		)
 	}
 }
@@ -736,12 +1081,31 @@ func (m model) renderResultDetail(result SearchResult) string {
 		details.WriteString(m.renderCurrentContentDetail(result))
 	case "author":
 		details.WriteString(m.renderAuthorDetail(result))
+	case "pickaxe":
+		details.WriteString(m.renderPickaxeDetail(result))
 	default:
 		details.WriteString(fmt.Sprintf("Type: %s\nContent: %s", result.Type, result.Content))
 	}
 
 	details.WriteString("\n\n")
-	details.WriteString(helpStyle.Render("esc: back to results • q: quit"))
+	var help []string
+	if result.Hash != "" {
+		help = append(help, terminal.Help(m.keys.Copy, "copy hash"))
+	}
+	// Content matches are shown with the lines around them
+	if result.LineNumber > 0 {
+		help = append(help, fmt.Sprintf("+/-: context (%d lines)", m.contextLines))
+	}
+	if isMerge(result.Commit) {
+		mode := "first parent"
+		if m.combinedDiff {
+			mode = "combined"
+		}
+		help = append(help, fmt.Sprintf("m: merge diff (%s)", mode))
+	}
+	help = append(help, terminal.Help(m.keys.Back, "back to results"), terminal.Help(m.keys.Quit, "quit"))
+	details.WriteString(helpStyle.Render(terminal.HelpLine(help...)))
+	details.WriteString("\n" + m.clipboard.View())
 
 	return details.String()
 }
@@ -751,12 +1115,23 @@ func (m model) renderCommitDetail(result SearchResult) string {
 
 	content.WriteString(fmt.Sprintf("📝 Hash: %s\n", result.Hash))
 	content.WriteString(fmt.Sprintf("👤 Author: %s\n", result.Author))
-	content.WriteString(fmt.Sprintf("📅 Date: %s\n\n", result.Date.Format("2006-01-02 15:04:05")))
+	content.WriteString(fmt.Sprintf("📅 Date: %s\n", gitservice.DetailDate(result.Date, "2006-01-02 15:04:05")))
+	if result.Signature != nil {
+		icon := result.Signature.Icon()
+		if icon == "" {
+			icon = "✍️"
+		}
+		content.WriteString(fmt.Sprintf("%s Signature: %s\n", icon, result.Signature))
+	}
+	content.WriteString("\n")
 
 	content.WriteString("💬 Message:\n")
 	content.WriteString(detailStyle.Render(result.Content))
 
-	if result.Commit != nil {
+	if result.Commit != nil && m.combinedDiff && isMerge(result.Commit) {
+		content.WriteString("\n\n📋 Combined diff:\n")
+		content.WriteString(detailStyle.Render(combinedDiffText(result.Commit)))
+	} else if result.Commit != nil {
 		content.WriteString("\n\n📋 Changes:\n")
 		if diff := m.getCommitDiff(result.Commit); diff != "" {
 			content.WriteString(detailStyle.Render(diff))
@@ -768,13 +1143,25 @@ func (m model) renderCommitDetail(result SearchResult) string {
 	return content.String()
 }
 
+func (m model) renderPickaxeDetail(result SearchResult) string {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("📁 File: %s\n", result.FilePath))
+	content.WriteString(fmt.Sprintf("%s %s of %q (%d → %d)\n", result.pickaxe.icon(), result.pickaxe.verb(),
+		m.searchQuery, result.pickaxe.Before, result.pickaxe.After))
+	content.WriteString("\n")
+	content.WriteString(m.renderCommitDetail(result))
+
+	return content.String()
+}
+
 func (m model) renderFileDetail(result SearchResult) string {
 	var content strings.Builder
 
 	content.WriteString(fmt.Sprintf("📁 File: %s\n", result.FilePath))
 	if result.Hash != "" {
 		content.WriteString(fmt.Sprintf("📝 Commit: %s\n", result.Hash))
-		content.WriteString(fmt.Sprintf("📅 Date: %s\n", result.Date.Format("2006-01-02 15:04:05")))
+		content.WriteString(fmt.Sprintf("📅 Date: %s\n", gitservice.DetailDate(result.Date, "2006-01-02 15:04:05")))
 	}
 	content.WriteString("\n")
 
@@ -793,7 +1180,7 @@ func (m model) renderContentDetail(result SearchResult) string {
 	content.WriteString(fmt.Sprintf("📍 Line: %d\n", result.LineNumber))
 	if result.Hash != "" {
 		content.WriteString(fmt.Sprintf("📝 Commit: %s\n", result.Hash))
-		content.WriteString(fmt.Sprintf("📅 Date: %s\n", result.Date.Format("2006-01-02 15:04:05")))
+		content.WriteString(fmt.Sprintf("📅 Date: %s\n", gitservice.DetailDate(result.Date, "2006-01-02 15:04:05")))
 	}
 	content.WriteString("\n")
 
@@ -813,9 +1200,9 @@ func (m model) renderCurrentFileDetail(result SearchResult) string {
 
 	content.WriteString(fmt.Sprintf("📄 Current File: %s\n\n", result.FilePath))
 
-	if info, err := os.Stat(result.FilePath); err == nil {
+	if info, err := os.Stat(filepath.Join(m.repoRoot, result.FilePath)); err == nil {
 		content.WriteString(fmt.Sprintf("📏 Size: %d bytes\n", info.Size()))
-		content.WriteString(fmt.Sprintf("📅 Modified: %s\n\n", info.ModTime().Format("2006-01-02 15:04:05")))
+		content.WriteString(fmt.Sprintf("📅 Modified: %s\n\n", gitservice.DetailDate(info.ModTime(), "2006-01-02 15:04:05")))
 	}
 
 	if fileContent := m.getCurrentFileContent(result.FilePath); fileContent != "" {
@@ -892,12 +1279,52 @@ func (m model) getCommitDiff(commit *object.Commit) string {
 	return diff.String()
 }
 
+// maxCombinedLines caps the lines of each file shown in a combined diff
+const maxCombinedLines = 50
+
+func isMerge(commit *object.Commit) bool {
+	return commit != nil && commit.NumParents() > 1
+}
+
+// combinedDiffText formats a merge's combined diff like git show -c, with a column of
+// markers per parent in front of each line
+func combinedDiffText(commit *object.Commit) string {
+	files, err := gitservice.CombinedDiff(commit)
+	if err != nil {
+		return fmt.Sprintf("Unable to retrieve combined diff: %v", err)
+	}
+	if len(files) == 0 {
+		return "No file differs from every parent: the merge took each file from one side"
+	}
+
+	var diff strings.Builder
+	for i, file := range files {
+		if i > 0 {
+			diff.WriteString("\n")
+		}
+		added, removed := file.Counts()
+		diff.WriteString(fmt.Sprintf("🔀 %s (+%d -%d)\n", file.Path, added, removed))
+		if file.Binary {
+			diff.WriteString("Binary file\n")
+			continue
+		}
+		for j, line := range file.Lines {
+			if j >= maxCombinedLines {
+				diff.WriteString(fmt.Sprintf("... (%d more lines)\n", len(file.Lines)-j))
+				break
+			}
+			diff.WriteString(line.String() + "\n")
+		}
+	}
+	return strings.TrimSuffix(diff.String(), "\n")
+}
+
 func (m model) getFileContent(result SearchResult) string {
 	if result.Hash == "" {
 		return ""
 	}
 
-	repo, err := git.PlainOpen(".")
+	repo, err := gitservice.OpenRepo(m.searchOptions.RepoPath)
 	if err != nil {
 		return ""
 	}
@@ -922,7 +1349,7 @@ func (m model) getFileContent(result SearchResult) string {
 		return ""
 	}
 
-	lines := strings.Split(content, "\n")
+	lines := strings.Split(gitservice.DisplayText(content), "\n")
 	if len(lines) > 50 {
 		lines = lines[:50]
 		lines = append(lines, "... (truncated)")
@@ -931,9 +1358,9 @@ func (m model) getFileContent(result SearchResult) string {
 	return strings.Join(lines, "\n")
 }
 
-func (m model) getCurrentFileContent(filepath string) string {
+func (m model) getCurrentFileContent(path string) string {
 	// #nosec G304 - CLI tool reads files from git repository by design
-	content, err := os.ReadFile(filepath)
+	content, err := os.ReadFile(filepath.Join(m.repoRoot, path))
 	if err != nil {
 		return ""
 	}
@@ -942,7 +1369,7 @@ func (m model) getCurrentFileContent(filepath string) string {
 		return "[Binary file]"
 	}
 
-	lines := strings.Split(string(content), "\n")
+	lines := strings.Split(gitservice.DisplayText(string(content)), "\n")
 	if len(lines) > 50 {
 		lines = lines[:50]
 		lines = append(lines, "... (truncated)")
@@ -956,7 +1383,7 @@ func (m model) getContentWithContext(result SearchResult) string {
 		return ""
 	}
 
-	repo, err := git.PlainOpen(".")
+	repo, err := gitservice.OpenRepo(m.searchOptions.RepoPath)
 	if err != nil {
 		return ""
 	}
@@ -981,7 +1408,7 @@ func (m model) getContentWithContext(result SearchResult) string {
 		return ""
 	}
 
-	return m.extractContextLines(content, result.LineNumber, 5)
+	return m.extractContextLines(content, result.LineNumber, m.contextLines)
 }
 
 func (m model) getCurrentContentWithContext(result SearchResult) string {
@@ -990,16 +1417,16 @@ func (m model) getCurrentContentWithContext(result SearchResult) string {
 	}
 
 	// #nosec G304 - CLI tool reads files from git repository by design
-	content, err := os.ReadFile(result.FilePath)
+	content, err := os.ReadFile(filepath.Join(m.repoRoot, result.FilePath))
 	if err != nil {
 		return ""
 	}
 
-	return m.extractContextLines(string(content), result.LineNumber, 5)
+	return m.extractContextLines(string(content), result.LineNumber, m.contextLines)
 }
 
 func (m model) extractContextLines(content string, lineNumber, contextLines int) string {
-	lines := strings.Split(content, "\n")
+	lines := strings.Split(gitservice.DisplayText(content), "\n")
 	if lineNumber > len(lines) {
 		return ""
 	}
@@ -1039,20 +1466,36 @@ func min(a, b int) int {
 func RunAdvancedSearch(args []string) error {
 	// Default options for backward compatibility
 	opts := SearchOptions{
-		Query:         args,
-		SearchCommits: true,
-		SearchFiles:   true,
-		SearchContent: true,
-		SearchAuthors: true,
-		SearchCurrent: true,
-		MaxResults:    100,
+		Query:          args,
+		SearchCommits:  true,
+		SearchFiles:    true,
+		SearchContent:  true,
+		SearchAuthors:  true,
+		SearchCurrent:  true,
+		MaxResults:     100,
+		MatchesPerFile: 1,
+		ContextLines:   DefaultContextLines,
 	}
 	return RunAdvancedSearchWithOptions(opts)
 }
 
 func RunAdvancedSearchWithOptions(opts SearchOptions) error {
-	p := tea.NewProgram(initialModelWithOptions(opts), tea.WithAltScreen())
-	_, err := p.Run()
+	if err := gitservice.CheckHistory(opts.RepoPath); err != nil {
+		return err
+	}
+	verifier, err := opts.Signatures.Verifier()
+	if err != nil {
+		return err
+	}
+
+	if err := terminal.RequireTTY(""); err != nil {
+		return err
+	}
+
+	m := initialModelWithOptions(opts)
+	m.verifier = verifier
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	_, err = p.Run()
 	if err != nil {
 		fmt.Printf("Error running search: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,380 @@
+package searchService
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+// newSearchTestRepo creates a repository with n commits and returns their hashes, oldest first.
+func newSearchTestRepo(t *testing.T, n int) (*gittest.Repo, []plumbing.Hash) {
+	t.Helper()
+
+	repo := gittest.New(t)
+	var hashes []plumbing.Hash
+	for i := 0; i < n; i++ {
+		message := fmt.Sprintf("Commit %d", i)
+		hashes = append(hashes, repo.CommitFile("file.txt", message, message))
+	}
+
+	return repo, hashes
+}
+
+func TestSearchCommitsByHashPrefix(t *testing.T) {
+	repo, hashes := newSearchTestRepo(t, 3)
+	target := hashes[1].String()
+
+	// Upper case queries match too, as hashes are printed in either case
+	results, err := searchCommits(repo.Repository, strings.ToUpper(target[:8]))
+	if err != nil {
+		t.Fatalf("searchCommits() error: %v", err)
+	}
+	if len(results) != 1 || results[0].Hash != target {
+		t.Fatalf("results = %+v, want only %s", results, target)
+	}
+	if results[0].Type != "commit" || results[0].Commit == nil {
+		t.Errorf("result = %+v, want a commit result", results[0])
+	}
+
+	// Words that aren't hex are only matched against messages
+	results, err = searchCommits(repo.Repository, "Commit 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Hash != hashes[2].String() {
+		t.Errorf("message search results = %+v, want %s", results, hashes[2])
+	}
+}
+
+func TestSearchCommitHashIgnoresRefs(t *testing.T) {
+	repo, hashes := newSearchTestRepo(t, 3)
+	prefix := hashes[1].String()[:8]
+
+	// A branch named like the prefix points at another commit, which resolving the
+	// prefix as a revision would pick
+	branch := plumbing.NewHashReference(plumbing.NewBranchReferenceName(prefix), hashes[2])
+	if err := repo.Storer.SetReference(branch); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := searchCommitHash(repo.Repository, prefix)
+	if err != nil {
+		t.Fatalf("searchCommitHash() error: %v", err)
+	}
+	if len(results) != 1 || results[0].Hash != hashes[1].String() {
+		t.Errorf("results = %+v, want only %s", results, hashes[1])
+	}
+}
+
+func TestSearchCommitHashAmbiguous(t *testing.T) {
+	// With more commits than hex digits, at least two hashes share a first digit
+	repo, hashes := newSearchTestRepo(t, 17)
+
+	byPrefix := make(map[string][]string)
+	for _, h := range hashes {
+		prefix := h.String()[:1]
+		byPrefix[prefix] = append(byPrefix[prefix], h.String())
+	}
+
+	for prefix, want := range byPrefix {
+		if len(want) < 2 {
+			continue
+		}
+
+		results, err := searchCommitHash(repo.Repository, prefix)
+		if err != nil {
+			t.Fatalf("searchCommitHash() error: %v", err)
+		}
+		if len(results) != len(want) {
+			t.Fatalf("got %d candidates for %s, want %d", len(results), prefix, len(want))
+		}
+		for _, r := range results {
+			if !strings.Contains(r.ItemDesc, "ambiguous") {
+				t.Errorf("candidate %s not marked ambiguous: %q", r.Hash, r.ItemDesc)
+			}
+		}
+		return
+	}
+	t.Fatal("no shared hash prefix found")
+}
+
+func TestPerformAdvancedSearchBatches(t *testing.T) {
+	repo, _ := newSearchTestRepo(t, 3)
+	opts := SearchOptions{
+		RepoPath:      repo.Dir,
+		SearchCommits: true,
+		SearchFiles:   true,
+		SearchCurrent: true,
+		SearchAuthors: true,
+	}
+
+	batches := make(chan searchResultBatchMsg, numSearchCategories)
+	if msg := performAdvancedSearch(1, "Commit", opts, batches); msg != nil {
+		t.Fatalf("performAdvancedSearch() = %v", msg)
+	}
+
+	// One batch per enabled category, then the channel is closed
+	var received []searchResultBatchMsg
+	for batch := range batches {
+		if batch.search != 1 {
+			t.Errorf("batch of search %d, want 1", batch.search)
+		}
+		received = append(received, batch)
+	}
+	if len(received) != 4 {
+		t.Fatalf("got %d batches, want 4", len(received))
+	}
+
+	// The results are in category order whatever order the batches arrive in
+	sort.Slice(received, func(i, j int) bool { return received[i].category < received[j].category })
+	forward := initialModelWithOptions(opts)
+	backward := initialModelWithOptions(opts)
+	for i := range received {
+		forward.addBatch(received[i])
+		backward.addBatch(received[len(received)-1-i])
+	}
+	if len(forward.results) == 0 {
+		t.Fatal("no results")
+	}
+	for i := range forward.results {
+		if forward.results[i].ItemTitle != backward.results[i].ItemTitle {
+			t.Fatalf("results[%d] = %q, want %q", i, backward.results[i].ItemTitle, forward.results[i].ItemTitle)
+		}
+	}
+	if forward.results[0].Type != "commit" {
+		t.Errorf("first result is a %s, want the commit results first", forward.results[0].Type)
+	}
+}
+
+func TestStaleSearchBatchesDropped(t *testing.T) {
+	m := initialModelWithOptions(SearchOptions{RepoPath: t.TempDir()})
+	m.search = 2
+	m.loading = true
+
+	updated, _ := m.Update(searchResultBatchMsg{search: 1, results: []SearchResult{{ItemTitle: "old"}}})
+	if got := updated.(model); len(got.results) != 0 {
+		t.Errorf("results = %+v, want the stale batch dropped", got.results)
+	}
+	updated, _ = updated.Update(searchCompletedMsg{search: 1})
+	if !updated.(model).loading {
+		t.Error("a stale completion ended the running search")
+	}
+}
+
+func TestSearchPickaxe(t *testing.T) {
+	repo := gittest.New(t)
+
+	// The TODO is introduced, doubled, unrelated lines change, then it is removed
+	var hashes []plumbing.Hash
+	for _, content := range []string{
+		"package main\n",
+		"package main\n// TODO: retry\n",
+		"package main\n// TODO: retry\n// TODO: retry\n",
+		"package main\n\n// TODO: retry\n// TODO: retry\n",
+		"package main\n",
+	} {
+		hashes = append(hashes, repo.CommitFile("main.go", content, fmt.Sprintf("Commit %d", len(hashes))))
+	}
+
+	results, err := searchPickaxe(repo.Repository, "TODO: retry")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Newest first, without the commit that only moved the TODOs
+	want := []struct {
+		hash plumbing.Hash
+		verb string
+	}{
+		{hashes[4], "Removed 2 occurrences"},
+		{hashes[2], "Added 1 occurrence"},
+		{hashes[1], "Introduced 1 occurrence"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i, w := range want {
+		if results[i].Hash != w.hash.String() || results[i].pickaxe.verb() != w.verb || results[i].FilePath != "main.go" {
+			t.Errorf("results[%d] = %s %q in %s, want %s %q", i, results[i].Hash[:8], results[i].pickaxe.verb(),
+				results[i].FilePath, w.hash.String()[:8], w.verb)
+		}
+	}
+
+	// The match is case sensitive, like git log -S
+	if results, _ := searchPickaxe(repo.Repository, "todo: retry"); len(results) != 0 {
+		t.Errorf("got %d results for a differently cased query, want none", len(results))
+	}
+}
+
+func TestDetailContextLines(t *testing.T) {
+	m := initialModelWithOptions(SearchOptions{RepoPath: t.TempDir()})
+	if m.contextLines != DefaultContextLines {
+		t.Fatalf("contextLines = %d, want the default %d", m.contextLines, DefaultContextLines)
+	}
+	m.currentMode = DetailMode
+	m.selectedResult = &SearchResult{Type: "content", LineNumber: 10}
+
+	press := func(key string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(model)
+	}
+	press("+")
+	press("+")
+	if m.contextLines != DefaultContextLines+2 {
+		t.Errorf("contextLines = %d after widening twice, want %d", m.contextLines, DefaultContextLines+2)
+	}
+	for range 10 {
+		press("-")
+	}
+	if m.contextLines != 1 {
+		t.Errorf("contextLines = %d after narrowing, want at least 1", m.contextLines)
+	}
+
+	var content strings.Builder
+	for i := 1; i <= 20; i++ {
+		fmt.Fprintf(&content, "line %d\n", i)
+	}
+	if got := strings.Count(m.extractContextLines(content.String(), 10, m.contextLines), "\n"); got != 3 {
+		t.Errorf("got %d lines around line 10 with 1 line of context, want 3", got)
+	}
+}
+
+func TestCurrentFileDetailFromRepoRoot(t *testing.T) {
+	repo := gittest.New(t)
+	repo.WriteFile("sub/file.txt", "12345")
+
+	// The process runs elsewhere; the result's path is relative to the repository root
+	t.Chdir(t.TempDir())
+	m := initialModelWithOptions(SearchOptions{RepoPath: repo.Dir})
+	if got := m.renderCurrentFileDetail(SearchResult{FilePath: "sub/file.txt"}); !strings.Contains(got, "Size: 5 bytes") {
+		t.Errorf("renderCurrentFileDetail() = %q, want the size of the file in the repository", got)
+	}
+}
+
+func TestSearchContentCRLFAndLatin1(t *testing.T) {
+	repo := gittest.New(t)
+	files := map[string]string{
+		"crlf.txt":   "first line\r\nthe needle is here\r\nlast line\r\n",
+		"latin1.txt": "premi\xe8re ligne\nune na\xefve needle\n",
+	}
+	for name, content := range files {
+		repo.WriteFile(name, content)
+	}
+	repo.Commit("Add files")
+
+	// A UTF-8 query matches the Latin-1 file, on the same line as in the file
+	for _, search := range []struct {
+		name string
+		run  func(query string) ([]SearchResult, error)
+	}{
+		{"historical", func(query string) ([]SearchResult, error) {
+			return searchHistoricalContent(repo.Repository, query, matchLimits{perFile: 1})
+		}},
+		{"current", func(query string) ([]SearchResult, error) {
+			return searchCurrentFiles(repo.Dir, query, matchLimits{perFile: 1})
+		}},
+	} {
+		for query, want := range map[string]string{"naïve": "latin1.txt:2", "the needle": "crlf.txt:2"} {
+			results, err := search.run(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range results {
+				if r.LineNumber > 0 {
+					got = append(got, fmt.Sprintf("%s:%d", r.FilePath, r.LineNumber))
+				}
+			}
+			if strings.Join(got, " ") != want {
+				t.Errorf("%s search for %q = %v, want %s", search.name, query, got, want)
+			}
+		}
+	}
+
+	m := initialModelWithOptions(SearchOptions{RepoPath: repo.Dir})
+	for name, want := range map[string]string{"crlf.txt": "the needle is here", "latin1.txt": "une naïve needle"} {
+		context := m.extractContextLines(files[name], 2, 1)
+		if strings.Contains(context, "\r") || !utf8.ValidString(context) || !strings.Contains(context, want) {
+			t.Errorf("context of %s = %q, want UTF-8 lines around %q", name, context, want)
+		}
+	}
+}
+
+func TestSearchContentMatchesPerFile(t *testing.T) {
+	repo := gittest.New(t)
+	for _, name := range []string{"a.go", "b.go"} {
+		content := "func parse() {}\n\nfunc main() {\n\tparse()\n\tparse()\n}\n"
+		repo.WriteFile(name, content)
+	}
+	repo.Commit("Add files")
+
+	for _, search := range []struct {
+		name string
+		run  func(limits matchLimits) ([]SearchResult, error)
+	}{
+		{"historical", func(limits matchLimits) ([]SearchResult, error) {
+			return searchHistoricalContent(repo.Repository, "parse", limits)
+		}},
+		{"current", func(limits matchLimits) ([]SearchResult, error) { return searchCurrentFiles(repo.Dir, "parse", limits) }},
+	} {
+		for _, tt := range []struct {
+			limits matchLimits
+			want   string
+		}{
+			{matchLimits{perFile: 1}, "a.go:1 b.go:1"},
+			{matchLimits{perFile: 2}, "a.go:1 a.go:4 b.go:1 b.go:4"},
+			{matchLimits{}, "a.go:1 a.go:4 a.go:5 b.go:1 b.go:4 b.go:5"},
+			// The cap on results for the search type wins over the per-file limit
+			{matchLimits{total: 4}, "a.go:1 a.go:4 a.go:5 b.go:1"},
+		} {
+			results, err := search.run(tt.limits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, fmt.Sprintf("%s:%d", r.FilePath, r.LineNumber))
+			}
+			if strings.Join(got, " ") != tt.want {
+				t.Errorf("%s search with %+v = %v, want %s", search.name, tt.limits, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSearchRecentCommits(t *testing.T) {
+	repo, hashes := newSearchTestRepo(t, 5)
+
+	results, err := searchRecentCommits(repo.Repository, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	for i, result := range results {
+		if want := hashes[len(hashes)-1-i].String(); result.Hash != want || result.Type != "commit" {
+			t.Errorf("results[%d] = %s %s, want commit %s", i, result.Type, result.Hash, want)
+		}
+	}
+
+	// An empty query lists them even when commits aren't searched
+	opts := SearchOptions{RepoPath: repo.Dir, SearchFiles: true, MaxResults: 2}
+	batches := make(chan searchResultBatchMsg, numSearchCategories)
+	if msg := performAdvancedSearch(1, "", opts, batches); msg != nil {
+		t.Fatalf("performAdvancedSearch() = %v", msg)
+	}
+	var received []searchResultBatchMsg
+	for batch := range batches {
+		received = append(received, batch)
+	}
+	if len(received) != 1 || received[0].category != commitSearch || len(received[0].results) != 2 {
+		t.Errorf("batches = %+v, want only the 2 most recent commits", received)
+	}
+}
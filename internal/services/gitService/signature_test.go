@@ -0,0 +1,107 @@
+package gitservice
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+// newTestKey generates a signing key and writes its armored public key to a keyring file
+func newTestKey(t *testing.T, name string) (*openpgp.Entity, string) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), name+".asc")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w, err := armor.Encode(f, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return entity, path
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	alice, aliceKeyring := newTestKey(t, "Alice")
+	_, bobKeyring := newTestKey(t, "Bob")
+
+	repo := gittest.New(t)
+	commit := func(message string, key *openpgp.Entity) *object.Commit {
+		t.Helper()
+		author := &object.Signature{Name: "Alice", Email: "Alice@example.com", When: repo.Tick()}
+		c, err := repo.CommitObject(repo.CommitWith(message, &git.CommitOptions{Author: author, SignKey: key}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	signed := commit("Signed commit", alice)
+	unsigned := commit("Unsigned commit", nil)
+
+	// A signature that no longer matches the commit it is attached to
+	tampered := *signed
+	tampered.Message = "Tampered commit"
+
+	ssh := *unsigned
+	ssh.PGPSignature = "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n"
+
+	verifyWithAlice, err := NewSignatureVerifier(aliceKeyring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyWithBob, err := NewSignatureVerifier(bobKeyring)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		verifier *SignatureVerifier
+		commit   *object.Commit
+		want     CommitSignature
+	}{
+		{"unsigned", verifyWithAlice, unsigned, CommitSignature{Status: SignatureUnsigned}},
+		{"detected only", nil, signed, CommitSignature{Type: "gpg", Status: SignatureSigned}},
+		{"verified", verifyWithAlice, signed, CommitSignature{Type: "gpg", Status: SignatureVerified, Signer: alice.PrimaryIdentity().Name}},
+		{"unknown key", verifyWithBob, signed, CommitSignature{Type: "gpg", Status: SignatureUnverifiable}},
+		{"tampered", verifyWithAlice, &tampered, CommitSignature{Type: "gpg", Status: SignatureBad}},
+		{"ssh", verifyWithAlice, &ssh, CommitSignature{Type: "ssh", Status: SignatureUnverifiable}},
+	}
+	for _, tt := range tests {
+		if got := tt.verifier.Check(tt.commit); got != tt.want {
+			t.Errorf("%s: Check() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSignatureOptionsVerifier(t *testing.T) {
+	if v, err := (SignatureOptions{Keyring: "ignored.asc"}).Verifier(); v != nil || err != nil {
+		t.Errorf("Verifier() without Verify = %v, %v, want nil, nil", v, err)
+	}
+	if _, err := (SignatureOptions{Verify: true}).Verifier(); err == nil {
+		t.Error("Verifier() without a keyring should fail")
+	}
+	if _, err := (SignatureOptions{Verify: true, Keyring: filepath.Join(t.TempDir(), "missing.asc")}).Verifier(); err == nil {
+		t.Error("Verifier() with a missing keyring should fail")
+	}
+}
@@ -1,6 +1,7 @@
 package sparsecloneservice
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,7 +11,7 @@ import (
 	gitservice "github.com/redjax/syst/internal/services/gitService"
 )
 
-// execCommand allows mocking for tests later if needed
+// execCommand runs external commands, so tests can stub them
 var execCommand = func(name string, args ...string) *exec.Cmd {
 	cmd := exec.Command(name, args...)
 	cmd.Stdin = os.Stdin
@@ -29,6 +30,16 @@ type SparseCloneOptions struct {
 	Paths      []string
 	// ssh or https
 	Protocol string
+	// Depth truncates history to the given number of commits (0 = full history)
+	Depth int
+	// ConeMode checks out whole directories (fast); when false, Paths are
+	// treated as gitignore-style patterns
+	ConeMode bool
+	// ValidatePaths checks each path exists on the remote branch before checkout
+	ValidatePaths bool
+	// Force replaces an output directory that isn't empty. Without it the clone refuses
+	// to touch one.
+	Force bool
 }
 
 func SparseClone(opts SparseCloneOptions) error {
@@ -37,21 +48,25 @@ func SparseClone(opts SparseCloneOptions) error {
 		return gitservice.ErrGitNotInstalled
 	}
 
-	if !gitservice.ValidateGitProvider(opts.Provider) {
-		return fmt.Errorf("unknown git provider: %s", opts.Provider)
+	if err := validateOptions(opts); err != nil {
+		return err
 	}
 
-	// Determine output directory
-	outputDir := opts.Output
-	if outputDir == "" || outputDir == "." {
-		outputDir = strings.TrimSuffix(opts.Repository, ".git")
-	}
+	repoURL, outputDir := cloneTarget(opts)
 
-	host := gitservice.GetHostByProvider(opts.Provider)
-	repoURL := gitservice.BuildRepoURL(opts.Protocol, host, opts.User, opts.Repository)
+	clobber, err := checkOutputDir(outputDir, opts.Force)
+	if err != nil {
+		return err
+	}
+	if clobber {
+		fmt.Printf("Removing existing %s (--force)\n", outputDir)
+		if err := os.RemoveAll(outputDir); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", outputDir, err)
+		}
+	}
 
 	// Clone no-checkout
-	if err := gitservice.CloneNoCheckout(repoURL, outputDir); err != nil {
+	if err := gitservice.CloneNoCheckoutWithDepth(repoURL, outputDir, opts.Branch, opts.Depth); err != nil {
 		return fmt.Errorf("git clone failed: %w", err)
 	}
 
@@ -68,11 +83,19 @@ func SparseClone(opts SparseCloneOptions) error {
 		return fmt.Errorf("failed to enter output directory: %w", err)
 	}
 
-	if err := SparseCheckoutInit(); err != nil {
+	if opts.ValidatePaths {
+		paths, err := confirmMissingPaths(opts.Branch, opts.Paths, opts.ConeMode)
+		if err != nil {
+			return err
+		}
+		opts.Paths = paths
+	}
+
+	if err := SparseCheckoutInit(opts.ConeMode); err != nil {
 		return fmt.Errorf("git sparse-checkout init failed: %w", err)
 	}
 
-	if err := SparseCheckoutPaths(opts.Paths); err != nil {
+	if err := SparseCheckoutPaths(opts.Paths, opts.ConeMode); err != nil {
 		return fmt.Errorf("git sparse-checkout set failed: %w", err)
 	}
 
@@ -84,13 +107,234 @@ func SparseClone(opts SparseCloneOptions) error {
 	return nil
 }
 
-func SparseCheckoutInit() error {
-	cmd := execCommand("git", "sparse-checkout", "init", "--cone")
+func validateOptions(opts SparseCloneOptions) error {
+	if !gitservice.ValidateGitProvider(opts.Provider) {
+		return fmt.Errorf("unknown git provider: %s", opts.Provider)
+	}
+
+	if opts.Depth < 0 {
+		return fmt.Errorf("depth must be non-negative, got %d", opts.Depth)
+	}
+
+	return nil
+}
+
+// cloneTarget returns the URL to clone and the directory to clone it into
+func cloneTarget(opts SparseCloneOptions) (string, string) {
+	outputDir := opts.Output
+	if outputDir == "" || outputDir == "." {
+		outputDir = strings.TrimSuffix(opts.Repository, ".git")
+	}
+
+	host := gitservice.GetHostByProvider(opts.Provider)
+	return gitservice.BuildRepoURL(opts.Protocol, host, opts.User, opts.Repository), outputDir
+}
+
+// checkOutputDir checks that the clone can go into dir: it doesn't exist yet or is an
+// empty directory. A directory with files in it is refused unless force is set, in which
+// case clobber is set and the directory has to be removed first.
+func checkOutputDir(dir string, force bool) (clobber bool, err error) {
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not check output directory %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return false, fmt.Errorf("output path %s exists and is not a directory", dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, fmt.Errorf("could not check output directory %s: %w", dir, err)
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+	if !force {
+		return false, fmt.Errorf("output directory %s is not empty (pass --force to replace it)", dir)
+	}
+	return true, nil
+}
+
+// Plan returns the commands SparseClone runs for opts, in order, as shell command lines
+// that can be copied into a script. Nothing is run.
+func Plan(opts SparseCloneOptions) ([]string, error) {
+	if err := validateOptions(opts); err != nil {
+		return nil, err
+	}
+
+	repoURL, outputDir := cloneTarget(opts)
+	clobber, err := checkOutputDir(outputDir, opts.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []string
+	if clobber {
+		plan = append(plan, shellJoin("rm", "-rf", outputDir))
+	}
+	plan = append(plan,
+		shellJoin("git", gitservice.CloneNoCheckoutArgs(repoURL, outputDir, opts.Branch, opts.Depth)...),
+		shellJoin("cd", outputDir),
+	)
+	if opts.ValidatePaths && opts.ConeMode {
+		plan = append(plan, "# Check that the paths exist on the branch, asking whether to keep missing ones:",
+			"# "+shellJoin("git", listRemoteTreeArgs(opts.Branch)...))
+	}
+	return append(plan,
+		shellJoin("git", sparseCheckoutInitArgs(opts.ConeMode)...),
+		shellJoin("git", sparseCheckoutSetArgs(opts.Paths, opts.ConeMode)...),
+		shellJoin("git", "checkout", opts.Branch),
+	), nil
+}
+
+// shellJoin joins a command and its arguments, quoting those the shell would split or
+// expand
+func shellJoin(name string, args ...string) string {
+	quoted := []string{name}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`*?[]{}()<>|&;#~!") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func SparseCheckoutInit(cone bool) error {
+	cmd := execCommand("git", sparseCheckoutInitArgs(cone)...)
 	return cmd.Run()
 }
 
-func SparseCheckoutPaths(paths []string) error {
-	args := append([]string{"sparse-checkout", "set"}, paths...)
-	cmd := execCommand("git", args...)
+func SparseCheckoutPaths(paths []string, cone bool) error {
+	cmd := execCommand("git", sparseCheckoutSetArgs(paths, cone)...)
 	return cmd.Run()
 }
+
+func sparseCheckoutInitArgs(cone bool) []string {
+	return []string{"sparse-checkout", "init", coneFlag(cone)}
+}
+
+func sparseCheckoutSetArgs(paths []string, cone bool) []string {
+	return append([]string{"sparse-checkout", "set", coneFlag(cone)}, paths...)
+}
+
+// LoadPathsFile reads newline-separated checkout paths from a file, skipping
+// blank lines and # comments
+func LoadPathsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open paths file: %w", err)
+	}
+	defer file.Close()
+
+	var paths []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read paths file: %w", err)
+	}
+
+	return paths, nil
+}
+
+// confirmMissingPaths checks paths against the cloned branch and asks whether to keep
+// each one that doesn't exist. Returns the paths to check out.
+func confirmMissingPaths(branch string, paths []string, cone bool) ([]string, error) {
+	if !cone {
+		fmt.Println("Skipping path validation: patterns can't be verified in non-cone mode")
+		return paths, nil
+	}
+
+	entries, err := listRemoteTree(branch)
+	if err != nil {
+		fmt.Printf("Warning: could not verify checkout paths: %v\n", err)
+		return paths, nil
+	}
+
+	missing := FindMissingPaths(entries, paths)
+	if len(missing) == 0 {
+		return paths, nil
+	}
+
+	fmt.Printf("\n%d checkout path(s) do not exist on branch %s:\n", len(missing), branch)
+	for _, path := range missing {
+		fmt.Printf("  - %s\n", path)
+	}
+	fmt.Println()
+
+	reader := bufio.NewReader(os.Stdin)
+	drop := make(map[string]bool)
+	for _, path := range missing {
+		if !gitservice.Confirm(reader, fmt.Sprintf("Keep %s anyway?", path)) {
+			drop[path] = true
+		}
+	}
+
+	var kept []string
+	for _, path := range paths {
+		if !drop[path] {
+			kept = append(kept, path)
+		}
+	}
+
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("no checkout paths left after validation")
+	}
+
+	return kept, nil
+}
+
+// listRemoteTree returns every file and directory path on the remote-tracking branch
+func listRemoteTree(branch string) ([]string, error) {
+	cmd := execCommand("git", listRemoteTreeArgs(branch)...)
+	// Output captures stdout itself
+	cmd.Stdout = nil
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-tree refs/remotes/origin/%s failed: %w", branch, err)
+	}
+
+	return strings.Split(strings.TrimSpace(string(output)), "\n"), nil
+}
+
+func listRemoteTreeArgs(branch string) []string {
+	return []string{"ls-tree", "-r", "-t", "--name-only", "refs/remotes/origin/" + branch}
+}
+
+// FindMissingPaths returns the paths that don't match any entry in the tree listing
+func FindMissingPaths(treeEntries []string, paths []string) []string {
+	existing := make(map[string]bool, len(treeEntries))
+	for _, entry := range treeEntries {
+		existing[entry] = true
+	}
+
+	var missing []string
+	for _, path := range paths {
+		normalized := strings.Trim(filepath.ToSlash(path), "/")
+		if normalized == "" || normalized == "." {
+			continue
+		}
+		if !existing[normalized] {
+			missing = append(missing, path)
+		}
+	}
+
+	return missing
+}
+
+// coneFlag returns the git sparse-checkout flag for the selected mode
+func coneFlag(cone bool) string {
+	if cone {
+		return "--cone"
+	}
+	return "--no-cone"
+}
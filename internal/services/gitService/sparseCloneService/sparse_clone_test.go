@@ -0,0 +1,180 @@
+package sparsecloneservice
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadPathsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "paths.txt")
+	content := "# services to check out\nservices/api\n\n  libs/common  \n# libs/legacy\ndocs\n"
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write paths file: %v", err)
+	}
+
+	got, err := LoadPathsFile(file)
+	if err != nil {
+		t.Fatalf("LoadPathsFile() error: %v", err)
+	}
+
+	want := []string{"services/api", "libs/common", "docs"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadPathsFile() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadPathsFileMissing(t *testing.T) {
+	if _, err := LoadPathsFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestFindMissingPaths(t *testing.T) {
+	tree := []string{"docs", "docs/README.md", "services", "services/api", "services/api/main.go"}
+	paths := []string{"docs/", "services/api", "services/aip", "/libs"}
+
+	got := FindMissingPaths(tree, paths)
+	want := []string{"services/aip", "/libs"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindMissingPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	opts := SparseCloneOptions{
+		Provider:      "github",
+		Protocol:      "https",
+		User:          "redjax",
+		Repository:    "syst",
+		Branch:        "dev",
+		Paths:         []string{"docs", "my dir"},
+		Depth:         1,
+		ConeMode:      true,
+		ValidatePaths: true,
+	}
+
+	got, err := Plan(opts)
+	if err != nil {
+		t.Fatalf("Plan() error: %v", err)
+	}
+	want := []string{
+		"git clone --no-checkout --depth 1 --branch dev https://github.com/redjax/syst.git syst",
+		"cd syst",
+		"# Check that the paths exist on the branch, asking whether to keep missing ones:",
+		"# git ls-tree -r -t --name-only refs/remotes/origin/dev",
+		"git sparse-checkout init --cone",
+		"git sparse-checkout set --cone docs 'my dir'",
+		"git checkout dev",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Plan() = %q, want %q", got, want)
+	}
+
+	// Non-cone patterns can't be validated, so the check is left out
+	opts.ConeMode = false
+	opts.Depth = 0
+	opts.Output = "out"
+	opts.Paths = []string{"docs/*.md"}
+	got, err = Plan(opts)
+	if err != nil {
+		t.Fatalf("Plan() error: %v", err)
+	}
+	want = []string{
+		"git clone --no-checkout https://github.com/redjax/syst.git out",
+		"cd out",
+		"git sparse-checkout init --no-cone",
+		"git sparse-checkout set --no-cone 'docs/*.md'",
+		"git checkout dev",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Plan() = %q, want %q", got, want)
+	}
+
+	if _, err := Plan(SparseCloneOptions{Provider: "nope"}); err == nil {
+		t.Error("expected error for unknown provider")
+	}
+}
+
+func TestCheckOutputDir(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	full := filepath.Join(dir, "full")
+	file := filepath.Join(dir, "file")
+	for _, d := range []string{empty, full} {
+		if err := os.Mkdir(d, 0o750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{filepath.Join(full, "README.md"), file} {
+		if err := os.WriteFile(f, []byte("keep me\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		force   bool
+		clobber bool
+		wantErr bool
+	}{
+		{"missing", filepath.Join(dir, "missing"), false, false, false},
+		{"empty", empty, false, false, false},
+		{"not empty", full, false, false, true},
+		{"not empty with force", full, true, true, false},
+		{"file", file, true, false, true},
+	}
+	for _, tt := range tests {
+		clobber, err := checkOutputDir(tt.dir, tt.force)
+		if (err != nil) != tt.wantErr || clobber != tt.clobber {
+			t.Errorf("%s: checkOutputDir() = %v, %v, want clobber %v and error %v", tt.name, clobber, err, tt.clobber, tt.wantErr)
+		}
+	}
+
+	// Checking never removes anything; only the clone does
+	if _, err := os.Stat(filepath.Join(full, "README.md")); err != nil {
+		t.Errorf("checkOutputDir() touched the directory: %v", err)
+	}
+
+	// The plan refuses the directory too, or removes it first with --force
+	opts := SparseCloneOptions{Provider: "github", Protocol: "https", User: "redjax", Repository: "syst", Output: full, Branch: "main", Paths: []string{"docs"}, ConeMode: true}
+	if _, err := Plan(opts); err == nil {
+		t.Error("Plan() into a non-empty directory succeeded without --force")
+	}
+	opts.Force = true
+	got, err := Plan(opts)
+	if err != nil {
+		t.Fatalf("Plan() error: %v", err)
+	}
+	if want := shellJoin("rm", "-rf", full); got[0] != want {
+		t.Errorf("Plan()[0] = %q, want %q", got[0], want)
+	}
+}
+
+func TestListRemoteTree(t *testing.T) {
+	orig := execCommand
+	t.Cleanup(func() { execCommand = orig })
+
+	var got []string
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		got = append([]string{name}, args...)
+		cmd := exec.Command("printf", "%s\n", "docs", "docs/guide.md")
+		// Like the real hook, which passes output through
+		cmd.Stdout = os.Stdout
+		return cmd
+	}
+
+	entries, err := listRemoteTree("main")
+	if err != nil {
+		t.Fatalf("listRemoteTree() error: %v", err)
+	}
+	if want := []string{"docs", "docs/guide.md"}; !reflect.DeepEqual(entries, want) {
+		t.Errorf("listRemoteTree() = %v, want %v", entries, want)
+	}
+	if want := append([]string{"git"}, listRemoteTreeArgs("main")...); !reflect.DeepEqual(got, want) {
+		t.Errorf("ran %v, want %v", got, want)
+	}
+}
@@ -2,11 +2,15 @@ package sparsecloneservice
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type viewState int
@@ -25,6 +29,8 @@ const (
 	repositoryInput
 	outputInput
 	branchInput
+	depthInput
+	modeInput
 	pathsInput
 	confirmInput
 )
@@ -41,41 +47,43 @@ type model struct {
 	terminalHeight int
 	options        SparseCloneOptions
 	currentView    viewState
+	coneMode       bool
+	keys           terminal.KeyMap
 }
 
 var (
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("212")).
+			Foreground(theme.Current.Primary).
 			MarginBottom(1)
 
 	labelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("86")).
+			Foreground(theme.Current.Info).
 			Bold(true)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
+			Foreground(theme.Current.Error).
 			Bold(true)
 
 	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("46")).
+			Foreground(theme.Current.Success).
 			Bold(true)
 
 	pathItemStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
+			Foreground(theme.Current.Text)
 
 	selectedPathStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("15")).
-				Background(lipgloss.Color("196")).
+				Foreground(theme.Current.TextOnPrimary).
+				Background(theme.Current.Primary).
 				Bold(true)
 )
 
 func NewSparseCloneTUI() model {
-	inputs := make([]textinput.Model, 8)
+	inputs := make([]textinput.Model, 10)
 
 	// Provider input
 	inputs[providerInput] = textinput.New()
@@ -116,9 +124,18 @@ func NewSparseCloneTUI() model {
 	inputs[branchInput].CharLimit = 50
 	inputs[branchInput].Width = 30
 
+	// Depth input
+	inputs[depthInput] = textinput.New()
+	inputs[depthInput].Placeholder = "0 (full history)"
+	inputs[depthInput].CharLimit = 6
+	inputs[depthInput].Width = 30
+
+	// Sparse-checkout mode toggle (rendered as a toggle, the input only tracks focus)
+	inputs[modeInput] = textinput.New()
+
 	// Paths input
 	inputs[pathsInput] = textinput.New()
-	inputs[pathsInput].Placeholder = "path to checkout (press Enter to add)"
+	inputs[pathsInput].Placeholder = conePathPlaceholder
 	inputs[pathsInput].CharLimit = 200
 	inputs[pathsInput].Width = 50
 
@@ -140,9 +157,16 @@ func NewSparseCloneTUI() model {
 		terminalWidth:  80,
 		terminalHeight: 24,
 		currentView:    formView,
+		coneMode:       true,
+		keys:           terminal.Keys(),
 	}
 }
 
+const (
+	conePathPlaceholder    = "directory to checkout (press Enter to add)"
+	nonConePathPlaceholder = "gitignore-style pattern (press Enter to add)"
+)
+
 func (m model) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -155,11 +179,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc":
+		// Letter keys are typed into the inputs, so the up and down bindings only move in
+		// the paths list, where nothing is typed
+		inPathsList := m.currentView == confirmationView || m.pathEditMode
+		switch {
+		case msg.String() == "ctrl+c" || key.Matches(msg, m.keys.Back):
 			return m, tea.Quit
 
-		case "tab", "down":
+		case key.Matches(msg, m.keys.NextView) || msg.String() == "down" || (inPathsList && key.Matches(msg, m.keys.Down)):
 			if m.currentView == confirmationView {
 				// Move down in paths list in confirmation view
 				if m.pathCursor < len(m.pathsList)-1 {
@@ -175,7 +202,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "shift+tab", "up":
+		case key.Matches(msg, m.keys.PrevView) || msg.String() == "up" || (inPathsList && key.Matches(msg, m.keys.Up)):
 			if m.currentView == confirmationView {
 				// Move up in paths list in confirmation view
 				if m.pathCursor > 0 {
@@ -191,7 +218,38 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "p":
+		case msg.String() == " " || key.Matches(msg, m.keys.Left, m.keys.Right):
+			// Toggle cone/non-cone mode when focused on the mode field
+			if m.currentView == formView && m.focused == modeInput {
+				m.coneMode = !m.coneMode
+				if m.coneMode {
+					m.inputs[pathsInput].Placeholder = conePathPlaceholder
+				} else {
+					m.inputs[pathsInput].Placeholder = nonConePathPlaceholder
+				}
+				return m, nil
+			}
+
+		case msg.String() == "ctrl+o":
+			// Load paths from the file named in the paths input
+			if m.currentView == formView && m.focused == pathsInput && !m.pathEditMode {
+				file := strings.TrimSpace(m.inputs[pathsInput].Value())
+				if file == "" {
+					m.err = fmt.Errorf("type a file path, then press ctrl+o to load paths from it")
+					return m, nil
+				}
+				paths, err := LoadPathsFile(file)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				m.err = nil
+				m.pathsList = append(m.pathsList, paths...)
+				m.inputs[pathsInput].SetValue("")
+				return m, nil
+			}
+
+		case msg.String() == "p":
 			// Toggle path edit mode when in pathsInput, but only if input is empty
 			if m.focused == pathsInput && len(m.pathsList) > 0 && strings.TrimSpace(m.inputs[pathsInput].Value()) == "" {
 				m.pathEditMode = !m.pathEditMode
@@ -201,7 +259,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-		case "enter":
+		case key.Matches(msg, m.keys.Select):
 			// Handle different behaviors based on current view and field
 			if m.currentView == confirmationView {
 				// In confirmation view, Enter submits the form
@@ -227,6 +285,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 
 			case confirmInput:
+				if _, err := m.parseDepth(); err != nil {
+					m.err = err
+					return m, nil
+				}
+				m.err = nil
+
 				// Transition to confirmation view
 				m.currentView = confirmationView
 				m.pathCursor = 0 // Reset cursor for confirmation view
@@ -240,7 +304,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-		case "d":
+		case msg.String() == "d":
 			// Delete path when in path edit mode OR in confirmation view
 			if (m.pathEditMode || m.currentView == confirmationView) && len(m.pathsList) > 0 && m.pathCursor < len(m.pathsList) {
 				m.pathsList = append(m.pathsList[:m.pathCursor], m.pathsList[m.pathCursor+1:]...)
@@ -253,7 +317,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-		case "backspace", "delete":
+		case msg.String() == "backspace" || msg.String() == "delete":
 			// Handle different actions based on current view
 			if m.currentView == confirmationView {
 				// In confirmation view, backspace goes back to form
@@ -270,8 +334,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	// Update the current input only if not in path edit mode
-	if !m.pathEditMode {
+	// Update the current input only if not in path edit mode (the mode field is a toggle)
+	if !m.pathEditMode && m.focused != modeInput {
 		var cmd tea.Cmd
 		m.inputs[m.focused], cmd = m.inputs[m.focused].Update(msg)
 		return m, cmd
@@ -354,6 +418,24 @@ func (m model) renderFormView() string {
 	allLines = append(allLines, m.inputs[branchInput].View())
 	allLines = append(allLines, "")
 
+	// Depth (lines 18-20)
+	allLines = append(allLines, labelStyle.Render("Clone Depth:"))
+	inputLine = m.inputs[depthInput].View()
+	if m.focused == depthInput {
+		inputLine += helpStyle.Render(" (optional, 0 = full history)")
+	}
+	allLines = append(allLines, inputLine)
+	allLines = append(allLines, "")
+
+	// Sparse-checkout mode (lines 21-23)
+	allLines = append(allLines, labelStyle.Render("Sparse Checkout Mode:"))
+	inputLine = m.renderModeToggle()
+	if m.focused == modeInput {
+		inputLine += helpStyle.Render(" (space: toggle)")
+	}
+	allLines = append(allLines, inputLine)
+	allLines = append(allLines, "")
+
 	// Track where paths section starts for scroll calculation
 	pathsSectionStart := len(allLines)
 
@@ -409,19 +491,25 @@ func (m model) renderFormView() string {
 		var helpText string
 		if len(m.pathsList) > 0 {
 			if m.pathEditMode {
-				helpText = "Path Edit Mode: ↑/↓: navigate • d: delete • Enter: exit edit"
+				helpText = "Path Edit Mode: " + terminal.HelpLine(terminal.HelpKey(m.keys.Up, m.keys.Down)+": navigate",
+					"d: delete", terminal.Help(m.keys.Select, "exit edit"))
 			} else {
 				currentInput := strings.TrimSpace(m.inputs[pathsInput].Value())
 				if currentInput == "" {
-					helpText = "Enter: add path • p: edit existing paths • Backspace: remove last"
+					helpText = terminal.HelpLine(terminal.Help(m.keys.Select, "add path"), "p: edit existing paths", "Backspace: remove last")
 				} else {
-					helpText = "Enter: add path • Backspace: remove last"
+					helpText = terminal.HelpLine(terminal.Help(m.keys.Select, "add path"), "ctrl+o: load paths from file", "Backspace: remove last")
 				}
 			}
 		} else {
-			helpText = "Enter: add path"
+			helpText = terminal.HelpLine(terminal.Help(m.keys.Select, "add path"), "ctrl+o: load paths from file")
 		}
 		allLines = append(allLines, helpStyle.Render(helpText))
+		if m.coneMode {
+			allLines = append(allLines, helpStyle.Render("Cone mode: enter directories (e.g. src/app); everything under them is checked out"))
+		} else {
+			allLines = append(allLines, helpStyle.Render("Non-cone mode: enter gitignore-style patterns (e.g. /docs/*.md, !*.tmp); slower on large repos"))
+		}
 	}
 	allLines = append(allLines, "")
 
@@ -448,6 +536,10 @@ func (m model) renderFormView() string {
 		focusedInputLine = 13
 	case branchInput:
 		focusedInputLine = 16
+	case depthInput:
+		focusedInputLine = 19
+	case modeInput:
+		focusedInputLine = 22
 	case pathsInput:
 		focusedInputLine = pathsSectionStart + 1 + len(m.pathsList)
 		if len(m.pathsList) > 0 {
@@ -522,7 +614,8 @@ func (m model) renderFormView() string {
 		// Don't show global help when focused on paths
 		// (help is already shown above near the paths)
 	} else {
-		b.WriteString(helpStyle.Render("tab/↓: next • shift+tab/↑: previous • enter: confirm/add • esc: quit"))
+		b.WriteString(helpStyle.Render(terminal.HelpLine(terminal.HelpKey(m.keys.NextView)+"/↓: next",
+			terminal.HelpKey(m.keys.PrevView)+"/↑: previous", terminal.Help(m.keys.Select, "confirm/add"), terminal.Help(m.keys.Back, "quit"))))
 	}
 
 	return b.String()
@@ -540,6 +633,7 @@ func (m model) renderConfirmationView() string {
 	repo := m.getFieldValue(repositoryInput, "")
 	output := m.getFieldValue(outputInput, repo)
 	branch := m.getFieldValue(branchInput, "main")
+	depth, _ := m.parseDepth()
 
 	b.WriteString(labelStyle.Render("Configuration Summary:"))
 	b.WriteString("\n")
@@ -548,6 +642,16 @@ func (m model) renderConfirmationView() string {
 	b.WriteString(fmt.Sprintf("  Repository: %s/%s\n", user, repo))
 	b.WriteString(fmt.Sprintf("  Output Directory: %s\n", output))
 	b.WriteString(fmt.Sprintf("  Branch: %s\n", branch))
+	if depth > 0 {
+		b.WriteString(fmt.Sprintf("  Depth: %d (history will be truncated to the last %d commits)\n", depth, depth))
+	} else {
+		b.WriteString("  Depth: full history\n")
+	}
+	if m.coneMode {
+		b.WriteString("  Sparse Mode: cone (directories)\n")
+	} else {
+		b.WriteString("  Sparse Mode: non-cone (patterns)\n")
+	}
 	b.WriteString("\n")
 
 	// Paths list with cursor navigation for editing
@@ -563,7 +667,11 @@ func (m model) renderConfirmationView() string {
 			b.WriteString("\n")
 		}
 		b.WriteString("\n")
-		b.WriteString(helpStyle.Render("↑/↓: navigate paths • d: delete selected path"))
+		if m.coneMode {
+			b.WriteString(helpStyle.Render("Paths will be checked against the remote branch after cloning"))
+			b.WriteString("\n")
+		}
+		b.WriteString(helpStyle.Render(terminal.HelpLine(terminal.HelpKey(m.keys.Up, m.keys.Down)+": navigate paths", "d: delete selected path")))
 	} else {
 		b.WriteString(helpStyle.Render("  (no paths added yet)"))
 	}
@@ -582,6 +690,12 @@ func (m model) renderConfirmationView() string {
 			cmdParts = append(cmdParts, fmt.Sprintf("-o %s", output))
 		}
 		cmdParts = append(cmdParts, fmt.Sprintf("-b %s", branch))
+		if depth > 0 {
+			cmdParts = append(cmdParts, fmt.Sprintf("--depth %d", depth))
+		}
+		if !m.coneMode {
+			cmdParts = append(cmdParts, "--no-cone")
+		}
 		for _, path := range m.pathsList {
 			cmdParts = append(cmdParts, fmt.Sprintf("-p %s", path))
 		}
@@ -591,10 +705,23 @@ func (m model) renderConfirmationView() string {
 		b.WriteString("\n\n")
 	}
 
+	// The git commands the clone will run, in order
+	if user != "" && repo != "" && len(m.pathsList) > 0 {
+		if plan, err := Plan(m.currentOptions()); err == nil {
+			b.WriteString(labelStyle.Render("Git commands:"))
+			b.WriteString("\n")
+			for _, line := range plan {
+				b.WriteString(helpStyle.Render("  " + line))
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+
 	// Action buttons
 	b.WriteString(labelStyle.Render("Actions:"))
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("Enter: Proceed with clone • Backspace: Go back to edit • esc: quit"))
+	b.WriteString(helpStyle.Render(terminal.HelpLine(terminal.Help(m.keys.Select, "Proceed with clone"), "Backspace: Go back to edit", terminal.Help(m.keys.Back, "quit"))))
 
 	if m.err != nil {
 		b.WriteString("\n\n")
@@ -604,6 +731,19 @@ func (m model) renderConfirmationView() string {
 	return b.String()
 }
 
+// renderModeToggle renders the cone/non-cone selector
+func (m model) renderModeToggle() string {
+	cone, nonCone := "  cone  ", "  non-cone  "
+	if m.coneMode {
+		cone = selectedPathStyle.Render("[cone]")
+		nonCone = pathItemStyle.Render(nonCone)
+	} else {
+		cone = pathItemStyle.Render(cone)
+		nonCone = selectedPathStyle.Render("[non-cone]")
+	}
+	return cone + " " + nonCone
+}
+
 // getFieldValue returns the current value of an input field, or the default if empty
 func (m model) getFieldValue(field inputField, defaultValue string) string {
 	value := strings.TrimSpace(m.inputs[field].Value())
@@ -631,8 +771,28 @@ func (m model) prevInput() model {
 	return m
 }
 
+// parseDepth parses the depth field, treating an empty value as full history
+func (m model) parseDepth() (int, error) {
+	value := m.getFieldValue(depthInput, "0")
+	depth, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("depth must be a whole number, got %q", value)
+	}
+	if depth < 0 {
+		return 0, fmt.Errorf("depth must be non-negative, got %d", depth)
+	}
+	return depth, nil
+}
+
 func (m *model) buildOptions() {
-	m.options = SparseCloneOptions{
+	m.options = m.currentOptions()
+}
+
+// currentOptions returns the options the form currently describes
+func (m model) currentOptions() SparseCloneOptions {
+	depth, _ := m.parseDepth()
+
+	return SparseCloneOptions{
 		Provider:   m.getFieldValue(providerInput, "github"),
 		Protocol:   m.getFieldValue(protocolInput, "ssh"),
 		User:       m.getFieldValue(userInput, ""),
@@ -640,6 +800,10 @@ func (m *model) buildOptions() {
 		Output:     m.getFieldValue(outputInput, ""),
 		Branch:     m.getFieldValue(branchInput, "main"),
 		Paths:      m.pathsList,
+		Depth:      depth,
+		ConeMode:   m.coneMode,
+		// Interactive users get a chance to fix typo'd paths before checkout
+		ValidatePaths: true,
 	}
 }
 
@@ -651,9 +815,26 @@ func (m model) IsSubmitted() bool {
 	return m.submitted
 }
 
-// RunSparseCloneTUI runs the interactive TUI and returns the configured options
-func RunSparseCloneTUI() (*SparseCloneOptions, error) {
+// RunSparseCloneTUI runs the interactive TUI and returns the configured options.
+// The provider, protocol, user and branch of defaults pre-fill their inputs, and its
+// paths pre-populate the checkout paths list (i.e. from --paths-file).
+func RunSparseCloneTUI(defaults SparseCloneOptions) (*SparseCloneOptions, error) {
+	if err := terminal.RequireTTY("--username and --repository"); err != nil {
+		return nil, err
+	}
+
 	tuiModel := NewSparseCloneTUI()
+	for input, value := range map[inputField]string{
+		providerInput: defaults.Provider,
+		protocolInput: defaults.Protocol,
+		userInput:     defaults.User,
+		branchInput:   defaults.Branch,
+	} {
+		if value != "" {
+			tuiModel.inputs[input].SetValue(value)
+		}
+	}
+	tuiModel.pathsList = append(tuiModel.pathsList, defaults.Paths...)
 
 	p := tea.NewProgram(tuiModel, tea.WithAltScreen())
 	finalModel, err := p.Run()
@@ -682,6 +863,9 @@ func RunSparseCloneTUI() (*SparseCloneOptions, error) {
 	if len(opts.Paths) == 0 {
 		return nil, fmt.Errorf("at least one checkout path is required")
 	}
+	if opts.Depth < 0 {
+		return nil, fmt.Errorf("depth must be non-negative")
+	}
 
 	return &opts, nil
 }
@@ -0,0 +1,238 @@
+package staleBranchesService
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// StaleBranchesOptions controls which repository is checked and whether branches are deleted
+type StaleBranchesOptions struct {
+	// RepoPath is the repository to check; empty means the current directory
+	RepoPath string
+	// Delete removes the stale branches after confirmation. Without it the branches are
+	// only listed.
+	Delete bool
+	// Yes skips the confirmation prompt
+	Yes bool
+	// Force deletes stale branches that are not merged, losing their unmerged commits
+	Force bool
+}
+
+// StaleBranch is a local branch selected for cleanup
+type StaleBranch struct {
+	Name string
+	// Hash is the branch's tip commit, to restore the branch from once it is deleted
+	Hash       string
+	LastCommit time.Time
+	// Merged is set when every commit on the branch is in the target branch
+	Merged bool
+	// Ahead is the number of commits on the branch that are not in the target branch
+	Ahead int
+	// Old is set when the tip commit is older than the age threshold
+	Old bool
+}
+
+// RunStaleBranches lists the local branches that are fully merged into mergedInto (the
+// repository's default branch if empty), or whose tip commit is older than olderThan
+// (ignored if 0). With opts.Delete it deletes them after confirmation. The current and
+// target branches are never selected.
+func RunStaleBranches(mergedInto string, olderThan time.Duration, opts StaleBranchesOptions) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+	if _, err := gitservice.Head(repo); err != nil {
+		return err
+	}
+
+	if mergedInto == "" {
+		if mergedInto, err = gitservice.DefaultBranch(repo); err != nil {
+			return err
+		}
+	}
+
+	stale, err := findStaleBranches(repo, mergedInto, olderThan, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(stale) == 0 {
+		fmt.Printf("No stale branches (merged into %s%s).\n", mergedInto, ageSuffix(olderThan))
+		return nil
+	}
+
+	fmt.Printf("Stale branches (merged into %s%s):\n\n", mergedInto, ageSuffix(olderThan))
+	printStaleBranches(os.Stdout, stale, time.Now())
+
+	if !opts.Delete {
+		fmt.Println("\nDry run, nothing was deleted. Pass --delete to delete these branches.")
+		return nil
+	}
+
+	fmt.Println()
+	toDelete := stale
+	if !opts.Force {
+		toDelete = nil
+		for _, b := range stale {
+			if b.Merged {
+				toDelete = append(toDelete, b)
+			} else {
+				fmt.Printf("Skipping %s: not merged into %s (use --force to delete it anyway)\n", b.Name, mergedInto)
+			}
+		}
+	}
+	if len(toDelete) == 0 {
+		return nil
+	}
+
+	if !opts.Yes && !gitservice.Confirm(bufio.NewReader(os.Stdin), fmt.Sprintf("Delete %d branch(es)?", len(toDelete))) {
+		fmt.Println("Aborted, nothing was deleted.")
+		return nil
+	}
+
+	var deleted int
+	for _, b := range toDelete {
+		if err := deleteBranch(repo, b.Name); err != nil {
+			fmt.Printf("Failed to delete %s: %v\n", b.Name, err)
+			continue
+		}
+		fmt.Printf("Deleted %s (restore with: %s)\n", b.Name, gitservice.UndoBranchHint(b.Name, b.Hash))
+		deleted++
+	}
+	fmt.Printf("\nDeleted %d branch(es).\n", deleted)
+
+	return nil
+}
+
+// findStaleBranches returns the local branches merged into target or older than
+// olderThan, skipping the current, target and default branches, oldest first.
+func findStaleBranches(repo *git.Repository, target string, olderThan time.Duration, now time.Time) ([]StaleBranch, error) {
+	targetHash, err := gitservice.ResolveBranch(repo, target)
+	if err != nil {
+		return nil, err
+	}
+	// Walked once and shared by every branch
+	targetAncestors, err := gitservice.Ancestors(repo, targetHash)
+	if err != nil {
+		return nil, err
+	}
+
+	protected := map[string]bool{target: true}
+	if defaultBranch, err := gitservice.DefaultBranch(repo); err == nil {
+		protected[defaultBranch] = true
+	}
+	if head, err := repo.Head(); err == nil && head.Name().IsBranch() {
+		protected[head.Name().Short()] = true
+	}
+
+	refs, err := repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var stale []StaleBranch
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if protected[name] {
+			return nil
+		}
+
+		commit, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to read tip of %s: %w", name, err)
+		}
+
+		// Reuse the ahead/behind count: a branch with nothing ahead of target is merged
+		ancestors, err := gitservice.Ancestors(repo, ref.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to walk %s: %w", name, err)
+		}
+		ahead, _ := gitservice.CountDivergence(targetAncestors, ancestors)
+
+		branch := StaleBranch{
+			Name:       name,
+			Hash:       ref.Hash().String(),
+			LastCommit: commit.Committer.When,
+			Merged:     ahead == 0,
+			Ahead:      ahead,
+			Old:        olderThan > 0 && now.Sub(commit.Committer.When) > olderThan,
+		}
+		if branch.Merged || branch.Old {
+			stale = append(stale, branch)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].LastCommit.Before(stale[j].LastCommit)
+	})
+
+	return stale, nil
+}
+
+// deleteBranch removes a local branch and its configuration, like git branch -D.
+func deleteBranch(repo *git.Repository, name string) error {
+	if err := repo.Storer.RemoveReference(plumbing.NewBranchReferenceName(name)); err != nil {
+		return err
+	}
+	if err := repo.DeleteBranch(name); err != nil && !errors.Is(err, git.ErrBranchNotFound) {
+		return err
+	}
+	return nil
+}
+
+// printStaleBranches writes a table of the stale branches and why they were selected.
+func printStaleBranches(w io.Writer, stale []StaleBranch, now time.Time) {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "BRANCH\tLAST COMMIT\tREASON")
+	for _, b := range stale {
+		fmt.Fprintf(writer, "%s\t%s (%d days ago)\t%s\n",
+			b.Name,
+			b.LastCommit.Format("2006-01-02"),
+			int(now.Sub(b.LastCommit).Hours()/24),
+			reason(b),
+		)
+	}
+	// #nosec G104 - Flushing to stdout only fails if stdout is closed
+	writer.Flush()
+}
+
+func reason(b StaleBranch) string {
+	switch {
+	case b.Merged && b.Old:
+		return "merged, old"
+	case b.Merged:
+		return "merged"
+	default:
+		return fmt.Sprintf("old, not merged (%d commits ahead)", b.Ahead)
+	}
+}
+
+func ageSuffix(olderThan time.Duration) string {
+	if olderThan <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(", or no commits in %s", formatAge(olderThan))
+}
+
+// formatAge formats whole days as "90d" and anything else as a Go duration.
+func formatAge(d time.Duration) string {
+	day := 24 * time.Hour
+	if d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
@@ -0,0 +1,105 @@
+package staleBranchesService
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+var now = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// newStaleTestRepo creates a repository on master with a "merged" branch at master, an
+// old unmerged "feature" branch and a recent unmerged "recent" branch.
+func newStaleTestRepo(t *testing.T) *gittest.Repo {
+	t.Helper()
+
+	repo := gittest.New(t)
+	commit := func(message string, when time.Time) {
+		t.Helper()
+		repo.WriteFile("file.txt", message)
+		repo.CommitWith(message, &git.CommitOptions{Author: gittest.Signature(when)})
+	}
+
+	commit("Initial commit", now.AddDate(0, -6, 0))
+	repo.Checkout("merged", true)
+
+	repo.Checkout("master", false)
+	repo.Checkout("feature", true)
+	commit("Old work", now.AddDate(0, -3, 0))
+
+	repo.Checkout("master", false)
+	repo.Checkout("recent", true)
+	commit("New work", now.AddDate(0, 0, -2))
+
+	repo.Checkout("master", false)
+	return repo
+}
+
+func names(stale []StaleBranch) []string {
+	var list []string
+	for _, b := range stale {
+		list = append(list, b.Name)
+	}
+	return list
+}
+
+func TestFindStaleBranches(t *testing.T) {
+	repo := newStaleTestRepo(t)
+
+	stale, err := findStaleBranches(repo.Repository, "master", 0, now)
+	if err != nil {
+		t.Fatalf("findStaleBranches() error: %v", err)
+	}
+	if got := names(stale); len(got) != 1 || got[0] != "merged" {
+		t.Errorf("merged branches = %v, want [merged]", got)
+	}
+
+	stale, err = findStaleBranches(repo.Repository, "master", 30*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("findStaleBranches() error: %v", err)
+	}
+	if got := names(stale); len(got) != 2 || got[0] != "merged" || got[1] != "feature" {
+		t.Errorf("stale branches = %v, want [merged feature]", got)
+	}
+	if feature := stale[1]; feature.Merged || !feature.Old || feature.Ahead != 1 {
+		t.Errorf("feature = %+v, want old, unmerged and 1 ahead", feature)
+	}
+
+	// The current branch is never selected
+	repo.Checkout("merged", false)
+	stale, err = findStaleBranches(repo.Repository, "master", 0, now)
+	if err != nil {
+		t.Fatalf("findStaleBranches() error: %v", err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("stale branches with merged checked out = %v, want none", names(stale))
+	}
+}
+
+func TestFindStaleBranchesKeepsDefaultBranch(t *testing.T) {
+	repo := newStaleTestRepo(t)
+	repo.Checkout("feature", false)
+
+	// master is merged into feature, but as the default branch it is never selected
+	stale, err := findStaleBranches(repo.Repository, "feature", 0, now)
+	if err != nil {
+		t.Fatalf("findStaleBranches() error: %v", err)
+	}
+	if got := names(stale); len(got) != 1 || got[0] != "merged" {
+		t.Errorf("branches merged into feature = %v, want [merged]", got)
+	}
+}
+
+func TestDeleteBranch(t *testing.T) {
+	repo := newStaleTestRepo(t)
+
+	if err := deleteBranch(repo.Repository, "merged"); err != nil {
+		t.Fatalf("deleteBranch() error: %v", err)
+	}
+	if _, err := repo.Reference(plumbing.NewBranchReferenceName("merged"), false); err == nil {
+		t.Error("merged branch still exists after deleteBranch()")
+	}
+}
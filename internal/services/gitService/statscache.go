@@ -0,0 +1,191 @@
+package gitservice
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/redjax/syst/internal/utils/profile"
+)
+
+// statsCacheDir is the directory inside .git where syst keeps on-disk caches
+const statsCacheDir = "syst-cache"
+
+// statsCacheFile holds cached per-commit file stats
+const statsCacheFile = "commit-stats.gob"
+
+// CommitStatsCache memoizes commit.Stats(), which diffs a commit's tree against its
+// parent and is the slowest part of most history analyses. Entries are keyed by commit
+// hash; when persisted, they are stored under .git/syst-cache.
+//
+// A nil *CommitStatsCache is valid and computes stats directly.
+type CommitStatsCache struct {
+	mu    sync.Mutex
+	stats map[plumbing.Hash]object.FileStats
+	path  string
+	head  plumbing.Hash
+	dirty bool
+}
+
+// commitStatsFile is the on-disk format of the cache.
+type commitStatsFile struct {
+	Head  string
+	Stats map[string]object.FileStats
+}
+
+// NewCommitStatsCache creates a stats cache for repo. If persist is true, previously
+// cached stats are loaded from .git/syst-cache and Save writes them back.
+//
+// The on-disk cache is discarded when HEAD has moved to a commit that does not descend
+// from the HEAD it was written at (e.g. after a rebase or reset), so stats for rewritten
+// commits do not accumulate.
+func NewCommitStatsCache(repo *git.Repository, persist bool) *CommitStatsCache {
+	cache := &CommitStatsCache{stats: make(map[plumbing.Hash]object.FileStats)}
+
+	if ref, err := repo.Head(); err == nil {
+		cache.head = ref.Hash()
+	}
+
+	if !persist {
+		return cache
+	}
+
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return cache
+	}
+	cache.path = filepath.Join(storage.Filesystem().Root(), statsCacheDir, statsCacheFile)
+
+	cache.load(repo)
+
+	return cache
+}
+
+// load reads the on-disk cache, ignoring it if it is unreadable or invalidated by HEAD.
+func (c *CommitStatsCache) load(repo *git.Repository) {
+	defer profile.Phase(profile.StatsCache)()
+
+	// #nosec G304 - Path is inside the repository's .git directory
+	file, err := os.Open(c.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	var data commitStatsFile
+	if err := gob.NewDecoder(file).Decode(&data); err != nil {
+		return
+	}
+
+	if !headDescends(repo, plumbing.NewHash(data.Head), c.head) {
+		// Rewrite the cache on Save so the stale file is replaced
+		c.dirty = true
+		return
+	}
+
+	for hash, stats := range data.Stats {
+		c.stats[plumbing.NewHash(hash)] = stats
+	}
+}
+
+// headDescends reports whether newHead is oldHead or one of its descendants.
+func headDescends(repo *git.Repository, oldHead, newHead plumbing.Hash) bool {
+	if oldHead == newHead {
+		return true
+	}
+
+	oldCommit, err := repo.CommitObject(oldHead)
+	if err != nil {
+		return false
+	}
+	newCommit, err := repo.CommitObject(newHead)
+	if err != nil {
+		return false
+	}
+
+	isAncestor, err := oldCommit.IsAncestor(newCommit)
+	return err == nil && isAncestor
+}
+
+// Stats returns the file stats for commit, computing and caching them on a miss.
+func (c *CommitStatsCache) Stats(commit *object.Commit) (object.FileStats, error) {
+	if c == nil {
+		defer profile.Phase(profile.Stats)()
+		return commit.Stats()
+	}
+
+	c.mu.Lock()
+	stats, ok := c.stats[commit.Hash]
+	c.mu.Unlock()
+	if ok {
+		return stats, nil
+	}
+
+	done := profile.Phase(profile.Stats)
+	stats, err := commit.Stats()
+	done()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.stats[commit.Hash] = stats
+	c.dirty = true
+	c.mu.Unlock()
+
+	return stats, nil
+}
+
+// Save writes the cache to .git/syst-cache if it is persistent and has changed.
+func (c *CommitStatsCache) Save() error {
+	if c == nil || c.path == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+	defer profile.Phase(profile.StatsCache)()
+
+	data := commitStatsFile{
+		Head:  c.head.String(),
+		Stats: make(map[string]object.FileStats, len(c.stats)),
+	}
+	for hash, stats := range c.stats {
+		data.Stats[hash.String()] = stats
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create stats cache directory: %w", err)
+	}
+
+	// Write to a temp file and rename so an interrupted run never leaves a truncated cache
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), statsCacheFile+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create stats cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write stats cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write stats cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write stats cache: %w", err)
+	}
+
+	c.dirty = false
+	return nil
+}
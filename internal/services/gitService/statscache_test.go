@@ -0,0 +1,152 @@
+package gitservice
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+// newStatsTestRepo creates a repository with the given number of commits, each
+// modifying a few of a fixed set of files.
+func newStatsTestRepo(tb testing.TB, commits int) *gittest.Repo {
+	tb.Helper()
+
+	repo := gittest.New(tb)
+	repo.When = time.Unix(1700000000, 0)
+	repo.Step = time.Minute
+	for i := 0; i < commits; i++ {
+		for f := 0; f < 3; f++ {
+			repo.WriteFile(fmt.Sprintf("file%d.txt", (i+f)%20), fmt.Sprintf("commit %d file %d\n", i, f))
+		}
+		repo.Commit(fmt.Sprintf("commit %d", i))
+	}
+
+	return repo
+}
+
+// headCommits returns all commits reachable from HEAD.
+func headCommits(tb testing.TB, repo *git.Repository) []*object.Commit {
+	tb.Helper()
+
+	ref, err := repo.Head()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	iter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	var commits []*object.Commit
+	// #nosec G104 - Callback never errors
+	iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	})
+	return commits
+}
+
+func TestCommitStatsCachePersists(t *testing.T) {
+	repo := newStatsTestRepo(t, 5)
+	commits := headCommits(t, repo.Repository)
+
+	cache := NewCommitStatsCache(repo.Repository, true)
+	for _, c := range commits {
+		want, err := c.Stats()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := cache.Stats(c)
+		if err != nil {
+			t.Fatalf("Stats() error: %v", err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("Stats(%s) = %v, want %v", c.Hash, got, want)
+		}
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	reloaded := NewCommitStatsCache(repo.Repository, true)
+	if len(reloaded.stats) != len(commits) {
+		t.Errorf("reloaded cache has %d entries, want %d", len(reloaded.stats), len(commits))
+	}
+}
+
+func TestCommitStatsCacheInvalidatedByRewrite(t *testing.T) {
+	repo := newStatsTestRepo(t, 3)
+	commits := headCommits(t, repo.Repository)
+
+	cache := NewCommitStatsCache(repo.Repository, true)
+	for _, c := range commits {
+		if _, err := cache.Stats(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Moving HEAD back to an ancestor (like 'git reset') invalidates the cache
+	ref := plumbing.NewHashReference(plumbing.Master, commits[len(commits)-1].Hash)
+	if err := repo.Storer.SetReference(ref); err != nil {
+		t.Fatal(err)
+	}
+
+	if reloaded := NewCommitStatsCache(repo.Repository, true); len(reloaded.stats) != 0 {
+		t.Errorf("cache after reset has %d entries, want 0", len(reloaded.stats))
+	}
+}
+
+func TestNilCommitStatsCache(t *testing.T) {
+	repo := newStatsTestRepo(t, 2)
+
+	var cache *CommitStatsCache
+	if _, err := cache.Stats(headCommits(t, repo.Repository)[0]); err != nil {
+		t.Errorf("nil cache Stats() error: %v", err)
+	}
+	if err := cache.Save(); err != nil {
+		t.Errorf("nil cache Save() error: %v", err)
+	}
+}
+
+// BenchmarkCommitStats compares walking a repository's history with and without a
+// warm stats cache, as the history, files, health and contributors analyses do.
+func BenchmarkCommitStats(b *testing.B) {
+	repo := newStatsTestRepo(b, 300)
+	commits := headCommits(b, repo.Repository)
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, c := range commits {
+				if _, err := c.Stats(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		cache := NewCommitStatsCache(repo.Repository, false)
+		for _, c := range commits {
+			if _, err := cache.Stats(c); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for _, c := range commits {
+				if _, err := cache.Stats(c); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 // StatusOptions configures the git status display
@@ -44,6 +45,7 @@ type model struct {
 	statusInfo   *StatusInfo
 	list         list.Model
 	tuiHelper    *terminal.ResponsiveTUIHelper
+	keys         terminal.KeyMap
 	err          error
 	loading      bool
 	showingDiff  bool
@@ -75,32 +77,32 @@ func (i statusItem) Description() string {
 // Styles
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#25A065")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			Bold(true)
 
 	sectionStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			Margin(1, 0)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 
 	modifiedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFF00")) // Yellow
+			Foreground(theme.Current.Warning)
 
 	deletedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF0000")) // Red
+			Foreground(theme.Current.Error)
 
 	untrackedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#808080")) // Gray
+			Foreground(theme.Current.Muted)
 
 	stagedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#00FF00")) // Green
+			Foreground(theme.Current.Success)
 )
 
 // TUI Messages
@@ -173,7 +175,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle diff view keys first
 		if m.showingDiff {
 			switch {
-			case key.Matches(msg, key.NewBinding(key.WithKeys("q", "esc"))):
+			case key.Matches(msg, m.keys.Quit, m.keys.Back):
 				m.showingDiff = false
 				m.diffContent = ""
 				m.diffFilename = ""
@@ -184,9 +186,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Normal status view keys
 		switch {
-		case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
-		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		case key.Matches(msg, m.keys.Select):
 			// Open selected file in editor
 			if selected := m.list.SelectedItem(); selected != nil {
 				if item, ok := selected.(statusItem); ok {
@@ -309,7 +311,8 @@ func (m model) View() string {
 	// File list
 	sections = append(sections, m.list.View())
 
-	help := helpStyle.Render("↑/↓: navigate • enter: open • d: diff • D: discard • i: ignored files • q: quit")
+	help := helpStyle.Render(terminal.HelpLine(terminal.HelpKey(m.keys.Up, m.keys.Down)+": navigate",
+		terminal.Help(m.keys.Select, "open"), "d: diff", "D: discard", "i: ignored files", terminal.Help(m.keys.Quit, "quit")))
 	sections = append(sections, help)
 
 	return strings.Join(sections, "\n")
@@ -378,7 +381,7 @@ func (m model) renderDiffView() string {
 	}
 
 	content.WriteString("\n")
-	help := helpStyle.Render("Press q/esc to return to status view")
+	help := helpStyle.Render(fmt.Sprintf("Press %s to return to status view", terminal.HelpKey(m.keys.Quit, m.keys.Back)))
 	content.WriteString(help)
 
 	return content.String()
@@ -497,12 +500,16 @@ func RunGitStatus(opts StatusOptions) error {
 		return fmt.Errorf("not a git repository")
 	}
 
+	if err := terminal.RequireTTY("git status"); err != nil {
+		return err
+	}
+
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("#01FAC6")).
-		BorderLeftForeground(lipgloss.Color("#01FAC6"))
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
 	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("#DDDDDD"))
+		Foreground(theme.Current.Text)
 
 	statusList := list.New([]list.Item{}, delegate, 0, 0)
 	statusList.Title = "Git Status"
@@ -513,7 +520,9 @@ func RunGitStatus(opts StatusOptions) error {
 		list:      statusList,
 		loading:   true,
 		tuiHelper: terminal.NewResponsiveTUIHelper(),
+		keys:      terminal.Keys(),
 	}
+	m.keys.ApplyToList(&m.list)
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	_, err := p.Run()
@@ -0,0 +1,266 @@
+package submodulesService
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// SubmodulesOptions controls the submodule status report
+type SubmodulesOptions struct {
+	// RepoPath is the superproject to inspect; empty means the current directory
+	RepoPath string
+	// JSON prints the report as JSON instead of a table
+	JSON bool
+}
+
+// SubmoduleState compares a submodule's checked-out commit with the one recorded in the
+// superproject
+type SubmoduleState string
+
+const (
+	// StateUninitialized is a submodule that was never cloned
+	StateUninitialized SubmoduleState = "uninitialized"
+	// StateUpToDate is a submodule checked out at the recorded commit
+	StateUpToDate SubmoduleState = "up to date"
+	// StateBehind is a submodule checked out at an ancestor of the recorded commit, i.e.
+	// after pulling the superproject without running git submodule update
+	StateBehind SubmoduleState = "behind"
+	// StateAhead has commits the superproject doesn't record yet
+	StateAhead SubmoduleState = "ahead"
+	// StateDiverged has commits of its own and lacks some of the recorded commit's
+	StateDiverged SubmoduleState = "diverged"
+	// StateUnknown is checked out elsewhere than the recorded commit, which it hasn't
+	// fetched
+	StateUnknown SubmoduleState = "recorded commit not fetched"
+)
+
+// Icon returns the emoji shown next to submodules in this state
+func (s SubmoduleState) Icon() string {
+	switch s {
+	case StateUninitialized:
+		return "⚪"
+	case StateUpToDate:
+		return "✅"
+	case StateBehind:
+		return "⬇️"
+	case StateAhead:
+		return "⬆️"
+	case StateDiverged:
+		return "🔀"
+	default:
+		return "❓"
+	}
+}
+
+// SubmoduleStatus is the state of one submodule listed in .gitmodules
+type SubmoduleStatus struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	URL    string `json:"url"`
+	Branch string `json:"branch,omitempty"`
+	// RecordedCommit is the commit the superproject's index points the submodule at
+	RecordedCommit string `json:"recorded_commit,omitempty"`
+	// Initialized is set when the submodule has been cloned
+	Initialized bool `json:"initialized"`
+	// CheckedOutCommit is the submodule's HEAD, empty if it isn't initialized
+	CheckedOutCommit string         `json:"checked_out_commit,omitempty"`
+	State            SubmoduleState `json:"state"`
+	// Ahead and Behind count the checked-out commit's commits missing from the recorded
+	// commit, and the other way around
+	Ahead  int `json:"ahead"`
+	Behind int `json:"behind"`
+	// Dirty is set when the submodule's working tree has uncommitted changes
+	Dirty bool `json:"dirty"`
+}
+
+// RunSubmodules reports the configured URL, recorded commit and checked-out state of
+// each submodule of the repository. Nothing is cloned or changed.
+func RunSubmodules(opts SubmodulesOptions) error {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+
+	submodules, err := submoduleStatuses(repo)
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(submodules)
+	}
+
+	printSubmodules(os.Stdout, submodules)
+	return nil
+}
+
+// submoduleStatuses returns the status of every submodule in .gitmodules, sorted by path.
+func submoduleStatuses(repo *git.Repository) ([]SubmoduleStatus, error) {
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	submodules, err := wt.Submodules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .gitmodules: %w", err)
+	}
+
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	statuses := make([]SubmoduleStatus, 0, len(submodules))
+	for _, sm := range submodules {
+		status, err := submoduleStatus(repo, idx, sm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status of submodule %s: %w", sm.Config().Name, err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Path < statuses[j].Path })
+	return statuses, nil
+}
+
+func submoduleStatus(repo *git.Repository, idx *index.Index, sm *git.Submodule) (SubmoduleStatus, error) {
+	cfg := sm.Config()
+	status := SubmoduleStatus{
+		Name:   cfg.Name,
+		Path:   cfg.Path,
+		URL:    cfg.URL,
+		Branch: cfg.Branch,
+		State:  StateUninitialized,
+	}
+
+	var recorded plumbing.Hash
+	if entry, err := idx.Entry(cfg.Path); err == nil {
+		recorded = entry.Hash
+		status.RecordedCommit = recorded.String()
+	} else if !errors.Is(err, index.ErrEntryNotFound) {
+		return status, err
+	}
+
+	cloned, err := isCloned(repo, cfg.Name)
+	if err != nil || !cloned {
+		return status, err
+	}
+
+	subRepo, err := sm.Repository()
+	if errors.Is(err, git.ErrSubmoduleNotInitialized) {
+		// Cloned, but removed from .git/config by git submodule deinit
+		return status, nil
+	}
+	if err != nil {
+		return status, err
+	}
+	status.Initialized = true
+
+	head, err := subRepo.Head()
+	if err != nil {
+		return status, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	status.CheckedOutCommit = head.Hash().String()
+
+	subWorktree, err := subRepo.Worktree()
+	if err != nil {
+		return status, err
+	}
+	changes, err := subWorktree.Status()
+	if err != nil {
+		return status, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+	status.Dirty = !changes.IsClean()
+
+	status.State = StateUpToDate
+	if head.Hash() == recorded {
+		return status, nil
+	}
+	if _, err := subRepo.CommitObject(recorded); err != nil {
+		status.State = StateUnknown
+		return status, nil
+	}
+	status.Ahead, status.Behind, err = gitservice.AheadBehind(subRepo, recorded, head.Hash())
+	if err != nil {
+		return status, err
+	}
+	switch {
+	case status.Ahead > 0 && status.Behind > 0:
+		status.State = StateDiverged
+	case status.Ahead > 0:
+		status.State = StateAhead
+	case status.Behind > 0:
+		status.State = StateBehind
+	}
+	return status, nil
+}
+
+// isCloned reports whether the submodule's repository exists under .git/modules.
+// Submodule.Repository creates an empty one otherwise, so it is only called for cloned
+// submodules.
+func isCloned(repo *git.Repository, name string) (bool, error) {
+	storer, err := repo.Storer.Module(name)
+	if err != nil {
+		return false, err
+	}
+	_, err = storer.Reference(plumbing.HEAD)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// printSubmodules writes the submodules as a table.
+func printSubmodules(w io.Writer, submodules []SubmoduleStatus) {
+	if len(submodules) == 0 {
+		fmt.Fprintln(w, "No submodules.")
+		return
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "PATH\tURL\tRECORDED\tCHECKED OUT\tSTATUS")
+	for _, s := range submodules {
+		// The icon goes last, as emoji widths throw off the column alignment
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s %s\n",
+			s.Path, s.URL, shortHash(s.RecordedCommit), shortHash(s.CheckedOutCommit), s.State.Icon(), describe(s))
+	}
+	// #nosec G104 - Flushing to stdout only fails if stdout is closed
+	writer.Flush()
+}
+
+// describe summarizes the state of a submodule, i.e. "behind by 2, dirty"
+func describe(s SubmoduleStatus) string {
+	description := string(s.State)
+	switch s.State {
+	case StateAhead:
+		description = fmt.Sprintf("ahead by %d", s.Ahead)
+	case StateBehind:
+		description = fmt.Sprintf("behind by %d", s.Behind)
+	case StateDiverged:
+		description = fmt.Sprintf("diverged, %d ahead and %d behind", s.Ahead, s.Behind)
+	}
+	if s.Dirty {
+		description += ", dirty"
+	}
+	return description
+}
+
+func shortHash(hash string) string {
+	if len(hash) < 8 {
+		return "-"
+	}
+	return hash[:8]
+}
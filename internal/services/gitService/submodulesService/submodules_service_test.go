@@ -0,0 +1,114 @@
+package submodulesService
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestSubmoduleStatuses(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	// A library repository with two commits, and a superproject recording the second one
+	// as the uninitialized submodule "lib"
+	lib := gittest.New(t)
+	libCommits := []plumbing.Hash{
+		lib.CommitFile("lib.go", "package lib", "Update lib.go"),
+		lib.CommitFile("lib.go", "package lib\n\nfunc Lib() {}", "Update lib.go"),
+	}
+
+	repo := gittest.New(t)
+	// Record the submodule commit like git submodule add would
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := idx.Add("lib")
+	entry.Hash = libCommits[1]
+	entry.Mode = filemode.Submodule
+	if err := repo.Storer.SetIndex(idx); err != nil {
+		t.Fatal(err)
+	}
+	repo.CommitFile(".gitmodules", "[submodule \"lib\"]\n\tpath = lib\n\turl = "+lib.Dir+"\n", "Update .gitmodules")
+
+	statuses, err := submoduleStatuses(repo.Repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("got %d submodules, want 1", len(statuses))
+	}
+	if s := statuses[0]; s.Name != "lib" || s.State != StateUninitialized || s.Initialized ||
+		s.RecordedCommit != libCommits[1].String() {
+		t.Fatalf("status = %+v, want lib uninitialized at %s", s, libCommits[1])
+	}
+	// Listing must not create the submodule's repository
+	if _, err := os.Stat(filepath.Join(repo.Dir, ".git", "modules", "lib")); !os.IsNotExist(err) {
+		t.Errorf(".git/modules/lib exists after listing submodules: %v", err)
+	}
+
+	// Clone the submodule, then check out the first commit, as if the superproject had
+	// been pulled without updating it
+	sm, err := repo.Worktree.Submodule("lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.Update(&git.SubmoduleUpdateOptions{Init: true}); err != nil {
+		t.Fatalf("submodule update: %v", err)
+	}
+	statuses, err = submoduleStatuses(repo.Repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := statuses[0]; s.State != StateUpToDate || !s.Initialized || s.Dirty {
+		t.Fatalf("status after update = %+v, want up to date and clean", s)
+	}
+
+	libRepo, err := sm.Repository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	libWorktree, err := libRepo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := libWorktree.Checkout(&git.CheckoutOptions{Hash: libCommits[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(libWorktree.Filesystem.Root(), "lib.go"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses, err = submoduleStatuses(repo.Repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := statuses[0]
+	if s.State != StateBehind || s.Behind != 1 || s.Ahead != 0 || !s.Dirty {
+		t.Errorf("status = %+v, want behind by 1 and dirty", s)
+	}
+	if s.CheckedOutCommit != libCommits[0].String() {
+		t.Errorf("CheckedOutCommit = %s, want %s", s.CheckedOutCommit, libCommits[0])
+	}
+	if got := describe(s); got != "behind by 1, dirty" {
+		t.Errorf("describe() = %q", got)
+	}
+}
+
+func TestSubmoduleStatusesWithoutSubmodules(t *testing.T) {
+	statuses, err := submoduleStatuses(gittest.New(t).Repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 0 {
+		t.Errorf("got %d submodules, want none", len(statuses))
+	}
+}
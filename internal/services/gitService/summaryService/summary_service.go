@@ -0,0 +1,267 @@
+package summaryService
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/activity"
+	"github.com/redjax/syst/internal/services/gitService/contributorsService"
+	"github.com/redjax/syst/internal/services/gitService/filesService"
+	"github.com/redjax/syst/internal/services/gitService/healthService"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// largestFiles is how many of the largest files the summary lists
+const largestFiles = 3
+
+// SummaryOptions controls which repository is summarized and how
+type SummaryOptions struct {
+	// RepoPath is the repository to summarize; empty means the current directory
+	RepoPath string
+	// ExcludeBots leaves commits from authors matching BotPatterns out of every analysis
+	ExcludeBots bool
+	// BotPatterns overrides gitservice.DefaultBotPatterns
+	BotPatterns []string
+	// NoCache disables the on-disk commit stats cache under .git/syst-cache
+	NoCache bool
+	// JSON prints the summary as JSON instead of a card
+	JSON bool
+}
+
+// Summary is the headline numbers of the other analyses
+type Summary struct {
+	Repository    string `json:"repository"`
+	Branch        string `json:"branch"`
+	TotalCommits  int    `json:"total_commits"`
+	Contributors  int    `json:"contributors"`
+	HealthScore   int    `json:"health_score"`
+	HealthIssues  int    `json:"health_issues"`
+	CurrentStreak int    `json:"current_streak"` // Days in a row with commits, up to today
+	LongestStreak int    `json:"longest_streak"`
+	// LastTag is the most recent tag reachable from HEAD, if there is one
+	LastTag         *TagSummary                    `json:"last_tag,omitempty"`
+	TrackedFiles    int                            `json:"tracked_files"`
+	TrackedSize     int64                          `json:"tracked_size"`
+	LargestFiles    []filesService.LargeFileInfo   `json:"largest_files"`
+	MostChangedFile *filesService.FrequentFileInfo `json:"most_changed_file,omitempty"`
+}
+
+// TagSummary is a tag and the commit it points at
+type TagSummary struct {
+	Name   string    `json:"name"`
+	Commit string    `json:"commit"`
+	Date   time.Time `json:"date"`
+}
+
+var (
+	titleStyle = lipgloss.NewStyle().Foreground(theme.Current.TextOnPrimary).Background(theme.Current.Primary).Bold(true).Padding(0, 1)
+	cardStyle  = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(theme.Current.Border).Padding(0, 1)
+	labelStyle = lipgloss.NewStyle().Foreground(theme.Current.Muted)
+	valueStyle = lipgloss.NewStyle().Foreground(theme.Current.Text).Bold(true)
+	goodStyle  = lipgloss.NewStyle().Foreground(theme.Current.Success).Bold(true)
+	warnStyle  = lipgloss.NewStyle().Foreground(theme.Current.Warning).Bold(true)
+	badStyle   = lipgloss.NewStyle().Foreground(theme.Current.Error).Bold(true)
+)
+
+// RunSummary prints the headline numbers of the activity, contributors, health and
+// files analyses on one screen, as a landing page for the other commands.
+func RunSummary(opts SummaryOptions) error {
+	if err := gitservice.CheckHistory(opts.RepoPath); err != nil {
+		return err
+	}
+
+	summary, err := analyzeSummary(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	}
+
+	printSummary(os.Stdout, summary, time.Now())
+	return nil
+}
+
+// analyzeSummary runs the analyses one after another, without their TUIs. Those that
+// diff commits share the on-disk commit stats cache, so each commit is only diffed by
+// the first of them.
+func analyzeSummary(opts SummaryOptions) (Summary, error) {
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return Summary{}, err
+	}
+	root, err := gitservice.RepoRoot(repo)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to resolve repository root: %w", err)
+	}
+	head, err := gitservice.Head(repo)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary := Summary{
+		Repository:   filepath.Base(root),
+		Branch:       head.Name().Short(),
+		LargestFiles: []filesService.LargeFileInfo{},
+	}
+
+	name, hash, err := gitservice.LastTag(repo)
+	switch {
+	case err == nil:
+		tag := &TagSummary{Name: name, Commit: hash.String()}
+		if commit, err := repo.CommitObject(hash); err == nil {
+			tag.Date = commit.Committer.When
+		}
+		summary.LastTag = tag
+	case !errors.Is(err, gitservice.ErrNoTags):
+		return Summary{}, err
+	}
+
+	activityData, err := activity.AnalyzeActivity(activity.ActivityOptions{
+		RepoPath:    opts.RepoPath,
+		ExcludeBots: opts.ExcludeBots,
+		BotPatterns: opts.BotPatterns,
+	})
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to analyze activity: %w", err)
+	}
+	summary.CurrentStreak = activityData.CurrentStreak
+	summary.LongestStreak = activityData.LongestStreak
+
+	// Contributors are counted by the contributors analysis, which merges identities
+	// with the repository's .mailmap
+	_, overall, err := contributorsService.AnalyzeContributors(contributorsService.ContributorsOptions{
+		RepoPath:    opts.RepoPath,
+		ExcludeBots: opts.ExcludeBots,
+		BotPatterns: opts.BotPatterns,
+		NoCache:     opts.NoCache,
+	})
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to analyze contributors: %w", err)
+	}
+	summary.TotalCommits = overall.TotalCommits
+	summary.Contributors = overall.TotalContributors
+
+	health, err := healthService.AnalyzeHealth(healthService.HealthOptions{
+		RepoPath:    opts.RepoPath,
+		ExcludeBots: opts.ExcludeBots,
+		BotPatterns: opts.BotPatterns,
+		NoCache:     opts.NoCache,
+	})
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to check health: %w", err)
+	}
+	summary.HealthScore = health.OverallScore
+	summary.HealthIssues = len(health.Issues)
+
+	files, err := filesService.AnalyzeFilesJSON(filesService.FileAnalysisOptions{
+		RepoPath: opts.RepoPath,
+		NoCache:  opts.NoCache,
+	})
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to analyze files: %w", err)
+	}
+	summary.TrackedFiles = files.Overview.TotalFiles
+	summary.TrackedSize = files.Overview.TotalSize
+	summary.LargestFiles = append(summary.LargestFiles, files.LargeFiles[:min(len(files.LargeFiles), largestFiles)]...)
+	// Only files over 100 KB are large to the files analysis; without any, the largest
+	// file is still worth showing
+	if len(summary.LargestFiles) == 0 && files.Overview.LargestFile != "" {
+		summary.LargestFiles = append(summary.LargestFiles, filesService.LargeFileInfo{
+			Path: files.Overview.LargestFile,
+			Size: files.Overview.LargestFileSize,
+		})
+	}
+	if len(files.FrequentFiles) > 0 {
+		summary.MostChangedFile = &files.FrequentFiles[0]
+	}
+
+	return summary, nil
+}
+
+// printSummary writes the summary as a card of labeled values
+func printSummary(w io.Writer, s Summary, now time.Time) {
+	var rows []string
+	row := func(label, value string) {
+		rows = append(rows, labelStyle.Render(fmt.Sprintf("%-14s", label))+value)
+	}
+
+	row("Commits", valueStyle.Render(fmt.Sprintf("%d", s.TotalCommits)))
+	row("Contributors", valueStyle.Render(fmt.Sprintf("%d", s.Contributors)))
+	row("Health", scoreStyle(s.HealthScore).Render(fmt.Sprintf("%d/100", s.HealthScore))+
+		labelStyle.Render(fmt.Sprintf(" (%s)", plural(s.HealthIssues, "issue"))))
+
+	if s.LastTag != nil {
+		tag := valueStyle.Render(s.LastTag.Name)
+		if !s.LastTag.Date.IsZero() {
+			tag += labelStyle.Render(fmt.Sprintf(" (%s, %s ago)", s.LastTag.Date.Format("2006-01-02"), daysAgo(s.LastTag.Date, now)))
+		}
+		row("Last tag", tag)
+	} else {
+		row("Last tag", labelStyle.Render("none"))
+	}
+
+	row("Streak", valueStyle.Render(plural(s.CurrentStreak, "day"))+
+		labelStyle.Render(fmt.Sprintf(" (longest %s)", plural(s.LongestStreak, "day"))))
+	row("Files", valueStyle.Render(fmt.Sprintf("%d", s.TrackedFiles))+
+		labelStyle.Render(fmt.Sprintf(" (%s)", gitservice.BytesToHumanReadable(uint64(s.TrackedSize)))))
+
+	if s.MostChangedFile != nil {
+		row("Most changed", valueStyle.Render(s.MostChangedFile.Path)+
+			labelStyle.Render(fmt.Sprintf(" (%s)", plural(s.MostChangedFile.ChangeCount, "change"))))
+	}
+	for i, file := range s.LargestFiles {
+		label := ""
+		if i == 0 {
+			label = "Largest file"
+			if len(s.LargestFiles) > 1 {
+				label += "s"
+			}
+		}
+		row(label, valueStyle.Render(file.Path)+
+			labelStyle.Render(fmt.Sprintf(" (%s)", gitservice.BytesToHumanReadable(uint64(file.Size)))))
+	}
+
+	title := titleStyle.Render(fmt.Sprintf("📋 %s", s.Repository)) + " " + labelStyle.Render("on "+s.Branch)
+	fmt.Fprintln(w, title)
+	fmt.Fprintln(w, cardStyle.Render(strings.Join(rows, "\n")))
+}
+
+// scoreStyle colors a health score like the health report does
+func scoreStyle(score int) lipgloss.Style {
+	switch {
+	case score < 50:
+		return badStyle
+	case score < 70:
+		return warnStyle
+	}
+	return goodStyle
+}
+
+// daysAgo formats how long ago t was in days, or "less than a day"
+func daysAgo(t, now time.Time) string {
+	days := int(now.Sub(t).Hours() / 24)
+	if days < 1 {
+		return "less than a day"
+	}
+	return plural(days, "day")
+}
+
+// plural formats a count with its noun, i.e. "1 day" and "3 days"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,64 @@
+package summaryService
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+)
+
+func TestAnalyzeSummary(t *testing.T) {
+	// Two authors, a tag on the second of three commits, and main.go as the file changed
+	// most
+	repo := gittest.New(t)
+	repo.When = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo.Step = 24 * time.Hour
+	commit := func(author, name, content string) {
+		t.Helper()
+		repo.WriteFile(name, content)
+		repo.CommitAs(author, strings.ToLower(author)+"@example.com", "Change "+name)
+	}
+
+	commit("Alice", "main.go", "package main\n")
+	commit("Bob", "main.go", "package main\n\nfunc main() {}\n")
+	repo.Tag("v1.0.0")
+	commit("Alice", "data.json", strings.Repeat("{}\n", 100))
+
+	summary, err := analyzeSummary(SummaryOptions{RepoPath: repo.Dir, NoCache: true})
+	if err != nil {
+		t.Fatalf("analyzeSummary() error: %v", err)
+	}
+
+	if summary.Repository != filepath.Base(repo.Dir) || summary.Branch != "master" {
+		t.Errorf("repository = %s on %s, want %s on master", summary.Repository, summary.Branch, filepath.Base(repo.Dir))
+	}
+	if summary.TotalCommits != 3 || summary.Contributors != 2 {
+		t.Errorf("got %d commits by %d contributors, want 3 by 2", summary.TotalCommits, summary.Contributors)
+	}
+	if summary.LastTag == nil || summary.LastTag.Name != "v1.0.0" {
+		t.Errorf("last tag = %+v, want v1.0.0", summary.LastTag)
+	}
+	if summary.LongestStreak != 3 {
+		t.Errorf("longest streak = %d, want 3", summary.LongestStreak)
+	}
+	// Neither file is over 100 KB, so only the largest one is listed
+	if summary.TrackedFiles != 2 || len(summary.LargestFiles) != 1 || summary.LargestFiles[0].Path != "data.json" {
+		t.Errorf("got %d files, largest %+v, want 2 with data.json the largest", summary.TrackedFiles, summary.LargestFiles)
+	}
+	if summary.MostChangedFile == nil || summary.MostChangedFile.Path != "main.go" {
+		t.Errorf("most changed file = %+v, want main.go", summary.MostChangedFile)
+	}
+	if summary.HealthScore <= 0 || summary.HealthScore > 100 {
+		t.Errorf("health score = %d, want 1-100", summary.HealthScore)
+	}
+
+	var out strings.Builder
+	printSummary(&out, summary, time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC))
+	for _, want := range []string{"v1.0.0", "(2026-03-03, 11 days ago)", "main.go (2 changes)", "data.json"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("summary is missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package gitservice
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// LastTagRef is a pseudo-ref for the most recent tag reachable from HEAD, so "what
+// changed since the last release" can be written as "@last-tag..HEAD"
+const LastTagRef = "@last-tag"
+
+// ErrNoTags is returned when resolving LastTagRef in a repository without a tag
+// reachable from HEAD
+var ErrNoTags = errors.New("no tags reachable from HEAD")
+
+// TagsByCommit maps commit hashes to the names of tags pointing at them. Annotated tags,
+// including tags of tags, are peeled to their commit.
+func TagsByCommit(repo *git.Repository) (map[plumbing.Hash]string, error) {
+	refs, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := make(map[plumbing.Hash]string)
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if hash, ok := peelTag(repo, ref.Hash()); ok {
+			tags[hash] = ref.Name().Short()
+		}
+		return nil
+	})
+
+	return tags, err
+}
+
+// LastTag returns the most recent tag reachable from HEAD, including a tag on HEAD
+// itself, and the commit it points at, like 'git describe --tags --abbrev=0'.
+func LastTag(repo *git.Repository) (string, plumbing.Hash, error) {
+	head, err := Head(repo)
+	if err != nil {
+		return "", plumbing.ZeroHash, err
+	}
+
+	tags, err := TagsByCommit(repo)
+	if err != nil {
+		return "", plumbing.ZeroHash, err
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return "", plumbing.ZeroHash, fmt.Errorf("failed to get log: %w", err)
+	}
+
+	var name string
+	var hash plumbing.Hash
+	err = iter.ForEach(func(c *object.Commit) error {
+		if tag, ok := tags[c.Hash]; ok {
+			name, hash = tag, c.Hash
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return "", plumbing.ZeroHash, err
+	}
+	if name == "" {
+		return "", plumbing.ZeroHash, ErrNoTags
+	}
+
+	return name, hash, nil
+}
+
+// peelTag follows a tag object, through tags of tags, to the commit it points at. A hash
+// that isn't a tag object is a lightweight tag's commit. ok is false for tags of trees
+// and blobs.
+func peelTag(repo *git.Repository, hash plumbing.Hash) (plumbing.Hash, bool) {
+	for {
+		tag, err := repo.TagObject(hash)
+		if err != nil {
+			// Lightweight tags point straight at a commit
+			return hash, true
+		}
+		if tag.TargetType != plumbing.TagObject && tag.TargetType != plumbing.CommitObject {
+			return hash, false
+		}
+		hash = tag.Target
+	}
+}
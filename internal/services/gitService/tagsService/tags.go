@@ -0,0 +1,113 @@
+package tagsService
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+)
+
+// maxCommits is how many recent commits are offered when choosing what to tag
+const maxCommits = 200
+
+// TagManagerOptions controls the tag manager
+type TagManagerOptions struct {
+	// RepoPath is the repository to manage; empty means the current directory
+	RepoPath string
+	// Remote is the remote tags are pushed to and deleted from
+	Remote string
+}
+
+// CommitInfo is a commit that can be tagged
+type CommitInfo struct {
+	Hash      plumbing.Hash
+	ShortHash string
+	Message   string
+	Author    string
+	Date      time.Time
+}
+
+// recentCommits returns up to limit commits reachable from HEAD, newest first.
+func recentCommits(repo *git.Repository, limit int) ([]CommitInfo, error) {
+	head, err := gitservice.Head(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	cIter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log: %w", err)
+	}
+
+	var commits []CommitInfo
+	err = cIter.ForEach(func(c *object.Commit) error {
+		if len(commits) >= limit {
+			return storer.ErrStop
+		}
+		commits = append(commits, CommitInfo{
+			Hash:      c.Hash,
+			ShortHash: c.Hash.String()[:8],
+			Message:   strings.Split(c.Message, "\n")[0],
+			Author:    c.Author.Name,
+			Date:      c.Author.When,
+		})
+		return nil
+	})
+
+	return commits, err
+}
+
+// createTag creates an annotated tag on hash. The tagger is read from the git config.
+func createTag(repo *git.Repository, name string, hash plumbing.Hash, message string) error {
+	if name == "" {
+		return fmt.Errorf("tag name cannot be empty")
+	}
+	if message == "" {
+		return fmt.Errorf("annotated tags need a message")
+	}
+
+	if _, err := repo.CreateTag(name, hash, &git.CreateTagOptions{Message: message}); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+	return nil
+}
+
+// deleteTag deletes a local tag. It returns the hash the tag pointed at, the tag object
+// for an annotated tag, so the tag can be restored.
+func deleteTag(repo *git.Repository, name string) (string, error) {
+	ref, err := repo.Tag(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete tag %s: %w", name, err)
+	}
+	if err := repo.DeleteTag(name); err != nil {
+		return "", fmt.Errorf("failed to delete tag %s: %w", name, err)
+	}
+	return ref.Hash().String(), nil
+}
+
+// pushTag pushes a tag to remote, or deletes it there if remove is set. It runs the git
+// CLI so the user's credential helpers and SSH agent are used.
+func pushTag(repo *git.Repository, remote, name string, remove bool) error {
+	root, err := gitservice.RepoRoot(repo)
+	if err != nil {
+		return err
+	}
+
+	refspec := "refs/tags/" + name
+	if remove {
+		refspec = ":" + refspec
+	}
+
+	// #nosec G204 - remote and tag names come from the repository and the user's own input
+	out, err := exec.Command("git", "-C", root, "push", remote, refspec).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git push failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package tagsService
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/redjax/syst/internal/services/gitService/gittest"
+	"github.com/redjax/syst/internal/services/gitService/historyService"
+)
+
+// newTagsTestRepo creates a repository with three commits and a git identity for tagging.
+func newTagsTestRepo(t *testing.T) *gittest.Repo {
+	t.Helper()
+
+	repo := gittest.New(t)
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.User.Name = "Test"
+	cfg.User.Email = "test@example.com"
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, message := range []string{"First", "Second", "Third"} {
+		repo.CommitFile("file.txt", message, message)
+	}
+
+	return repo
+}
+
+func TestCreateAndDeleteTag(t *testing.T) {
+	repo := newTagsTestRepo(t)
+
+	commits, err := recentCommits(repo.Repository, 2)
+	if err != nil {
+		t.Fatalf("recentCommits() error: %v", err)
+	}
+	if len(commits) != 2 || commits[1].Message != "Second" {
+		t.Fatalf("recentCommits() = %+v, want Third and Second", commits)
+	}
+
+	if err := createTag(repo.Repository, "v1.0.0", commits[1].Hash, "Release v1.0.0"); err != nil {
+		t.Fatalf("createTag() error: %v", err)
+	}
+	if err := createTag(repo.Repository, "v1.0.1", commits[1].Hash, ""); err == nil {
+		t.Error("createTag() without a message should fail")
+	}
+
+	tags, err := historyService.LoadTags(repo.Repository)
+	if err != nil {
+		t.Fatalf("LoadTags() error: %v", err)
+	}
+	if len(tags) != 1 {
+		t.Fatalf("LoadTags() = %+v, want one tag", tags)
+	}
+	tag := tags[0]
+	if tag.Name != "v1.0.0" || tag.Type != "annotated" || tag.Tagger != "Test" {
+		t.Errorf("tag = %+v, want annotated v1.0.0 by Test", tag)
+	}
+	if tag.Hash != commits[1].ShortHash || tag.CommitsSince != 1 {
+		t.Errorf("tag points at %s with %d commits since, want %s with 1", tag.Hash, tag.CommitsSince, commits[1].ShortHash)
+	}
+
+	ref, err := repo.Repository.Tag("v1.0.0")
+	if err != nil {
+		t.Fatalf("repo.Tag() error: %v", err)
+	}
+	hash, err := deleteTag(repo.Repository, "v1.0.0")
+	if err != nil {
+		t.Fatalf("deleteTag() error: %v", err)
+	}
+	if _, err := repo.Repository.Tag("v1.0.0"); err == nil {
+		t.Error("tag still exists after deleteTag()")
+	}
+
+	// The returned hash is the tag object, so restoring the ref brings the annotated tag back
+	if hash != ref.Hash().String() {
+		t.Errorf("deleteTag() = %s, want %s", hash, ref.Hash())
+	}
+	restored := plumbing.NewHashReference(plumbing.NewTagReferenceName("v1.0.0"), plumbing.NewHash(hash))
+	if err := repo.Storer.SetReference(restored); err != nil {
+		t.Fatalf("failed to restore tag: %v", err)
+	}
+	if tagObj, err := repo.TagObject(restored.Hash()); err != nil || tagObj.Name != "v1.0.0" {
+		t.Errorf("restored tag = %v, %v, want the annotated v1.0.0", tagObj, err)
+	}
+}
+
+func TestPushTag(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	repo := newTagsTestRepo(t)
+
+	remote, remoteDir := gittest.NewBare(t, "remote.git")
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remoteDir}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := createTag(repo.Repository, "v2.0.0", repo.Head(), "Release v2.0.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pushTag(repo.Repository, "origin", "v2.0.0", false); err != nil {
+		t.Fatalf("pushTag() error: %v", err)
+	}
+	if _, err := remote.Reference(plumbing.NewTagReferenceName("v2.0.0"), false); err != nil {
+		t.Errorf("tag missing on remote after push: %v", err)
+	}
+
+	if err := pushTag(repo.Repository, "origin", "v2.0.0", true); err != nil {
+		t.Fatalf("pushTag() delete error: %v", err)
+	}
+	if _, err := remote.Reference(plumbing.NewTagReferenceName("v2.0.0"), false); err == nil {
+		t.Error("tag still on remote after delete")
+	}
+}
@@ -0,0 +1,533 @@
+package tagsService
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/go-git/go-git/v5"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
+	"github.com/redjax/syst/internal/services/gitService/historyService"
+	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+type viewMode int
+
+const (
+	listView viewMode = iota
+	commitView
+	formView
+	confirmView
+)
+
+// Actions that ask for confirmation
+const (
+	actionDelete       = "delete"
+	actionPush         = "push"
+	actionDeleteRemote = "delete-remote"
+)
+
+type model struct {
+	repo           *git.Repository
+	opts           TagManagerOptions
+	currentView    viewMode
+	tagList        list.Model
+	commitList     list.Model
+	formInputs     []textinput.Model
+	focusedInput   int
+	selectedCommit CommitInfo
+	confirmAction  string
+	confirmTarget  string
+	tuiHelper      *terminal.ResponsiveTUIHelper
+	keys           terminal.KeyMap
+	loading        bool
+	message        string
+	err            error
+}
+
+type tagItem struct {
+	tag historyService.TagInfo
+}
+
+func (i tagItem) FilterValue() string { return i.tag.Name }
+func (i tagItem) Title() string {
+	prefix := "🏷️"
+	if i.tag.Type == "annotated" {
+		prefix = "📋"
+	}
+	return fmt.Sprintf("%s %s", prefix, i.tag.Name)
+}
+func (i tagItem) Description() string {
+	return fmt.Sprintf("%s • %s • %s • %s • %d commits since",
+		i.tag.Hash, i.tag.Type, i.tag.Tagger, i.tag.Date.Format("2006-01-02"), i.tag.CommitsSince)
+}
+
+type commitItem struct {
+	commit CommitInfo
+}
+
+func (i commitItem) FilterValue() string { return i.commit.Message }
+func (i commitItem) Title() string {
+	return fmt.Sprintf("%s %s", i.commit.ShortHash, i.commit.Message)
+}
+func (i commitItem) Description() string {
+	return fmt.Sprintf("%s • %s", i.commit.Author, i.commit.Date.Format("2006-01-02 15:04"))
+}
+
+type tagsLoadedMsg struct {
+	tags []historyService.TagInfo
+}
+
+type commitsLoadedMsg struct {
+	commits []CommitInfo
+}
+
+// tagChangedMsg is sent after a tag is created or deleted locally, to offer
+// repeating the change on the remote
+type tagChangedMsg struct {
+	tag     string
+	deleted bool
+	// hash is what a deleted tag pointed at, for the undo hint
+	hash string
+}
+
+type successMsg struct {
+	message string
+}
+
+type errMsg struct {
+	err error
+}
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
+			Padding(0, 1)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Muted).
+			Padding(1, 0)
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Error)
+
+	successStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Success)
+
+	formStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.Current.Border).
+			Padding(1, 2).
+			MarginTop(1)
+)
+
+func (m model) Init() tea.Cmd {
+	return loadTags(m.repo)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.tuiHelper.HandleWindowSizeMsg(msg)
+		m.tagList.SetSize(m.tuiHelper.GetWidth(), m.tuiHelper.GetHeight()-8)
+		m.commitList.SetSize(m.tuiHelper.GetWidth(), m.tuiHelper.GetHeight()-8)
+		return m, nil
+
+	case tagsLoadedMsg:
+		m.loading = false
+		items := make([]list.Item, len(msg.tags))
+		for i, tag := range msg.tags {
+			items[i] = tagItem{tag: tag}
+		}
+		return m, m.tagList.SetItems(items)
+
+	case commitsLoadedMsg:
+		items := make([]list.Item, len(msg.commits))
+		for i, commit := range msg.commits {
+			items[i] = commitItem{commit: commit}
+		}
+		m.commitList.ResetSelected()
+		m.currentView = commitView
+		return m, m.commitList.SetItems(items)
+
+	case tagChangedMsg:
+		m.err = nil
+		m.currentView = confirmView
+		m.confirmTarget = msg.tag
+		if msg.deleted {
+			m.message = fmt.Sprintf("Deleted tag %s (restore with: %s)", msg.tag, gitservice.UndoTagHint(msg.tag, msg.hash))
+			m.confirmAction = actionDeleteRemote
+		} else {
+			m.message = fmt.Sprintf("Created tag %s", msg.tag)
+			m.confirmAction = actionPush
+		}
+		return m, loadTags(m.repo)
+
+	case successMsg:
+		m.message = msg.message
+		m.err = nil
+		m.currentView = listView
+		return m, nil
+
+	case errMsg:
+		m.err = msg.err
+		m.message = ""
+		m.currentView = listView
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.currentView {
+		case listView:
+			return m.handleListViewKeys(msg)
+		case commitView:
+			return m.handleCommitViewKeys(msg)
+		case formView:
+			return m.handleFormViewKeys(msg)
+		case confirmView:
+			return m.handleConfirmViewKeys(msg)
+		}
+	}
+
+	return m, nil
+}
+
+func (m model) handleListViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// While filtering, keys are typed into the filter
+	if m.tagList.FilterState() == list.Filtering {
+		var cmd tea.Cmd
+		m.tagList, cmd = m.tagList.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
+		m.message, m.err = "", nil
+		return m, loadCommits(m.repo)
+	case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
+		if item, ok := m.tagList.SelectedItem().(tagItem); ok {
+			m.message, m.err = "", nil
+			m.currentView = confirmView
+			m.confirmAction = actionDelete
+			m.confirmTarget = item.tag.Name
+		}
+		return m, nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("p"))):
+		if item, ok := m.tagList.SelectedItem().(tagItem); ok {
+			m.message, m.err = "", nil
+			m.currentView = confirmView
+			m.confirmAction = actionPush
+			m.confirmTarget = item.tag.Name
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Refresh):
+		return m, loadTags(m.repo)
+	}
+
+	var cmd tea.Cmd
+	m.tagList, cmd = m.tagList.Update(msg)
+	return m, cmd
+}
+
+func (m model) handleCommitViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.commitList.FilterState() == list.Filtering {
+		var cmd tea.Cmd
+		m.commitList, cmd = m.commitList.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.Back, m.keys.Quit):
+		m.currentView = listView
+		return m, nil
+	case key.Matches(msg, m.keys.Select):
+		if item, ok := m.commitList.SelectedItem().(commitItem); ok {
+			m.selectedCommit = item.commit
+			m.currentView = formView
+			m.formInputs = createTagForm()
+			m.focusedInput = 0
+			return m, m.formInputs[0].Focus()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.commitList, cmd = m.commitList.Update(msg)
+	return m, cmd
+}
+
+func (m model) handleFormViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "ctrl+c" || key.Matches(msg, m.keys.Back):
+		m.currentView = listView
+		m.formInputs = nil
+		return m, nil
+	case key.Matches(msg, m.keys.NextView, m.keys.PrevView):
+		if key.Matches(msg, m.keys.NextView) {
+			m.focusedInput = (m.focusedInput + 1) % len(m.formInputs)
+		} else {
+			m.focusedInput = (m.focusedInput + len(m.formInputs) - 1) % len(m.formInputs)
+		}
+		for i := range m.formInputs {
+			if i == m.focusedInput {
+				m.formInputs[i].Focus()
+			} else {
+				m.formInputs[i].Blur()
+			}
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Select):
+		name := strings.TrimSpace(m.formInputs[0].Value())
+		message := strings.TrimSpace(m.formInputs[1].Value())
+		return m, createTagCmd(m.repo, name, m.selectedCommit, message)
+	}
+
+	var cmd tea.Cmd
+	m.formInputs[m.focusedInput], cmd = m.formInputs[m.focusedInput].Update(msg)
+	return m, cmd
+}
+
+func (m model) handleConfirmViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "y" || msg.String() == "Y":
+		m.currentView = listView
+		switch m.confirmAction {
+		case actionDelete:
+			return m, deleteTagCmd(m.repo, m.confirmTarget)
+		case actionPush:
+			m.message = fmt.Sprintf("Pushing %s to %s...", m.confirmTarget, m.opts.Remote)
+			return m, pushTagCmd(m.repo, m.opts.Remote, m.confirmTarget, false)
+		case actionDeleteRemote:
+			m.message = fmt.Sprintf("Deleting %s from %s...", m.confirmTarget, m.opts.Remote)
+			return m, pushTagCmd(m.repo, m.opts.Remote, m.confirmTarget, true)
+		}
+	case msg.String() == "n" || msg.String() == "N" || msg.String() == "ctrl+c" || key.Matches(msg, m.keys.Back):
+		m.currentView = listView
+		m.confirmAction = ""
+		m.confirmTarget = ""
+	}
+	return m, nil
+}
+
+func createTagForm() []textinput.Model {
+	inputs := make([]textinput.Model, 2)
+
+	inputs[0] = textinput.New()
+	inputs[0].Placeholder = "v1.2.3"
+	inputs[0].CharLimit = 128
+	inputs[0].Width = 50
+	inputs[0].Prompt = "Name: "
+
+	inputs[1] = textinput.New()
+	inputs[1].Placeholder = "Release v1.2.3"
+	inputs[1].CharLimit = 256
+	inputs[1].Width = 50
+	inputs[1].Prompt = "Message: "
+
+	return inputs
+}
+
+func (m model) View() string {
+	switch m.currentView {
+	case commitView:
+		return m.renderCommitView()
+	case formView:
+		return m.renderFormView()
+	case confirmView:
+		return m.renderConfirmView()
+	default:
+		return m.renderListView()
+	}
+}
+
+func (m model) renderStatus() string {
+	if m.err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n"
+	}
+	if m.message != "" {
+		return successStyle.Render(m.message) + "\n"
+	}
+	return ""
+}
+
+func (m model) renderListView() string {
+	var s strings.Builder
+
+	s.WriteString(titleStyle.Render("🏷️ Tag Manager") + "\n\n")
+	s.WriteString(m.renderStatus())
+
+	if m.loading {
+		s.WriteString("Loading tags...\n")
+	} else if len(m.tagList.Items()) == 0 {
+		s.WriteString("No tags found in repository\n")
+	} else {
+		s.WriteString(m.tagList.View() + "\n")
+	}
+
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(n) new tag  (d) delete  (p) push  (%s) filter  (%s) refresh  (%s) quit",
+		terminal.HelpKey(m.keys.Filter), terminal.HelpKey(m.keys.Refresh), terminal.HelpKey(m.keys.Quit))))
+	return s.String()
+}
+
+func (m model) renderCommitView() string {
+	var s strings.Builder
+
+	s.WriteString(titleStyle.Render("🏷️ Choose a commit to tag") + "\n\n")
+	s.WriteString(m.commitList.View() + "\n")
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(%s) select  (%s) filter  (%s) cancel",
+		terminal.HelpKey(m.keys.Select), terminal.HelpKey(m.keys.Filter), terminal.HelpKey(m.keys.Back))))
+	return s.String()
+}
+
+func (m model) renderFormView() string {
+	var s strings.Builder
+
+	s.WriteString(titleStyle.Render("New Annotated Tag") + "\n\n")
+	s.WriteString(fmt.Sprintf("Commit: %s %s\n\n", m.selectedCommit.ShortHash, m.selectedCommit.Message))
+
+	for i, input := range m.formInputs {
+		if i == m.focusedInput {
+			s.WriteString("► ")
+		} else {
+			s.WriteString("  ")
+		}
+		s.WriteString(input.View() + "\n")
+	}
+
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(%s) next field  (%s) create  (%s) cancel",
+		terminal.HelpKey(m.keys.NextView), terminal.HelpKey(m.keys.Select), terminal.HelpKey(m.keys.Back))))
+	return formStyle.Render(s.String())
+}
+
+func (m model) renderConfirmView() string {
+	var s strings.Builder
+
+	s.WriteString(m.renderStatus())
+	s.WriteString(titleStyle.Render("Confirm") + "\n\n")
+
+	switch m.confirmAction {
+	case actionDelete:
+		s.WriteString(fmt.Sprintf("Delete local tag %s?\n", m.confirmTarget))
+	case actionPush:
+		s.WriteString(fmt.Sprintf("Push tag %s to %s?\n", m.confirmTarget, m.opts.Remote))
+	case actionDeleteRemote:
+		s.WriteString(fmt.Sprintf("Also delete tag %s from %s?\n", m.confirmTarget, m.opts.Remote))
+	}
+
+	s.WriteString(helpStyle.Render("(y) yes  (n) no"))
+	return formStyle.Render(s.String())
+}
+
+// Commands
+func loadTags(repo *git.Repository) tea.Cmd {
+	return func() tea.Msg {
+		tags, err := historyService.LoadTags(repo)
+		if err != nil {
+			return errMsg{err: fmt.Errorf("failed to load tags: %w", err)}
+		}
+		return tagsLoadedMsg{tags: tags}
+	}
+}
+
+func loadCommits(repo *git.Repository) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := recentCommits(repo, maxCommits)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return commitsLoadedMsg{commits: commits}
+	}
+}
+
+func createTagCmd(repo *git.Repository, name string, commit CommitInfo, message string) tea.Cmd {
+	return func() tea.Msg {
+		if err := createTag(repo, name, commit.Hash, message); err != nil {
+			return errMsg{err: err}
+		}
+		return tagChangedMsg{tag: name}
+	}
+}
+
+func deleteTagCmd(repo *git.Repository, name string) tea.Cmd {
+	return func() tea.Msg {
+		hash, err := deleteTag(repo, name)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return tagChangedMsg{tag: name, deleted: true, hash: hash}
+	}
+}
+
+func pushTagCmd(repo *git.Repository, remote, name string, remove bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := pushTag(repo, remote, name, remove); err != nil {
+			return errMsg{err: err}
+		}
+		if remove {
+			return successMsg{message: fmt.Sprintf("Deleted tag %s from %s", name, remote)}
+		}
+		return successMsg{message: fmt.Sprintf("Pushed tag %s to %s", name, remote)}
+	}
+}
+
+// RunTagManager starts the interactive tag manager TUI
+func RunTagManager(opts TagManagerOptions) error {
+	if opts.Remote == "" {
+		opts.Remote = "origin"
+	}
+
+	repo, err := gitservice.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return err
+	}
+	if _, err := gitservice.Head(repo); err != nil {
+		return err
+	}
+
+	if err := terminal.RequireTTY(""); err != nil {
+		return err
+	}
+
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
+		Foreground(theme.Current.Text)
+
+	tagList := list.New([]list.Item{}, delegate, 0, 0)
+	tagList.SetShowTitle(false)
+	tagList.SetShowStatusBar(false)
+	tagList.SetShowHelp(false)
+
+	commitList := list.New([]list.Item{}, delegate, 0, 0)
+	commitList.SetShowTitle(false)
+	commitList.SetShowStatusBar(false)
+	commitList.SetShowHelp(false)
+
+	m := model{
+		repo:        repo,
+		opts:        opts,
+		currentView: listView,
+		tagList:     tagList,
+		commitList:  commitList,
+		tuiHelper:   terminal.NewResponsiveTUIHelper(),
+		keys:        terminal.Keys(),
+		loading:     true,
+	}
+	m.keys.ApplyToList(&m.tagList)
+	m.keys.ApplyToList(&m.commitList)
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	_, err = p.Run()
+	return err
+}
@@ -0,0 +1,68 @@
+package gitservice
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// IsBinary uses git's heuristic: a NUL byte in the first 8000 bytes means binary.
+func IsBinary(content string) bool {
+	return strings.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+}
+
+// DisplayText prepares file content for the TUIs: it is converted to UTF-8 with ToUTF8,
+// and CRLF line endings become LF so lines don't end in a stray "\r". Only the "\r"
+// of each line ending is dropped, so the content keeps its line numbers.
+func DisplayText(content string) string {
+	return strings.ReplaceAll(ToUTF8(content), "\r\n", "\n")
+}
+
+// ToUTF8 returns content as valid UTF-8. Git stores bytes, not text, so files in legacy
+// encodings come out as invalid UTF-8 that garbles the terminal. Content without any
+// valid multibyte UTF-8 sequence is taken to be Windows-1252, a superset of Latin-1
+// and the most common legacy encoding; otherwise it is UTF-8 with a few bad bytes,
+// which are replaced with U+FFFD.
+func ToUTF8(content string) string {
+	if utf8.ValidString(content) {
+		return content
+	}
+	if hasMultibyteUTF8(content) {
+		return strings.ToValidUTF8(content, string(utf8.RuneError))
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(content) + len(content)/4)
+	for i := 0; i < len(content); i++ {
+		b := content[i]
+		switch {
+		case b < 0x80:
+			sb.WriteByte(b)
+		case b < 0xA0:
+			sb.WriteRune(windows1252[b-0x80])
+		default:
+			// Latin-1 and Windows-1252 both match Unicode from 0xA0 up
+			sb.WriteRune(rune(b))
+		}
+	}
+	return sb.String()
+}
+
+// hasMultibyteUTF8 reports whether content has a valid UTF-8 sequence of more than one
+// byte, which Latin-1 text almost never does by accident
+func hasMultibyteUTF8(content string) bool {
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		if r != utf8.RuneError && size > 1 {
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// windows1252 maps the bytes 0x80-0x9F, which are control characters in Latin-1, to
+// their Windows-1252 characters. The five unassigned bytes become U+FFFD.
+var windows1252 = [32]rune{
+	'€', utf8.RuneError, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', utf8.RuneError, 'Ž', utf8.RuneError,
+	utf8.RuneError, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', utf8.RuneError, 'ž', 'Ÿ',
+}
@@ -0,0 +1,31 @@
+package gitservice
+
+import "testing"
+
+func TestDisplayText(t *testing.T) {
+	tests := []struct {
+		name, content, want string
+	}{
+		{"utf-8", "naïve café\n", "naïve café\n"},
+		{"crlf", "one\r\ntwo\r\nthree", "one\ntwo\nthree"},
+		{"lone carriage return", "progress\r100%\n", "progress\r100%\n"},
+		{"latin-1", "na\xefve caf\xe9\r\n", "naïve café\n"},
+		{"windows-1252", "\x93quoted\x94 \x80 5", "“quoted” € 5"},
+		{"unassigned windows-1252 byte", "a\x81b", "a�b"},
+		{"mostly utf-8", "café \xff", "café �"},
+	}
+	for _, tt := range tests {
+		if got := DisplayText(tt.content); got != tt.want {
+			t.Errorf("%s: DisplayText(%q) = %q, want %q", tt.name, tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	if IsBinary("caf\xe9\r\n") {
+		t.Error("latin-1 text with CRLF endings is binary")
+	}
+	if !IsBinary("PNG\x00\x01") {
+		t.Error("content with a NUL byte isn't binary")
+	}
+}
@@ -0,0 +1,87 @@
+package gitservice
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// ViewState is where a TUI was left: the selected section or view, and the selected
+// item in its list, which also restores the scroll position
+type ViewState struct {
+	View  int `json:"view"`
+	Index int `json:"index"`
+}
+
+// viewStates maps a repository root to the saved state of each TUI in it
+type viewStates map[string]map[string]ViewState
+
+// viewStatePath returns the file the TUI view state is saved in.
+func viewStatePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "syst", "view-state.json"), nil
+}
+
+// LoadViewState returns the state the named TUI was left in for repo, and whether one
+// was saved. State is kept per repository so unrelated projects don't share it.
+func LoadViewState(repo *git.Repository, tui string) (ViewState, bool) {
+	path, err := viewStatePath()
+	if err != nil {
+		return ViewState{}, false
+	}
+	root, err := RepoRoot(repo)
+	if err != nil {
+		return ViewState{}, false
+	}
+
+	state, ok := loadViewStates(path)[root][tui]
+	return state, ok
+}
+
+// SaveViewState records the state the named TUI was left in for repo.
+func SaveViewState(repo *git.Repository, tui string, state ViewState) error {
+	path, err := viewStatePath()
+	if err != nil {
+		return err
+	}
+	root, err := RepoRoot(repo)
+	if err != nil {
+		return err
+	}
+	return saveViewState(path, root, tui, state)
+}
+
+func loadViewStates(path string) viewStates {
+	states := make(viewStates)
+
+	// #nosec G304 - Path is derived from the user's config directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return states
+	}
+	// #nosec G104 - A corrupt file just means nothing is restored; the next save replaces it
+	json.Unmarshal(data, &states)
+	return states
+}
+
+func saveViewState(path, root, tui string, state ViewState) error {
+	states := loadViewStates(path)
+	if states[root] == nil {
+		states[root] = make(map[string]ViewState)
+	}
+	states[root][tui] = state
+
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,50 @@
+package gitservice
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestViewStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "syst", "view-state.json")
+
+	if states := loadViewStates(path); len(states) != 0 {
+		t.Errorf("missing file loaded %v, want no states", states)
+	}
+
+	if err := saveViewState(path, "/src/a", "history", ViewState{View: 2, Index: 14}); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveViewState(path, "/src/a", "health", ViewState{View: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveViewState(path, "/src/b", "history", ViewState{View: 1, Index: 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	states := loadViewStates(path)
+	if got := states["/src/a"]["history"]; got != (ViewState{View: 2, Index: 14}) {
+		t.Errorf("history state for a = %+v", got)
+	}
+	if got := states["/src/a"]["health"]; got != (ViewState{View: 3}) {
+		t.Errorf("health state for a = %+v", got)
+	}
+	if got := states["/src/b"]["history"]; got != (ViewState{View: 1, Index: 4}) {
+		t.Errorf("repositories share state: history state for b = %+v", got)
+	}
+
+	// A corrupt file is ignored and replaced on the next save
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if states := loadViewStates(path); len(states) != 0 {
+		t.Errorf("corrupt file loaded %v, want no states", states)
+	}
+	if err := saveViewState(path, "/src/a", "history", ViewState{View: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadViewStates(path)["/src/a"]["history"]; got != (ViewState{View: 1}) {
+		t.Errorf("state after replacing corrupt file = %+v", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 type viewMode int
@@ -31,7 +32,9 @@ type model struct {
 	confirmAction    string
 	confirmTarget    string
 	tuiHelper        *terminal.ResponsiveTUIHelper
+	keys             terminal.KeyMap
 	message          string
+	warning          string
 	terminalOnlyPath string // Set when exiting for terminal-only cd
 }
 
@@ -49,33 +52,40 @@ type successMsg struct {
 
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#25A065")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1)
 
 	selectedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#874BFD")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1)
 
 	normalStyle = lipgloss.NewStyle().
 			Padding(0, 1)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			Padding(1, 0)
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87")).
+			Foreground(theme.Current.Error).
 			Padding(1, 2)
 
 	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575")).
+			Foreground(theme.Current.Success).
 			Padding(1, 2)
 
+	warningStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Warning).
+			Padding(1, 2)
+
+	statusStyle = lipgloss.NewStyle().
+			Foreground(theme.Current.Muted)
+
 	formStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			MarginTop(1)
 )
@@ -87,6 +97,7 @@ func initialModel(manager *WorktreeManager) model {
 		manager:     manager,
 		currentView: listView,
 		tuiHelper:   tuiHelper,
+		keys:        terminal.Keys(),
 	}
 }
 
@@ -103,6 +114,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case worktreesLoadedMsg:
 		m.worktrees = msg.worktrees
 		m.message = ""
+		m.warning = ""
+		if m.cursor >= len(m.worktrees) {
+			m.cursor = max(len(m.worktrees)-1, 0)
+		}
 		return m, nil
 
 	case terminalOnlyMsg:
@@ -156,14 +171,15 @@ func (m model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) handleListViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.warning = ""
 	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("q", "ctrl+c"))):
+	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
-	case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+	case key.Matches(msg, m.keys.Up):
 		if m.cursor > 0 {
 			m.cursor--
 		}
-	case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+	case key.Matches(msg, m.keys.Down):
 		if m.cursor < len(m.worktrees)-1 {
 			m.cursor++
 		}
@@ -177,6 +193,10 @@ func (m model) handleListViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case key.Matches(msg, key.NewBinding(key.WithKeys("m"))):
 		if len(m.worktrees) > 0 && m.cursor < len(m.worktrees) {
+			if m.worktrees[m.cursor].IsMain {
+				m.warning = "The main worktree can't be moved"
+				return m, nil
+			}
 			m.currentView = formView
 			m.formType = "move"
 			m.formInputs = m.createMoveForm()
@@ -187,28 +207,46 @@ func (m model) handleListViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
 		if len(m.worktrees) > 0 && m.cursor < len(m.worktrees) {
+			if m.worktrees[m.cursor].IsMain {
+				m.warning = "The main worktree can't be deleted"
+				return m, nil
+			}
 			m.currentView = confirmView
 			m.confirmAction = "delete"
 			m.confirmTarget = m.worktrees[m.cursor].Path
 		}
+	case key.Matches(msg, key.NewBinding(key.WithKeys("p"))):
+		var prunable []string
+		for _, wt := range m.worktrees {
+			if wt.Prunable && !wt.IsMain {
+				prunable = append(prunable, wt.Path)
+			}
+		}
+		if len(prunable) == 0 {
+			m.warning = "No worktrees to prune"
+			return m, nil
+		}
+		m.currentView = confirmView
+		m.confirmAction = "prune"
+		m.confirmTarget = strings.Join(prunable, "\n")
 	case key.Matches(msg, key.NewBinding(key.WithKeys("o"))):
 		if len(m.worktrees) > 0 && m.cursor < len(m.worktrees) {
 			return m, openWorktree(m.worktrees[m.cursor].Path)
 		}
-	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+	case key.Matches(msg, m.keys.Refresh):
 		return m, loadWorktrees(m.manager)
 	}
 	return m, nil
 }
 
 func (m model) handleFormViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "esc":
+	switch {
+	case msg.String() == "ctrl+c" || key.Matches(msg, m.keys.Back):
 		m.currentView = listView
 		m.formInputs = nil
 		return m, nil
-	case "tab", "shift+tab":
-		if msg.String() == "tab" {
+	case key.Matches(msg, m.keys.NextView, m.keys.PrevView):
+		if key.Matches(msg, m.keys.NextView) {
 			m.focusedInput++
 		} else {
 			m.focusedInput--
@@ -227,7 +265,7 @@ func (m model) handleFormViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
-	case "enter":
+	case key.Matches(msg, m.keys.Select):
 		if m.formType == "move" {
 			return m, m.submitMoveForm()
 		}
@@ -237,12 +275,15 @@ func (m model) handleFormViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) handleConfirmViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
-		if m.confirmAction == "delete" {
+	switch {
+	case msg.String() == "y" || msg.String() == "Y":
+		switch m.confirmAction {
+		case "delete":
 			return m, deleteWorktree(m.manager, m.confirmTarget)
+		case "prune":
+			return m, pruneWorktrees(m.manager, strings.Count(m.confirmTarget, "\n")+1)
 		}
-	case "n", "N", "esc", "ctrl+c":
+	case msg.String() == "n" || msg.String() == "N" || msg.String() == "ctrl+c" || key.Matches(msg, m.keys.Back):
 		m.currentView = listView
 		m.confirmAction = ""
 		m.confirmTarget = ""
@@ -335,6 +376,9 @@ func (m model) renderListView() string {
 	if m.message != "" {
 		s.WriteString(successStyle.Render(m.message) + "\n\n")
 	}
+	if m.warning != "" {
+		s.WriteString(warningStyle.Render(m.warning) + "\n\n")
+	}
 
 	// Worktree list
 	if len(m.worktrees) == 0 {
@@ -354,18 +398,23 @@ func (m model) renderListView() string {
 			line := fmt.Sprintf("%s %s [%s]", cursor, wt.Path, branch)
 
 			if m.cursor == i {
-				s.WriteString(selectedStyle.Render(line) + "\n")
+				line = selectedStyle.Render(line)
 			} else {
-				s.WriteString(normalStyle.Render(line) + "\n")
+				line = normalStyle.Render(line)
 			}
+			if status := wt.Status(); status != "" {
+				line += " " + statusStyle.Render(status)
+			}
+			s.WriteString(line + "\n")
 		}
 	}
 
 	// Help
 	s.WriteString("\n")
-	s.WriteString(helpStyle.Render("(n) new worktree  (m) move  (d) delete  (o) open  (r) refresh  (q) quit"))
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(n) new worktree  (m) move  (d) delete  (p) prune  (o) open  (%s) refresh  (%s) quit",
+		terminal.HelpKey(m.keys.Refresh), terminal.HelpKey(m.keys.Quit))))
 	s.WriteString("\n")
-	s.WriteString(helpStyle.Render("(↑/k) up  (↓/j) down"))
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(%s) up  (%s) down", terminal.HelpKey(m.keys.Up), terminal.HelpKey(m.keys.Down))))
 
 	return s.String()
 }
@@ -403,7 +452,8 @@ func (m model) renderFormView() string {
 	s.WriteString("\n")
 	s.WriteString(helpStyle.Render(helpText))
 	s.WriteString("\n")
-	s.WriteString(helpStyle.Render(fmt.Sprintf("(Tab) next field  (Enter) %s  (Esc) cancel", actionText)))
+	s.WriteString(helpStyle.Render(fmt.Sprintf("(%s) next field  (%s) %s  (%s) cancel",
+		terminal.HelpKey(m.keys.NextView), terminal.HelpKey(m.keys.Select), actionText, terminal.HelpKey(m.keys.Back))))
 
 	return formStyle.Render(s.String())
 }
@@ -414,7 +464,11 @@ func (m model) renderConfirmView() string {
 	title := titleStyle.Render("Confirm")
 	s.WriteString(title + "\n\n")
 
-	s.WriteString(fmt.Sprintf("Are you sure you want to delete worktree:\n%s\n\n", m.confirmTarget))
+	if m.confirmAction == "prune" {
+		s.WriteString(fmt.Sprintf("Prune the information of these missing worktrees?\n%s\n\n", m.confirmTarget))
+	} else {
+		s.WriteString(fmt.Sprintf("Are you sure you want to delete worktree:\n%s\n\n", m.confirmTarget))
+	}
 	s.WriteString(helpStyle.Render("(y) yes  (n) no"))
 
 	return formStyle.Render(s.String())
@@ -509,6 +563,15 @@ func deleteWorktree(manager *WorktreeManager, path string) tea.Cmd {
 	}
 }
 
+func pruneWorktrees(manager *WorktreeManager, count int) tea.Cmd {
+	return func() tea.Msg {
+		if err := manager.PruneWorktrees(false); err != nil {
+			return errMsg{err: err}
+		}
+		return successMsg{message: fmt.Sprintf("Pruned %d worktree(s)", count)}
+	}
+}
+
 func moveWorktree(manager *WorktreeManager, worktreePath, destDir, newName string) tea.Cmd {
 	return func() tea.Msg {
 		if err := manager.MoveWorktree(worktreePath, destDir, newName); err != nil {
@@ -547,6 +610,10 @@ type terminalOnlyMsg struct {
 
 // RunWorktreeTUI starts the worktree TUI
 func RunWorktreeTUI(repoPath string) error {
+	if err := terminal.RequireTTY("syst git worktree list --json"); err != nil {
+		return err
+	}
+
 	manager, err := NewWorktreeManager(repoPath)
 	if err != nil {
 		return err
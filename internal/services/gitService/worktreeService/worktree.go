@@ -1,6 +1,7 @@
 package worktreeservice
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,15 +10,48 @@ import (
 	"strings"
 
 	"github.com/go-git/go-git/v5"
+	gitservice "github.com/redjax/syst/internal/services/gitService"
 	pathutil "github.com/redjax/syst/internal/utils/path"
 )
 
+// ErrMainWorktree is returned when asked to remove or move the main worktree, the one
+// holding the repository itself
+var ErrMainWorktree = errors.New("the main worktree can't be removed or moved")
+
 // Worktree represents a Git worktree
 type Worktree struct {
-	Path   string
-	Branch string
-	Commit string
-	IsBare bool
+	Path   string `json:"path"`
+	Branch string `json:"branch,omitempty"` // Empty when HEAD is detached
+	Commit string `json:"head"`
+	IsBare bool   `json:"bare"`
+	// IsMain is set on the main worktree, which git always lists first
+	IsMain     bool   `json:"main"`
+	Locked     bool   `json:"locked"`
+	LockReason string `json:"lock_reason,omitempty"`
+	// Prunable is set when the worktree's directory is gone, so git worktree prune would
+	// remove its information
+	Prunable       bool   `json:"prunable"`
+	PrunableReason string `json:"prunable_reason,omitempty"`
+}
+
+// Status summarizes whether the worktree is the main one, locked or prunable, i.e.
+// "locked (on usb), prunable"
+func (w Worktree) Status() string {
+	var status []string
+	if w.IsMain {
+		status = append(status, "main")
+	}
+	if w.Locked {
+		if w.LockReason != "" {
+			status = append(status, fmt.Sprintf("locked (%s)", w.LockReason))
+		} else {
+			status = append(status, "locked")
+		}
+	}
+	if w.Prunable {
+		status = append(status, "prunable")
+	}
+	return strings.Join(status, ", ")
 }
 
 // WorktreeManager handles Git worktree operations
@@ -36,9 +70,14 @@ func NewWorktreeManager(repoPath string) (*WorktreeManager, error) {
 		}
 	}
 
-	repo, err := git.PlainOpen(repoPath)
+	repo, err := gitservice.OpenRepo(repoPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open repository: %w", err)
+		return nil, err
+	}
+
+	// Run git commands from the repository root, even if repoPath is a subdirectory
+	if root, err := gitservice.RepoRoot(repo); err == nil {
+		repoPath = root
 	}
 
 	return &WorktreeManager{
@@ -77,14 +116,30 @@ func parseWorktreeList(output string) []Worktree {
 
 		if strings.HasPrefix(line, "worktree ") {
 			current = &Worktree{
-				Path: strings.TrimPrefix(line, "worktree "),
+				Path:   strings.TrimPrefix(line, "worktree "),
+				IsMain: len(worktrees) == 0,
 			}
-		} else if strings.HasPrefix(line, "HEAD ") && current != nil {
-			current.Commit = strings.TrimPrefix(line, "HEAD ")
-		} else if strings.HasPrefix(line, "branch ") && current != nil {
-			current.Branch = strings.TrimPrefix(line, "branch ")
-		} else if line == "bare" && current != nil {
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		// "locked" and "prunable" are followed by a reason when there is one
+		attribute, value, _ := strings.Cut(line, " ")
+		switch attribute {
+		case "HEAD":
+			current.Commit = value
+		case "branch":
+			current.Branch = value
+		case "bare":
 			current.IsBare = true
+		case "locked":
+			current.Locked = true
+			current.LockReason = value
+		case "prunable":
+			current.Prunable = true
+			current.PrunableReason = value
 		}
 	}
 
@@ -179,6 +234,10 @@ func (wm *WorktreeManager) RemoveWorktree(path string, force bool) error {
 		return fmt.Errorf("failed to expand path: %w", err)
 	}
 
+	if err := wm.checkNotMain(expandedPath); err != nil {
+		return err
+	}
+
 	args := []string{"worktree", "remove"}
 
 	if force {
@@ -238,6 +297,10 @@ func (wm *WorktreeManager) MoveWorktree(worktreePath, destDir, newName string) e
 		return fmt.Errorf("worktree does not exist: %s", expandedWorktreePath)
 	}
 
+	if err := wm.checkNotMain(expandedWorktreePath); err != nil {
+		return err
+	}
+
 	// Create destination directory if it doesn't exist
 	if _, err := os.Stat(expandedDestDir); os.IsNotExist(err) {
 		// #nosec G301 -- CLI tool creating user-specified directories with standard permissions
@@ -275,6 +338,37 @@ func (wm *WorktreeManager) MoveWorktree(worktreePath, destDir, newName string) e
 	return nil
 }
 
+// checkNotMain returns ErrMainWorktree if path is the main worktree. Relative paths are
+// resolved from the repository root, like the git commands run there do.
+func (wm *WorktreeManager) checkNotMain(path string) error {
+	worktrees, err := wm.ListWorktrees()
+	if err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(wm.repoPath, path)
+	}
+	for _, wt := range worktrees {
+		if wt.IsMain && samePath(wt.Path, path) {
+			return fmt.Errorf("%s: %w", wt.Path, ErrMainWorktree)
+		}
+	}
+	return nil
+}
+
+// samePath reports whether a and b are the same directory, following symlinks when the
+// directories exist
+func samePath(a, b string) bool {
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
 // BranchExists checks if a branch exists in the repository
 func (wm *WorktreeManager) BranchExists(branchName string) (bool, error) {
 	// Use git command to check if branch exists
@@ -0,0 +1,68 @@
+package worktreeservice
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseWorktreeList(t *testing.T) {
+	output := `worktree /src/repo
+HEAD 2436da8b1e8ee6ffe8b5dd5f2fecaa23d85a0cd4
+branch refs/heads/main
+
+worktree /src/feature
+HEAD 2436da8b1e8ee6ffe8b5dd5f2fecaa23d85a0cd4
+branch refs/heads/feature
+locked on usb
+
+worktree /src/gone
+HEAD 2436da8b1e8ee6ffe8b5dd5f2fecaa23d85a0cd4
+detached
+locked
+prunable gitdir file points to non-existent location
+`
+
+	want := []Worktree{
+		{Path: "/src/repo", Branch: "refs/heads/main", Commit: "2436da8b1e8ee6ffe8b5dd5f2fecaa23d85a0cd4", IsMain: true},
+		{Path: "/src/feature", Branch: "refs/heads/feature", Commit: "2436da8b1e8ee6ffe8b5dd5f2fecaa23d85a0cd4",
+			Locked: true, LockReason: "on usb"},
+		{Path: "/src/gone", Commit: "2436da8b1e8ee6ffe8b5dd5f2fecaa23d85a0cd4", Locked: true,
+			Prunable: true, PrunableReason: "gitdir file points to non-existent location"},
+	}
+	got := parseWorktreeList(output)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseWorktreeList() = %+v, want %+v", got, want)
+	}
+
+	statuses := []string{"main", "locked (on usb)", "locked, prunable"}
+	for i, wt := range got {
+		if wt.Status() != statuses[i] {
+			t.Errorf("%s: Status() = %q, want %q", wt.Path, wt.Status(), statuses[i])
+		}
+	}
+}
+
+func TestRemoveMainWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	manager, err := NewWorktreeManager(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Relative paths are resolved from the repository root
+	for _, path := range []string{dir, ".", filepath.Join(dir, "sub", "..")} {
+		if err := manager.RemoveWorktree(path, true); !errors.Is(err, ErrMainWorktree) {
+			t.Errorf("RemoveWorktree(%q) = %v, want ErrMainWorktree", path, err)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/redjax/syst/internal/services/pathScanService/tbl"
 	"github.com/redjax/syst/internal/utils/terminal"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 // FileItem represents a file or directory with metadata
@@ -92,39 +93,39 @@ func (i fileItem) Description() string {
 // Styles
 var (
 	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#25A065")).
+			Foreground(theme.Current.TextOnPrimary).
+			Background(theme.Current.Primary).
 			Padding(0, 1).
 			Bold(true)
 
 	sectionStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#25A065")).
+			BorderForeground(theme.Current.Border).
 			Padding(1, 2).
 			Margin(1, 0)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
+			Foreground(theme.Current.Muted).
 			MarginTop(1)
 
 	pathStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFA500")).
+			Foreground(theme.Current.Warning).
 			Bold(true)
 
 	dialogStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#25A065")).
-			Background(lipgloss.Color("#1A1A1A")).
+			BorderForeground(theme.Current.Border).
+			Background(theme.Current.Subtle).
 			Padding(1, 2).
 			Width(50)
 
 	selectedStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("#25A065")).
-			Foreground(lipgloss.Color("#FFFDF5")).
+			Background(theme.Current.Primary).
+			Foreground(theme.Current.TextOnPrimary).
 			Padding(0, 1)
 
 	unselectedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#DDDDDD")).
+			Foreground(theme.Current.Text).
 			Padding(0, 1)
 )
 
@@ -499,10 +500,10 @@ func (m model) renderSortDialog() string {
 func ScanDirectoryTUI(path string, limit int, sortBy, order, filter string, recursive bool) error {
 	delegate := list.NewDefaultDelegate()
 	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("#25A065")).
-		BorderLeftForeground(lipgloss.Color("#25A065"))
+		Foreground(theme.Current.Accent).
+		BorderLeftForeground(theme.Current.Accent)
 	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("#DDDDDD"))
+		Foreground(theme.Current.Text)
 
 	fileList := list.New([]list.Item{}, delegate, 0, 0)
 	fileList.Title = "Files"
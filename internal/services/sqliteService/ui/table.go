@@ -6,6 +6,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	t "github.com/evertras/bubble-table/table"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 func (m UIModel) buildTable() t.Model {
@@ -63,7 +64,7 @@ func (m UIModel) buildTable() t.Model {
 	// Row highlight style for the focused row
 	highlightStyle := lipgloss.NewStyle().
 		Bold(true).
-		Background(lipgloss.Color("236")) // subtle dark gray background
+		Background(theme.Current.Subtle)
 
 	// rows
 	var tRows []t.Row
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ExitCodeError is returned from a command that has already printed its result, and
+// only has to end with a non-zero exit status, like a check failing a CI job. It is
+// not printed as an error; main exits with Code.
+type ExitCodeError struct {
+	Code int
+}
+
+func (e ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ErrExitFailure ends a command with exit status 1.
+var ErrExitFailure error = ExitCodeError{Code: 1}
+
+// ExitCode returns the exit status a command that returned err should end with: 0 for
+// no error, the code of an ExitCodeError, or 1 for any other error.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr ExitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
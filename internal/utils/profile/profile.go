@@ -0,0 +1,157 @@
+// Package profile times the phases of slow commands for the hidden --profile flag, so a
+// report that syst is slow on some repository can say where the time went.
+//
+// Profiling is process-wide, like color in the styles package: Start enables it, services
+// wrap their phases with Phase, and Stop prints the breakdown. While profiling is off,
+// Phase does nothing.
+package profile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Phases timed by the git analyses
+const (
+	OpenRepo = "open repository"
+	// LogWalk is the walk over the commit history, including the commit stats it computes
+	LogWalk = "log walk"
+	// Stats is diffing commits against their parents, on stats cache misses
+	Stats      = "commit stats"
+	StatsCache = "stats cache load/save"
+	// Render is turning the walked data into what the views and reports show, i.e.
+	// sorting and aggregating
+	Render = "rendering prep"
+)
+
+// phase is the time spent in one named phase, over every call
+type phase struct {
+	name  string
+	total time.Duration
+	calls int
+}
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	started time.Time
+	phases  []*phase // In the order they first ran
+	cpuFile *os.File
+)
+
+// Start enables profiling. If cpuProfile is not empty, a pprof CPU profile is also
+// written to that file until Stop.
+func Start(cpuProfile string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if cpuProfile != "" {
+		// #nosec G304 - CLI tool writes to a user-specified file by design
+		file, err := os.Create(cpuProfile)
+		if err != nil {
+			return fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		cpuFile = file
+	}
+
+	enabled = true
+	started = time.Now()
+	phases = nil
+	return nil
+}
+
+// Phase starts timing the named phase and returns the function that ends it, so a phase
+// can be timed with:
+//
+//	defer profile.Phase(profile.LogWalk)()
+//
+// Phases may run concurrently and inside each other; each call's duration is added on
+// its own.
+func Phase(name string) func() {
+	mu.Lock()
+	on := enabled
+	mu.Unlock()
+	if !on {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, p := range phases {
+			if p.name == name {
+				p.total += elapsed
+				p.calls++
+				return
+			}
+		}
+		phases = append(phases, &phase{name: name, total: elapsed, calls: 1})
+	}
+}
+
+// Stop ends profiling and writes the time spent in each phase to w. It does nothing if
+// profiling was never started.
+func Stop(w io.Writer) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !enabled {
+		return nil
+	}
+	enabled = false
+
+	fmt.Fprintf(w, "\nProfile (total %s):\n", formatDuration(time.Since(started)))
+	if len(phases) == 0 {
+		fmt.Fprintln(w, "  No phases were timed")
+	}
+	var walked, diffed bool
+	for _, p := range phases {
+		walked = walked || p.name == LogWalk
+		diffed = diffed || p.name == Stats
+		calls := "1 call"
+		if p.calls != 1 {
+			calls = strconv.Itoa(p.calls) + " calls"
+		}
+		fmt.Fprintf(w, "  %-22s %10s  %s\n", p.name, formatDuration(p.total), calls)
+	}
+	if walked && diffed {
+		fmt.Fprintf(w, "  The %s time is part of the %s time\n", Stats, LogWalk)
+	}
+
+	if cpuFile == nil {
+		return nil
+	}
+	pprof.StopCPUProfile()
+	name := cpuFile.Name()
+	err := cpuFile.Close()
+	cpuFile = nil
+	if err != nil {
+		return fmt.Errorf("failed to write CPU profile: %w", err)
+	}
+	fmt.Fprintf(w, "CPU profile written to %s (inspect it with 'go tool pprof %s')\n", name, name)
+	return nil
+}
+
+// formatDuration rounds d to a readable precision, i.e. "1.25s" or "340ms"
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Millisecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
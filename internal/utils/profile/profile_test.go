@@ -0,0 +1,70 @@
+package profile
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPhase(t *testing.T) {
+	// Phases aren't recorded while profiling is off
+	Phase(LogWalk)()
+
+	if err := Start(""); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	Phase(OpenRepo)()
+	walk := Phase(LogWalk)
+	for range 3 {
+		Phase(Stats)()
+	}
+	walk()
+
+	var out strings.Builder
+	if err := Stop(&out); err != nil {
+		t.Fatalf("Stop() error: %v", err)
+	}
+	for _, want := range []string{"open repository", "log walk", "1 call", "commit stats", "3 calls", "part of the log walk time"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Stop() output is missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Index(out.String(), OpenRepo) > strings.Index(out.String(), LogWalk) {
+		t.Errorf("phases aren't in the order they ran:\n%s", out.String())
+	}
+
+	// Stopping again is a no-op
+	out.Reset()
+	if err := Stop(&out); err != nil || out.Len() != 0 {
+		t.Errorf("second Stop() = %v, wrote %q", err, out.String())
+	}
+}
+
+func TestCPUProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cpu.out")
+	if err := Start(path); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+
+	var out strings.Builder
+	if err := Stop(&out); err != nil {
+		t.Fatalf("Stop() error: %v", err)
+	}
+	if !strings.Contains(out.String(), "CPU profile written to "+path) {
+		t.Errorf("Stop() output doesn't name the CPU profile:\n%s", out.String())
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		1234567 * time.Microsecond: "1.23s",
+		340400 * time.Microsecond:  "340ms",
+		1500 * time.Nanosecond:     "2µs",
+	}
+	for d, want := range tests {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
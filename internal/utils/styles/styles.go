@@ -0,0 +1,36 @@
+// Package styles holds output settings shared by every syst TUI and styled printer.
+//
+// Services define their own lipgloss styles; this package only controls whether those
+// styles emit color, so disabling color here applies everywhere at once.
+package styles
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var colorDisabled bool
+
+// Init configures color output for the process. Color is disabled when noColor is
+// true, when NO_COLOR is set to a non-empty value (https://no-color.org), or when
+// TERM is "dumb". Call it once, before any TUI starts.
+func Init(noColor bool) {
+	if noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		DisableColor()
+	}
+}
+
+// DisableColor makes every lipgloss style render as plain text, dropping colors and
+// text attributes like bold.
+func DisableColor() {
+	colorDisabled = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// ColorEnabled reports whether styled output may use color. Code that writes raw ANSI
+// escape sequences instead of using lipgloss should check it.
+func ColorEnabled() bool {
+	return !colorDisabled
+}
@@ -0,0 +1,47 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestInit(t *testing.T) {
+	original := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		colorDisabled = false
+		lipgloss.SetColorProfile(original)
+	})
+
+	tests := []struct {
+		name    string
+		noColor bool
+		env     string
+		term    string
+		want    bool
+	}{
+		{"default", false, "", "xterm-256color", true},
+		{"flag", true, "", "xterm-256color", false},
+		{"NO_COLOR", false, "1", "xterm-256color", false},
+		{"dumb terminal", false, "", "dumb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			colorDisabled = false
+			lipgloss.SetColorProfile(termenv.TrueColor)
+			t.Setenv("NO_COLOR", tt.env)
+			t.Setenv("TERM", tt.term)
+
+			Init(tt.noColor)
+
+			if got := ColorEnabled(); got != tt.want {
+				t.Errorf("ColorEnabled() = %v, want %v", got, tt.want)
+			}
+			styled := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true).Render("x")
+			if !tt.want && styled != "x" {
+				t.Errorf("Render() with color disabled = %q, want %q", styled, "x")
+			}
+		})
+	}
+}
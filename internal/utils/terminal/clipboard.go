@@ -0,0 +1,113 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/redjax/syst/internal/utils/theme"
+	"golang.org/x/term"
+)
+
+// ClipboardNoticeDuration is how long a ClipboardNotice stays visible
+const ClipboardNoticeDuration = 2 * time.Second
+
+// CopyToClipboard copies text to the system clipboard. Over SSH, or when no clipboard
+// tool is available, it falls back to the OSC 52 escape sequence, which asks the
+// terminal emulator to set the clipboard on the machine it runs on.
+func CopyToClipboard(text string) error {
+	if !isSSHSession() && !clipboard.Unsupported {
+		if err := clipboard.WriteAll(text); err == nil {
+			return nil
+		}
+	}
+
+	// The terminal can't report whether it supports OSC 52, so this assumes it does. The
+	// sequence goes to stderr when stdout is redirected, so it stays out of the output.
+	out := os.Stdout
+	if !term.IsTerminal(int(out.Fd())) {
+		out = os.Stderr
+	}
+	termenv.NewOutput(out).Copy(text)
+	return nil
+}
+
+func isSSHSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// copyFunc is swapped out in tests
+var copyFunc = CopyToClipboard
+
+// ClipboardMsg reports the result of CopyCmd
+type ClipboardMsg struct {
+	Text string
+	Err  error
+}
+
+// ClipboardClearMsg hides a ClipboardNotice once it has been shown long enough
+type ClipboardClearMsg struct {
+	id int
+}
+
+// CopyCmd copies text to the clipboard and reports the result as a ClipboardMsg.
+func CopyCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		return ClipboardMsg{Text: text, Err: copyFunc(text)}
+	}
+}
+
+var (
+	clipboardStyle      = lipgloss.NewStyle().Foreground(theme.Current.Success)
+	clipboardErrorStyle = lipgloss.NewStyle().Foreground(theme.Current.Error)
+)
+
+// ClipboardNotice shows a short-lived "copied" confirmation in a TUI. Pass ClipboardMsg
+// and ClipboardClearMsg to Update and render View wherever the notice should appear.
+type ClipboardNotice struct {
+	message string
+	failed  bool
+	id      int
+}
+
+// Update shows the result of a copy and schedules hiding it. It returns nil for
+// messages it does not handle.
+func (n *ClipboardNotice) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case ClipboardMsg:
+		n.id++
+		n.failed = msg.Err != nil
+		if n.failed {
+			n.message = fmt.Sprintf("Copy failed: %v", msg.Err)
+		} else {
+			n.message = fmt.Sprintf("📋 Copied %s", msg.Text)
+		}
+
+		id := n.id
+		return tea.Tick(ClipboardNoticeDuration, func(time.Time) tea.Msg {
+			return ClipboardClearMsg{id: id}
+		})
+
+	case ClipboardClearMsg:
+		// A later copy restarts the timer
+		if msg.id == n.id {
+			n.message = ""
+		}
+	}
+	return nil
+}
+
+// View returns the notice, or "" when there is nothing to show.
+func (n ClipboardNotice) View() string {
+	if n.message == "" {
+		return ""
+	}
+	if n.failed {
+		return clipboardErrorStyle.Render(n.message)
+	}
+	return clipboardStyle.Render(n.message)
+}
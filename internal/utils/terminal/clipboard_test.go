@@ -0,0 +1,45 @@
+package terminal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClipboardNotice(t *testing.T) {
+	var copied string
+	copyFunc = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { copyFunc = CopyToClipboard }()
+
+	var notice ClipboardNotice
+	msg := CopyCmd("abc123")()
+	if copied != "abc123" {
+		t.Fatalf("copied %q, want abc123", copied)
+	}
+	if cmd := notice.Update(msg); cmd == nil {
+		t.Error("Update(ClipboardMsg) should schedule hiding the notice")
+	}
+	if !strings.Contains(notice.View(), "Copied abc123") {
+		t.Errorf("View() = %q, want the copied text", notice.View())
+	}
+
+	// A second copy replaces the first, so the first timer must not hide it
+	first := ClipboardClearMsg{id: notice.id}
+	notice.Update(ClipboardMsg{Text: "def456"})
+	notice.Update(first)
+	if !strings.Contains(notice.View(), "def456") {
+		t.Errorf("stale clear hid the notice: View() = %q", notice.View())
+	}
+	notice.Update(ClipboardClearMsg{id: notice.id})
+	if notice.View() != "" {
+		t.Errorf("View() after clear = %q, want empty", notice.View())
+	}
+
+	notice.Update(ClipboardMsg{Text: "abc123", Err: errors.New("no clipboard")})
+	if !strings.Contains(notice.View(), "Copy failed: no clipboard") {
+		t.Errorf("View() = %q, want the error", notice.View())
+	}
+}
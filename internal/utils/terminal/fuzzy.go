@@ -0,0 +1,141 @@
+package terminal
+
+import (
+	"sort"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Fuzzy match scores. Substring matches always outrank subsequence matches, and a
+// substring of the file name outranks one elsewhere in the path.
+const (
+	fuzzyNameSubstring = 3000
+	fuzzySubstring     = 2000
+	fuzzySubsequence   = 1000
+)
+
+// FuzzyMatch reports whether the characters of query appear in target in order, ignoring
+// case, so "cmmain" matches "cmd/entrypoint/main.go". The score ranks matches: exact
+// substrings first, then subsequences whose characters are consecutive or start words
+// and path segments. matched holds the rune indexes of target that matched.
+func FuzzyMatch(query, target string) (score int, matched []int, ok bool) {
+	q := lowerRunes(query)
+	t := lowerRunes(target)
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+
+	// Shorter targets rank higher within each tier
+	lengthPenalty := min(len(t), fuzzySubsequence/2)
+
+	if i := runeIndex(t, q); i >= 0 {
+		base := fuzzySubstring
+		if i >= lastSegment(t) {
+			base = fuzzyNameSubstring
+		}
+		return base - lengthPenalty, runeRange(i, len(q)), true
+	}
+
+	matched = make([]int, 0, len(q))
+	bonus := 0
+	ti := 0
+	for _, r := range q {
+		for ti < len(t) && t[ti] != r {
+			ti++
+		}
+		if ti == len(t) {
+			return 0, nil, false
+		}
+		switch {
+		case len(matched) > 0 && matched[len(matched)-1] == ti-1:
+			bonus += 5
+		case ti == 0 || isWordBoundary(t[ti-1]):
+			bonus += 8
+		}
+		matched = append(matched, ti)
+		ti++
+	}
+
+	gaps := matched[len(matched)-1] - matched[0] + 1 - len(matched)
+	score = fuzzySubsequence/2 + bonus - gaps - lengthPenalty/2
+	return max(min(score, fuzzySubsequence-1), 1), matched, true
+}
+
+// FuzzyFilter is a list.FilterFunc that ranks items with FuzzyMatch, best first.
+func FuzzyFilter(term string, targets []string) []list.Rank {
+	type scored struct {
+		rank  list.Rank
+		score int
+	}
+	var matches []scored
+	for i, target := range targets {
+		if score, matched, ok := FuzzyMatch(term, target); ok {
+			matches = append(matches, scored{list.Rank{Index: i, MatchedIndexes: matched}, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	ranks := make([]list.Rank, len(matches))
+	for i, m := range matches {
+		ranks[i] = m.rank
+	}
+	return ranks
+}
+
+// FuzzySort returns the items whose key fuzzy-matches query, best match first.
+func FuzzySort[T any](query string, items []T, key func(T) string) []T {
+	targets := make([]string, len(items))
+	for i, item := range items {
+		targets[i] = key(item)
+	}
+
+	ranks := FuzzyFilter(query, targets)
+	sorted := make([]T, len(ranks))
+	for i, rank := range ranks {
+		sorted[i] = items[rank.Index]
+	}
+	return sorted
+}
+
+// lowerRunes lowercases s rune by rune, so indexes into it are rune indexes into s
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+func isWordBoundary(r rune) bool {
+	return r == '/' || r == '\\' || r == '.' || r == '_' || r == '-' || unicode.IsSpace(r)
+}
+
+// lastSegment returns the index where the last path segment of t starts
+func lastSegment(t []rune) int {
+	for i := len(t) - 1; i >= 0; i-- {
+		if t[i] == '/' {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func runeIndex(t, q []rune) int {
+	for i := 0; i+len(q) <= len(t); i++ {
+		if string(t[i:i+len(q)]) == string(q) {
+			return i
+		}
+	}
+	return -1
+}
+
+func runeRange(start, n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = start + i
+	}
+	return indexes
+}
@@ -0,0 +1,51 @@
+package terminal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	score, matched, ok := FuzzyMatch("cmmain", "cmd/entrypoint/main.go")
+	if !ok {
+		t.Fatal("cmmain should match cmd/entrypoint/main.go")
+	}
+	if want := []int{0, 1, 15, 16, 17, 18}; !reflect.DeepEqual(matched, want) {
+		t.Errorf("matched = %v, want %v", matched, want)
+	}
+	if score <= 0 || score >= fuzzySubsequence {
+		t.Errorf("subsequence score = %d, want within (0, %d)", score, fuzzySubsequence)
+	}
+
+	if _, _, ok := FuzzyMatch("mainc", "cmd/entrypoint/main.go"); ok {
+		t.Error("out of order characters should not match")
+	}
+	if _, _, ok := FuzzyMatch("README", "docs/readme.md"); !ok {
+		t.Error("matching should ignore case")
+	}
+}
+
+func TestFuzzyFilterRanking(t *testing.T) {
+	targets := []string{
+		"pkg/mapping.go",         // subsequence only
+		"docs/main.md",           // substring in the file name
+		"cmd/main/run.go",        // substring in a directory
+		"cmd/entrypoint/main.go", // substring in the file name, longer path
+		"README.md",              // no match
+	}
+
+	var got []string
+	for _, rank := range FuzzyFilter("main", targets) {
+		got = append(got, targets[rank.Index])
+	}
+	want := []string{"docs/main.md", "cmd/entrypoint/main.go", "cmd/main/run.go", "pkg/mapping.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ranking = %v, want %v", got, want)
+	}
+
+	// Exact substrings beat tighter-looking subsequences
+	got = FuzzySort("ser", []string{"s/e/r.go", "user.go"}, func(s string) string { return s })
+	if got[0] != "user.go" {
+		t.Errorf("FuzzySort() = %v, want the substring match first", got)
+	}
+}
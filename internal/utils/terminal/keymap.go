@@ -0,0 +1,194 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// KeyMap holds the keys shared by the TUIs. Keys specific to one TUI (like the number
+// keys that switch views) are not part of it.
+type KeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	NextView key.Binding
+	PrevView key.Binding
+	Select   key.Binding
+	Back     key.Binding
+	Quit     key.Binding
+	Filter   key.Binding
+	Refresh  key.Binding
+	Copy     key.Binding
+}
+
+// DefaultKeyMap returns the built-in keys.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:       key.NewBinding(key.WithKeys("up", "k")),
+		Down:     key.NewBinding(key.WithKeys("down", "j")),
+		Left:     key.NewBinding(key.WithKeys("left", "h")),
+		Right:    key.NewBinding(key.WithKeys("right", "l")),
+		NextView: key.NewBinding(key.WithKeys("tab")),
+		PrevView: key.NewBinding(key.WithKeys("shift+tab")),
+		Select:   key.NewBinding(key.WithKeys("enter")),
+		Back:     key.NewBinding(key.WithKeys("esc")),
+		Quit:     key.NewBinding(key.WithKeys("q", "ctrl+c")),
+		Filter:   key.NewBinding(key.WithKeys("/")),
+		Refresh:  key.NewBinding(key.WithKeys("r")),
+		Copy:     key.NewBinding(key.WithKeys("y")),
+	}
+}
+
+// bindings maps the names used in the keymap file to the KeyMap fields.
+func (km *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":        &km.Up,
+		"down":      &km.Down,
+		"left":      &km.Left,
+		"right":     &km.Right,
+		"next_view": &km.NextView,
+		"prev_view": &km.PrevView,
+		"select":    &km.Select,
+		"back":      &km.Back,
+		"quit":      &km.Quit,
+		"filter":    &km.Filter,
+		"refresh":   &km.Refresh,
+		"copy":      &km.Copy,
+	}
+}
+
+// KeyMapPath returns the keymap file: $SYST_KEYMAP if set, else syst/keymap.json under
+// the OS config directory.
+func KeyMapPath() (string, error) {
+	if path := os.Getenv("SYST_KEYMAP"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "syst", "keymap.json"), nil
+}
+
+// LoadKeyMap reads the keymap file at path on top of the defaults. The file is a JSON
+// object of binding names to key lists, like {"quit": ["q"], "down": ["j", "down"]};
+// bindings it leaves out keep their default keys. A missing file is not an error.
+func LoadKeyMap(path string) (KeyMap, error) {
+	km := DefaultKeyMap()
+
+	// #nosec G304 - The keymap path comes from the user's config directory or environment
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return km, nil
+	}
+	if err != nil {
+		return km, err
+	}
+
+	var overrides map[string][]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return km, fmt.Errorf("failed to parse keymap %s: %w", path, err)
+	}
+
+	bindings := km.bindings()
+	for name, keys := range overrides {
+		binding, ok := bindings[name]
+		if !ok {
+			return DefaultKeyMap(), fmt.Errorf("unknown binding %q in keymap %s (valid: %s)", name, path, strings.Join(bindingNames(bindings), ", "))
+		}
+		if len(keys) == 0 {
+			return DefaultKeyMap(), fmt.Errorf("binding %q in keymap %s has no keys", name, path)
+		}
+		binding.SetKeys(keys...)
+	}
+
+	return km, nil
+}
+
+func bindingNames(bindings map[string]*key.Binding) []string {
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	keysOnce sync.Once
+	keys     KeyMap
+)
+
+// Keys returns the active keymap, loading the keymap file the first time it is called.
+// A broken keymap file is reported on stderr and the defaults are used instead.
+func Keys() KeyMap {
+	keysOnce.Do(func() {
+		keys = DefaultKeyMap()
+		path, err := KeyMapPath()
+		if err != nil {
+			return
+		}
+		if keys, err = LoadKeyMap(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: using default keys: %v\n", err)
+		}
+	})
+	return keys
+}
+
+// keyLabels are the symbols help footers show for named keys
+var keyLabels = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+// HelpKey formats the first key of each binding for a help footer, joined with "/",
+// e.g. "↑/↓" for Up and Down. Footers built from the keymap stay accurate after keys
+// are remapped.
+func HelpKey(bindings ...key.Binding) string {
+	labels := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		k := ""
+		if bound := b.Keys(); len(bound) > 0 {
+			k = bound[0]
+		}
+		if label, ok := keyLabels[k]; ok {
+			k = label
+		}
+		labels = append(labels, k)
+	}
+	return strings.Join(labels, "/")
+}
+
+// Help formats a help footer entry like "q: quit" for b.
+func Help(b key.Binding, desc string) string {
+	return HelpKey(b) + ": " + desc
+}
+
+// HelpLine joins help footer entries with " • ".
+func HelpLine(entries ...string) string {
+	return strings.Join(entries, " • ")
+}
+
+// ErrorHelp is the help footer of an error screen, where the refresh key retries the
+// load that failed.
+func (km KeyMap) ErrorHelp() string {
+	return HelpLine(Help(km.Refresh, "retry"), Help(km.Quit, "quit"))
+}
+
+// ApplyToList makes the cursor and filter keys of l follow the keymap.
+func (km KeyMap) ApplyToList(l *list.Model) {
+	l.KeyMap.CursorUp.SetKeys(km.Up.Keys()...)
+	l.KeyMap.CursorDown.SetKeys(km.Down.Keys()...)
+	l.KeyMap.Filter.SetKeys(km.Filter.Keys()...)
+}
@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeKeyMap(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadKeyMap(t *testing.T) {
+	km, err := LoadKeyMap(writeKeyMap(t, `{"quit": ["x", "ctrl+c"], "down": ["n", "down"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := km.Quit.Keys(); !reflect.DeepEqual(got, []string{"x", "ctrl+c"}) {
+		t.Errorf("Quit keys = %v", got)
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, km.Down) {
+		t.Error("remapped Down doesn't match n")
+	}
+	// Bindings left out of the file keep their defaults
+	if got, want := km.Back.Keys(), DefaultKeyMap().Back.Keys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Back keys = %v, want default %v", got, want)
+	}
+
+	if got := HelpLine(Help(km.Quit, "quit"), HelpKey(km.Up, km.Down)+": navigate"); got != "x: quit • ↑/n: navigate" {
+		t.Errorf("help = %q", got)
+	}
+
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	km.ApplyToList(&l)
+	if got := l.KeyMap.CursorDown.Keys(); !reflect.DeepEqual(got, []string{"n", "down"}) {
+		t.Errorf("list CursorDown keys = %v", got)
+	}
+}
+
+func TestLoadKeyMapErrors(t *testing.T) {
+	if _, err := LoadKeyMap(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Errorf("missing keymap file: %v, want defaults without error", err)
+	}
+
+	tests := map[string]string{
+		"invalid json":    `{"quit": `,
+		"unknown binding": `{"jump": ["g"]}`,
+		"no keys":         `{"quit": []}`,
+	}
+	for name, content := range tests {
+		km, err := LoadKeyMap(writeKeyMap(t, content))
+		if err == nil {
+			t.Errorf("%s: LoadKeyMap() succeeded, want an error", name)
+		}
+		if !reflect.DeepEqual(km.Quit.Keys(), DefaultKeyMap().Quit.Keys()) {
+			t.Errorf("%s: keymap isn't the default after an error", name)
+		}
+		if name == "unknown binding" && (err == nil || !strings.Contains(err.Error(), "next_view")) {
+			t.Errorf("%s: error %v doesn't list the valid bindings", name, err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package terminal
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ListItemAt returns the index (into l.VisibleItems()) of the item drawn at screen row y.
+// top is the screen row of the list's first line and delegate is the list's item delegate.
+// It reports false for rows on the list's title, status bar, item spacing or empty space.
+func ListItemAt(l list.Model, delegate list.ItemDelegate, top, y int) (int, bool) {
+	row := y - top
+
+	// Skip the title and status bars the list draws above its items
+	if l.ShowTitle() || (l.ShowFilter() && l.FilteringEnabled()) {
+		row -= lipgloss.Height(l.Styles.TitleBar.Render(l.Styles.Title.Render(l.Title)))
+	}
+	if l.ShowStatusBar() {
+		row -= lipgloss.Height(l.Styles.StatusBar.Render(" "))
+	}
+	if row < 0 {
+		return 0, false
+	}
+
+	stride := delegate.Height() + delegate.Spacing()
+	if stride <= 0 || row%stride >= delegate.Height() {
+		return 0, false
+	}
+
+	start, end := l.Paginator.GetSliceBounds(len(l.VisibleItems()))
+	index := start + row/stride
+	if index >= end {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// HandleListMouse scrolls l with the mouse wheel and selects the item under a left click.
+// top is the screen row of the list's first line. It returns true when an item was
+// clicked, so callers can handle the click like pressing enter.
+func HandleListMouse(l *list.Model, delegate list.ItemDelegate, top int, msg tea.MouseMsg) bool {
+	// Leave the list alone while its filter input has focus
+	if l.FilterState() == list.Filtering || msg.Action != tea.MouseActionPress {
+		return false
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		l.CursorUp()
+	case tea.MouseButtonWheelDown:
+		l.CursorDown()
+	case tea.MouseButtonLeft:
+		if index, ok := ListItemAt(*l, delegate, top, msg.Y); ok {
+			l.Select(index)
+			return true
+		}
+	}
+
+	return false
+}
+
+// StyleTopOffset returns how many rows a style's top margin, border and padding take up
+// before its content, i.e. the row offset of content rendered inside the style.
+func StyleTopOffset(style lipgloss.Style) int {
+	return style.GetMarginTop() + style.GetBorderTopSize() + style.GetPaddingTop()
+}
@@ -0,0 +1,105 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type testItem string
+
+func (i testItem) FilterValue() string { return string(i) }
+func (i testItem) Title() string       { return string(i) }
+func (i testItem) Description() string { return "description of " + string(i) }
+
+func newTestList(n int) (list.Model, list.ItemDelegate) {
+	items := make([]list.Item, n)
+	for i := range items {
+		items[i] = testItem(fmt.Sprintf("item-%d", i))
+	}
+
+	delegate := list.NewDefaultDelegate()
+	l := list.New(items, delegate, 60, 20)
+	l.Title = "Items"
+	return l, delegate
+}
+
+// rowOf returns the row of the first line of l's view that contains s.
+func rowOf(t *testing.T, l list.Model, s string) int {
+	t.Helper()
+	for row, line := range strings.Split(l.View(), "\n") {
+		if strings.Contains(line, s) {
+			return row
+		}
+	}
+	t.Fatalf("%q not found in list view", s)
+	return -1
+}
+
+func TestListItemAt(t *testing.T) {
+	l, delegate := newTestList(10)
+	const top = 5
+
+	for _, want := range []int{0, 1, 2} {
+		name := fmt.Sprintf("item-%d", want)
+		row := rowOf(t, l, name)
+
+		// Both the title and description lines of an item select it
+		for _, y := range []int{top + row, top + row + 1} {
+			got, ok := ListItemAt(l, delegate, top, y)
+			if !ok || got != want {
+				t.Errorf("ListItemAt(y=%d) = %d, %v, want %d, true", y, got, ok, want)
+			}
+		}
+	}
+
+	if _, ok := ListItemAt(l, delegate, top, top); ok {
+		t.Error("ListItemAt() on the title bar should report no item")
+	}
+	if _, ok := ListItemAt(l, delegate, top, top+rowOf(t, l, "item-0")+2); ok {
+		t.Error("ListItemAt() on the spacing between items should report no item")
+	}
+}
+
+func TestListItemAtSecondPage(t *testing.T) {
+	l, delegate := newTestList(30)
+	l.Paginator.NextPage()
+
+	first := l.Paginator.PerPage
+	name := fmt.Sprintf("item-%d", first)
+	got, ok := ListItemAt(l, delegate, 0, rowOf(t, l, name))
+	if !ok || got != first {
+		t.Errorf("ListItemAt() on page 2 = %d, %v, want %d, true", got, ok, first)
+	}
+}
+
+func TestHandleListMouse(t *testing.T) {
+	l, delegate := newTestList(10)
+
+	wheel := tea.MouseMsg{Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress}
+	if HandleListMouse(&l, delegate, 0, wheel) {
+		t.Error("wheel events should not report a click")
+	}
+	if l.Index() != 1 {
+		t.Errorf("Index() after wheel down = %d, want 1", l.Index())
+	}
+
+	click := tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionPress, Y: rowOf(t, l, "item-3")}
+	if !HandleListMouse(&l, delegate, 0, click) {
+		t.Error("clicking an item should report a click")
+	}
+	if l.Index() != 3 {
+		t.Errorf("Index() after click = %d, want 3", l.Index())
+	}
+}
+
+func TestStyleTopOffset(t *testing.T) {
+	style := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(1, 2).MarginTop(1)
+	if got := StyleTopOffset(style); got != 3 {
+		t.Errorf("StyleTopOffset() = %d, want 3", got)
+	}
+}
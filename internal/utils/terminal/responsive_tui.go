@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/syst/internal/utils/theme"
 )
 
 // ResponsiveTUIModel is an interface that all bubbletea models should implement
@@ -110,7 +111,7 @@ func (h *ResponsiveTUIHelper) TruncateContentToHeight(content string) string {
 	// Truncate if too many lines
 	lines = lines[:h.height-2]
 	lines = append(lines, lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#626262")).
+		Foreground(theme.Current.Muted).
 		Render("... (content truncated)"))
 	return strings.Join(lines, "\n")
 }
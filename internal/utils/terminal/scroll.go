@@ -0,0 +1,73 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/syst/internal/utils/theme"
+)
+
+// Scrolling for views that build their content by hand with a strings.Builder, rather
+// than with a list or viewport that scrolls itself. The view keeps a line offset into its
+// content and shows the lines that fit between its fixed header and footer, which take up
+// reservedLines.
+
+var (
+	scrollPageUp   = key.NewBinding(key.WithKeys("pgup"))
+	scrollPageDown = key.NewBinding(key.WithKeys("pgdown"))
+
+	scrollStatusStyle = lipgloss.NewStyle().Foreground(theme.Current.Muted)
+)
+
+// ScrollHeight returns how many lines of scrolled content fit in the terminal, including
+// the status line shown when the content doesn't fit
+func (h *ResponsiveTUIHelper) ScrollHeight(reservedLines int) int {
+	return max(h.height-reservedLines, 2)
+}
+
+// ClampScroll limits offset to the range that keeps the content filling the scroll height.
+// It is 0 when the content fits.
+func (h *ResponsiveTUIHelper) ClampScroll(content string, offset, reservedLines int) int {
+	lines := len(scrollLines(content))
+	height := h.ScrollHeight(reservedLines)
+	if lines <= height {
+		return 0
+	}
+	return min(max(offset, 0), lines-(height-1))
+}
+
+// ScrollKey returns offset moved a page up or down for pgup and pgdown; ok is false for
+// other keys. The result still needs ClampScroll.
+func (h *ResponsiveTUIHelper) ScrollKey(msg tea.KeyMsg, offset, reservedLines int) (int, bool) {
+	page := max(h.ScrollHeight(reservedLines)-1, 1)
+	switch {
+	case key.Matches(msg, scrollPageUp):
+		return offset - page, true
+	case key.Matches(msg, scrollPageDown):
+		return offset + page, true
+	}
+	return offset, false
+}
+
+// ScrollContent returns the lines of content that fit in the terminal from offset. When
+// the content doesn't fit, the last line says which lines are shown and how to scroll.
+func (h *ResponsiveTUIHelper) ScrollContent(content string, offset, reservedLines int) string {
+	lines := scrollLines(content)
+	height := h.ScrollHeight(reservedLines)
+	if len(lines) <= height {
+		return content
+	}
+
+	offset = h.ClampScroll(content, offset, reservedLines)
+	visible := lines[offset : offset+height-1]
+	status := scrollStatusStyle.Render(fmt.Sprintf("lines %d-%d of %d • pgup/pgdn: scroll",
+		offset+1, offset+len(visible), len(lines)))
+	return strings.Join(append(visible, status), "\n")
+}
+
+func scrollLines(content string) []string {
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
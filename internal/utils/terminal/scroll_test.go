@@ -0,0 +1,88 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// numberedLines returns content of n lines, "line 1" to "line n"
+func numberedLines(n int) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func TestScrollContent(t *testing.T) {
+	h := NewResponsiveTUIHelper()
+	h.SetSize(80, 12) // 10 lines after 2 reserved: 9 of content and the status line
+
+	content := numberedLines(20)
+	got := strings.Split(h.ScrollContent(content, 5, 2), "\n")
+	if len(got) != 10 {
+		t.Fatalf("got %d lines, want 10", len(got))
+	}
+	if got[0] != "line 6" || got[8] != "line 14" {
+		t.Errorf("shown lines %q to %q, want line 6 to line 14", got[0], got[8])
+	}
+	if !strings.Contains(got[9], "lines 6-14 of 20") {
+		t.Errorf("status line = %q", got[9])
+	}
+
+	// Past the end, the last lines fill the window
+	got = strings.Split(h.ScrollContent(content, 100, 2), "\n")
+	if got[0] != "line 12" || got[8] != "line 20" {
+		t.Errorf("shown lines %q to %q, want line 12 to line 20", got[0], got[8])
+	}
+
+	// Content that fits is left alone
+	short := numberedLines(10)
+	if got := h.ScrollContent(short, 3, 2); got != short {
+		t.Errorf("ScrollContent() changed content that fits: %q", got)
+	}
+}
+
+func TestClampScroll(t *testing.T) {
+	h := NewResponsiveTUIHelper()
+	h.SetSize(80, 12)
+	content := numberedLines(20)
+
+	tests := []struct {
+		offset, want int
+	}{
+		{-3, 0},
+		{4, 4},
+		{11, 11},
+		{50, 11},
+	}
+	for _, tt := range tests {
+		if got := h.ClampScroll(content, tt.offset, 2); got != tt.want {
+			t.Errorf("ClampScroll(%d) = %d, want %d", tt.offset, got, tt.want)
+		}
+	}
+
+	// Growing the terminal brings back lines hidden above
+	h.SetSize(80, 30)
+	if got := h.ClampScroll(content, 11, 2); got != 0 {
+		t.Errorf("ClampScroll() after resize = %d, want 0", got)
+	}
+}
+
+func TestScrollKey(t *testing.T) {
+	h := NewResponsiveTUIHelper()
+	h.SetSize(80, 12)
+
+	if got, ok := h.ScrollKey(tea.KeyMsg{Type: tea.KeyPgDown}, 2, 2); !ok || got != 11 {
+		t.Errorf("pgdown = %d, %v; want 11, true", got, ok)
+	}
+	if got, ok := h.ScrollKey(tea.KeyMsg{Type: tea.KeyPgUp}, 2, 2); !ok || got != -7 {
+		t.Errorf("pgup = %d, %v; want -7, true", got, ok)
+	}
+	if _, ok := h.ScrollKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}, 2, 2); ok {
+		t.Error("ScrollKey() handled a key other than pgup/pgdown")
+	}
+}
@@ -0,0 +1,33 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ErrNoTTY is returned by TUIs started without a terminal to draw on and read keys from,
+// i.e. with their output piped or in CI.
+var ErrNoTTY = errors.New("not running in an interactive terminal")
+
+// isInteractive reports whether stdin and stdout are both terminals. A variable so tests
+// can stand in for a terminal.
+var isInteractive = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// RequireTTY returns ErrNoTTY when stdin or stdout isn't a terminal, so a TUI fails with
+// a clear message instead of hanging or writing escape codes into a pipe. alternative,
+// if not empty, names the non-interactive way to get the same data (i.e. "--format
+// json") and is suggested in the error.
+func RequireTTY(alternative string) error {
+	if isInteractive() {
+		return nil
+	}
+	if alternative == "" {
+		return fmt.Errorf("%w: this command needs a terminal", ErrNoTTY)
+	}
+	return fmt.Errorf("%w: use %s for output that can be piped", ErrNoTTY, alternative)
+}
@@ -0,0 +1,25 @@
+package terminal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRequireTTY(t *testing.T) {
+	defer func(original func() bool) { isInteractive = original }(isInteractive)
+
+	isInteractive = func() bool { return true }
+	if err := RequireTTY("--format json"); err != nil {
+		t.Errorf("RequireTTY() in a terminal = %v, want nil", err)
+	}
+
+	isInteractive = func() bool { return false }
+	err := RequireTTY("--format json")
+	if !errors.Is(err, ErrNoTTY) || !strings.Contains(err.Error(), "use --format json") {
+		t.Errorf("RequireTTY() without a terminal = %v, want ErrNoTTY suggesting --format json", err)
+	}
+	if err := RequireTTY(""); !errors.Is(err, ErrNoTTY) {
+		t.Errorf("RequireTTY(\"\") without a terminal = %v, want ErrNoTTY", err)
+	}
+}
@@ -0,0 +1,203 @@
+// Package theme holds the color themes of syst's TUIs and styled printers.
+//
+// Services build their lipgloss styles from the roles of the Current theme instead of
+// raw color codes. As those styles are package variables built when the program starts,
+// Current is chosen then too: from --theme on the command line, SYST_THEME, or theme in
+// the config file. Color being disabled (--no-color, NO_COLOR, SYST_NO_COLOR or
+// TERM=dumb) always selects the monochrome theme.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/syst/internal/config"
+)
+
+// Theme is a set of colors by role. An empty color draws in the terminal's own color.
+type Theme struct {
+	Name string
+	// Primary is for titles and headings, drawn as text or as a background
+	Primary lipgloss.Color
+	// TextOnPrimary is text drawn on a Primary background
+	TextOnPrimary lipgloss.Color
+	// Accent marks the selected item, the cursor and spinners
+	Accent lipgloss.Color
+	// Border is for the borders of panes and boxes
+	Border lipgloss.Color
+	// Text is regular text
+	Text lipgloss.Color
+	// Muted is for help, status lines and secondary details
+	Muted lipgloss.Color
+	// Subtle is for separators, faint borders and background bars
+	Subtle  lipgloss.Color
+	Success lipgloss.Color
+	Warning lipgloss.Color
+	Error   lipgloss.Color
+	// Info is for neutral notes, i.e. diff hunk headers and merge commits
+	Info lipgloss.Color
+	// Highlight marks search matches
+	Highlight lipgloss.Color
+	// Palette is cycled through to tell apart authors or graph lanes; empty draws them
+	// all in the terminal's own color
+	Palette []lipgloss.Color
+	// Cold and Hot are the ends of gradients from oldest to newest, as "#RRGGBB"
+	Cold lipgloss.Color
+	Hot  lipgloss.Color
+}
+
+// Default is the theme syst has always used
+var Default = Theme{
+	Name:          "default",
+	Primary:       "#7D56F4",
+	TextOnPrimary: "#FFFDF5",
+	Accent:        "#01FAC6",
+	Border:        "#874BFD",
+	Text:          "#DDDDDD",
+	Muted:         "#626262",
+	Subtle:        "238",
+	Success:       "#04B575",
+	Warning:       "#FFB86C",
+	Error:         "#FF5F87",
+	Info:          "#8BE9FD",
+	Highlight:     "#F1FA8C",
+	Palette:       []lipgloss.Color{"#F25D94", "#01FAC6", "#FFB86C", "#8BE9FD", "#BD93F9", "#50FA7B", "#F1FA8C", "#FF79C6"},
+	Cold:          "#5F87FF",
+	Hot:           "#FF5F5F",
+}
+
+// Solarized uses the accent colors of Solarized dark (https://ethanschoonover.com/solarized)
+var Solarized = Theme{
+	Name:          "solarized",
+	Primary:       "#268BD2",
+	TextOnPrimary: "#FDF6E3",
+	Accent:        "#2AA198",
+	Border:        "#6C71C4",
+	Text:          "#93A1A1",
+	Muted:         "#586E75",
+	Subtle:        "#073642",
+	Success:       "#859900",
+	Warning:       "#B58900",
+	Error:         "#DC322F",
+	Info:          "#6C71C4",
+	Highlight:     "#CB4B16",
+	Palette:       []lipgloss.Color{"#268BD2", "#2AA198", "#859900", "#B58900", "#CB4B16", "#DC322F", "#D33682", "#6C71C4"},
+	Cold:          "#268BD2",
+	Hot:           "#DC322F",
+}
+
+// Dracula uses the Dracula palette (https://draculatheme.com)
+var Dracula = Theme{
+	Name:          "dracula",
+	Primary:       "#BD93F9",
+	TextOnPrimary: "#282A36",
+	Accent:        "#FF79C6",
+	Border:        "#6272A4",
+	Text:          "#F8F8F2",
+	Muted:         "#6272A4",
+	Subtle:        "#44475A",
+	Success:       "#50FA7B",
+	Warning:       "#FFB86C",
+	Error:         "#FF5555",
+	Info:          "#8BE9FD",
+	Highlight:     "#F1FA8C",
+	Palette:       []lipgloss.Color{"#FF79C6", "#8BE9FD", "#FFB86C", "#BD93F9", "#50FA7B", "#F1FA8C", "#FF5555"},
+	Cold:          "#8BE9FD",
+	Hot:           "#FF5555",
+}
+
+// HighContrast uses only the bright ANSI colors, which every terminal has and draws
+// clearly on a dark background
+var HighContrast = Theme{
+	Name:          "high-contrast",
+	Primary:       "11",
+	TextOnPrimary: "0",
+	Accent:        "14",
+	Border:        "15",
+	Text:          "15",
+	Muted:         "7",
+	Subtle:        "8",
+	Success:       "10",
+	Warning:       "11",
+	Error:         "9",
+	Info:          "12",
+	Highlight:     "13",
+	Palette:       []lipgloss.Color{"14", "11", "13", "10", "12", "9", "15"},
+	Cold:          "#00FFFF",
+	Hot:           "#FF0000",
+}
+
+// Monochrome has no colors, leaving only text attributes like bold to tell things
+// apart. It is the theme whenever color is disabled.
+var Monochrome = Theme{Name: "monochrome"}
+
+// presets are the themes --theme selects from, in the order they are listed
+var presets = []Theme{Default, Solarized, Dracula, HighContrast, Monochrome}
+
+// Current is the theme every style is built from
+var Current = resolve(os.Args[1:], config.Getenv(os.Args[1:]))
+
+// PaletteColor returns the i-th color of the palette, cycling through it. It is empty
+// when the palette is.
+func (t Theme) PaletteColor(i int) lipgloss.Color {
+	if len(t.Palette) == 0 {
+		return ""
+	}
+	return t.Palette[i%len(t.Palette)]
+}
+
+// Names returns the names of the bundled themes
+func Names() []string {
+	names := make([]string, len(presets))
+	for i, t := range presets {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Lookup returns the bundled theme called name
+func Lookup(name string) (Theme, error) {
+	for _, t := range presets {
+		if strings.EqualFold(t.Name, name) {
+			return t, nil
+		}
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(Names(), ", "))
+}
+
+// resolve picks the theme from the command line args and environment. It runs before
+// the flags are parsed, so an unknown name falls back to Default here and is reported
+// by the root command once it validates --theme.
+func resolve(args []string, getenv func(string) string) Theme {
+	name := getenv("SYST_THEME")
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		switch {
+		case arg == "--no-color" || arg == "--no-color=true":
+			return Monochrome
+		case arg == "--theme" && i+1 < len(args):
+			i++
+			name = args[i]
+		case strings.HasPrefix(arg, "--theme="):
+			name = strings.TrimPrefix(arg, "--theme=")
+		}
+	}
+	if noColor, _ := strconv.ParseBool(getenv("SYST_NO_COLOR")); noColor || getenv("NO_COLOR") != "" || getenv("TERM") == "dumb" {
+		return Monochrome
+	}
+
+	if name == "" {
+		return Default
+	}
+	t, err := Lookup(name)
+	if err != nil {
+		return Default
+	}
+	return t
+}
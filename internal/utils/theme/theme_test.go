@@ -0,0 +1,85 @@
+package theme
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want string
+	}{
+		{"default", nil, nil, "default"},
+		{"flag", []string{"git", "blame", "--theme", "dracula", "main.go"}, nil, "dracula"},
+		{"flag with value", []string{"--theme=Solarized", "git"}, nil, "solarized"},
+		{"environment", []string{"git"}, map[string]string{"SYST_THEME": "high-contrast"}, "high-contrast"},
+		{"flag over environment", []string{"--theme", "dracula"}, map[string]string{"SYST_THEME": "solarized"}, "dracula"},
+		{"unknown", []string{"--theme", "nope"}, nil, "default"},
+		{"after --", []string{"--", "--theme", "dracula"}, nil, "default"},
+		{"no color flag", []string{"--theme", "dracula", "--no-color"}, nil, "monochrome"},
+		{"NO_COLOR", []string{"--theme", "dracula"}, map[string]string{"NO_COLOR": "1"}, "monochrome"},
+		{"SYST_NO_COLOR", nil, map[string]string{"SYST_NO_COLOR": "true"}, "monochrome"},
+		{"SYST_NO_COLOR false", nil, map[string]string{"SYST_NO_COLOR": "false"}, "default"},
+		{"dumb terminal", nil, map[string]string{"TERM": "dumb"}, "monochrome"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := resolve(tt.args, getenv); got.Name != tt.want {
+				t.Errorf("resolve(%q) = %s, want %s", tt.args, got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range Names() {
+		if _, err := Lookup(name); err != nil {
+			t.Errorf("Lookup(%q) error = %v", name, err)
+		}
+	}
+	if _, err := Lookup("nope"); err == nil {
+		t.Error("Lookup() of an unknown theme should fail")
+	}
+}
+
+func TestPresetsSetEveryRole(t *testing.T) {
+	for _, theme := range presets {
+		if theme.Name == Monochrome.Name {
+			continue
+		}
+		roles := map[string]string{
+			"Primary":       string(theme.Primary),
+			"TextOnPrimary": string(theme.TextOnPrimary),
+			"Accent":        string(theme.Accent),
+			"Border":        string(theme.Border),
+			"Text":          string(theme.Text),
+			"Muted":         string(theme.Muted),
+			"Subtle":        string(theme.Subtle),
+			"Success":       string(theme.Success),
+			"Warning":       string(theme.Warning),
+			"Error":         string(theme.Error),
+			"Info":          string(theme.Info),
+			"Highlight":     string(theme.Highlight),
+			"Cold":          string(theme.Cold),
+			"Hot":           string(theme.Hot),
+		}
+		for role, color := range roles {
+			if color == "" {
+				t.Errorf("%s theme has no %s color", theme.Name, role)
+			}
+		}
+		if len(theme.Palette) == 0 {
+			t.Errorf("%s theme has no palette", theme.Name)
+		}
+	}
+}
+
+func TestPaletteColor(t *testing.T) {
+	if got := Default.PaletteColor(len(Default.Palette) + 1); got != Default.Palette[1] {
+		t.Errorf("PaletteColor() = %s, want it to cycle to %s", got, Default.Palette[1])
+	}
+	if got := Monochrome.PaletteColor(3); got != "" {
+		t.Errorf("PaletteColor() without a palette = %s, want no color", got)
+	}
+}
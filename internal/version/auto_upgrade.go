@@ -0,0 +1,157 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/redjax/syst/internal/config"
+)
+
+const (
+	// DefaultUpgradeCheckInterval is how often the startup check contacts GitHub.
+	DefaultUpgradeCheckInterval = 24 * time.Hour
+	// startupCheckTimeout bounds the background release check on startup.
+	startupCheckTimeout = 5 * time.Second
+)
+
+// upgradeCheckState is cached between runs so the startup check only hits the network once per interval.
+type upgradeCheckState struct {
+	LastCheck time.Time `json:"last_check"`
+	Latest    string    `json:"latest,omitempty"`
+	Channel   Channel   `json:"channel,omitempty"`
+}
+
+// UpgradeNotice is the result of a background startup check for a newer release.
+type UpgradeNotice struct {
+	done    chan struct{}
+	current string
+	latest  string
+	err     error
+}
+
+// upgradeCheckStatePath returns the path of the cached startup check state.
+func upgradeCheckStatePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "syst", "upgrade-check.json"), nil
+}
+
+// loadUpgradeCheckState reads the cached state, returning an empty state if it is missing or invalid.
+func loadUpgradeCheckState(path string) upgradeCheckState {
+	var state upgradeCheckState
+
+	// #nosec G304 - Path is derived from the user's cache directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	// #nosec G104 - A corrupt cache file is treated as "never checked"
+	json.Unmarshal(data, &state)
+
+	return state
+}
+
+// saveUpgradeCheckState writes the cached state, creating its directory if needed.
+func saveUpgradeCheckState(path string, state upgradeCheckState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// checkDue reports whether the last check is older than interval.
+func checkDue(state upgradeCheckState, now time.Time, interval time.Duration) bool {
+	return state.LastCheck.IsZero() || now.Sub(state.LastCheck) >= interval
+}
+
+// StartUpgradeCheck checks for a newer release in the background and returns immediately.
+// GitHub is contacted at most once per interval; between checks the cached result is reused.
+// It checks the channel set with SYST_UPGRADE_CHANNEL or the config file. Development builds are never checked and return nil.
+func StartUpgradeCheck(interval time.Duration) *UpgradeNotice {
+	current := GetPackageInfo().PackageVersion
+	if current == "dev" {
+		return nil
+	}
+	if interval <= 0 {
+		interval = DefaultUpgradeCheckInterval
+	}
+
+	notice := &UpgradeNotice{done: make(chan struct{}), current: current}
+
+	statePath, err := upgradeCheckStatePath()
+	if err != nil {
+		close(notice.done)
+		return notice
+	}
+
+	// An invalid channel is reported by 'syst self upgrade' instead of by every command
+	channel, err := configuredChannel(config.Getenv(os.Args[1:]))
+	if err != nil {
+		close(notice.done)
+		return notice
+	}
+	state := loadUpgradeCheckState(statePath)
+	// A cached result from another channel is stale
+	if state.Channel == channel && !checkDue(state, time.Now(), interval) {
+		notice.latest = state.Latest
+		close(notice.done)
+		return notice
+	}
+
+	go func() {
+		defer close(notice.done)
+
+		ctx, cancel := context.WithTimeout(context.Background(), startupCheckTimeout)
+		defer cancel()
+
+		// Record the attempt even on failure so an offline machine doesn't retry every run
+		state.LastCheck = time.Now()
+		state.Channel = channel
+		state.Latest = ""
+		check, err := CheckForUpgrade(ctx, channel)
+		if err != nil {
+			notice.err = err
+		} else {
+			state.Latest = check.Latest
+			notice.latest = check.Latest
+		}
+		// #nosec G104 - Failing to cache only means the next run checks again
+		saveUpgradeCheckState(statePath, state)
+	}()
+
+	return notice
+}
+
+// Print writes an upgrade banner to w if a newer release is available, or a brief warning
+// if the check failed. It waits at most wait for an in-flight check and is safe to call on nil.
+func (n *UpgradeNotice) Print(w io.Writer, wait time.Duration) {
+	if n == nil {
+		return
+	}
+
+	select {
+	case <-n.done:
+	case <-time.After(wait):
+		return
+	}
+
+	if n.err != nil {
+		fmt.Fprintf(w, "⚠️  Could not check for syst updates: %v\n", n.err)
+		return
+	}
+
+	if n.latest != "" && compareVersion(n.current, n.latest) < 0 {
+		fmt.Fprintf(w, "🚀 syst %s is available (current: %s). Run 'syst self upgrade' to install it.\n", n.latest, n.current)
+	}
+}
@@ -0,0 +1,82 @@
+package version
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckDue(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		lastCheck time.Time
+		want      bool
+	}{
+		{"never checked", time.Time{}, true},
+		{"checked recently", now.Add(-time.Hour), false},
+		{"interval elapsed", now.Add(-25 * time.Hour), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkDue(upgradeCheckState{LastCheck: tt.lastCheck}, now, 24*time.Hour)
+			if got != tt.want {
+				t.Errorf("checkDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeCheckStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "upgrade-check.json")
+
+	if state := loadUpgradeCheckState(path); !state.LastCheck.IsZero() {
+		t.Errorf("loadUpgradeCheckState() on missing file = %+v, want zero state", state)
+	}
+
+	want := upgradeCheckState{LastCheck: time.Now().Truncate(time.Second), Latest: "v1.2.3"}
+	if err := saveUpgradeCheckState(path, want); err != nil {
+		t.Fatalf("saveUpgradeCheckState() error: %v", err)
+	}
+
+	got := loadUpgradeCheckState(path)
+	if !got.LastCheck.Equal(want.LastCheck) || got.Latest != want.Latest {
+		t.Errorf("loadUpgradeCheckState() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUpgradeNoticePrint(t *testing.T) {
+	newNotice := func(current, latest string, err error) *UpgradeNotice {
+		n := &UpgradeNotice{done: make(chan struct{}), current: current, latest: latest, err: err}
+		close(n.done)
+		return n
+	}
+
+	tests := []struct {
+		name   string
+		notice *UpgradeNotice
+		want   string
+	}{
+		{"nil notice", nil, ""},
+		{"upgrade available", newNotice("1.0.0", "v1.1.0", nil), "syst v1.1.0 is available"},
+		{"up to date", newNotice("1.1.0", "v1.1.0", nil), ""},
+		{"check failed", newNotice("1.0.0", "", errors.New("offline")), "Could not check for syst updates: offline"},
+		{"still running", &UpgradeNotice{done: make(chan struct{})}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.notice.Print(&buf, 10*time.Millisecond)
+			if tt.want == "" && buf.Len() != 0 {
+				t.Errorf("Print() = %q, want no output", buf.String())
+			}
+			if tt.want != "" && !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Print() = %q, want it to contain %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Channel selects which GitHub releases self upgrade considers.
+type Channel string
+
+const (
+	// ChannelStable only considers full releases with plain semver tags (the default)
+	ChannelStable Channel = "stable"
+	// ChannelPrerelease also considers GitHub pre-releases and suffixed tags like -rc1
+	ChannelPrerelease Channel = "prerelease"
+)
+
+// semverTagPattern matches release tags like v1.2.3 or 1.2.3-rc1.
+var semverTagPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`)
+
+// ParseChannel validates a channel name.
+func ParseChannel(s string) (Channel, error) {
+	switch Channel(strings.ToLower(strings.TrimSpace(s))) {
+	case ChannelStable:
+		return ChannelStable, nil
+	case ChannelPrerelease:
+		return ChannelPrerelease, nil
+	default:
+		return "", fmt.Errorf("invalid channel %q: must be %q or %q", s, ChannelStable, ChannelPrerelease)
+	}
+}
+
+// configuredChannel returns the channel set with SYST_UPGRADE_CHANNEL, or upgrade_channel
+// in the config file, as looked up by getenv. It is ChannelStable when neither is set.
+func configuredChannel(getenv func(string) string) (Channel, error) {
+	name := getenv("SYST_UPGRADE_CHANNEL")
+	if name == "" {
+		return ChannelStable, nil
+	}
+
+	channel, err := ParseChannel(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid value for upgrade.channel in the environment or config file: %w", err)
+	}
+	return channel, nil
+}
@@ -2,7 +2,10 @@ package version
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/redjax/syst/internal/config"
+	"github.com/redjax/syst/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -68,7 +71,12 @@ func NewPackageInfoCommand() *cobra.Command {
 //
 //	cmd.AddCommand(version.NewUpgradeCommand())
 func NewUpgradeCommand() *cobra.Command {
-	var checkOnly bool
+	var (
+		opts      UpgradeOptions
+		checkOnly bool
+		asJSON    bool
+		channel   string
+	)
 
 	cmd := &cobra.Command{
 		Use: "upgrade",
@@ -76,12 +84,51 @@ func NewUpgradeCommand() *cobra.Command {
 		Aliases: []string{"update"},
 		Short:   "Upgrade syst CLI to the latest release",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return UpgradeSelf(cmd, args, checkOnly)
+			if asJSON && !checkOnly {
+				return fmt.Errorf("--json can only be used with --check-only")
+			}
+			// --check-only compares against the latest release; --check handles a pinned one
+			if checkOnly && opts.Version != "" {
+				return fmt.Errorf("--version can't be used with --check-only, use --check to compare with a specific release")
+			}
+
+			// --channel applies to this run only; without it the configured channel is used
+			var err error
+			if channel != "" {
+				opts.Channel, err = ParseChannel(channel)
+			} else {
+				opts.Channel, err = configuredChannel(config.Getenv(os.Args[1:]))
+			}
+			if err != nil {
+				return err
+			}
+
+			if checkOnly {
+				available, err := CheckUpgradeOnly(cmd, asJSON, opts)
+				if err != nil {
+					return err
+				}
+				// Exit non-zero so scripts and CI can detect a stale install
+				if available {
+					cmd.SilenceErrors, cmd.SilenceUsage = true, true
+					return utils.ErrExitFailure
+				}
+				return nil
+			}
+
+			return UpgradeSelf(cmd, args, opts)
 		},
 	}
 
 	// Register flags
-	cmd.Flags().BoolVar(&checkOnly, "check", false, "Only check for latest version, don't upgrade if one is found.")
+	cmd.Flags().BoolVar(&opts.CheckOnly, "check", false, "Only check for latest version, don't upgrade if one is found.")
+	cmd.Flags().BoolVar(&checkOnly, "check-only", false, "Print current vs latest version and exit 1 if an upgrade is available. Never downloads anything.")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output the --check-only result as JSON.")
+	cmd.Flags().StringVar(&opts.Version, "version", "", "Install a specific release (e.g. 0.0.18) instead of the latest.")
+	cmd.Flags().BoolVar(&opts.AllowDowngrade, "allow-downgrade", false, "Allow --version to install an older release without confirmation.")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", DefaultUpgradeTimeout, "Timeout for each release check/download request (e.g. 30s, 2m).")
+	cmd.Flags().StringVar(&channel, "channel", "", "Release channel to upgrade from: stable or prerelease (default SYST_UPGRADE_CHANNEL, or stable).")
+	cmd.Flags().BoolVar(&opts.SkipChecksum, "skip-checksum", false, "Install even if the release has no published SHA256 checksum.")
 
 	return cmd
 }
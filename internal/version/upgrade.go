@@ -2,83 +2,343 @@ package version
 
 import (
 	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
-// UpgradeSelf is the entrypoint for 'syst self upgrade'.
-// It downloads the latest release, extracts the binary, replaces the current
-// executable in-place, verifies the new binary, and rolls back on failure.
-func UpgradeSelf(cmd *cobra.Command, args []string, checkOnly bool) error {
-	info := GetPackageInfo()
+// DefaultUpgradeTimeout bounds each release-check and download request.
+const DefaultUpgradeTimeout = 60 * time.Second
+
+// githubAPIURL is the GitHub API base URL, overridden in tests.
+var githubAPIURL = "https://api.github.com"
+
+// upgradeHTTPClient is used for all self-upgrade requests. Proxy settings are
+// read from HTTP_PROXY/HTTPS_PROXY/NO_PROXY.
+var upgradeHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+}
+
+// httpGet issues a GET request bound to ctx using the upgrade HTTP client.
+func httpGet(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return upgradeHTTPClient.Do(req)
+}
+
+// upgradeContext returns a context that expires after timeout, or DefaultUpgradeTimeout if unset.
+func upgradeContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = DefaultUpgradeTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// UpgradeOptions controls the behavior of 'syst self upgrade'.
+type UpgradeOptions struct {
+	// CheckOnly reports whether an upgrade is available without installing it
+	CheckOnly bool
+	// SkipChecksum installs the release even if no SHA256 checksum is published for it
+	SkipChecksum bool
+	// Version pins the upgrade to a specific release instead of the latest one
+	Version string
+	// AllowDowngrade installs an older pinned Version without asking for confirmation
+	AllowDowngrade bool
+	// Timeout bounds each network request; DefaultUpgradeTimeout is used when zero
+	Timeout time.Duration
+	// Channel selects which releases are considered when upgrading to the latest one
+	Channel Channel
+}
+
+// githubRelease is the subset of the GitHub release API response used for upgrades.
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Prerelease bool   `json:"prerelease"`
+	Draft      bool   `json:"draft"`
+	Assets     []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// UpgradeCheck is the result of comparing the running version against the latest release.
+type UpgradeCheck struct {
+	Current          string `json:"current"`
+	Latest           string `json:"latest"`
+	UpgradeAvailable bool   `json:"upgrade_available"`
+}
 
+// fetchReleases lists the repository's most recent releases, newest first.
+func fetchReleases(ctx context.Context) ([]githubRelease, error) {
 	repo, err := getRepoUrlPath()
 	if err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error getting repository path (user/repo): %v\n", err)
-		return err
+		return nil, fmt.Errorf("error getting repository path (user/repo): %w", err)
 	}
 
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
-	fmt.Fprintln(cmd.ErrOrStderr(), "Checking for latest release...")
+	apiURL := fmt.Sprintf("%s/repos/%s/releases?per_page=100", githubAPIURL, repo)
 
 	// #nosec G107 - URL is constructed from hardcoded GitHub API endpoint and repo constant
-	resp, err := http.Get(apiURL)
+	resp, err := httpGet(ctx, apiURL)
 	if err != nil {
-		return fmt.Errorf("failed to fetch latest release: %w", err)
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("GitHub API returned status: %s", resp.Status)
+		return nil, fmt.Errorf("GitHub API returned status: %s", resp.Status)
+	}
+
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases JSON: %w", err)
+	}
+
+	return releases, nil
+}
+
+// fetchLatestRelease returns the newest release of this repository on the given channel.
+func fetchLatestRelease(ctx context.Context, channel Channel) (githubRelease, error) {
+	releases, err := fetchReleases(ctx)
+	if err != nil {
+		return githubRelease{}, err
+	}
+	return latestRelease(releases, channel)
+}
+
+// fetchReleaseByVersion looks up the release tagged version, with or without a leading
+// 'v', so a pinned release is found however old it is.
+func fetchReleaseByVersion(ctx context.Context, version string) (githubRelease, error) {
+	for _, tag := range releaseTags(version) {
+		release, found, err := fetchReleaseByTag(ctx, tag)
+		if err != nil {
+			return githubRelease{}, err
+		}
+		if found {
+			return release, nil
+		}
+	}
+	return githubRelease{}, fmt.Errorf("release %s not found", version)
+}
+
+// fetchReleaseByTag fetches the release for tag. found is false when there is none.
+func fetchReleaseByTag(ctx context.Context, tag string) (release githubRelease, found bool, err error) {
+	repo, err := getRepoUrlPath()
+	if err != nil {
+		return githubRelease{}, false, fmt.Errorf("error getting repository path (user/repo): %w", err)
 	}
 
-	var release struct {
-		TagName string `json:"tag_name"`
-		Assets  []struct {
-			Name               string `json:"name"`
-			BrowserDownloadURL string `json:"browser_download_url"`
-		} `json:"assets"`
+	apiURL := fmt.Sprintf("%s/repos/%s/releases/tags/%s", githubAPIURL, repo, url.PathEscape(tag))
+
+	// #nosec G107 - URL is constructed from hardcoded GitHub API endpoint and repo constant
+	resp, err := httpGet(ctx, apiURL)
+	if err != nil {
+		return githubRelease{}, false, fmt.Errorf("failed to fetch release %s: %w", tag, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return githubRelease{}, false, nil
+	default:
+		return githubRelease{}, false, fmt.Errorf("GitHub API returned status: %s", resp.Status)
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return fmt.Errorf("failed to parse release JSON: %w", err)
+		return githubRelease{}, false, fmt.Errorf("failed to parse release JSON: %w", err)
+	}
+	return release, true, nil
+}
+
+// releaseTags returns the tags a release of version may have: version as given, then with
+// its leading 'v' added or removed.
+func releaseTags(version string) []string {
+	if trimmed, ok := strings.CutPrefix(version, "v"); ok {
+		return []string{version, trimmed}
+	}
+	return []string{version, "v" + version}
+}
+
+// latestRelease returns the highest semver release on channel. Drafts and non-semver tags
+// are always skipped; the stable channel also skips pre-releases and tags with a suffix
+// like -rc1. When versions tie, the first (newest) release wins.
+func latestRelease(releases []githubRelease, channel Channel) (githubRelease, error) {
+	var (
+		best  githubRelease
+		found bool
+	)
+	for _, release := range releases {
+		if release.Draft || !semverTagPattern.MatchString(release.TagName) {
+			continue
+		}
+		if channel != ChannelPrerelease && (release.Prerelease || strings.Contains(release.TagName, "-")) {
+			continue
+		}
+		if !found || compareVersion(release.TagName, best.TagName) > 0 {
+			best = release
+			found = true
+		}
+	}
+
+	if !found {
+		return githubRelease{}, fmt.Errorf("no %s releases found", channel)
+	}
+	return best, nil
+}
+
+// confirmDowngrade asks the user on stdin whether to install an older release.
+func confirmDowngrade(cmd *cobra.Command, current, target string) bool {
+	fmt.Fprintf(cmd.ErrOrStderr(), "Downgrade syst from %s to %s? [y/N]: ", current, target)
+	reader := bufio.NewReader(cmd.InOrStdin())
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(strings.ToLower(input))
+	return input == "y" || input == "yes"
+}
+
+// CheckForUpgrade compares the running version against the latest release without
+// downloading anything. Development builds never report an available upgrade.
+func CheckForUpgrade(ctx context.Context, channel Channel) (UpgradeCheck, error) {
+	release, err := fetchLatestRelease(ctx, channel)
+	if err != nil {
+		return UpgradeCheck{}, err
+	}
+
+	current := GetPackageInfo().PackageVersion
+	return UpgradeCheck{
+		Current:          current,
+		Latest:           release.TagName,
+		UpgradeAvailable: current != "dev" && compareVersion(current, release.TagName) < 0,
+	}, nil
+}
+
+// CheckUpgradeOnly is the entrypoint for 'syst self upgrade --check-only'.
+// It prints the current and latest versions (as JSON if asJSON is set) and never
+// downloads or writes a binary. The returned bool reports whether an upgrade is available.
+func CheckUpgradeOnly(cmd *cobra.Command, asJSON bool, opts UpgradeOptions) (bool, error) {
+	ctx, cancel := upgradeContext(opts.Timeout)
+	defer cancel()
+
+	check, err := CheckForUpgrade(ctx, opts.Channel)
+	if err != nil {
+		return false, err
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(check); err != nil {
+			return false, fmt.Errorf("failed to encode upgrade check: %w", err)
+		}
+		return check.UpgradeAvailable, nil
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "Current version:", check.Current)
+	fmt.Fprintln(cmd.OutOrStdout(), "Latest version: ", check.Latest)
+	if check.UpgradeAvailable {
+		fmt.Fprintf(cmd.OutOrStdout(), "🚀 Upgrade available: %s → %s\n", check.Current, check.Latest)
+	} else {
+		fmt.Fprintln(cmd.OutOrStdout(), "✅ syst is up to date.")
+	}
+
+	return check.UpgradeAvailable, nil
+}
+
+// UpgradeSelf is the entrypoint for 'syst self upgrade'.
+// It downloads the latest release, verifies its SHA256 checksum, extracts the binary,
+// replaces the current executable in-place, verifies the new binary, and rolls back on failure.
+func UpgradeSelf(cmd *cobra.Command, args []string, opts UpgradeOptions) error {
+	info := GetPackageInfo()
+
+	var (
+		release githubRelease
+		err     error
+	)
+	pinned := opts.Version != ""
+	if opts.Channel == "" {
+		opts.Channel = ChannelStable
+	}
+	ctx, cancel := upgradeContext(opts.Timeout)
+	if pinned {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Looking up release %s...\n", opts.Version)
+		release, err = fetchReleaseByVersion(ctx, opts.Version)
+	} else {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Checking for latest %s release...\n", opts.Channel)
+		release, err = fetchLatestRelease(ctx, opts.Channel)
+	}
+	cancel()
+	if err != nil {
+		return err
 	}
 
 	current := info.PackageVersion
 	latest := release.TagName
 
 	fmt.Fprintln(cmd.ErrOrStderr(), "Current version:", current)
-	fmt.Fprintln(cmd.ErrOrStderr(), "Latest version: ", latest)
+	if pinned {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Target version: ", latest)
+	} else {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Latest version: ", latest)
+	}
 
-	if current == "dev" {
+	// Development builds can still install a pinned release, but never auto-upgrade
+	if current == "dev" && !pinned {
 		fmt.Fprintf(cmd.ErrOrStderr(), "🛠️  This is a development release: %s\n", current)
 		return nil
 	}
 
 	cmp := compareVersion(current, latest)
+	if current == "dev" {
+		cmp = -1
+	}
 
 	switch cmp {
 	case -1:
 		fmt.Fprintf(cmd.ErrOrStderr(), "🚀 Upgrade available: %s → %s\n", current, latest)
-		if checkOnly {
+		if opts.CheckOnly {
 			fmt.Fprintln(cmd.ErrOrStderr(), "✅ Use this command without --check to upgrade.")
 			return nil
 		}
 	case 0:
+		if pinned {
+			fmt.Fprintf(cmd.ErrOrStderr(), "🔄 syst is already at version %s.\n", current)
+			return nil
+		}
 		fmt.Fprintf(cmd.ErrOrStderr(), "🔄 No new release available, syst is up to date (%s).\n", current)
 		return nil
 	case 1:
-		fmt.Fprintf(cmd.ErrOrStderr(), "🤯 You're ahead of the latest release: current=%s, release=%s\n", current, latest)
-		return nil
+		if !pinned {
+			fmt.Fprintf(cmd.ErrOrStderr(), "🤯 You're ahead of the latest release: current=%s, release=%s\n", current, latest)
+			return nil
+		}
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "⏪ Downgrade requested: %s → %s\n", current, latest)
+		if opts.CheckOnly {
+			fmt.Fprintln(cmd.ErrOrStderr(), "✅ Use this command without --check to downgrade.")
+			return nil
+		}
+		if !opts.AllowDowngrade && !confirmDowngrade(cmd, current, latest) {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Downgrade cancelled. Pass --allow-downgrade to skip this prompt.")
+			return nil
+		}
 	}
 
 	normalizedOS := normalizeOS(runtime.GOOS)
@@ -88,7 +348,7 @@ func UpgradeSelf(cmd *cobra.Command, args []string, checkOnly bool) error {
 	expectedPrefixLower := fmt.Sprintf("syst-%s-%s-", strings.ToLower(normalizedOS), strings.ToLower(arch))
 	expectedPrefixMacOS := fmt.Sprintf("syst-macOS-%s-", arch) // preserve macOS casing as assets use it exactly
 
-	var assetURL string
+	var assetURL, assetName string
 	for _, asset := range release.Assets {
 		if asset.Name == "" {
 			continue
@@ -97,12 +357,14 @@ func UpgradeSelf(cmd *cobra.Command, args []string, checkOnly bool) error {
 			// macOS casing exact match
 			if strings.HasPrefix(asset.Name, expectedPrefixMacOS) && strings.HasSuffix(asset.Name, ".zip") {
 				assetURL = asset.BrowserDownloadURL
+				assetName = asset.Name
 				break
 			}
 		} else {
 			// case-insensitive match for linux/windows
 			if strings.HasPrefix(strings.ToLower(asset.Name), expectedPrefixLower) && strings.HasSuffix(strings.ToLower(asset.Name), ".zip") {
 				assetURL = asset.BrowserDownloadURL
+				assetName = asset.Name
 				break
 			}
 		}
@@ -116,10 +378,40 @@ func UpgradeSelf(cmd *cobra.Command, args []string, checkOnly bool) error {
 		return fmt.Errorf("no suitable release found for platform: %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
 
+	// Look up the published checksum before downloading so a missing checksum fails fast
+	var expectedChecksum string
+	checksumURL := ""
+	for _, asset := range release.Assets {
+		if asset.Name == assetName+".sha256" {
+			checksumURL = asset.BrowserDownloadURL
+			break
+		}
+	}
+
+	if checksumURL != "" {
+		ctx, cancel := upgradeContext(opts.Timeout)
+		expectedChecksum, err = fetchChecksum(ctx, checksumURL, assetName)
+		cancel()
+		if err != nil && !opts.SkipChecksum {
+			return fmt.Errorf("failed to fetch checksum for %s: %w", assetName, err)
+		}
+	}
+
+	if expectedChecksum == "" {
+		if !opts.SkipChecksum {
+			return fmt.Errorf("no checksum published for %s; rerun with --skip-checksum to upgrade without verification", assetName)
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "⚠️  Skipping checksum verification (--skip-checksum)")
+	}
+
 	fmt.Fprintln(cmd.ErrOrStderr(), "Downloading:", assetURL)
 
+	// The timeout also covers reading the body, so a stalled mirror cannot hang the download
+	downloadCtx, cancelDownload := upgradeContext(opts.Timeout)
+	defer cancelDownload()
+
 	// #nosec G107 - URL is from GitHub release API response, validated to be from github.com
-	resp2, err := http.Get(assetURL)
+	resp2, err := httpGet(downloadCtx, assetURL)
 	if err != nil {
 		return fmt.Errorf("failed to download binary zip: %w", err)
 	}
@@ -131,12 +423,20 @@ func UpgradeSelf(cmd *cobra.Command, args []string, checkOnly bool) error {
 	}
 	defer os.Remove(zipTmp.Name())
 
-	if _, err := io.Copy(zipTmp, resp2.Body); err != nil {
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(zipTmp, hasher), resp2.Body); err != nil {
 		return fmt.Errorf("failed to write zip file: %w", err)
 	}
 	// #nosec G104 - Close error is non-critical, file is fully written
 	zipTmp.Close()
 
+	if expectedChecksum != "" {
+		if err := verifyChecksum(assetName, expectedChecksum, hex.EncodeToString(hasher.Sum(nil))); err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "✓ Checksum verified")
+	}
+
 	binaryTmp, err := extractBinaryFromZip(zipTmp.Name())
 	if err != nil {
 		return fmt.Errorf("failed to extract binary: %w", err)
@@ -356,3 +656,57 @@ func verifyBinary(path string) error {
 	}
 	return nil
 }
+
+// fetchChecksum downloads a checksum file and returns the SHA256 hash for assetName.
+func fetchChecksum(ctx context.Context, url, assetName string) (string, error) {
+	// #nosec G107 - URL is from GitHub release API response
+	resp, err := httpGet(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checksum download returned status: %s", resp.Status)
+	}
+
+	return parseChecksum(io.LimitReader(resp.Body, 64*1024), assetName)
+}
+
+// parseChecksum reads a checksum file in sha256sum format ("<hash>  <file>") and
+// returns the hash for assetName. A file containing only a bare hash is also accepted.
+func parseChecksum(r io.Reader, assetName string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		hash := strings.ToLower(fields[0])
+		if len(hash) != sha256.Size*2 {
+			continue
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			continue
+		}
+
+		// Bare hash, or a line naming our asset (sha256sum prefixes binary mode files with '*')
+		if len(fields) == 1 || strings.TrimPrefix(fields[1], "*") == assetName {
+			return hash, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("no SHA256 checksum found for %s", assetName)
+}
+
+// verifyChecksum compares the expected and actual SHA256 hashes of a download.
+func verifyChecksum(assetName, expected, actual string) error {
+	if !strings.EqualFold(expected, actual) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s; refusing to install", assetName, expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package version
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stallingServer returns a server that never responds until the test ends.
+func stallingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(func() {
+		close(done)
+		srv.Close()
+	})
+	return srv
+}
+
+func TestFetchLatestReleaseTimeout(t *testing.T) {
+	srv := stallingServer(t)
+
+	orig := githubAPIURL
+	githubAPIURL = srv.URL
+	t.Cleanup(func() { githubAPIURL = orig })
+
+	ctx, cancel := upgradeContext(100 * time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := fetchLatestRelease(ctx, ChannelStable)
+	if err == nil {
+		t.Fatal("fetchLatestRelease() expected timeout error from stalled server")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("fetchLatestRelease() took %v, timeout was not applied", elapsed)
+	}
+}
+
+func TestFetchChecksumTimeout(t *testing.T) {
+	srv := stallingServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := fetchChecksum(ctx, srv.URL+"/syst.zip.sha256", "syst.zip"); err == nil {
+		t.Fatal("fetchChecksum() expected timeout error from stalled server")
+	}
+}
+
+func TestFetchLatestRelease(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/redjax/syst/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"tag_name":"v1.3.0-rc1","prerelease":true},{"tag_name":"v1.2.3","assets":[{"name":"syst-linux-amd64-1.2.3.zip","browser_download_url":"http://example/x.zip"}]}]`))
+	}))
+	defer srv.Close()
+
+	orig := githubAPIURL
+	githubAPIURL = srv.URL
+	t.Cleanup(func() { githubAPIURL = orig })
+
+	ctx, cancel := upgradeContext(time.Second)
+	defer cancel()
+
+	release, err := fetchLatestRelease(ctx, ChannelStable)
+	if err != nil {
+		t.Fatalf("fetchLatestRelease() error: %v", err)
+	}
+	if release.TagName != "v1.2.3" || len(release.Assets) != 1 {
+		t.Errorf("fetchLatestRelease() = %+v, want tag v1.2.3 with 1 asset", release)
+	}
+}
+
+func TestFetchReleaseByVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/redjax/syst/releases/tags/v0.0.18":
+			w.Write([]byte(`{"tag_name":"v0.0.18"}`))
+		case "/repos/redjax/syst/releases/tags/0.0.17":
+			w.Write([]byte(`{"tag_name":"0.0.17"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	orig := githubAPIURL
+	githubAPIURL = srv.URL
+	t.Cleanup(func() { githubAPIURL = orig })
+
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{"0.0.18", "v0.0.18", false},
+		{"v0.0.18", "v0.0.18", false},
+		{"v0.0.17", "0.0.17", false},
+		{"0.0.1", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			ctx, cancel := upgradeContext(time.Second)
+			defer cancel()
+
+			got, err := fetchReleaseByVersion(ctx, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetchReleaseByVersion(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+			if got.TagName != tt.want {
+				t.Errorf("fetchReleaseByVersion(%q) = %q, want %q", tt.version, got.TagName, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	releases := []githubRelease{
+		{TagName: "v1.3.0-rc1", Prerelease: true},
+		{TagName: "v1.4.0", Draft: true},
+		{TagName: "nightly"},
+		{TagName: "v1.2.0"},
+		{TagName: "v1.2.1-beta"},
+		{TagName: "v1.1.0"},
+	}
+
+	tests := []struct {
+		channel Channel
+		want    string
+	}{
+		{ChannelStable, "v1.2.0"},
+		{ChannelPrerelease, "v1.3.0-rc1"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.channel), func(t *testing.T) {
+			got, err := latestRelease(releases, tt.channel)
+			if err != nil {
+				t.Fatalf("latestRelease() error: %v", err)
+			}
+			if got.TagName != tt.want {
+				t.Errorf("latestRelease(%s) = %q, want %q", tt.channel, got.TagName, tt.want)
+			}
+		})
+	}
+
+	if _, err := latestRelease([]githubRelease{{TagName: "v2.0.0-rc1", Prerelease: true}}, ChannelStable); err == nil {
+		t.Error("latestRelease() expected error when no stable release exists")
+	}
+
+	// Prereleases are ordered by semver, whatever order the API lists them in
+	for _, tt := range []struct {
+		name     string
+		releases []string
+		want     string
+	}{
+		{"rc to rc", []string{"v1.3.0-rc1", "v1.3.0-rc2"}, "v1.3.0-rc2"},
+		{"rc to final", []string{"v1.3.0-rc2", "v1.3.0", "v1.3.0-rc1"}, "v1.3.0"},
+		{"rc over older final", []string{"v1.3.0-rc1", "v1.2.0"}, "v1.3.0-rc1"},
+	} {
+		var releases []githubRelease
+		for _, tag := range tt.releases {
+			releases = append(releases, githubRelease{TagName: tag, Prerelease: strings.Contains(tag, "-")})
+		}
+		got, err := latestRelease(releases, ChannelPrerelease)
+		if err != nil {
+			t.Fatalf("%s: latestRelease() error: %v", tt.name, err)
+		}
+		if got.TagName != tt.want {
+			t.Errorf("%s: latestRelease() = %q, want %q", tt.name, got.TagName, tt.want)
+		}
+	}
+}
+
+func TestConfiguredChannel(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Channel
+		wantErr bool
+	}{
+		{"", ChannelStable, false},
+		{"prerelease", ChannelPrerelease, false},
+		{"Stable", ChannelStable, false},
+		{"beta", "", true},
+	}
+	for _, tt := range tests {
+		getenv := func(name string) string {
+			if name == "SYST_UPGRADE_CHANNEL" {
+				return tt.value
+			}
+			return ""
+		}
+		got, err := configuredChannel(getenv)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("configuredChannel() with %q = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseChannel(t *testing.T) {
+	if c, err := ParseChannel(" Prerelease "); err != nil || c != ChannelPrerelease {
+		t.Errorf("ParseChannel(\" Prerelease \") = %q, %v", c, err)
+	}
+	if _, err := ParseChannel("beta"); err == nil {
+		t.Error("ParseChannel(\"beta\") expected error")
+	}
+}
+
+func TestUpgradeCheckOnlyRejectsVersion(t *testing.T) {
+	cmd := NewUpgradeCommand()
+	cmd.SetArgs([]string{"--check-only", "--version", "0.0.18"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--check-only") {
+		t.Errorf("upgrade --check-only --version error = %v, want it rejected", err)
+	}
+}
@@ -1,10 +1,14 @@
 package version
 
 import (
+	"cmp"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
 )
 
@@ -27,6 +31,27 @@ type PackageInfo struct {
 	PackageReleaseDate string
 }
 
+// BuildInfo describes the build provenance of the running binary.
+// Version, Commit and Date are set via ldflags at release time.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// GetBuildInfo returns the build metadata of the running binary
+func GetBuildInfo() BuildInfo {
+	return BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
+
 // GetPackageInfo returns a struct with information about the current package
 func GetPackageInfo() PackageInfo {
 	exePath, err := os.Executable()
@@ -64,35 +89,98 @@ func getRepoUrlPath() (string, error) {
 	return fmt.Sprintf("%s/%s", segments[0], segments[1]), nil
 }
 
-func compareVersion(version1 string, version2 string) int {
-	// Strip suffixes (like -abcdef123)
-	ver1 := strings.SplitN(version1, "-", 2)[0]
-	ver2 := strings.SplitN(version2, "-", 2)[0]
+// describeSuffix matches what git describe appends to the version of a build made after
+// a tag: the number of commits since the tag and the commit, like -4-gabc1234
+var describeSuffix = regexp.MustCompile(`-\d+-g[0-9a-f]+$`)
 
-	s1 := strings.Split(ver1, ".")
-	s2 := strings.Split(ver2, ".")
+// parsedVersion is a version split for comparing
+type parsedVersion struct {
+	core []int
+	// pre are the dot separated identifiers of a prerelease like -rc.1, none for a release
+	pre []string
+	// described is set for a git describe build, which comes after its tag
+	described bool
+}
+
+// parseVersion splits a version like v1.2.3-rc.1+build. Build metadata is ignored.
+func parseVersion(version string) parsedVersion {
+	var v parsedVersion
+	version = strings.TrimPrefix(version, "v")
+	version, _, _ = strings.Cut(version, "+")
+	if loc := describeSuffix.FindStringIndex(version); loc != nil {
+		version, v.described = version[:loc[0]], true
+	}
 
-	maxlen := len(s1)
-	if len(s2) > maxlen {
-		maxlen = len(s2)
+	core, pre, hasPre := strings.Cut(version, "-")
+	for _, part := range strings.Split(core, ".") {
+		var n int
+		// #nosec G104 - Sscanf error ignored, defaults to 0 which is correct for version comparison
+		fmt.Sscanf(part, "%d", &n)
+		v.core = append(v.core, n)
 	}
+	if hasPre {
+		v.pre = strings.Split(pre, ".")
+	}
+	return v
+}
 
-	for i := 0; i < maxlen; i++ {
+// compareVersion compares two versions with semver precedence: by their numbers, then a
+// prerelease (like 1.3.0-rc1) before its release, with prereleases compared identifier
+// by identifier. A git describe build (like 1.2.3-4-gabc1234) comes right after its tag.
+// It returns -1, 0 or 1 as version1 is older, the same as or newer than version2.
+func compareVersion(version1 string, version2 string) int {
+	v1, v2 := parseVersion(version1), parseVersion(version2)
+
+	for i := 0; i < max(len(v1.core), len(v2.core)); i++ {
 		var n1, n2 int
-		if i < len(s1) {
-			// #nosec G104 - Sscanf error ignored, defaults to 0 which is correct for version comparison
-			fmt.Sscanf(s1[i], "%d", &n1)
+		if i < len(v1.core) {
+			n1 = v1.core[i]
 		}
-		if i < len(s2) {
-			// #nosec G104 - Sscanf error ignored, defaults to 0 which is correct for version comparison
-			fmt.Sscanf(s2[i], "%d", &n2)
+		if i < len(v2.core) {
+			n2 = v2.core[i]
 		}
-		if n1 > n2 {
-			return 1
+		if c := cmp.Compare(n1, n2); c != 0 {
+			return c
 		}
-		if n1 < n2 {
-			return -1
+	}
+
+	// A prerelease comes before the release
+	switch {
+	case len(v1.pre) == 0 && len(v2.pre) > 0:
+		return 1
+	case len(v1.pre) > 0 && len(v2.pre) == 0:
+		return -1
+	}
+	for i := 0; i < min(len(v1.pre), len(v2.pre)); i++ {
+		if c := comparePrerelease(v1.pre[i], v2.pre[i]); c != 0 {
+			return c
 		}
 	}
+	if c := cmp.Compare(len(v1.pre), len(v2.pre)); c != 0 {
+		return c
+	}
+
+	switch {
+	case v1.described && !v2.described:
+		return 1
+	case !v1.described && v2.described:
+		return -1
+	}
 	return 0
 }
+
+// comparePrerelease compares two prerelease identifiers: numbers by value, before any
+// identifier with letters, which compare in ASCII order
+func comparePrerelease(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
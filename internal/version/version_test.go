@@ -1,6 +1,11 @@
 package version
 
-import "testing"
+import (
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+)
 
 func TestCompareVersion(t *testing.T) {
 	tests := []struct {
@@ -16,9 +21,22 @@ func TestCompareVersion(t *testing.T) {
 		{"v1 greater patch", "1.0.2", "1.0.1", 1},
 		{"v1 less patch", "1.0.1", "1.0.2", -1},
 		{"different lengths", "1.0", "1.0.0", 0},
-		{"with suffix stripped", "1.2.3-abc123", "1.2.3", 0},
-		{"suffix comparison", "1.2.3-abc", "1.2.4", -1},
-		{"v prefix stripped TBD", "1.0.0", "1.0.0", 0},
+		{"prerelease before release", "1.2.3-abc123", "1.2.3", -1},
+		{"prerelease of next version", "1.2.3-abc", "1.2.4", -1},
+		{"rc to rc", "v1.3.0-rc1", "v1.3.0-rc2", -1},
+		{"rc to final", "v1.3.0-rc2", "v1.3.0", -1},
+		{"final after rc", "v1.3.0", "v1.3.0-rc1", 1},
+		{"same rc", "v1.3.0-rc1", "v1.3.0-rc1", 0},
+		{"numeric identifiers", "1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"numeric before alphanumeric", "1.0.0-1", "1.0.0-alpha", -1},
+		{"more identifiers after", "1.0.0-alpha.1", "1.0.0-alpha", 1},
+		{"alpha before beta", "1.0.0-alpha", "1.0.0-beta", -1},
+		{"build metadata ignored", "1.0.0+linux", "1.0.0", 0},
+		{"describe build after its tag", "v1.2.3-4-gabc1234", "v1.2.3", 1},
+		{"describe build before next", "v1.2.3-4-gabc1234", "v1.2.4", -1},
+		{"describe build of a prerelease", "v1.3.0-rc1-2-gabc1234", "v1.3.0-rc2", -1},
+		{"v prefix stripped", "v1.0.0", "1.0.0", 0},
+		{"v prefix comparison", "v1.0.0", "v1.0.1", -1},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -58,3 +76,76 @@ func TestGetPackageInfo(t *testing.T) {
 		t.Error("PackageInfo.PackageName is empty")
 	}
 }
+
+func TestParseChecksum(t *testing.T) {
+	hash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	asset := "syst-linux-amd64-1.0.0.zip"
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{"bare hash", hash + "\n", hash, false},
+		{"sha256sum format", hash + "  " + asset + "\n", hash, false},
+		{"binary mode marker", hash + " *" + asset + "\n", hash, false},
+		{"uppercase hash", strings.ToUpper(hash) + "  " + asset, hash, false},
+		{"other asset only", hash + "  syst-windows-amd64-1.0.0.zip\n", "", true},
+		{"not a hash", "deadbeef  " + asset + "\n", "", true},
+		{"empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseChecksum(strings.NewReader(tt.content), asset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseChecksum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	if err := verifyChecksum("a.zip", "ABCD", "abcd"); err != nil {
+		t.Errorf("verifyChecksum() unexpected error for matching hashes: %v", err)
+	}
+
+	err := verifyChecksum("a.zip", "abcd", "ef01")
+	if err == nil {
+		t.Fatal("verifyChecksum() expected error for mismatched hashes")
+	}
+	if !strings.Contains(err.Error(), "expected abcd") || !strings.Contains(err.Error(), "got ef01") {
+		t.Errorf("verifyChecksum() error %q should include expected and actual hashes", err)
+	}
+}
+
+func TestGetBuildInfo(t *testing.T) {
+	info := GetBuildInfo()
+
+	if info.Version != Version || info.Commit != Commit || info.Date != Date {
+		t.Errorf("GetBuildInfo() = %+v, want version/commit/date from ldflags vars", info)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("BuildInfo.GoVersion = %q, want %q", info.GoVersion, runtime.Version())
+	}
+	if want := runtime.GOOS + "/" + runtime.GOARCH; info.Platform != want {
+		t.Errorf("BuildInfo.Platform = %q, want %q", info.Platform, want)
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("json.Marshal(BuildInfo) error: %v", err)
+	}
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+	for _, key := range []string{"version", "commit", "date", "goVersion", "platform"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("BuildInfo JSON missing key %q: %s", key, data)
+		}
+	}
+}